@@ -2,12 +2,46 @@ package config
 
 import (
 	"fmt"
+	"net"
 	"time"
 
 	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
 )
 
+const defaultEBGPMRAI = 30 * time.Second
+
+func localAddressExists(addr bnet.IP) error {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return fmt.Errorf("unable to get interface addresses: %w", err)
+	}
+
+	for _, a := range addrs {
+		ipNet, ok := a.(*net.IPNet)
+		if !ok {
+			continue
+		}
+
+		raw := ipNet.IP
+		if v4 := raw.To4(); v4 != nil {
+			raw = v4
+		}
+
+		ip, err := bnet.IPFromBytes(raw)
+		if err != nil {
+			continue
+		}
+
+		if ip.Equal(addr) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("address %s is not configured on any interface", addr.String())
+}
+
 type BGP struct {
 	Groups []*BGPGroup `yaml:"groups"`
 }
@@ -27,18 +61,47 @@ type BGPGroup struct {
 	Name              string `yaml:"name"`
 	LocalAddress      string `yaml:"local_address"`
 	LocalAddressIP    *bnet.IP
-	TTL               uint8          `yaml:"ttl"`
-	AuthenticationKey string         `yaml:"authentication_key"`
-	PeerAS            uint32         `yaml:"peer_as"`
-	LocalAS           uint32         `yaml:"local_as"`
-	HoldTime          uint16         `yaml:"hold_time"`
-	Multipath         *Multipath     `yaml:"multipath"`
-	Import            []string       `yaml:"import"`
-	Export            []string       `yaml:"export"`
-	RouteServerClient bool           `yaml:"route_server_client"`
-	Passive           bool           `yaml:"passive"`
-	Neighbors         []*BGPNeighbor `yaml:"neighbors"`
-	AFIs              []*AFI         `yaml:"afi"`
+	TTL               uint8             `yaml:"ttl"`
+	TTLSecurityHops   uint8             `yaml:"ttl_security_hops"`
+	EBGPMultihop      uint8             `yaml:"ebgp_multihop"`
+	PrivateAS         string            `yaml:"private_as"`
+	AuthenticationKey string            `yaml:"authentication_key"`
+	PeerAS            uint32            `yaml:"peer_as"`
+	LocalAS           uint32            `yaml:"local_as"`
+	LocalASOverride   *LocalASOverride  `yaml:"local_as_override"`
+	HoldTime          uint16            `yaml:"hold_time"`
+	Multipath         *Multipath        `yaml:"multipath"`
+	Import            []string          `yaml:"import"`
+	Export            []string          `yaml:"export"`
+	RouteServerClient bool              `yaml:"route_server_client"`
+	NextHopSelf       bool              `yaml:"next_hop_self"`
+	Passive           bool              `yaml:"passive"`
+	Active            bool              `yaml:"active"`
+	ExtendedMessage   bool              `yaml:"extended_message"`
+	RouteRefresh      bool              `yaml:"route_refresh"`
+	EnhancedRR        bool              `yaml:"enhanced_route_refresh"`
+	LinkState         bool              `yaml:"link_state"`
+	InboundQueueSize  uint32            `yaml:"inbound_queue_size"`
+	MRAI              *uint16           `yaml:"mrai"`
+	DefaultOriginate  *DefaultOriginate `yaml:"default_originate"`
+	Capabilities      *Capabilities     `yaml:"capabilities"`
+	PeerRole          string            `yaml:"peer_role"`
+	PeerRoleStrict    bool              `yaml:"peer_role_strict"`
+	Neighbors         []*BGPNeighbor    `yaml:"neighbors"`
+	AFIs              []*AFI            `yaml:"afi"`
+
+	// SendCommunity and SendLargeCommunity control if the respective attributes are advertised. Both default to true.
+	SendCommunity      *bool `yaml:"send_community"`
+	SendLargeCommunity *bool `yaml:"send_large_community"`
+
+	// AdvertisementDelay is the number of seconds to wait with advertising routes after a session came up
+	AdvertisementDelay uint16 `yaml:"advertisement_delay"`
+
+	// LogASLoops logs samples of received routes containing our ASN in their AS path
+	LogASLoops bool `yaml:"log_as_loops"`
+
+	// GracefulRestart enables the graceful restart helper mode for all neighbors of the group
+	GracefulRestart *GracefulRestart `yaml:"graceful_restart"`
 }
 
 func (bg *BGPGroup) load(localAS uint32, policyOptions *PolicyOptions) error {
@@ -64,10 +127,78 @@ func (bg *BGPGroup) load(localAS uint32, policyOptions *PolicyOptions) error {
 			n.RouteServerClient = &bg.RouteServerClient
 		}
 
+		if n.NextHopSelf == nil {
+			n.NextHopSelf = &bg.NextHopSelf
+		}
+
+		if *n.RouteServerClient && *n.NextHopSelf {
+			return fmt.Errorf("next_hop_self must not be enabled for route server client %q", n.PeerAddress)
+		}
+
 		if n.Passive == nil {
 			n.Passive = &bg.Passive
 		}
 
+		if n.Active == nil {
+			n.Active = &bg.Active
+		}
+
+		if *n.Passive && *n.Active {
+			return fmt.Errorf("passive and active must not both be enabled for neighbor %q", n.PeerAddress)
+		}
+
+		if n.ExtendedMessage == nil {
+			n.ExtendedMessage = &bg.ExtendedMessage
+		}
+
+		if n.RouteRefresh == nil {
+			n.RouteRefresh = &bg.RouteRefresh
+		}
+
+		if n.EnhancedRR == nil {
+			n.EnhancedRR = &bg.EnhancedRR
+		}
+
+		if n.LinkState == nil {
+			n.LinkState = &bg.LinkState
+		}
+
+		if n.SendCommunity == nil {
+			n.SendCommunity = bg.SendCommunity
+		}
+
+		if n.SendLargeCommunity == nil {
+			n.SendLargeCommunity = bg.SendLargeCommunity
+		}
+
+		if n.LogASLoops == nil {
+			n.LogASLoops = &bg.LogASLoops
+		}
+
+		if n.DefaultOriginate == nil {
+			n.DefaultOriginate = bg.DefaultOriginate
+		}
+
+		if n.GracefulRestart == nil {
+			n.GracefulRestart = bg.GracefulRestart
+		}
+
+		if n.LocalASOverride == nil {
+			n.LocalASOverride = bg.LocalASOverride
+		}
+
+		if n.Capabilities == nil {
+			n.Capabilities = bg.Capabilities
+		}
+
+		if n.PeerRole == "" {
+			n.PeerRole = bg.PeerRole
+		}
+
+		if n.PeerRoleStrict == nil {
+			n.PeerRoleStrict = &bg.PeerRoleStrict
+		}
+
 		if n.LocalAddress == "" {
 			n.LocalAddressIP = bg.LocalAddressIP
 		}
@@ -76,6 +207,30 @@ func (bg *BGPGroup) load(localAS uint32, policyOptions *PolicyOptions) error {
 			n.TTL = bg.TTL
 		}
 
+		if n.TTLSecurityHops == 0 {
+			n.TTLSecurityHops = bg.TTLSecurityHops
+		}
+
+		if n.EBGPMultihop == 0 {
+			n.EBGPMultihop = bg.EBGPMultihop
+		}
+
+		if n.PrivateAS == "" {
+			n.PrivateAS = bg.PrivateAS
+		}
+
+		if n.InboundQueueSize == 0 {
+			n.InboundQueueSize = bg.InboundQueueSize
+		}
+
+		if n.MRAI == nil {
+			n.MRAI = bg.MRAI
+		}
+
+		if n.AdvertisementDelay == 0 {
+			n.AdvertisementDelay = bg.AdvertisementDelay
+		}
+
 		if n.AuthenticationKey == "" {
 			n.AuthenticationKey = bg.AuthenticationKey
 		}
@@ -100,6 +255,10 @@ func (bg *BGPGroup) load(localAS uint32, policyOptions *PolicyOptions) error {
 			n.HoldTime = bg.HoldTime
 		}
 
+		if n.AFIs == nil {
+			n.AFIs = bg.AFIs
+		}
+
 		err := n.load(policyOptions)
 		if err != nil {
 			return err
@@ -119,22 +278,144 @@ type BGPNeighbor struct {
 	PeerAddressIP     *bnet.IP
 	LocalAddress      string `yaml:"local_address"`
 	LocalAddressIP    *bnet.IP
-	TTL               uint8  `yaml:"ttl"`
-	AuthenticationKey string `yaml:"authentication_key"`
-	PeerAS            uint32 `yaml:"peer_as"`
-	LocalAS           uint32 `yaml:"local_as"`
-	HoldTime          uint16 `yaml:"hold_time"`
+	UpdateSource      string `yaml:"update_source"`
+	UpdateSourceIP    *bnet.IP
+	TTL               uint8            `yaml:"ttl"`
+	TTLSecurityHops   uint8            `yaml:"ttl_security_hops"`
+	EBGPMultihop      uint8            `yaml:"ebgp_multihop"`
+	PrivateAS         string           `yaml:"private_as"`
+	AuthenticationKey string           `yaml:"authentication_key"`
+	PeerAS            uint32           `yaml:"peer_as"`
+	LocalAS           uint32           `yaml:"local_as"`
+	LocalASOverride   *LocalASOverride `yaml:"local_as_override"`
+	HoldTime          uint16           `yaml:"hold_time"`
 	HoldTimeDuration  time.Duration
+	DisableKeepalive  bool       `yaml:"disable_keepalive"`
 	Multipath         *Multipath `yaml:"multipath"`
 	Import            []string   `yaml:"import"`
 	ImportFilterChain filter.Chain
 	Export            []string `yaml:"export"`
 	ExportFilterChain filter.Chain
-	RouteServerClient *bool  `yaml:"route_server_client"`
-	Passive           *bool  `yaml:"passive"`
+	RouteServerClient *bool   `yaml:"route_server_client"`
+	NextHopSelf       *bool   `yaml:"next_hop_self"`
+	Passive           *bool   `yaml:"passive"`
+	Active            *bool   `yaml:"active"`
+	ExtendedMessage   *bool   `yaml:"extended_message"`
+	RouteRefresh      *bool   `yaml:"route_refresh"`
+	EnhancedRR        *bool   `yaml:"enhanced_route_refresh"`
+	LinkState         *bool   `yaml:"link_state"`
+	InboundQueueSize  uint32  `yaml:"inbound_queue_size"`
+	MRAI              *uint16 `yaml:"mrai"`
+	MRAIDuration      time.Duration
 	ClusterID         string `yaml:"cluster_id"`
 	ClusterIDIP       *bnet.IP
-	AFIs              []*AFI `yaml:"afi"`
+	AFIs              []*AFI            `yaml:"afi"`
+	DefaultOriginate  *DefaultOriginate `yaml:"default_originate"`
+	Capabilities      *Capabilities     `yaml:"capabilities"`
+
+	// PeerRole is our BGP role towards the neighbor (RFC9234): provider, rs, rs-client, customer or peer.
+	// In strict mode a session is only established if the neighbor advertises a matching role.
+	PeerRole       string `yaml:"peer_role"`
+	PeerRoleStrict *bool  `yaml:"peer_role_strict"`
+
+	SendCommunity      *bool `yaml:"send_community"`
+	SendLargeCommunity *bool `yaml:"send_large_community"`
+
+	// AdvertisementDelay is the number of seconds to wait with advertising routes after the session came up,
+	// e.g. to let the IGP converge. Advertisements are not delayed if zero.
+	AdvertisementDelay         uint16 `yaml:"advertisement_delay"`
+	AdvertisementDelayDuration time.Duration
+
+	// LogASLoops logs samples of received routes containing our ASN in their AS path. Many of them indicate
+	// a misconfigured neighbor reflecting our routes back to us.
+	LogASLoops *bool `yaml:"log_as_loops"`
+
+	// GracefulRestart retains the routes of a restarting neighbor (RFC4724)
+	GracefulRestart *GracefulRestart `yaml:"graceful_restart"`
+
+	DefaultOriginateFilterChain filter.Chain
+}
+
+const (
+	defaultGracefulRestartTime = 120
+	defaultStalePathTime       = 360
+)
+
+// GracefulRestart enables the graceful restart helper mode. RestartTime is the number of seconds advertised to the
+// neighbor as our restart time. Stale routes of a restarting neighbor are kept for at most StalePathTime seconds
+// after the session came back up. They default to 120 and 360 seconds.
+type GracefulRestart struct {
+	RestartTime           uint16 `yaml:"restart_time"`
+	StalePathTime         uint16 `yaml:"stale_path_time"`
+	RestartTimeDuration   time.Duration
+	StalePathTimeDuration time.Duration
+}
+
+func (gr *GracefulRestart) load() error {
+	if gr.RestartTime == 0 {
+		gr.RestartTime = defaultGracefulRestartTime
+	}
+
+	if gr.StalePathTime == 0 {
+		gr.StalePathTime = defaultStalePathTime
+	}
+
+	gr.RestartTimeDuration = time.Duration(gr.RestartTime) * time.Second
+	gr.StalePathTimeDuration = time.Duration(gr.StalePathTime) * time.Second
+	return nil
+}
+
+// Capabilities forces or suppresses the advertisement of individual capabilities, e.g. to work around buggy peers
+type Capabilities struct {
+	Advertise []string `yaml:"advertise"`
+	Suppress  []string `yaml:"suppress"`
+	Overrides map[uint8]bool
+}
+
+var capabilityCodes = map[string]uint8{
+	"route-refresh":    packet.RouteRefreshCapabilityCode,
+	"4-octet-asn":      packet.ASN4CapabilityCode,
+	"add-path":         packet.AddPathCapabilityCode,
+	"graceful-restart": packet.GracefulRestartCapabilityCode,
+}
+
+func (c *Capabilities) load() error {
+	c.Overrides = make(map[uint8]bool)
+	for _, list := range []struct {
+		names     []string
+		advertise bool
+	}{
+		{names: c.Advertise, advertise: true},
+		{names: c.Suppress, advertise: false},
+	} {
+		for _, name := range list.names {
+			code, ok := capabilityCodes[name]
+			if !ok {
+				return fmt.Errorf("unknown capability %q", name)
+			}
+
+			if advertise, exists := c.Overrides[code]; exists && advertise != list.advertise {
+				return fmt.Errorf("capability %q must not be advertised and suppressed", name)
+			}
+
+			c.Overrides[code] = list.advertise
+		}
+	}
+
+	return nil
+}
+
+// DefaultOriginate configures the origination of a default route towards a neighbor
+type DefaultOriginate struct {
+	Policy []string `yaml:"policy"`
+}
+
+// LocalASOverride presents an alternate ASN to a neighbor instead of the real local ASN (local-as)
+type LocalASOverride struct {
+	ASN       uint32 `yaml:"asn"`
+	NoPrepend bool   `yaml:"no_prepend"`
+	ReplaceAS bool   `yaml:"replace_as"`
+	DualAS    bool   `yaml:"dual_as"`
 }
 
 func (bn *BGPNeighbor) load(po *PolicyOptions) error {
@@ -142,10 +423,75 @@ func (bn *BGPNeighbor) load(po *PolicyOptions) error {
 		return fmt.Errorf("Peer %q is lacking peer as number", bn.PeerAddress)
 	}
 
+	if bn.LocalASOverride != nil && bn.LocalASOverride.ASN == 0 {
+		return fmt.Errorf("Peer %q: local_as_override asn 0 is invalid", bn.PeerAddress)
+	}
+
 	if bn.PeerAddress == "" {
 		return fmt.Errorf("Mandatory parameter BGP peer address is empty")
 	}
 
+	if bn.EBGPMultihop != 0 {
+		if bn.PeerAS == bn.LocalAS {
+			return fmt.Errorf("Peer %q: ebgp_multihop is only valid for eBGP peers", bn.PeerAddress)
+		}
+
+		if bn.TTL != 0 && bn.TTL != bn.EBGPMultihop {
+			return fmt.Errorf("Peer %q: ebgp_multihop and ttl are conflicting", bn.PeerAddress)
+		}
+
+		bn.TTL = bn.EBGPMultihop
+	}
+
+	if bn.TTLSecurityHops != 0 && bn.TTL != 0 {
+		return fmt.Errorf("Peer %q: ttl_security_hops and ttl (ebgp_multihop) are mutually exclusive", bn.PeerAddress)
+	}
+
+	if bn.TTLSecurityHops == 255 {
+		return fmt.Errorf("Peer %q: ttl_security_hops must be less than 255", bn.PeerAddress)
+	}
+
+	switch bn.PeerRole {
+	case "":
+		if bn.PeerRoleStrict != nil && *bn.PeerRoleStrict {
+			return fmt.Errorf("Peer %q: peer_role_strict requires a peer_role", bn.PeerAddress)
+		}
+	case "provider", "rs", "rs-client", "customer", "peer":
+		if bn.PeerAS == bn.LocalAS {
+			return fmt.Errorf("Peer %q: peer_role is only valid for eBGP peers", bn.PeerAddress)
+		}
+	default:
+		return fmt.Errorf("Peer %q: invalid peer_role %q (provider, rs, rs-client, customer or peer expected)", bn.PeerAddress, bn.PeerRole)
+	}
+
+	afis := make(map[string]struct{})
+	for _, afi := range bn.AFIs {
+		// An address family is either exchanged as unicast or as labeled unicast
+		if _, exists := afis[afi.Name]; exists {
+			return fmt.Errorf("Peer %q: afi %q configured more than once", bn.PeerAddress, afi.Name)
+		}
+		afis[afi.Name] = struct{}{}
+
+		err := afi.load()
+		if err != nil {
+			return fmt.Errorf("Peer %q: %w", bn.PeerAddress, err)
+		}
+	}
+
+	if bn.Capabilities != nil {
+		err := bn.Capabilities.load()
+		if err != nil {
+			return fmt.Errorf("Peer %q: %w", bn.PeerAddress, err)
+		}
+	}
+
+	if bn.GracefulRestart != nil {
+		err := bn.GracefulRestart.load()
+		if err != nil {
+			return fmt.Errorf("Peer %q: %w", bn.PeerAddress, err)
+		}
+	}
+
 	if bn.LocalAddress != "" {
 		a, err := bnet.IPFromString(bn.LocalAddress)
 		if err != nil {
@@ -155,13 +501,57 @@ func (bn *BGPNeighbor) load(po *PolicyOptions) error {
 		bn.LocalAddressIP = a.Dedup()
 	}
 
+	if bn.UpdateSource != "" {
+		a, err := bnet.IPFromString(bn.UpdateSource)
+		if err != nil {
+			return fmt.Errorf("unable to parse BGP update source: %w", err)
+		}
+
+		err = localAddressExists(a)
+		if err != nil {
+			return fmt.Errorf("Peer %q: invalid update source: %w", bn.PeerAddress, err)
+		}
+
+		bn.UpdateSourceIP = a.Dedup()
+	}
+
 	b, err := bnet.IPFromString(bn.PeerAddress)
 	if err != nil {
 		return fmt.Errorf("unable to parse BGP peer address: %w", err)
 	}
 
 	bn.PeerAddressIP = b.Dedup()
+
+	// A hold time must be either zero or at least three seconds (RFC4271 section 4.2)
+	if bn.HoldTime < 3 {
+		return fmt.Errorf("Peer %q: hold time %d is invalid", bn.PeerAddress, bn.HoldTime)
+	}
+
 	bn.HoldTimeDuration = time.Second * time.Duration(bn.HoldTime)
+	if bn.DisableKeepalive {
+		// Proposing a hold time of zero disables the hold timer and keepalives
+		bn.HoldTimeDuration = 0
+	}
+
+	// The MRAI defaults to 30 seconds for eBGP and is disabled for iBGP (RFC4271 section 10)
+	if bn.MRAI != nil {
+		bn.MRAIDuration = time.Second * time.Duration(*bn.MRAI)
+	} else if bn.PeerAS != bn.LocalAS {
+		bn.MRAIDuration = defaultEBGPMRAI
+	}
+
+	bn.AdvertisementDelayDuration = time.Second * time.Duration(bn.AdvertisementDelay)
+
+	var privateASFilter *filter.Filter
+	switch bn.PrivateAS {
+	case "":
+	case "reject":
+		privateASFilter = filter.NewRejectPrivateASFilter()
+	case "remove":
+		privateASFilter = filter.NewRemovePrivateASFilter()
+	default:
+		return fmt.Errorf("Peer %q: invalid private_as %q (reject or remove expected)", bn.PeerAddress, bn.PrivateAS)
+	}
 
 	for i := range bn.Import {
 		f := po.getPolicyStatementFilter(bn.Import[i])
@@ -172,6 +562,11 @@ func (bn *BGPNeighbor) load(po *PolicyOptions) error {
 		bn.ImportFilterChain = append(bn.ImportFilterChain, f)
 	}
 
+	// Private ASNs are handled before the import policies. Without import policies all routes are rejected anyway.
+	if privateASFilter != nil && len(bn.ImportFilterChain) != 0 {
+		bn.ImportFilterChain = append(filter.Chain{privateASFilter}, bn.ImportFilterChain...)
+	}
+
 	for i := range bn.Export {
 		f := po.getPolicyStatementFilter(bn.Export[i])
 		if f == nil {
@@ -180,6 +575,18 @@ func (bn *BGPNeighbor) load(po *PolicyOptions) error {
 
 		bn.ExportFilterChain = append(bn.ExportFilterChain, f)
 	}
+
+	if bn.DefaultOriginate != nil {
+		for i := range bn.DefaultOriginate.Policy {
+			f := po.getPolicyStatementFilter(bn.DefaultOriginate.Policy[i])
+			if f == nil {
+				return fmt.Errorf("policy statement %q undefined", bn.DefaultOriginate.Policy[i])
+			}
+
+			bn.DefaultOriginateFilterChain = append(bn.DefaultOriginateFilterChain, f)
+		}
+	}
+
 	return nil
 }
 
@@ -188,9 +595,89 @@ type AFI struct {
 	SAFI SAFI   `yaml:"safi"`
 }
 
+// SAFI is the config of a subsequent address family. SuppressFIBFailures withholds routes from the neighbor that
+// failed to be installed into the FIB.
 type SAFI struct {
-	Name    string   `yaml:"name"`
-	AddPath *AddPath `yaml:"add_path"`
+	Name                string             `yaml:"name"`
+	AddPath             *AddPath           `yaml:"add_path"`
+	PrefixLimit         *PrefixLimit       `yaml:"prefix_limit"`
+	NextHopValidation   *NextHopValidation `yaml:"next_hop_validation"`
+	ORFPrefixList       []*ORFPrefix       `yaml:"orf_prefix_list"`
+	SuppressFIBFailures bool               `yaml:"suppress_fib_failures"`
+}
+
+// ORFPrefix is an entry of the prefix list pushed to the neighbor via Outbound Route Filtering (RFC5292) so that it
+// only sends us matching routes. LenMin and LenMax optionally include more specifics of Prefix.
+type ORFPrefix struct {
+	Prefix       string `yaml:"prefix"`
+	LenMin       uint8  `yaml:"len_min"`
+	LenMax       uint8  `yaml:"len_max"`
+	Deny         bool   `yaml:"deny"`
+	PrefixParsed *bnet.Prefix
+}
+
+// NextHopValidation hides routes with an invalid next hop or, with Reject set, treats them as withdrawn.
+// With Resolve set the next hop must also be resolvable via the RIB when the route is received. Routes are not
+// checked again on later changes of the RIB.
+type NextHopValidation struct {
+	Resolve bool `yaml:"resolve"`
+	Reject  bool `yaml:"reject"`
+}
+
+// PrefixLimit tears down the session once the neighbor announces more than Limit prefixes for the address family.
+// With Restart set the session is reestablished after that many minutes, at most MaxRestarts times (0 for unlimited)
+// in a row. Restarts are counted from zero again once a session stayed established for an hour.
+type PrefixLimit struct {
+	Limit           uint32 `yaml:"limit"`
+	Restart         uint32 `yaml:"restart"`
+	MaxRestarts     uint32 `yaml:"max_restarts"`
+	RestartDuration time.Duration
+}
+
+func (a *AFI) load() error {
+	switch a.SAFI.Name {
+	case "unicast", "labeled-unicast":
+	default:
+		return fmt.Errorf("afi %q: invalid safi %q (unicast or labeled-unicast expected)", a.Name, a.SAFI.Name)
+	}
+
+	// Neighbors are only configured for IPv4
+	if a.Name != "ipv4" && len(a.SAFI.ORFPrefixList) > 0 {
+		return fmt.Errorf("afi %q safi %q: orf_prefix_list is only supported for afi \"ipv4\"", a.Name, a.SAFI.Name)
+	}
+
+	for _, p := range a.SAFI.ORFPrefixList {
+		pfx, err := bnet.PrefixFromString(p.Prefix)
+		if err != nil {
+			return fmt.Errorf("afi %q safi %q: invalid orf_prefix_list prefix %q: %w", a.Name, a.SAFI.Name, p.Prefix, err)
+		}
+
+		p.PrefixParsed = pfx.Dedup()
+	}
+
+	if a.SAFI.PrefixLimit == nil {
+		return nil
+	}
+
+	err := a.SAFI.PrefixLimit.load()
+	if err != nil {
+		return fmt.Errorf("afi %q safi %q: %w", a.Name, a.SAFI.Name, err)
+	}
+
+	return nil
+}
+
+func (pl *PrefixLimit) load() error {
+	if pl.Limit == 0 {
+		return fmt.Errorf("prefix_limit limit must be greater than 0")
+	}
+
+	if pl.MaxRestarts != 0 && pl.Restart == 0 {
+		return fmt.Errorf("prefix_limit max_restarts requires restart")
+	}
+
+	pl.RestartDuration = time.Duration(pl.Restart) * time.Minute
+	return nil
 }
 
 type AddPath struct {
@@ -45,6 +45,10 @@ func (c *Config) load() error {
 		if err != nil {
 			return fmt.Errorf("Failed to load protocols: %w", err)
 		}
+
+		if c.Protocols.ISIS != nil && c.Protocols.ISIS.SegmentRouting != nil && c.RoutingOptions.RouterIDUint32 == 0 {
+			return fmt.Errorf("ISIS segment_routing requires a router_id")
+		}
 	}
 
 	return nil
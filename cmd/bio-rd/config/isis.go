@@ -1,19 +1,49 @@
 package config
 
+import (
+	"fmt"
+	"os"
+
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+)
+
 const (
 	defaultHelloInterval      = 9
 	defaultHoldTime           = 27
 	lspMinLifetime            = 350
 	lspDefaultLifetimeSeconds = 1200
+	defaultSRGBBase           = 16000
+	defaultSRGBRange          = 8000
+	maxMPLSLabel              = 1<<20 - 1
+
+	// teBandwidthPriorities is the number of priority levels of the unreserved bandwidth (RFC5305)
+	teBandwidthPriorities = 8
 )
 
 // ISIS config
 type ISIS struct {
 	NETs        []string         `yaml:"NETs"`
+	Hostname    string           `yaml:"hostname"`
 	Level1      *ISISLevel       `yaml:"level1"`
 	Level2      *ISISLevel       `yaml:"level2"`
 	Interfaces  []*ISISInterface `yaml:"interfaces"`
 	LSPLifetime uint16           `yaml:"lsp_lifetime"`
+
+	// LSPRefreshInterval is the number of seconds after which our LSPs are reoriginated. It has to be shorter than
+	// lsp_lifetime and defaults to three quarters of it.
+	LSPRefreshInterval uint16 `yaml:"lsp_refresh_interval"`
+
+	// ZeroAgeLifetime is the number of seconds a purge is kept in the LSDB after an LSP expired. Defaults to 60.
+	ZeroAgeLifetime uint16 `yaml:"zero_age_lifetime"`
+
+	// SegmentRouting enables the advertisement of our SR capabilities in the router capability TLV (RFC8667)
+	SegmentRouting *ISISSegmentRouting `yaml:"segment_routing"`
+}
+
+// ISISSegmentRouting Segment Routing config. The SRGB defaults to the labels 16000 to 23999.
+type ISISSegmentRouting struct {
+	SRGBBase  uint32 `yaml:"srgb_base"`
+	SRGBRange uint32 `yaml:"srgb_range"`
 }
 
 // ISISLevel level config
@@ -24,6 +54,36 @@ type ISISLevel struct {
 	NoHelloAuthentication bool   `yaml:"no_hello_authentication"`
 	NoPSNPAuthentication  bool   `yaml:"no_psnp_authentication"`
 	WideMetricsOnly       bool   `yaml:"wide_metrics_only"`
+	MetricStyle           string `yaml:"metric_style"`
+
+	// Keychain lists keys rotated by their lifetimes. It is used instead of authentication_key. Keys are sent as
+	// cleartext passwords.
+	Keychain []*ISISKey `yaml:"keychain"`
+
+	// Leak lists the policy statements selecting the level 2 routes leaked into level 1 (level1 only)
+	Leak            []string `yaml:"leak"`
+	LeakFilterChain filter.Chain
+
+	// Export lists the policy statements filtering the prefixes advertised in our LSP and setting their isis_metric
+	// and isis_tag
+	Export            []string `yaml:"export"`
+	ExportFilterChain filter.Chain
+
+	// SuppressAttached keeps the ATT bit from being set in our level 1 LSP (level1 only)
+	SuppressAttached bool `yaml:"suppress_attached"`
+
+	// NoPurgeOriginatorIdentification omits our system ID from the purges we generate (RFC6232)
+	NoPurgeOriginatorIdentification bool `yaml:"no_purge_originator_identification"`
+}
+
+// ISISKey is a key of a keychain. Lifetimes are given as RFC3339 timestamps, an unset time leaves the lifetime unbounded.
+type ISISKey struct {
+	ID          uint16 `yaml:"id"`
+	Secret      string `yaml:"secret"`
+	SendStart   string `yaml:"send_start"`
+	SendEnd     string `yaml:"send_end"`
+	AcceptStart string `yaml:"accept_start"`
+	AcceptEnd   string `yaml:"accept_end"`
 }
 
 // ISISInterface interface config
@@ -33,6 +93,18 @@ type ISISInterface struct {
 	PointToPoint bool                `yaml:"point_to_point"`
 	Level1       *ISISInterfaceLevel `yaml:"level1"`
 	Level2       *ISISInterfaceLevel `yaml:"level2"`
+	TE           *ISISInterfaceTE    `yaml:"te"`
+}
+
+// ISISInterfaceTE interface traffic engineering config. Bandwidths are given in bytes per second,
+// unreserved_bandwidth lists the bandwidth of up to eight priority levels. Priority levels not listed default to
+// max_reservable_bandwidth.
+type ISISInterfaceTE struct {
+	AdminGroup             uint32    `yaml:"admin_group"`
+	MaxLinkBandwidth       float32   `yaml:"max_link_bandwidth"`
+	MaxReservableBandwidth float32   `yaml:"max_reservable_bandwidth"`
+	UnreservedBandwidth    []float32 `yaml:"unreserved_bandwidth"`
+	DefaultMetric          *uint32   `yaml:"default_metric"`
 }
 
 // ISISInterfaceLevel interface level config
@@ -45,7 +117,75 @@ type ISISInterfaceLevel struct {
 	Priority      uint8  `yaml:"priority"`
 }
 
+func (i *ISIS) load(po *PolicyOptions) error {
+	i.loadDefaults()
+
+	if i.Level2 != nil && len(i.Level2.Leak) > 0 {
+		return fmt.Errorf("leaking is configured in level1")
+	}
+
+	if i.Level2 != nil && i.Level2.SuppressAttached {
+		return fmt.Errorf("suppress_attached is configured in level1")
+	}
+
+	if i.Level1 != nil && i.Level1.Disable && i.Level2 != nil && i.Level2.Disable {
+		return fmt.Errorf("level1 and level2 must not both be disabled")
+	}
+
+	if i.LSPRefreshInterval != 0 && i.LSPRefreshInterval >= i.LSPLifetime {
+		return fmt.Errorf("lsp_refresh_interval %d must be shorter than lsp_lifetime %d", i.LSPRefreshInterval, i.LSPLifetime)
+	}
+
+	if sr := i.SegmentRouting; sr != nil && uint64(sr.SRGBBase)+uint64(sr.SRGBRange)-1 > maxMPLSLabel {
+		return fmt.Errorf("SRGB %d+%d exceeds the MPLS label space", sr.SRGBBase, sr.SRGBRange)
+	}
+
+	for _, ifa := range i.Interfaces {
+		if ifa.TE != nil && len(ifa.TE.UnreservedBandwidth) > teBandwidthPriorities {
+			return fmt.Errorf("interface %q: unreserved_bandwidth lists more than %d priority levels", ifa.Name, teBandwidthPriorities)
+		}
+	}
+
+	for _, l := range []*ISISLevel{i.Level1, i.Level2} {
+		if l != nil && l.AuthenticationKey != "" && len(l.Keychain) > 0 {
+			return fmt.Errorf("authentication_key and keychain are mutually exclusive")
+		}
+	}
+
+	if i.Level1 != nil {
+		for _, name := range i.Level1.Leak {
+			f := po.getPolicyStatementFilter(name)
+			if f == nil {
+				return fmt.Errorf("policy statement %q undefined", name)
+			}
+
+			i.Level1.LeakFilterChain = append(i.Level1.LeakFilterChain, f)
+		}
+	}
+
+	for _, l := range []*ISISLevel{i.Level1, i.Level2} {
+		if l == nil {
+			continue
+		}
+
+		for _, name := range l.Export {
+			f := po.getPolicyStatementFilter(name)
+			if f == nil {
+				return fmt.Errorf("policy statement %q undefined", name)
+			}
+
+			l.ExportFilterChain = append(l.ExportFilterChain, f)
+		}
+	}
+
+	return nil
+}
+
 func (i *ISIS) loadDefaults() {
+	if i.Hostname == "" {
+		i.Hostname, _ = os.Hostname()
+	}
+
 	if i.LSPLifetime == 0 {
 		i.LSPLifetime = lspDefaultLifetimeSeconds
 	}
@@ -54,6 +194,16 @@ func (i *ISIS) loadDefaults() {
 		i.LSPLifetime = lspMinLifetime
 	}
 
+	if i.SegmentRouting != nil {
+		if i.SegmentRouting.SRGBBase == 0 {
+			i.SegmentRouting.SRGBBase = defaultSRGBBase
+		}
+
+		if i.SegmentRouting.SRGBRange == 0 {
+			i.SegmentRouting.SRGBRange = defaultSRGBRange
+		}
+	}
+
 	for _, ifa := range i.Interfaces {
 		ifa.loadDefaults()
 	}
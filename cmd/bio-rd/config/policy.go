@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
 	"github.com/bio-routing/bio-rd/routingtable/filter/actions"
 )
@@ -24,13 +25,19 @@ type PolicyStatement struct {
 }
 
 type PolicyStatementTerm struct {
-	Name string                  `yaml:"name"`
-	From PolicyStatementTermFrom `yaml:"from"`
-	Then PolicyStatementTermThen `yaml:"then"`
+	Name     string                  `yaml:"name"`
+	Sequence uint32                  `yaml:"sequence"`
+	From     PolicyStatementTermFrom `yaml:"from"`
+	Then     PolicyStatementTermThen `yaml:"then"`
 }
 
 type PolicyStatementTermFrom struct {
 	RouteFilters []*RouteFilter `yaml:"route_filters"`
+	NextHop      []string       `yaml:"next_hop"`
+	PeerAS       []uint32       `yaml:"peer_as"`
+	Origin       []string       `yaml:"origin"`
+	Source       []string       `yaml:"source"`
+	Community    []string       `yaml:"community"`
 }
 
 type RouteFilter struct {
@@ -45,8 +52,15 @@ type PolicyStatementTermThen struct {
 	Reject        bool           `yaml:"reject"`
 	MED           *uint32        `yaml:"med"`
 	LocalPref     *uint32        `yaml:"local_pref"`
+	Origin        *string        `yaml:"origin"`
+	Weight        *uint32        `yaml:"weight"`
 	ASPathPrepend *ASPathPrepend `yaml:"as_path_prepend"`
 	NextHop       *NextHop       `yaml:"next_hop"`
+	ISISMetric    *uint32        `yaml:"isis_metric"`
+	ISISTag       *uint32        `yaml:"isis_tag"`
+	Discard       bool           `yaml:"discard"`
+	Continue      bool           `yaml:"continue"`
+	GotoSequence  *uint32        `yaml:"goto_sequence"`
 }
 
 type ASPathPrepend struct {
@@ -120,6 +134,74 @@ func (ps *PolicyStatement) toFilter() (*filter.Filter, error) {
 	return filter.NewFilter(ps.Name, terms), nil
 }
 
+func (from *PolicyStatementTermFrom) toTermCondition(routeFilters []*filter.RouteFilter) (*filter.TermCondition, error) {
+	if len(routeFilters) == 0 && len(from.NextHop) == 0 && len(from.PeerAS) == 0 && len(from.Origin) == 0 && len(from.Source) == 0 && len(from.Community) == 0 {
+		return nil, nil
+	}
+
+	c := filter.NewTermConditionWithRouteFilters(routeFilters...)
+
+	if len(from.NextHop) > 0 {
+		pfxs := make([]*bnet.Prefix, 0, len(from.NextHop))
+		for _, x := range from.NextHop {
+			pfx, err := bnet.PrefixFromString(x)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid next_hop prefix: %w", err)
+			}
+
+			pfxs = append(pfxs, pfx)
+		}
+
+		c.AddNextHopFilters(filter.NewNextHopFilter(filter.NewPrefixListWithMatcher(filter.NewOrLongerMatcher(), pfxs...)))
+	}
+
+	for _, asn := range from.PeerAS {
+		c.AddPeerASFilters(filter.NewPeerASFilter(asn))
+	}
+
+	for _, x := range from.Origin {
+		origin, err := originFromString(x)
+		if err != nil {
+			return nil, err
+		}
+
+		c.AddOriginFilters(filter.NewOriginFilter(origin))
+	}
+
+	for _, x := range from.Source {
+		addr, err := bnet.IPFromString(x)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid source address: %w", err)
+		}
+
+		c.AddSourceFilters(filter.NewSourceFilter(addr.Dedup()))
+	}
+
+	for _, x := range from.Community {
+		com, err := types.ParseCommunityString(x)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid community: %w", err)
+		}
+
+		c.AddCommunityFilters(filter.NewCommunityFilter(com))
+	}
+
+	return c, nil
+}
+
+func originFromString(s string) (uint8, error) {
+	switch s {
+	case "igp":
+		return 0, nil
+	case "egp":
+		return 1, nil
+	case "incomplete":
+		return 2, nil
+	}
+
+	return 0, fmt.Errorf("Invalid origin: %q", s)
+}
+
 func (pst *PolicyStatementTerm) toFilterTerm() (*filter.Term, error) {
 	conditions := make([]*filter.TermCondition, 0)
 	a := make([]actions.Action, 0)
@@ -134,8 +216,13 @@ func (pst *PolicyStatementTerm) toFilterTerm() (*filter.Term, error) {
 		routeFilters = append(routeFilters, rf)
 	}
 
-	if len(routeFilters) > 0 {
-		conditions = append(conditions, filter.NewTermConditionWithRouteFilters(routeFilters...))
+	c, err := pst.From.toTermCondition(routeFilters)
+	if err != nil {
+		return nil, err
+	}
+
+	if c != nil {
+		conditions = append(conditions, c)
 	}
 
 	if pst.Then.Reject {
@@ -150,6 +237,19 @@ func (pst *PolicyStatementTerm) toFilterTerm() (*filter.Term, error) {
 		a = append(a, actions.NewSetMEDAction(*pst.Then.MED))
 	}
 
+	if pst.Then.Origin != nil {
+		origin, err := originFromString(*pst.Then.Origin)
+		if err != nil {
+			return nil, err
+		}
+
+		a = append(a, actions.NewSetOriginAction(origin))
+	}
+
+	if pst.Then.Weight != nil {
+		a = append(a, actions.NewSetWeightAction(*pst.Then.Weight))
+	}
+
 	if pst.Then.ASPathPrepend != nil {
 		a = append(a, actions.NewASPathPrependAction(pst.Then.ASPathPrepend.ASN, pst.Then.ASPathPrepend.Count))
 	}
@@ -163,9 +263,35 @@ func (pst *PolicyStatementTerm) toFilterTerm() (*filter.Term, error) {
 		a = append(a, actions.NewSetNextHopAction(addr.Dedup()))
 	}
 
+	if pst.Then.ISISMetric != nil {
+		a = append(a, actions.NewSetISISMetricAction(*pst.Then.ISISMetric))
+	}
+
+	if pst.Then.ISISTag != nil {
+		a = append(a, actions.NewSetISISTagAction(*pst.Then.ISISTag))
+	}
+
+	if pst.Then.Discard {
+		a = append(a, actions.NewDiscardAction())
+	}
+
 	if pst.Then.Accept {
 		a = append(a, actions.NewAcceptAction())
 	}
 
-	return filter.NewTerm(pst.Name, conditions, a), nil
+	flow := filter.FlowControl{}
+	if pst.Then.Continue {
+		flow.Mode = filter.FlowContinue
+	}
+
+	if pst.Then.GotoSequence != nil {
+		if *pst.Then.GotoSequence <= pst.Sequence {
+			return nil, fmt.Errorf("goto_sequence %d of term %q must be greater than its sequence %d", *pst.Then.GotoSequence, pst.Name, pst.Sequence)
+		}
+
+		flow.Mode = filter.FlowGoto
+		flow.Goto = *pst.Then.GotoSequence
+	}
+
+	return filter.NewSequencedTerm(pst.Name, pst.Sequence, conditions, a, flow), nil
 }
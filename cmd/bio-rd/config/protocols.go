@@ -14,7 +14,10 @@ func (p *Protocols) load(localAS uint32, policyOptions *PolicyOptions) error {
 	}
 
 	if p.ISIS != nil {
-		p.ISIS.loadDefaults()
+		err := p.ISIS.load(policyOptions)
+		if err != nil {
+			return fmt.Errorf("ISIS error: %w", err)
+		}
 	}
 
 	return nil
@@ -4,13 +4,73 @@ import (
 	"fmt"
 
 	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
 )
 
 type RoutingOptions struct {
-	StaticRoutes     []StaticRoute `yaml:"static_routes"`
-	RouterID         string        `yaml:"router_id"`
-	RouterIDUint32   uint32
-	AutonomousSystem uint32 `yaml:"autonomous_system"`
+	StaticRoutes           []StaticRoute `yaml:"static_routes"`
+	RouterID               string        `yaml:"router_id"`
+	RouterIDUint32         uint32
+	AutonomousSystem       uint32                  `yaml:"autonomous_system"`
+	BestPath               *BestPath               `yaml:"bestpath"`
+	AdministrativeDistance *AdministrativeDistance `yaml:"administrative_distance"`
+}
+
+// BestPath holds knobs altering the best path selection
+type BestPath struct {
+	ASPathIgnore          bool `yaml:"as_path_ignore"`
+	ASPathMultipathStrict bool `yaml:"as_path_multipath_strict"`
+	MEDMissingAsWorst     bool `yaml:"med_missing_as_worst"`
+}
+
+// AdministrativeDistance overrides the default distances used to arbitrate between routes of different protocols.
+// Distances left unset (0) keep their default.
+type AdministrativeDistance struct {
+	Static uint8 `yaml:"static"`
+	EBGP   uint8 `yaml:"ebgp"`
+	IBGP   uint8 `yaml:"ibgp"`
+	OSPF   uint8 `yaml:"ospf"`
+	ISIS   uint8 `yaml:"isis"`
+}
+
+func (a *AdministrativeDistance) distances() *route.AdministrativeDistances {
+	d := route.DefaultAdministrativeDistances()
+	for _, x := range []struct {
+		configured uint8
+		distance   *uint8
+	}{
+		{configured: a.Static, distance: &d.Static},
+		{configured: a.EBGP, distance: &d.EBGP},
+		{configured: a.IBGP, distance: &d.IBGP},
+		{configured: a.OSPF, distance: &d.OSPF},
+		{configured: a.ISIS, distance: &d.ISIS},
+	} {
+		if x.configured != 0 {
+			*x.distance = x.configured
+		}
+	}
+
+	return d
+}
+
+// SelectionOptions returns the route selection options configured in r
+func (r *RoutingOptions) SelectionOptions() *route.SelectionOptions {
+	if r == nil || (r.BestPath == nil && r.AdministrativeDistance == nil) {
+		return nil
+	}
+
+	ret := &route.SelectionOptions{}
+	if r.BestPath != nil {
+		ret.IgnoreASPathLength = r.BestPath.ASPathIgnore
+		ret.ASPathMultipathStrict = r.BestPath.ASPathMultipathStrict
+		ret.MEDMissingAsWorst = r.BestPath.MEDMissingAsWorst
+	}
+
+	if r.AdministrativeDistance != nil {
+		ret.AdministrativeDistances = r.AdministrativeDistance.distances()
+	}
+
+	return ret
 }
 
 func (r *RoutingOptions) load() error {
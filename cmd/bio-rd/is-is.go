@@ -2,10 +2,11 @@ package main
 
 import (
 	"fmt"
-	"strconv"
-	"strings"
+	"time"
 
 	"github.com/bio-routing/bio-rd/cmd/bio-rd/config"
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
 	"github.com/bio-routing/bio-rd/protocols/isis/server"
 	"github.com/bio-routing/bio-rd/protocols/isis/types"
 	"github.com/bio-routing/bio-rd/util/log"
@@ -21,13 +22,48 @@ func configureProtocolsISIS(isis *config.ISIS) error {
 		return err
 	}
 
+	level1, err := translateLevelConfig(isis.Level1)
+	if err != nil {
+		return fmt.Errorf("invalid level 1 config: %w", err)
+	}
+
+	level2, err := translateLevelConfig(isis.Level2)
+	if err != nil {
+		return fmt.Errorf("invalid level 2 config: %w", err)
+	}
+
 	if isisSrv == nil {
-		var err error
-		isisSrv, err = server.New(nets, ds, isis.LSPLifetime)
+		srv, err := server.New(nets, ds, isis.LSPLifetime, isis.Hostname, level1, level2)
 		if err != nil {
 			return fmt.Errorf("unable to create ISIS server: %w", err)
 		}
 
+		err = srv.SetLSPTimers(&server.LSPTimers{
+			RefreshInterval: isis.LSPRefreshInterval,
+			ZeroAgeLifetime: isis.ZeroAgeLifetime,
+		})
+		if err != nil {
+			return fmt.Errorf("invalid LSP timers: %w", err)
+		}
+
+		if bgpSrv.RouterID() != 0 {
+			srv.SetRouterID(bnet.IPv4(bgpSrv.RouterID()))
+		}
+
+		if isis.SegmentRouting != nil {
+			err = srv.SetSegmentRouting(&server.SegmentRoutingConfig{
+				SRGBBase:  isis.SegmentRouting.SRGBBase,
+				SRGBRange: isis.SegmentRouting.SRGBRange,
+			})
+			if err != nil {
+				return fmt.Errorf("invalid segment routing config: %w", err)
+			}
+		}
+
+		isisSrvMu.Lock()
+		isisSrv = srv
+		isisSrvMu.Unlock()
+
 		err = isisSrv.Start()
 		if err != nil {
 			return fmt.Errorf("unable to start ISIS server: %w", err)
@@ -38,6 +74,11 @@ func configureProtocolsISIS(isis *config.ISIS) error {
 	for _, ifa := range isis.Interfaces {
 		if strSliceContains(configuredInterfaces, ifa.Name) {
 			log.Debugf("ISIS: Interface %q is already configured", ifa.Name)
+			err := isisSrv.UpdateInterfaceTE(ifa.Name, translateInterfaceTEConfig(ifa.TE))
+			if err != nil {
+				return fmt.Errorf("unable to update TE config of interface %s: %w", ifa.Name, err)
+			}
+
 			continue
 		}
 
@@ -48,6 +89,7 @@ func configureProtocolsISIS(isis *config.ISIS) error {
 			PointToPoint: ifa.PointToPoint,
 			Level1:       translateInterfaceLevelConfig(ifa.Level1),
 			Level2:       translateInterfaceLevelConfig(ifa.Level2),
+			TE:           translateInterfaceTEConfig(ifa.TE),
 		})
 		if err != nil {
 			return fmt.Errorf("unable to add interface: %s: %w", ifa.Name, err)
@@ -66,6 +108,117 @@ func configureProtocolsISIS(isis *config.ISIS) error {
 	return nil
 }
 
+// isisLSDB provides the LSDB of the ISIS server to BGP-LS. ISIS may be configured after BGP or not at all.
+type isisLSDB struct{}
+
+func (isisLSDB) GetLSDB() []*server.LSDBEntry {
+	srv := getISISServer()
+	if srv == nil {
+		return nil
+	}
+
+	return srv.GetLSDB()
+}
+
+func (isisLSDB) LSDBLevel() int {
+	srv := getISISServer()
+	if srv == nil {
+		return 2
+	}
+
+	return srv.LSDBLevel()
+}
+
+// getISISServer gets the ISIS server from outside the config reloader, which is the only writer of isisSrv
+func getISISServer() server.ISISServer {
+	isisSrvMu.RLock()
+	defer isisSrvMu.RUnlock()
+
+	return isisSrv
+}
+
+func translateLevelConfig(c *config.ISISLevel) (*server.LevelConfig, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	metricStyle, err := server.ParseMetricStyle(c.MetricStyle)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.WideMetricsOnly && metricStyle != server.MetricStyleWide {
+		return nil, fmt.Errorf("wide_metrics_only conflicts with metric style %s", metricStyle)
+	}
+
+	keychain, err := translateKeychain(c)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keychain: %w", err)
+	}
+
+	return &server.LevelConfig{
+		Disabled:              c.Disable,
+		MetricStyle:           metricStyle,
+		LeakPolicy:            c.LeakFilterChain,
+		ExportPolicy:          c.ExportFilterChain,
+		SuppressAttached:      c.SuppressAttached,
+		Keychain:              keychain,
+		NoHelloAuthentication: c.NoHelloAuthentication,
+		NoCSNPAuthentication:  c.NoCSNPAuthentication,
+		NoPSNPAuthentication:  c.NoPSNPAuthentication,
+
+		NoPurgeOriginatorIdentification: c.NoPurgeOriginatorIdentification,
+	}, nil
+}
+
+// translateKeychain translates the keychain of a level. A single authentication key becomes a keychain with a key valid forever.
+func translateKeychain(c *config.ISISLevel) (*server.Keychain, error) {
+	if c.AuthenticationKey != "" {
+		return server.NewKeychain(&server.Key{
+			Secret: []byte(c.AuthenticationKey),
+		})
+	}
+
+	if len(c.Keychain) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]*server.Key, 0, len(c.Keychain))
+	for _, k := range c.Keychain {
+		key := &server.Key{
+			ID:     k.ID,
+			Secret: []byte(k.Secret),
+		}
+
+		lifetimes := []struct {
+			value string
+			t     *time.Time
+		}{
+			{value: k.SendStart, t: &key.SendStart},
+			{value: k.SendEnd, t: &key.SendEnd},
+			{value: k.AcceptStart, t: &key.AcceptStart},
+			{value: k.AcceptEnd, t: &key.AcceptEnd},
+		}
+
+		for _, l := range lifetimes {
+			if l.value == "" {
+				continue
+			}
+
+			t, err := time.Parse(time.RFC3339, l.value)
+			if err != nil {
+				return nil, fmt.Errorf("key %d: invalid lifetime: %w", k.ID, err)
+			}
+
+			*l.t = t
+		}
+
+		keys = append(keys, key)
+	}
+
+	return server.NewKeychain(keys...)
+}
+
 func translateInterfaceLevelConfig(c *config.ISISInterfaceLevel) *server.InterfaceLevelConfig {
 	if c == nil {
 		return nil
@@ -80,40 +233,40 @@ func translateInterfaceLevelConfig(c *config.ISISInterfaceLevel) *server.Interfa
 	}
 }
 
-func parseNETs(nets []string) ([]*types.NET, error) {
-	ret := make([]*types.NET, 0, len(nets))
+func translateInterfaceTEConfig(c *config.ISISInterfaceTE) *server.InterfaceTEConfig {
+	if c == nil {
+		return nil
+	}
 
-	for _, net := range nets {
-		b, err := parseHexString(net)
-		if err != nil {
-			return nil, fmt.Errorf("unable to convert NET hex string into ints: %w", err)
-		}
+	ret := &server.InterfaceTEConfig{
+		AdminGroup:             c.AdminGroup,
+		MaxLinkBandwidth:       c.MaxLinkBandwidth,
+		MaxReservableBandwidth: c.MaxReservableBandwidth,
+		DefaultMetric:          c.DefaultMetric,
+	}
 
-		n, err := types.ParseNET(b)
-		if err != nil {
-			return nil, fmt.Errorf("unable to parse NET %q: %w", net, err)
+	if len(c.UnreservedBandwidth) > 0 {
+		ret.UnreservedBandwidth = &[packet.UnreservedBandwidthPriorities]float32{}
+		for i := range ret.UnreservedBandwidth {
+			ret.UnreservedBandwidth[i] = c.MaxReservableBandwidth
 		}
 
-		ret = append(ret, n)
+		copy(ret.UnreservedBandwidth[:], c.UnreservedBandwidth)
 	}
 
-	return ret, nil
+	return ret
 }
 
-// parseHexString converts a string of hex numbers into a byte slice
-func parseHexString(s string) ([]byte, error) {
-	ret := make([]byte, 0)
-
-	s = strings.Replace(s, ".", "", -1)
-	runes := []rune(s)
+func parseNETs(nets []string) ([]*types.NET, error) {
+	ret := make([]*types.NET, 0, len(nets))
 
-	for i := 0; i < len(runes); i += 2 {
-		x, err := strconv.ParseInt(fmt.Sprintf("%c%c", runes[i], runes[i+1]), 16, 8)
+	for _, net := range nets {
+		n, err := types.ParseNETString(net)
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse int: %w", err)
+			return nil, fmt.Errorf("unable to parse NET %q: %w", net, err)
 		}
 
-		ret = append(ret, uint8(x))
+		ret = append(ret, n)
 	}
 
 	return ret, nil
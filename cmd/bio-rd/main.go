@@ -3,8 +3,11 @@ package main
 import (
 	"flag"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -13,6 +16,7 @@ import (
 	bgpserver "github.com/bio-routing/bio-rd/protocols/bgp/server"
 	"github.com/bio-routing/bio-rd/protocols/device"
 	isisapi "github.com/bio-routing/bio-rd/protocols/isis/api"
+	"github.com/bio-routing/bio-rd/protocols/isis/bgpls"
 	isisserver "github.com/bio-routing/bio-rd/protocols/isis/server"
 	"github.com/bio-routing/bio-rd/routingtable"
 	"github.com/bio-routing/bio-rd/routingtable/vrf"
@@ -28,12 +32,15 @@ var (
 	grpcPort             = flag.Uint("grpc_port", 5566, "GRPC API server port")
 	grpcKeepaliveMinTime = flag.Uint("grpc_keepalive_min_time", 1, "Minimum time (seconds) for a client to wait between GRPC keepalive pings")
 	metricsPort          = flag.Uint("metrics_port", 55667, "Metrics HTTP server port")
+	readinessDeadline    = flag.Uint("readiness_deadline", 300, "Time (seconds) after which peers that did not converge no longer delay readiness")
 	sigHUP               = make(chan os.Signal)
 	vrfReg               = vrf.NewVRFRegistry()
 	bgpSrv               bgpserver.BGPServer
 	isisSrv              isisserver.ISISServer
+	isisSrvMu            sync.RWMutex
 	ds                   device.Updater
 	runCfg               *config.Config
+	configLoaded         atomic.Bool
 )
 
 func main() {
@@ -100,6 +107,8 @@ func main() {
 		os.Exit(1)
 	}
 
+	http.HandleFunc("/ready", readinessHandler)
+
 	bgpapi.RegisterBgpServiceServer(srv.GRPC(), s)
 	isisapi.RegisterIsisServiceServer(srv.GRPC(), isisAPISrv)
 	if err := srv.Serve(); err != nil {
@@ -110,6 +119,16 @@ func main() {
 	select {}
 }
 
+// readinessHandler responds with 200 once the configuration is loaded and BGP has converged initially
+func readinessHandler(w http.ResponseWriter, r *http.Request) {
+	if !configLoaded.Load() || !bgpSrv.Ready(time.Duration(*readinessDeadline)*time.Second) {
+		http.Error(w, "not ready", http.StatusServiceUnavailable)
+		return
+	}
+
+	fmt.Fprintln(w, "ready")
+}
+
 func installSignalHandler() {
 	signal.Notify(sigHUP, syscall.SIGHUP)
 }
@@ -130,11 +149,13 @@ func configReloader() {
 			continue
 		}
 
+		configLoaded.Store(true)
 		log.Infof("Configuration reloaded")
 	}
 }
 
 func loadConfig(cfg *config.Config) error {
+	vrfReg.GetVRFByRD(0).SetSelectionOptions(cfg.RoutingOptions.SelectionOptions())
 
 	for _, ri := range cfg.RoutingInstances {
 		err := configureRoutingInstance(ri)
@@ -219,16 +240,21 @@ func configureProtocolsBGP(bgp *config.BGP) error {
 // BGPPeerConfig converts a BGPNeighbor config into a PeerConfig
 func BGPPeerConfig(n *config.BGPNeighbor, vrf *vrf.VRF) *bgpserver.PeerConfig {
 	r := &bgpserver.PeerConfig{
-		AuthenticationKey: n.AuthenticationKey,
-		LocalAS:           n.LocalAS,
-		PeerAS:            n.PeerAS,
-		PeerAddress:       n.PeerAddressIP,
-		LocalAddress:      n.LocalAddressIP,
-		TTL:               n.TTL,
-		ReconnectInterval: time.Second * 15,
-		HoldTime:          n.HoldTimeDuration,
-		KeepAlive:         n.HoldTimeDuration / 3,
-		RouterID:          bgpSrv.RouterID(),
+		AuthenticationKey:             n.AuthenticationKey,
+		LocalAS:                       n.LocalAS,
+		PeerAS:                        n.PeerAS,
+		PeerAddress:                   n.PeerAddressIP,
+		LocalAddress:                  n.LocalAddressIP,
+		UpdateSource:                  n.UpdateSourceIP,
+		TTL:                           n.TTL,
+		TTLSecurityHops:               n.TTLSecurityHops,
+		ReconnectInterval:             time.Second * 15,
+		HoldTime:                      n.HoldTimeDuration,
+		KeepAlive:                     n.HoldTimeDuration / 3,
+		RouterID:                      bgpSrv.RouterID(),
+		InboundQueueSize:              n.InboundQueueSize,
+		MinRouteAdvertisementInterval: n.MRAIDuration,
+		AdvertisementDelay:            n.AdvertisementDelayDuration,
 		IPv4: &bgpserver.AddressFamilyConfig{
 			ImportFilterChain: n.ImportFilterChain,
 			ExportFilterChain: n.ExportFilterChain,
@@ -243,13 +269,135 @@ func BGPPeerConfig(n *config.BGPNeighbor, vrf *vrf.VRF) *bgpserver.PeerConfig {
 		r.Passive = *n.Passive
 	}
 
+	if n.Active != nil {
+		r.Active = *n.Active
+	}
+
+	if n.ExtendedMessage != nil {
+		r.ExtendedMessage = *n.ExtendedMessage
+	}
+
+	if n.RouteRefresh != nil {
+		r.RouteRefresh = *n.RouteRefresh
+	}
+
+	if n.EnhancedRR != nil {
+		r.EnhancedRouteRefresh = *n.EnhancedRR
+	}
+
+	if n.SendCommunity != nil {
+		r.StripCommunities = !*n.SendCommunity
+	}
+
+	if n.SendLargeCommunity != nil {
+		r.StripLargeCommunities = !*n.SendLargeCommunity
+	}
+
+	if n.LogASLoops != nil {
+		r.LogASLoops = *n.LogASLoops
+	}
+
+	if n.Capabilities != nil {
+		r.CapabilityOverrides = n.Capabilities.Overrides
+	}
+
+	if n.GracefulRestart != nil {
+		r.GracefulRestart = &bgpserver.GracefulRestart{
+			RestartTime:   n.GracefulRestart.RestartTimeDuration,
+			StalePathTime: n.GracefulRestart.StalePathTimeDuration,
+		}
+	}
+
+	if n.LinkState != nil && *n.LinkState {
+		r.LinkState = &bgpserver.LinkStateConfig{
+			Source: bgpls.NewSource(isisLSDB{}, n.LocalAS, 0),
+		}
+	}
+
+	if n.LocalASOverride != nil {
+		r.LocalASOverride = &bgpserver.LocalASOverride{
+			ASN:       n.LocalASOverride.ASN,
+			NoPrepend: n.LocalASOverride.NoPrepend,
+			ReplaceAS: n.LocalASOverride.ReplaceAS,
+			DualAS:    n.LocalASOverride.DualAS,
+		}
+	}
+
+	if n.DefaultOriginate != nil {
+		r.IPv4.DefaultOriginate = true
+		r.IPv4.DefaultOriginateFilterChain = n.DefaultOriginateFilterChain
+	}
+
+	for _, afi := range n.AFIs {
+		if afi.Name == "ipv4" {
+			applySAFIConfig(r.IPv4, &afi.SAFI)
+		}
+	}
+
 	if n.RouteServerClient != nil {
 		r.RouteServerClient = *n.RouteServerClient
 	}
 
+	r.PeerRole = peerRole(n.PeerRole)
+	if n.PeerRoleStrict != nil {
+		r.PeerRoleStrictMode = *n.PeerRoleStrict
+	}
+
+	if n.NextHopSelf != nil {
+		r.NextHopSelf = *n.NextHopSelf
+	}
+
 	return r
 }
 
+// applySAFIConfig applies the SAFI specific settings of an address family
+func applySAFIConfig(f *bgpserver.AddressFamilyConfig, safi *config.SAFI) {
+	f.LabeledUnicast = safi.Name == "labeled-unicast"
+	f.SuppressFIBFailures = safi.SuppressFIBFailures
+
+	if safi.PrefixLimit != nil {
+		f.PrefixLimit = &bgpserver.PrefixLimit{
+			Limit:           safi.PrefixLimit.Limit,
+			RestartInterval: safi.PrefixLimit.RestartDuration,
+			MaxRestarts:     safi.PrefixLimit.MaxRestarts,
+		}
+	}
+
+	if safi.NextHopValidation != nil {
+		f.NextHopValidation = &bgpserver.NextHopValidation{
+			Resolve: safi.NextHopValidation.Resolve,
+			Reject:  safi.NextHopValidation.Reject,
+		}
+	}
+
+	for _, p := range safi.ORFPrefixList {
+		f.ORFPrefixList = append(f.ORFPrefixList, bgpserver.ORFPrefixListEntry{
+			Prefix: p.PrefixParsed,
+			MinLen: p.LenMin,
+			MaxLen: p.LenMax,
+			Deny:   p.Deny,
+		})
+	}
+}
+
+// peerRole translates the name of a BGP role as validated by the config
+func peerRole(name string) uint8 {
+	switch name {
+	case "provider":
+		return bgpserver.PeerConfigRoleProvider
+	case "rs":
+		return bgpserver.PeerConfigRoleRS
+	case "rs-client":
+		return bgpserver.PeerConfigRoleRSClient
+	case "customer":
+		return bgpserver.PeerConfigRoleCustomer
+	case "peer":
+		return bgpserver.PeerConfigRolePeer
+	}
+
+	return bgpserver.PeerConfigRoleOff
+}
+
 func configureRoutingInstance(ri *config.RoutingInstance) error {
 	vrf := vrfReg.GetVRFByName(ri.Name)
 
@@ -263,5 +411,7 @@ func configureRoutingInstance(ri *config.RoutingInstance) error {
 		// TODO: Add all routing adjacencies
 	}
 
+	vrf.SetSelectionOptions(ri.RoutingOptions.SelectionOptions())
+
 	return nil
 }
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/cmd/bio-rd/config"
+	bnet "github.com/bio-routing/bio-rd/net"
+	bgpserver "github.com/bio-routing/bio-rd/protocols/bgp/server"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplySAFIConfig(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+
+	tests := []struct {
+		name     string
+		safi     *config.SAFI
+		expected *bgpserver.AddressFamilyConfig
+	}{
+		{
+			name: "Unicast",
+			safi: &config.SAFI{
+				Name: "unicast",
+				NextHopValidation: &config.NextHopValidation{
+					Resolve: true,
+				},
+				SuppressFIBFailures: true,
+			},
+			expected: &bgpserver.AddressFamilyConfig{
+				NextHopValidation: &bgpserver.NextHopValidation{
+					Resolve: true,
+				},
+				SuppressFIBFailures: true,
+			},
+		},
+		{
+			name: "Labeled unicast",
+			safi: &config.SAFI{
+				Name: "labeled-unicast",
+				PrefixLimit: &config.PrefixLimit{
+					Limit: 1000,
+				},
+				ORFPrefixList: []*config.ORFPrefix{
+					{
+						PrefixParsed: pfx,
+						LenMax:       24,
+					},
+				},
+			},
+			expected: &bgpserver.AddressFamilyConfig{
+				LabeledUnicast: true,
+				PrefixLimit: &bgpserver.PrefixLimit{
+					Limit: 1000,
+				},
+				ORFPrefixList: bgpserver.ORFPrefixList{
+					{
+						Prefix: pfx,
+						MaxLen: 24,
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := &bgpserver.AddressFamilyConfig{}
+			applySAFIConfig(f, test.safi)
+			assert.Equal(t, test.expected, f)
+		})
+	}
+}
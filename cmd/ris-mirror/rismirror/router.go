@@ -52,8 +52,13 @@ func (r *Router) GetVRFs() []*vrf.VRF {
 	return r.vrfRegistry.List()
 }
 
+// CreateVRFIfNotExists gets a VRF and creates it if it does not exist yet
+func (r *Router) CreateVRFIfNotExists(rd uint64) *vrf.VRF {
+	return r.vrfRegistry.CreateVRFIfNotExists(fmt.Sprintf("%d", rd), rd)
+}
+
 func (r *Router) addVRF(rd uint64, sources []*grpc.ClientConn) {
-	v := r.vrfRegistry.CreateVRFIfNotExists(fmt.Sprintf("%d", rd), rd)
+	v := r.CreateVRFIfNotExists(rd)
 
 	r.vrfs[rd] = newVRF(v.IPv4UnicastRIB(), v.IPv6UnicastRIB())
 
@@ -114,6 +114,52 @@ func (DumpRIBRequest_AFISAFI) EnumDescriptor() ([]byte, []int) {
 	return file_cmd_ris_api_ris_proto_rawDescGZIP(), []int{9, 0}
 }
 
+type RIBStatus_AFISAFI int32
+
+const (
+	RIBStatus_IPv4Unicast RIBStatus_AFISAFI = 0
+	RIBStatus_IPv6Unicast RIBStatus_AFISAFI = 1
+)
+
+// Enum value maps for RIBStatus_AFISAFI.
+var (
+	RIBStatus_AFISAFI_name = map[int32]string{
+		0: "IPv4Unicast",
+		1: "IPv6Unicast",
+	}
+	RIBStatus_AFISAFI_value = map[string]int32{
+		"IPv4Unicast": 0,
+		"IPv6Unicast": 1,
+	}
+)
+
+func (x RIBStatus_AFISAFI) Enum() *RIBStatus_AFISAFI {
+	p := new(RIBStatus_AFISAFI)
+	*p = x
+	return p
+}
+
+func (x RIBStatus_AFISAFI) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RIBStatus_AFISAFI) Descriptor() protoreflect.EnumDescriptor {
+	return file_cmd_ris_api_ris_proto_enumTypes[2].Descriptor()
+}
+
+func (RIBStatus_AFISAFI) Type() protoreflect.EnumType {
+	return &file_cmd_ris_api_ris_proto_enumTypes[2]
+}
+
+func (x RIBStatus_AFISAFI) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RIBStatus_AFISAFI.Descriptor instead.
+func (RIBStatus_AFISAFI) EnumDescriptor() ([]byte, []int) {
+	return file_cmd_ris_api_ris_proto_rawDescGZIP(), []int{17, 0}
+}
+
 type LPMRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -683,6 +729,10 @@ type DumpRIBRequest struct {
 	Vrf     string                 `protobuf:"bytes,4,opt,name=vrf,proto3" json:"vrf,omitempty"`
 	Afisafi DumpRIBRequest_AFISAFI `protobuf:"varint,3,opt,name=afisafi,proto3,enum=bio.ris.DumpRIBRequest_AFISAFI" json:"afisafi,omitempty"`
 	Filter  *RIBFilter             `protobuf:"bytes,5,opt,name=filter,proto3" json:"filter,omitempty"`
+	// page_size limits the number of routes sent. 0 sends all routes.
+	PageSize uint32 `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// cursor resumes the dump after the route the cursor was received with
+	Cursor string `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
 }
 
 func (x *DumpRIBRequest) Reset() {
@@ -752,12 +802,28 @@ func (x *DumpRIBRequest) GetFilter() *RIBFilter {
 	return nil
 }
 
+func (x *DumpRIBRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *DumpRIBRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type DumpRIBReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Route *api1.Route `protobuf:"bytes,1,opt,name=route,proto3" json:"route,omitempty"`
+	// cursor is an opaque token to resume the dump after this route
+	Cursor string `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"`
 }
 
 func (x *DumpRIBReply) Reset() {
@@ -799,6 +865,13 @@ func (x *DumpRIBReply) GetRoute() *api1.Route {
 	return nil
 }
 
+func (x *DumpRIBReply) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type GetRoutersRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -947,6 +1020,272 @@ func (x *GetRoutersResponse) GetRouters() []*Router {
 	return nil
 }
 
+type ListRoutersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListRoutersRequest) Reset() {
+	*x = ListRoutersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cmd_ris_api_ris_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRoutersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoutersRequest) ProtoMessage() {}
+
+func (x *ListRoutersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_ris_api_ris_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoutersRequest.ProtoReflect.Descriptor instead.
+func (*ListRoutersRequest) Descriptor() ([]byte, []int) {
+	return file_cmd_ris_api_ris_proto_rawDescGZIP(), []int{14}
+}
+
+type ListRoutersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Routers []*Router `protobuf:"bytes,1,rep,name=routers,proto3" json:"routers,omitempty"`
+}
+
+func (x *ListRoutersResponse) Reset() {
+	*x = ListRoutersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cmd_ris_api_ris_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRoutersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoutersResponse) ProtoMessage() {}
+
+func (x *ListRoutersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_ris_api_ris_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoutersResponse.ProtoReflect.Descriptor instead.
+func (*ListRoutersResponse) Descriptor() ([]byte, []int) {
+	return file_cmd_ris_api_ris_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListRoutersResponse) GetRouters() []*Router {
+	if x != nil {
+		return x.Routers
+	}
+	return nil
+}
+
+type GetRouterStatusRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Router string `protobuf:"bytes,1,opt,name=router,proto3" json:"router,omitempty"`
+}
+
+func (x *GetRouterStatusRequest) Reset() {
+	*x = GetRouterStatusRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cmd_ris_api_ris_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRouterStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRouterStatusRequest) ProtoMessage() {}
+
+func (x *GetRouterStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_ris_api_ris_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRouterStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetRouterStatusRequest) Descriptor() ([]byte, []int) {
+	return file_cmd_ris_api_ris_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetRouterStatusRequest) GetRouter() string {
+	if x != nil {
+		return x.Router
+	}
+	return ""
+}
+
+type RIBStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	VrfId      uint64            `protobuf:"varint,1,opt,name=vrf_id,json=vrfId,proto3" json:"vrf_id,omitempty"`
+	Afisafi    RIBStatus_AFISAFI `protobuf:"varint,2,opt,name=afisafi,proto3,enum=bio.ris.RIBStatus_AFISAFI" json:"afisafi,omitempty"`
+	RouteCount uint64            `protobuf:"varint,3,opt,name=route_count,json=routeCount,proto3" json:"route_count,omitempty"`
+	Ready      bool              `protobuf:"varint,4,opt,name=ready,proto3" json:"ready,omitempty"`
+	LastUpdate uint64            `protobuf:"varint,5,opt,name=last_update,json=lastUpdate,proto3" json:"last_update,omitempty"`
+}
+
+func (x *RIBStatus) Reset() {
+	*x = RIBStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cmd_ris_api_ris_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RIBStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RIBStatus) ProtoMessage() {}
+
+func (x *RIBStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_ris_api_ris_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RIBStatus.ProtoReflect.Descriptor instead.
+func (*RIBStatus) Descriptor() ([]byte, []int) {
+	return file_cmd_ris_api_ris_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RIBStatus) GetVrfId() uint64 {
+	if x != nil {
+		return x.VrfId
+	}
+	return 0
+}
+
+func (x *RIBStatus) GetAfisafi() RIBStatus_AFISAFI {
+	if x != nil {
+		return x.Afisafi
+	}
+	return RIBStatus_IPv4Unicast
+}
+
+func (x *RIBStatus) GetRouteCount() uint64 {
+	if x != nil {
+		return x.RouteCount
+	}
+	return 0
+}
+
+func (x *RIBStatus) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *RIBStatus) GetLastUpdate() uint64 {
+	if x != nil {
+		return x.LastUpdate
+	}
+	return 0
+}
+
+type GetRouterStatusResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Router *Router      `protobuf:"bytes,1,opt,name=router,proto3" json:"router,omitempty"`
+	Ribs   []*RIBStatus `protobuf:"bytes,2,rep,name=ribs,proto3" json:"ribs,omitempty"`
+}
+
+func (x *GetRouterStatusResponse) Reset() {
+	*x = GetRouterStatusResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_cmd_ris_api_ris_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRouterStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRouterStatusResponse) ProtoMessage() {}
+
+func (x *GetRouterStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_cmd_ris_api_ris_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRouterStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetRouterStatusResponse) Descriptor() ([]byte, []int) {
+	return file_cmd_ris_api_ris_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetRouterStatusResponse) GetRouter() *Router {
+	if x != nil {
+		return x.Router
+	}
+	return nil
+}
+
+func (x *GetRouterStatusResponse) GetRibs() []*RIBStatus {
+	if x != nil {
+		return x.Ribs
+	}
+	return nil
+}
+
 var File_cmd_ris_api_ris_proto protoreflect.FileDescriptor
 
 var file_cmd_ris_api_ris_proto_rawDesc = []byte{
@@ -1017,7 +1356,7 @@ var file_cmd_ris_api_ris_proto_rawDesc = []byte{
 	0x6e, 0x64, 0x4f, 0x66, 0x52, 0x69, 0x62, 0x12, 0x26, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65,
 	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75,
 	0x74, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x22,
-	0xe5, 0x01, 0x0a, 0x0e, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x9a, 0x02, 0x0a, 0x0e, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65,
 	0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x12, 0x15, 0x0a, 0x06, 0x76, 0x72,
 	0x66, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x72, 0x66, 0x49,
@@ -1028,53 +1367,97 @@ var file_cmd_ris_api_ris_proto_rawDesc = []byte{
 	0x49, 0x53, 0x41, 0x46, 0x49, 0x52, 0x07, 0x61, 0x66, 0x69, 0x73, 0x61, 0x66, 0x69, 0x12, 0x2a,
 	0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
 	0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x52, 0x49, 0x42, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x2b, 0x0a, 0x07, 0x41, 0x46,
-	0x49, 0x53, 0x41, 0x46, 0x49, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x50, 0x76, 0x34, 0x55, 0x6e, 0x69,
-	0x63, 0x61, 0x73, 0x74, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x50, 0x76, 0x36, 0x55, 0x6e,
-	0x69, 0x63, 0x61, 0x73, 0x74, 0x10, 0x01, 0x22, 0x36, 0x0a, 0x0c, 0x44, 0x75, 0x6d, 0x70, 0x52,
-	0x49, 0x42, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x26, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75,
-	0x74, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x22,
-	0x13, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x22, 0x56, 0x0a, 0x06, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x12, 0x19,
-	0x0a, 0x08, 0x73, 0x79, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x73, 0x79, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x76, 0x72, 0x66,
-	0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04, 0x52, 0x06, 0x76, 0x72, 0x66, 0x49,
-	0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x3f, 0x0a, 0x12,
-	0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x52, 0x6f,
-	0x75, 0x74, 0x65, 0x72, 0x52, 0x07, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x73, 0x32, 0x8f, 0x03,
-	0x0a, 0x19, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x32, 0x0a, 0x03, 0x4c,
-	0x50, 0x4d, 0x12, 0x13, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x4c, 0x50, 0x4d,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69,
-	0x73, 0x2e, 0x4c, 0x50, 0x4d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x32, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x13, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73,
-	0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x62, 0x69,
-	0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72,
-	0x73, 0x12, 0x1a, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x52,
-	0x6f, 0x75, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
-	0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65,
-	0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x09,
-	0x47, 0x65, 0x74, 0x4c, 0x6f, 0x6e, 0x67, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x62, 0x69, 0x6f, 0x2e,
-	0x72, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x6e, 0x67, 0x65, 0x72, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x47,
-	0x65, 0x74, 0x4c, 0x6f, 0x6e, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0a, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x49, 0x42,
-	0x12, 0x1a, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x4f, 0x62, 0x73, 0x65, 0x72,
-	0x76, 0x65, 0x52, 0x49, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x62,
-	0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x52, 0x49, 0x42, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
-	0x30, 0x01, 0x12, 0x3b, 0x0a, 0x07, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x12, 0x17, 0x2e,
-	0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73,
-	0x2e, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x30, 0x01, 0x42,
-	0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x69,
-	0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x64,
-	0x2f, 0x63, 0x6d, 0x64, 0x2f, 0x72, 0x69, 0x73, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61,
+	0x67, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70,
+	0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f,
+	0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22,
+	0x2b, 0x0a, 0x07, 0x41, 0x46, 0x49, 0x53, 0x41, 0x46, 0x49, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x50,
+	0x76, 0x34, 0x55, 0x6e, 0x69, 0x63, 0x61, 0x73, 0x74, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x49,
+	0x50, 0x76, 0x36, 0x55, 0x6e, 0x69, 0x63, 0x61, 0x73, 0x74, 0x10, 0x01, 0x22, 0x4e, 0x0a, 0x0c,
+	0x44, 0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x26, 0x0a, 0x05,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x62, 0x69,
+	0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x05, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x22, 0x13, 0x0a, 0x11,
+	0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x56, 0x0a, 0x06, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x73,
+	0x79, 0x73, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73,
+	0x79, 0x73, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x76, 0x72, 0x66, 0x5f, 0x69, 0x64,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x04, 0x52, 0x06, 0x76, 0x72, 0x66, 0x49, 0x64, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x3f, 0x0a, 0x12, 0x47, 0x65, 0x74,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x29, 0x0a, 0x07, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65,
+	0x72, 0x52, 0x07, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x73, 0x22, 0x14, 0x0a, 0x12, 0x4c, 0x69,
+	0x73, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0x40, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x07, 0x72, 0x6f, 0x75, 0x74, 0x65,
+	0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72,
+	0x69, 0x73, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x52, 0x07, 0x72, 0x6f, 0x75, 0x74, 0x65,
+	0x72, 0x73, 0x22, 0x30, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x6f,
+	0x75, 0x74, 0x65, 0x72, 0x22, 0xdd, 0x01, 0x0a, 0x09, 0x52, 0x49, 0x42, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x76, 0x72, 0x66, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x76, 0x72, 0x66, 0x49, 0x64, 0x12, 0x34, 0x0a, 0x07, 0x61, 0x66, 0x69,
+	0x73, 0x61, 0x66, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x62, 0x69, 0x6f,
+	0x2e, 0x72, 0x69, 0x73, 0x2e, 0x52, 0x49, 0x42, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x2e, 0x41,
+	0x46, 0x49, 0x53, 0x41, 0x46, 0x49, 0x52, 0x07, 0x61, 0x66, 0x69, 0x73, 0x61, 0x66, 0x69, 0x12,
+	0x1f, 0x0a, 0x0b, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75,
+	0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6c, 0x61, 0x73,
+	0x74, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x2b, 0x0a, 0x07, 0x41, 0x46, 0x49, 0x53, 0x41,
+	0x46, 0x49, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x50, 0x76, 0x34, 0x55, 0x6e, 0x69, 0x63, 0x61, 0x73,
+	0x74, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x50, 0x76, 0x36, 0x55, 0x6e, 0x69, 0x63, 0x61,
+	0x73, 0x74, 0x10, 0x01, 0x22, 0x6a, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65,
+	0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x27, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72,
+	0x52, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x04, 0x72, 0x69, 0x62, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73,
+	0x2e, 0x52, 0x49, 0x42, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x04, 0x72, 0x69, 0x62, 0x73,
+	0x32, 0xb3, 0x04, 0x0a, 0x19, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x66, 0x6f,
+	0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x32,
+	0x0a, 0x03, 0x4c, 0x50, 0x4d, 0x12, 0x13, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e,
+	0x4c, 0x50, 0x4d, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x62, 0x69, 0x6f,
+	0x2e, 0x72, 0x69, 0x73, 0x2e, 0x4c, 0x50, 0x4d, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x12, 0x32, 0x0a, 0x03, 0x47, 0x65, 0x74, 0x12, 0x13, 0x2e, 0x62, 0x69, 0x6f, 0x2e,
+	0x72, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14,
+	0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x47,
+	0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f,
+	0x75, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
+	0x44, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x6e, 0x67, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x62,
+	0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x6e, 0x67, 0x65, 0x72,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69,
+	0x73, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x6f, 0x6e, 0x67, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x3e, 0x0a, 0x0a, 0x4f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x52, 0x49, 0x42, 0x12, 0x1a, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x4f, 0x62,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x49, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x52, 0x49, 0x42, 0x55, 0x70, 0x64,
+	0x61, 0x74, 0x65, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x07, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x49, 0x42,
+	0x12, 0x17, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x44, 0x75, 0x6d, 0x70, 0x52,
+	0x49, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x62, 0x69, 0x6f, 0x2e,
+	0x72, 0x69, 0x73, 0x2e, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x30, 0x01, 0x12, 0x4a, 0x0a, 0x0b, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72,
+	0x73, 0x12, 0x1b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c,
+	0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x56,
+	0x0a, 0x0f, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x52,
+	0x6f, 0x75, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x20, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x2b, 0x5a, 0x29, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x64, 0x2f, 0x63, 0x6d, 0x64, 0x2f, 0x72, 0x69, 0x73, 0x2f,
+	0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1089,58 +1472,72 @@ func file_cmd_ris_api_ris_proto_rawDescGZIP() []byte {
 	return file_cmd_ris_api_ris_proto_rawDescData
 }
 
-var file_cmd_ris_api_ris_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_cmd_ris_api_ris_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_cmd_ris_api_ris_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_cmd_ris_api_ris_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_cmd_ris_api_ris_proto_goTypes = []interface{}{
-	(ObserveRIBRequest_AFISAFI)(0), // 0: bio.ris.ObserveRIBRequest.AFISAFI
-	(DumpRIBRequest_AFISAFI)(0),    // 1: bio.ris.DumpRIBRequest.AFISAFI
-	(*LPMRequest)(nil),             // 2: bio.ris.LPMRequest
-	(*LPMResponse)(nil),            // 3: bio.ris.LPMResponse
-	(*GetRequest)(nil),             // 4: bio.ris.GetRequest
-	(*GetResponse)(nil),            // 5: bio.ris.GetResponse
-	(*GetLongerRequest)(nil),       // 6: bio.ris.GetLongerRequest
-	(*GetLongerResponse)(nil),      // 7: bio.ris.GetLongerResponse
-	(*ObserveRIBRequest)(nil),      // 8: bio.ris.ObserveRIBRequest
-	(*RIBFilter)(nil),              // 9: bio.ris.RIBFilter
-	(*RIBUpdate)(nil),              // 10: bio.ris.RIBUpdate
-	(*DumpRIBRequest)(nil),         // 11: bio.ris.DumpRIBRequest
-	(*DumpRIBReply)(nil),           // 12: bio.ris.DumpRIBReply
-	(*GetRoutersRequest)(nil),      // 13: bio.ris.GetRoutersRequest
-	(*Router)(nil),                 // 14: bio.ris.Router
-	(*GetRoutersResponse)(nil),     // 15: bio.ris.GetRoutersResponse
-	(*api.Prefix)(nil),             // 16: bio.net.Prefix
-	(*api1.Route)(nil),             // 17: bio.route.Route
+	(ObserveRIBRequest_AFISAFI)(0),  // 0: bio.ris.ObserveRIBRequest.AFISAFI
+	(DumpRIBRequest_AFISAFI)(0),     // 1: bio.ris.DumpRIBRequest.AFISAFI
+	(RIBStatus_AFISAFI)(0),          // 2: bio.ris.RIBStatus.AFISAFI
+	(*LPMRequest)(nil),              // 3: bio.ris.LPMRequest
+	(*LPMResponse)(nil),             // 4: bio.ris.LPMResponse
+	(*GetRequest)(nil),              // 5: bio.ris.GetRequest
+	(*GetResponse)(nil),             // 6: bio.ris.GetResponse
+	(*GetLongerRequest)(nil),        // 7: bio.ris.GetLongerRequest
+	(*GetLongerResponse)(nil),       // 8: bio.ris.GetLongerResponse
+	(*ObserveRIBRequest)(nil),       // 9: bio.ris.ObserveRIBRequest
+	(*RIBFilter)(nil),               // 10: bio.ris.RIBFilter
+	(*RIBUpdate)(nil),               // 11: bio.ris.RIBUpdate
+	(*DumpRIBRequest)(nil),          // 12: bio.ris.DumpRIBRequest
+	(*DumpRIBReply)(nil),            // 13: bio.ris.DumpRIBReply
+	(*GetRoutersRequest)(nil),       // 14: bio.ris.GetRoutersRequest
+	(*Router)(nil),                  // 15: bio.ris.Router
+	(*GetRoutersResponse)(nil),      // 16: bio.ris.GetRoutersResponse
+	(*ListRoutersRequest)(nil),      // 17: bio.ris.ListRoutersRequest
+	(*ListRoutersResponse)(nil),     // 18: bio.ris.ListRoutersResponse
+	(*GetRouterStatusRequest)(nil),  // 19: bio.ris.GetRouterStatusRequest
+	(*RIBStatus)(nil),               // 20: bio.ris.RIBStatus
+	(*GetRouterStatusResponse)(nil), // 21: bio.ris.GetRouterStatusResponse
+	(*api.Prefix)(nil),              // 22: bio.net.Prefix
+	(*api1.Route)(nil),              // 23: bio.route.Route
 }
 var file_cmd_ris_api_ris_proto_depIdxs = []int32{
-	16, // 0: bio.ris.LPMRequest.pfx:type_name -> bio.net.Prefix
-	17, // 1: bio.ris.LPMResponse.routes:type_name -> bio.route.Route
-	16, // 2: bio.ris.GetRequest.pfx:type_name -> bio.net.Prefix
-	17, // 3: bio.ris.GetResponse.routes:type_name -> bio.route.Route
-	16, // 4: bio.ris.GetLongerRequest.pfx:type_name -> bio.net.Prefix
-	17, // 5: bio.ris.GetLongerResponse.routes:type_name -> bio.route.Route
+	22, // 0: bio.ris.LPMRequest.pfx:type_name -> bio.net.Prefix
+	23, // 1: bio.ris.LPMResponse.routes:type_name -> bio.route.Route
+	22, // 2: bio.ris.GetRequest.pfx:type_name -> bio.net.Prefix
+	23, // 3: bio.ris.GetResponse.routes:type_name -> bio.route.Route
+	22, // 4: bio.ris.GetLongerRequest.pfx:type_name -> bio.net.Prefix
+	23, // 5: bio.ris.GetLongerResponse.routes:type_name -> bio.route.Route
 	0,  // 6: bio.ris.ObserveRIBRequest.afisafi:type_name -> bio.ris.ObserveRIBRequest.AFISAFI
-	17, // 7: bio.ris.RIBUpdate.route:type_name -> bio.route.Route
+	23, // 7: bio.ris.RIBUpdate.route:type_name -> bio.route.Route
 	1,  // 8: bio.ris.DumpRIBRequest.afisafi:type_name -> bio.ris.DumpRIBRequest.AFISAFI
-	9,  // 9: bio.ris.DumpRIBRequest.filter:type_name -> bio.ris.RIBFilter
-	17, // 10: bio.ris.DumpRIBReply.route:type_name -> bio.route.Route
-	14, // 11: bio.ris.GetRoutersResponse.routers:type_name -> bio.ris.Router
-	2,  // 12: bio.ris.RoutingInformationService.LPM:input_type -> bio.ris.LPMRequest
-	4,  // 13: bio.ris.RoutingInformationService.Get:input_type -> bio.ris.GetRequest
-	13, // 14: bio.ris.RoutingInformationService.GetRouters:input_type -> bio.ris.GetRoutersRequest
-	6,  // 15: bio.ris.RoutingInformationService.GetLonger:input_type -> bio.ris.GetLongerRequest
-	8,  // 16: bio.ris.RoutingInformationService.ObserveRIB:input_type -> bio.ris.ObserveRIBRequest
-	11, // 17: bio.ris.RoutingInformationService.DumpRIB:input_type -> bio.ris.DumpRIBRequest
-	3,  // 18: bio.ris.RoutingInformationService.LPM:output_type -> bio.ris.LPMResponse
-	5,  // 19: bio.ris.RoutingInformationService.Get:output_type -> bio.ris.GetResponse
-	15, // 20: bio.ris.RoutingInformationService.GetRouters:output_type -> bio.ris.GetRoutersResponse
-	7,  // 21: bio.ris.RoutingInformationService.GetLonger:output_type -> bio.ris.GetLongerResponse
-	10, // 22: bio.ris.RoutingInformationService.ObserveRIB:output_type -> bio.ris.RIBUpdate
-	12, // 23: bio.ris.RoutingInformationService.DumpRIB:output_type -> bio.ris.DumpRIBReply
-	18, // [18:24] is the sub-list for method output_type
-	12, // [12:18] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	10, // 9: bio.ris.DumpRIBRequest.filter:type_name -> bio.ris.RIBFilter
+	23, // 10: bio.ris.DumpRIBReply.route:type_name -> bio.route.Route
+	15, // 11: bio.ris.GetRoutersResponse.routers:type_name -> bio.ris.Router
+	15, // 12: bio.ris.ListRoutersResponse.routers:type_name -> bio.ris.Router
+	2,  // 13: bio.ris.RIBStatus.afisafi:type_name -> bio.ris.RIBStatus.AFISAFI
+	15, // 14: bio.ris.GetRouterStatusResponse.router:type_name -> bio.ris.Router
+	20, // 15: bio.ris.GetRouterStatusResponse.ribs:type_name -> bio.ris.RIBStatus
+	3,  // 16: bio.ris.RoutingInformationService.LPM:input_type -> bio.ris.LPMRequest
+	5,  // 17: bio.ris.RoutingInformationService.Get:input_type -> bio.ris.GetRequest
+	14, // 18: bio.ris.RoutingInformationService.GetRouters:input_type -> bio.ris.GetRoutersRequest
+	7,  // 19: bio.ris.RoutingInformationService.GetLonger:input_type -> bio.ris.GetLongerRequest
+	9,  // 20: bio.ris.RoutingInformationService.ObserveRIB:input_type -> bio.ris.ObserveRIBRequest
+	12, // 21: bio.ris.RoutingInformationService.DumpRIB:input_type -> bio.ris.DumpRIBRequest
+	17, // 22: bio.ris.RoutingInformationService.ListRouters:input_type -> bio.ris.ListRoutersRequest
+	19, // 23: bio.ris.RoutingInformationService.GetRouterStatus:input_type -> bio.ris.GetRouterStatusRequest
+	4,  // 24: bio.ris.RoutingInformationService.LPM:output_type -> bio.ris.LPMResponse
+	6,  // 25: bio.ris.RoutingInformationService.Get:output_type -> bio.ris.GetResponse
+	16, // 26: bio.ris.RoutingInformationService.GetRouters:output_type -> bio.ris.GetRoutersResponse
+	8,  // 27: bio.ris.RoutingInformationService.GetLonger:output_type -> bio.ris.GetLongerResponse
+	11, // 28: bio.ris.RoutingInformationService.ObserveRIB:output_type -> bio.ris.RIBUpdate
+	13, // 29: bio.ris.RoutingInformationService.DumpRIB:output_type -> bio.ris.DumpRIBReply
+	18, // 30: bio.ris.RoutingInformationService.ListRouters:output_type -> bio.ris.ListRoutersResponse
+	21, // 31: bio.ris.RoutingInformationService.GetRouterStatus:output_type -> bio.ris.GetRouterStatusResponse
+	24, // [24:32] is the sub-list for method output_type
+	16, // [16:24] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_cmd_ris_api_ris_proto_init() }
@@ -1317,14 +1714,74 @@ func file_cmd_ris_api_ris_proto_init() {
 				return nil
 			}
 		}
+		file_cmd_ris_api_ris_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRoutersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cmd_ris_api_ris_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRoutersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cmd_ris_api_ris_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRouterStatusRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cmd_ris_api_ris_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RIBStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_cmd_ris_api_ris_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetRouterStatusResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_cmd_ris_api_ris_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   14,
+			NumEnums:      3,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -24,6 +24,8 @@ type RoutingInformationServiceClient interface {
 	GetLonger(ctx context.Context, in *GetLongerRequest, opts ...grpc.CallOption) (*GetLongerResponse, error)
 	ObserveRIB(ctx context.Context, in *ObserveRIBRequest, opts ...grpc.CallOption) (RoutingInformationService_ObserveRIBClient, error)
 	DumpRIB(ctx context.Context, in *DumpRIBRequest, opts ...grpc.CallOption) (RoutingInformationService_DumpRIBClient, error)
+	ListRouters(ctx context.Context, in *ListRoutersRequest, opts ...grpc.CallOption) (*ListRoutersResponse, error)
+	GetRouterStatus(ctx context.Context, in *GetRouterStatusRequest, opts ...grpc.CallOption) (*GetRouterStatusResponse, error)
 }
 
 type routingInformationServiceClient struct {
@@ -134,6 +136,24 @@ func (x *routingInformationServiceDumpRIBClient) Recv() (*DumpRIBReply, error) {
 	return m, nil
 }
 
+func (c *routingInformationServiceClient) ListRouters(ctx context.Context, in *ListRoutersRequest, opts ...grpc.CallOption) (*ListRoutersResponse, error) {
+	out := new(ListRoutersResponse)
+	err := c.cc.Invoke(ctx, "/bio.ris.RoutingInformationService/ListRouters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routingInformationServiceClient) GetRouterStatus(ctx context.Context, in *GetRouterStatusRequest, opts ...grpc.CallOption) (*GetRouterStatusResponse, error) {
+	out := new(GetRouterStatusResponse)
+	err := c.cc.Invoke(ctx, "/bio.ris.RoutingInformationService/GetRouterStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RoutingInformationServiceServer is the server API for RoutingInformationService service.
 // All implementations must embed UnimplementedRoutingInformationServiceServer
 // for forward compatibility
@@ -144,6 +164,8 @@ type RoutingInformationServiceServer interface {
 	GetLonger(context.Context, *GetLongerRequest) (*GetLongerResponse, error)
 	ObserveRIB(*ObserveRIBRequest, RoutingInformationService_ObserveRIBServer) error
 	DumpRIB(*DumpRIBRequest, RoutingInformationService_DumpRIBServer) error
+	ListRouters(context.Context, *ListRoutersRequest) (*ListRoutersResponse, error)
+	GetRouterStatus(context.Context, *GetRouterStatusRequest) (*GetRouterStatusResponse, error)
 	mustEmbedUnimplementedRoutingInformationServiceServer()
 }
 
@@ -169,6 +191,12 @@ func (UnimplementedRoutingInformationServiceServer) ObserveRIB(*ObserveRIBReques
 func (UnimplementedRoutingInformationServiceServer) DumpRIB(*DumpRIBRequest, RoutingInformationService_DumpRIBServer) error {
 	return status.Errorf(codes.Unimplemented, "method DumpRIB not implemented")
 }
+func (UnimplementedRoutingInformationServiceServer) ListRouters(context.Context, *ListRoutersRequest) (*ListRoutersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRouters not implemented")
+}
+func (UnimplementedRoutingInformationServiceServer) GetRouterStatus(context.Context, *GetRouterStatusRequest) (*GetRouterStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRouterStatus not implemented")
+}
 func (UnimplementedRoutingInformationServiceServer) mustEmbedUnimplementedRoutingInformationServiceServer() {
 }
 
@@ -297,6 +325,42 @@ func (x *routingInformationServiceDumpRIBServer) Send(m *DumpRIBReply) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _RoutingInformationService_ListRouters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoutersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingInformationServiceServer).ListRouters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bio.ris.RoutingInformationService/ListRouters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingInformationServiceServer).ListRouters(ctx, req.(*ListRoutersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutingInformationService_GetRouterStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRouterStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutingInformationServiceServer).GetRouterStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bio.ris.RoutingInformationService/GetRouterStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutingInformationServiceServer).GetRouterStatus(ctx, req.(*GetRouterStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RoutingInformationService_ServiceDesc is the grpc.ServiceDesc for RoutingInformationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -320,6 +384,14 @@ var RoutingInformationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLonger",
 			Handler:    _RoutingInformationService_GetLonger_Handler,
 		},
+		{
+			MethodName: "ListRouters",
+			Handler:    _RoutingInformationService_ListRouters_Handler,
+		},
+		{
+			MethodName: "GetRouterStatus",
+			Handler:    _RoutingInformationService_GetRouterStatus_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
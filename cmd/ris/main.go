@@ -9,6 +9,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/bio-routing/bio-rd/cmd/ris/config"
+	"github.com/bio-routing/bio-rd/cmd/ris/persistence"
 	"github.com/bio-routing/bio-rd/cmd/ris/risserver"
 	"github.com/bio-routing/bio-rd/protocols/bgp/server"
 	"github.com/bio-routing/bio-rd/util/log"
@@ -29,6 +30,10 @@ var (
 	configFilePath       = flag.String("config.file", "", "Configuration file")
 	tcpKeepaliveInterval = flag.Uint("tcp-keepalive-interval", 1, "TCP keepalive interval (seconds)")
 	allowAny             = flag.Bool("allow.any", false, "Allow BMP sessions from anywhere")
+
+	persistenceDir          = flag.String("persistence.dir", "", "Directory to persist the Loc-RIBs of configured routers to (set empty to disable persistence)")
+	persistenceInterval     = flag.Duration("persistence.interval", 5*time.Minute, "Interval between two Loc-RIB snapshots")
+	persistenceStaleTimeout = flag.Duration("persistence.stale_timeout", 15*time.Minute, "Time after which restored paths not refreshed by the router are removed")
 )
 
 func main() {
@@ -80,6 +85,21 @@ func main() {
 		b.AddRouter(ip, r.Port, r.Passive, false)
 	}
 
+	if *persistenceDir != "" {
+		p := persistence.New(persistence.Config{
+			Dir:          *persistenceDir,
+			Interval:     *persistenceInterval,
+			StaleTimeout: *persistenceStaleTimeout,
+		}, b)
+
+		if err := p.Load(); err != nil {
+			log.WithError(err).Error("Failed to restore Loc-RIBs")
+		}
+
+		p.Start()
+		defer p.Stop()
+	}
+
 	s := risserver.NewServer(b)
 	unaryInterceptors := []grpc.UnaryServerInterceptor{}
 	streamInterceptors := []grpc.StreamServerInterceptor{}
@@ -0,0 +1,438 @@
+package persistence
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/server"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+	"github.com/bio-routing/bio-rd/util/log"
+	"google.golang.org/protobuf/proto"
+
+	routeapi "github.com/bio-routing/bio-rd/route/api"
+)
+
+const (
+	fileSuffix = ".rib"
+
+	// maxRecordLen limits the size of a single serialized route to detect corrupted files early
+	maxRecordLen = 64 << 20
+
+	staleCheckInterval = 10 * time.Second
+	staleEventsBuffer  = 4096
+)
+
+// Config configures the persistence of the Loc-RIBs
+type Config struct {
+	// Dir is the directory snapshots are written to and restored from. There is one file per router, VRF and AFI/SAFI.
+	Dir string
+
+	// Interval is the time between two snapshots
+	Interval time.Duration
+
+	// StaleTimeout is the time after which restored paths not confirmed by the router are removed
+	StaleTimeout time.Duration
+}
+
+// Store periodically writes the Loc-RIBs of all routers of a BMP receiver to disk and restores them on startup so that
+// routes are available immediately while the BMP sessions re-establish. Restored paths are marked stale until they
+// are refreshed by the router, all of them are removed once the VRF is ready or the stale timeout expired.
+type Store struct {
+	cfg           Config
+	bmp           server.BMPReceiverInterface
+	checkInterval time.Duration
+	stop          chan struct{}
+	stopOnce      sync.Once
+	wg            sync.WaitGroup
+}
+
+// New creates a new Store
+func New(cfg Config, bmp server.BMPReceiverInterface) *Store {
+	return &Store{
+		cfg:           cfg,
+		bmp:           bmp,
+		checkInterval: staleCheckInterval,
+		stop:          make(chan struct{}),
+	}
+}
+
+type ribID struct {
+	router string
+	rd     uint64
+	afi    uint8
+}
+
+func (id ribID) fileName() string {
+	return fmt.Sprintf("%s_%d_ipv%d%s", id.router, id.rd, id.afi, fileSuffix)
+}
+
+func ribIDFromFileName(name string) (ribID, error) {
+	parts := strings.Split(strings.TrimSuffix(name, fileSuffix), "_")
+	if len(parts) != 3 {
+		return ribID{}, fmt.Errorf("invalid file name %q", name)
+	}
+
+	rd, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return ribID{}, fmt.Errorf("invalid route distinguisher in file name %q: %w", name, err)
+	}
+
+	switch parts[2] {
+	case "ipv4":
+		return ribID{router: parts[0], rd: rd, afi: 4}, nil
+	case "ipv6":
+		return ribID{router: parts[0], rd: rd, afi: 6}, nil
+	}
+
+	return ribID{}, fmt.Errorf("invalid address family in file name %q", name)
+}
+
+func vrfRIB(v *vrf.VRF, afi uint8) *locRIB.LocRIB {
+	if afi == 6 {
+		return v.IPv6UnicastRIB()
+	}
+
+	return v.IPv4UnicastRIB()
+}
+
+// Start writes snapshots every Interval until Stop is called
+func (s *Store) Start() {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		t := time.NewTicker(s.cfg.Interval)
+		defer t.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-t.C:
+				err := s.Save()
+				if err != nil {
+					log.WithError(err).Error("Unable to persist Loc-RIBs")
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops writing snapshots and expiring restored paths
+func (s *Store) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+	})
+
+	s.wg.Wait()
+}
+
+// Save writes a snapshot of the Loc-RIBs of all routers. Files of Loc-RIBs not existing anymore are removed.
+func (s *Store) Save() error {
+	written := make(map[string]struct{})
+	for _, r := range s.bmp.GetRouters() {
+		for _, v := range r.GetVRFs() {
+			for _, afi := range []uint8{4, 6} {
+				rib := vrfRIB(v, afi)
+				if rib == nil {
+					continue
+				}
+
+				id := ribID{
+					router: r.Address().String(),
+					rd:     v.RD(),
+					afi:    afi,
+				}
+
+				err := s.saveRIB(id.fileName(), rib)
+				if err != nil {
+					return fmt.Errorf("unable to write %s: %w", id.fileName(), err)
+				}
+
+				written[id.fileName()] = struct{}{}
+			}
+		}
+	}
+
+	files, err := s.files()
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if _, ok := written[f]; ok {
+			continue
+		}
+
+		err := os.Remove(filepath.Join(s.cfg.Dir, f))
+		if err != nil {
+			return fmt.Errorf("unable to remove outdated snapshot %s: %w", f, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) saveRIB(name string, rib *locRIB.LocRIB) error {
+	f, err := os.CreateTemp(s.cfg.Dir, name+".tmp")
+	if err != nil {
+		return fmt.Errorf("unable to create temporary file: %w", err)
+	}
+	defer os.Remove(f.Name())
+
+	w := bufio.NewWriter(f)
+	err = writeRIB(w, rib)
+	if err == nil {
+		err = w.Flush()
+	}
+
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return fmt.Errorf("unable to close temporary file: %w", err)
+	}
+
+	return os.Rename(f.Name(), filepath.Join(s.cfg.Dir, name))
+}
+
+func (s *Store) files() ([]string, error) {
+	entries, err := os.ReadDir(s.cfg.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read directory %s: %w", s.cfg.Dir, err)
+	}
+
+	ret := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), fileSuffix) {
+			continue
+		}
+
+		ret = append(ret, e.Name())
+	}
+
+	return ret, nil
+}
+
+// Load restores the snapshots of all routers known to the BMP receiver. Restored paths are marked stale.
+func (s *Store) Load() error {
+	files, err := s.files()
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		id, err := ribIDFromFileName(f)
+		if err != nil {
+			log.WithError(err).Error("Skipping Loc-RIB snapshot")
+			continue
+		}
+
+		r := s.bmp.GetRouter(id.router)
+		if r == nil {
+			log.Infof("Skipping Loc-RIB snapshot %s of unknown router", f)
+			continue
+		}
+
+		rib := vrfRIB(r.CreateVRFIfNotExists(id.rd), id.afi)
+		n, err := s.loadRIB(f, rib)
+		if err != nil {
+			return fmt.Errorf("unable to restore %s: %w", f, err)
+		}
+
+		log.Infof("Restored %d paths of %s/%s/IPv%d", n, id.router, vrf.RouteDistinguisherHumanReadable(id.rd), id.afi)
+
+		s.wg.Add(1)
+		go s.expireStalePaths(r, id, rib, rib.Subscribe(staleEventsBuffer))
+	}
+
+	return nil
+}
+
+func (s *Store) loadRIB(name string, rib *locRIB.LocRIB) (int, error) {
+	f, err := os.Open(filepath.Join(s.cfg.Dir, name))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	return readRIB(bufio.NewReader(f), rib)
+}
+
+// expireStalePaths removes restored paths once the router sent them again, all remaining ones are removed when all
+// neighbors of the VRF sent their End-of-RIB markers or the stale timeout expired
+func (s *Store) expireStalePaths(r server.RouterInterface, id ribID, rib *locRIB.LocRIB, sub *locRIB.Subscription) {
+	defer s.wg.Done()
+	defer func() {
+		rib.Unsubscribe(sub)
+	}()
+
+	// Routes might have been refreshed before we subscribed
+	removeAllRefreshedStalePaths(rib)
+
+	timeout := time.NewTimer(s.cfg.StaleTimeout)
+	defer timeout.Stop()
+
+	check := time.NewTicker(s.checkInterval)
+	defer check.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case e, ok := <-sub.Events():
+			if !ok {
+				// We fell behind and lost events, resync
+				sub = rib.Subscribe(staleEventsBuffer)
+				removeAllRefreshedStalePaths(rib)
+				continue
+			}
+
+			removeRefreshedStalePaths(rib, e.Route)
+			continue
+		case <-check.C:
+			if !r.Ready(id.rd, uint16(id.afi)) {
+				continue
+			}
+		case <-timeout.C:
+		}
+
+		n := removeStalePaths(rib)
+		if n > 0 {
+			log.Infof("Removed %d stale paths of %s/%s/IPv%d", n, id.router, vrf.RouteDistinguisherHumanReadable(id.rd), id.afi)
+		}
+
+		return
+	}
+}
+
+func removeAllRefreshedStalePaths(rib *locRIB.LocRIB) {
+	for _, rt := range rib.Snapshot() {
+		removeRefreshedStalePaths(rib, rt)
+	}
+}
+
+// removeRefreshedStalePaths removes stale paths of the route the source sent a path for again
+func removeRefreshedStalePaths(rib *locRIB.LocRIB, rt *route.Route) {
+	paths := rt.Paths()
+	for _, p := range paths {
+		if !p.Stale {
+			continue
+		}
+
+		for _, q := range paths {
+			if !q.Stale && sameSource(p, q) {
+				rib.RemovePath(rt.Prefix(), p)
+				break
+			}
+		}
+	}
+}
+
+func sameSource(p *route.Path, q *route.Path) bool {
+	if p.Type != route.BGPPathType || q.Type != route.BGPPathType {
+		return false
+	}
+
+	if p.BGPPath.PathIdentifier != q.BGPPath.PathIdentifier {
+		return false
+	}
+
+	a, b := p.BGPPath.BGPPathA.Source, q.BGPPath.BGPPathA.Source
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Compare(b) == 0
+}
+
+func removeStalePaths(rib *locRIB.LocRIB) int {
+	n := 0
+	for _, rt := range rib.Snapshot() {
+		for _, p := range rt.Paths() {
+			if p.Stale {
+				rib.RemovePath(rt.Prefix(), p)
+				n++
+			}
+		}
+	}
+
+	return n
+}
+
+// writeRIB writes all routes of rib as length prefixed protobuf messages
+func writeRIB(w io.Writer, rib *locRIB.LocRIB) error {
+	lenBuf := make([]byte, binary.MaxVarintLen64)
+	for _, rt := range rib.Snapshot() {
+		b, err := proto.Marshal(rt.ToProto())
+		if err != nil {
+			return fmt.Errorf("unable to marshal route %s: %w", rt.Prefix(), err)
+		}
+
+		n := binary.PutUvarint(lenBuf, uint64(len(b)))
+		_, err = w.Write(lenBuf[:n])
+		if err != nil {
+			return err
+		}
+
+		_, err = w.Write(b)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readRIB adds all routes written by writeRIB to rib with their paths marked stale and returns the number of paths.
+// Nothing is added if the snapshot is malformed.
+func readRIB(r *bufio.Reader, rib *locRIB.LocRIB) (int, error) {
+	batch := locRIB.NewBatch(0)
+	for {
+		l, err := binary.ReadUvarint(r)
+		if err == io.EOF {
+			rib.Apply(batch)
+			return batch.Len(), nil
+		}
+
+		if err != nil {
+			return 0, fmt.Errorf("unable to read record length: %w", err)
+		}
+
+		if l > maxRecordLen {
+			return 0, fmt.Errorf("record length %d exceeds limit of %d bytes", l, maxRecordLen)
+		}
+
+		b := make([]byte, l)
+		_, err = io.ReadFull(r, b)
+		if err != nil {
+			return 0, fmt.Errorf("unable to read record: %w", err)
+		}
+
+		ar := &routeapi.Route{}
+		err = proto.Unmarshal(b, ar)
+		if err != nil {
+			return 0, fmt.Errorf("unable to unmarshal route: %w", err)
+		}
+
+		rt := route.RouteFromProtoRoute(ar, true)
+		for i, p := range rt.Paths() {
+			p.Stale = true
+			p.LTime = ar.Paths[i].TimeLearned
+			batch.AddPath(rt.Prefix(), p)
+		}
+	}
+}
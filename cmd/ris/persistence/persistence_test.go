@@ -0,0 +1,216 @@
+package persistence
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/server"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockBMPReceiver struct {
+	routers []server.RouterInterface
+}
+
+func (m *mockBMPReceiver) GetRouter(name string) server.RouterInterface {
+	for _, r := range m.routers {
+		if r.Address().String() == name {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func (m *mockBMPReceiver) GetRouters() []server.RouterInterface {
+	return m.routers
+}
+
+type mockRouter struct {
+	address     net.IP
+	vrfRegistry *vrf.VRFRegistry
+	ready       atomic.Bool
+}
+
+func newMockRouter(address net.IP) *mockRouter {
+	return &mockRouter{
+		address:     address,
+		vrfRegistry: vrf.NewVRFRegistry(),
+	}
+}
+
+func (m *mockRouter) Name() string {
+	return m.address.String()
+}
+
+func (m *mockRouter) Address() net.IP {
+	return m.address
+}
+
+func (m *mockRouter) GetVRF(rd uint64) *vrf.VRF {
+	return m.vrfRegistry.GetVRFByRD(rd)
+}
+
+func (m *mockRouter) GetVRFs() []*vrf.VRF {
+	return m.vrfRegistry.List()
+}
+
+func (m *mockRouter) CreateVRFIfNotExists(rd uint64) *vrf.VRF {
+	return m.vrfRegistry.CreateVRFIfNotExists("test", rd)
+}
+
+func (m *mockRouter) Ready(vrf uint64, afi uint16) bool {
+	return m.ready.Load()
+}
+
+func bgpPath(source uint32, localPref uint32) *route.Path {
+	return &route.Path{
+		Type:  route.BGPPathType,
+		LTime: 1000,
+		BGPPath: &route.BGPPath{
+			BGPPathA: &route.BGPPathA{
+				NextHop:   bnet.IPv4(source).Ptr(),
+				Source:    bnet.IPv4(source).Ptr(),
+				LocalPref: localPref,
+			},
+			ASPath: &types.ASPath{
+				{
+					Type: types.ASSequence,
+					ASNs: []uint32{65001, 65002},
+				},
+			},
+		},
+	}
+}
+
+func stalePath(p *route.Path) *route.Path {
+	p.Stale = true
+	return p
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	pfx4 := bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()
+	pfx6 := bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32).Ptr()
+
+	r := newMockRouter(net.IPv4(10, 0, 0, 1))
+	v := r.CreateVRFIfNotExists(100)
+	v.IPv4UnicastRIB().AddPath(pfx4, bgpPath(1, 100))
+	v.IPv4UnicastRIB().AddPath(pfx4, bgpPath(2, 200))
+	v.IPv6UnicastRIB().AddPath(pfx6, bgpPath(3, 100))
+
+	s := New(Config{Dir: dir}, &mockBMPReceiver{routers: []server.RouterInterface{r}})
+	assert.NoError(t, s.Save())
+
+	files, err := s.files()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"10.0.0.1_100_ipv4.rib", "10.0.0.1_100_ipv6.rib"}, files)
+
+	restored := newMockRouter(net.IPv4(10, 0, 0, 1))
+	s = New(Config{Dir: dir, StaleTimeout: time.Hour}, &mockBMPReceiver{routers: []server.RouterInterface{restored}})
+	assert.NoError(t, s.Load())
+	defer s.Stop()
+
+	v = restored.GetVRF(100)
+	if !assert.NotNil(t, v) {
+		return
+	}
+
+	assert.Equal(t, []*route.Path{stalePath(bgpPath(2, 200)), stalePath(bgpPath(1, 100))}, v.IPv4UnicastRIB().Get(pfx4).Paths())
+	assert.Equal(t, []*route.Path{stalePath(bgpPath(3, 100))}, v.IPv6UnicastRIB().Get(pfx6).Paths())
+}
+
+func TestSaveRemovesOutdatedSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	outdated := filepath.Join(dir, "10.0.0.2_0_ipv4.rib")
+	assert.NoError(t, os.WriteFile(outdated, nil, 0644))
+
+	r := newMockRouter(net.IPv4(10, 0, 0, 1))
+	r.CreateVRFIfNotExists(0)
+
+	s := New(Config{Dir: dir}, &mockBMPReceiver{routers: []server.RouterInterface{r}})
+	assert.NoError(t, s.Save())
+
+	files, err := s.files()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"10.0.0.1_0_ipv4.rib", "10.0.0.1_0_ipv6.rib"}, files)
+}
+
+func TestLoadMalformed(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "10.0.0.1_0_ipv4.rib"), []byte{10, 1, 2}, 0644))
+
+	r := newMockRouter(net.IPv4(10, 0, 0, 1))
+	s := New(Config{Dir: dir}, &mockBMPReceiver{routers: []server.RouterInterface{r}})
+	assert.Error(t, s.Load())
+}
+
+func TestStalePaths(t *testing.T) {
+	tests := []struct {
+		name         string
+		ready        bool
+		staleTimeout time.Duration
+		expected     []*route.Path
+	}{
+		{
+			name:         "Refreshed path",
+			staleTimeout: time.Hour,
+			expected:     []*route.Path{bgpPath(1, 300), stalePath(bgpPath(2, 200))},
+		},
+		{
+			name:         "VRF ready",
+			ready:        true,
+			staleTimeout: time.Hour,
+			expected:     []*route.Path{bgpPath(1, 300)},
+		},
+		{
+			name:         "Stale timeout",
+			staleTimeout: 100 * time.Millisecond,
+			expected:     []*route.Path{bgpPath(1, 300)},
+		},
+	}
+
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			r := newMockRouter(net.IPv4(10, 0, 0, 1))
+			v := r.CreateVRFIfNotExists(0)
+			v.IPv4UnicastRIB().AddPath(pfx, bgpPath(1, 100))
+			v.IPv4UnicastRIB().AddPath(pfx, bgpPath(2, 200))
+
+			s := New(Config{Dir: dir}, &mockBMPReceiver{routers: []server.RouterInterface{r}})
+			assert.NoError(t, s.Save())
+
+			restored := newMockRouter(net.IPv4(10, 0, 0, 1))
+			s = New(Config{Dir: dir, StaleTimeout: test.staleTimeout}, &mockBMPReceiver{routers: []server.RouterInterface{restored}})
+			s.checkInterval = 10 * time.Millisecond
+			assert.NoError(t, s.Load())
+			defer s.Stop()
+
+			// The router sends the path of source 1 again with changed attributes
+			rib := restored.GetVRF(0).IPv4UnicastRIB()
+			rib.AddPath(pfx, bgpPath(1, 300))
+			restored.ready.Store(test.ready)
+
+			// Get returns the route of the RIB itself which is modified concurrently
+			paths := func() []*route.Path {
+				return rib.Snapshot()[0].Paths()
+			}
+
+			assert.Eventually(t, func() bool {
+				return assert.ObjectsAreEqual(test.expected, paths())
+			}, time.Second, 10*time.Millisecond)
+			assert.Equal(t, test.expected, paths())
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package risserver
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+// dumpCursor encodes the position after pfx in a RIB dump
+func dumpCursor(pfx *bnet.Prefix) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(pfx.String()))
+}
+
+func parseDumpCursor(cursor string) (*bnet.Prefix, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode cursor: %w", err)
+	}
+
+	pfx, err := bnet.PrefixFromString(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse cursor: %w", err)
+	}
+
+	return pfx, nil
+}
+
+// dumpedAfter returns true if pfx is dumped after cursor. RIBs are dumped in trie order which is ascending by address
+// with less specific prefixes preceding more specific prefixes of the same address.
+func dumpedAfter(pfx *bnet.Prefix, cursor *bnet.Prefix) bool {
+	addr := pfx.Addr()
+	cursorAddr := cursor.Addr()
+	switch addr.Compare(&cursorAddr) {
+	case 1:
+		return true
+	case -1:
+		return false
+	}
+
+	return pfx.Len() > cursor.Len()
+}
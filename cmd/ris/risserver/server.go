@@ -3,6 +3,7 @@ package risserver
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/bio-routing/bio-rd/protocols/bgp/server"
 	"github.com/bio-routing/bio-rd/route"
@@ -263,17 +264,37 @@ func (s *Server) DumpRIB(req *pb.DumpRIBRequest, stream pb.RoutingInformationSer
 		},
 	}
 
-	routes := rib.Dump()
+	var cursor *bnet.Prefix
+	if req.Cursor != "" {
+		cursor, err = parseDumpCursor(req.Cursor)
+		if err != nil {
+			return status.New(codes.InvalidArgument, err.Error()).Err()
+		}
+	}
+
+	sent := uint32(0)
+	routes := rib.Snapshot()
 	for i := range routes {
+		if cursor != nil && !dumpedAfter(routes[i].Prefix(), cursor) {
+			continue
+		}
+
 		if !s.filterRIB(req.GetFilter(), routes[i]) {
 			continue
 		}
+
+		if req.PageSize != 0 && sent == req.PageSize {
+			return nil
+		}
+
 		toSend.Route = routes[i].ToProto()
+		toSend.Cursor = dumpCursor(routes[i].Prefix())
 
 		err = stream.Send(toSend)
 		if err != nil {
 			return err
 		}
+		sent++
 	}
 
 	return nil
@@ -317,6 +338,99 @@ func (s *Server) GetRouters(c context.Context, request *pb.GetRoutersRequest) (*
 	return resp, nil
 }
 
+// ListRouters implements the ListRouters RPC. Routers are ordered by name and address.
+func (s *Server) ListRouters(c context.Context, request *pb.ListRoutersRequest) (*pb.ListRoutersResponse, error) {
+	return &pb.ListRoutersResponse{
+		Routers: s.routers(),
+	}, nil
+}
+
+// GetRouterStatus implements the GetRouterStatus RPC
+func (s *Server) GetRouterStatus(c context.Context, request *pb.GetRouterStatusRequest) (*pb.GetRouterStatusResponse, error) {
+	r := s.bmp.GetRouter(request.Router)
+	if r == nil {
+		return nil, status.New(codes.NotFound, fmt.Sprintf("unable to get router %q", request.Router)).Err()
+	}
+
+	resp := &pb.GetRouterStatusResponse{
+		Router: routerToProto(r),
+	}
+
+	for _, v := range sortedVRFs(r) {
+		ribs := []struct {
+			afisafi   pb.RIBStatus_AFISAFI
+			ipVersion uint16
+			rib       *locRIB.LocRIB
+		}{
+			{pb.RIBStatus_IPv4Unicast, 4, v.IPv4UnicastRIB()},
+			{pb.RIBStatus_IPv6Unicast, 6, v.IPv6UnicastRIB()},
+		}
+
+		for _, x := range ribs {
+			if x.rib == nil {
+				continue
+			}
+
+			lastUpdate := uint64(0)
+			if t := x.rib.LastUpdate(); !t.IsZero() {
+				lastUpdate = uint64(t.Unix())
+			}
+
+			resp.Ribs = append(resp.Ribs, &pb.RIBStatus{
+				VrfId:      v.RD(),
+				Afisafi:    x.afisafi,
+				RouteCount: x.rib.Count(),
+				Ready:      r.Ready(v.RD(), x.ipVersion),
+				LastUpdate: lastUpdate,
+			})
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *Server) routers() []*pb.Router {
+	routers := s.bmp.GetRouters()
+	ret := make([]*pb.Router, 0, len(routers))
+	for _, r := range routers {
+		ret = append(ret, routerToProto(r))
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].SysName != ret[j].SysName {
+			return ret[i].SysName < ret[j].SysName
+		}
+
+		return ret[i].Address < ret[j].Address
+	})
+
+	return ret
+}
+
+func routerToProto(r server.RouterInterface) *pb.Router {
+	vrfs := sortedVRFs(r)
+	vrfIDs := make([]uint64, 0, len(vrfs))
+	for _, v := range vrfs {
+		vrfIDs = append(vrfIDs, v.RD())
+	}
+
+	return &pb.Router{
+		SysName: r.Name(),
+		VrfIds:  vrfIDs,
+		Address: r.Address().String(),
+	}
+}
+
+// sortedVRFs returns the VRFs of r ordered by route distinguisher
+func sortedVRFs(r server.RouterInterface) []*vrf.VRF {
+	vrfs := r.GetVRFs()
+	sort.Slice(vrfs, func(i, j int) bool {
+		return vrfs[i].RD() < vrfs[j].RD()
+	})
+
+	return vrfs
+}
+
 type RequestWithVRF interface {
 	GetVrfId() uint64
 	GetVrf() string
@@ -0,0 +1,314 @@
+package risserver
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	pb "github.com/bio-routing/bio-rd/cmd/ris/api"
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/server"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockBMPReceiver struct {
+	routers []server.RouterInterface
+}
+
+func (m *mockBMPReceiver) GetRouter(rtr string) server.RouterInterface {
+	for _, r := range m.routers {
+		if r.Address().String() == rtr {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func (m *mockBMPReceiver) GetRouters() []server.RouterInterface {
+	return m.routers
+}
+
+type mockRouter struct {
+	name    string
+	address net.IP
+	vrfs    []*vrf.VRF
+	ready   map[uint16]bool
+}
+
+func (m *mockRouter) Name() string {
+	return m.name
+}
+
+func (m *mockRouter) Address() net.IP {
+	return m.address
+}
+
+func (m *mockRouter) GetVRF(vrfID uint64) *vrf.VRF {
+	for _, v := range m.vrfs {
+		if v.RD() == vrfID {
+			return v
+		}
+	}
+
+	return nil
+}
+
+func (m *mockRouter) GetVRFs() []*vrf.VRF {
+	return m.vrfs
+}
+
+func (m *mockRouter) CreateVRFIfNotExists(rd uint64) *vrf.VRF {
+	return m.GetVRF(rd)
+}
+
+func (m *mockRouter) Ready(vrf uint64, afi uint16) bool {
+	return m.ready[afi]
+}
+
+func TestGetRouters(t *testing.T) {
+	v, err := vrf.New("risserver-test", 100)
+	assert.NoError(t, err)
+	defer v.Unregister()
+
+	s := NewServer(&mockBMPReceiver{
+		routers: []server.RouterInterface{
+			&mockRouter{name: "core02", address: net.IPv4(10, 0, 0, 2)},
+			&mockRouter{name: "core01", address: net.IPv4(10, 0, 0, 3), vrfs: []*vrf.VRF{v}},
+			&mockRouter{name: "core01", address: net.IPv4(10, 0, 0, 1)},
+		},
+	})
+
+	resp, err := s.GetRouters(context.Background(), &pb.GetRoutersRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.Router{
+		{
+			SysName: "core02",
+			VrfIds:  []uint64{},
+			Address: "10.0.0.2",
+		},
+		{
+			SysName: "core01",
+			VrfIds:  []uint64{100},
+			Address: "10.0.0.3",
+		},
+		{
+			SysName: "core01",
+			VrfIds:  []uint64{},
+			Address: "10.0.0.1",
+		},
+	}, resp.Routers)
+}
+
+func TestGetRoutersEmpty(t *testing.T) {
+	s := NewServer(&mockBMPReceiver{})
+
+	resp, err := s.GetRouters(context.Background(), &pb.GetRoutersRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Routers)
+}
+
+func seededReceiver(t *testing.T) (*mockBMPReceiver, func()) {
+	v1, err := vrf.New("risserver-status-1", 0)
+	assert.NoError(t, err)
+	v2, err := vrf.New("risserver-status-2", 200)
+	assert.NoError(t, err)
+
+	p := &route.Path{
+		Type: route.StaticPathType,
+		StaticPath: &route.StaticPath{
+			NextHop: bnet.IPv4FromOctets(192, 168, 0, 1).Ptr(),
+		},
+	}
+	v1.IPv4UnicastRIB().AddPath(bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), p)
+	v1.IPv4UnicastRIB().AddPath(bnet.NewPfx(bnet.IPv4FromOctets(10, 1, 0, 0), 16).Ptr(), p)
+	v2.IPv6UnicastRIB().AddPath(bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32).Ptr(), p)
+
+	b := &mockBMPReceiver{
+		routers: []server.RouterInterface{
+			&mockRouter{
+				name:    "core02",
+				address: net.IPv4(10, 0, 0, 2),
+				ready:   map[uint16]bool{4: true},
+			},
+			&mockRouter{
+				name:    "core01",
+				address: net.IPv4(10, 0, 0, 1),
+				vrfs:    []*vrf.VRF{v2, v1},
+				ready:   map[uint16]bool{4: true},
+			},
+		},
+	}
+
+	return b, func() {
+		v1.Unregister()
+		v2.Unregister()
+	}
+}
+
+func TestListRouters(t *testing.T) {
+	b, cleanup := seededReceiver(t)
+	defer cleanup()
+
+	resp, err := NewServer(b).ListRouters(context.Background(), &pb.ListRoutersRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []*pb.Router{
+		{
+			SysName: "core01",
+			VrfIds:  []uint64{0, 200},
+			Address: "10.0.0.1",
+		},
+		{
+			SysName: "core02",
+			VrfIds:  []uint64{},
+			Address: "10.0.0.2",
+		},
+	}, resp.Routers)
+}
+
+func TestGetRouterStatus(t *testing.T) {
+	b, cleanup := seededReceiver(t)
+	defer cleanup()
+
+	before := uint64(time.Now().Unix())
+	s := NewServer(b)
+
+	resp, err := s.GetRouterStatus(context.Background(), &pb.GetRouterStatusRequest{
+		Router: "10.0.0.1",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &pb.Router{
+		SysName: "core01",
+		VrfIds:  []uint64{0, 200},
+		Address: "10.0.0.1",
+	}, resp.Router)
+
+	// LastUpdate depends on the wall clock. Check and reset it before comparing the other fields.
+	for _, rib := range resp.Ribs {
+		if rib.RouteCount == 0 {
+			assert.Equal(t, uint64(0), rib.LastUpdate, "unchanged RIB")
+			continue
+		}
+
+		assert.GreaterOrEqual(t, rib.LastUpdate+1, before)
+		rib.LastUpdate = 0
+	}
+
+	assert.Equal(t, []*pb.RIBStatus{
+		{VrfId: 0, Afisafi: pb.RIBStatus_IPv4Unicast, RouteCount: 2, Ready: true},
+		{VrfId: 0, Afisafi: pb.RIBStatus_IPv6Unicast, RouteCount: 0, Ready: false},
+		{VrfId: 200, Afisafi: pb.RIBStatus_IPv4Unicast, RouteCount: 0, Ready: true},
+		{VrfId: 200, Afisafi: pb.RIBStatus_IPv6Unicast, RouteCount: 1, Ready: false},
+	}, resp.Ribs)
+
+	resp, err = s.GetRouterStatus(context.Background(), &pb.GetRouterStatusRequest{
+		Router: "10.0.0.2",
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.Ribs)
+
+	_, err = s.GetRouterStatus(context.Background(), &pb.GetRouterStatusRequest{
+		Router: "10.0.0.3",
+	})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+type mockDumpRIBServer struct {
+	grpc.ServerStream
+	replies []*pb.DumpRIBReply
+}
+
+func (m *mockDumpRIBServer) Send(r *pb.DumpRIBReply) error {
+	m.replies = append(m.replies, &pb.DumpRIBReply{
+		Route:  r.Route,
+		Cursor: r.Cursor,
+	})
+	return nil
+}
+
+func TestDumpRIBPagination(t *testing.T) {
+	v, err := vrf.New("risserver-dump", 0)
+	assert.NoError(t, err)
+	defer v.Unregister()
+
+	p := &route.Path{
+		Type: route.StaticPathType,
+		StaticPath: &route.StaticPath{
+			NextHop: bnet.IPv4FromOctets(192, 168, 0, 1).Ptr(),
+		},
+	}
+
+	pfxs := []bnet.Prefix{
+		bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8),
+		bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 16),
+		bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24),
+		bnet.NewPfx(bnet.IPv4FromOctets(10, 1, 0, 0), 16),
+		bnet.NewPfx(bnet.IPv4FromOctets(10, 128, 0, 0), 9),
+		bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24),
+		bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 128), 25),
+		bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24),
+	}
+	for i := range pfxs {
+		v.IPv4UnicastRIB().AddPath(pfxs[i].Ptr(), p)
+	}
+
+	expected := make([]string, 0)
+	for _, r := range v.IPv4UnicastRIB().Dump() {
+		expected = append(expected, r.Prefix().String())
+	}
+	assert.Len(t, expected, len(pfxs))
+
+	s := NewServer(&mockBMPReceiver{
+		routers: []server.RouterInterface{
+			&mockRouter{
+				name:    "core01",
+				address: net.IPv4(10, 0, 0, 1),
+				vrfs:    []*vrf.VRF{v},
+			},
+		},
+	})
+
+	for _, pageSize := range []uint32{1, 2, 3, 8, 100} {
+		received := make([]string, 0)
+		cursor := ""
+		for pages := 0; ; pages++ {
+			if !assert.Less(t, pages, len(pfxs)+1, "page size %d does not terminate", pageSize) {
+				break
+			}
+
+			stream := &mockDumpRIBServer{}
+			err := s.DumpRIB(&pb.DumpRIBRequest{
+				Router:   "10.0.0.1",
+				Afisafi:  pb.DumpRIBRequest_IPv4Unicast,
+				PageSize: pageSize,
+				Cursor:   cursor,
+			}, stream)
+			assert.NoError(t, err)
+			assert.LessOrEqual(t, len(stream.replies), int(pageSize))
+
+			if len(stream.replies) == 0 {
+				break
+			}
+
+			for _, r := range stream.replies {
+				received = append(received, bnet.NewPrefixFromProtoPrefix(r.Route.Pfx).String())
+			}
+			cursor = stream.replies[len(stream.replies)-1].Cursor
+		}
+
+		assert.Equal(t, expected, received, "page size %d", pageSize)
+	}
+
+	err = s.DumpRIB(&pb.DumpRIBRequest{
+		Router:  "10.0.0.1",
+		Afisafi: pb.DumpRIBRequest_IPv4Unicast,
+		Cursor:  "not a cursor",
+	}, &mockDumpRIBServer{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"sync"
 
 	pb "github.com/bio-routing/bio-rd/cmd/ris/api"
+	routeapi "github.com/bio-routing/bio-rd/route/api"
 	"github.com/bio-routing/bio-rd/util/log"
 	"github.com/urfave/cli"
 	"google.golang.org/grpc"
@@ -23,6 +26,7 @@ func NewDumpLocRIBCommand() cli.Command {
 			&cli.Uint64Flag{Name: "origin", Usage: "print routes originated by ASN"},
 			&cli.Uint64Flag{Name: "min", Usage: "print routes having at least this prefix length"},
 			&cli.Uint64Flag{Name: "max", Usage: "print routes having at most this prefix length"},
+			&cli.BoolFlag{Name: "all", Usage: "dump the RIBs of all routers known to the RIS"},
 		},
 	}
 
@@ -53,9 +57,15 @@ func NewDumpLocRIBCommand() cli.Command {
 		}
 
 		client := pb.NewRoutingInformationServiceClient(conn)
+		routers, err := resolveRouters(client, c.GlobalString("router"), c.Bool("all"))
+		if err != nil {
+			log.Errorf("Unable to get routers: %v", err)
+			os.Exit(1)
+		}
+
 		for _, afisafi := range afisafis {
 			fmt.Printf(" --- Dump %s ---\n", pb.DumpRIBRequest_AFISAFI_name[int32(afisafi)])
-			err = dumpRIB(client, c.GlobalString("router"), c.GlobalUint64("vrf_id"), c.GlobalString("vrf"), afisafi, filter)
+			err = dumpRIBs(os.Stdout, client, routers, c.GlobalUint64("vrf_id"), c.GlobalString("vrf"), afisafi, filter)
 			if err != nil {
 				log.Errorf("DumpRIB failed: %v", err)
 				os.Exit(1)
@@ -68,7 +78,87 @@ func NewDumpLocRIBCommand() cli.Command {
 	return cmd
 }
 
-func dumpRIB(c pb.RoutingInformationServiceClient, routerName string, vrfID uint64, vrf string, afisafi pb.DumpRIBRequest_AFISAFI, filter *pb.RIBFilter) error {
+// resolveRouters returns the routers to query. routers is a comma separated list of routers.
+// If all is set all routers known to the RIS are returned instead.
+func resolveRouters(c pb.RoutingInformationServiceClient, routers string, all bool) ([]string, error) {
+	if !all {
+		ret := make([]string, 0)
+		for _, r := range strings.Split(routers, ",") {
+			r = strings.TrimSpace(r)
+			if r != "" {
+				ret = append(ret, r)
+			}
+		}
+
+		if len(ret) == 0 {
+			return nil, fmt.Errorf("no router given")
+		}
+
+		return ret, nil
+	}
+
+	resp, err := c.ListRouters(context.Background(), &pb.ListRoutersRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("ListRouters failed: %w", err)
+	}
+
+	ret := make([]string, 0, len(resp.Routers))
+	for _, r := range resp.Routers {
+		ret = append(ret, r.Address)
+	}
+
+	return ret, nil
+}
+
+// dumpRIBs dumps the RIBs of all routers concurrently. If there is more than one router each route
+// is annotated with the router it was received from.
+func dumpRIBs(w io.Writer, c pb.RoutingInformationServiceClient, routers []string, vrfID uint64, vrf string, afisafi pb.DumpRIBRequest_AFISAFI, filter *pb.RIBFilter) error {
+	if len(routers) == 1 {
+		return dumpRIB(c, routers[0], vrfID, vrf, afisafi, filter, func(r *routeapi.Route) {
+			fprintRoute(w, r)
+		})
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	errs := make([]error, len(routers))
+
+	for i, rtr := range routers {
+		wg.Add(1)
+		go func(i int, rtr string) {
+			defer wg.Done()
+
+			buf := make([]byte, 0, 1024)
+			err := dumpRIB(c, rtr, vrfID, vrf, afisafi, filter, func(r *routeapi.Route) {
+				buf = appendRoute(buf[:0], rtr, r)
+
+				mu.Lock()
+				defer mu.Unlock()
+				w.Write(buf)
+			})
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", rtr, err)
+			}
+		}(i, rtr)
+	}
+
+	wg.Wait()
+
+	failed := make([]string, 0)
+	for _, err := range errs {
+		if err != nil {
+			failed = append(failed, err.Error())
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("%s", strings.Join(failed, ", "))
+	}
+
+	return nil
+}
+
+func dumpRIB(c pb.RoutingInformationServiceClient, routerName string, vrfID uint64, vrf string, afisafi pb.DumpRIBRequest_AFISAFI, filter *pb.RIBFilter, print func(*routeapi.Route)) error {
 	client, err := c.DumpRIB(context.Background(), &pb.DumpRIBRequest{
 		Router:  routerName,
 		VrfId:   vrfID,
@@ -89,6 +179,6 @@ func dumpRIB(c pb.RoutingInformationServiceClient, routerName string, vrfID uint
 			return fmt.Errorf("received failed: %w", err)
 		}
 
-		printRoute(r.Route)
+		print(r.Route)
 	}
 }
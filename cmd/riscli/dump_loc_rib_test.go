@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	pb "github.com/bio-routing/bio-rd/cmd/ris/api"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// mockDumpClient serves DumpRIB and ListRouters requests from a per router list of routes
+type mockDumpClient struct {
+	pb.RoutingInformationServiceClient
+	ribs    map[string][]*route.Route
+	routers []*pb.Router
+}
+
+func (m *mockDumpClient) ListRouters(ctx context.Context, in *pb.ListRoutersRequest, opts ...grpc.CallOption) (*pb.ListRoutersResponse, error) {
+	return &pb.ListRoutersResponse{
+		Routers: m.routers,
+	}, nil
+}
+
+func (m *mockDumpClient) DumpRIB(ctx context.Context, in *pb.DumpRIBRequest, opts ...grpc.CallOption) (pb.RoutingInformationService_DumpRIBClient, error) {
+	routes, ok := m.ribs[in.Router]
+	if !ok {
+		return nil, fmt.Errorf("unable to get router")
+	}
+
+	return &mockDumpStream{
+		routes: routes,
+	}, nil
+}
+
+type mockDumpStream struct {
+	grpc.ClientStream
+	routes []*route.Route
+}
+
+func (m *mockDumpStream) Recv() (*pb.DumpRIBReply, error) {
+	if len(m.routes) == 0 {
+		return nil, io.EOF
+	}
+
+	r := m.routes[0]
+	m.routes = m.routes[1:]
+	return &pb.DumpRIBReply{
+		Route: r.ToProto(),
+	}, nil
+}
+
+func TestResolveRouters(t *testing.T) {
+	c := &mockDumpClient{
+		routers: []*pb.Router{
+			{SysName: "core01", Address: "10.0.0.1"},
+			{SysName: "core02", Address: "10.0.0.2"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		routers  string
+		all      bool
+		expected []string
+		wantFail bool
+	}{
+		{
+			name:     "Single router",
+			routers:  "10.0.0.1",
+			expected: []string{"10.0.0.1"},
+		},
+		{
+			name:     "Comma separated list",
+			routers:  "10.0.0.1, 10.0.0.3,,",
+			expected: []string{"10.0.0.1", "10.0.0.3"},
+		},
+		{
+			name:     "All routers",
+			routers:  "10.0.0.3",
+			all:      true,
+			expected: []string{"10.0.0.1", "10.0.0.2"},
+		},
+		{
+			name:     "No router",
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		res, err := resolveRouters(c, test.routers, test.all)
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, res, test.name)
+	}
+}
+
+func TestDumpRIBsSingleRouter(t *testing.T) {
+	c := &mockDumpClient{
+		ribs: map[string][]*route.Route{
+			"10.0.0.1": {testRoute("10.0.0.0/8"), testRoute("10.1.0.0/16")},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := dumpRIBs(buf, c, []string{"10.0.0.1"}, 0, "", pb.DumpRIBRequest_IPv4Unicast, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, printed(testRoute("10.0.0.0/8"), testRoute("10.1.0.0/16")), buf.String(), "single router output must not be annotated")
+}
+
+func TestDumpRIBsMultipleRouters(t *testing.T) {
+	c := &mockDumpClient{
+		ribs: map[string][]*route.Route{
+			"10.0.0.1": {testRoute("10.0.0.0/8"), testRoute("10.1.0.0/16")},
+			"10.0.0.2": {testRoute("10.0.0.0/8"), testRoute("192.168.0.0/24"), testRoute("2001:db8::/32")},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := dumpRIBs(buf, c, []string{"10.0.0.1", "10.0.0.2"}, 0, "", pb.DumpRIBRequest_IPv4Unicast, nil)
+	assert.NoError(t, err)
+
+	// Routers are dumped concurrently. Output of different routers may interleave but never within a route.
+	expected := make([]string, 0)
+	for _, rtr := range []string{"10.0.0.1", "10.0.0.2"} {
+		for _, r := range c.ribs[rtr] {
+			block := string(appendRoute(nil, rtr, r.ToProto()))
+			assert.Contains(t, buf.String(), block)
+			expected = append(expected, block)
+		}
+	}
+	assert.Equal(t, len(strings.Join(expected, "")), buf.Len())
+	assert.True(t, strings.HasPrefix(expected[0], "[10.0.0.1] 10.0.0.0/8:\n"), expected[0])
+}
+
+func TestDumpRIBsMultipleRoutersError(t *testing.T) {
+	c := &mockDumpClient{
+		ribs: map[string][]*route.Route{
+			"10.0.0.1": {testRoute("10.0.0.0/8")},
+		},
+	}
+
+	buf := bytes.NewBuffer(nil)
+	err := dumpRIBs(buf, c, []string{"10.0.0.1", "10.0.0.2"}, 0, "", pb.DumpRIBRequest_IPv4Unicast, nil)
+	assert.EqualError(t, err, "10.0.0.2: unable to get client: unable to get router")
+	assert.Equal(t, string(appendRoute(nil, "10.0.0.1", testRoute("10.0.0.0/8").ToProto())), buf.String(), "routes of healthy routers must still be printed")
+}
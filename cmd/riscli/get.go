@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	pb "github.com/bio-routing/bio-rd/cmd/ris/api"
+	bnet "github.com/bio-routing/bio-rd/net"
+	routeapi "github.com/bio-routing/bio-rd/route/api"
+	"github.com/bio-routing/bio-rd/util/log"
+	"github.com/urfave/cli"
+	"google.golang.org/grpc"
+)
+
+// NewGetCommand creates a new get command
+func NewGetCommand() cli.Command {
+	cmd := cli.Command{
+		Name:      "get",
+		Usage:     "get the route(s) for a single prefix",
+		ArgsUsage: "<prefix>",
+		Flags: []cli.Flag{
+			&cli.BoolFlag{Name: "exact", Usage: "only print the route for exactly this prefix"},
+			&cli.BoolFlag{Name: "all", Usage: "print all covering routes instead of only the longest match"},
+		},
+	}
+
+	cmd.Action = func(c *cli.Context) error {
+		if c.NArg() != 1 {
+			return fmt.Errorf("expected exactly one prefix, got %d arguments", c.NArg())
+		}
+
+		pfx, err := parseGetPrefix(c.Args().First())
+		if err != nil {
+			return fmt.Errorf("unable to parse prefix: %w", err)
+		}
+
+		conn, err := grpc.Dial(c.GlobalString("ris"), grpc.WithInsecure())
+		if err != nil {
+			log.Errorf("GRPC dial failed: %v", err)
+			os.Exit(1)
+		}
+		defer conn.Close()
+
+		client := pb.NewRoutingInformationServiceClient(conn)
+		err = get(os.Stdout, client, c.GlobalString("router"), c.GlobalUint64("vrf_id"), c.GlobalString("vrf"), pfx, getOptions{
+			exact: c.Bool("exact"),
+			all:   c.Bool("all"),
+		})
+		if err != nil {
+			log.Errorf("Get failed: %v", err)
+			os.Exit(1)
+		}
+
+		return nil
+	}
+
+	return cmd
+}
+
+type getOptions struct {
+	exact bool
+	all   bool
+}
+
+// parseGetPrefix parses a prefix. Addresses without prefix length are treated as host routes.
+func parseGetPrefix(s string) (bnet.Prefix, error) {
+	if strings.Contains(s, "/") {
+		return bnet.ParsePrefix(s)
+	}
+
+	addr, err := bnet.IPFromString(s)
+	if err != nil {
+		return bnet.Prefix{}, err
+	}
+
+	return bnet.NewPfx(addr, addr.SizeBytes()*8), nil
+}
+
+func get(w io.Writer, c pb.RoutingInformationServiceClient, routerName string, vrfID uint64, vrf string, pfx bnet.Prefix, opts getOptions) error {
+	routes, err := getRoutes(c, routerName, vrfID, vrf, pfx, opts)
+	if err != nil {
+		return err
+	}
+
+	if len(routes) == 0 {
+		fmt.Fprintf(w, "No route found for %s\n", pfx.String())
+		return nil
+	}
+
+	for _, r := range routes {
+		fprintRoute(w, r)
+	}
+
+	return nil
+}
+
+func getRoutes(c pb.RoutingInformationServiceClient, routerName string, vrfID uint64, vrf string, pfx bnet.Prefix, opts getOptions) ([]*routeapi.Route, error) {
+	if opts.exact {
+		resp, err := c.Get(context.Background(), &pb.GetRequest{
+			Router: routerName,
+			VrfId:  vrfID,
+			Vrf:    vrf,
+			Pfx:    pfx.ToProto(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("get failed: %w", err)
+		}
+
+		return resp.Routes, nil
+	}
+
+	resp, err := c.LPM(context.Background(), &pb.LPMRequest{
+		Router: routerName,
+		VrfId:  vrfID,
+		Vrf:    vrf,
+		Pfx:    pfx.ToProto(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("LPM failed: %w", err)
+	}
+
+	if opts.all || len(resp.Routes) == 0 {
+		return resp.Routes, nil
+	}
+
+	longest := resp.Routes[0]
+	for _, r := range resp.Routes[1:] {
+		if r.Pfx.Length > longest.Pfx.Length {
+			longest = r
+		}
+	}
+
+	return []*routeapi.Route{longest}, nil
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	pb "github.com/bio-routing/bio-rd/cmd/ris/api"
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	routeapi "github.com/bio-routing/bio-rd/route/api"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// mockRISClient serves LPM and Get requests from a list of routes
+type mockRISClient struct {
+	pb.RoutingInformationServiceClient
+	routes   []*route.Route
+	requests []string
+	err      error
+}
+
+func (m *mockRISClient) LPM(ctx context.Context, in *pb.LPMRequest, opts ...grpc.CallOption) (*pb.LPMResponse, error) {
+	m.requests = append(m.requests, "LPM "+in.Router)
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	needle := bnet.NewPrefixFromProtoPrefix(in.Pfx)
+	res := &pb.LPMResponse{}
+	for _, r := range m.routes {
+		if r.Prefix().Equal(needle) || r.Prefix().Contains(needle) {
+			res.Routes = append(res.Routes, r.ToProto())
+		}
+	}
+
+	return res, nil
+}
+
+func (m *mockRISClient) Get(ctx context.Context, in *pb.GetRequest, opts ...grpc.CallOption) (*pb.GetResponse, error) {
+	m.requests = append(m.requests, "Get "+in.Router)
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	needle := bnet.NewPrefixFromProtoPrefix(in.Pfx)
+	res := &pb.GetResponse{
+		Routes: make([]*routeapi.Route, 0),
+	}
+	for _, r := range m.routes {
+		if r.Prefix().Equal(needle) {
+			res.Routes = append(res.Routes, r.ToProto())
+		}
+	}
+
+	return res, nil
+}
+
+func testRoute(pfx string) *route.Route {
+	p, err := bnet.ParsePrefix(pfx)
+	if err != nil {
+		panic(err)
+	}
+
+	return route.NewRoute(p.Ptr(), &route.Path{
+		Type: route.StaticPathType,
+		StaticPath: &route.StaticPath{
+			NextHop: bnet.IPv4FromOctets(192, 168, 0, 1).Ptr(),
+		},
+	})
+}
+
+func printed(routes ...*route.Route) string {
+	buf := bytes.NewBuffer(nil)
+	for _, r := range routes {
+		fprintRoute(buf, r.ToProto())
+	}
+
+	return buf.String()
+}
+
+func TestGet(t *testing.T) {
+	routes := []*route.Route{
+		testRoute("10.0.0.0/8"),
+		testRoute("10.1.0.0/16"),
+		testRoute("10.1.1.0/24"),
+		testRoute("2001:db8::/32"),
+	}
+
+	tests := []struct {
+		name             string
+		pfx              string
+		opts             getOptions
+		expected         string
+		expectedRequests []string
+	}{
+		{
+			name:             "Longest match",
+			pfx:              "10.1.1.1",
+			expected:         printed(routes[2]),
+			expectedRequests: []string{"LPM core01"},
+		},
+		{
+			name:             "Longest match of prefix",
+			pfx:              "10.1.2.0/24",
+			expected:         printed(routes[1]),
+			expectedRequests: []string{"LPM core01"},
+		},
+		{
+			name:             "All covering routes",
+			pfx:              "10.1.1.0/24",
+			opts:             getOptions{all: true},
+			expected:         printed(routes[0], routes[1], routes[2]),
+			expectedRequests: []string{"LPM core01"},
+		},
+		{
+			name:             "Exact match",
+			pfx:              "10.1.0.0/16",
+			opts:             getOptions{exact: true},
+			expected:         printed(routes[1]),
+			expectedRequests: []string{"Get core01"},
+		},
+		{
+			name:             "Exact match not found",
+			pfx:              "10.1.2.0/24",
+			opts:             getOptions{exact: true},
+			expected:         "No route found for 10.1.2.0/24\n",
+			expectedRequests: []string{"Get core01"},
+		},
+		{
+			name:             "IPv6",
+			pfx:              "2001:db8::1",
+			expected:         printed(routes[3]),
+			expectedRequests: []string{"LPM core01"},
+		},
+		{
+			name:             "No match",
+			pfx:              "192.168.0.0/24",
+			expected:         "No route found for 192.168.0.0/24\n",
+			expectedRequests: []string{"LPM core01"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pfx, err := parseGetPrefix(test.pfx)
+			assert.NoError(t, err)
+
+			c := &mockRISClient{
+				routes: routes,
+			}
+			buf := bytes.NewBuffer(nil)
+			err = get(buf, c, "core01", 0, "", pfx, test.opts)
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, buf.String())
+			assert.Equal(t, test.expectedRequests, c.requests)
+		})
+	}
+}
+
+func TestGetError(t *testing.T) {
+	c := &mockRISClient{
+		err: fmt.Errorf("unknown router"),
+	}
+
+	pfx, err := parseGetPrefix("10.0.0.0/8")
+	assert.NoError(t, err)
+
+	buf := bytes.NewBuffer(nil)
+	assert.Error(t, get(buf, c, "core01", 0, "", pfx, getOptions{}))
+	assert.Error(t, get(buf, c, "core01", 0, "", pfx, getOptions{exact: true}))
+	assert.Empty(t, buf.String())
+}
+
+func TestParseGetPrefix(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bnet.Prefix
+		wantFail bool
+	}{
+		{
+			input:    "10.0.0.1",
+			expected: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 1), 32),
+		},
+		{
+			input:    "2001:db8::1",
+			expected: bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1), 128),
+		},
+		{
+			input:    "10.0.0.0/8",
+			expected: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8),
+		},
+		{
+			input:    "10.0.0.0/33",
+			wantFail: true,
+		},
+		{
+			input:    "foo",
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		pfx, err := parseGetPrefix(test.input)
+		if test.wantFail {
+			assert.Error(t, err, test.input)
+			continue
+		}
+
+		assert.NoError(t, err, test.input)
+		assert.Equal(t, test.expected, pfx, test.input)
+	}
+}
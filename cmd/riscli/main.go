@@ -20,7 +20,7 @@ func main() {
 		},
 		cli.StringFlag{
 			Name:  "router",
-			Usage: "Router Name",
+			Usage: "Router Name (dump-loc-rib accepts a comma separated list)",
 			Value: "",
 		},
 		cli.Uint64Flag{
@@ -33,12 +33,22 @@ func main() {
 			Usage: "VRF",
 			Value: "",
 		},
+		cli.BoolFlag{
+			Name:  "json",
+			Usage: "Print routes as JSON (one object per line)",
+		},
+	}
+
+	app.Before = func(c *cli.Context) error {
+		jsonOutput = c.GlobalBool("json")
+		return nil
 	}
 
 	app.Commands = []cli.Command{
 		NewObserveRIBCommand(),
 		NewDumpLocRIBCommand(),
 		NewLPMCommand(),
+		NewGetCommand(),
 	}
 
 	err := app.Run(os.Args)
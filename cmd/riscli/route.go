@@ -1,13 +1,67 @@
 package main
 
 import (
-	"fmt"
+	"encoding/json"
+	"io"
+	"os"
 
 	"github.com/bio-routing/bio-rd/route"
 	"github.com/bio-routing/bio-rd/route/api"
 )
 
+// printBuf is reused across printRoute calls to avoid allocations when dumping large RIBs
+var printBuf []byte
+
+// jsonOutput makes routes get printed as one JSON object per line
+var jsonOutput bool
+
+// jsonRoute is the JSON representation of a route learned from a source
+type jsonRoute struct {
+	Source string       `json:"source"`
+	Route  *route.Route `json:"route"`
+}
+
 func printRoute(ar *api.Route) {
+	fprintRoute(os.Stdout, ar)
+}
+
+func fprintRoute(w io.Writer, ar *api.Route) {
+	printBuf = appendRoute(printBuf[:0], "", ar)
+	w.Write(printBuf)
+}
+
+// appendRoute appends the printable representation of ar to dst. A non empty source (e.g. the router
+// the route was learned from) is prepended in brackets.
+func appendRoute(dst []byte, source string, ar *api.Route) []byte {
 	r := route.RouteFromProtoRoute(ar, false)
-	fmt.Println(r.Print())
+	if jsonOutput {
+		return appendRouteJSON(dst, source, r)
+	}
+
+	if source != "" {
+		dst = append(dst, '[')
+		dst = append(dst, source...)
+		dst = append(dst, "] "...)
+	}
+
+	dst = r.AppendPrint(dst)
+	return append(dst, '\n')
+}
+
+// appendRouteJSON appends the JSON representation of r to dst. A non empty source is added as a separate field.
+func appendRouteJSON(dst []byte, source string, r *route.Route) []byte {
+	var b []byte
+	var err error
+	if source != "" {
+		b, err = json.Marshal(jsonRoute{Source: source, Route: r})
+	} else {
+		b, err = json.Marshal(r)
+	}
+
+	if err != nil {
+		return append(dst, err.Error()+"\n"...)
+	}
+
+	dst = append(dst, b...)
+	return append(dst, '\n')
 }
@@ -0,0 +1,18 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppendRouteJSON(t *testing.T) {
+	jsonOutput = true
+	defer func() {
+		jsonOutput = false
+	}()
+
+	r := testRoute("10.0.0.0/8").ToProto()
+	assert.Equal(t, `{"prefix":"10.0.0.0/8","paths":[{"protocol":"static","static":{"next_hop":"192.168.0.1"}}]}`+"\n", string(appendRoute(nil, "", r)))
+	assert.Equal(t, `{"source":"10.0.0.1","route":{"prefix":"10.0.0.0/8","paths":[{"protocol":"static","static":{"next_hop":"192.168.0.1"}}]}}`+"\n", string(appendRoute(nil, "10.0.0.1", r)))
+}
@@ -7,6 +7,7 @@ import (
 	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/protocols/kernel"
 	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable"
 	"github.com/bio-routing/bio-rd/routingtable/vrf"
 	"github.com/sirupsen/logrus"
 )
@@ -32,8 +33,9 @@ func main() {
 		os.Exit(1)
 	}
 	defer k.Dispose()
+	k.SetNextHopResolver(rib4)
 
-	rib4.Register(k)
+	rib4.RegisterWithOptions(k, routingtable.ClientOptions{BestOnly: true, FIB: true})
 
 	time.Sleep(time.Second * 10)
 }
@@ -19,19 +19,34 @@ var (
 	uptimeDesc                *prometheus.Desc
 	updatesReceivedDesc       *prometheus.Desc
 	updatesSentDesc           *prometheus.Desc
+	inboundQueueDepthDesc     *prometheus.Desc
+	refreshesReceivedDesc     *prometheus.Desc
+	refreshesCoalescedDesc    *prometheus.Desc
+	holdTimeDesc              *prometheus.Desc
+	policyVersionDesc         *prometheus.Desc
 	stateDescRouter           *prometheus.Desc
 	uptimeDescRouter          *prometheus.Desc
 	updatesReceivedDescRouter *prometheus.Desc
 	updatesSentDescRouter     *prometheus.Desc
+	inboundQueueDepthRouter   *prometheus.Desc
+	refreshesReceivedRouter   *prometheus.Desc
+	refreshesCoalescedRouter  *prometheus.Desc
+	holdTimeDescRouter        *prometheus.Desc
 	routesReceivedDesc        *prometheus.Desc
 	routesSentDesc            *prometheus.Desc
 	routesRejectedDesc        *prometheus.Desc
 	routesAcceptedDesc        *prometheus.Desc
+	prePolicyPathsDesc        *prometheus.Desc
+	prePolicyBytesDesc        *prometheus.Desc
+	asLoopsDesc               *prometheus.Desc
 	endOfRIBMarkerDesc        *prometheus.Desc
 	routesReceivedDescRouter  *prometheus.Desc
 	routesSentDescRouter      *prometheus.Desc
 	routesRejectedDescRouter  *prometheus.Desc
 	routesAcceptedDescRouter  *prometheus.Desc
+	prePolicyPathsDescRouter  *prometheus.Desc
+	prePolicyBytesDescRouter  *prometheus.Desc
+	asLoopsDescRouter         *prometheus.Desc
 	endOfRIBMarkerDescRouter  *prometheus.Desc
 )
 
@@ -41,18 +56,30 @@ func init() {
 	uptimeDesc = prometheus.NewDesc(prefix+"uptime_second", "Time since the session was established in seconds", labels, nil)
 	updatesReceivedDesc = prometheus.NewDesc(prefix+"update_received_count", "Number of updates received", labels, nil)
 	updatesSentDesc = prometheus.NewDesc(prefix+"update_sent_count", "Number of updates sent", labels, nil)
+	inboundQueueDepthDesc = prometheus.NewDesc(prefix+"inbound_queue_depth", "Number of received messages waiting to be processed", labels, nil)
+	refreshesReceivedDesc = prometheus.NewDesc(prefix+"route_refresh_received_count", "Number of ROUTE-REFRESH requests received", labels, nil)
+	refreshesCoalescedDesc = prometheus.NewDesc(prefix+"route_refresh_coalesced_count", "Number of ROUTE-REFRESH requests coalesced into a pending re-advertisement", labels, nil)
+	holdTimeDesc = prometheus.NewDesc(prefix+"hold_time_seconds", "Negotiated hold time of the established session in seconds (0 = keepalives disabled)", labels, nil)
+	policyVersionDesc = prometheus.NewDesc(prefix+"policy_version", "Version of the import and export policy, incremented on every policy change", labels, nil)
 
 	labelsRouter := append(labels, "sys_name", "agent_address")
 	stateDescRouter = prometheus.NewDesc(prefix+"state", "State of the BGP session (Down = 0, Idle = 1, Connect = 2, Active = 3, OpenSent = 4, OpenConfirm = 5, Established = 6)", labelsRouter, nil)
 	uptimeDescRouter = prometheus.NewDesc(prefix+"uptime_second", "Time since the session was established in seconds", labelsRouter, nil)
 	updatesReceivedDescRouter = prometheus.NewDesc(prefix+"update_received_count", "Number of updates received", labelsRouter, nil)
 	updatesSentDescRouter = prometheus.NewDesc(prefix+"update_sent_count", "Number of updates sent", labelsRouter, nil)
+	inboundQueueDepthRouter = prometheus.NewDesc(prefix+"inbound_queue_depth", "Number of received messages waiting to be processed", labelsRouter, nil)
+	refreshesReceivedRouter = prometheus.NewDesc(prefix+"route_refresh_received_count", "Number of ROUTE-REFRESH requests received", labelsRouter, nil)
+	refreshesCoalescedRouter = prometheus.NewDesc(prefix+"route_refresh_coalesced_count", "Number of ROUTE-REFRESH requests coalesced into a pending re-advertisement", labelsRouter, nil)
+	holdTimeDescRouter = prometheus.NewDesc(prefix+"hold_time_seconds", "Negotiated hold time of the established session in seconds (0 = keepalives disabled)", labelsRouter, nil)
 
 	labels = append(labels, "afi", "safi")
 	routesReceivedDesc = prometheus.NewDesc(prefix+"route_received_count", "Number of routes received", labels, nil)
 	routesSentDesc = prometheus.NewDesc(prefix+"route_sent_count", "Number of routes sent", labels, nil)
 	routesRejectedDesc = prometheus.NewDesc(prefix+"route_rejected_count", "Number of routes rejected", labels, nil)
 	routesAcceptedDesc = prometheus.NewDesc(prefix+"route_accepted_count", "Number of routes accepted", labels, nil)
+	prePolicyPathsDesc = prometheus.NewDesc(prefix+"pre_policy_path_count", "Number of received paths stored before applying the import policy", labels, nil)
+	prePolicyBytesDesc = prometheus.NewDesc(prefix+"pre_policy_bytes", "Estimated memory used by the received paths stored before applying the import policy", labels, nil)
+	asLoopsDesc = prometheus.NewDesc(prefix+"route_as_loop_count", "Number of prefixes currently rejected as one of our ASNs is part of their AS path", labels, nil)
 	endOfRIBMarkerDesc = prometheus.NewDesc(prefix+"end_of_rib_marker_received", "End of RIB marker received", labels, nil)
 
 	labelsRouter = append(labelsRouter, "afi", "safi")
@@ -60,6 +87,9 @@ func init() {
 	routesSentDescRouter = prometheus.NewDesc(prefix+"route_sent_count", "Number of routes sent", labelsRouter, nil)
 	routesRejectedDescRouter = prometheus.NewDesc(prefix+"route_rejected_count", "Number of routes rejected", labelsRouter, nil)
 	routesAcceptedDescRouter = prometheus.NewDesc(prefix+"route_accepted_count", "Number of routes accepted", labelsRouter, nil)
+	prePolicyPathsDescRouter = prometheus.NewDesc(prefix+"pre_policy_path_count", "Number of received paths stored before applying the import policy", labelsRouter, nil)
+	prePolicyBytesDescRouter = prometheus.NewDesc(prefix+"pre_policy_bytes", "Estimated memory used by the received paths stored before applying the import policy", labelsRouter, nil)
+	asLoopsDescRouter = prometheus.NewDesc(prefix+"route_as_loop_count", "Number of prefixes currently rejected as one of our ASNs is part of their AS path", labelsRouter, nil)
 	endOfRIBMarkerDescRouter = prometheus.NewDesc(prefix+"end_of_rib_marker_received", "End of RIB marker received", labelsRouter, nil)
 }
 
@@ -79,10 +109,18 @@ func (c *bgpCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- uptimeDesc
 	ch <- updatesReceivedDesc
 	ch <- updatesSentDesc
+	ch <- inboundQueueDepthDesc
+	ch <- refreshesReceivedDesc
+	ch <- refreshesCoalescedDesc
+	ch <- holdTimeDesc
+	ch <- policyVersionDesc
 	ch <- routesReceivedDesc
 	ch <- routesSentDesc
 	ch <- routesRejectedDesc
 	ch <- routesAcceptedDesc
+	ch <- prePolicyPathsDesc
+	ch <- prePolicyBytesDesc
+	ch <- asLoopsDesc
 	ch <- endOfRIBMarkerDesc
 }
 
@@ -91,10 +129,17 @@ func DescribeRouter(ch chan<- *prometheus.Desc) {
 	ch <- uptimeDescRouter
 	ch <- updatesReceivedDescRouter
 	ch <- updatesSentDescRouter
+	ch <- inboundQueueDepthRouter
+	ch <- refreshesReceivedRouter
+	ch <- refreshesCoalescedRouter
+	ch <- holdTimeDescRouter
 	ch <- routesReceivedDescRouter
 	ch <- routesSentDescRouter
 	ch <- routesRejectedDescRouter
 	ch <- routesAcceptedDescRouter
+	ch <- prePolicyPathsDescRouter
+	ch <- prePolicyBytesDescRouter
+	ch <- asLoopsDescRouter
 	ch <- endOfRIBMarkerDescRouter
 }
 
@@ -128,6 +173,11 @@ func collectForPeer(ch chan<- prometheus.Metric, peer *metrics.BGPPeerMetrics) {
 
 	ch <- prometheus.MustNewConstMetric(updatesReceivedDesc, prometheus.CounterValue, float64(peer.UpdatesReceived), l...)
 	ch <- prometheus.MustNewConstMetric(updatesSentDesc, prometheus.CounterValue, float64(peer.UpdatesSent), l...)
+	ch <- prometheus.MustNewConstMetric(inboundQueueDepthDesc, prometheus.GaugeValue, float64(peer.InboundQueueDepth), l...)
+	ch <- prometheus.MustNewConstMetric(refreshesReceivedDesc, prometheus.CounterValue, float64(peer.RouteRefreshesReceived), l...)
+	ch <- prometheus.MustNewConstMetric(refreshesCoalescedDesc, prometheus.CounterValue, float64(peer.RouteRefreshesCoalesced), l...)
+	ch <- prometheus.MustNewConstMetric(holdTimeDesc, prometheus.GaugeValue, peer.HoldTime.Seconds(), l...)
+	ch <- prometheus.MustNewConstMetric(policyVersionDesc, prometheus.GaugeValue, float64(peer.PolicyVersion), l...)
 
 	for _, family := range peer.AddressFamilies {
 		collectForFamily(ch, family, l)
@@ -153,6 +203,10 @@ func CollectForPeerRouter(ch chan<- prometheus.Metric, sysName string, agentAddr
 
 	ch <- prometheus.MustNewConstMetric(updatesReceivedDescRouter, prometheus.CounterValue, float64(peer.UpdatesReceived), l...)
 	ch <- prometheus.MustNewConstMetric(updatesSentDescRouter, prometheus.CounterValue, float64(peer.UpdatesSent), l...)
+	ch <- prometheus.MustNewConstMetric(inboundQueueDepthRouter, prometheus.GaugeValue, float64(peer.InboundQueueDepth), l...)
+	ch <- prometheus.MustNewConstMetric(refreshesReceivedRouter, prometheus.CounterValue, float64(peer.RouteRefreshesReceived), l...)
+	ch <- prometheus.MustNewConstMetric(refreshesCoalescedRouter, prometheus.CounterValue, float64(peer.RouteRefreshesCoalesced), l...)
+	ch <- prometheus.MustNewConstMetric(holdTimeDescRouter, prometheus.GaugeValue, peer.HoldTime.Seconds(), l...)
 
 	for _, family := range peer.AddressFamilies {
 		collectForFamilyRouter(ch, family, l)
@@ -164,6 +218,9 @@ func collectForFamily(ch chan<- prometheus.Metric, family *metrics.BGPAddressFam
 
 	ch <- prometheus.MustNewConstMetric(routesReceivedDesc, prometheus.CounterValue, float64(family.RoutesReceived), l...)
 	ch <- prometheus.MustNewConstMetric(routesSentDesc, prometheus.CounterValue, float64(family.RoutesSent), l...)
+	ch <- prometheus.MustNewConstMetric(prePolicyPathsDesc, prometheus.GaugeValue, float64(family.PrePolicyPaths), l...)
+	ch <- prometheus.MustNewConstMetric(prePolicyBytesDesc, prometheus.GaugeValue, float64(family.PrePolicyBytes), l...)
+	ch <- prometheus.MustNewConstMetric(asLoopsDesc, prometheus.GaugeValue, float64(family.ASLoops), l...)
 
 	eor := 0
 	if family.EndOfRIBMarkerReceived {
@@ -177,6 +234,9 @@ func collectForFamilyRouter(ch chan<- prometheus.Metric, family *metrics.BGPAddr
 
 	ch <- prometheus.MustNewConstMetric(routesReceivedDescRouter, prometheus.CounterValue, float64(family.RoutesReceived), l...)
 	ch <- prometheus.MustNewConstMetric(routesSentDescRouter, prometheus.CounterValue, float64(family.RoutesSent), l...)
+	ch <- prometheus.MustNewConstMetric(prePolicyPathsDescRouter, prometheus.GaugeValue, float64(family.PrePolicyPaths), l...)
+	ch <- prometheus.MustNewConstMetric(prePolicyBytesDescRouter, prometheus.GaugeValue, float64(family.PrePolicyBytes), l...)
+	ch <- prometheus.MustNewConstMetric(asLoopsDescRouter, prometheus.GaugeValue, float64(family.ASLoops), l...)
 
 	eor := 0
 	if family.EndOfRIBMarkerReceived {
@@ -63,7 +63,7 @@ func (x IP_Version) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use IP_Version.Descriptor instead.
 func (IP_Version) EnumDescriptor() ([]byte, []int) {
-	return file_net_api_net_proto_rawDescGZIP(), []int{1, 0}
+	return file_net_api_net_proto_rawDescGZIP(), []int{2, 0}
 }
 
 type Prefix struct {
@@ -121,6 +121,61 @@ func (x *Prefix) GetLength() uint32 {
 	return 0
 }
 
+type VRFPrefix struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RouteDistinguisher uint64  `protobuf:"varint,1,opt,name=route_distinguisher,json=routeDistinguisher,proto3" json:"route_distinguisher,omitempty"`
+	Prefix             *Prefix `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+}
+
+func (x *VRFPrefix) Reset() {
+	*x = VRFPrefix{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_net_api_net_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VRFPrefix) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VRFPrefix) ProtoMessage() {}
+
+func (x *VRFPrefix) ProtoReflect() protoreflect.Message {
+	mi := &file_net_api_net_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VRFPrefix.ProtoReflect.Descriptor instead.
+func (*VRFPrefix) Descriptor() ([]byte, []int) {
+	return file_net_api_net_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *VRFPrefix) GetRouteDistinguisher() uint64 {
+	if x != nil {
+		return x.RouteDistinguisher
+	}
+	return 0
+}
+
+func (x *VRFPrefix) GetPrefix() *Prefix {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
 type IP struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -134,7 +189,7 @@ type IP struct {
 func (x *IP) Reset() {
 	*x = IP{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_net_api_net_proto_msgTypes[1]
+		mi := &file_net_api_net_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -147,7 +202,7 @@ func (x *IP) String() string {
 func (*IP) ProtoMessage() {}
 
 func (x *IP) ProtoReflect() protoreflect.Message {
-	mi := &file_net_api_net_proto_msgTypes[1]
+	mi := &file_net_api_net_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -160,7 +215,7 @@ func (x *IP) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IP.ProtoReflect.Descriptor instead.
 func (*IP) Descriptor() ([]byte, []int) {
-	return file_net_api_net_proto_rawDescGZIP(), []int{1}
+	return file_net_api_net_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *IP) GetHigher() uint64 {
@@ -193,18 +248,24 @@ var file_net_api_net_proto_rawDesc = []byte{
 	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65,
 	0x74, 0x2e, 0x49, 0x50, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a,
 	0x06, 0x6c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6c,
-	0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x80, 0x01, 0x0a, 0x02, 0x49, 0x50, 0x12, 0x16, 0x0a, 0x06,
-	0x68, 0x69, 0x67, 0x68, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x69,
-	0x67, 0x68, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x6f, 0x77, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x07, 0x76, 0x65,
-	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x62, 0x69,
-	0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49, 0x50, 0x2e, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
-	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x1d, 0x0a, 0x07, 0x56, 0x65, 0x72,
-	0x73, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x50, 0x76, 0x34, 0x10, 0x00, 0x12, 0x08,
-	0x0a, 0x04, 0x49, 0x50, 0x76, 0x36, 0x10, 0x01, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74, 0x69,
-	0x6e, 0x67, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x64, 0x2f, 0x6e, 0x65, 0x74, 0x2f, 0x61, 0x70,
-	0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x6e, 0x67, 0x74, 0x68, 0x22, 0x65, 0x0a, 0x09, 0x56, 0x52, 0x46, 0x50, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x12, 0x2f, 0x0a, 0x13, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x5f, 0x64, 0x69, 0x73, 0x74,
+	0x69, 0x6e, 0x67, 0x75, 0x69, 0x73, 0x68, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x12, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x44, 0x69, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x75, 0x69, 0x73,
+	0x68, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x50, 0x72,
+	0x65, 0x66, 0x69, 0x78, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x22, 0x80, 0x01, 0x0a,
+	0x02, 0x49, 0x50, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x69, 0x67, 0x68, 0x65, 0x72, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x68, 0x69, 0x67, 0x68, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6c,
+	0x6f, 0x77, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6c, 0x6f, 0x77, 0x65,
+	0x72, 0x12, 0x2d, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49, 0x50, 0x2e,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x22, 0x1d, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x08, 0x0a, 0x04, 0x49,
+	0x50, 0x76, 0x34, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x49, 0x50, 0x76, 0x36, 0x10, 0x01, 0x42,
+	0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x69,
+	0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x64,
+	0x2f, 0x6e, 0x65, 0x74, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -220,20 +281,22 @@ func file_net_api_net_proto_rawDescGZIP() []byte {
 }
 
 var file_net_api_net_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_net_api_net_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_net_api_net_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
 var file_net_api_net_proto_goTypes = []interface{}{
-	(IP_Version)(0), // 0: bio.net.IP.Version
-	(*Prefix)(nil),  // 1: bio.net.Prefix
-	(*IP)(nil),      // 2: bio.net.IP
+	(IP_Version)(0),   // 0: bio.net.IP.Version
+	(*Prefix)(nil),    // 1: bio.net.Prefix
+	(*VRFPrefix)(nil), // 2: bio.net.VRFPrefix
+	(*IP)(nil),        // 3: bio.net.IP
 }
 var file_net_api_net_proto_depIdxs = []int32{
-	2, // 0: bio.net.Prefix.address:type_name -> bio.net.IP
-	0, // 1: bio.net.IP.version:type_name -> bio.net.IP.Version
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	3, // 0: bio.net.Prefix.address:type_name -> bio.net.IP
+	1, // 1: bio.net.VRFPrefix.prefix:type_name -> bio.net.Prefix
+	0, // 2: bio.net.IP.version:type_name -> bio.net.IP.Version
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_net_api_net_proto_init() }
@@ -255,6 +318,18 @@ func file_net_api_net_proto_init() {
 			}
 		}
 		file_net_api_net_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VRFPrefix); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_net_api_net_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*IP); i {
 			case 0:
 				return &v.state
@@ -273,7 +348,7 @@ func file_net_api_net_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_net_api_net_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   2,
+			NumMessages:   3,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
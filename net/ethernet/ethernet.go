@@ -18,11 +18,7 @@ const (
 )
 
 // MACAddr represens a MAC address
-type MACAddr [ethALen]byte
-
-func (m MACAddr) String() string {
-	return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x", m[0], m[1], m[2], m[3], m[4], m[5])
-}
+type MACAddr = bnet.MACAddr
 
 // Handler is an Ethernet handler
 type Handler struct {
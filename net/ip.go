@@ -1,9 +1,12 @@
 package net
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math"
 	"net"
+	"net/netip"
+	"strconv"
 
 	api "github.com/bio-routing/bio-rd/net/api"
 	bmath "github.com/bio-routing/bio-rd/util/math"
@@ -134,17 +137,33 @@ func IPFromBytes(b []byte) (IP, error) {
 
 // IPFromString returns an IP address for a given string
 func IPFromString(str string) (IP, error) {
-	ip := net.ParseIP(str)
-	if ip == nil {
+	addr, err := netip.ParseAddr(str)
+	if err != nil || addr.Zone() != "" {
 		return IP{}, fmt.Errorf("%s is not a valid IP address", str)
 	}
 
-	ip4 := ip.To4()
-	if ip4 != nil {
-		return IPFromBytes(ip4)
+	return ipFromNetIPAddr(addr), nil
+}
+
+// IPFromNetIPAddr converts a `netip.Addr` into an IP address. IPv4-mapped IPv6 addresses are converted into IPv4
+// addresses like in IPFromBytes. The zone of an IPv6 address is dropped as IP does not keep it.
+func IPFromNetIPAddr(addr netip.Addr) (IP, error) {
+	if !addr.IsValid() {
+		return IP{}, fmt.Errorf("invalid address")
+	}
+
+	return ipFromNetIPAddr(addr), nil
+}
+
+func ipFromNetIPAddr(addr netip.Addr) IP {
+	addr = addr.Unmap()
+	if addr.Is4() {
+		b := addr.As4()
+		return IPv4FromOctets(b[0], b[1], b[2], b[3])
 	}
 
-	return IPFromBytes(ip.To16())
+	b := addr.As16()
+	return IPv6(binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:]))
 }
 
 // Equal returns true if ip is equal to other
@@ -175,21 +194,30 @@ func (ip *IP) Compare(other *IP) int8 {
 
 // String returns string representation of an IP address
 func (ip IP) String() string {
+	const maxLen = len("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")
+	return string(ip.AppendString(make([]byte, 0, maxLen)))
+}
+
+// AppendString appends the string representation of ip to dst and returns the extended buffer
+func (ip IP) AppendString(dst []byte) []byte {
 	if !ip.isLegacy {
-		return ip.stringIPv6()
+		return ip.appendIPv6(dst)
 	}
 
-	return ip.stringIPv4()
+	return ip.appendIPv4(dst)
 }
 
-func (ip IP) stringIPv6() string {
-	p := ip.Bytes()
+func (ip IP) appendIPv6(dst []byte) []byte {
+	var p [16]byte
+	binary.BigEndian.PutUint64(p[:8], ip.higher)
+	binary.BigEndian.PutUint64(p[8:], ip.lower)
+
 	// Find longest run of zeros.
 	e0 := -1
 	e1 := -1
-	for i := 0; i < int(ip.SizeBytes()); i += 2 {
+	for i := 0; i < len(p); i += 2 {
 		j := i
-		for j < int(ip.SizeBytes()) && p[j] == 0 && p[j+1] == 0 {
+		for j < len(p) && p[j] == 0 && p[j+1] == 0 {
 			j += 2
 		}
 		if j > i && j-i > e1-e0 {
@@ -204,30 +232,33 @@ func (ip IP) stringIPv6() string {
 		e1 = -1
 	}
 
-	const maxLen = len("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff")
-	b := make([]byte, 0, maxLen)
-
 	// Print with possible :: in place of run of zeros
-	for i := 0; i < int(ip.SizeBytes()); i += 2 {
+	for i := 0; i < len(p); i += 2 {
 		if i == e0 {
-			b = append(b, ':', ':')
+			dst = append(dst, ':', ':')
 			i = e1
-			if i >= int(ip.SizeBytes()) {
+			if i >= len(p) {
 				break
 			}
 		} else if i > 0 {
-			b = append(b, ':')
+			dst = append(dst, ':')
 		}
-		b = appendHex(b, (uint32(p[i])<<8)|uint32(p[i+1]))
+		dst = appendHex(dst, (uint32(p[i])<<8)|uint32(p[i+1]))
 	}
 
-	return string(b)
+	return dst
 }
 
-func (ip IP) stringIPv4() string {
-	b := ip.Bytes()
+func (ip IP) appendIPv4(dst []byte) []byte {
+	v := uint32(ip.lower)
+	for i := 3; i >= 0; i-- {
+		dst = strconv.AppendUint(dst, uint64(uint8(v>>uint(i*8))), 10)
+		if i > 0 {
+			dst = append(dst, '.')
+		}
+	}
 
-	return fmt.Sprintf("%d.%d.%d.%d", b[0], b[1], b[2], b[3])
+	return dst
 }
 
 // Convert i to a hexadecimal string. Leading zeros are not printed.
@@ -320,6 +351,17 @@ func (ip *IP) ToNetIP() net.IP {
 	return net.IP(ip.Bytes())
 }
 
+// ToNetIPAddr converts the IP address in a `netip.Addr`. Link local IPv6 addresses can be scoped using its WithZone method.
+func (ip IP) ToNetIPAddr() netip.Addr {
+	if ip.isLegacy {
+		b := [4]byte{}
+		binary.BigEndian.PutUint32(b[:], ip.ToUint32())
+		return netip.AddrFrom4(b)
+	}
+
+	return netip.AddrFrom16(ip.To16BytesArray())
+}
+
 // BitAtPosition returns the bit at position pos
 func (ip IP) BitAtPosition(pos uint8) bool {
 	if ip.isLegacy {
@@ -3,6 +3,7 @@ package net
 import (
 	"math"
 	"net"
+	"net/netip"
 	"testing"
 
 	"github.com/bio-routing/bio-rd/net/api"
@@ -471,6 +472,112 @@ func TestToNetIP(t *testing.T) {
 	}
 }
 
+func TestIPFromNetIPAddr(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    netip.Addr
+		expected IP
+		wantFail bool
+	}{
+		{
+			name:     "IPv4",
+			input:    netip.MustParseAddr("192.168.1.1"),
+			expected: IPv4FromOctets(192, 168, 1, 1),
+		},
+		{
+			name:     "IPv6",
+			input:    netip.MustParseAddr("2001:678:1e0::cafe"),
+			expected: IPv6FromBlocks(0x2001, 0x678, 0x1e0, 0, 0, 0, 0, 0xcafe),
+		},
+		{
+			name:     "IPv4-mapped IPv6",
+			input:    netip.MustParseAddr("::ffff:192.168.1.1"),
+			expected: IPv4FromOctets(192, 168, 1, 1),
+		},
+		{
+			name:     "IPv6 with zone",
+			input:    netip.MustParseAddr("fe80::1%eth0"),
+			expected: IPv6FromBlocks(0xfe80, 0, 0, 0, 0, 0, 0, 1),
+		},
+		{
+			name:     "Invalid",
+			input:    netip.Addr{},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := IPFromNetIPAddr(test.input)
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestNetIPRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		ip       IP
+		netIP    net.IP
+		netIPStr string
+	}{
+		{
+			name:     "IPv4",
+			ip:       IPv4FromOctets(192, 0, 2, 1),
+			netIP:    net.IP{192, 0, 2, 1},
+			netIPStr: "192.0.2.1",
+		},
+		{
+			name:     "IPv4 zero",
+			ip:       IPv4(0),
+			netIP:    net.IP{0, 0, 0, 0},
+			netIPStr: "0.0.0.0",
+		},
+		{
+			name:     "IPv6",
+			ip:       IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1),
+			netIP:    net.ParseIP("2001:db8::1"),
+			netIPStr: "2001:db8::1",
+		},
+		{
+			name:     "IPv6 link local",
+			ip:       IPv6FromBlocks(0xfe80, 0, 0, 0, 0, 0, 0, 1),
+			netIP:    net.ParseIP("fe80::1"),
+			netIPStr: "fe80::1",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			addr := test.ip.ToNetIPAddr()
+			assert.Equal(t, netip.MustParseAddr(test.netIPStr), addr)
+			assert.Equal(t, test.ip.IsIPv4(), addr.Is4())
+
+			ip, err := IPFromNetIPAddr(addr)
+			assert.NoError(t, err)
+			assert.Equal(t, test.ip, ip)
+
+			assert.Equal(t, test.netIP, test.ip.ToNetIP())
+			ip, err = IPFromBytes(test.ip.ToNetIP())
+			assert.NoError(t, err)
+			assert.Equal(t, test.ip, ip)
+
+			// net.IP to netip.Addr to IP
+			addr, ok := netip.AddrFromSlice(test.netIP)
+			assert.True(t, ok)
+			ip, err = IPFromNetIPAddr(addr)
+			assert.NoError(t, err)
+			assert.Equal(t, test.ip, ip)
+		})
+	}
+}
+
 func TestBitAtPosition(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -563,11 +670,31 @@ func TestIPFromString(t *testing.T) {
 			input:    "2001:678:1e0::cafe",
 			expected: IPv6FromBlocks(0x2001, 0x678, 0x1e0, 0, 0, 0, 0, 0xcafe),
 		},
+		{
+			name:     "ipv4 mapped ipv6",
+			input:    "::ffff:192.168.1.234",
+			expected: IPv4FromOctets(192, 168, 1, 234),
+		},
+		{
+			name:     "ipv4 compatible ipv6",
+			input:    "::192.168.1.234",
+			expected: IPv6FromBlocks(0, 0, 0, 0, 0, 0, 0xc0a8, 0x01ea),
+		},
 		{
 			name:     "invalid",
 			input:    "foo",
 			wantFail: true,
 		},
+		{
+			name:     "ipv4 leading zero",
+			input:    "192.168.01.234",
+			wantFail: true,
+		},
+		{
+			name:     "ipv6 zone",
+			input:    "fe80::1%eth0",
+			wantFail: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,49 @@
+package net
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+)
+
+// MACAddrLength is the length of a MAC address in bytes
+const MACAddrLength = 6
+
+// MACAddr represents a MAC address. ISIS uses it as SNPA (subnetwork point of attachment) on LAN interfaces.
+type MACAddr [MACAddrLength]byte
+
+// ParseMACAddr parses a MAC address in colon (00:11:22:33:44:55), hyphen (00-11-22-33-44-55) or dot (0011.2233.4455) notation
+func ParseMACAddr(s string) (MACAddr, error) {
+	hw, err := net.ParseMAC(s)
+	if err != nil {
+		return MACAddr{}, fmt.Errorf("unable to parse MAC address %q: %w", s, err)
+	}
+
+	return MACAddrFromBytes(hw)
+}
+
+// MACAddrFromBytes creates a MAC address from its wire representation
+func MACAddrFromBytes(b []byte) (MACAddr, error) {
+	m := MACAddr{}
+	if len(b) != MACAddrLength {
+		return m, fmt.Errorf("invalid MAC address length: %d", len(b))
+	}
+
+	copy(m[:], b)
+	return m, nil
+}
+
+// String returns the colon separated representation of a MAC address
+func (m MACAddr) String() string {
+	return fmt.Sprintf("%02x:%02x:%02x:%02x:%02x:%02x", m[0], m[1], m[2], m[3], m[4], m[5])
+}
+
+// Compare compares m and n as unsigned integers (as used for the DIS election tie-break). Returns 1 if m > n, -1 if m < n, 0 if equal.
+func (m MACAddr) Compare(n MACAddr) int8 {
+	return int8(bytes.Compare(m[:], n[:]))
+}
+
+// Serialize writes the wire representation of m into buf
+func (m MACAddr) Serialize(buf *bytes.Buffer) {
+	buf.Write(m[:])
+}
@@ -0,0 +1,107 @@
+package net
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMACAddr(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantFail bool
+		expected MACAddr
+	}{
+		{
+			name:     "Colon notation",
+			input:    "00:1b:21:aa:bb:cc",
+			expected: MACAddr{0x00, 0x1b, 0x21, 0xaa, 0xbb, 0xcc},
+		},
+		{
+			name:     "Hyphen notation",
+			input:    "00-1B-21-AA-BB-CC",
+			expected: MACAddr{0x00, 0x1b, 0x21, 0xaa, 0xbb, 0xcc},
+		},
+		{
+			name:     "Dot notation",
+			input:    "001b.21aa.bbcc",
+			expected: MACAddr{0x00, 0x1b, 0x21, 0xaa, 0xbb, 0xcc},
+		},
+		{
+			name:     "EUI-64 is no MAC address",
+			input:    "00:1b:21:aa:bb:cc:dd:ee",
+			wantFail: true,
+		},
+		{
+			name:     "Invalid",
+			input:    "00:1b:21:aa:bb",
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		m, err := ParseMACAddr(test.input)
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, m, test.name)
+		assert.Equal(t, "00:1b:21:aa:bb:cc", m.String(), test.name)
+	}
+}
+
+func TestMACAddrCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        MACAddr
+		b        MACAddr
+		expected int8
+	}{
+		{
+			name:     "Equal",
+			a:        MACAddr{1, 2, 3, 4, 5, 6},
+			b:        MACAddr{1, 2, 3, 4, 5, 6},
+			expected: 0,
+		},
+		{
+			name:     "Greater in last octet",
+			a:        MACAddr{1, 2, 3, 4, 5, 7},
+			b:        MACAddr{1, 2, 3, 4, 5, 6},
+			expected: 1,
+		},
+		{
+			name:     "Smaller in first octet",
+			a:        MACAddr{0x01, 0xff, 0xff, 0xff, 0xff, 0xff},
+			b:        MACAddr{0x02, 0, 0, 0, 0, 0},
+			expected: -1,
+		},
+		{
+			name:     "Compared unsigned",
+			a:        MACAddr{0xfe, 0, 0, 0, 0, 0},
+			b:        MACAddr{0x7f, 0, 0, 0, 0, 0},
+			expected: 1,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.a.Compare(test.b), test.name)
+	}
+}
+
+func TestMACAddrSerialize(t *testing.T) {
+	m := MACAddr{0x00, 0x1b, 0x21, 0xaa, 0xbb, 0xcc}
+	buf := bytes.NewBuffer(nil)
+	m.Serialize(buf)
+	assert.Equal(t, []byte{0x00, 0x1b, 0x21, 0xaa, 0xbb, 0xcc}, buf.Bytes())
+
+	decoded, err := MACAddrFromBytes(buf.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, m, decoded)
+
+	_, err = MACAddrFromBytes([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
@@ -1,9 +1,12 @@
 package net
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math"
+	"math/bits"
 	gonet "net"
+	"net/netip"
 	"strconv"
 	"strings"
 
@@ -37,27 +40,59 @@ func NewPrefixFromProtoPrefix(pfx *api.Prefix) *Prefix {
 
 // PrefixFromString converts prefix from string representation to Prefix
 func PrefixFromString(s string) (*Prefix, error) {
-	parts := strings.Split(s, "/")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid format: %q", s)
-	}
-
-	ip, err := IPFromString(parts[0])
+	pfx, err := ParsePrefix(s)
 	if err != nil {
 		return nil, err
 	}
 
-	l, err := strconv.Atoi(parts[1])
+	return &pfx, nil
+}
+
+// ParsePrefix parses a prefix in CIDR notation without allocating on success
+func ParsePrefix(s string) (Prefix, error) {
+	i := strings.IndexByte(s, '/')
+	if i < 0 {
+		return Prefix{}, fmt.Errorf("invalid format: %q", s)
+	}
+
+	ip, err := IPFromString(s[:i])
 	if err != nil {
-		return nil, fmt.Errorf("unable to convert to int: %w", err)
+		return Prefix{}, err
 	}
 
-	return &Prefix{
+	l, ok := parsePfxLen(s[i+1:])
+	if !ok {
+		return Prefix{}, fmt.Errorf("invalid prefix length: %q", s[i+1:])
+	}
+
+	if l > int(ip.SizeBytes())*8 {
+		return Prefix{}, fmt.Errorf("prefix length %d exceeds address length", l)
+	}
+
+	return Prefix{
 		addr: ip,
 		len:  uint8(l),
 	}, nil
 }
 
+// parsePfxLen parses a prefix length consisting of up to 3 decimal digits
+func parsePfxLen(s string) (int, bool) {
+	if len(s) == 0 || len(s) > 3 {
+		return 0, false
+	}
+
+	l := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, false
+		}
+
+		l = l*10 + int(s[i]-'0')
+	}
+
+	return l, true
+}
+
 // ToProto converts prefix to proto prefix
 func (p Prefix) ToProto() *api.Prefix {
 	return &api.Prefix{
@@ -121,7 +156,57 @@ func (pfx *Prefix) Len() uint8 {
 
 // String returns a string representation of pfx
 func (pfx *Prefix) String() string {
-	return fmt.Sprintf("%s/%d", pfx.addr.String(), pfx.len)
+	const maxLen = len("ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128")
+	return string(pfx.AppendString(make([]byte, 0, maxLen)))
+}
+
+// AppendString appends the string representation of pfx to dst and returns the extended buffer
+func (pfx *Prefix) AppendString(dst []byte) []byte {
+	dst = pfx.addr.AppendString(dst)
+	dst = append(dst, '/')
+	return strconv.AppendUint(dst, uint64(pfx.len), 10)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (pfx Prefix) MarshalText() ([]byte, error) {
+	return pfx.AppendString(nil), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (pfx *Prefix) UnmarshalText(text []byte) error {
+	p, err := ParsePrefix(string(text))
+	if err != nil {
+		return err
+	}
+
+	*pfx = p
+	return nil
+}
+
+// PrefixFromNetIPPrefix converts a `netip.Prefix` into a Prefix. Prefixes of IPv4-mapped IPv6 addresses of length 96
+// or more are converted into IPv4 prefixes. The zone of an IPv6 address is dropped.
+func PrefixFromNetIPPrefix(p netip.Prefix) (Prefix, error) {
+	if !p.IsValid() {
+		return Prefix{}, fmt.Errorf("invalid prefix")
+	}
+
+	addr := p.Addr()
+	l := p.Bits()
+	if addr.Is4In6() {
+		if l < 96 {
+			b := addr.As16()
+			return NewPfx(IPv6(binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])), uint8(l)), nil
+		}
+
+		l -= 96
+	}
+
+	return NewPfx(ipFromNetIPAddr(addr), uint8(l)), nil
+}
+
+// ToNetIPPrefix converts the Prefix in a `netip.Prefix`
+func (pfx Prefix) ToNetIPPrefix() netip.Prefix {
+	return netip.PrefixFrom(pfx.addr.ToNetIPAddr(), int(pfx.len))
 }
 
 // GetIPNet returns the gonet.IP object for a Prefix object
@@ -139,9 +224,9 @@ func (pfx *Prefix) GetIPNet() *gonet.IPNet {
 	return &dstNetwork
 }
 
-// Contains checks if x is a subnet of or equal to pfx
+// Contains checks if x is a more specific of pfx. Prefixes of different address families never contain each other.
 func (pfx *Prefix) Contains(x *Prefix) bool {
-	if x.len <= pfx.len {
+	if x.len <= pfx.len || pfx.addr.isLegacy != x.addr.isLegacy {
 		return false
 	}
 
@@ -160,15 +245,15 @@ func (pfx *Prefix) containsIPv4(x *Prefix) bool {
 func (pfx *Prefix) containsIPv6(x *Prefix) bool {
 	var maskHigh, maskLow uint64
 	if pfx.len <= 64 {
-		maskHigh = math.MaxUint32 << (64 - pfx.len)
+		maskHigh = math.MaxUint64 << (64 - pfx.len)
 		maskLow = uint64(0)
 	} else {
-		maskHigh = math.MaxUint32
-		maskLow = math.MaxUint32 << (128 - pfx.len)
+		maskHigh = math.MaxUint64
+		maskLow = math.MaxUint64 << (128 - pfx.len)
 	}
 
-	return pfx.addr.higher&maskHigh&maskHigh == x.addr.higher&maskHigh&maskHigh &&
-		pfx.addr.lower&maskHigh&maskLow == x.addr.lower&maskHigh&maskLow
+	return pfx.addr.higher&maskHigh == x.addr.higher&maskHigh &&
+		pfx.addr.lower&maskLow == x.addr.lower&maskLow
 }
 
 // Equal checks if pfx and x are equal
@@ -186,6 +271,10 @@ func (pfx *Prefix) GetSupernet(x *Prefix) Prefix {
 }
 
 func (pfx *Prefix) supernetIPv4(x *Prefix) Prefix {
+	if min(pfx.len, x.len) == 0 {
+		return NewPfx(IPv4(0), 0)
+	}
+
 	maxPfxLen := min(pfx.len, x.len) - 1
 	a := pfx.addr.ToUint32() >> (32 - maxPfxLen)
 	b := x.addr.ToUint32() >> (32 - maxPfxLen)
@@ -203,34 +292,13 @@ func (pfx *Prefix) supernetIPv4(x *Prefix) Prefix {
 }
 
 func (pfx *Prefix) supernetIPv6(x *Prefix) Prefix {
-	maxPfxLen := min(pfx.len, x.len)
-
-	a := pfx.addr.BitAtPosition(1)
-	b := x.addr.BitAtPosition(1)
-	pfxLen := uint8(0)
-	mask := uint64(0)
-	for a == b && pfxLen < maxPfxLen {
-		a = pfx.addr.BitAtPosition(pfxLen + 2)
-		b = x.addr.BitAtPosition(pfxLen + 2)
-		pfxLen++
-
-		if pfxLen == 64 {
-			mask = 0
-		}
-
-		m := pfxLen % 64
-		mask = mask + uint64(1)<<(64-m)
-	}
-
-	if pfxLen == 0 {
-		return NewPfx(IPv6(0, 0), pfxLen)
-	}
-
-	if pfxLen > 64 {
-		return NewPfx(IPv6(pfx.addr.higher, pfx.addr.lower&mask), pfxLen)
+	pfxLen := uint8(bits.LeadingZeros64(pfx.addr.higher ^ x.addr.higher))
+	if pfxLen == 64 {
+		pfxLen += uint8(bits.LeadingZeros64(pfx.addr.lower ^ x.addr.lower))
 	}
 
-	return NewPfx(IPv6(pfx.addr.higher&mask, 0), pfxLen)
+	supernet := NewPfx(pfx.addr, min(pfxLen, min(pfx.len, x.len)))
+	return NewPfx(supernet.BaseAddr(), supernet.len)
 }
 
 // Valid checks if all bits outside of the prefix lengths range are zero (no host bit set)
@@ -0,0 +1,116 @@
+package net
+
+import (
+	"fmt"
+)
+
+// maxIterationBits limits iterators to 2^maxIterationBits subnets or host addresses to guard against the enormous
+// ranges of short IPv6 prefixes
+const maxIterationBits = 32
+
+// SubnetIterator iterates over the subnets of a fixed length within a prefix
+type SubnetIterator struct {
+	next      IP
+	len       uint8
+	remaining uint64
+}
+
+// HostIterator iterates over the host addresses of a prefix
+type HostIterator struct {
+	next      IP
+	remaining uint64
+}
+
+func (pfx *Prefix) addrLen() uint8 {
+	if pfx.addr.isLegacy {
+		return 32
+	}
+
+	return 128
+}
+
+// Subnets gets an iterator over the subnets of prefix length length within pfx in ascending order. It fails if length
+// is shorter than pfx or longer than an address or if pfx would be divided into more than 2^32 subnets.
+func (pfx *Prefix) Subnets(length uint8) (*SubnetIterator, error) {
+	if length < pfx.len || length > pfx.addrLen() {
+		return nil, fmt.Errorf("unable to divide %s into subnets of length %d", pfx.String(), length)
+	}
+
+	if length-pfx.len > maxIterationBits {
+		return nil, fmt.Errorf("dividing %s into subnets of length %d exceeds 2^%d subnets", pfx.String(), length, maxIterationBits)
+	}
+
+	return &SubnetIterator{
+		next:      pfx.BaseAddr(),
+		len:       length,
+		remaining: 1 << (length - pfx.len),
+	}, nil
+}
+
+// Next gets the next subnet. false is returned once all subnets were iterated.
+func (it *SubnetIterator) Next() (Prefix, bool) {
+	if it.remaining == 0 {
+		return Prefix{}, false
+	}
+
+	ret := NewPfx(it.next, it.len)
+	it.remaining--
+	it.next = it.next.addPowerOfTwo(ret.addrLen() - it.len)
+
+	return ret, true
+}
+
+// Hosts gets an iterator over the host addresses of pfx in ascending order. The network and broadcast addresses of IPv4
+// prefixes shorter than /31 (RFC3021) and the subnet-router anycast address of IPv6 prefixes shorter than /127 (RFC6164)
+// are skipped. It fails for prefixes of more than 2^32 addresses.
+func (pfx *Prefix) Hosts() (*HostIterator, error) {
+	hostBits := pfx.addrLen() - pfx.len
+	if hostBits > maxIterationBits {
+		return nil, fmt.Errorf("%s exceeds 2^%d host addresses", pfx.String(), maxIterationBits)
+	}
+
+	it := &HostIterator{
+		next:      pfx.BaseAddr(),
+		remaining: 1 << hostBits,
+	}
+
+	if hostBits > 1 {
+		it.next = it.next.Next()
+		it.remaining--
+
+		if pfx.addr.isLegacy {
+			it.remaining--
+		}
+	}
+
+	return it, nil
+}
+
+// Next gets the next host address. false is returned once all host addresses were iterated.
+func (it *HostIterator) Next() (IP, bool) {
+	if it.remaining == 0 {
+		return IP{}, false
+	}
+
+	ret := it.next
+	it.remaining--
+	it.next = it.next.Next()
+
+	return ret, true
+}
+
+// addPowerOfTwo adds 2^n to ip
+func (ip IP) addPowerOfTwo(n uint8) IP {
+	if n >= 64 {
+		ip.higher += 1 << (n - 64)
+		return ip
+	}
+
+	lower := ip.lower + 1<<n
+	if lower < ip.lower {
+		ip.higher++
+	}
+
+	ip.lower = lower
+	return ip
+}
@@ -0,0 +1,226 @@
+package net
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func collectSubnets(it *SubnetIterator) []Prefix {
+	ret := make([]Prefix, 0)
+	for pfx, ok := it.Next(); ok; pfx, ok = it.Next() {
+		ret = append(ret, pfx)
+	}
+
+	return ret
+}
+
+func collectHosts(it *HostIterator) []IP {
+	ret := make([]IP, 0)
+	for addr, ok := it.Next(); ok; addr, ok = it.Next() {
+		ret = append(ret, addr)
+	}
+
+	return ret
+}
+
+func TestSubnets(t *testing.T) {
+	tests := []struct {
+		name     string
+		pfx      Prefix
+		length   uint8
+		expected []Prefix
+		wantFail bool
+	}{
+		{
+			name:   "IPv4 /22 into /24",
+			pfx:    NewPfx(IPv4FromOctets(10, 0, 1, 0), 22),
+			length: 24,
+			expected: []Prefix{
+				NewPfx(IPv4FromOctets(10, 0, 0, 0), 24),
+				NewPfx(IPv4FromOctets(10, 0, 1, 0), 24),
+				NewPfx(IPv4FromOctets(10, 0, 2, 0), 24),
+				NewPfx(IPv4FromOctets(10, 0, 3, 0), 24),
+			},
+		},
+		{
+			name:   "IPv4 same length",
+			pfx:    NewPfx(IPv4FromOctets(192, 0, 2, 0), 24),
+			length: 24,
+			expected: []Prefix{
+				NewPfx(IPv4FromOctets(192, 0, 2, 0), 24),
+			},
+		},
+		{
+			name:   "IPv4 /31 into /32",
+			pfx:    NewPfx(IPv4FromOctets(255, 255, 255, 254), 31),
+			length: 32,
+			expected: []Prefix{
+				NewPfx(IPv4FromOctets(255, 255, 255, 254), 32),
+				NewPfx(IPv4FromOctets(255, 255, 255, 255), 32),
+			},
+		},
+		{
+			name:   "IPv6 /63 into /64",
+			pfx:    NewPfx(IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 63),
+			length: 64,
+			expected: []Prefix{
+				NewPfx(IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 64),
+				NewPfx(IPv6FromBlocks(0x2001, 0xdb8, 0, 1, 0, 0, 0, 0), 64),
+			},
+		},
+		{
+			name:   "IPv6 /64 into /66",
+			pfx:    NewPfx(IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 64),
+			length: 66,
+			expected: []Prefix{
+				NewPfx(IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 66),
+				NewPfx(IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0x4000, 0, 0, 0), 66),
+				NewPfx(IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0x8000, 0, 0, 0), 66),
+				NewPfx(IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0xc000, 0, 0, 0), 66),
+			},
+		},
+		{
+			name:     "Length shorter than prefix",
+			pfx:      NewPfx(IPv4FromOctets(192, 0, 2, 0), 24),
+			length:   23,
+			wantFail: true,
+		},
+		{
+			name:     "Length longer than IPv4 address",
+			pfx:      NewPfx(IPv4FromOctets(192, 0, 2, 0), 24),
+			length:   33,
+			wantFail: true,
+		},
+		{
+			name:     "Too many IPv6 subnets",
+			pfx:      NewPfx(IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32),
+			length:   128,
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			it, err := test.pfx.Ptr().Subnets(test.length)
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, test.expected, collectSubnets(it))
+		})
+	}
+}
+
+func TestSubnetsBounded(t *testing.T) {
+	it, err := NewPfx(IPv6(0, 0), 0).Ptr().Subnets(32)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	first, _ := it.Next()
+	second, _ := it.Next()
+	assert.Equal(t, NewPfx(IPv6(0, 0), 32), first)
+	assert.Equal(t, NewPfx(IPv6FromBlocks(0, 1, 0, 0, 0, 0, 0, 0), 32), second)
+	assert.Equal(t, uint64(1<<32-2), it.remaining)
+}
+
+func TestHosts(t *testing.T) {
+	tests := []struct {
+		name     string
+		pfx      Prefix
+		expected []IP
+		wantFail bool
+	}{
+		{
+			name: "IPv4 /29",
+			pfx:  NewPfx(IPv4FromOctets(192, 0, 2, 8), 29),
+			expected: []IP{
+				IPv4FromOctets(192, 0, 2, 9),
+				IPv4FromOctets(192, 0, 2, 10),
+				IPv4FromOctets(192, 0, 2, 11),
+				IPv4FromOctets(192, 0, 2, 12),
+				IPv4FromOctets(192, 0, 2, 13),
+				IPv4FromOctets(192, 0, 2, 14),
+			},
+		},
+		{
+			name: "IPv4 /31",
+			pfx:  NewPfx(IPv4FromOctets(192, 0, 2, 0), 31),
+			expected: []IP{
+				IPv4FromOctets(192, 0, 2, 0),
+				IPv4FromOctets(192, 0, 2, 1),
+			},
+		},
+		{
+			name: "IPv4 /32",
+			pfx:  NewPfx(IPv4FromOctets(192, 0, 2, 1), 32),
+			expected: []IP{
+				IPv4FromOctets(192, 0, 2, 1),
+			},
+		},
+		{
+			name: "IPv6 /126",
+			pfx:  NewPfx(IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 126),
+			expected: []IP{
+				IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1),
+				IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 2),
+				IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 3),
+			},
+		},
+		{
+			name: "IPv6 /127",
+			pfx:  NewPfx(IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 127),
+			expected: []IP{
+				IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0),
+				IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1),
+			},
+		},
+		{
+			name:     "Too many IPv6 hosts",
+			pfx:      NewPfx(IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 64),
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			it, err := test.pfx.Ptr().Hosts()
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, test.expected, collectHosts(it))
+		})
+	}
+}
+
+func TestHostsBounded(t *testing.T) {
+	it, err := NewPfx(IPv4(0), 0).Ptr().Hosts()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	first, _ := it.Next()
+	assert.Equal(t, IPv4FromOctets(0, 0, 0, 1), first)
+	assert.Equal(t, uint64(1<<32-3), it.remaining, "network and broadcast address must be skipped")
+
+	it, err = NewPfx(IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 96).Ptr().Hosts()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	first, _ = it.Next()
+	assert.Equal(t, IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1), first)
+	assert.Equal(t, uint64(1<<32-2), it.remaining)
+}
@@ -1,7 +1,12 @@
 package net
 
 import (
+	"encoding/json"
+	"fmt"
 	gonet "net"
+	"net/netip"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/bio-routing/bio-rd/net/api"
@@ -57,6 +62,84 @@ func TestNewPfxFromIPNet(t *testing.T) {
 	}
 }
 
+func TestPrefixFromNetIPPrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    netip.Prefix
+		expected Prefix
+		wantFail bool
+	}{
+		{
+			name:     "IPv4",
+			input:    netip.MustParsePrefix("192.0.2.0/24"),
+			expected: NewPfx(IPv4FromOctets(192, 0, 2, 0), 24),
+		},
+		{
+			name:     "IPv6",
+			input:    netip.MustParsePrefix("2001:db8::/32"),
+			expected: NewPfx(IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32),
+		},
+		{
+			name:     "IPv4-mapped IPv6",
+			input:    netip.MustParsePrefix("::ffff:192.0.2.0/120"),
+			expected: NewPfx(IPv4FromOctets(192, 0, 2, 0), 24),
+		},
+		{
+			name:     "IPv4-mapped IPv6 shorter than the mapping",
+			input:    netip.MustParsePrefix("::ffff:192.0.2.0/80"),
+			expected: NewPfx(IPv6FromBlocks(0, 0, 0, 0, 0, 0xffff, 0xc000, 0x0200), 80),
+		},
+		{
+			name:     "Invalid",
+			input:    netip.Prefix{},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := PrefixFromNetIPPrefix(test.input)
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestNetIPPrefixRoundTrip(t *testing.T) {
+	tests := []string{
+		"0.0.0.0/0",
+		"192.0.2.0/24",
+		"192.0.2.1/32",
+		"::/0",
+		"2001:db8::/32",
+		"2001:db8::1/128",
+	}
+
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			pfx, err := ParsePrefix(test)
+			assert.NoError(t, err)
+
+			p := pfx.ToNetIPPrefix()
+			assert.Equal(t, netip.MustParsePrefix(test), p)
+
+			res, err := PrefixFromNetIPPrefix(p)
+			assert.NoError(t, err)
+			assert.Equal(t, pfx, res)
+
+			_, ipNet, err := gonet.ParseCIDR(test)
+			assert.NoError(t, err)
+			assert.Equal(t, ipNet, pfx.GetIPNet())
+			assert.Equal(t, &pfx, NewPfxFromIPNet(pfx.GetIPNet()))
+		})
+	}
+}
+
 func TestPrefixToProto(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -271,6 +354,36 @@ func TestGetSupernet(t *testing.T) {
 				len:  127,
 			},
 		},
+		{
+			name: "Supernet of 2001:678:1e0:100::1/128 and 2001:678:1e0:100:8000::/65 -> 2001:678:1e0:100::/64",
+			a: &Prefix{
+				addr: IPv6FromBlocks(0x2001, 0x678, 0x1e0, 0x100, 0, 0, 0, 1),
+				len:  128,
+			},
+			b: &Prefix{
+				addr: IPv6FromBlocks(0x2001, 0x678, 0x1e0, 0x100, 0x8000, 0, 0, 0),
+				len:  65,
+			},
+			expected: &Prefix{
+				addr: IPv6FromBlocks(0x2001, 0x678, 0x1e0, 0x100, 0, 0, 0, 0),
+				len:  64,
+			},
+		},
+		{
+			name: "Supernet of 10.0.0.0/8 and 0.0.0.0/0 -> 0.0.0.0/0",
+			a: &Prefix{
+				addr: IPv4FromOctets(10, 0, 0, 0),
+				len:  8,
+			},
+			b: &Prefix{
+				addr: IPv4(0),
+				len:  0,
+			},
+			expected: &Prefix{
+				addr: IPv4(0),
+				len:  0,
+			},
+		},
 		{
 			name: "Supernet of all ones and all zeros -> ::/0",
 			a: &Prefix{
@@ -436,6 +549,105 @@ func TestContains(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			a: &Prefix{
+				addr: IPv6(0, 0),
+				len:  0,
+			},
+			b: &Prefix{
+				addr: IPv6FromBlocks(0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff, 0xffff),
+				len:  128,
+			},
+			expected: true,
+		},
+		{
+			a: &Prefix{
+				addr: IPv6FromBlocks(0x2001, 0xdb8, 0x1, 0, 0, 0, 0, 0),
+				len:  48,
+			},
+			b: &Prefix{
+				addr: IPv6FromBlocks(0x3001, 0xdb8, 0x1, 0, 0, 0, 0, 0),
+				len:  64,
+			},
+			expected: false,
+		},
+		{
+			a: &Prefix{
+				addr: IPv6FromBlocks(0x2001, 0xdb8, 0x1, 0x1, 0, 0, 0, 0),
+				len:  64,
+			},
+			b: &Prefix{
+				addr: IPv6FromBlocks(0x2001, 0xdb8, 0x1, 0x1, 0, 0, 0, 1),
+				len:  128,
+			},
+			expected: true,
+		},
+		{
+			a: &Prefix{
+				addr: IPv6FromBlocks(0x2001, 0xdb8, 0x1, 0x1, 0, 0, 0, 0),
+				len:  64,
+			},
+			b: &Prefix{
+				addr: IPv6FromBlocks(0x2001, 0xdb8, 0x1, 0x2, 0, 0, 0, 1),
+				len:  128,
+			},
+			expected: false,
+		},
+		{
+			a: &Prefix{
+				addr: IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 2),
+				len:  127,
+			},
+			b: &Prefix{
+				addr: IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 3),
+				len:  128,
+			},
+			expected: true,
+		},
+		{
+			a: &Prefix{
+				addr: IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 2),
+				len:  127,
+			},
+			b: &Prefix{
+				addr: IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0x8000, 3),
+				len:  128,
+			},
+			expected: false,
+		},
+		{
+			a: &Prefix{
+				addr: IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 3),
+				len:  128,
+			},
+			b: &Prefix{
+				addr: IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 3),
+				len:  128,
+			},
+			expected: false,
+		},
+		{
+			a: &Prefix{
+				addr: IPv4(0),
+				len:  0,
+			},
+			b: &Prefix{
+				addr: IPv6FromBlocks(0, 0, 0, 0, 0, 0, 0x0a00, 0),
+				len:  120,
+			},
+			expected: false,
+		},
+		{
+			a: &Prefix{
+				addr: IPv6(0, 0),
+				len:  0,
+			},
+			b: &Prefix{
+				addr: IPv4(100),
+				len:  32,
+			},
+			expected: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -520,11 +732,52 @@ func TestString(t *testing.T) {
 			pfx:      NewPfx(IPv4FromOctets(10, 0, 0, 0), 16),
 			expected: "10.0.0.0/16",
 		},
+		{
+			name:     "IPv4 default route",
+			pfx:      NewPfx(IPv4(0), 0),
+			expected: "0.0.0.0/0",
+		},
+		{
+			name:     "IPv4 host route",
+			pfx:      NewPfx(IPv4FromOctets(255, 255, 255, 255), 32),
+			expected: "255.255.255.255/32",
+		},
+		{
+			name:     "IPv6 default route",
+			pfx:      NewPfx(IPv6(0, 0), 0),
+			expected: "::/0",
+		},
+		{
+			name:     "IPv6 host route",
+			pfx:      NewPfx(IPv6(^uint64(0), ^uint64(0)), 128),
+			expected: "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128",
+		},
+		{
+			name:     "IPv6 prefix",
+			pfx:      NewPfx(IPv6FromBlocks(0x2001, 0x678, 0x1e0, 0, 0, 0, 0, 0), 48),
+			expected: "2001:678:1e0::/48",
+		},
 	}
 
 	for _, test := range tests {
 		res := test.pfx.String()
 		assert.Equal(t, res, test.expected, "Unexpected result for %q")
+
+		buf := []byte("prefix: ")
+		assert.Equal(t, "prefix: "+test.expected, string(test.pfx.AppendString(buf)), test.name)
+	}
+}
+
+func TestAppendStringAllocs(t *testing.T) {
+	buf := make([]byte, 0, 64)
+	for _, pfx := range []Prefix{
+		NewPfx(IPv4FromOctets(10, 0, 0, 0), 8),
+		NewPfx(IPv6FromBlocks(0x2001, 0x678, 0x1e0, 0, 0, 0, 0, 0), 48),
+	} {
+		allocs := testing.AllocsPerRun(100, func() {
+			buf = pfx.AppendString(buf[:0])
+		})
+		assert.Equal(t, float64(0), allocs, pfx.String())
 	}
 }
 
@@ -650,6 +903,36 @@ func TestValid(t *testing.T) {
 			input:    "2a05:1234:abcd:face:b00c::aa/128",
 			expected: true,
 		},
+		{
+			name:     "IPv6 default",
+			input:    "::/0",
+			expected: true,
+		},
+		{
+			name:     "IPv6 /0 with host bits set",
+			input:    "::1/0",
+			expected: false,
+		},
+		{
+			name:     "IPv6 /127",
+			input:    "2a05:1234::2/127",
+			expected: true,
+		},
+		{
+			name:     "IPv6 /127 with host bit set",
+			input:    "2a05:1234::3/127",
+			expected: false,
+		},
+		{
+			name:     "IPv4 default",
+			input:    "0.0.0.0/0",
+			expected: true,
+		},
+		{
+			name:     "IPv4 /0 with host bits set",
+			input:    "0.0.0.1/0",
+			expected: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -689,6 +972,26 @@ func TestBaseAddr(t *testing.T) {
 			input:    NewPfx(IPv6FromBlocks(10, 10, 20, 20, 1, 0, 5, 1), 126).Ptr(),
 			expected: IPv6FromBlocks(10, 10, 20, 20, 1, 0, 5, 0),
 		},
+		{
+			name:     "IPv6 /0",
+			input:    NewPfx(IPv6FromBlocks(10, 10, 20, 20, 1, 0, 5, 1), 0).Ptr(),
+			expected: IPv6(0, 0),
+		},
+		{
+			name:     "IPv6 /127",
+			input:    NewPfx(IPv6FromBlocks(10, 10, 20, 20, 1, 0, 5, 3), 127).Ptr(),
+			expected: IPv6FromBlocks(10, 10, 20, 20, 1, 0, 5, 2),
+		},
+		{
+			name:     "IPv6 /128",
+			input:    NewPfx(IPv6FromBlocks(10, 10, 20, 20, 1, 0, 5, 3), 128).Ptr(),
+			expected: IPv6FromBlocks(10, 10, 20, 20, 1, 0, 5, 3),
+		},
+		{
+			name:     "IPv4 /0",
+			input:    NewPfx(IPv4FromOctets(10, 1, 1, 2), 0).Ptr(),
+			expected: IPv4(0),
+		},
 	}
 
 	for _, test := range tests {
@@ -729,3 +1032,207 @@ func TestPrefixFromString(t *testing.T) {
 		assert.Equal(t, test.wantFail, err != nil, test.name)
 	}
 }
+
+func TestParsePrefix(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected Prefix
+		wantFail bool
+	}{
+		{
+			name:     "IPv4 prefix",
+			input:    "10.0.0.0/8",
+			expected: NewPfx(IPv4FromOctets(10, 0, 0, 0), 8),
+		},
+		{
+			name:     "IPv4 default route",
+			input:    "0.0.0.0/0",
+			expected: NewPfx(IPv4(0), 0),
+		},
+		{
+			name:     "IPv4 host route",
+			input:    "192.168.1.1/32",
+			expected: NewPfx(IPv4FromOctets(192, 168, 1, 1), 32),
+		},
+		{
+			name:     "IPv4 mapped IPv6 address",
+			input:    "::ffff:192.168.1.0/24",
+			expected: NewPfx(IPv4FromOctets(192, 168, 1, 0), 24),
+		},
+		{
+			name:     "IPv6 prefix",
+			input:    "2001:678:1e0::/48",
+			expected: NewPfx(IPv6FromBlocks(0x2001, 0x678, 0x1e0, 0, 0, 0, 0, 0), 48),
+		},
+		{
+			name:     "IPv6 default route",
+			input:    "::/0",
+			expected: NewPfx(IPv6(0, 0), 0),
+		},
+		{
+			name:     "IPv6 host route",
+			input:    "ffff:ffff:ffff:ffff:ffff:ffff:ffff:ffff/128",
+			expected: NewPfx(IPv6(^uint64(0), ^uint64(0)), 128),
+		},
+		{
+			name:     "IPv4 length too long",
+			input:    "10.0.0.0/33",
+			wantFail: true,
+		},
+		{
+			name:     "IPv6 length too long",
+			input:    "2001:678:1e0::/129",
+			wantFail: true,
+		},
+		{
+			name:     "Length overflowing uint8",
+			input:    "2001:678:1e0::/264",
+			wantFail: true,
+		},
+		{
+			name:     "Empty length",
+			input:    "10.0.0.0/",
+			wantFail: true,
+		},
+		{
+			name:     "Signed length",
+			input:    "10.0.0.0/+8",
+			wantFail: true,
+		},
+		{
+			name:     "Two slashes",
+			input:    "10.0.0.0/8/8",
+			wantFail: true,
+		},
+		{
+			name:     "IPv6 zone",
+			input:    "fe80::1%eth0/64",
+			wantFail: true,
+		},
+		{
+			name:     "Empty",
+			input:    "",
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		pfx, err := ParsePrefix(test.input)
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, pfx, test.name)
+	}
+}
+
+func TestParsePrefixRoundTrip(t *testing.T) {
+	for _, s := range []string{"0.0.0.0/0", "10.0.0.0/8", "100.64.1.1/32", "::/0", "2001:db8::/32", "2001:db8::1/128", "2001:db8:0:1::/64"} {
+		pfx, err := ParsePrefix(s)
+		assert.NoError(t, err, s)
+		assert.Equal(t, s, pfx.String())
+
+		allocs := testing.AllocsPerRun(100, func() {
+			ParsePrefix(s)
+		})
+		assert.Equal(t, float64(0), allocs, s)
+	}
+}
+
+func TestPrefixJSON(t *testing.T) {
+	type export struct {
+		Prefix Prefix `json:"prefix"`
+	}
+
+	in := export{
+		Prefix: NewPfx(IPv6FromBlocks(0x2001, 0x678, 0x1e0, 0, 0, 0, 0, 0), 48),
+	}
+
+	b, err := json.Marshal(in)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"prefix":"2001:678:1e0::/48"}`, string(b))
+
+	out := export{}
+	assert.NoError(t, json.Unmarshal(b, &out))
+	assert.Equal(t, in, out)
+
+	assert.Error(t, json.Unmarshal([]byte(`{"prefix":"10.0.0.0/33"}`), &out))
+}
+
+func BenchmarkPrefixString(b *testing.B) {
+	for _, pfx := range []Prefix{
+		NewPfx(IPv4FromOctets(10, 0, 0, 0), 8),
+		NewPfx(IPv6FromBlocks(0x2001, 0x678, 0x1e0, 0, 0, 0, 0, 0), 48),
+	} {
+		b.Run("Sprintf-"+pfx.String(), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				_ = fmt.Sprintf("%s/%d", pfx.addr.String(), pfx.len)
+			}
+		})
+
+		b.Run("String-"+pfx.String(), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				_ = pfx.String()
+			}
+		})
+
+		buf := make([]byte, 0, 64)
+		b.Run("AppendString-"+pfx.String(), func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				buf = pfx.AppendString(buf[:0])
+			}
+		})
+	}
+}
+
+func BenchmarkParsePrefix(b *testing.B) {
+	for _, s := range []string{"10.0.0.0/8", "2001:678:1e0::/48"} {
+		b.Run("Legacy-"+s, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				legacyPrefixFromString(s)
+			}
+		})
+
+		b.Run("ParsePrefix-"+s, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				ParsePrefix(s)
+			}
+		})
+	}
+}
+
+// legacyPrefixFromString is the former strings.Split and gonet.ParseIP based parser used as benchmark baseline
+func legacyPrefixFromString(s string) (*Prefix, error) {
+	parts := strings.Split(s, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid format: %q", s)
+	}
+
+	ip := gonet.ParseIP(parts[0])
+	if ip == nil {
+		return nil, fmt.Errorf("%s is not a valid IP address", parts[0])
+	}
+
+	addr, err := IPFromBytes(ip)
+	if err != nil {
+		return nil, err
+	}
+
+	l, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("unable to convert to int: %w", err)
+	}
+
+	return &Prefix{
+		addr: addr,
+		len:  uint8(l),
+	}, nil
+}
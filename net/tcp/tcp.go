@@ -14,6 +14,12 @@ const (
 
 	// SOL_IPV6 is not defined on darwin
 	SOL_IPV6 = 0x29
+
+	// IP_MINTTL is not defined on darwin
+	IP_MINTTL = 0x15
+
+	// IPV6_MINHOPCOUNT is not defined on darwin
+	IPV6_MINHOPCOUNT = 0x49
 )
 
 // Conn is TCP connection
@@ -103,6 +109,15 @@ func (c *Conn) SetTTL(ttl uint8) error {
 	return unix.SetsockoptInt(c.fd, unix.IPPROTO_IPV6, unix.IPV6_UNICAST_HOPS, int(ttl))
 }
 
+// SetMinTTL sets the minimum TTL (hop limit) of received packets, packets below are discarded (RFC5082)
+func (c *Conn) SetMinTTL(ttl uint8) error {
+	if c.raddr.IP.To4() != nil {
+		return unix.SetsockoptInt(c.fd, SOL_IP, IP_MINTTL, int(ttl))
+	}
+
+	return unix.SetsockoptInt(c.fd, unix.IPPROTO_IPV6, IPV6_MINHOPCOUNT, int(ttl))
+}
+
 // SetDontRoute sets the SO_DONTROUTE option
 func (c *Conn) SetDontRoute() error {
 	return unix.SetsockoptInt(c.fd, unix.SOL_SOCKET, unix.SO_DONTROUTE, 1)
@@ -0,0 +1,73 @@
+package net
+
+import (
+	"fmt"
+
+	"github.com/bio-routing/bio-rd/net/api"
+)
+
+// VRFPrefix is a prefix qualified by the route distinguisher of the VRF it belongs to.
+// A route distinguisher of 0 denotes a prefix without VRF context.
+// VRFPrefix is comparable and can be used as map key.
+type VRFPrefix struct {
+	rd  uint64
+	pfx Prefix
+}
+
+// NewVRFPfx creates a new VRFPrefix
+func NewVRFPfx(rd uint64, pfx Prefix) VRFPrefix {
+	return VRFPrefix{
+		rd:  rd,
+		pfx: pfx,
+	}
+}
+
+// NewVRFPrefixFromProtoVRFPrefix creates a VRFPrefix from a proto VRFPrefix
+func NewVRFPrefixFromProtoVRFPrefix(pfx *api.VRFPrefix) *VRFPrefix {
+	return &VRFPrefix{
+		rd:  pfx.RouteDistinguisher,
+		pfx: *NewPrefixFromProtoPrefix(pfx.Prefix),
+	}
+}
+
+// Ptr returns a pointer to p
+func (p VRFPrefix) Ptr() *VRFPrefix {
+	return &p
+}
+
+// ToProto converts p to proto VRFPrefix
+func (p VRFPrefix) ToProto() *api.VRFPrefix {
+	return &api.VRFPrefix{
+		RouteDistinguisher: p.rd,
+		Prefix:             p.pfx.ToProto(),
+	}
+}
+
+// RD gets the route distinguisher of p
+func (p *VRFPrefix) RD() uint64 {
+	return p.rd
+}
+
+// HasRD checks if p carries a route distinguisher
+func (p *VRFPrefix) HasRD() bool {
+	return p.rd != 0
+}
+
+// Prefix gets the prefix of p
+func (p *VRFPrefix) Prefix() *Prefix {
+	return &p.pfx
+}
+
+// Equal checks if p and x are equal
+func (p *VRFPrefix) Equal(x *VRFPrefix) bool {
+	return p.rd == x.rd && p.pfx.Equal(&x.pfx)
+}
+
+// String returns the string representation of p. The route distinguisher is formatted as ASN:ID.
+func (p *VRFPrefix) String() string {
+	if !p.HasRD() {
+		return p.pfx.String()
+	}
+
+	return fmt.Sprintf("%d:%d:%s", p.rd>>32, p.rd&0xffffffff, p.pfx.String())
+}
@@ -0,0 +1,75 @@
+package net
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/net/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVRFPrefixEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        VRFPrefix
+		b        VRFPrefix
+		expected bool
+	}{
+		{
+			name:     "Equal",
+			a:        NewVRFPfx(100, NewPfx(IPv4FromOctets(10, 0, 0, 0), 8)),
+			b:        NewVRFPfx(100, NewPfx(IPv4FromOctets(10, 0, 0, 0), 8)),
+			expected: true,
+		},
+		{
+			name:     "Different RD",
+			a:        NewVRFPfx(100, NewPfx(IPv4FromOctets(10, 0, 0, 0), 8)),
+			b:        NewVRFPfx(200, NewPfx(IPv4FromOctets(10, 0, 0, 0), 8)),
+			expected: false,
+		},
+		{
+			name:     "Different prefix",
+			a:        NewVRFPfx(100, NewPfx(IPv4FromOctets(10, 0, 0, 0), 8)),
+			b:        NewVRFPfx(100, NewPfx(IPv4FromOctets(10, 0, 0, 0), 16)),
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.a.Equal(&test.b), test.name)
+		assert.Equal(t, test.expected, test.a == test.b, test.name)
+	}
+}
+
+func TestVRFPrefixMapKey(t *testing.T) {
+	m := make(map[VRFPrefix]int)
+	m[NewVRFPfx(100, NewPfx(IPv4FromOctets(10, 0, 0, 0), 8))] = 1
+	m[NewVRFPfx(200, NewPfx(IPv4FromOctets(10, 0, 0, 0), 8))] = 2
+	m[NewVRFPfx(100, NewPfx(IPv4FromOctets(10, 0, 0, 0), 8))] = 3
+
+	assert.Equal(t, 2, len(m))
+	assert.Equal(t, 3, m[NewVRFPfx(100, NewPfx(IPv4FromOctets(10, 0, 0, 0), 8))])
+	assert.Equal(t, 2, m[NewVRFPfx(200, NewPfx(IPv4FromOctets(10, 0, 0, 0), 8))])
+}
+
+func TestVRFPrefixProto(t *testing.T) {
+	p := NewVRFPfx(51324<<32+100, NewPfx(IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32))
+
+	pb := p.ToProto()
+	assert.Equal(t, &api.VRFPrefix{
+		RouteDistinguisher: 51324<<32 + 100,
+		Prefix: &api.Prefix{
+			Address: &api.IP{
+				Higher:  0x20010db800000000,
+				Version: api.IP_IPv6,
+			},
+			Length: 32,
+		},
+	}, pb)
+
+	assert.Equal(t, p, *NewVRFPrefixFromProtoVRFPrefix(pb))
+}
+
+func TestVRFPrefixString(t *testing.T) {
+	assert.Equal(t, "10.0.0.0/8", NewVRFPfx(0, NewPfx(IPv4FromOctets(10, 0, 0, 0), 8)).Ptr().String())
+	assert.Equal(t, "51324:100:10.0.0.0/8", NewVRFPfx(51324<<32+100, NewPfx(IPv4FromOctets(10, 0, 0, 0), 8)).Ptr().String())
+}
@@ -22,31 +22,691 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SoftRefreshInResponse_Method int32
+
+const (
+	SoftRefreshInResponse_EnhancedRouteRefresh SoftRefreshInResponse_Method = 0
+	SoftRefreshInResponse_RouteRefresh         SoftRefreshInResponse_Method = 1
+	SoftRefreshInResponse_StoredRoutes         SoftRefreshInResponse_Method = 2
+)
+
+// Enum value maps for SoftRefreshInResponse_Method.
+var (
+	SoftRefreshInResponse_Method_name = map[int32]string{
+		0: "EnhancedRouteRefresh",
+		1: "RouteRefresh",
+		2: "StoredRoutes",
+	}
+	SoftRefreshInResponse_Method_value = map[string]int32{
+		"EnhancedRouteRefresh": 0,
+		"RouteRefresh":         1,
+		"StoredRoutes":         2,
+	}
+)
+
+func (x SoftRefreshInResponse_Method) Enum() *SoftRefreshInResponse_Method {
+	p := new(SoftRefreshInResponse_Method)
+	*p = x
+	return p
+}
+
+func (x SoftRefreshInResponse_Method) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SoftRefreshInResponse_Method) Descriptor() protoreflect.EnumDescriptor {
+	return file_protocols_bgp_api_bgp_proto_enumTypes[0].Descriptor()
+}
+
+func (SoftRefreshInResponse_Method) Type() protoreflect.EnumType {
+	return &file_protocols_bgp_api_bgp_proto_enumTypes[0]
+}
+
+func (x SoftRefreshInResponse_Method) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SoftRefreshInResponse_Method.Descriptor instead.
+func (SoftRefreshInResponse_Method) EnumDescriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{5, 0}
+}
+
+type EvaluatePolicyRequest_Direction int32
+
+const (
+	EvaluatePolicyRequest_In  EvaluatePolicyRequest_Direction = 0
+	EvaluatePolicyRequest_Out EvaluatePolicyRequest_Direction = 1
+)
+
+// Enum value maps for EvaluatePolicyRequest_Direction.
+var (
+	EvaluatePolicyRequest_Direction_name = map[int32]string{
+		0: "In",
+		1: "Out",
+	}
+	EvaluatePolicyRequest_Direction_value = map[string]int32{
+		"In":  0,
+		"Out": 1,
+	}
+)
+
+func (x EvaluatePolicyRequest_Direction) Enum() *EvaluatePolicyRequest_Direction {
+	p := new(EvaluatePolicyRequest_Direction)
+	*p = x
+	return p
+}
+
+func (x EvaluatePolicyRequest_Direction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EvaluatePolicyRequest_Direction) Descriptor() protoreflect.EnumDescriptor {
+	return file_protocols_bgp_api_bgp_proto_enumTypes[1].Descriptor()
+}
+
+func (EvaluatePolicyRequest_Direction) Type() protoreflect.EnumType {
+	return &file_protocols_bgp_api_bgp_proto_enumTypes[1]
+}
+
+func (x EvaluatePolicyRequest_Direction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EvaluatePolicyRequest_Direction.Descriptor instead.
+func (EvaluatePolicyRequest_Direction) EnumDescriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{12, 0}
+}
+
+type PolicyEvaluation_Decision int32
+
+const (
+	PolicyEvaluation_Permit PolicyEvaluation_Decision = 0
+	PolicyEvaluation_Deny   PolicyEvaluation_Decision = 1
+)
+
+// Enum value maps for PolicyEvaluation_Decision.
+var (
+	PolicyEvaluation_Decision_name = map[int32]string{
+		0: "Permit",
+		1: "Deny",
+	}
+	PolicyEvaluation_Decision_value = map[string]int32{
+		"Permit": 0,
+		"Deny":   1,
+	}
+)
+
+func (x PolicyEvaluation_Decision) Enum() *PolicyEvaluation_Decision {
+	p := new(PolicyEvaluation_Decision)
+	*p = x
+	return p
+}
+
+func (x PolicyEvaluation_Decision) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PolicyEvaluation_Decision) Descriptor() protoreflect.EnumDescriptor {
+	return file_protocols_bgp_api_bgp_proto_enumTypes[2].Descriptor()
+}
+
+func (PolicyEvaluation_Decision) Type() protoreflect.EnumType {
+	return &file_protocols_bgp_api_bgp_proto_enumTypes[2]
+}
+
+func (x PolicyEvaluation_Decision) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PolicyEvaluation_Decision.Descriptor instead.
+func (PolicyEvaluation_Decision) EnumDescriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{14, 0}
+}
+
 type ListSessionsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Filter *SessionFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Filter *SessionFilter `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *ListSessionsRequest) Reset() {
+	*x = ListSessionsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSessionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsRequest) ProtoMessage() {}
+
+func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
+func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ListSessionsRequest) GetFilter() *SessionFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type SessionFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	NeighborIp *api.IP `protobuf:"bytes,1,opt,name=neighbor_ip,json=neighborIp,proto3" json:"neighbor_ip,omitempty"`
+	VrfName    string  `protobuf:"bytes,2,opt,name=vrf_name,json=vrfName,proto3" json:"vrf_name,omitempty"`
+}
+
+func (x *SessionFilter) Reset() {
+	*x = SessionFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionFilter) ProtoMessage() {}
+
+func (x *SessionFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionFilter.ProtoReflect.Descriptor instead.
+func (*SessionFilter) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SessionFilter) GetNeighborIp() *api.IP {
+	if x != nil {
+		return x.NeighborIp
+	}
+	return nil
+}
+
+func (x *SessionFilter) GetVrfName() string {
+	if x != nil {
+		return x.VrfName
+	}
+	return ""
+}
+
+type ListSessionsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sessions []*Session `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+}
+
+func (x *ListSessionsResponse) Reset() {
+	*x = ListSessionsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSessionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSessionsResponse) ProtoMessage() {}
+
+func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
+func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListSessionsResponse) GetSessions() []*Session {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type DumpRIBRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Peer *api.IP `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Afi  uint32  `protobuf:"varint,2,opt,name=afi,proto3" json:"afi,omitempty"`
+	Safi uint32  `protobuf:"varint,3,opt,name=safi,proto3" json:"safi,omitempty"`
+}
+
+func (x *DumpRIBRequest) Reset() {
+	*x = DumpRIBRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DumpRIBRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpRIBRequest) ProtoMessage() {}
+
+func (x *DumpRIBRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpRIBRequest.ProtoReflect.Descriptor instead.
+func (*DumpRIBRequest) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DumpRIBRequest) GetPeer() *api.IP {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *DumpRIBRequest) GetAfi() uint32 {
+	if x != nil {
+		return x.Afi
+	}
+	return 0
+}
+
+func (x *DumpRIBRequest) GetSafi() uint32 {
+	if x != nil {
+		return x.Safi
+	}
+	return 0
+}
+
+type SoftRefreshInRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Peer *api.IP `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Afi  uint32  `protobuf:"varint,2,opt,name=afi,proto3" json:"afi,omitempty"`
+	Safi uint32  `protobuf:"varint,3,opt,name=safi,proto3" json:"safi,omitempty"`
+}
+
+func (x *SoftRefreshInRequest) Reset() {
+	*x = SoftRefreshInRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SoftRefreshInRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SoftRefreshInRequest) ProtoMessage() {}
+
+func (x *SoftRefreshInRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SoftRefreshInRequest.ProtoReflect.Descriptor instead.
+func (*SoftRefreshInRequest) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SoftRefreshInRequest) GetPeer() *api.IP {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *SoftRefreshInRequest) GetAfi() uint32 {
+	if x != nil {
+		return x.Afi
+	}
+	return 0
+}
+
+func (x *SoftRefreshInRequest) GetSafi() uint32 {
+	if x != nil {
+		return x.Safi
+	}
+	return 0
+}
+
+type SoftRefreshInResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Method SoftRefreshInResponse_Method `protobuf:"varint,1,opt,name=method,proto3,enum=bio.bgp.SoftRefreshInResponse_Method" json:"method,omitempty"`
+}
+
+func (x *SoftRefreshInResponse) Reset() {
+	*x = SoftRefreshInResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SoftRefreshInResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SoftRefreshInResponse) ProtoMessage() {}
+
+func (x *SoftRefreshInResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SoftRefreshInResponse.ProtoReflect.Descriptor instead.
+func (*SoftRefreshInResponse) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SoftRefreshInResponse) GetMethod() SoftRefreshInResponse_Method {
+	if x != nil {
+		return x.Method
+	}
+	return SoftRefreshInResponse_EnhancedRouteRefresh
+}
+
+type GetSessionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Peer *api.IP `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+}
+
+func (x *GetSessionRequest) Reset() {
+	*x = GetSessionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSessionRequest) ProtoMessage() {}
+
+func (x *GetSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSessionRequest.ProtoReflect.Descriptor instead.
+func (*GetSessionRequest) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetSessionRequest) GetPeer() *api.IP {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+type GetSessionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Session *SessionDetail `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+}
+
+func (x *GetSessionResponse) Reset() {
+	*x = GetSessionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSessionResponse) ProtoMessage() {}
+
+func (x *GetSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSessionResponse.ProtoReflect.Descriptor instead.
+func (*GetSessionResponse) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetSessionResponse) GetSession() *SessionDetail {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type ShutdownPeerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Peer *api.IP `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	// communication is sent to the peer as shutdown communication (RFC8203)
+	Communication string `protobuf:"bytes,2,opt,name=communication,proto3" json:"communication,omitempty"`
+	// reset resets the session instead of keeping it down
+	Reset_ bool `protobuf:"varint,3,opt,name=reset,proto3" json:"reset,omitempty"`
+}
+
+func (x *ShutdownPeerRequest) Reset() {
+	*x = ShutdownPeerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShutdownPeerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShutdownPeerRequest) ProtoMessage() {}
+
+func (x *ShutdownPeerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShutdownPeerRequest.ProtoReflect.Descriptor instead.
+func (*ShutdownPeerRequest) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ShutdownPeerRequest) GetPeer() *api.IP {
+	if x != nil {
+		return x.Peer
+	}
+	return nil
+}
+
+func (x *ShutdownPeerRequest) GetCommunication() string {
+	if x != nil {
+		return x.Communication
+	}
+	return ""
+}
+
+func (x *ShutdownPeerRequest) GetReset_() bool {
+	if x != nil {
+		return x.Reset_
+	}
+	return false
+}
+
+type ShutdownPeerResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ShutdownPeerResponse) Reset() {
+	*x = ShutdownPeerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShutdownPeerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShutdownPeerResponse) ProtoMessage() {}
+
+func (x *ShutdownPeerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShutdownPeerResponse.ProtoReflect.Descriptor instead.
+func (*ShutdownPeerResponse) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{9}
+}
+
+type EnablePeerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Peer *api.IP `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
 }
 
-func (x *ListSessionsRequest) Reset() {
-	*x = ListSessionsRequest{}
+func (x *EnablePeerRequest) Reset() {
+	*x = EnablePeerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[0]
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListSessionsRequest) String() string {
+func (x *EnablePeerRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSessionsRequest) ProtoMessage() {}
+func (*EnablePeerRequest) ProtoMessage() {}
 
-func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[0]
+func (x *EnablePeerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,44 +717,85 @@ func (x *ListSessionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSessionsRequest.ProtoReflect.Descriptor instead.
-func (*ListSessionsRequest) Descriptor() ([]byte, []int) {
-	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use EnablePeerRequest.ProtoReflect.Descriptor instead.
+func (*EnablePeerRequest) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *ListSessionsRequest) GetFilter() *SessionFilter {
+func (x *EnablePeerRequest) GetPeer() *api.IP {
 	if x != nil {
-		return x.Filter
+		return x.Peer
 	}
 	return nil
 }
 
-type SessionFilter struct {
+type EnablePeerResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
+}
 
-	NeighborIp *api.IP `protobuf:"bytes,1,opt,name=neighbor_ip,json=neighborIp,proto3" json:"neighbor_ip,omitempty"`
-	VrfName    string  `protobuf:"bytes,2,opt,name=vrf_name,json=vrfName,proto3" json:"vrf_name,omitempty"`
+func (x *EnablePeerResponse) Reset() {
+	*x = EnablePeerResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
 }
 
-func (x *SessionFilter) Reset() {
-	*x = SessionFilter{}
+func (x *EnablePeerResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnablePeerResponse) ProtoMessage() {}
+
+func (x *EnablePeerResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnablePeerResponse.ProtoReflect.Descriptor instead.
+func (*EnablePeerResponse) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{11}
+}
+
+type EvaluatePolicyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Peer      *api.IP                         `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
+	Afi       uint32                          `protobuf:"varint,2,opt,name=afi,proto3" json:"afi,omitempty"`
+	Safi      uint32                          `protobuf:"varint,3,opt,name=safi,proto3" json:"safi,omitempty"`
+	Direction EvaluatePolicyRequest_Direction `protobuf:"varint,4,opt,name=direction,proto3,enum=bio.bgp.EvaluatePolicyRequest_Direction" json:"direction,omitempty"`
+	Pfx       *api.Prefix                     `protobuf:"bytes,5,opt,name=pfx,proto3" json:"pfx,omitempty"`
+}
+
+func (x *EvaluatePolicyRequest) Reset() {
+	*x = EvaluatePolicyRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[1]
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *SessionFilter) String() string {
+func (x *EvaluatePolicyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SessionFilter) ProtoMessage() {}
+func (*EvaluatePolicyRequest) ProtoMessage() {}
 
-func (x *SessionFilter) ProtoReflect() protoreflect.Message {
-	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[1]
+func (x *EvaluatePolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -105,50 +806,71 @@ func (x *SessionFilter) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SessionFilter.ProtoReflect.Descriptor instead.
-func (*SessionFilter) Descriptor() ([]byte, []int) {
-	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use EvaluatePolicyRequest.ProtoReflect.Descriptor instead.
+func (*EvaluatePolicyRequest) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *SessionFilter) GetNeighborIp() *api.IP {
+func (x *EvaluatePolicyRequest) GetPeer() *api.IP {
 	if x != nil {
-		return x.NeighborIp
+		return x.Peer
 	}
 	return nil
 }
 
-func (x *SessionFilter) GetVrfName() string {
+func (x *EvaluatePolicyRequest) GetAfi() uint32 {
 	if x != nil {
-		return x.VrfName
+		return x.Afi
 	}
-	return ""
+	return 0
 }
 
-type ListSessionsResponse struct {
+func (x *EvaluatePolicyRequest) GetSafi() uint32 {
+	if x != nil {
+		return x.Safi
+	}
+	return 0
+}
+
+func (x *EvaluatePolicyRequest) GetDirection() EvaluatePolicyRequest_Direction {
+	if x != nil {
+		return x.Direction
+	}
+	return EvaluatePolicyRequest_In
+}
+
+func (x *EvaluatePolicyRequest) GetPfx() *api.Prefix {
+	if x != nil {
+		return x.Pfx
+	}
+	return nil
+}
+
+type EvaluatePolicyResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sessions []*Session `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	Evaluations []*PolicyEvaluation `protobuf:"bytes,1,rep,name=evaluations,proto3" json:"evaluations,omitempty"`
 }
 
-func (x *ListSessionsResponse) Reset() {
-	*x = ListSessionsResponse{}
+func (x *EvaluatePolicyResponse) Reset() {
+	*x = EvaluatePolicyResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[2]
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListSessionsResponse) String() string {
+func (x *EvaluatePolicyResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListSessionsResponse) ProtoMessage() {}
+func (*EvaluatePolicyResponse) ProtoMessage() {}
 
-func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[2]
+func (x *EvaluatePolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -159,45 +881,49 @@ func (x *ListSessionsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListSessionsResponse.ProtoReflect.Descriptor instead.
-func (*ListSessionsResponse) Descriptor() ([]byte, []int) {
-	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use EvaluatePolicyResponse.ProtoReflect.Descriptor instead.
+func (*EvaluatePolicyResponse) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ListSessionsResponse) GetSessions() []*Session {
+func (x *EvaluatePolicyResponse) GetEvaluations() []*PolicyEvaluation {
 	if x != nil {
-		return x.Sessions
+		return x.Evaluations
 	}
 	return nil
 }
 
-type DumpRIBRequest struct {
+type PolicyEvaluation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Peer *api.IP `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"`
-	Afi  uint32  `protobuf:"varint,2,opt,name=afi,proto3" json:"afi,omitempty"`
-	Safi uint32  `protobuf:"varint,3,opt,name=safi,proto3" json:"safi,omitempty"`
+	// path is the path as found in the RIB
+	Path     *api1.Path                `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Decision PolicyEvaluation_Decision `protobuf:"varint,2,opt,name=decision,proto3,enum=bio.bgp.PolicyEvaluation_Decision" json:"decision,omitempty"`
+	// result is the path as modified by the policy. It is unset if the path is denied.
+	Result *api1.Path `protobuf:"bytes,3,opt,name=result,proto3" json:"result,omitempty"`
+	// reject_reason describes why the path is denied, e.g. the denying term of the policy
+	RejectReason string `protobuf:"bytes,4,opt,name=reject_reason,json=rejectReason,proto3" json:"reject_reason,omitempty"`
 }
 
-func (x *DumpRIBRequest) Reset() {
-	*x = DumpRIBRequest{}
+func (x *PolicyEvaluation) Reset() {
+	*x = PolicyEvaluation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[3]
+		mi := &file_protocols_bgp_api_bgp_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DumpRIBRequest) String() string {
+func (x *PolicyEvaluation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DumpRIBRequest) ProtoMessage() {}
+func (*PolicyEvaluation) ProtoMessage() {}
 
-func (x *DumpRIBRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[3]
+func (x *PolicyEvaluation) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_bgp_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -208,30 +934,37 @@ func (x *DumpRIBRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DumpRIBRequest.ProtoReflect.Descriptor instead.
-func (*DumpRIBRequest) Descriptor() ([]byte, []int) {
-	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use PolicyEvaluation.ProtoReflect.Descriptor instead.
+func (*PolicyEvaluation) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_bgp_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *DumpRIBRequest) GetPeer() *api.IP {
+func (x *PolicyEvaluation) GetPath() *api1.Path {
 	if x != nil {
-		return x.Peer
+		return x.Path
 	}
 	return nil
 }
 
-func (x *DumpRIBRequest) GetAfi() uint32 {
+func (x *PolicyEvaluation) GetDecision() PolicyEvaluation_Decision {
 	if x != nil {
-		return x.Afi
+		return x.Decision
 	}
-	return 0
+	return PolicyEvaluation_Permit
 }
 
-func (x *DumpRIBRequest) GetSafi() uint32 {
+func (x *PolicyEvaluation) GetResult() *api1.Path {
 	if x != nil {
-		return x.Safi
+		return x.Result
 	}
-	return 0
+	return nil
+}
+
+func (x *PolicyEvaluation) GetRejectReason() string {
+	if x != nil {
+		return x.RejectReason
+	}
+	return ""
 }
 
 var File_protocols_bgp_api_bgp_proto protoreflect.FileDescriptor
@@ -264,24 +997,120 @@ var file_protocols_bgp_api_bgp_proto_rawDesc = []byte{
 	0x65, 0x74, 0x2e, 0x49, 0x50, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x61,
 	0x66, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x61, 0x66, 0x69, 0x12, 0x12, 0x0a,
 	0x04, 0x73, 0x61, 0x66, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x61, 0x66,
-	0x69, 0x32, 0xd4, 0x01, 0x0a, 0x0a, 0x42, 0x67, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65,
-	0x12, 0x4d, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73,
-	0x12, 0x1c, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53,
-	0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d,
-	0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73,
-	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12,
-	0x3a, 0x0a, 0x09, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x49, 0x6e, 0x12, 0x17, 0x2e, 0x62,
-	0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74,
-	0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x0a, 0x44,
-	0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x4f, 0x75, 0x74, 0x12, 0x17, 0x2e, 0x62, 0x69, 0x6f, 0x2e,
-	0x62, 0x67, 0x70, 0x2e, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x10, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x52,
-	0x6f, 0x75, 0x74, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74, 0x69,
-	0x6e, 0x67, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63,
-	0x6f, 0x6c, 0x73, 0x2f, 0x62, 0x67, 0x70, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x33,
+	0x69, 0x22, 0x5d, 0x0a, 0x14, 0x53, 0x6f, 0x66, 0x74, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x04, 0x70, 0x65, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65,
+	0x74, 0x2e, 0x49, 0x50, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x66,
+	0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x61, 0x66, 0x69, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x61, 0x66, 0x69, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x61, 0x66, 0x69,
+	0x22, 0x9e, 0x01, 0x0a, 0x15, 0x53, 0x6f, 0x66, 0x74, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3d, 0x0a, 0x06, 0x6d, 0x65,
+	0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x25, 0x2e, 0x62, 0x69, 0x6f,
+	0x2e, 0x62, 0x67, 0x70, 0x2e, 0x53, 0x6f, 0x66, 0x74, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x49, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0x46, 0x0a, 0x06, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x12, 0x18, 0x0a, 0x14, 0x45, 0x6e, 0x68, 0x61, 0x6e, 0x63, 0x65, 0x64, 0x52,
+	0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x10, 0x00, 0x12, 0x10, 0x0a,
+	0x0c, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x10, 0x01, 0x12,
+	0x10, 0x0a, 0x0c, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x64, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x10,
+	0x02, 0x22, 0x34, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49,
+	0x50, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x22, 0x46, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a,
+	0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x52, 0x07, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22,
+	0x72, 0x0a, 0x13, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x50, 0x65, 0x65, 0x72, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49,
+	0x50, 0x52, 0x04, 0x70, 0x65, 0x65, 0x72, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x75,
+	0x6e, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x63, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a,
+	0x05, 0x72, 0x65, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65,
+	0x73, 0x65, 0x74, 0x22, 0x16, 0x0a, 0x14, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x50,
+	0x65, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x34, 0x0a, 0x11, 0x45,
+	0x6e, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1f, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b,
+	0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49, 0x50, 0x52, 0x04, 0x70, 0x65, 0x65,
+	0x72, 0x22, 0x14, 0x0a, 0x12, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x65, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xe7, 0x01, 0x0a, 0x15, 0x45, 0x76, 0x61, 0x6c,
+	0x75, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1f, 0x0a, 0x04, 0x70, 0x65, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49, 0x50, 0x52, 0x04, 0x70, 0x65,
+	0x65, 0x72, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x66, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x03, 0x61, 0x66, 0x69, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x61, 0x66, 0x69, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x73, 0x61, 0x66, 0x69, 0x12, 0x46, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x28, 0x2e, 0x62, 0x69,
+	0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x21, 0x0a, 0x03, 0x70, 0x66, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x03,
+	0x70, 0x66, 0x78, 0x22, 0x1c, 0x0a, 0x09, 0x44, 0x69, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x06, 0x0a, 0x02, 0x49, 0x6e, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4f, 0x75, 0x74, 0x10,
+	0x01, 0x22, 0x55, 0x0a, 0x16, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x0b, 0x65,
+	0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0b, 0x65, 0x76, 0x61,
+	0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0xe7, 0x01, 0x0a, 0x10, 0x50, 0x6f, 0x6c,
+	0x69, 0x63, 0x79, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a,
+	0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x69,
+	0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x52, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x12, 0x3e, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x22, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x50,
+	0x6f, 0x6c, 0x69, 0x63, 0x79, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x27, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x50,
+	0x61, 0x74, 0x68, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72,
+	0x65, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x22, 0x20, 0x0a, 0x08, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x0a, 0x0a, 0x06,
+	0x50, 0x65, 0x72, 0x6d, 0x69, 0x74, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x44, 0x65, 0x6e, 0x79,
+	0x10, 0x01, 0x32, 0xdc, 0x04, 0x0a, 0x0a, 0x42, 0x67, 0x70, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x4d, 0x0a, 0x0c, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x1c, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1d, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x3a, 0x0a, 0x09, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x49, 0x6e, 0x12, 0x17, 0x2e,
+	0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x0a,
+	0x44, 0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x4f, 0x75, 0x74, 0x12, 0x17, 0x2e, 0x62, 0x69, 0x6f,
+	0x2e, 0x62, 0x67, 0x70, 0x2e, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x49, 0x42, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x22, 0x00, 0x30, 0x01, 0x12, 0x50, 0x0a, 0x0d, 0x53, 0x6f, 0x66,
+	0x74, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x49, 0x6e, 0x12, 0x1d, 0x2e, 0x62, 0x69, 0x6f,
+	0x2e, 0x62, 0x67, 0x70, 0x2e, 0x53, 0x6f, 0x66, 0x74, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68,
+	0x49, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x62, 0x69, 0x6f, 0x2e,
+	0x62, 0x67, 0x70, 0x2e, 0x53, 0x6f, 0x66, 0x74, 0x52, 0x65, 0x66, 0x72, 0x65, 0x73, 0x68, 0x49,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0a, 0x47,
+	0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x62, 0x69, 0x6f, 0x2e,
+	0x62, 0x67, 0x70, 0x2e, 0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e,
+	0x47, 0x65, 0x74, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x4d, 0x0a, 0x0c, 0x53, 0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e,
+	0x50, 0x65, 0x65, 0x72, 0x12, 0x1c, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x53,
+	0x68, 0x75, 0x74, 0x64, 0x6f, 0x77, 0x6e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x53, 0x68, 0x75,
+	0x74, 0x64, 0x6f, 0x77, 0x6e, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x47, 0x0a, 0x0a, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x65, 0x65,
+	0x72, 0x12, 0x1a, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x45, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x50, 0x65, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e,
+	0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x50, 0x65,
+	0x65, 0x72, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x0e,
+	0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x1e,
+	0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74,
+	0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1f,
+	0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74,
+	0x65, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x62, 0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x62, 0x69, 0x6f, 0x2d,
+	0x72, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x62, 0x67, 0x70,
+	0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -296,32 +1125,73 @@ func file_protocols_bgp_api_bgp_proto_rawDescGZIP() []byte {
 	return file_protocols_bgp_api_bgp_proto_rawDescData
 }
 
-var file_protocols_bgp_api_bgp_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_protocols_bgp_api_bgp_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_protocols_bgp_api_bgp_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_protocols_bgp_api_bgp_proto_goTypes = []interface{}{
-	(*ListSessionsRequest)(nil),  // 0: bio.bgp.ListSessionsRequest
-	(*SessionFilter)(nil),        // 1: bio.bgp.SessionFilter
-	(*ListSessionsResponse)(nil), // 2: bio.bgp.ListSessionsResponse
-	(*DumpRIBRequest)(nil),       // 3: bio.bgp.DumpRIBRequest
-	(*api.IP)(nil),               // 4: bio.net.IP
-	(*Session)(nil),              // 5: bio.bgp.Session
-	(*api1.Route)(nil),           // 6: bio.route.Route
+	(SoftRefreshInResponse_Method)(0),    // 0: bio.bgp.SoftRefreshInResponse.Method
+	(EvaluatePolicyRequest_Direction)(0), // 1: bio.bgp.EvaluatePolicyRequest.Direction
+	(PolicyEvaluation_Decision)(0),       // 2: bio.bgp.PolicyEvaluation.Decision
+	(*ListSessionsRequest)(nil),          // 3: bio.bgp.ListSessionsRequest
+	(*SessionFilter)(nil),                // 4: bio.bgp.SessionFilter
+	(*ListSessionsResponse)(nil),         // 5: bio.bgp.ListSessionsResponse
+	(*DumpRIBRequest)(nil),               // 6: bio.bgp.DumpRIBRequest
+	(*SoftRefreshInRequest)(nil),         // 7: bio.bgp.SoftRefreshInRequest
+	(*SoftRefreshInResponse)(nil),        // 8: bio.bgp.SoftRefreshInResponse
+	(*GetSessionRequest)(nil),            // 9: bio.bgp.GetSessionRequest
+	(*GetSessionResponse)(nil),           // 10: bio.bgp.GetSessionResponse
+	(*ShutdownPeerRequest)(nil),          // 11: bio.bgp.ShutdownPeerRequest
+	(*ShutdownPeerResponse)(nil),         // 12: bio.bgp.ShutdownPeerResponse
+	(*EnablePeerRequest)(nil),            // 13: bio.bgp.EnablePeerRequest
+	(*EnablePeerResponse)(nil),           // 14: bio.bgp.EnablePeerResponse
+	(*EvaluatePolicyRequest)(nil),        // 15: bio.bgp.EvaluatePolicyRequest
+	(*EvaluatePolicyResponse)(nil),       // 16: bio.bgp.EvaluatePolicyResponse
+	(*PolicyEvaluation)(nil),             // 17: bio.bgp.PolicyEvaluation
+	(*api.IP)(nil),                       // 18: bio.net.IP
+	(*Session)(nil),                      // 19: bio.bgp.Session
+	(*SessionDetail)(nil),                // 20: bio.bgp.SessionDetail
+	(*api.Prefix)(nil),                   // 21: bio.net.Prefix
+	(*api1.Path)(nil),                    // 22: bio.route.Path
+	(*api1.Route)(nil),                   // 23: bio.route.Route
 }
 var file_protocols_bgp_api_bgp_proto_depIdxs = []int32{
-	1, // 0: bio.bgp.ListSessionsRequest.filter:type_name -> bio.bgp.SessionFilter
-	4, // 1: bio.bgp.SessionFilter.neighbor_ip:type_name -> bio.net.IP
-	5, // 2: bio.bgp.ListSessionsResponse.sessions:type_name -> bio.bgp.Session
-	4, // 3: bio.bgp.DumpRIBRequest.peer:type_name -> bio.net.IP
-	0, // 4: bio.bgp.BgpService.ListSessions:input_type -> bio.bgp.ListSessionsRequest
-	3, // 5: bio.bgp.BgpService.DumpRIBIn:input_type -> bio.bgp.DumpRIBRequest
-	3, // 6: bio.bgp.BgpService.DumpRIBOut:input_type -> bio.bgp.DumpRIBRequest
-	2, // 7: bio.bgp.BgpService.ListSessions:output_type -> bio.bgp.ListSessionsResponse
-	6, // 8: bio.bgp.BgpService.DumpRIBIn:output_type -> bio.route.Route
-	6, // 9: bio.bgp.BgpService.DumpRIBOut:output_type -> bio.route.Route
-	7, // [7:10] is the sub-list for method output_type
-	4, // [4:7] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	4,  // 0: bio.bgp.ListSessionsRequest.filter:type_name -> bio.bgp.SessionFilter
+	18, // 1: bio.bgp.SessionFilter.neighbor_ip:type_name -> bio.net.IP
+	19, // 2: bio.bgp.ListSessionsResponse.sessions:type_name -> bio.bgp.Session
+	18, // 3: bio.bgp.DumpRIBRequest.peer:type_name -> bio.net.IP
+	18, // 4: bio.bgp.SoftRefreshInRequest.peer:type_name -> bio.net.IP
+	0,  // 5: bio.bgp.SoftRefreshInResponse.method:type_name -> bio.bgp.SoftRefreshInResponse.Method
+	18, // 6: bio.bgp.GetSessionRequest.peer:type_name -> bio.net.IP
+	20, // 7: bio.bgp.GetSessionResponse.session:type_name -> bio.bgp.SessionDetail
+	18, // 8: bio.bgp.ShutdownPeerRequest.peer:type_name -> bio.net.IP
+	18, // 9: bio.bgp.EnablePeerRequest.peer:type_name -> bio.net.IP
+	18, // 10: bio.bgp.EvaluatePolicyRequest.peer:type_name -> bio.net.IP
+	1,  // 11: bio.bgp.EvaluatePolicyRequest.direction:type_name -> bio.bgp.EvaluatePolicyRequest.Direction
+	21, // 12: bio.bgp.EvaluatePolicyRequest.pfx:type_name -> bio.net.Prefix
+	17, // 13: bio.bgp.EvaluatePolicyResponse.evaluations:type_name -> bio.bgp.PolicyEvaluation
+	22, // 14: bio.bgp.PolicyEvaluation.path:type_name -> bio.route.Path
+	2,  // 15: bio.bgp.PolicyEvaluation.decision:type_name -> bio.bgp.PolicyEvaluation.Decision
+	22, // 16: bio.bgp.PolicyEvaluation.result:type_name -> bio.route.Path
+	3,  // 17: bio.bgp.BgpService.ListSessions:input_type -> bio.bgp.ListSessionsRequest
+	6,  // 18: bio.bgp.BgpService.DumpRIBIn:input_type -> bio.bgp.DumpRIBRequest
+	6,  // 19: bio.bgp.BgpService.DumpRIBOut:input_type -> bio.bgp.DumpRIBRequest
+	7,  // 20: bio.bgp.BgpService.SoftRefreshIn:input_type -> bio.bgp.SoftRefreshInRequest
+	9,  // 21: bio.bgp.BgpService.GetSession:input_type -> bio.bgp.GetSessionRequest
+	11, // 22: bio.bgp.BgpService.ShutdownPeer:input_type -> bio.bgp.ShutdownPeerRequest
+	13, // 23: bio.bgp.BgpService.EnablePeer:input_type -> bio.bgp.EnablePeerRequest
+	15, // 24: bio.bgp.BgpService.EvaluatePolicy:input_type -> bio.bgp.EvaluatePolicyRequest
+	5,  // 25: bio.bgp.BgpService.ListSessions:output_type -> bio.bgp.ListSessionsResponse
+	23, // 26: bio.bgp.BgpService.DumpRIBIn:output_type -> bio.route.Route
+	23, // 27: bio.bgp.BgpService.DumpRIBOut:output_type -> bio.route.Route
+	8,  // 28: bio.bgp.BgpService.SoftRefreshIn:output_type -> bio.bgp.SoftRefreshInResponse
+	10, // 29: bio.bgp.BgpService.GetSession:output_type -> bio.bgp.GetSessionResponse
+	12, // 30: bio.bgp.BgpService.ShutdownPeer:output_type -> bio.bgp.ShutdownPeerResponse
+	14, // 31: bio.bgp.BgpService.EnablePeer:output_type -> bio.bgp.EnablePeerResponse
+	16, // 32: bio.bgp.BgpService.EvaluatePolicy:output_type -> bio.bgp.EvaluatePolicyResponse
+	25, // [25:33] is the sub-list for method output_type
+	17, // [17:25] is the sub-list for method input_type
+	17, // [17:17] is the sub-list for extension type_name
+	17, // [17:17] is the sub-list for extension extendee
+	0,  // [0:17] is the sub-list for field type_name
 }
 
 func init() { file_protocols_bgp_api_bgp_proto_init() }
@@ -379,19 +1249,152 @@ func file_protocols_bgp_api_bgp_proto_init() {
 				return nil
 			}
 		}
+		file_protocols_bgp_api_bgp_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SoftRefreshInRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_bgp_api_bgp_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SoftRefreshInResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_bgp_api_bgp_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSessionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_bgp_api_bgp_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetSessionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_bgp_api_bgp_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShutdownPeerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_bgp_api_bgp_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShutdownPeerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_bgp_api_bgp_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnablePeerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_bgp_api_bgp_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EnablePeerResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_bgp_api_bgp_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvaluatePolicyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_bgp_api_bgp_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvaluatePolicyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_bgp_api_bgp_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PolicyEvaluation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protocols_bgp_api_bgp_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   4,
+			NumEnums:      3,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_protocols_bgp_api_bgp_proto_goTypes,
 		DependencyIndexes: file_protocols_bgp_api_bgp_proto_depIdxs,
+		EnumInfos:         file_protocols_bgp_api_bgp_proto_enumTypes,
 		MessageInfos:      file_protocols_bgp_api_bgp_proto_msgTypes,
 	}.Build()
 	File_protocols_bgp_api_bgp_proto = out.File
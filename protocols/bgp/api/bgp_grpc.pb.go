@@ -22,6 +22,11 @@ type BgpServiceClient interface {
 	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error)
 	DumpRIBIn(ctx context.Context, in *DumpRIBRequest, opts ...grpc.CallOption) (BgpService_DumpRIBInClient, error)
 	DumpRIBOut(ctx context.Context, in *DumpRIBRequest, opts ...grpc.CallOption) (BgpService_DumpRIBOutClient, error)
+	SoftRefreshIn(ctx context.Context, in *SoftRefreshInRequest, opts ...grpc.CallOption) (*SoftRefreshInResponse, error)
+	GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*GetSessionResponse, error)
+	ShutdownPeer(ctx context.Context, in *ShutdownPeerRequest, opts ...grpc.CallOption) (*ShutdownPeerResponse, error)
+	EnablePeer(ctx context.Context, in *EnablePeerRequest, opts ...grpc.CallOption) (*EnablePeerResponse, error)
+	EvaluatePolicy(ctx context.Context, in *EvaluatePolicyRequest, opts ...grpc.CallOption) (*EvaluatePolicyResponse, error)
 }
 
 type bgpServiceClient struct {
@@ -105,6 +110,51 @@ func (x *bgpServiceDumpRIBOutClient) Recv() (*api.Route, error) {
 	return m, nil
 }
 
+func (c *bgpServiceClient) SoftRefreshIn(ctx context.Context, in *SoftRefreshInRequest, opts ...grpc.CallOption) (*SoftRefreshInResponse, error) {
+	out := new(SoftRefreshInResponse)
+	err := c.cc.Invoke(ctx, "/bio.bgp.BgpService/SoftRefreshIn", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bgpServiceClient) GetSession(ctx context.Context, in *GetSessionRequest, opts ...grpc.CallOption) (*GetSessionResponse, error) {
+	out := new(GetSessionResponse)
+	err := c.cc.Invoke(ctx, "/bio.bgp.BgpService/GetSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bgpServiceClient) ShutdownPeer(ctx context.Context, in *ShutdownPeerRequest, opts ...grpc.CallOption) (*ShutdownPeerResponse, error) {
+	out := new(ShutdownPeerResponse)
+	err := c.cc.Invoke(ctx, "/bio.bgp.BgpService/ShutdownPeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bgpServiceClient) EnablePeer(ctx context.Context, in *EnablePeerRequest, opts ...grpc.CallOption) (*EnablePeerResponse, error) {
+	out := new(EnablePeerResponse)
+	err := c.cc.Invoke(ctx, "/bio.bgp.BgpService/EnablePeer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bgpServiceClient) EvaluatePolicy(ctx context.Context, in *EvaluatePolicyRequest, opts ...grpc.CallOption) (*EvaluatePolicyResponse, error) {
+	out := new(EvaluatePolicyResponse)
+	err := c.cc.Invoke(ctx, "/bio.bgp.BgpService/EvaluatePolicy", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BgpServiceServer is the server API for BgpService service.
 // All implementations must embed UnimplementedBgpServiceServer
 // for forward compatibility
@@ -112,6 +162,11 @@ type BgpServiceServer interface {
 	ListSessions(context.Context, *ListSessionsRequest) (*ListSessionsResponse, error)
 	DumpRIBIn(*DumpRIBRequest, BgpService_DumpRIBInServer) error
 	DumpRIBOut(*DumpRIBRequest, BgpService_DumpRIBOutServer) error
+	SoftRefreshIn(context.Context, *SoftRefreshInRequest) (*SoftRefreshInResponse, error)
+	GetSession(context.Context, *GetSessionRequest) (*GetSessionResponse, error)
+	ShutdownPeer(context.Context, *ShutdownPeerRequest) (*ShutdownPeerResponse, error)
+	EnablePeer(context.Context, *EnablePeerRequest) (*EnablePeerResponse, error)
+	EvaluatePolicy(context.Context, *EvaluatePolicyRequest) (*EvaluatePolicyResponse, error)
 	mustEmbedUnimplementedBgpServiceServer()
 }
 
@@ -128,6 +183,21 @@ func (UnimplementedBgpServiceServer) DumpRIBIn(*DumpRIBRequest, BgpService_DumpR
 func (UnimplementedBgpServiceServer) DumpRIBOut(*DumpRIBRequest, BgpService_DumpRIBOutServer) error {
 	return status.Errorf(codes.Unimplemented, "method DumpRIBOut not implemented")
 }
+func (UnimplementedBgpServiceServer) SoftRefreshIn(context.Context, *SoftRefreshInRequest) (*SoftRefreshInResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SoftRefreshIn not implemented")
+}
+func (UnimplementedBgpServiceServer) GetSession(context.Context, *GetSessionRequest) (*GetSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSession not implemented")
+}
+func (UnimplementedBgpServiceServer) ShutdownPeer(context.Context, *ShutdownPeerRequest) (*ShutdownPeerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShutdownPeer not implemented")
+}
+func (UnimplementedBgpServiceServer) EnablePeer(context.Context, *EnablePeerRequest) (*EnablePeerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnablePeer not implemented")
+}
+func (UnimplementedBgpServiceServer) EvaluatePolicy(context.Context, *EvaluatePolicyRequest) (*EvaluatePolicyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluatePolicy not implemented")
+}
 func (UnimplementedBgpServiceServer) mustEmbedUnimplementedBgpServiceServer() {}
 
 // UnsafeBgpServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -201,6 +271,96 @@ func (x *bgpServiceDumpRIBOutServer) Send(m *api.Route) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _BgpService_SoftRefreshIn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SoftRefreshInRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BgpServiceServer).SoftRefreshIn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bio.bgp.BgpService/SoftRefreshIn",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BgpServiceServer).SoftRefreshIn(ctx, req.(*SoftRefreshInRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BgpService_GetSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BgpServiceServer).GetSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bio.bgp.BgpService/GetSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BgpServiceServer).GetSession(ctx, req.(*GetSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BgpService_ShutdownPeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShutdownPeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BgpServiceServer).ShutdownPeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bio.bgp.BgpService/ShutdownPeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BgpServiceServer).ShutdownPeer(ctx, req.(*ShutdownPeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BgpService_EnablePeer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnablePeerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BgpServiceServer).EnablePeer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bio.bgp.BgpService/EnablePeer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BgpServiceServer).EnablePeer(ctx, req.(*EnablePeerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BgpService_EvaluatePolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluatePolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BgpServiceServer).EvaluatePolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bio.bgp.BgpService/EvaluatePolicy",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BgpServiceServer).EvaluatePolicy(ctx, req.(*EvaluatePolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BgpService_ServiceDesc is the grpc.ServiceDesc for BgpService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -212,6 +372,26 @@ var BgpService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListSessions",
 			Handler:    _BgpService_ListSessions_Handler,
 		},
+		{
+			MethodName: "SoftRefreshIn",
+			Handler:    _BgpService_SoftRefreshIn_Handler,
+		},
+		{
+			MethodName: "GetSession",
+			Handler:    _BgpService_GetSession_Handler,
+		},
+		{
+			MethodName: "ShutdownPeer",
+			Handler:    _BgpService_ShutdownPeer_Handler,
+		},
+		{
+			MethodName: "EnablePeer",
+			Handler:    _BgpService_EnablePeer_Handler,
+		},
+		{
+			MethodName: "EvaluatePolicy",
+			Handler:    _BgpService_EvaluatePolicy_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -272,6 +272,196 @@ func (x *SessionStats) GetRoutesExported() uint64 {
 	return 0
 }
 
+type SessionDetail struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LocalAddress              *api.IP          `protobuf:"bytes,1,opt,name=local_address,json=localAddress,proto3" json:"local_address,omitempty"`
+	NeighborAddress           *api.IP          `protobuf:"bytes,2,opt,name=neighbor_address,json=neighborAddress,proto3" json:"neighbor_address,omitempty"`
+	LocalAsn                  uint32           `protobuf:"varint,3,opt,name=local_asn,json=localAsn,proto3" json:"local_asn,omitempty"`
+	PeerAsn                   uint32           `protobuf:"varint,4,opt,name=peer_asn,json=peerAsn,proto3" json:"peer_asn,omitempty"`
+	Status                    Session_State    `protobuf:"varint,5,opt,name=status,proto3,enum=bio.bgp.Session_State" json:"status,omitempty"`
+	LocalRouterId             uint32           `protobuf:"varint,6,opt,name=local_router_id,json=localRouterId,proto3" json:"local_router_id,omitempty"`
+	RemoteRouterId            uint32           `protobuf:"varint,7,opt,name=remote_router_id,json=remoteRouterId,proto3" json:"remote_router_id,omitempty"`
+	AdvertisedHoldTimeSeconds uint32           `protobuf:"varint,8,opt,name=advertised_hold_time_seconds,json=advertisedHoldTimeSeconds,proto3" json:"advertised_hold_time_seconds,omitempty"`
+	NegotiatedHoldTimeSeconds uint32           `protobuf:"varint,9,opt,name=negotiated_hold_time_seconds,json=negotiatedHoldTimeSeconds,proto3" json:"negotiated_hold_time_seconds,omitempty"`
+	AddressFamilies           []*AddressFamily `protobuf:"bytes,10,rep,name=address_families,json=addressFamilies,proto3" json:"address_families,omitempty"`
+	UptimeSeconds             uint64           `protobuf:"varint,11,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	LastError                 string           `protobuf:"bytes,12,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+}
+
+func (x *SessionDetail) Reset() {
+	*x = SessionDetail{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_session_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SessionDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionDetail) ProtoMessage() {}
+
+func (x *SessionDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_session_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionDetail.ProtoReflect.Descriptor instead.
+func (*SessionDetail) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_session_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SessionDetail) GetLocalAddress() *api.IP {
+	if x != nil {
+		return x.LocalAddress
+	}
+	return nil
+}
+
+func (x *SessionDetail) GetNeighborAddress() *api.IP {
+	if x != nil {
+		return x.NeighborAddress
+	}
+	return nil
+}
+
+func (x *SessionDetail) GetLocalAsn() uint32 {
+	if x != nil {
+		return x.LocalAsn
+	}
+	return 0
+}
+
+func (x *SessionDetail) GetPeerAsn() uint32 {
+	if x != nil {
+		return x.PeerAsn
+	}
+	return 0
+}
+
+func (x *SessionDetail) GetStatus() Session_State {
+	if x != nil {
+		return x.Status
+	}
+	return Session_Disabled
+}
+
+func (x *SessionDetail) GetLocalRouterId() uint32 {
+	if x != nil {
+		return x.LocalRouterId
+	}
+	return 0
+}
+
+func (x *SessionDetail) GetRemoteRouterId() uint32 {
+	if x != nil {
+		return x.RemoteRouterId
+	}
+	return 0
+}
+
+func (x *SessionDetail) GetAdvertisedHoldTimeSeconds() uint32 {
+	if x != nil {
+		return x.AdvertisedHoldTimeSeconds
+	}
+	return 0
+}
+
+func (x *SessionDetail) GetNegotiatedHoldTimeSeconds() uint32 {
+	if x != nil {
+		return x.NegotiatedHoldTimeSeconds
+	}
+	return 0
+}
+
+func (x *SessionDetail) GetAddressFamilies() []*AddressFamily {
+	if x != nil {
+		return x.AddressFamilies
+	}
+	return nil
+}
+
+func (x *SessionDetail) GetUptimeSeconds() uint64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *SessionDetail) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+type AddressFamily struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Afi  uint32 `protobuf:"varint,1,opt,name=afi,proto3" json:"afi,omitempty"`
+	Safi uint32 `protobuf:"varint,2,opt,name=safi,proto3" json:"safi,omitempty"`
+}
+
+func (x *AddressFamily) Reset() {
+	*x = AddressFamily{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_bgp_api_session_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AddressFamily) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddressFamily) ProtoMessage() {}
+
+func (x *AddressFamily) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_bgp_api_session_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddressFamily.ProtoReflect.Descriptor instead.
+func (*AddressFamily) Descriptor() ([]byte, []int) {
+	return file_protocols_bgp_api_session_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AddressFamily) GetAfi() uint32 {
+	if x != nil {
+		return x.Afi
+	}
+	return 0
+}
+
+func (x *AddressFamily) GetSafi() uint32 {
+	if x != nil {
+		return x.Safi
+	}
+	return 0
+}
+
 var File_protocols_bgp_api_session_proto protoreflect.FileDescriptor
 
 var file_protocols_bgp_api_session_proto_rawDesc = []byte{
@@ -321,11 +511,50 @@ var file_protocols_bgp_api_session_proto_rawDesc = []byte{
 	0x52, 0x0e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x49, 0x6d, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64,
 	0x12, 0x27, 0x0a, 0x0f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x5f, 0x65, 0x78, 0x70, 0x6f, 0x72,
 	0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x72, 0x6f, 0x75, 0x74, 0x65,
-	0x73, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74,
-	0x69, 0x6e, 0x67, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x62, 0x67, 0x70, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+	0x73, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x22, 0xbe, 0x04, 0x0a, 0x0d, 0x53, 0x65,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x30, 0x0a, 0x0d, 0x6c,
+	0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49, 0x50, 0x52,
+	0x0c, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x36, 0x0a,
+	0x10, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65,
+	0x74, 0x2e, 0x49, 0x50, 0x52, 0x0f, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x61,
+	0x73, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x41,
+	0x73, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x70, 0x65, 0x65, 0x72, 0x5f, 0x61, 0x73, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x07, 0x70, 0x65, 0x65, 0x72, 0x41, 0x73, 0x6e, 0x12, 0x2e, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e,
+	0x62, 0x69, 0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x53, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x2e,
+	0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x26, 0x0a,
+	0x0f, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x52, 0x6f, 0x75,
+	0x74, 0x65, 0x72, 0x49, 0x64, 0x12, 0x28, 0x0a, 0x10, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x5f,
+	0x72, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x0e, 0x72, 0x65, 0x6d, 0x6f, 0x74, 0x65, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x3f, 0x0a, 0x1c, 0x61, 0x64, 0x76, 0x65, 0x72, 0x74, 0x69, 0x73, 0x65, 0x64, 0x5f, 0x68, 0x6f,
+	0x6c, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x19, 0x61, 0x64, 0x76, 0x65, 0x72, 0x74, 0x69, 0x73, 0x65,
+	0x64, 0x48, 0x6f, 0x6c, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x12, 0x3f, 0x0a, 0x1c, 0x6e, 0x65, 0x67, 0x6f, 0x74, 0x69, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x68,
+	0x6f, 0x6c, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x19, 0x6e, 0x65, 0x67, 0x6f, 0x74, 0x69, 0x61, 0x74,
+	0x65, 0x64, 0x48, 0x6f, 0x6c, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x12, 0x41, 0x0a, 0x10, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x66, 0x61, 0x6d,
+	0x69, 0x6c, 0x69, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62, 0x69,
+	0x6f, 0x2e, 0x62, 0x67, 0x70, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x46, 0x61, 0x6d,
+	0x69, 0x6c, 0x79, 0x52, 0x0f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x46, 0x61, 0x6d, 0x69,
+	0x6c, 0x69, 0x65, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x75, 0x70, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x75, 0x70,
+	0x74, 0x69, 0x6d, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6c,
+	0x61, 0x73, 0x74, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6c, 0x61, 0x73, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x35, 0x0a, 0x0d, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x46, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x61,
+	0x66, 0x69, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x61, 0x66, 0x69, 0x12, 0x12, 0x0a,
+	0x04, 0x73, 0x61, 0x66, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x73, 0x61, 0x66,
+	0x69, 0x42, 0x31, 0x5a, 0x2f, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x62, 0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x62, 0x69, 0x6f, 0x2d,
+	0x72, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x62, 0x67, 0x70,
+	0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -341,23 +570,29 @@ func file_protocols_bgp_api_session_proto_rawDescGZIP() []byte {
 }
 
 var file_protocols_bgp_api_session_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_protocols_bgp_api_session_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_protocols_bgp_api_session_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_protocols_bgp_api_session_proto_goTypes = []interface{}{
-	(Session_State)(0),   // 0: bio.bgp.Session.State
-	(*Session)(nil),      // 1: bio.bgp.Session
-	(*SessionStats)(nil), // 2: bio.bgp.SessionStats
-	(*api.IP)(nil),       // 3: bio.net.IP
+	(Session_State)(0),    // 0: bio.bgp.Session.State
+	(*Session)(nil),       // 1: bio.bgp.Session
+	(*SessionStats)(nil),  // 2: bio.bgp.SessionStats
+	(*SessionDetail)(nil), // 3: bio.bgp.SessionDetail
+	(*AddressFamily)(nil), // 4: bio.bgp.AddressFamily
+	(*api.IP)(nil),        // 5: bio.net.IP
 }
 var file_protocols_bgp_api_session_proto_depIdxs = []int32{
-	3, // 0: bio.bgp.Session.local_address:type_name -> bio.net.IP
-	3, // 1: bio.bgp.Session.neighbor_address:type_name -> bio.net.IP
+	5, // 0: bio.bgp.Session.local_address:type_name -> bio.net.IP
+	5, // 1: bio.bgp.Session.neighbor_address:type_name -> bio.net.IP
 	0, // 2: bio.bgp.Session.status:type_name -> bio.bgp.Session.State
 	2, // 3: bio.bgp.Session.stats:type_name -> bio.bgp.SessionStats
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	5, // 4: bio.bgp.SessionDetail.local_address:type_name -> bio.net.IP
+	5, // 5: bio.bgp.SessionDetail.neighbor_address:type_name -> bio.net.IP
+	0, // 6: bio.bgp.SessionDetail.status:type_name -> bio.bgp.Session.State
+	4, // 7: bio.bgp.SessionDetail.address_families:type_name -> bio.bgp.AddressFamily
+	8, // [8:8] is the sub-list for method output_type
+	8, // [8:8] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_protocols_bgp_api_session_proto_init() }
@@ -390,6 +625,30 @@ func file_protocols_bgp_api_session_proto_init() {
 				return nil
 			}
 		}
+		file_protocols_bgp_api_session_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SessionDetail); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_bgp_api_session_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressFamily); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -397,7 +656,7 @@ func file_protocols_bgp_api_session_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protocols_bgp_api_session_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
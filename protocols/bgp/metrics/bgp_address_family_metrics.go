@@ -14,6 +14,15 @@ type BGPAddressFamilyMetrics struct {
 	// RoutesAccepted is the number of routes we sent
 	RoutesSent uint64
 
+	// PrePolicyPaths is the number of received paths stored before applying the import filter chain
+	PrePolicyPaths uint64
+
+	// PrePolicyBytes is the estimated memory used by the paths stored before applying the import filter chain
+	PrePolicyBytes uint64
+
+	// ASLoops is the number of prefixes with a received path hidden as one of our ASNs is part of its AS path
+	ASLoops uint64
+
 	// EndOfRIBMarkerReceived indicates if a BGP End of RIB marker was received for this AFI/SAFI from the peer
 	EndOfRIBMarkerReceived bool
 }
@@ -33,6 +33,9 @@ type BGPPeerMetrics struct {
 	// Since is the time the session was established
 	Since time.Time
 
+	// HoldTime is the negotiated hold time of the established session. Zero means the hold timer and keepalives are disabled.
+	HoldTime time.Duration
+
 	// State of the BGP session (Down = 0, Idle = 1, Connect = 2, Active = 3, OpenSent = 4, OpenConfirm = 5, Established = 6)
 	State uint8
 
@@ -42,6 +45,18 @@ type BGPPeerMetrics struct {
 	// UpdatesReceived is the number of update messages we sent on this session
 	UpdatesSent uint64
 
+	// InboundQueueDepth is the number of received messages waiting to be processed
+	InboundQueueDepth uint64
+
+	// RouteRefreshesReceived is the number of ROUTE-REFRESH requests received on this session
+	RouteRefreshesReceived uint64
+
+	// RouteRefreshesCoalesced is the number of ROUTE-REFRESH requests merged into a re-advertisement already pending
+	RouteRefreshesCoalesced uint64
+
+	// PolicyVersion is incremented on every change of the import or export policy of the peer
+	PolicyVersion uint64
+
 	// AddressFamilies provides metrics on AFI/SAFI level
 	AddressFamilies []*BGPAddressFamilyMetrics
 }
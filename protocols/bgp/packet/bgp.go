@@ -10,6 +10,7 @@ const (
 	HeaderLen         = 19
 	MinLen            = 19
 	MaxLen            = 4096
+	MaxExtendedLen    = 65535
 	MinUpdateLen      = 4
 	NLRIMaxLen        = 5
 	AFILen            = 2
@@ -25,6 +26,13 @@ const (
 	UpdateMsg       = 2
 	NotificationMsg = 3
 	KeepaliveMsg    = 4
+	RouteRefreshMsg = 5
+
+	// ROUTE-REFRESH message length and subtypes (RFC2918, RFC7313)
+	RouteRefreshLen    = 23
+	RouteRefreshNormal = 0
+	RouteRefreshBoRR   = 1
+	RouteRefreshEoRR   = 2
 
 	// BGP errors
 	MessageHeaderError      = 1
@@ -33,12 +41,16 @@ const (
 	HoldTimeExpired         = 4
 	FiniteStateMachineError = 5
 	Cease                   = 6
+	RouteRefreshError       = 7
 
 	// Msg Header Errors
 	ConnectionNotSync = 1
 	BadMessageLength  = 2
 	BadMessageType    = 3
 
+	// ROUTE-REFRESH Msg Errors (RFC7313)
+	InvalidRouteRefreshLength = 1
+
 	// Open Msg Errors
 	UnsupportedVersionNumber     = 1
 	BadPeerAS                    = 2
@@ -80,8 +92,11 @@ const (
 	MultiProtocolUnreachNLRIAttr = 15
 	AS4PathAttr                  = 17
 	AS4AggregatorAttr            = 18
+	PMSITunnelAttr               = 22
+	TunnelEncapsulationAttr      = 23
 	LargeCommunitiesAttr         = 32
 	OnlyToCustomerAttr           = 35
+	PrefixSIDAttr                = 40
 
 	// ORIGIN values
 	IGP        = 0
@@ -98,26 +113,41 @@ const (
 	ConnectionCollisionResolution = 7
 	OutOfResources                = 8
 
+	// NOTIFICATION Finite State Machine Error SubCodes (RFC6608)
+	UnspecifiedFSMError                 = 0
+	UnexpectedMessageInOpenSentState    = 1
+	UnexpectedMessageInOpenConfirmState = 2
+	UnexpectedMessageInEstablishedState = 3
+
 	// Address Familiy Identifiers
-	AFIIPv4 = 1
-	AFIIPv6 = 2
+	AFIIPv4      = 1
+	AFIIPv6      = 2
+	AFILinkState = 16388
 
 	// Sub-Address Familiy Identifiers
 	SAFIUnicast        = 1
 	SAFILabeledUnicast = 4
+	SAFILinkState      = 71
 
 	// Capabilities
-	CapabilitiesParamType       = 2
-	MultiProtocolCapabilityCode = 1
-	PeerRoleCapabilityCode      = 9
-	ASN4CapabilityCode          = 65
-	AddPathCapabilityCode       = 69
+	CapabilitiesParamType              = 2
+	MultiProtocolCapabilityCode        = 1
+	RouteRefreshCapabilityCode         = 2
+	ExtendedMessageCapabilityCode      = 6
+	PeerRoleCapabilityCode             = 9
+	GracefulRestartCapabilityCode      = 64
+	ASN4CapabilityCode                 = 65
+	AddPathCapabilityCode              = 69
+	EnhancedRouteRefreshCapabilityCode = 70
 
 	// AddPath capability
 	AddPathReceive     = 1
 	AddPathSend        = 2
 	AddPathSendReceive = 3
 
+	// Graceful restart capability
+	GracefulRestartForwardingState = 0x80
+
 	// BGP Role capability
 	PeerRoleRoleProvider = 0
 	PeerRoleRoleRS       = 1
@@ -167,6 +197,18 @@ type BGPOpen struct {
 type BGPNotification struct {
 	ErrorCode    uint8
 	ErrorSubcode uint8
+	Data         []byte
+}
+
+// BGPRouteRefresh represents a ROUTE-REFRESH message (RFC2918). Subtype is the former reserved field (RFC7313).
+type BGPRouteRefresh struct {
+	AFI     uint16
+	Subtype uint8
+	SAFI    uint8
+
+	// WhenToRefresh and ORFs are only present if the message carries ORF entries (RFC5291)
+	WhenToRefresh uint8
+	ORFs          []ORFEntries
 }
 
 type PathAttribute struct {
@@ -187,6 +229,8 @@ func AFIName(afi uint16) string {
 		return "IPv4"
 	case AFIIPv6:
 		return "IPv6"
+	case AFILinkState:
+		return "Link-State"
 	default:
 		return "Unknown AFI"
 	}
@@ -13,6 +13,9 @@ func TestAFIName(t *testing.T) {
 	afiIPv6 := AFIName(2)
 	assert.Equal(t, "IPv6", afiIPv6)
 
+	afiLinkState := AFIName(16388)
+	assert.Equal(t, "Link-State", afiLinkState)
+
 	afiUnknown := AFIName(0)
 	assert.Equal(t, "Unknown AFI", afiUnknown)
 }
@@ -5,6 +5,17 @@ type DecodeOptions struct {
 	AddPathIPv4Unicast bool
 	AddPathIPv6Unicast bool
 	Use32BitASN        bool
+	ExtendedMessage    bool
+}
+
+// maxLen returns the maximum length of a message of type msgType. OPEN and KEEPALIVE messages
+// are never extended (RFC8654 Sect. 4)
+func (d *DecodeOptions) maxLen(msgType uint8) uint16 {
+	if d != nil && d.ExtendedMessage && msgType != OpenMsg && msgType != KeepaliveMsg {
+		return MaxExtendedLen
+	}
+
+	return MaxLen
 }
 
 func (d *DecodeOptions) addPath(afi uint16, safi uint8) bool {
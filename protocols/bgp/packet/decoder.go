@@ -2,6 +2,7 @@ package packet
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net"
 
@@ -10,19 +11,20 @@ import (
 )
 
 const (
-	addPathTupleSize = 4
+	addPathTupleSize         = 4
+	gracefulRestartTupleSize = 4
 )
 
 // Decode decodes a BGP message
 func Decode(buf *bytes.Buffer, opt *DecodeOptions) (*BGPMessage, error) {
-	hdr, err := decodeHeader(buf)
+	hdr, err := decodeHeader(buf, opt)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode header: %w", err)
 	}
 
 	body, err := decodeMsgBody(buf, hdr.Type, hdr.Length-MinLen, opt)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode message: %w", err)
+		return nil, fmt.Errorf("failed to decode message: %w", msgBodyError(hdr.Type, err))
 	}
 
 	return &BGPMessage{
@@ -31,6 +33,33 @@ func Decode(buf *bytes.Buffer, opt *DecodeOptions) (*BGPMessage, error) {
 	}, nil
 }
 
+// msgBodyError turns an error decoding the body of a message of type msgType not indicating the NOTIFICATION to send
+// into a BGPError. Errors decoding NOTIFICATIONs are returned as is, as a NOTIFICATION must not be answered with one.
+func msgBodyError(msgType uint8, err error) error {
+	var bgpErr BGPError
+	if errors.As(err, &bgpErr) {
+		return err
+	}
+
+	switch msgType {
+	case OpenMsg:
+		// The fixed fields or optional parameters of the message are inconsistent with its length
+		return BGPError{
+			ErrorCode:    MessageHeaderError,
+			ErrorSubCode: BadMessageLength,
+			ErrorStr:     err.Error(),
+		}
+	case UpdateMsg:
+		return BGPError{
+			ErrorCode:    UpdateMessageError,
+			ErrorSubCode: MalformedAttributeList,
+			ErrorStr:     err.Error(),
+		}
+	}
+
+	return err
+}
+
 func decodeMsgBody(buf *bytes.Buffer, msgType uint8, l uint16, opt *DecodeOptions) (interface{}, error) {
 	switch msgType {
 	case OpenMsg:
@@ -40,11 +69,61 @@ func decodeMsgBody(buf *bytes.Buffer, msgType uint8, l uint16, opt *DecodeOption
 	case KeepaliveMsg:
 		return nil, nil // Nothing to decode in Keepalive message
 	case NotificationMsg:
-		return decodeNotificationMsg(buf)
+		return decodeNotificationMsg(buf, l)
+	case RouteRefreshMsg:
+		return decodeRouteRefreshMsg(buf, l)
 	}
 	return nil, fmt.Errorf("unknown message type: %d", msgType)
 }
 
+func decodeRouteRefreshMsg(buf *bytes.Buffer, l uint16) (*BGPRouteRefresh, error) {
+	invalidLength := BGPError{
+		ErrorCode:    RouteRefreshError,
+		ErrorSubCode: InvalidRouteRefreshLength,
+		ErrorStr:     fmt.Sprintf("Invalid ROUTE-REFRESH message length: %d", l+MinLen),
+	}
+
+	if l < RouteRefreshLen-MinLen {
+		return nil, invalidLength
+	}
+
+	msg := &BGPRouteRefresh{}
+	fields := []interface{}{
+		&msg.AFI,
+		&msg.Subtype,
+		&msg.SAFI,
+	}
+
+	err := decode.Decode(buf, fields)
+	if err != nil {
+		return msg, err
+	}
+
+	if l == RouteRefreshLen-MinLen {
+		return msg, nil
+	}
+
+	// Only normal ROUTE-REFRESH messages may carry ORF entries (RFC7313)
+	if msg.Subtype != RouteRefreshNormal {
+		return nil, invalidLength
+	}
+
+	orfBuf := bytes.NewBuffer(buf.Next(int(l - (RouteRefreshLen - MinLen))))
+	err = decode.Decode(orfBuf, []interface{}{
+		&msg.WhenToRefresh,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode when-to-refresh: %w", err)
+	}
+
+	msg.ORFs, err = decodeORFEntries(orfBuf, msg.AFI)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode ORF entries: %w", err)
+	}
+
+	return msg, nil
+}
+
 func decodeUpdateMsg(buf *bytes.Buffer, l uint16, opt *DecodeOptions) (*BGPUpdate, error) {
 	msg := &BGPUpdate{}
 
@@ -79,7 +158,7 @@ func decodeUpdateMsg(buf *bytes.Buffer, l uint16, opt *DecodeOptions) (*BGPUpdat
 	return msg, nil
 }
 
-func decodeNotificationMsg(buf *bytes.Buffer) (*BGPNotification, error) {
+func decodeNotificationMsg(buf *bytes.Buffer, l uint16) (*BGPNotification, error) {
 	msg := &BGPNotification{}
 
 	fields := []interface{}{
@@ -92,7 +171,15 @@ func decodeNotificationMsg(buf *bytes.Buffer) (*BGPNotification, error) {
 		return msg, err
 	}
 
-	if msg.ErrorCode > Cease {
+	if l > 2 {
+		msg.Data = make([]byte, l-2)
+		err = decode.Decode(buf, []interface{}{&msg.Data})
+		if err != nil {
+			return msg, fmt.Errorf("unable to decode data: %w", err)
+		}
+	}
+
+	if msg.ErrorCode > RouteRefreshError {
 		return msg, fmt.Errorf("invalid error code: %d", msg.ErrorSubcode)
 	}
 
@@ -102,7 +189,7 @@ func decodeNotificationMsg(buf *bytes.Buffer) (*BGPNotification, error) {
 			return invalidErrCode(msg)
 		}
 	case OpenMessageError:
-		if msg.ErrorSubcode > UnacceptableHoldTime || msg.ErrorSubcode == 0 || msg.ErrorSubcode == DeprecatedOpenMsgError5 {
+		if (msg.ErrorSubcode > UnacceptableHoldTime && msg.ErrorSubcode != RoleMismatchError) || msg.ErrorSubcode == 0 || msg.ErrorSubcode == DeprecatedOpenMsgError5 {
 			return invalidErrCode(msg)
 		}
 	case UpdateMessageError:
@@ -114,7 +201,7 @@ func decodeNotificationMsg(buf *bytes.Buffer) (*BGPNotification, error) {
 			return invalidErrCode(msg)
 		}
 	case FiniteStateMachineError:
-		if msg.ErrorSubcode != 0 {
+		if msg.ErrorSubcode > UnexpectedMessageInEstablishedState {
 			return invalidErrCode(msg)
 		}
 	case Cease:
@@ -122,6 +209,10 @@ func decodeNotificationMsg(buf *bytes.Buffer) (*BGPNotification, error) {
 		if msg.ErrorSubcode > OutOfResources {
 			return invalidErrCode(msg)
 		}
+	case RouteRefreshError:
+		if msg.ErrorSubcode != InvalidRouteRefreshLength {
+			return invalidErrCode(msg)
+		}
 	default:
 		return invalidErrCode(msg)
 	}
@@ -201,7 +292,11 @@ func decodeOptParams(buf *bytes.Buffer, optParmLen uint8) ([]OptParam, error) {
 				read += cap.Length + 2
 			}
 		default:
-			return nil, fmt.Errorf("unrecognized option: %d", o.Type)
+			return nil, BGPError{
+				ErrorCode:    OpenMessageError,
+				ErrorSubCode: UnsupportedOptionalParameter,
+				ErrorStr:     fmt.Sprintf("unrecognized option: %d", o.Type),
+			}
 		}
 
 	}
@@ -256,6 +351,33 @@ func decodeCapability(buf *bytes.Buffer) (Capability, error) {
 			return cap, fmt.Errorf("unable to decode 4 octet ASN capability: %w", err)
 		}
 		cap.Value = asn4Cap
+	case ExtendedMessageCapabilityCode:
+		if cap.Length != 0 {
+			return cap, fmt.Errorf("invalid extended message capability length: %d", cap.Length)
+		}
+		cap.Value = ExtendedMessageCapability{}
+	case GracefulRestartCapabilityCode:
+		grCap, err := decodeGracefulRestartCapability(buf, cap.Length)
+		if err != nil {
+			return cap, fmt.Errorf("unable to decode graceful restart capability: %w", err)
+		}
+		cap.Value = grCap
+	case RouteRefreshCapabilityCode:
+		if cap.Length != 0 {
+			return cap, fmt.Errorf("invalid route refresh capability length: %d", cap.Length)
+		}
+		cap.Value = RouteRefreshCapability{}
+	case EnhancedRouteRefreshCapabilityCode:
+		if cap.Length != 0 {
+			return cap, fmt.Errorf("invalid enhanced route refresh capability length: %d", cap.Length)
+		}
+		cap.Value = EnhancedRouteRefreshCapability{}
+	case OutboundRouteFilteringCapabilityCode:
+		orfCap, err := decodeORFCapability(buf, cap.Length)
+		if err != nil {
+			return cap, fmt.Errorf("unable to decode outbound route filtering capability: %w", err)
+		}
+		cap.Value = orfCap
 	case PeerRoleCapabilityCode:
 		peerRoleCap, err := decodePeerRoleCapability(buf)
 		if err != nil {
@@ -314,6 +436,40 @@ func decodeAddPathCapability(buf *bytes.Buffer, capLength uint8) (AddPathCapabil
 	return addPathCaps, nil
 }
 
+func decodeGracefulRestartCapability(buf *bytes.Buffer, capLength uint8) (GracefulRestartCapability, error) {
+	grCap := GracefulRestartCapability{}
+
+	if capLength < 2 || (capLength-2)%gracefulRestartTupleSize != 0 {
+		return grCap, fmt.Errorf("invalid caplength %d", capLength)
+	}
+
+	flagsAndTime := uint16(0)
+	err := decode.DecodeUint16(buf, &flagsAndTime)
+	if err != nil {
+		return grCap, err
+	}
+
+	grCap.RestartFlags = uint8(flagsAndTime >> 12)
+	grCap.RestartTime = flagsAndTime & 0x0fff
+
+	for capLength -= 2; capLength >= gracefulRestartTupleSize; capLength -= gracefulRestartTupleSize {
+		t := GracefulRestartCapabilityTuple{}
+		fields := []interface{}{
+			&t.AFI,
+			&t.SAFI,
+			&t.Flags,
+		}
+		err := decode.Decode(buf, fields)
+		if err != nil {
+			return grCap, err
+		}
+
+		grCap.Tuples = append(grCap.Tuples, t)
+	}
+
+	return grCap, nil
+}
+
 func decodeASN4Capability(buf *bytes.Buffer) (ASN4Capability, error) {
 	asn4Cap := ASN4Capability{}
 	fields := []interface{}{
@@ -382,7 +538,7 @@ func isValidIdentifier(id uint32) bool {
 	return true
 }
 
-func decodeHeader(buf *bytes.Buffer) (*BGPHeader, error) {
+func decodeHeader(buf *bytes.Buffer, opt *DecodeOptions) (*BGPHeader, error) {
 	hdr := &BGPHeader{}
 
 	for i := 0; i < MarkerLen; i++ {
@@ -422,7 +578,7 @@ func decodeHeader(buf *bytes.Buffer) (*BGPHeader, error) {
 		}
 	}
 
-	if hdr.Length < MinLen || hdr.Length > MaxLen {
+	if hdr.Length < MinLen || hdr.Length > opt.maxLen(hdr.Type) {
 		return hdr, BGPError{
 			ErrorCode:    MessageHeaderError,
 			ErrorSubCode: BadMessageLength,
@@ -430,7 +586,7 @@ func decodeHeader(buf *bytes.Buffer) (*BGPHeader, error) {
 		}
 	}
 
-	if hdr.Type > KeepaliveMsg || hdr.Type == 0 {
+	if hdr.Type > RouteRefreshMsg || hdr.Type == 0 {
 		return hdr, BGPError{
 			ErrorCode:    MessageHeaderError,
 			ErrorSubCode: BadMessageType,
@@ -342,6 +342,15 @@ func TestDecodeNotificationMsg(t *testing.T) {
 				ErrorSubcode: 2,
 			},
 		},
+		{
+			name:     "Role Mismatch",
+			input:    []byte{2, 11},
+			wantFail: false,
+			expected: &BGPNotification{
+				ErrorCode:    2,
+				ErrorSubcode: 11,
+			},
+		},
 		{
 			name:     "Empty input",
 			input:    []byte{},
@@ -370,9 +379,18 @@ func TestDecodeNotificationMsg(t *testing.T) {
 				ErrorSubcode: 0,
 			},
 		},
+		{
+			name:     "FSM Error (unexpected message in Established state)",
+			input:    []byte{5, 3},
+			wantFail: false,
+			expected: &BGPNotification{
+				ErrorCode:    5,
+				ErrorSubcode: 3,
+			},
+		},
 		{
 			name:     "FSM Error (invalid subcode)",
-			input:    []byte{5, 1},
+			input:    []byte{5, 4},
 			wantFail: true,
 		},
 		{
@@ -384,6 +402,16 @@ func TestDecodeNotificationMsg(t *testing.T) {
 				ErrorSubcode: 0,
 			},
 		},
+		{
+			name:     "Cease with shutdown communication",
+			input:    []byte{6, 2, 3, 'f', 'o', 'o'},
+			wantFail: false,
+			expected: &BGPNotification{
+				ErrorCode:    6,
+				ErrorSubcode: 2,
+				Data:         []byte{3, 'f', 'o', 'o'},
+			},
+		},
 		{
 			name:     "Cease (invalid subcode)",
 			input:    []byte{6, 9},
@@ -392,7 +420,7 @@ func TestDecodeNotificationMsg(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		res, err := decodeNotificationMsg(bytes.NewBuffer(test.input))
+		res, err := decodeNotificationMsg(bytes.NewBuffer(test.input), uint16(len(test.input)))
 
 		if test.wantFail {
 			if err != nil {
@@ -1087,7 +1115,7 @@ func TestDecodeUpdateMsg(t *testing.T) {
 											Length:         6,
 											TypeCode:       7,
 											Value: types.Aggregator{
-												ASN:     uint16(258),
+												ASN:     uint32(258),
 												Address: bnet.IPv4FromOctets(10, 11, 12, 13).Ptr().ToUint32(),
 											},
 										},
@@ -1237,8 +1265,79 @@ func TestDecodeMsgBody(t *testing.T) {
 	}{
 		{
 			name:     "Unknown msgType",
-			msgType:  5,
+			msgType:  6,
+			wantFail: true,
+		},
+		{
+			name:    "Route refresh",
+			buffer:  bytes.NewBuffer([]byte{0, 2, 0, 1}),
+			msgType: RouteRefreshMsg,
+			length:  4,
+			expected: &BGPRouteRefresh{
+				AFI:     AFIIPv6,
+				Subtype: RouteRefreshNormal,
+				SAFI:    SAFIUnicast,
+			},
+		},
+		{
+			name:    "Route refresh BoRR",
+			buffer:  bytes.NewBuffer([]byte{0, 1, 1, 1}),
+			msgType: RouteRefreshMsg,
+			length:  4,
+			expected: &BGPRouteRefresh{
+				AFI:     AFIIPv4,
+				Subtype: RouteRefreshBoRR,
+				SAFI:    SAFIUnicast,
+			},
+		},
+		{
+			name: "Route refresh with ORF entries",
+			buffer: bytes.NewBuffer([]byte{
+				0, 2, 0, 1, // AFI, Subtype, SAFI
+				ORFDefer,
+				1, 0, 2, 0xff, 0xff, // Unsupported ORF type
+				AddressPrefixORFType, 0, 12,
+				0x40, 0, 0, 0, 5, 48, 64, 32, 0x20, 0x01, 0, 0, // REMOVE, PERMIT 2001::/32 ge 48 le 64
+			}),
+			msgType: RouteRefreshMsg,
+			length:  25,
+			expected: &BGPRouteRefresh{
+				AFI:           AFIIPv6,
+				Subtype:       RouteRefreshNormal,
+				SAFI:          SAFIUnicast,
+				WhenToRefresh: ORFDefer,
+				ORFs: []ORFEntries{
+					{
+						Type: AddressPrefixORFType,
+						AddressPrefixEntries: []AddressPrefixORFEntry{
+							{
+								Action:   ORFActionRemove,
+								Match:    ORFMatchPermit,
+								Sequence: 5,
+								MinLen:   48,
+								MaxLen:   64,
+								Prefix:   bnet.NewPfx(bnet.IPv6(0x2001000000000000, 0), 32).Dedup(),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "Route refresh with truncated ORF entry",
+			buffer:   bytes.NewBuffer([]byte{0, 1, 0, 1, ORFImmediate, AddressPrefixORFType, 0, 9, 0, 0, 0, 0, 1, 0, 0, 16, 10}),
+			msgType:  RouteRefreshMsg,
+			length:   17,
 			wantFail: true,
+			expected: (*BGPRouteRefresh)(nil),
+		},
+		{
+			name:     "Route refresh with invalid length",
+			buffer:   bytes.NewBuffer([]byte{0, 1, 2, 1, 0}),
+			msgType:  RouteRefreshMsg,
+			length:   5,
+			wantFail: true,
+			expected: (*BGPRouteRefresh)(nil),
 		},
 	}
 
@@ -1316,9 +1415,9 @@ func TestDecodeHeader(t *testing.T) {
 			},
 		},
 		{
-			// Invalid message type 5
+			// Invalid message type 6
 			testNum:  4,
-			input:    []byte{255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 0, 19, 5},
+			input:    []byte{255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 0, 19, 6},
 			wantFail: true,
 			expected: &BGPHeader{
 				Length: 19,
@@ -1367,7 +1466,7 @@ func TestDecodeHeader(t *testing.T) {
 
 	for _, test := range tests {
 		buf := bytes.NewBuffer(test.input)
-		res, err := decodeHeader(buf)
+		res, err := decodeHeader(buf, &DecodeOptions{})
 
 		if err != nil {
 			if test.wantFail {
@@ -1385,6 +1484,83 @@ func TestDecodeHeader(t *testing.T) {
 	}
 }
 
+func TestDecodeHeaderExtendedMessage(t *testing.T) {
+	marker := []byte{255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255}
+
+	tests := []struct {
+		name            string
+		length          uint16
+		msgType         uint8
+		extendedMessage bool
+		wantFail        bool
+	}{
+		{
+			name:    "UPDATE of maximum length",
+			length:  4096,
+			msgType: UpdateMsg,
+		},
+		{
+			name:     "UPDATE exceeding maximum length",
+			length:   4097,
+			msgType:  UpdateMsg,
+			wantFail: true,
+		},
+		{
+			name:            "extended UPDATE",
+			length:          4097,
+			msgType:         UpdateMsg,
+			extendedMessage: true,
+		},
+		{
+			name:            "extended UPDATE of maximum length",
+			length:          65535,
+			msgType:         UpdateMsg,
+			extendedMessage: true,
+		},
+		{
+			name:            "extended NOTIFICATION",
+			length:          4097,
+			msgType:         NotificationMsg,
+			extendedMessage: true,
+		},
+		{
+			name:            "OPEN is never extended",
+			length:          4097,
+			msgType:         OpenMsg,
+			extendedMessage: true,
+			wantFail:        true,
+		},
+		{
+			name:            "KEEPALIVE is never extended",
+			length:          4097,
+			msgType:         KeepaliveMsg,
+			extendedMessage: true,
+			wantFail:        true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := append([]byte{}, marker...)
+			input = append(input, uint8(test.length>>8), uint8(test.length), test.msgType)
+
+			hdr, err := decodeHeader(bytes.NewBuffer(input), &DecodeOptions{
+				ExtendedMessage: test.extendedMessage,
+			})
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, &BGPHeader{
+				Length: test.length,
+				Type:   test.msgType,
+			}, hdr)
+		})
+	}
+}
+
 func genericTest(f decodeFunc, tests []test, t *testing.T) {
 	for _, test := range tests {
 		buf := bytes.NewBuffer(test.input)
@@ -1659,6 +1835,104 @@ func TestDecodeCapability(t *testing.T) {
 			},
 			wantFail: false,
 		},
+		{
+			name:  "Extended Message Capability",
+			input: []byte{6, 0},
+			expected: Capability{
+				Code:  ExtendedMessageCapabilityCode,
+				Value: ExtendedMessageCapability{},
+			},
+			wantFail: false,
+		},
+		{
+			name:     "Extended Message Capability with invalid length",
+			input:    []byte{6, 1, 0},
+			wantFail: true,
+		},
+		{
+			name:  "Route Refresh Capability",
+			input: []byte{2, 0},
+			expected: Capability{
+				Code:  RouteRefreshCapabilityCode,
+				Value: RouteRefreshCapability{},
+			},
+		},
+		{
+			name:  "Outbound Route Filtering Capability",
+			input: []byte{3, 12, 0, 1, 0, 1, 1, 64, 2, 0, 2, 0, 1, 0},
+			expected: Capability{
+				Code:   OutboundRouteFilteringCapabilityCode,
+				Length: 12,
+				Value: ORFCapability{
+					{
+						AFI:  AFIIPv4,
+						SAFI: SAFIUnicast,
+						ORFs: []ORFTypeCapability{
+							{
+								Type:        AddressPrefixORFType,
+								SendReceive: ORFSend,
+							},
+						},
+					},
+					{
+						AFI:  AFIIPv6,
+						SAFI: SAFIUnicast,
+						ORFs: []ORFTypeCapability{},
+					},
+				},
+			},
+		},
+		{
+			name:     "Outbound Route Filtering Capability with invalid length",
+			input:    []byte{3, 6, 0, 1, 0, 1, 1, 64},
+			wantFail: true,
+		},
+		{
+			name:  "Enhanced Route Refresh Capability",
+			input: []byte{70, 0},
+			expected: Capability{
+				Code:  EnhancedRouteRefreshCapabilityCode,
+				Value: EnhancedRouteRefreshCapability{},
+			},
+		},
+		{
+			name:  "Graceful Restart Capability",
+			input: []byte{64, 6, 0x80, 0x78, 0, 1, 1, 0x80},
+			expected: Capability{
+				Code:   GracefulRestartCapabilityCode,
+				Length: 6,
+				Value: GracefulRestartCapability{
+					RestartFlags: 8,
+					RestartTime:  120,
+					Tuples: []GracefulRestartCapabilityTuple{
+						{
+							AFI:   AFIIPv4,
+							SAFI:  SAFIUnicast,
+							Flags: 0x80,
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "Graceful Restart Capability without address families",
+			input: []byte{64, 2, 0, 0},
+			expected: Capability{
+				Code:   GracefulRestartCapabilityCode,
+				Length: 2,
+				Value:  GracefulRestartCapability{},
+			},
+		},
+		{
+			name:     "Graceful Restart Capability with invalid length",
+			input:    []byte{64, 3, 0, 0, 0},
+			wantFail: true,
+		},
+		{
+			name:     "Enhanced Route Refresh Capability with invalid length",
+			input:    []byte{70, 1, 0},
+			wantFail: true,
+		},
 		{
 			name:     "PeerRole Capability without value",
 			input:    []byte{9, 4},
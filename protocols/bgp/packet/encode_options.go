@@ -1,6 +1,16 @@
 package packet
 
 type EncodeOptions struct {
-	Use32BitASN bool
-	UseAddPath  bool
+	Use32BitASN     bool
+	UseAddPath      bool
+	ExtendedMessage bool
+}
+
+// MaxMessageLen returns the maximum length of an UPDATE message
+func (e *EncodeOptions) MaxMessageLen() int {
+	if e.ExtendedMessage {
+		return MaxExtendedLen
+	}
+
+	return MaxLen
 }
@@ -15,11 +15,33 @@ func SerializeKeepaliveMsg() []byte {
 }
 
 func SerializeNotificationMsg(msg *BGPNotification) []byte {
-	notificationLen := uint16(21)
+	notificationLen := uint16(21 + len(msg.Data))
 	buf := bytes.NewBuffer(make([]byte, 0, notificationLen))
 	serializeHeader(buf, notificationLen, NotificationMsg)
 	buf.WriteByte(msg.ErrorCode)
 	buf.WriteByte(msg.ErrorSubcode)
+	buf.Write(msg.Data)
+
+	return buf.Bytes()
+}
+
+// SerializeRouteRefreshMsg serializes a ROUTE-REFRESH message (RFC2918, RFC7313) and the ORF entries it carries (RFC5291)
+func SerializeRouteRefreshMsg(msg *BGPRouteRefresh) []byte {
+	orfBuf := bytes.NewBuffer(nil)
+	if len(msg.ORFs) > 0 {
+		orfBuf.WriteByte(msg.WhenToRefresh)
+		for i := range msg.ORFs {
+			msg.ORFs[i].serialize(orfBuf)
+		}
+	}
+
+	routeRefreshLen := uint16(RouteRefreshLen + orfBuf.Len())
+	buf := bytes.NewBuffer(make([]byte, 0, routeRefreshLen))
+	serializeHeader(buf, routeRefreshLen, RouteRefreshMsg)
+	buf.Write(convert.Uint16Byte(msg.AFI))
+	buf.WriteByte(msg.Subtype)
+	buf.WriteByte(msg.SAFI)
+	buf.Write(orfBuf.Bytes())
 
 	return buf.Bytes()
 }
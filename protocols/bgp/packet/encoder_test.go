@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"testing"
 
+	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/tflow2/convert"
 	"github.com/stretchr/testify/assert"
 )
@@ -52,6 +53,23 @@ func TestSerializeNotificationMsg(t *testing.T) {
 				0x06, // Error Subcode
 			},
 		},
+		{
+			name: "Shutdown communication",
+			input: &BGPNotification{
+				ErrorCode:    6,
+				ErrorSubcode: 2,
+				Data:         []byte{3, 'f', 'o', 'o'},
+			},
+			expected: []byte{
+				0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+				0x00, 0x19, // Length
+				0x03,             // Type
+				0x06,             // Error Code
+				0x02,             // Error Subcode
+				0x03,             // Shutdown communication length
+				0x66, 0x6f, 0x6f, // Shutdown communication
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -60,6 +78,86 @@ func TestSerializeNotificationMsg(t *testing.T) {
 	}
 }
 
+func TestSerializeRouteRefreshMsg(t *testing.T) {
+	msg := &BGPRouteRefresh{
+		AFI:     AFIIPv6,
+		Subtype: RouteRefreshEoRR,
+		SAFI:    SAFIUnicast,
+	}
+
+	res := SerializeRouteRefreshMsg(msg)
+	assert.Equal(t, []byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0x00, 0x17, // Length
+		0x05,       // Type
+		0x00, 0x02, // AFI
+		0x02, // Subtype
+		0x01, // SAFI
+	}, res)
+
+	decoded, err := Decode(bytes.NewBuffer(res), &DecodeOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, msg, decoded.Body)
+}
+
+func TestSerializeRouteRefreshMsgWithORFs(t *testing.T) {
+	msg := &BGPRouteRefresh{
+		AFI:           AFIIPv4,
+		Subtype:       RouteRefreshNormal,
+		SAFI:          SAFIUnicast,
+		WhenToRefresh: ORFImmediate,
+		ORFs: []ORFEntries{
+			{
+				Type: AddressPrefixORFType,
+				AddressPrefixEntries: []AddressPrefixORFEntry{
+					{
+						Action: ORFActionRemoveAll,
+					},
+					{
+						Action:   ORFActionAdd,
+						Match:    ORFMatchPermit,
+						Sequence: 1,
+						MaxLen:   24,
+						Prefix:   bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Dedup(),
+					},
+					{
+						Action:   ORFActionAdd,
+						Match:    ORFMatchDeny,
+						Sequence: 2,
+						Prefix:   bnet.NewPfx(bnet.IPv4FromOctets(192, 168, 0, 0), 16).Dedup(),
+					},
+				},
+			},
+		},
+	}
+
+	res := SerializeRouteRefreshMsg(msg)
+	assert.Equal(t, []byte{
+		0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+		0x00, 0x2f, // Length
+		0x05,       // Type
+		0x00, 0x01, // AFI
+		0x00,     // Subtype
+		0x01,     // SAFI
+		0x01,     // When-to-refresh
+		64,       // ORF type
+		0x00, 20, // Length of ORF entries
+		0x80,                   // REMOVE-ALL
+		0x00,                   // ADD, PERMIT
+		0x00, 0x00, 0x00, 0x01, // Sequence
+		0, 24, // Minlen, Maxlen
+		8, 10, // Prefix
+		0x20,                   // ADD, DENY
+		0x00, 0x00, 0x00, 0x02, // Sequence
+		0, 0, // Minlen, Maxlen
+		16, 192, 168, // Prefix
+	}, res)
+
+	decoded, err := Decode(bytes.NewBuffer(res), &DecodeOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, msg, decoded.Body)
+}
+
 func TestSerializeOpenMsg(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -174,6 +272,47 @@ func TestSerializeOptParams(t *testing.T) {
 			},
 			expected: []byte{2, 3, 9, 1, 4},
 		},
+		{
+			name: "Extended Message",
+			optParams: []OptParam{
+				{
+					Type:   2,
+					Length: 2,
+					Value: Capabilities{
+						Capability{
+							Code:  ExtendedMessageCapabilityCode,
+							Value: ExtendedMessageCapability{},
+						},
+					},
+				},
+			},
+			expected: []byte{2, 2, 6, 0},
+		},
+		{
+			name: "Graceful Restart",
+			optParams: []OptParam{
+				{
+					Type: 2,
+					Value: Capabilities{
+						Capability{
+							Code: GracefulRestartCapabilityCode,
+							Value: GracefulRestartCapability{
+								RestartFlags: 8,
+								RestartTime:  120,
+								Tuples: []GracefulRestartCapabilityTuple{
+									{
+										AFI:   AFIIPv6,
+										SAFI:  SAFIUnicast,
+										Flags: 0x80,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []byte{2, 8, 64, 6, 0x80, 0x78, 0, 2, 1, 0x80},
+		},
 	}
 
 	for _, test := range tests {
@@ -7,6 +7,10 @@ import (
 )
 
 func deserializePrefix(b []byte, pfxLen uint8, afi uint16) (*bnet.Prefix, error) {
+	if pfxLen > afiAddrLenBytes[afi]*8 {
+		return nil, fmt.Errorf("prefix length %d exceeds address length", pfxLen)
+	}
+
 	numBytes := BytesInAddr(pfxLen)
 
 	if numBytes != uint8(len(b)) {
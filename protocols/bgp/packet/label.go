@@ -4,12 +4,16 @@ import (
 	"bytes"
 	"fmt"
 
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
 	"github.com/bio-routing/tflow2/convert"
 )
 
 const bottomOfStackBit = 0x01
 const lengthEXPAndBottomOfStack = 0x04
 
+// withdrawalLabel is sent instead of a label stack when withdrawing a labeled unicast route (RFC8277 2.4)
+const withdrawalLabel = LabelStackEntry(0x800000)
+
 type LabelStackEntry uint32
 
 // NewLabelStackEntry creates a new label stack entry
@@ -45,3 +49,31 @@ func decodeLabelStackEntry(buf *bytes.Buffer) (LabelStackEntry, error) {
 
 	return LabelStackEntry(convert.Uint32b([]byte{0, label[0], label[1], label[2]})), nil
 }
+
+// LabelStackFromEntries converts the label stack entries of an NLRI into a LabelStack. It returns nil if there are no entries.
+func LabelStackFromEntries(entries []LabelStackEntry) *types.LabelStack {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	ls := make(types.LabelStack, len(entries))
+	for i, e := range entries {
+		ls[i] = e.GetLabel()
+	}
+
+	return &ls
+}
+
+// LabelStackEntriesFromLabelStack converts a LabelStack into label stack entries of an NLRI
+func LabelStackEntriesFromLabelStack(ls *types.LabelStack) []LabelStackEntry {
+	if ls == nil || len(*ls) == 0 {
+		return nil
+	}
+
+	entries := make([]LabelStackEntry, len(*ls))
+	for i, l := range *ls {
+		entries[i] = NewLabelStackEntry(l)
+	}
+
+	return entries
+}
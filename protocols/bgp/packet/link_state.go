@@ -0,0 +1,484 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+// Link state NLRI types (RFC7752)
+const (
+	LinkStateNodeNLRIType       = 1
+	LinkStateLinkNLRIType       = 2
+	LinkStateIPv4PrefixNLRIType = 3
+	LinkStateIPv6PrefixNLRIType = 4
+)
+
+// Link state protocol identifiers (RFC7752)
+const (
+	LinkStateProtocolISISL1 = 1
+	LinkStateProtocolISISL2 = 2
+	LinkStateProtocolOSPFv2 = 3
+	LinkStateProtocolDirect = 4
+	LinkStateProtocolStatic = 5
+	LinkStateProtocolOSPFv3 = 6
+)
+
+// Link state NLRI TLV code points (RFC7752)
+const (
+	linkStateLocalNodeDescriptorsTLV         = 256
+	linkStateRemoteNodeDescriptorsTLV        = 257
+	linkStateLinkIdentifiersTLV              = 258
+	linkStateIPv4InterfaceAddressTLV         = 259
+	linkStateIPv4NeighborAddressTLV          = 260
+	linkStateIPv6InterfaceAddressTLV         = 261
+	linkStateIPv6NeighborAddressTLV          = 262
+	linkStateMultiTopologyIDTLV              = 263
+	linkStateOSPFRouteTypeTLV                = 264
+	linkStateIPReachabilityInformationTLV    = 265
+	linkStateAutonomousSystemTLV             = 512
+	linkStateBGPLSIdentifierTLV              = 513
+	linkStateOSPFAreaIDTLV                   = 514
+	linkStateIGPRouterIDTLV                  = 515
+	linkStateNLRIHeaderLen                   = 4
+	linkStateTLVHeaderLen                    = 4
+	linkStateProtocolIDAndIdentifierLen      = 9
+	linkStateLinkIdentifiersLen              = 8
+	linkStateMultiTopologyIDLen              = 2
+	linkStateMultiTopologyIDMask             = 0x0fff
+	linkStateIPReachabilityInformationMinLen = 1
+)
+
+// LinkStateNLRI represents a BGP-LS NLRI (RFC7752)
+type LinkStateNLRI struct {
+	Type       uint16
+	ProtocolID uint8
+	Identifier uint64
+	LocalNode  *LinkStateNodeDescriptor
+
+	// RemoteNode and Link are only present in link NLRIs
+	RemoteNode *LinkStateNodeDescriptor
+	Link       *LinkStateLinkDescriptor
+
+	// Prefix is only present in prefix NLRIs
+	Prefix *LinkStatePrefixDescriptor
+}
+
+// LinkStateNodeDescriptor represents the node descriptor sub-TLVs of a local or remote node
+type LinkStateNodeDescriptor struct {
+	// ASN and BGPLSIdentifier are only encoded if not 0
+	ASN             uint32
+	BGPLSIdentifier uint32
+	OSPFAreaID      *uint32
+
+	// IGPRouterID is the 6 octet ISIS system ID or 7 octets system ID and pseudonode ID of a pseudonode
+	IGPRouterID []byte
+}
+
+// LinkStateLinkDescriptor represents the link descriptor TLVs of a link NLRI
+type LinkStateLinkDescriptor struct {
+	LinkIdentifiers      *LinkStateLinkIdentifiers
+	IPv4InterfaceAddress *bnet.IP
+	IPv4NeighborAddress  *bnet.IP
+	IPv6InterfaceAddress *bnet.IP
+	IPv6NeighborAddress  *bnet.IP
+	MultiTopologyIDs     []uint16
+}
+
+// LinkStateLinkIdentifiers represents the link local/remote identifiers of a link
+type LinkStateLinkIdentifiers struct {
+	Local  uint32
+	Remote uint32
+}
+
+// LinkStatePrefixDescriptor represents the prefix descriptor TLVs of a prefix NLRI
+type LinkStatePrefixDescriptor struct {
+	MultiTopologyIDs []uint16
+
+	// OSPFRouteType is only encoded if not 0
+	OSPFRouteType uint8
+	Prefix        *bnet.Prefix
+}
+
+// Key returns the wire representation of n for use as map key
+func (n *LinkStateNLRI) Key() string {
+	buf := bytes.NewBuffer(nil)
+	n.serialize(buf)
+
+	return buf.String()
+}
+
+func (n *LinkStateNLRI) serialize(buf *bytes.Buffer) uint16 {
+	body := bytes.NewBuffer(nil)
+	body.WriteByte(n.ProtocolID)
+	body.Write(convert.Uint64Byte(n.Identifier))
+
+	writeLinkStateTLV(body, linkStateLocalNodeDescriptorsTLV, n.LocalNode.serialize())
+
+	switch n.Type {
+	case LinkStateLinkNLRIType:
+		writeLinkStateTLV(body, linkStateRemoteNodeDescriptorsTLV, n.RemoteNode.serialize())
+		n.Link.serialize(body)
+	case LinkStateIPv4PrefixNLRIType, LinkStateIPv6PrefixNLRIType:
+		n.Prefix.serialize(body)
+	}
+
+	buf.Write(convert.Uint16Byte(n.Type))
+	buf.Write(convert.Uint16Byte(uint16(body.Len())))
+	buf.Write(body.Bytes())
+
+	return uint16(linkStateNLRIHeaderLen + body.Len())
+}
+
+func (d *LinkStateNodeDescriptor) serialize() []byte {
+	buf := bytes.NewBuffer(nil)
+	if d == nil {
+		return buf.Bytes()
+	}
+
+	if d.ASN != 0 {
+		writeLinkStateTLV(buf, linkStateAutonomousSystemTLV, convert.Uint32Byte(d.ASN))
+	}
+
+	if d.BGPLSIdentifier != 0 {
+		writeLinkStateTLV(buf, linkStateBGPLSIdentifierTLV, convert.Uint32Byte(d.BGPLSIdentifier))
+	}
+
+	if d.OSPFAreaID != nil {
+		writeLinkStateTLV(buf, linkStateOSPFAreaIDTLV, convert.Uint32Byte(*d.OSPFAreaID))
+	}
+
+	if len(d.IGPRouterID) > 0 {
+		writeLinkStateTLV(buf, linkStateIGPRouterIDTLV, d.IGPRouterID)
+	}
+
+	return buf.Bytes()
+}
+
+func (d *LinkStateLinkDescriptor) serialize(buf *bytes.Buffer) {
+	if d == nil {
+		return
+	}
+
+	if d.LinkIdentifiers != nil {
+		writeLinkStateTLV(buf, linkStateLinkIdentifiersTLV, append(convert.Uint32Byte(d.LinkIdentifiers.Local), convert.Uint32Byte(d.LinkIdentifiers.Remote)...))
+	}
+
+	if d.IPv4InterfaceAddress != nil {
+		writeLinkStateTLV(buf, linkStateIPv4InterfaceAddressTLV, d.IPv4InterfaceAddress.Bytes())
+	}
+
+	if d.IPv4NeighborAddress != nil {
+		writeLinkStateTLV(buf, linkStateIPv4NeighborAddressTLV, d.IPv4NeighborAddress.Bytes())
+	}
+
+	if d.IPv6InterfaceAddress != nil {
+		writeLinkStateTLV(buf, linkStateIPv6InterfaceAddressTLV, d.IPv6InterfaceAddress.Bytes())
+	}
+
+	if d.IPv6NeighborAddress != nil {
+		writeLinkStateTLV(buf, linkStateIPv6NeighborAddressTLV, d.IPv6NeighborAddress.Bytes())
+	}
+
+	if len(d.MultiTopologyIDs) > 0 {
+		writeLinkStateTLV(buf, linkStateMultiTopologyIDTLV, serializeMultiTopologyIDs(d.MultiTopologyIDs))
+	}
+}
+
+func (d *LinkStatePrefixDescriptor) serialize(buf *bytes.Buffer) {
+	if d == nil {
+		return
+	}
+
+	if len(d.MultiTopologyIDs) > 0 {
+		writeLinkStateTLV(buf, linkStateMultiTopologyIDTLV, serializeMultiTopologyIDs(d.MultiTopologyIDs))
+	}
+
+	if d.OSPFRouteType != 0 {
+		writeLinkStateTLV(buf, linkStateOSPFRouteTypeTLV, []byte{d.OSPFRouteType})
+	}
+
+	if d.Prefix != nil {
+		v := []byte{d.Prefix.Len()}
+		v = append(v, d.Prefix.Addr().Bytes()[:BytesInAddr(d.Prefix.Len())]...)
+		writeLinkStateTLV(buf, linkStateIPReachabilityInformationTLV, v)
+	}
+}
+
+func serializeMultiTopologyIDs(ids []uint16) []byte {
+	v := make([]byte, 0, len(ids)*linkStateMultiTopologyIDLen)
+	for _, id := range ids {
+		v = append(v, convert.Uint16Byte(id)...)
+	}
+
+	return v
+}
+
+func writeLinkStateTLV(buf *bytes.Buffer, tlvType uint16, value []byte) {
+	buf.Write(convert.Uint16Byte(tlvType))
+	buf.Write(convert.Uint16Byte(uint16(len(value))))
+	buf.Write(value)
+}
+
+func decodeLinkStateNLRIs(b []byte) ([]*LinkStateNLRI, error) {
+	ret := make([]*LinkStateNLRI, 0)
+
+	for len(b) > 0 {
+		if len(b) < linkStateNLRIHeaderLen {
+			return nil, fmt.Errorf("link state NLRI header truncated")
+		}
+
+		nlriType := uint16(b[0])<<8 | uint16(b[1])
+		length := int(b[2])<<8 | int(b[3])
+		b = b[linkStateNLRIHeaderLen:]
+
+		if len(b) < length {
+			return nil, fmt.Errorf("link state NLRI length %d exceeds remaining %d bytes", length, len(b))
+		}
+
+		n, err := decodeLinkStateNLRI(nlriType, b[:length])
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode link state NLRI of type %d: %w", nlriType, err)
+		}
+
+		// NLRIs of unknown types are ignored
+		if n != nil {
+			ret = append(ret, n)
+		}
+
+		b = b[length:]
+	}
+
+	return ret, nil
+}
+
+func decodeLinkStateNLRI(nlriType uint16, b []byte) (*LinkStateNLRI, error) {
+	switch nlriType {
+	case LinkStateNodeNLRIType, LinkStateLinkNLRIType, LinkStateIPv4PrefixNLRIType, LinkStateIPv6PrefixNLRIType:
+	default:
+		return nil, nil
+	}
+
+	if len(b) < linkStateProtocolIDAndIdentifierLen {
+		return nil, fmt.Errorf("NLRI too short: %d bytes", len(b))
+	}
+
+	n := &LinkStateNLRI{
+		Type:       nlriType,
+		ProtocolID: b[0],
+		Identifier: convert.Uint64b(b[1:linkStateProtocolIDAndIdentifierLen]),
+	}
+
+	tlvs, err := decodeLinkStateTLVs(b[linkStateProtocolIDAndIdentifierLen:])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(tlvs) == 0 || tlvs[0].tlvType != linkStateLocalNodeDescriptorsTLV {
+		return nil, fmt.Errorf("local node descriptors missing")
+	}
+
+	n.LocalNode, err = decodeLinkStateNodeDescriptor(tlvs[0].value)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode local node descriptors: %w", err)
+	}
+	tlvs = tlvs[1:]
+
+	switch nlriType {
+	case LinkStateLinkNLRIType:
+		if len(tlvs) == 0 || tlvs[0].tlvType != linkStateRemoteNodeDescriptorsTLV {
+			return nil, fmt.Errorf("remote node descriptors missing")
+		}
+
+		n.RemoteNode, err = decodeLinkStateNodeDescriptor(tlvs[0].value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode remote node descriptors: %w", err)
+		}
+
+		n.Link, err = decodeLinkStateLinkDescriptor(tlvs[1:])
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode link descriptors: %w", err)
+		}
+	case LinkStateIPv4PrefixNLRIType, LinkStateIPv6PrefixNLRIType:
+		afi := uint16(AFIIPv4)
+		if nlriType == LinkStateIPv6PrefixNLRIType {
+			afi = AFIIPv6
+		}
+
+		n.Prefix, err = decodeLinkStatePrefixDescriptor(tlvs, afi)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode prefix descriptors: %w", err)
+		}
+	}
+
+	return n, nil
+}
+
+type linkStateTLV struct {
+	tlvType uint16
+	value   []byte
+}
+
+func decodeLinkStateTLVs(b []byte) ([]linkStateTLV, error) {
+	ret := make([]linkStateTLV, 0)
+
+	for len(b) > 0 {
+		if len(b) < linkStateTLVHeaderLen {
+			return nil, fmt.Errorf("TLV header truncated")
+		}
+
+		tlvType := uint16(b[0])<<8 | uint16(b[1])
+		length := int(b[2])<<8 | int(b[3])
+		b = b[linkStateTLVHeaderLen:]
+
+		if len(b) < length {
+			return nil, fmt.Errorf("TLV %d length %d exceeds remaining %d bytes", tlvType, length, len(b))
+		}
+
+		ret = append(ret, linkStateTLV{
+			tlvType: tlvType,
+			value:   b[:length],
+		})
+		b = b[length:]
+	}
+
+	return ret, nil
+}
+
+func decodeLinkStateNodeDescriptor(b []byte) (*LinkStateNodeDescriptor, error) {
+	tlvs, err := decodeLinkStateTLVs(b)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &LinkStateNodeDescriptor{}
+	for _, tlv := range tlvs {
+		switch tlv.tlvType {
+		case linkStateAutonomousSystemTLV:
+			if len(tlv.value) != 4 {
+				return nil, fmt.Errorf("invalid autonomous system length %d", len(tlv.value))
+			}
+			d.ASN = convert.Uint32b(tlv.value)
+		case linkStateBGPLSIdentifierTLV:
+			if len(tlv.value) != 4 {
+				return nil, fmt.Errorf("invalid BGP-LS identifier length %d", len(tlv.value))
+			}
+			d.BGPLSIdentifier = convert.Uint32b(tlv.value)
+		case linkStateOSPFAreaIDTLV:
+			if len(tlv.value) != 4 {
+				return nil, fmt.Errorf("invalid OSPF area ID length %d", len(tlv.value))
+			}
+			areaID := convert.Uint32b(tlv.value)
+			d.OSPFAreaID = &areaID
+		case linkStateIGPRouterIDTLV:
+			d.IGPRouterID = make([]byte, len(tlv.value))
+			copy(d.IGPRouterID, tlv.value)
+		}
+	}
+
+	return d, nil
+}
+
+func decodeLinkStateLinkDescriptor(tlvs []linkStateTLV) (*LinkStateLinkDescriptor, error) {
+	d := &LinkStateLinkDescriptor{}
+
+	var err error
+	for _, tlv := range tlvs {
+		switch tlv.tlvType {
+		case linkStateLinkIdentifiersTLV:
+			if len(tlv.value) != linkStateLinkIdentifiersLen {
+				return nil, fmt.Errorf("invalid link identifiers length %d", len(tlv.value))
+			}
+			d.LinkIdentifiers = &LinkStateLinkIdentifiers{
+				Local:  convert.Uint32b(tlv.value[:4]),
+				Remote: convert.Uint32b(tlv.value[4:]),
+			}
+		case linkStateIPv4InterfaceAddressTLV:
+			d.IPv4InterfaceAddress, err = decodeLinkStateAddress(tlv.value, IPv4Len)
+		case linkStateIPv4NeighborAddressTLV:
+			d.IPv4NeighborAddress, err = decodeLinkStateAddress(tlv.value, IPv4Len)
+		case linkStateIPv6InterfaceAddressTLV:
+			d.IPv6InterfaceAddress, err = decodeLinkStateAddress(tlv.value, IPv6Len)
+		case linkStateIPv6NeighborAddressTLV:
+			d.IPv6NeighborAddress, err = decodeLinkStateAddress(tlv.value, IPv6Len)
+		case linkStateMultiTopologyIDTLV:
+			d.MultiTopologyIDs, err = decodeMultiTopologyIDs(tlv.value)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode TLV %d: %w", tlv.tlvType, err)
+		}
+	}
+
+	return d, nil
+}
+
+func decodeLinkStatePrefixDescriptor(tlvs []linkStateTLV, afi uint16) (*LinkStatePrefixDescriptor, error) {
+	d := &LinkStatePrefixDescriptor{}
+
+	var err error
+	for _, tlv := range tlvs {
+		switch tlv.tlvType {
+		case linkStateMultiTopologyIDTLV:
+			d.MultiTopologyIDs, err = decodeMultiTopologyIDs(tlv.value)
+		case linkStateOSPFRouteTypeTLV:
+			if len(tlv.value) != 1 {
+				return nil, fmt.Errorf("invalid OSPF route type length %d", len(tlv.value))
+			}
+			d.OSPFRouteType = tlv.value[0]
+		case linkStateIPReachabilityInformationTLV:
+			if len(tlv.value) < linkStateIPReachabilityInformationMinLen {
+				return nil, fmt.Errorf("IP reachability information too short")
+			}
+
+			pfxLen := tlv.value[0]
+			if len(tlv.value)-1 != int(BytesInAddr(pfxLen)) {
+				return nil, fmt.Errorf("invalid IP reachability information length %d for prefix length %d", len(tlv.value), pfxLen)
+			}
+
+			d.Prefix, err = deserializePrefix(tlv.value[1:], pfxLen, afi)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode TLV %d: %w", tlv.tlvType, err)
+		}
+	}
+
+	if d.Prefix == nil {
+		return nil, fmt.Errorf("IP reachability information missing")
+	}
+
+	return d, nil
+}
+
+func decodeLinkStateAddress(b []byte, length int) (*bnet.IP, error) {
+	if len(b) != length {
+		return nil, fmt.Errorf("invalid address length %d", len(b))
+	}
+
+	addr, err := bnet.IPFromBytes(b)
+	if err != nil {
+		return nil, err
+	}
+
+	return addr.Dedup(), nil
+}
+
+func decodeMultiTopologyIDs(b []byte) ([]uint16, error) {
+	if len(b)%linkStateMultiTopologyIDLen != 0 {
+		return nil, fmt.Errorf("invalid multi topology ID length %d", len(b))
+	}
+
+	ids := make([]uint16, 0, len(b)/linkStateMultiTopologyIDLen)
+	for i := 0; i < len(b); i += linkStateMultiTopologyIDLen {
+		ids = append(ids, (uint16(b[i])<<8|uint16(b[i+1]))&linkStateMultiTopologyIDMask)
+	}
+
+	return ids, nil
+}
+
+func isLinkState(afi uint16, safi uint8) bool {
+	return afi == AFILinkState && safi == SAFILinkState
+}
@@ -0,0 +1,236 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkStateNLRIRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expected *LinkStateNLRI
+	}{
+		{
+			name: "Node NLRI",
+			input: []byte{
+				0, 1, 0, 31, // Node NLRI, Length
+				2,                      // Protocol ID: ISIS L2
+				0, 0, 0, 0, 0, 0, 0, 0, // Identifier
+				1, 0, 0, 18, // Local Node Descriptors
+				2, 0, 0, 4, 0, 0, 0xfd, 0xe8, // Autonomous System 65000
+				2, 3, 0, 6, 0, 0, 0, 0, 0, 1, // IGP Router-ID
+			},
+			expected: &LinkStateNLRI{
+				Type:       LinkStateNodeNLRIType,
+				ProtocolID: LinkStateProtocolISISL2,
+				LocalNode: &LinkStateNodeDescriptor{
+					ASN:         65000,
+					IGPRouterID: []byte{0, 0, 0, 0, 0, 1},
+				},
+			},
+		},
+		{
+			name: "Link NLRI",
+			input: []byte{
+				0, 2, 0, 73, // Link NLRI, Length
+				2,                      // Protocol ID: ISIS L2
+				0, 0, 0, 0, 0, 0, 0, 0, // Identifier
+				1, 0, 0, 18, // Local Node Descriptors
+				2, 0, 0, 4, 0, 0, 0xfd, 0xe8, // Autonomous System 65000
+				2, 3, 0, 6, 0, 0, 0, 0, 0, 1, // IGP Router-ID
+				1, 1, 0, 10, // Remote Node Descriptors
+				2, 3, 0, 6, 0, 0, 0, 0, 0, 2, // IGP Router-ID
+				1, 2, 0, 8, 0, 0, 0, 1, 0, 0, 0, 2, // Link Local/Remote Identifiers
+				1, 3, 0, 4, 10, 0, 0, 1, // IPv4 Interface Address
+				1, 4, 0, 4, 10, 0, 0, 2, // IPv4 Neighbor Address
+			},
+			expected: &LinkStateNLRI{
+				Type:       LinkStateLinkNLRIType,
+				ProtocolID: LinkStateProtocolISISL2,
+				LocalNode: &LinkStateNodeDescriptor{
+					ASN:         65000,
+					IGPRouterID: []byte{0, 0, 0, 0, 0, 1},
+				},
+				RemoteNode: &LinkStateNodeDescriptor{
+					IGPRouterID: []byte{0, 0, 0, 0, 0, 2},
+				},
+				Link: &LinkStateLinkDescriptor{
+					LinkIdentifiers: &LinkStateLinkIdentifiers{
+						Local:  1,
+						Remote: 2,
+					},
+					IPv4InterfaceAddress: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+					IPv4NeighborAddress:  bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+				},
+			},
+		},
+		{
+			name: "IPv4 Prefix NLRI",
+			input: []byte{
+				0, 3, 0, 39, // IPv4 Prefix NLRI, Length
+				2,                      // Protocol ID: ISIS L2
+				0, 0, 0, 0, 0, 0, 0, 0, // Identifier
+				1, 0, 0, 18, // Local Node Descriptors
+				2, 0, 0, 4, 0, 0, 0xfd, 0xe8, // Autonomous System 65000
+				2, 3, 0, 6, 0, 0, 0, 0, 0, 1, // IGP Router-ID
+				1, 9, 0, 4, 24, 10, 0, 0, // IP Reachability Information 10.0.0.0/24
+			},
+			expected: &LinkStateNLRI{
+				Type:       LinkStateIPv4PrefixNLRIType,
+				ProtocolID: LinkStateProtocolISISL2,
+				LocalNode: &LinkStateNodeDescriptor{
+					ASN:         65000,
+					IGPRouterID: []byte{0, 0, 0, 0, 0, 1},
+				},
+				Prefix: &LinkStatePrefixDescriptor{
+					Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24).Ptr(),
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nlris, err := decodeLinkStateNLRIs(test.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert.Equal(t, []*LinkStateNLRI{test.expected}, nlris)
+
+			buf := bytes.NewBuffer(nil)
+			n := test.expected.serialize(buf)
+			assert.Equal(t, test.input, buf.Bytes())
+			assert.Equal(t, uint16(len(test.input)), n)
+		})
+	}
+}
+
+func TestDecodeLinkStateNLRIsMalformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{
+			name:  "Truncated NLRI header",
+			input: []byte{0, 1, 0},
+		},
+		{
+			name:  "NLRI length exceeds buffer",
+			input: []byte{0, 1, 0, 20, 2, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:  "Missing local node descriptors",
+			input: []byte{0, 1, 0, 9, 2, 0, 0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name: "Link NLRI without remote node descriptors",
+			input: []byte{
+				0, 2, 0, 13,
+				2, 0, 0, 0, 0, 0, 0, 0, 0,
+				1, 0, 0, 0,
+			},
+		},
+		{
+			name: "Invalid link identifiers length",
+			input: []byte{
+				0, 2, 0, 25,
+				2, 0, 0, 0, 0, 0, 0, 0, 0,
+				1, 0, 0, 0,
+				1, 1, 0, 0,
+				1, 2, 0, 4, 0, 0, 0, 1,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := decodeLinkStateNLRIs(test.input)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDecodeLinkStateNLRIsUnknownType(t *testing.T) {
+	nlris, err := decodeLinkStateNLRIs([]byte{0, 99, 0, 2, 1, 2})
+	assert.NoError(t, err)
+	assert.Empty(t, nlris)
+}
+
+func TestMultiProtocolReachNLRILinkStateRoundTrip(t *testing.T) {
+	input := []byte{
+		0x80, 14, 40, // Optional, MP_REACH_NLRI, Length
+		0x40, 0x04, // AFI: Link-State
+		71,          // SAFI: Link-State
+		0,           // NextHop length
+		0,           // Reserved
+		0, 1, 0, 31, // Node NLRI, Length
+		2,                      // Protocol ID: ISIS L2
+		0, 0, 0, 0, 0, 0, 0, 0, // Identifier
+		1, 0, 0, 18, // Local Node Descriptors
+		2, 0, 0, 4, 0, 0, 0xfd, 0xe8, // Autonomous System 65000
+		2, 3, 0, 6, 0, 0, 0, 0, 0, 1, // IGP Router-ID
+	}
+
+	expected := MultiProtocolReachNLRI{
+		AFI:  AFILinkState,
+		SAFI: SAFILinkState,
+		LinkStateNLRIs: []*LinkStateNLRI{
+			{
+				Type:       LinkStateNodeNLRIType,
+				ProtocolID: LinkStateProtocolISISL2,
+				LocalNode: &LinkStateNodeDescriptor{
+					ASN:         65000,
+					IGPRouterID: []byte{0, 0, 0, 0, 0, 1},
+				},
+			},
+		},
+	}
+
+	pa, _, err := decodePathAttr(bytes.NewBuffer(input), &DecodeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, expected, pa.Value)
+
+	buf := bytes.NewBuffer(nil)
+	(&PathAttribute{
+		TypeCode: MultiProtocolReachNLRIAttr,
+		Value:    pa.Value,
+	}).Serialize(buf, &EncodeOptions{})
+	assert.Equal(t, input, buf.Bytes())
+}
+
+func TestMultiProtocolUnreachNLRILinkStateRoundTrip(t *testing.T) {
+	input := []byte{
+		0x80, 15, 38, // Optional, MP_UNREACH_NLRI, Length
+		0x40, 0x04, // AFI: Link-State
+		71,          // SAFI: Link-State
+		0, 1, 0, 31, // Node NLRI, Length
+		2,                      // Protocol ID: ISIS L2
+		0, 0, 0, 0, 0, 0, 0, 0, // Identifier
+		1, 0, 0, 18, // Local Node Descriptors
+		2, 0, 0, 4, 0, 0, 0xfd, 0xe8, // Autonomous System 65000
+		2, 3, 0, 6, 0, 0, 0, 0, 0, 1, // IGP Router-ID
+	}
+
+	pa, _, err := decodePathAttr(bytes.NewBuffer(input), &DecodeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	nlri := pa.Value.(MultiProtocolUnreachNLRI)
+	assert.Len(t, nlri.LinkStateNLRIs, 1)
+
+	buf := bytes.NewBuffer(nil)
+	(&PathAttribute{
+		TypeCode: MultiProtocolUnreachNLRIAttr,
+		Value:    pa.Value,
+	}).Serialize(buf, &EncodeOptions{})
+	assert.Equal(t, input, buf.Bytes())
+}
@@ -15,10 +15,16 @@ type MultiProtocolReachNLRI struct {
 	SAFI    uint8
 	NextHop *bnet.IP
 	NLRI    *NLRI
+
+	// LinkStateNLRIs are the NLRIs of the link state address family (RFC7752)
+	LinkStateNLRIs []*LinkStateNLRI
 }
 
 func (n *MultiProtocolReachNLRI) serialize(buf *bytes.Buffer, opt *EncodeOptions) uint16 {
-	nextHop := n.NextHop.Bytes()
+	var nextHop []byte
+	if n.NextHop != nil {
+		nextHop = n.NextHop.Bytes()
+	}
 
 	tempBuf := bytes.NewBuffer(nil)
 	tempBuf.Write(convert.Uint16Byte(n.AFI))
@@ -31,6 +37,10 @@ func (n *MultiProtocolReachNLRI) serialize(buf *bytes.Buffer, opt *EncodeOptions
 		cur.serialize(tempBuf, opt.UseAddPath, n.SAFI)
 	}
 
+	for _, ls := range n.LinkStateNLRIs {
+		ls.serialize(tempBuf)
+	}
+
 	buf.Write(tempBuf.Bytes())
 
 	return uint16(tempBuf.Len())
@@ -68,11 +78,14 @@ func deserializeMultiProtocolReachNLRI(b []byte, opt *DecodeOptions) (MultiProto
 		// second next-hop is lladdr (see rfc2545 sec 3 par 2)
 		firstNextHopLength = 16
 	}
-	nh, err := bnet.IPFromBytes(variable[:firstNextHopLength])
-	if err != nil {
-		return MultiProtocolReachNLRI{}, fmt.Errorf("failed to decode next hop IP: %w", err)
+	// Link state NLRIs may be advertised without next hop (RFC7752 sec 3.4)
+	if nextHopLength > 0 || !isLinkState(n.AFI, n.SAFI) {
+		nh, err := bnet.IPFromBytes(variable[:firstNextHopLength])
+		if err != nil {
+			return MultiProtocolReachNLRI{}, fmt.Errorf("failed to decode next hop IP: %w", err)
+		}
+		n.NextHop = nh.Dedup()
 	}
-	n.NextHop = nh.Dedup()
 	budget -= int(nextHopLength)
 
 	if budget == 0 {
@@ -81,6 +94,15 @@ func deserializeMultiProtocolReachNLRI(b []byte, opt *DecodeOptions) (MultiProto
 
 	variable = variable[1+nextHopLength:] // 1 <- RESERVED field
 
+	if isLinkState(n.AFI, n.SAFI) {
+		n.LinkStateNLRIs, err = decodeLinkStateNLRIs(variable)
+		if err != nil {
+			return MultiProtocolReachNLRI{}, err
+		}
+
+		return n, nil
+	}
+
 	buf := bytes.NewBuffer(variable)
 	nlri, err := decodeNLRIs(buf, uint16(buf.Len()), n.AFI, n.SAFI, opt.addPath(n.AFI, n.SAFI))
 	if err != nil {
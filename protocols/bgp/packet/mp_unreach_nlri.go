@@ -13,6 +13,9 @@ type MultiProtocolUnreachNLRI struct {
 	AFI  uint16
 	SAFI uint8
 	NLRI *NLRI
+
+	// LinkStateNLRIs are the NLRIs of the link state address family (RFC7752)
+	LinkStateNLRIs []*LinkStateNLRI
 }
 
 func (n *MultiProtocolUnreachNLRI) serialize(buf *bytes.Buffer, opt *EncodeOptions) uint16 {
@@ -24,6 +27,10 @@ func (n *MultiProtocolUnreachNLRI) serialize(buf *bytes.Buffer, opt *EncodeOptio
 		cur.serialize(tempBuf, opt.UseAddPath, n.SAFI)
 	}
 
+	for _, ls := range n.LinkStateNLRIs {
+		ls.serialize(tempBuf)
+	}
+
 	buf.Write(tempBuf.Bytes())
 
 	return uint16(tempBuf.Len())
@@ -52,6 +59,15 @@ func deserializeMultiProtocolUnreachNLRI(b []byte, opt *DecodeOptions) (MultiPro
 		return n, nil
 	}
 
+	if isLinkState(n.AFI, n.SAFI) {
+		n.LinkStateNLRIs, err = decodeLinkStateNLRIs(nlris)
+		if err != nil {
+			return MultiProtocolUnreachNLRI{}, err
+		}
+
+		return n, nil
+	}
+
 	buf := bytes.NewBuffer(nlris)
 	nlri, err := decodeNLRIs(buf, uint16(buf.Len()), n.AFI, n.SAFI, opt.addPath(n.AFI, n.SAFI))
 	if err != nil {
@@ -48,6 +48,23 @@ func TestSerializeMultiProtocolUnreachNLRI(t *testing.T) {
 			},
 			addPath: true,
 		},
+		{
+			name: "IPv4 labeled unicast prefix",
+			nlri: MultiProtocolUnreachNLRI{
+				AFI:  AFIIPv4,
+				SAFI: SAFILabeledUnicast,
+				NLRI: &NLRI{
+					Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Dedup(),
+				},
+			},
+			expected: []byte{
+				0x00, 0x01, // AFI
+				0x04,             // SAFI
+				0x20,             // Prefix length including label
+				0x80, 0x00, 0x00, // Compatibility field
+				0x0a, // Prefix
+			},
+		},
 	}
 
 	for _, test := range tests {
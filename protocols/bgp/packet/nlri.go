@@ -77,6 +77,10 @@ func decodeNLRI(buf *bytes.Buffer, afi uint16, safi uint8, addPath bool) (*NLRI,
 	if safi == SAFILabeledUnicast {
 		nlri.LabelStack = make([]LabelStackEntry, 0, 1)
 		for {
+			if pfxLen < BitsPerLabel {
+				return nil, consumed, fmt.Errorf("prefix length %d too short for label stack", pfxLen)
+			}
+
 			lse, err := decodeLabelStackEntry(buf)
 			if err != nil {
 				return nil, consumed, fmt.Errorf("decode label stack entry failed: %w", err)
@@ -84,6 +88,13 @@ func decodeNLRI(buf *bytes.Buffer, afi uint16, safi uint8, addPath bool) (*NLRI,
 
 			consumed += BytesPerLabel
 			pfxLen -= BitsPerLabel
+
+			// The compatibility field of a withdrawal carries no label and has no bottom of stack bit set
+			if lse == withdrawalLabel && len(nlri.LabelStack) == 0 {
+				nlri.LabelStack = nil
+				break
+			}
+
 			nlri.LabelStack = append(nlri.LabelStack, lse)
 
 			if lse.isBottomOfStack() {
@@ -123,13 +134,18 @@ func (n *NLRI) serialize(buf *bytes.Buffer, addPath bool, safi uint8) uint8 {
 
 	pfxLen := n.Prefix.Len()
 	if safi == SAFILabeledUnicast {
-		pfxLen += uint8(len(n.LabelStack) * BitsPerLabel)
+		pfxLen += uint8(n.labelCount() * BitsPerLabel)
 	}
 
 	buf.WriteByte(pfxLen)
 	numBytes++
 
 	if safi == SAFILabeledUnicast {
+		if len(n.LabelStack) == 0 {
+			withdrawalLabel.serialize(buf, false)
+			numBytes += BytesPerLabel
+		}
+
 		labelCount := len(n.LabelStack)
 		for i, l := range n.LabelStack {
 			l.serialize(buf, i == labelCount-1)
@@ -144,6 +160,16 @@ func (n *NLRI) serialize(buf *bytes.Buffer, addPath bool, safi uint8) uint8 {
 	return numBytes
 }
 
+// labelCount returns the number of labels encoded for a labeled unicast NLRI. An NLRI without labels is a
+// withdrawal which carries the compatibility field in place of the label stack.
+func (n *NLRI) labelCount() int {
+	if len(n.LabelStack) == 0 {
+		return 1
+	}
+
+	return len(n.LabelStack)
+}
+
 // BytesInAddr gets the amount of bytes needed to encode an NLRI of prefix length pfxlen
 func BytesInAddr(pfxlen uint8) uint8 {
 	return uint8(math.Ceil(float64(pfxlen) / 8))
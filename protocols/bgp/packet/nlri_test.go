@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -56,7 +57,7 @@ func TestDecodeNLRIs(t *testing.T) {
 			t.Errorf("Unexpected failure for test %q: %v", test.name, err)
 		}
 
-		assert.Equal(t, test.expected, res)
+		assert.Equal(t, test.expected, res, test.name)
 	}
 }
 
@@ -78,6 +79,40 @@ func TestDecodeNLRIv6(t *testing.T) {
 				Prefix: bnet.NewPfx(bnet.IPv6FromBlocks(0, 0, 0, 0, 0, 0, 0, 0), 0).Dedup(),
 			},
 		},
+		{
+			name: "IPv6 /127",
+			input: []byte{
+				127, 0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x02,
+			},
+			wantFail: false,
+			expected: &NLRI{
+				Prefix: bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 2), 127).Dedup(),
+			},
+		},
+		{
+			name: "IPv6 host route",
+			input: []byte{
+				128, 0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x03,
+			},
+			wantFail: false,
+			expected: &NLRI{
+				Prefix: bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 3), 128).Dedup(),
+			},
+		},
+		{
+			name: "IPv6 /127 with host bit set",
+			input: []byte{
+				127, 0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x03,
+			},
+			wantFail: true,
+		},
+		{
+			name: "IPv6 prefix length exceeding address length",
+			input: []byte{
+				129, 0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x03, 0,
+			},
+			wantFail: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -139,6 +174,28 @@ func TestDecodeNLRI(t *testing.T) {
 				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(5, 193, 0, 0), 18).Dedup(),
 			},
 		},
+		{
+			name: "LU withdrawal",
+			safi: SAFILabeledUnicast,
+			input: []byte{
+				42,               // prefix + label stack length
+				0x80, 0x00, 0x00, // compatibility field instead of a label
+				5, 193, 0, // 5.193.0.0/18 (42 - 24 = 18)
+			},
+			wantFail: false,
+			expected: &NLRI{
+				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(5, 193, 0, 0), 18).Dedup(),
+			},
+		},
+		{
+			name: "LU prefix length shorter than label",
+			safi: SAFILabeledUnicast,
+			input: []byte{
+				16,               // prefix + label stack length
+				0x49, 0x33, 0x01, // MPLS label
+			},
+			wantFail: true,
+		},
 		{
 			name: "Valid NRLI #1",
 			input: []byte{
@@ -222,6 +279,23 @@ func TestDecodeNLRI(t *testing.T) {
 			addPath:  true,
 			wantFail: true,
 		},
+		{
+			name: "Host route",
+			input: []byte{
+				32, 192, 168, 0, 1,
+			},
+			wantFail: false,
+			expected: &NLRI{
+				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 168, 0, 1), 32).Dedup(),
+			},
+		},
+		{
+			name: "Prefix length exceeding address length",
+			input: []byte{
+				33, 192, 168, 0, 1, 0,
+			},
+			wantFail: true,
+		},
 		{
 			name:     "Empty input",
 			input:    []byte{},
@@ -245,6 +319,97 @@ func TestDecodeNLRI(t *testing.T) {
 	}
 }
 
+func TestLabeledUnicastNLRIRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		afi      uint16
+		nlri     *NLRI
+		expected []byte
+	}{
+		{
+			name: "Single label",
+			afi:  AFIIPv4,
+			nlri: &NLRI{
+				LabelStack: []LabelStackEntry{
+					NewLabelStackEntry(299824),
+				},
+				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Dedup(),
+			},
+			expected: []byte{8 + 24, 0x49, 0x33, 0x01, 10},
+		},
+		{
+			name: "Multi label stack",
+			afi:  AFIIPv4,
+			nlri: &NLRI{
+				LabelStack: []LabelStackEntry{
+					NewLabelStackEntry(16001),
+					NewLabelStackEntry(24005),
+					NewLabelStackEntry(299824),
+				},
+				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 1, 0, 0), 16).Dedup(),
+			},
+			expected: []byte{16 + 72, 0x03, 0xe8, 0x10, 0x05, 0xdc, 0x50, 0x49, 0x33, 0x01, 10, 1},
+		},
+		{
+			name: "Implicit null",
+			afi:  AFIIPv4,
+			nlri: &NLRI{
+				LabelStack: []LabelStackEntry{
+					NewLabelStackEntry(types.LabelImplicitNull),
+				},
+				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 1), 32).Dedup(),
+			},
+			expected: []byte{32 + 24, 0x00, 0x00, 0x31, 192, 0, 2, 1},
+		},
+		{
+			name: "IPv4 explicit null",
+			afi:  AFIIPv4,
+			nlri: &NLRI{
+				LabelStack: []LabelStackEntry{
+					NewLabelStackEntry(types.LabelIPv4ExplicitNull),
+				},
+				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 1), 32).Dedup(),
+			},
+			expected: []byte{32 + 24, 0x00, 0x00, 0x01, 192, 0, 2, 1},
+		},
+		{
+			name: "IPv6 explicit null",
+			afi:  AFIIPv6,
+			nlri: &NLRI{
+				LabelStack: []LabelStackEntry{
+					NewLabelStackEntry(types.LabelIPv6ExplicitNull),
+				},
+				Prefix: bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32).Dedup(),
+			},
+			expected: []byte{32 + 24, 0x00, 0x00, 0x21, 0x20, 0x01, 0x0d, 0xb8},
+		},
+		{
+			name: "Withdrawal without labels",
+			afi:  AFIIPv4,
+			nlri: &NLRI{
+				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Dedup(),
+			},
+			expected: []byte{8 + 24, 0x80, 0x00, 0x00, 10},
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(nil)
+		test.nlri.serialize(buf, false, SAFILabeledUnicast)
+		assert.Equal(t, test.expected, buf.Bytes(), test.name)
+
+		res, consumed, err := decodeNLRI(buf, test.afi, SAFILabeledUnicast, false)
+		if err != nil {
+			t.Errorf("Unexpected failure for test %q: %v", test.name, err)
+			continue
+		}
+
+		assert.Equal(t, uint8(len(test.expected)), consumed, test.name)
+		assert.Equal(t, test.nlri.Prefix, res.Prefix, test.name)
+		assert.Equal(t, LabelStackFromEntries(test.nlri.LabelStack), LabelStackFromEntries(res.LabelStack), test.name)
+	}
+}
+
 func TestBytesInAddr(t *testing.T) {
 	tests := []struct {
 		name     string
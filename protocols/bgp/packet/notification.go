@@ -0,0 +1,78 @@
+package packet
+
+import "fmt"
+
+var notificationErrorCodeNames = map[uint8]string{
+	MessageHeaderError:      "Message Header Error",
+	OpenMessageError:        "OPEN Message Error",
+	UpdateMessageError:      "UPDATE Message Error",
+	HoldTimeExpired:         "Hold Timer Expired",
+	FiniteStateMachineError: "Finite State Machine Error",
+	Cease:                   "Cease",
+	RouteRefreshError:       "ROUTE-REFRESH Message Error",
+}
+
+var notificationErrorSubcodeNames = map[uint8]map[uint8]string{
+	MessageHeaderError: {
+		ConnectionNotSync: "Connection Not Synchronized",
+		BadMessageLength:  "Bad Message Length",
+		BadMessageType:    "Bad Message Type",
+	},
+	OpenMessageError: {
+		UnsupportedVersionNumber:     "Unsupported Version Number",
+		BadPeerAS:                    "Bad Peer AS",
+		BadBGPIdentifier:             "Bad BGP Identifier",
+		UnsupportedOptionalParameter: "Unsupported Optional Parameter",
+		UnacceptableHoldTime:         "Unacceptable Hold Time",
+		RoleMismatchError:            "Role Mismatch",
+	},
+	UpdateMessageError: {
+		MalformedAttributeList:    "Malformed Attribute List",
+		UnrecognizedWellKnownAttr: "Unrecognized Well-known Attribute",
+		MissingWellKnownAttr:      "Missing Well-known Attribute",
+		AttrFlagsError:            "Attribute Flags Error",
+		AttrLengthError:           "Attribute Length Error",
+		InvalidOriginAttr:         "Invalid ORIGIN Attribute",
+		InvalidNextHopAttr:        "Invalid NEXT_HOP Attribute",
+		OptionalAttrError:         "Optional Attribute Error",
+		InvalidNetworkField:       "Invalid Network Field",
+		MalformedASPath:           "Malformed AS_PATH",
+	},
+	FiniteStateMachineError: {
+		UnexpectedMessageInOpenSentState:    "Receive Unexpected Message in OpenSent State",
+		UnexpectedMessageInOpenConfirmState: "Receive Unexpected Message in OpenConfirm State",
+		UnexpectedMessageInEstablishedState: "Receive Unexpected Message in Established State",
+	},
+	Cease: {
+		MaxPrefReached:                "Maximum Number of Prefixes Reached",
+		AdminShut:                     "Administrative Shutdown",
+		PeerDeconfigured:              "Peer De-configured",
+		AdminReset:                    "Administrative Reset",
+		ConnectionRejected:            "Connection Rejected",
+		OtherConfigChange:             "Other Configuration Change",
+		ConnectionCollisionResolution: "Connection Collision Resolution",
+		OutOfResources:                "Out of Resources",
+	},
+	RouteRefreshError: {
+		InvalidRouteRefreshLength: "Invalid Message Length",
+	},
+}
+
+// String returns the names of the error code and subcode of the NOTIFICATION (RFC4271, RFC4486, RFC6608)
+func (n *BGPNotification) String() string {
+	code, ok := notificationErrorCodeNames[n.ErrorCode]
+	if !ok {
+		return fmt.Sprintf("Unknown error code %d/%d", n.ErrorCode, n.ErrorSubcode)
+	}
+
+	subcode, ok := notificationErrorSubcodeNames[n.ErrorCode][n.ErrorSubcode]
+	if !ok {
+		if n.ErrorSubcode == 0 {
+			return code
+		}
+
+		return fmt.Sprintf("%s/%d", code, n.ErrorSubcode)
+	}
+
+	return fmt.Sprintf("%s/%s", code, subcode)
+}
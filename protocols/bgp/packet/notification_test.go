@@ -0,0 +1,109 @@
+package packet
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationString(t *testing.T) {
+	tests := []struct {
+		n        *BGPNotification
+		expected string
+	}{
+		{
+			n:        &BGPNotification{ErrorCode: HoldTimeExpired},
+			expected: "Hold Timer Expired",
+		},
+		{
+			n:        &BGPNotification{ErrorCode: FiniteStateMachineError, ErrorSubcode: UnexpectedMessageInOpenConfirmState},
+			expected: "Finite State Machine Error/Receive Unexpected Message in OpenConfirm State",
+		},
+		{
+			n:        &BGPNotification{ErrorCode: Cease, ErrorSubcode: 42},
+			expected: "Cease/42",
+		},
+		{
+			n:        &BGPNotification{ErrorCode: 42, ErrorSubcode: 1},
+			expected: "Unknown error code 42/1",
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.n.String())
+	}
+}
+
+func TestDecodeMsgBodyErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expected *BGPError
+	}{
+		{
+			name: "Truncated OPEN",
+			input: []byte{
+				255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+				0, 22, OpenMsg,
+				4, 0, 100,
+			},
+			expected: &BGPError{
+				ErrorCode:    MessageHeaderError,
+				ErrorSubCode: BadMessageLength,
+			},
+		},
+		{
+			name: "OPEN with unsupported optional parameter",
+			input: []byte{
+				255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+				0, 32, OpenMsg,
+				4, 0, 100, 0, 90, 10, 0, 0, 1, 3,
+				1, 1, 0,
+			},
+			expected: &BGPError{
+				ErrorCode:    OpenMessageError,
+				ErrorSubCode: UnsupportedOptionalParameter,
+			},
+		},
+		{
+			name: "Truncated UPDATE",
+			input: []byte{
+				255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+				0, 20, UpdateMsg,
+				0,
+			},
+			expected: &BGPError{
+				ErrorCode:    UpdateMessageError,
+				ErrorSubCode: MalformedAttributeList,
+			},
+		},
+		{
+			name: "Truncated NOTIFICATION",
+			input: []byte{
+				255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
+				0, 20, NotificationMsg,
+				4,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		_, err := Decode(bytes.NewBuffer(test.input), &DecodeOptions{})
+		if !assert.Error(t, err, test.name) {
+			continue
+		}
+
+		var bgpErr BGPError
+		if test.expected == nil {
+			assert.False(t, errors.As(err, &bgpErr), test.name)
+			continue
+		}
+
+		if assert.True(t, errors.As(err, &bgpErr), test.name) {
+			assert.Equal(t, test.expected.ErrorCode, bgpErr.ErrorCode, test.name)
+			assert.Equal(t, test.expected.ErrorSubCode, bgpErr.ErrorSubCode, test.name)
+		}
+	}
+}
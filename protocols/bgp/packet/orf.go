@@ -0,0 +1,249 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/util/decode"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+const (
+	// OutboundRouteFilteringCapabilityCode is the code of the Outbound Route Filtering capability (RFC5291)
+	OutboundRouteFilteringCapabilityCode = 3
+
+	// AddressPrefixORFType is the ORF type of Address Prefix ORFs (RFC5292)
+	AddressPrefixORFType = 64
+
+	// ORF capability Send/Receive values
+	ORFReceive     = 1
+	ORFSend        = 2
+	ORFSendReceive = 3
+
+	// When-to-refresh values of ROUTE-REFRESH messages carrying ORF entries
+	ORFImmediate = 1
+	ORFDefer     = 2
+
+	// ORF entry actions
+	ORFActionAdd       = 0
+	ORFActionRemove    = 1
+	ORFActionRemoveAll = 2
+
+	// ORF entry matches
+	ORFMatchPermit = 0
+	ORFMatchDeny   = 1
+
+	// ORFEntriesHeaderLen is the length of the ORF type and length fields preceding the entries of an ORF type
+	ORFEntriesHeaderLen = 3
+
+	// AddressPrefixORFEntryMinLen is the length of an Address Prefix ORF entry without its prefix
+	AddressPrefixORFEntryMinLen = 8
+
+	orfCapabilityTupleMinLen = 5
+)
+
+// ORFCapability signals the ORF types and directions supported per AFI/SAFI (RFC5291)
+type ORFCapability []ORFCapabilityTuple
+
+func (a ORFCapability) serialize(buf *bytes.Buffer) {
+	for _, t := range a {
+		t.serialize(buf)
+	}
+}
+
+// ORFCapabilityTuple holds the ORF types supported for an AFI/SAFI
+type ORFCapabilityTuple struct {
+	AFI  uint16
+	SAFI uint8
+	ORFs []ORFTypeCapability
+}
+
+func (a ORFCapabilityTuple) serialize(buf *bytes.Buffer) {
+	buf.Write(convert.Uint16Byte(a.AFI))
+	buf.WriteByte(0) // RESERVED
+	buf.WriteByte(a.SAFI)
+	buf.WriteByte(uint8(len(a.ORFs)))
+	for _, o := range a.ORFs {
+		buf.WriteByte(o.Type)
+		buf.WriteByte(o.SendReceive)
+	}
+}
+
+// ORFTypeCapability holds if we are able to send, receive or both send and receive ORFs of a type
+type ORFTypeCapability struct {
+	Type        uint8
+	SendReceive uint8
+}
+
+func decodeORFCapability(buf *bytes.Buffer, capLength uint8) (ORFCapability, error) {
+	orfCap := make(ORFCapability, 0)
+
+	for capLength > 0 {
+		if capLength < orfCapabilityTupleMinLen {
+			return nil, fmt.Errorf("invalid caplength %d", capLength)
+		}
+
+		t := ORFCapabilityTuple{}
+		reserved := uint8(0)
+		n := uint8(0)
+		err := decode.Decode(buf, []interface{}{
+			&t.AFI,
+			&reserved,
+			&t.SAFI,
+			&n,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		capLength -= orfCapabilityTupleMinLen
+		if capLength < 2*n {
+			return nil, fmt.Errorf("%d ORF types exceed remaining caplength %d", n, capLength)
+		}
+
+		t.ORFs = make([]ORFTypeCapability, n)
+		for i := range t.ORFs {
+			err := decode.Decode(buf, []interface{}{
+				&t.ORFs[i].Type,
+				&t.ORFs[i].SendReceive,
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		capLength -= 2 * n
+		orfCap = append(orfCap, t)
+	}
+
+	return orfCap, nil
+}
+
+// ORFEntries holds the entries of an ORF type carried in a ROUTE-REFRESH message (RFC5291).
+// Only Address Prefix ORFs are supported.
+type ORFEntries struct {
+	Type                 uint8
+	AddressPrefixEntries []AddressPrefixORFEntry
+}
+
+// AddressPrefixORFEntry is an entry of an Address Prefix ORF (RFC5292). A REMOVE-ALL entry carries no further fields.
+type AddressPrefixORFEntry struct {
+	Action   uint8
+	Match    uint8
+	Sequence uint32
+	MinLen   uint8
+	MaxLen   uint8
+	Prefix   *bnet.Prefix
+}
+
+// Len gets the length of the serialized entry
+func (e *AddressPrefixORFEntry) Len() int {
+	if e.Action == ORFActionRemoveAll {
+		return 1
+	}
+
+	return AddressPrefixORFEntryMinLen + int(BytesInAddr(e.Prefix.Len()))
+}
+
+func (e *AddressPrefixORFEntry) serialize(buf *bytes.Buffer) {
+	buf.WriteByte(e.Action<<6 | e.Match<<5)
+	if e.Action == ORFActionRemoveAll {
+		return
+	}
+
+	buf.Write(convert.Uint32Byte(e.Sequence))
+	buf.WriteByte(e.MinLen)
+	buf.WriteByte(e.MaxLen)
+	buf.WriteByte(e.Prefix.Len())
+	buf.Write(e.Prefix.Addr().Bytes()[:BytesInAddr(e.Prefix.Len())])
+}
+
+func (o *ORFEntries) serialize(buf *bytes.Buffer) {
+	entriesLen := 0
+	for i := range o.AddressPrefixEntries {
+		entriesLen += o.AddressPrefixEntries[i].Len()
+	}
+
+	buf.WriteByte(o.Type)
+	buf.Write(convert.Uint16Byte(uint16(entriesLen)))
+	for i := range o.AddressPrefixEntries {
+		o.AddressPrefixEntries[i].serialize(buf)
+	}
+}
+
+// decodeORFEntries decodes the ORF entries of a ROUTE-REFRESH message. Entries of unsupported ORF types are skipped.
+func decodeORFEntries(buf *bytes.Buffer, afi uint16) ([]ORFEntries, error) {
+	ret := make([]ORFEntries, 0)
+	for buf.Len() > 0 {
+		o := ORFEntries{}
+		entriesLen := uint16(0)
+		err := decode.Decode(buf, []interface{}{
+			&o.Type,
+			&entriesLen,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode ORF type: %w", err)
+		}
+
+		if buf.Len() < int(entriesLen) {
+			return nil, fmt.Errorf("ORF entries length %d exceeds remaining %d bytes", entriesLen, buf.Len())
+		}
+
+		entriesBuf := bytes.NewBuffer(buf.Next(int(entriesLen)))
+		if o.Type != AddressPrefixORFType {
+			continue
+		}
+
+		for entriesBuf.Len() > 0 {
+			e, err := decodeAddressPrefixORFEntry(entriesBuf, afi)
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode address prefix ORF entry: %w", err)
+			}
+
+			o.AddressPrefixEntries = append(o.AddressPrefixEntries, e)
+		}
+
+		ret = append(ret, o)
+	}
+
+	return ret, nil
+}
+
+func decodeAddressPrefixORFEntry(buf *bytes.Buffer, afi uint16) (AddressPrefixORFEntry, error) {
+	e := AddressPrefixORFEntry{}
+
+	actionMatch, err := buf.ReadByte()
+	if err != nil {
+		return e, err
+	}
+
+	e.Action = actionMatch >> 6
+	e.Match = actionMatch >> 5 & 1
+	if e.Action == ORFActionRemoveAll {
+		return e, nil
+	}
+
+	pfxLen := uint8(0)
+	err = decode.Decode(buf, []interface{}{
+		&e.Sequence,
+		&e.MinLen,
+		&e.MaxLen,
+		&pfxLen,
+	})
+	if err != nil {
+		return e, err
+	}
+
+	numBytes := int(BytesInAddr(pfxLen))
+	if buf.Len() < numBytes {
+		return e, fmt.Errorf("expected %d bytes for prefix, only %d remaining", numBytes, buf.Len())
+	}
+
+	e.Prefix, err = deserializePrefix(buf.Next(numBytes), pfxLen, afi)
+	if err != nil {
+		return e, err
+	}
+
+	return e, nil
+}
@@ -89,3 +89,46 @@ type PeerRoleCapability struct {
 func (a PeerRoleCapability) serialize(buf *bytes.Buffer) {
 	buf.Write(convert.Uint8Byte(a.PeerRole))
 }
+
+// ExtendedMessageCapability signals support for BGP messages up to 65535 bytes (RFC8654)
+type ExtendedMessageCapability struct{}
+
+func (a ExtendedMessageCapability) serialize(buf *bytes.Buffer) {}
+
+// RouteRefreshCapability signals support for the ROUTE-REFRESH message (RFC2918)
+type RouteRefreshCapability struct{}
+
+func (a RouteRefreshCapability) serialize(buf *bytes.Buffer) {}
+
+// EnhancedRouteRefreshCapability signals support for BoRR and EoRR demarcation of route refreshes (RFC7313)
+type EnhancedRouteRefreshCapability struct{}
+
+func (a EnhancedRouteRefreshCapability) serialize(buf *bytes.Buffer) {}
+
+// GracefulRestartCapability signals support for graceful restart (RFC4724). Without tuples
+// it only announces that we are able to act as receiving speaker.
+type GracefulRestartCapability struct {
+	RestartFlags uint8
+	RestartTime  uint16
+	Tuples       []GracefulRestartCapabilityTuple
+}
+
+func (a GracefulRestartCapability) serialize(buf *bytes.Buffer) {
+	buf.Write(convert.Uint16Byte(uint16(a.RestartFlags)<<12 | a.RestartTime&0x0fff))
+	for _, t := range a.Tuples {
+		t.serialize(buf)
+	}
+}
+
+// GracefulRestartCapabilityTuple holds the per AFI/SAFI part of the graceful restart capability
+type GracefulRestartCapabilityTuple struct {
+	AFI   uint16
+	SAFI  uint8
+	Flags uint8
+}
+
+func (a GracefulRestartCapabilityTuple) serialize(buf *bytes.Buffer) {
+	buf.Write(convert.Uint16Byte(a.AFI))
+	buf.WriteByte(a.SAFI)
+	buf.WriteByte(a.Flags)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"math"
+	"sort"
 
 	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/protocols/bgp/types"
@@ -125,7 +126,12 @@ func decodePathAttr(buf *bytes.Buffer, opt *DecodeOptions) (pa *PathAttribute, c
 			return nil, consumed, fmt.Errorf("failed to decode local pref: %w", err)
 		}
 	case AggregatorAttr:
-		if err := pa.decodeAggregator(buf); err != nil {
+		asnLength := uint8(2)
+		if opt.Use32BitASN {
+			asnLength = 4
+		}
+
+		if err := pa.decodeAggregator(buf, asnLength); err != nil {
 			return nil, consumed, fmt.Errorf("failed to decode Aggregator: %w", err)
 		}
 	case AtomicAggrAttr:
@@ -151,13 +157,25 @@ func decodePathAttr(buf *bytes.Buffer, opt *DecodeOptions) (pa *PathAttribute, c
 			return nil, consumed, fmt.Errorf("failed to multi protocol unreachable NLRI: %w", err)
 		}
 	case AS4AggregatorAttr:
-		if err := pa.decodeAS4Aggregator(buf); err != nil {
-			return nil, consumed, fmt.Errorf("failed to skip not supported AS4Aggregator: %w", err)
+		if err := pa.decodeAggregator(buf, 4); err != nil {
+			return nil, consumed, fmt.Errorf("failed to decode AS4 Aggregator: %w", err)
 		}
 	case LargeCommunitiesAttr:
 		if err := pa.decodeLargeCommunities(buf); err != nil {
 			return nil, consumed, fmt.Errorf("failed to decode large communities: %w", err)
 		}
+	case PMSITunnelAttr:
+		if err := pa.decodePMSITunnel(buf); err != nil {
+			return nil, consumed, fmt.Errorf("failed to decode PMSI tunnel: %w", err)
+		}
+	case TunnelEncapsulationAttr:
+		if err := pa.decodeTunnelEncapsulation(buf); err != nil {
+			return nil, consumed, fmt.Errorf("failed to decode tunnel encapsulation: %w", err)
+		}
+	case PrefixSIDAttr:
+		if err := pa.decodePrefixSID(buf); err != nil {
+			return nil, consumed, fmt.Errorf("failed to decode prefix SID: %w", err)
+		}
 	default:
 		if err := pa.decodeUnknown(buf); err != nil {
 			return nil, consumed, fmt.Errorf("failed to decode unknown attribute: %w", err)
@@ -251,7 +269,7 @@ func (pa *PathAttribute) decodeASPath(buf *bytes.Buffer, asnLength uint8) error
 
 		p += 2
 
-		if segment.Type != types.ASSet && segment.Type != types.ASSequence {
+		if segment.Type < types.ASSet || segment.Type > types.ASConfedSet {
 			return fmt.Errorf("invalid AS Path segment type: %d", segment.Type)
 		}
 
@@ -339,15 +357,24 @@ func (pa *PathAttribute) decodeLocalPref(buf *bytes.Buffer) error {
 	return nil
 }
 
-func (pa *PathAttribute) decodeAggregator(buf *bytes.Buffer) error {
+func (pa *PathAttribute) decodeAggregator(buf *bytes.Buffer, asnLength uint8) error {
 	aggr := types.Aggregator{}
-	p := uint16(0)
 
-	err := decode.Decode(buf, []interface{}{&aggr.ASN, &aggr.Address})
-	if err != nil {
-		return err
+	if asnLength == 4 {
+		err := decode.Decode(buf, []interface{}{&aggr.ASN, &aggr.Address})
+		if err != nil {
+			return err
+		}
+	} else {
+		asn := uint16(0)
+		err := decode.Decode(buf, []interface{}{&asn, &aggr.Address})
+		if err != nil {
+			return err
+		}
+		aggr.ASN = uint32(asn)
 	}
-	p += 6
+
+	p := uint16(asnLength) + 4
 	pa.Value = aggr
 	return dumpNBytes(buf, pa.Length-p)
 }
@@ -408,10 +435,6 @@ func (pa *PathAttribute) decodeLargeCommunities(buf *bytes.Buffer) error {
 	return nil
 }
 
-func (pa *PathAttribute) decodeAS4Aggregator(buf *bytes.Buffer) error {
-	return pa.decodeUint32(buf, "AS4Aggregator")
-}
-
 func (pa *PathAttribute) decodeUint32(buf *bytes.Buffer, attrName string) error {
 	v, err := read4BytesAsUint32(buf)
 	if err != nil {
@@ -523,7 +546,9 @@ func (pa *PathAttribute) Serialize(buf *bytes.Buffer, opt *EncodeOptions) uint16
 	case AtomicAggrAttr:
 		pathAttrLen = uint16(pa.serializeAtomicAggregate(buf))
 	case AggregatorAttr:
-		pathAttrLen = uint16(pa.serializeAggregator(buf))
+		pathAttrLen = pa.serializeAggregator(buf, opt)
+	case AS4AggregatorAttr:
+		pathAttrLen = serializeAggregator4(buf, AS4AggregatorAttr, pa.Value.(types.Aggregator))
 	case CommunitiesAttr:
 		pathAttrLen = uint16(pa.serializeCommunities(buf))
 	case LargeCommunitiesAttr:
@@ -536,6 +561,12 @@ func (pa *PathAttribute) Serialize(buf *bytes.Buffer, opt *EncodeOptions) uint16
 		pathAttrLen = uint16(pa.serializeOriginatorID(buf))
 	case ClusterListAttr:
 		pathAttrLen = uint16(pa.serializeClusterList(buf))
+	case PMSITunnelAttr:
+		pathAttrLen = pa.serializePMSITunnel(buf)
+	case TunnelEncapsulationAttr:
+		pathAttrLen = pa.serializeTunnelEncapsulation(buf)
+	case PrefixSIDAttr:
+		pathAttrLen = pa.serializePrefixSID(buf)
 	default:
 		pathAttrLen = pa.serializeUnknownAttribute(buf)
 	}
@@ -640,17 +671,48 @@ func (pa *PathAttribute) serializeAtomicAggregate(buf *bytes.Buffer) uint8 {
 	return 3
 }
 
-func (pa *PathAttribute) serializeAggregator(buf *bytes.Buffer) uint8 {
+// serializeAggregator serializes an AGGREGATOR attribute. Towards peers not supporting 4 octet ASNs an ASN not fitting
+// into 2 octets is replaced by AS_TRANS and carried in an additional AS4_AGGREGATOR attribute (RFC6793).
+func (pa *PathAttribute) serializeAggregator(buf *bytes.Buffer, opt *EncodeOptions) uint16 {
+	aggregator := pa.Value.(types.Aggregator)
+	if opt.Use32BitASN {
+		return serializeAggregator4(buf, AggregatorAttr, aggregator)
+	}
+
+	asn := uint16(aggregator.ASN)
+	if aggregator.ASN > math.MaxUint16 {
+		asn = types.ASTrans
+	}
+
 	attrFlags := uint8(0)
 	attrFlags = setOptional(attrFlags)
 	attrFlags = setTransitive(attrFlags)
 	buf.WriteByte(attrFlags)
 	buf.WriteByte(AggregatorAttr)
-	length := uint8(6)
-	buf.WriteByte(length)
+	length := uint16(6)
+	buf.WriteByte(uint8(length))
 
-	aggregator := pa.Value.(types.Aggregator)
-	buf.Write(convert.Uint16Byte(aggregator.ASN))
+	buf.Write(convert.Uint16Byte(asn))
+	buf.Write(convert.Uint32Byte(aggregator.Address))
+
+	if aggregator.ASN > math.MaxUint16 {
+		return length + 3 + serializeAggregator4(buf, AS4AggregatorAttr, aggregator)
+	}
+
+	return length + 3
+}
+
+// serializeAggregator4 serializes an AGGREGATOR or AS4_AGGREGATOR attribute with a 4 octet ASN
+func serializeAggregator4(buf *bytes.Buffer, typeCode uint8, aggregator types.Aggregator) uint16 {
+	attrFlags := uint8(0)
+	attrFlags = setOptional(attrFlags)
+	attrFlags = setTransitive(attrFlags)
+	buf.WriteByte(attrFlags)
+	buf.WriteByte(typeCode)
+	length := uint16(8)
+	buf.WriteByte(uint8(length))
+
+	buf.Write(convert.Uint32Byte(aggregator.ASN))
 	buf.Write(convert.Uint32Byte(aggregator.Address))
 
 	return length + 3
@@ -685,7 +747,7 @@ func (pa *PathAttribute) serializeCommunities(buf *bytes.Buffer) uint16 {
 		length++
 	}
 
-	for _, com := range *coms {
+	for _, com := range sortedCommunities(*coms) {
 		buf.Write(convert.Uint32Byte(com))
 	}
 
@@ -721,7 +783,7 @@ func (pa *PathAttribute) serializeLargeCommunities(buf *bytes.Buffer) uint16 {
 		length++
 	}
 
-	for _, com := range *coms {
+	for _, com := range sortedLargeCommunities(*coms) {
 		buf.Write(convert.Uint32Byte(com.GlobalAdministrator))
 		buf.Write(convert.Uint32Byte(com.DataPart1))
 		buf.Write(convert.Uint32Byte(com.DataPart2))
@@ -730,6 +792,36 @@ func (pa *PathAttribute) serializeLargeCommunities(buf *bytes.Buffer) uint16 {
 	return length + 3
 }
 
+// sortedCommunities returns coms in ascending order. coms might be shared and is not modified.
+func sortedCommunities(coms types.Communities) types.Communities {
+	if sort.SliceIsSorted(coms, func(i, j int) bool { return coms[i] < coms[j] }) {
+		return coms
+	}
+
+	ret := make(types.Communities, len(coms))
+	copy(ret, coms)
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i] < ret[j]
+	})
+
+	return ret
+}
+
+// sortedLargeCommunities returns coms in ascending order. coms might be shared and is not modified.
+func sortedLargeCommunities(coms types.LargeCommunities) types.LargeCommunities {
+	if sort.SliceIsSorted(coms, func(i, j int) bool { return coms[i].Less(coms[j]) }) {
+		return coms
+	}
+
+	ret := make(types.LargeCommunities, len(coms))
+	copy(ret, coms)
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Less(ret[j])
+	})
+
+	return ret
+}
+
 func (pa *PathAttribute) serializeOriginatorID(buf *bytes.Buffer) uint8 {
 	attrFlags := uint8(0)
 	attrFlags = setOptional(attrFlags)
@@ -775,6 +867,9 @@ func (pa *PathAttribute) serializeUnknownAttribute(buf *bytes.Buffer) uint16 {
 	if pa.ExtendedLength {
 		attrFlags = setExtendedLength(attrFlags)
 	}
+	if pa.Partial {
+		attrFlags = setPartial(attrFlags)
+	}
 	attrFlags = setTransitive(attrFlags)
 
 	buf.WriteByte(attrFlags)
@@ -785,9 +880,12 @@ func (pa *PathAttribute) serializeUnknownAttribute(buf *bytes.Buffer) uint16 {
 		l := len(b)
 		buf.WriteByte(uint8(l >> 8))
 		buf.WriteByte(uint8(l & 0x0000FFFF))
-	} else {
-		buf.WriteByte(uint8(len(b)))
+		buf.Write(b)
+
+		return uint16(len(b)) + 4
 	}
+
+	buf.WriteByte(uint8(len(b)))
 	buf.Write(b)
 
 	return uint16(len(b)) + 3
@@ -888,6 +986,33 @@ func (pa *PathAttribute) AddOptionalPathAttributes(p *route.Path) *PathAttribute
 		current = largeCommunities
 	}
 
+	if p.BGPPath.PMSITunnel != nil {
+		pmsiTunnel := &PathAttribute{
+			TypeCode: PMSITunnelAttr,
+			Value:    p.BGPPath.PMSITunnel,
+		}
+		current.Next = pmsiTunnel
+		current = pmsiTunnel
+	}
+
+	if p.BGPPath.TunnelEncapsulation != nil && len(*p.BGPPath.TunnelEncapsulation) > 0 {
+		tunnelEncapsulation := &PathAttribute{
+			TypeCode: TunnelEncapsulationAttr,
+			Value:    p.BGPPath.TunnelEncapsulation,
+		}
+		current.Next = tunnelEncapsulation
+		current = tunnelEncapsulation
+	}
+
+	if p.BGPPath.PrefixSID != nil {
+		prefixSID := &PathAttribute{
+			TypeCode: PrefixSIDAttr,
+			Value:    p.BGPPath.PrefixSID,
+		}
+		current.Next = prefixSID
+		current = prefixSID
+	}
+
 	return current
 }
 
@@ -913,7 +1038,7 @@ func PathAttributes(p *route.Path, iBGP bool, rrClient bool) (*PathAttribute, er
 	last.Next = nextHop
 	last = nextHop
 
-	if p.BGPPath.BGPPathA.MED != 0 {
+	if p.BGPPath.BGPPathA.MEDPresent {
 		med := &PathAttribute{
 			TypeCode: MEDAttr,
 			Value:    p.BGPPath.BGPPathA.MED,
@@ -970,12 +1095,14 @@ func PathAttributes(p *route.Path, iBGP bool, rrClient bool) (*PathAttribute, er
 
 	last = optionals
 	for _, unknownAttr := range p.BGPPath.UnknownAttributes {
+		// Unrecognized optional transitive attributes are passed on with the Partial bit set (RFC 4271, section 5)
 		last.Next = &PathAttribute{
-			TypeCode:   unknownAttr.TypeCode,
-			Optional:   unknownAttr.Optional,
-			Transitive: unknownAttr.Transitive,
-			Partial:    unknownAttr.Partial,
-			Value:      unknownAttr.Value,
+			TypeCode:       unknownAttr.TypeCode,
+			Optional:       unknownAttr.Optional,
+			Transitive:     unknownAttr.Transitive,
+			Partial:        unknownAttr.Partial || (unknownAttr.Optional && unknownAttr.Transitive),
+			ExtendedLength: len(unknownAttr.Value) > 255,
+			Value:          unknownAttr.Value,
 		}
 		last = last.Next
 	}
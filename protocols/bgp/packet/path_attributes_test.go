@@ -422,6 +422,47 @@ func TestDecodeASPath(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "AS_CONFED_SEQUENCE and AS_CONFED_SET",
+			input: []byte{
+				3, // AS_CONFED_SEQUENCE
+				2, // Path Length
+				0, 0, 0xfd, 0xe9, 0, 0, 0xfd, 0xea,
+				4, // AS_CONFED_SET
+				1, // Path Length
+				0, 0, 0xfd, 0xeb,
+				2, // AS_SEQUENCE
+				1, // Path Length
+				0, 0, 0, 100,
+			},
+			use4OctetASNs: true,
+			expected: &PathAttribute{
+				Length: 22,
+				Value: &types.ASPath{
+					types.ASPathSegment{
+						Type: types.ASConfedSequence,
+						ASNs: []uint32{65001, 65002},
+					},
+					types.ASPathSegment{
+						Type: types.ASConfedSet,
+						ASNs: []uint32{65003},
+					},
+					types.ASPathSegment{
+						Type: types.ASSequence,
+						ASNs: []uint32{100},
+					},
+				},
+			},
+		},
+		{
+			name: "Invalid segment type",
+			input: []byte{
+				5, // Unknown
+				1, // Path Length
+				0, 100,
+			},
+			wantFail: true,
+		},
 		{
 			name:           "Empty input",
 			input:          []byte{},
@@ -643,6 +684,7 @@ func TestDecodeAggregator(t *testing.T) {
 	tests := []struct {
 		name           string
 		input          []byte
+		asnLength      uint8
 		wantFail       bool
 		explicitLength uint16
 		expected       *PathAttribute
@@ -662,6 +704,31 @@ func TestDecodeAggregator(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Valid aggregator with 4 octet ASN",
+			input: []byte{
+				0, 3, 13, 64, // ASN
+				10, 20, 30, 40, // Aggregator IP
+			},
+			asnLength: 4,
+			wantFail:  false,
+			expected: &PathAttribute{
+				Length: 8,
+				Value: types.Aggregator{
+					ASN:     200000,
+					Address: bnet.IPv4FromOctets(10, 20, 30, 40).Ptr().ToUint32(),
+				},
+			},
+		},
+		{
+			name: "Incomplete Address with 4 octet ASN",
+			input: []byte{
+				0, 3, 13, 64, // ASN
+				10, 20, // Aggregator IP
+			},
+			asnLength: 4,
+			wantFail:  true,
+		},
 		{
 			name: "Incomplete Address",
 			input: []byte{
@@ -689,10 +756,14 @@ func TestDecodeAggregator(t *testing.T) {
 		if test.explicitLength != 0 {
 			l = test.explicitLength
 		}
+		asnLength := test.asnLength
+		if asnLength == 0 {
+			asnLength = 2
+		}
 		pa := &PathAttribute{
 			Length: l,
 		}
-		err := pa.decodeAggregator(bytes.NewBuffer(test.input))
+		err := pa.decodeAggregator(bytes.NewBuffer(test.input), asnLength)
 
 		if test.wantFail {
 			if err != nil {
@@ -1549,8 +1620,9 @@ func TestSerializeAggregator(t *testing.T) {
 	tests := []struct {
 		name        string
 		input       *PathAttribute
+		use32BitASN bool
 		expected    []byte
-		expectedLen uint8
+		expectedLen uint16
 	}{
 		{
 			name: "Test #1",
@@ -1570,17 +1642,61 @@ func TestSerializeAggregator(t *testing.T) {
 			},
 			expectedLen: 9,
 		},
+		{
+			name: "4 octet ASN",
+			input: &PathAttribute{
+				TypeCode: AggregatorAttr,
+				Value: types.Aggregator{
+					ASN:     200000,
+					Address: bnet.IPv4FromOctets(10, 20, 30, 40).Ptr().ToUint32(),
+				},
+			},
+			use32BitASN: true,
+			expected: []byte{
+				192,          // Attribute flags
+				7,            // Type
+				8,            // Length
+				0, 3, 13, 64, // Value = 200000
+				10, 20, 30, 40,
+			},
+			expectedLen: 11,
+		},
+		{
+			name: "4 octet ASN to peer not supporting 4 octet ASNs",
+			input: &PathAttribute{
+				TypeCode: AggregatorAttr,
+				Value: types.Aggregator{
+					ASN:     200000,
+					Address: bnet.IPv4FromOctets(10, 20, 30, 40).Ptr().ToUint32(),
+				},
+			},
+			expected: []byte{
+				192,     // Attribute flags
+				7,       // Type
+				6,       // Length
+				91, 160, // Value = AS_TRANS
+				10, 20, 30, 40,
+				192,          // Attribute flags
+				18,           // Type AS4_AGGREGATOR
+				8,            // Length
+				0, 3, 13, 64, // Value = 200000
+				10, 20, 30, 40,
+			},
+			expectedLen: 20,
+		},
 	}
 
 	for _, test := range tests {
 		buf := bytes.NewBuffer(nil)
-		n := test.input.serializeAggregator(buf)
+		n := test.input.serializeAggregator(buf, &EncodeOptions{
+			Use32BitASN: test.use32BitASN,
+		})
 		if n != test.expectedLen {
 			t.Errorf("Unexpected length for test %q: %d", test.name, n)
 			continue
 		}
 
-		assert.Equal(t, test.expected, buf.Bytes())
+		assert.Equal(t, test.expected, buf.Bytes(), test.name)
 	}
 }
 
@@ -1888,6 +2004,23 @@ func TestSerializeUnknownAttribute(t *testing.T) {
 			},
 			expectedLen: 7,
 		},
+		{
+			name: "Optional transitive partial attribute",
+			input: &PathAttribute{
+				TypeCode:   200,
+				Value:      []byte{1, 2, 3, 4},
+				Optional:   true,
+				Transitive: true,
+				Partial:    true,
+			},
+			expected: []byte{
+				224,        // Attribute flags
+				200,        // Type
+				4,          // Length
+				1, 2, 3, 4, // Payload
+			},
+			expectedLen: 7,
+		},
 		{
 			name: "Extended length",
 			input: &PathAttribute{
@@ -1916,7 +2049,7 @@ func TestSerializeUnknownAttribute(t *testing.T) {
 				0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
 				0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, // Payload
 			},
-			expectedLen: 259,
+			expectedLen: 260,
 		},
 	}
 
@@ -0,0 +1,49 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+const pmsiTunnelMinLen = 5
+
+func (pa *PathAttribute) decodePMSITunnel(buf *bytes.Buffer) error {
+	if pa.Length < pmsiTunnelMinLen {
+		return fmt.Errorf("PMSI tunnel attribute too short: %d bytes", pa.Length)
+	}
+
+	b := make([]byte, pa.Length)
+	n, err := buf.Read(b)
+	if err != nil {
+		return fmt.Errorf("unable to read %d bytes from buffer: %w", pa.Length, err)
+	}
+	if n != int(pa.Length) {
+		return fmt.Errorf("unable to read %d bytes from buffer, only got %d bytes", pa.Length, n)
+	}
+
+	pa.Value = &types.PMSITunnel{
+		Flags:      b[0],
+		TunnelType: b[1],
+		Label:      uint32(b[2])<<16 | uint32(b[3])<<8 | uint32(b[4]),
+		TunnelID:   b[pmsiTunnelMinLen:],
+	}
+
+	return nil
+}
+
+func (pa *PathAttribute) serializePMSITunnel(buf *bytes.Buffer) uint16 {
+	p := pa.Value.(*types.PMSITunnel)
+	pa.Optional = true
+	pa.Transitive = true
+
+	tempBuf := bytes.NewBuffer(nil)
+	tempBuf.WriteByte(p.Flags)
+	tempBuf.WriteByte(p.TunnelType)
+	tempBuf.Write(convert.Uint32Byte(p.Label)[1:])
+	tempBuf.Write(p.TunnelID)
+
+	return pa.serializeGeneric(tempBuf.Bytes(), buf)
+}
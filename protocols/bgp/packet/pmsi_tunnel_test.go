@@ -0,0 +1,46 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPMSITunnelRoundTrip(t *testing.T) {
+	input := []byte{
+		0xc0, 22, 9, // Optional, transitive, PMSI Tunnel, Length
+		0,             // Flags
+		6,             // Ingress Replication
+		0, 0x27, 0x10, // VNI 10000
+		192, 0, 2, 1, // Tunnel Identifier
+	}
+
+	pa, _, err := decodePathAttr(bytes.NewBuffer(input), &DecodeOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assert.Equal(t, &types.PMSITunnel{
+		TunnelType: types.PMSITunnelTypeIngressReplication,
+		Label:      10000,
+		TunnelID:   []byte{192, 0, 2, 1},
+	}, pa.Value)
+
+	buf := bytes.NewBuffer(nil)
+	(&PathAttribute{
+		TypeCode: PMSITunnelAttr,
+		Value:    pa.Value,
+	}).Serialize(buf, &EncodeOptions{})
+	assert.Equal(t, input, buf.Bytes())
+}
+
+func TestDecodePMSITunnelTooShort(t *testing.T) {
+	pa := &PathAttribute{
+		Length: 4,
+	}
+
+	err := pa.decodePMSITunnel(bytes.NewBuffer([]byte{0, 6, 0, 0}))
+	assert.Error(t, err)
+}
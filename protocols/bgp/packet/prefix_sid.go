@@ -0,0 +1,272 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+// Prefix-SID TLV types (RFC8669, RFC9252)
+const (
+	PrefixSIDLabelIndexTLV     = 1
+	PrefixSIDOriginatorSRGBTLV = 3
+	PrefixSIDSRv6L3ServiceTLV  = 5
+	PrefixSIDSRv6L2ServiceTLV  = 6
+
+	// SRv6SIDInformationSubTLV is the sub-TLV type of SRv6 SID Information sub-TLVs of SRv6 Service TLVs
+	SRv6SIDInformationSubTLV = 1
+
+	// SRv6SIDStructureSubSubTLV is the sub-sub-TLV type of SRv6 SID Structure sub-sub-TLVs
+	SRv6SIDStructureSubSubTLV = 1
+
+	prefixSIDTLVHeaderLen     = 3
+	prefixSIDLabelIndexLen    = 7
+	srv6SIDInformationMinLen  = 21
+	srv6SIDStructureLen       = 6
+	srv6ServiceReservedLen    = 1
+	srv6SIDInformationSIDSize = 16
+)
+
+func (pa *PathAttribute) decodePrefixSID(buf *bytes.Buffer) error {
+	b := make([]byte, pa.Length)
+	n, err := buf.Read(b)
+	if err != nil {
+		return fmt.Errorf("unable to read %d bytes from buffer: %w", pa.Length, err)
+	}
+	if n != int(pa.Length) {
+		return fmt.Errorf("unable to read %d bytes from buffer, only got %d bytes", pa.Length, n)
+	}
+
+	p, err := deserializePrefixSID(b)
+	if err != nil {
+		return fmt.Errorf("unable to decode prefix SID: %w", err)
+	}
+
+	pa.Value = p
+	return nil
+}
+
+func deserializePrefixSID(b []byte) (*types.PrefixSID, error) {
+	p := &types.PrefixSID{}
+
+	err := walkPrefixSIDTLVs(b, func(tlvType uint8, v []byte) error {
+		switch tlvType {
+		case PrefixSIDLabelIndexTLV:
+			if p.LabelIndex != nil {
+				return fmt.Errorf("duplicate label-index TLV")
+			}
+
+			if len(v) != prefixSIDLabelIndexLen {
+				return fmt.Errorf("invalid label-index TLV length %d", len(v))
+			}
+
+			p.LabelIndex = &types.PrefixSIDLabelIndex{
+				Flags: uint16(v[1])<<8 | uint16(v[2]),
+				Index: uint32(v[3])<<24 | uint32(v[4])<<16 | uint32(v[5])<<8 | uint32(v[6]),
+			}
+			return nil
+		case PrefixSIDSRv6L3ServiceTLV:
+			if p.SRv6L3Service != nil {
+				return fmt.Errorf("duplicate SRv6 L3 service TLV")
+			}
+
+			s, err := deserializeSRv6Service(v)
+			if err != nil {
+				return fmt.Errorf("unable to decode SRv6 L3 service TLV: %w", err)
+			}
+
+			p.SRv6L3Service = s
+			return nil
+		}
+
+		p.TLVs = append(p.TLVs, newPrefixSIDTLV(tlvType, v))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func deserializeSRv6Service(b []byte) (*types.SRv6Service, error) {
+	if len(b) < srv6ServiceReservedLen {
+		return nil, fmt.Errorf("SRv6 service TLV truncated")
+	}
+
+	s := &types.SRv6Service{}
+	err := walkPrefixSIDTLVs(b[srv6ServiceReservedLen:], func(subTLVType uint8, v []byte) error {
+		if subTLVType != SRv6SIDInformationSubTLV {
+			s.SubTLVs = append(s.SubTLVs, newPrefixSIDTLV(subTLVType, v))
+			return nil
+		}
+
+		sid, err := deserializeSRv6SIDInformation(v)
+		if err != nil {
+			return fmt.Errorf("unable to decode SRv6 SID information sub-TLV: %w", err)
+		}
+
+		s.SIDs = append(s.SIDs, sid)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func deserializeSRv6SIDInformation(b []byte) (*types.SRv6SIDInformation, error) {
+	if len(b) < srv6SIDInformationMinLen {
+		return nil, fmt.Errorf("SRv6 SID information sub-TLV too short: %d bytes", len(b))
+	}
+
+	sid, err := bnet.IPFromBytes(b[1 : 1+srv6SIDInformationSIDSize])
+	if err != nil {
+		return nil, fmt.Errorf("invalid SRv6 SID: %w", err)
+	}
+
+	s := &types.SRv6SIDInformation{
+		SID:              sid,
+		Flags:            b[17],
+		EndpointBehavior: uint16(b[18])<<8 | uint16(b[19]),
+	}
+
+	err = walkPrefixSIDTLVs(b[srv6SIDInformationMinLen:], func(subSubTLVType uint8, v []byte) error {
+		if subSubTLVType != SRv6SIDStructureSubSubTLV {
+			s.SubSubTLVs = append(s.SubSubTLVs, newPrefixSIDTLV(subSubTLVType, v))
+			return nil
+		}
+
+		if s.Structure != nil {
+			return fmt.Errorf("duplicate SRv6 SID structure sub-sub-TLV")
+		}
+
+		if len(v) != srv6SIDStructureLen {
+			return fmt.Errorf("invalid SRv6 SID structure sub-sub-TLV length %d", len(v))
+		}
+
+		s.Structure = &types.SRv6SIDStructure{
+			LocatorBlockLen:     v[0],
+			LocatorNodeLen:      v[1],
+			FunctionLen:         v[2],
+			ArgumentLen:         v[3],
+			TranspositionLen:    v[4],
+			TranspositionOffset: v[5],
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// walkPrefixSIDTLVs calls f for each TLV in b. TLVs, sub-TLVs and sub-sub-TLVs of the Prefix-SID attribute share
+// the same format of a one octet type and a two octet length.
+func walkPrefixSIDTLVs(b []byte, f func(tlvType uint8, v []byte) error) error {
+	for len(b) > 0 {
+		if len(b) < prefixSIDTLVHeaderLen {
+			return fmt.Errorf("TLV header truncated")
+		}
+
+		tlvType := b[0]
+		length := int(b[1])<<8 | int(b[2])
+		b = b[prefixSIDTLVHeaderLen:]
+
+		if len(b) < length {
+			return fmt.Errorf("TLV length %d exceeds remaining %d bytes", length, len(b))
+		}
+
+		err := f(tlvType, b[:length])
+		if err != nil {
+			return err
+		}
+
+		b = b[length:]
+	}
+
+	return nil
+}
+
+func newPrefixSIDTLV(tlvType uint8, v []byte) types.PrefixSIDTLV {
+	value := make([]byte, len(v))
+	copy(value, v)
+
+	return types.PrefixSIDTLV{
+		Type:  tlvType,
+		Value: value,
+	}
+}
+
+func (pa *PathAttribute) serializePrefixSID(buf *bytes.Buffer) uint16 {
+	p := pa.Value.(*types.PrefixSID)
+	pa.Optional = true
+	pa.Transitive = true
+
+	tempBuf := bytes.NewBuffer(nil)
+	if p.LabelIndex != nil {
+		v := make([]byte, prefixSIDLabelIndexLen)
+		copy(v[1:3], convert.Uint16Byte(p.LabelIndex.Flags))
+		copy(v[3:], convert.Uint32Byte(p.LabelIndex.Index))
+		serializePrefixSIDTLV(tempBuf, PrefixSIDLabelIndexTLV, v)
+	}
+
+	for _, t := range p.TLVs {
+		serializePrefixSIDTLV(tempBuf, t.Type, t.Value)
+	}
+
+	if p.SRv6L3Service != nil {
+		serializePrefixSIDTLV(tempBuf, PrefixSIDSRv6L3ServiceTLV, serializeSRv6Service(p.SRv6L3Service))
+	}
+
+	return pa.serializeGeneric(tempBuf.Bytes(), buf)
+}
+
+func serializeSRv6Service(s *types.SRv6Service) []byte {
+	buf := bytes.NewBuffer(make([]byte, srv6ServiceReservedLen))
+
+	for _, sid := range s.SIDs {
+		addr := sid.SID.To16BytesArray()
+		v := bytes.NewBuffer(nil)
+		v.WriteByte(0) // Reserved
+		v.Write(addr[:])
+		v.WriteByte(sid.Flags)
+		v.Write(convert.Uint16Byte(sid.EndpointBehavior))
+		v.WriteByte(0) // Reserved
+
+		if sid.Structure != nil {
+			st := sid.Structure
+			serializePrefixSIDTLV(v, SRv6SIDStructureSubSubTLV, []byte{
+				st.LocatorBlockLen,
+				st.LocatorNodeLen,
+				st.FunctionLen,
+				st.ArgumentLen,
+				st.TranspositionLen,
+				st.TranspositionOffset,
+			})
+		}
+
+		for _, t := range sid.SubSubTLVs {
+			serializePrefixSIDTLV(v, t.Type, t.Value)
+		}
+
+		serializePrefixSIDTLV(buf, SRv6SIDInformationSubTLV, v.Bytes())
+	}
+
+	for _, t := range s.SubTLVs {
+		serializePrefixSIDTLV(buf, t.Type, t.Value)
+	}
+
+	return buf.Bytes()
+}
+
+func serializePrefixSIDTLV(buf *bytes.Buffer, tlvType uint8, v []byte) {
+	buf.WriteByte(tlvType)
+	buf.Write(convert.Uint16Byte(uint16(len(v))))
+	buf.Write(v)
+}
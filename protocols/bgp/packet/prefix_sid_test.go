@@ -0,0 +1,133 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrefixSIDRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		expected *types.PrefixSID
+	}{
+		{
+			name: "Label-Index and Originator SRGB",
+			input: []byte{
+				0xc0, 40, 21, // Optional, transitive, Prefix-SID, Length
+				1, 0, 7, 0, 0, 0, 0, 0, 0, 100, // Label-Index 100
+				3, 0, 8, 0, 0, 0, 0x3e, 0x80, 0, 0x1f, 0x40, // Originator SRGB 16000, range 8000
+			},
+			expected: &types.PrefixSID{
+				LabelIndex: &types.PrefixSIDLabelIndex{
+					Index: 100,
+				},
+				TLVs: []types.PrefixSIDTLV{
+					{
+						Type:  PrefixSIDOriginatorSRGBTLV,
+						Value: []byte{0, 0, 0, 0x3e, 0x80, 0, 0x1f, 0x40},
+					},
+				},
+			},
+		},
+		{
+			name: "SRv6 L3 Service with SID structure",
+			input: []byte{
+				0xc0, 40, 37, // Optional, transitive, Prefix-SID, Length
+				5, 0, 34, 0, // SRv6 L3 Service TLV
+				1, 0, 30, 0, // SRv6 SID Information sub-TLV
+				0x20, 0x01, 0x0d, 0xb8, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, // SID 2001:db8:1::
+				0, 0, 0x13, 0, // Flags, End.DT4, Reserved
+				1, 0, 6, 40, 24, 16, 0, 16, 64, // SRv6 SID Structure
+			},
+			expected: &types.PrefixSID{
+				SRv6L3Service: &types.SRv6Service{
+					SIDs: []*types.SRv6SIDInformation{
+						{
+							SID:              bnet.IPv6FromBlocks(0x2001, 0xdb8, 1, 0, 0, 0, 0, 0),
+							EndpointBehavior: 0x13,
+							Structure: &types.SRv6SIDStructure{
+								LocatorBlockLen:     40,
+								LocatorNodeLen:      24,
+								FunctionLen:         16,
+								TranspositionLen:    16,
+								TranspositionOffset: 64,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pa, _, err := decodePathAttr(bytes.NewBuffer(test.input), &DecodeOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert.Equal(t, uint8(PrefixSIDAttr), pa.TypeCode)
+			assert.Equal(t, test.expected, pa.Value)
+
+			buf := bytes.NewBuffer(nil)
+			(&PathAttribute{
+				TypeCode: PrefixSIDAttr,
+				Value:    pa.Value,
+			}).Serialize(buf, &EncodeOptions{})
+			assert.Equal(t, test.input, buf.Bytes())
+			assert.Equal(t, uint16(len(test.input)), test.expected.WireLength())
+		})
+	}
+}
+
+func TestDecodePrefixSIDMalformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{
+			name:  "Truncated TLV header",
+			input: []byte{1, 0},
+		},
+		{
+			name:  "TLV length exceeding attribute",
+			input: []byte{1, 0, 7, 0, 0, 0, 0},
+		},
+		{
+			name:  "Invalid Label-Index length",
+			input: []byte{1, 0, 4, 0, 0, 0, 100},
+		},
+		{
+			name:  "Duplicate Label-Index",
+			input: []byte{1, 0, 7, 0, 0, 0, 0, 0, 0, 100, 1, 0, 7, 0, 0, 0, 0, 0, 0, 200},
+		},
+		{
+			name:  "SRv6 SID Information too short",
+			input: []byte{5, 0, 7, 0, 1, 0, 3, 0, 0x20, 0x01},
+		},
+		{
+			name: "Invalid SRv6 SID Structure length",
+			input: []byte{
+				5, 0, 31, 0,
+				1, 0, 27, 0,
+				0x20, 0x01, 0x0d, 0xb8, 0, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+				0, 0, 0x13, 0,
+				1, 0, 3, 40, 24, 16,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		pa := &PathAttribute{
+			Length: uint16(len(test.input)),
+		}
+
+		err := pa.decodePrefixSID(bytes.NewBuffer(test.input))
+		assert.Error(t, err, test.name)
+	}
+}
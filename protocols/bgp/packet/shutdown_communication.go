@@ -0,0 +1,70 @@
+package packet
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// ShutdownCommunicationMaxLen is the maximum length of a shutdown communication in bytes (RFC 8203 as updated by RFC 9003)
+const ShutdownCommunicationMaxLen = 255
+
+// NewShutdownNotification creates an administrative shutdown or reset Cease NOTIFICATION carrying the
+// human readable shutdown communication (RFC 8203). An empty communication is omitted.
+func NewShutdownNotification(subcode uint8, communication string) (*BGPNotification, error) {
+	if subcode != AdminShut && subcode != AdminReset {
+		return nil, fmt.Errorf("shutdown communication is not supported for Cease subcode %d", subcode)
+	}
+
+	err := ValidateShutdownCommunication(communication)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &BGPNotification{
+		ErrorCode:    Cease,
+		ErrorSubcode: subcode,
+	}
+
+	if communication != "" {
+		n.Data = append([]byte{uint8(len(communication))}, communication...)
+	}
+
+	return n, nil
+}
+
+// ValidateShutdownCommunication checks if s can be sent as shutdown communication
+func ValidateShutdownCommunication(s string) error {
+	if len(s) > ShutdownCommunicationMaxLen {
+		return fmt.Errorf("shutdown communication exceeds %d bytes", ShutdownCommunicationMaxLen)
+	}
+
+	if !utf8.ValidString(s) {
+		return fmt.Errorf("shutdown communication is not valid UTF-8")
+	}
+
+	return nil
+}
+
+// ShutdownCommunication gets the shutdown communication of an administrative shutdown or reset Cease NOTIFICATION.
+// false is returned if the NOTIFICATION carries no or a malformed communication.
+func (n *BGPNotification) ShutdownCommunication() (string, bool) {
+	if n.ErrorCode != Cease || (n.ErrorSubcode != AdminShut && n.ErrorSubcode != AdminReset) {
+		return "", false
+	}
+
+	if len(n.Data) == 0 {
+		return "", false
+	}
+
+	l := int(n.Data[0])
+	if l == 0 || l > len(n.Data)-1 {
+		return "", false
+	}
+
+	s := string(n.Data[1 : l+1])
+	if !utf8.ValidString(s) {
+		return "", false
+	}
+
+	return s, true
+}
@@ -0,0 +1,174 @@
+package packet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewShutdownNotification(t *testing.T) {
+	tests := []struct {
+		name          string
+		subcode       uint8
+		communication string
+		wantFail      bool
+		expected      *BGPNotification
+	}{
+		{
+			name:          "Administrative shutdown",
+			subcode:       AdminShut,
+			communication: "maintenance, ticket #42",
+			expected: &BGPNotification{
+				ErrorCode:    Cease,
+				ErrorSubcode: AdminShut,
+				Data:         append([]byte{23}, "maintenance, ticket #42"...),
+			},
+		},
+		{
+			name:          "Administrative reset with UTF-8",
+			subcode:       AdminReset,
+			communication: "Wartungsfenster ✓",
+			expected: &BGPNotification{
+				ErrorCode:    Cease,
+				ErrorSubcode: AdminReset,
+				Data:         append([]byte{19}, "Wartungsfenster ✓"...),
+			},
+		},
+		{
+			name:    "Without communication",
+			subcode: AdminShut,
+			expected: &BGPNotification{
+				ErrorCode:    Cease,
+				ErrorSubcode: AdminShut,
+			},
+		},
+		{
+			name:          "Maximum length",
+			subcode:       AdminShut,
+			communication: strings.Repeat("a", 255),
+			expected: &BGPNotification{
+				ErrorCode:    Cease,
+				ErrorSubcode: AdminShut,
+				Data:         append([]byte{255}, strings.Repeat("a", 255)...),
+			},
+		},
+		{
+			name:          "Too long",
+			subcode:       AdminShut,
+			communication: strings.Repeat("a", 256),
+			wantFail:      true,
+		},
+		{
+			name:          "Invalid UTF-8",
+			subcode:       AdminShut,
+			communication: "\xff\xfe",
+			wantFail:      true,
+		},
+		{
+			name:          "Unsupported subcode",
+			subcode:       ConnectionCollisionResolution,
+			communication: "foo",
+			wantFail:      true,
+		},
+	}
+
+	for _, test := range tests {
+		res, err := NewShutdownNotification(test.subcode, test.communication)
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, res, test.name)
+	}
+}
+
+func TestShutdownCommunication(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *BGPNotification
+		expected string
+		ok       bool
+	}{
+		{
+			name: "Administrative shutdown",
+			input: &BGPNotification{
+				ErrorCode:    Cease,
+				ErrorSubcode: AdminShut,
+				Data:         []byte{3, 'f', 'o', 'o'},
+			},
+			expected: "foo",
+			ok:       true,
+		},
+		{
+			name: "Trailing data is ignored",
+			input: &BGPNotification{
+				ErrorCode:    Cease,
+				ErrorSubcode: AdminReset,
+				Data:         []byte{3, 'f', 'o', 'o', 'b', 'a', 'r'},
+			},
+			expected: "foo",
+			ok:       true,
+		},
+		{
+			name: "No data",
+			input: &BGPNotification{
+				ErrorCode:    Cease,
+				ErrorSubcode: AdminShut,
+			},
+		},
+		{
+			name: "Zero length",
+			input: &BGPNotification{
+				ErrorCode:    Cease,
+				ErrorSubcode: AdminShut,
+				Data:         []byte{0},
+			},
+		},
+		{
+			name: "Length exceeds data",
+			input: &BGPNotification{
+				ErrorCode:    Cease,
+				ErrorSubcode: AdminShut,
+				Data:         []byte{4, 'f', 'o', 'o'},
+			},
+		},
+		{
+			name: "Invalid UTF-8",
+			input: &BGPNotification{
+				ErrorCode:    Cease,
+				ErrorSubcode: AdminShut,
+				Data:         []byte{2, 0xff, 0xfe},
+			},
+		},
+		{
+			name: "Other Cease subcode",
+			input: &BGPNotification{
+				ErrorCode:    Cease,
+				ErrorSubcode: PeerDeconfigured,
+				Data:         []byte{3, 'f', 'o', 'o'},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		res, ok := test.input.ShutdownCommunication()
+		assert.Equal(t, test.ok, ok, test.name)
+		assert.Equal(t, test.expected, res, test.name)
+	}
+}
+
+func TestShutdownNotificationRoundTrip(t *testing.T) {
+	n, err := NewShutdownNotification(AdminShut, "planned maintenance")
+	assert.NoError(t, err)
+
+	msg, err := Decode(bytes.NewBuffer(SerializeNotificationMsg(n)), &DecodeOptions{})
+	assert.NoError(t, err)
+
+	communication, ok := msg.Body.(*BGPNotification).ShutdownCommunication()
+	assert.True(t, ok)
+	assert.Equal(t, "planned maintenance", communication)
+}
@@ -0,0 +1,263 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+// Tunnel Encapsulation sub-TLV types (RFC9012)
+const (
+	TunnelEncapsulationSubTLV  = 1
+	TunnelColorSubTLV          = 4
+	TunnelEgressEndpointSubTLV = 6
+
+	tunnelTLVHeaderLen          = 4
+	tunnelEgressEndpointMinLen  = 6
+	tunnelColorLen              = 8
+	tunnelVXLANEncapsulationLen = 12
+
+	// Sub-TLVs of type 128 and above have a two octet length field
+	tunnelSubTLVExtendedLengthType = 128
+
+	tunnelColorExtCommunityType    = 0x03
+	tunnelColorExtCommunitySubType = 0x0b
+
+	vxlanFlagVNIDValid = 0x80
+	vxlanFlagMACValid  = 0x40
+)
+
+func (pa *PathAttribute) decodeTunnelEncapsulation(buf *bytes.Buffer) error {
+	b := make([]byte, pa.Length)
+	n, err := buf.Read(b)
+	if err != nil {
+		return fmt.Errorf("unable to read %d bytes from buffer: %w", pa.Length, err)
+	}
+	if n != int(pa.Length) {
+		return fmt.Errorf("unable to read %d bytes from buffer, only got %d bytes", pa.Length, n)
+	}
+
+	te, err := deserializeTunnelEncapsulation(b)
+	if err != nil {
+		return fmt.Errorf("unable to decode tunnel encapsulation: %w", err)
+	}
+
+	pa.Value = te
+	return nil
+}
+
+func deserializeTunnelEncapsulation(b []byte) (*types.TunnelEncapsulation, error) {
+	te := make(types.TunnelEncapsulation, 0)
+
+	for len(b) > 0 {
+		if len(b) < tunnelTLVHeaderLen {
+			return nil, fmt.Errorf("tunnel TLV header truncated")
+		}
+
+		tunnelType := uint16(b[0])<<8 | uint16(b[1])
+		length := int(b[2])<<8 | int(b[3])
+		b = b[tunnelTLVHeaderLen:]
+
+		if len(b) < length {
+			return nil, fmt.Errorf("tunnel TLV length %d exceeds remaining %d bytes", length, len(b))
+		}
+
+		t, err := deserializeTunnel(tunnelType, b[:length])
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode tunnel TLV of type %d: %w", tunnelType, err)
+		}
+
+		te = append(te, t)
+		b = b[length:]
+	}
+
+	return &te, nil
+}
+
+func deserializeTunnel(tunnelType uint16, b []byte) (*types.Tunnel, error) {
+	t := &types.Tunnel{
+		Type: tunnelType,
+	}
+
+	for len(b) > 0 {
+		subTLVType := b[0]
+		headerLen := 2
+		if subTLVType >= tunnelSubTLVExtendedLengthType {
+			headerLen = 3
+		}
+
+		if len(b) < headerLen {
+			return nil, fmt.Errorf("sub-TLV header truncated")
+		}
+
+		length := int(b[1])
+		if headerLen == 3 {
+			length = int(b[1])<<8 | int(b[2])
+		}
+		b = b[headerLen:]
+
+		if len(b) < length {
+			return nil, fmt.Errorf("sub-TLV length %d exceeds remaining %d bytes", length, len(b))
+		}
+
+		err := decodeTunnelSubTLV(t, subTLVType, b[:length])
+		if err != nil {
+			return nil, err
+		}
+
+		b = b[length:]
+	}
+
+	return t, nil
+}
+
+func decodeTunnelSubTLV(t *types.Tunnel, subTLVType uint8, v []byte) error {
+	switch subTLVType {
+	case TunnelEgressEndpointSubTLV:
+		return decodeTunnelEgressEndpoint(t, v)
+	case TunnelColorSubTLV:
+		return decodeTunnelColor(t, v)
+	case TunnelEncapsulationSubTLV:
+		if t.Type == types.TunnelTypeVXLAN {
+			return decodeVXLANEncapsulation(t, v)
+		}
+	}
+
+	value := make([]byte, len(v))
+	copy(value, v)
+	t.SubTLVs = append(t.SubTLVs, types.TunnelSubTLV{
+		Type:  subTLVType,
+		Value: value,
+	})
+
+	return nil
+}
+
+func decodeTunnelEgressEndpoint(t *types.Tunnel, v []byte) error {
+	if len(v) < tunnelEgressEndpointMinLen {
+		return fmt.Errorf("tunnel egress endpoint sub-TLV too short: %d bytes", len(v))
+	}
+
+	afi := uint16(v[4])<<8 | uint16(v[5])
+	addr := v[tunnelEgressEndpointMinLen:]
+
+	switch afi {
+	case 0:
+		if len(addr) != 0 {
+			return fmt.Errorf("unexpected address of length %d in tunnel egress endpoint without address family", len(addr))
+		}
+	case AFIIPv4:
+		if len(addr) != IPv4Len {
+			return fmt.Errorf("invalid IPv4 tunnel egress endpoint length %d", len(addr))
+		}
+		t.Endpoint = bnet.IPv4FromBytes(addr).Dedup()
+	case AFIIPv6:
+		if len(addr) != IPv6Len {
+			return fmt.Errorf("invalid IPv6 tunnel egress endpoint length %d", len(addr))
+		}
+		ip, err := bnet.IPFromBytes(addr)
+		if err != nil {
+			return fmt.Errorf("invalid IPv6 tunnel egress endpoint: %w", err)
+		}
+		t.Endpoint = ip.Dedup()
+	default:
+		return fmt.Errorf("unsupported address family %d in tunnel egress endpoint", afi)
+	}
+
+	return nil
+}
+
+func decodeTunnelColor(t *types.Tunnel, v []byte) error {
+	if len(v) != tunnelColorLen {
+		return fmt.Errorf("invalid color sub-TLV length %d", len(v))
+	}
+
+	if v[0] != tunnelColorExtCommunityType || v[1] != tunnelColorExtCommunitySubType {
+		return fmt.Errorf("invalid color extended community type %d/%d", v[0], v[1])
+	}
+
+	t.Colors = append(t.Colors, uint32(v[4])<<24|uint32(v[5])<<16|uint32(v[6])<<8|uint32(v[7]))
+	return nil
+}
+
+func decodeVXLANEncapsulation(t *types.Tunnel, v []byte) error {
+	if len(v) != tunnelVXLANEncapsulationLen {
+		return fmt.Errorf("invalid VXLAN encapsulation sub-TLV length %d", len(v))
+	}
+
+	t.VXLAN = &types.VXLANEncapsulation{
+		VNIDValid: v[0]&vxlanFlagVNIDValid != 0,
+		MACValid:  v[0]&vxlanFlagMACValid != 0,
+		VNID:      uint32(v[1])<<16 | uint32(v[2])<<8 | uint32(v[3]),
+	}
+	copy(t.VXLAN.MAC[:], v[4:10])
+
+	return nil
+}
+
+func (pa *PathAttribute) serializeTunnelEncapsulation(buf *bytes.Buffer) uint16 {
+	te := pa.Value.(*types.TunnelEncapsulation)
+	pa.Optional = true
+	pa.Transitive = true
+
+	tempBuf := bytes.NewBuffer(nil)
+	for _, t := range *te {
+		serializeTunnel(tempBuf, t)
+	}
+
+	return pa.serializeGeneric(tempBuf.Bytes(), buf)
+}
+
+func serializeTunnel(buf *bytes.Buffer, t *types.Tunnel) {
+	subTLVs := bytes.NewBuffer(nil)
+
+	if t.Endpoint != nil {
+		v := make([]byte, tunnelEgressEndpointMinLen)
+		afi := uint16(AFIIPv6)
+		if t.Endpoint.IsIPv4() {
+			afi = AFIIPv4
+		}
+		copy(v[4:], convert.Uint16Byte(afi))
+		serializeTunnelSubTLV(subTLVs, TunnelEgressEndpointSubTLV, append(v, t.Endpoint.Bytes()...))
+	}
+
+	if t.VXLAN != nil {
+		v := make([]byte, tunnelVXLANEncapsulationLen)
+		if t.VXLAN.VNIDValid {
+			v[0] |= vxlanFlagVNIDValid
+		}
+		if t.VXLAN.MACValid {
+			v[0] |= vxlanFlagMACValid
+		}
+		copy(v[1:4], convert.Uint32Byte(t.VXLAN.VNID)[1:])
+		copy(v[4:10], t.VXLAN.MAC[:])
+		serializeTunnelSubTLV(subTLVs, TunnelEncapsulationSubTLV, v)
+	}
+
+	for _, c := range t.Colors {
+		v := []byte{tunnelColorExtCommunityType, tunnelColorExtCommunitySubType, 0, 0}
+		serializeTunnelSubTLV(subTLVs, TunnelColorSubTLV, append(v, convert.Uint32Byte(c)...))
+	}
+
+	for _, s := range t.SubTLVs {
+		serializeTunnelSubTLV(subTLVs, s.Type, s.Value)
+	}
+
+	buf.Write(convert.Uint16Byte(t.Type))
+	buf.Write(convert.Uint16Byte(uint16(subTLVs.Len())))
+	buf.Write(subTLVs.Bytes())
+}
+
+func serializeTunnelSubTLV(buf *bytes.Buffer, subTLVType uint8, v []byte) {
+	buf.WriteByte(subTLVType)
+	if subTLVType >= tunnelSubTLVExtendedLengthType {
+		buf.Write(convert.Uint16Byte(uint16(len(v))))
+	} else {
+		buf.WriteByte(uint8(len(v)))
+	}
+
+	buf.Write(v)
+}
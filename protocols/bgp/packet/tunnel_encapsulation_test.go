@@ -0,0 +1,152 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTunnelEncapsulationRoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      []byte
+		expected   *types.TunnelEncapsulation
+		decodeOnly bool
+	}{
+		{
+			name: "VXLAN with IPv4 endpoint, VNI and color",
+			input: []byte{
+				0xc0, 23, 40, // Optional, transitive, Tunnel Encapsulation, Length
+				0, 8, 0, 36, // VXLAN tunnel TLV
+				6, 10, 0, 0, 0, 0, 0, 1, 192, 0, 2, 1, // Tunnel Egress Endpoint 192.0.2.1
+				1, 12, 0x80, 0, 0, 100, 0, 0, 0, 0, 0, 0, 0, 0, // Encapsulation, VNI 100
+				4, 8, 3, 0x0b, 0, 0, 0, 0, 0, 10, // Color 10
+			},
+			expected: &types.TunnelEncapsulation{
+				{
+					Type:     types.TunnelTypeVXLAN,
+					Endpoint: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+					Colors:   []uint32{10},
+					VXLAN: &types.VXLANEncapsulation{
+						VNIDValid: true,
+						VNID:      100,
+					},
+				},
+			},
+		},
+		{
+			name: "VXLAN with IPv6 endpoint and MAC, unknown extended length sub-TLV",
+			input: []byte{
+				0xc0, 23, 52, // Optional, transitive, Tunnel Encapsulation, Length
+				0, 8, 0, 48, // VXLAN tunnel TLV
+				6, 22, 0, 0, 0, 0, 0, 2, 0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, // Tunnel Egress Endpoint 2001:db8::1
+				1, 12, 0x40, 0, 0, 0, 1, 2, 3, 4, 5, 6, 0, 0, // Encapsulation, MAC 01:02:03:04:05:06
+				200, 0, 7, 1, 2, 3, 4, 5, 6, 7, // Unknown sub-TLV with two octet length
+			},
+			expected: &types.TunnelEncapsulation{
+				{
+					Type:     types.TunnelTypeVXLAN,
+					Endpoint: bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1).Ptr(),
+					VXLAN: &types.VXLANEncapsulation{
+						MACValid: true,
+						MAC:      [6]byte{1, 2, 3, 4, 5, 6},
+					},
+					SubTLVs: []types.TunnelSubTLV{
+						{
+							Type:  200,
+							Value: []byte{1, 2, 3, 4, 5, 6, 7},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Two tunnels, endpoint without address",
+			input: []byte{
+				0xc0, 23, 26, // Optional, transitive, Tunnel Encapsulation, Length
+				0, 8, 0, 8, // VXLAN tunnel TLV
+				6, 6, 0, 0, 0, 0, 0, 0, // Tunnel Egress Endpoint without address
+				0, 19, 0, 10, // GENEVE tunnel TLV
+				4, 8, 3, 0x0b, 0, 0, 0, 0, 0, 20, // Color 20
+			},
+			expected: &types.TunnelEncapsulation{
+				{
+					Type: types.TunnelTypeVXLAN,
+				},
+				{
+					Type:   types.TunnelTypeGENEVE,
+					Colors: []uint32{20},
+				},
+			},
+			decodeOnly: true, // An endpoint sub-TLV without address is not serialized again
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pa, _, err := decodePathAttr(bytes.NewBuffer(test.input), &DecodeOptions{})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assert.Equal(t, uint8(TunnelEncapsulationAttr), pa.TypeCode)
+			assert.Equal(t, test.expected, pa.Value)
+
+			if test.decodeOnly {
+				return
+			}
+
+			buf := bytes.NewBuffer(nil)
+			(&PathAttribute{
+				TypeCode: TunnelEncapsulationAttr,
+				Value:    pa.Value,
+			}).Serialize(buf, &EncodeOptions{})
+			assert.Equal(t, test.input, buf.Bytes())
+			assert.Equal(t, uint16(len(test.input)), test.expected.WireLength())
+		})
+	}
+}
+
+func TestDecodeTunnelEncapsulationMalformed(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+	}{
+		{
+			name:  "Truncated tunnel TLV header",
+			input: []byte{0, 8, 0},
+		},
+		{
+			name:  "Tunnel TLV length exceeding attribute",
+			input: []byte{0, 8, 0, 20, 6, 10, 0, 0, 0, 0, 0, 1, 192, 0, 2, 1},
+		},
+		{
+			name:  "Sub-TLV length exceeding tunnel TLV",
+			input: []byte{0, 8, 0, 4, 6, 10, 0, 0},
+		},
+		{
+			name:  "Invalid IPv4 endpoint length",
+			input: []byte{0, 8, 0, 11, 6, 9, 0, 0, 0, 0, 0, 1, 192, 0, 2},
+		},
+		{
+			name:  "Invalid color type",
+			input: []byte{0, 8, 0, 10, 4, 8, 0, 2, 0, 0, 0, 0, 0, 10},
+		},
+		{
+			name:  "Invalid VXLAN encapsulation length",
+			input: []byte{0, 8, 0, 6, 1, 4, 0x80, 0, 0, 100},
+		},
+	}
+
+	for _, test := range tests {
+		pa := &PathAttribute{
+			Length: uint16(len(test.input)),
+		}
+
+		err := pa.decodeTunnelEncapsulation(bytes.NewBuffer(test.input))
+		assert.Error(t, err, test.name)
+	}
+}
@@ -3,6 +3,7 @@ package packet
 import (
 	"bytes"
 	"fmt"
+	"sort"
 
 	"github.com/bio-routing/tflow2/convert"
 )
@@ -18,7 +19,7 @@ type BGPUpdate struct {
 
 // SerializeUpdate serializes an BGPUpdate to wire format
 func (b *BGPUpdate) SerializeUpdate(opt *EncodeOptions) ([]byte, error) {
-	budget := MaxLen - MinLen
+	budget := opt.MaxMessageLen() - MinLen
 	buf := bytes.NewBuffer(nil)
 
 	withdrawBuf := bytes.NewBuffer(nil)
@@ -30,7 +31,7 @@ func (b *BGPUpdate) SerializeUpdate(opt *EncodeOptions) ([]byte, error) {
 	}
 
 	pathAttributesBuf := bytes.NewBuffer(nil)
-	for pa := b.PathAttributes; pa != nil; pa = pa.Next {
+	for _, pa := range b.sortedPathAttributes() {
 		paLen := int(pa.Serialize(pathAttributesBuf, opt))
 		budget -= paLen
 		if budget < 0 {
@@ -57,7 +58,7 @@ func (b *BGPUpdate) SerializeUpdate(opt *EncodeOptions) ([]byte, error) {
 	}
 
 	totalLength := 2 + withdrawnRoutesLen + totalPathAttributesLen + 2 + nlriBuf.Len() + 19
-	if totalLength > 4096 {
+	if totalLength > opt.MaxMessageLen() {
 		return nil, fmt.Errorf("update too long: %d bytes", totalLength)
 	}
 
@@ -74,6 +75,22 @@ func (b *BGPUpdate) SerializeUpdate(opt *EncodeOptions) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// sortedPathAttributes returns the path attributes of b in ascending order of their type code (RFC4271 section 5)
+func (b *BGPUpdate) sortedPathAttributes() []*PathAttribute {
+	ret := make([]*PathAttribute, 0, 16)
+	for pa := b.PathAttributes; pa != nil; pa = pa.Next {
+		ret = append(ret, pa)
+	}
+
+	sort.SliceStable(ret, func(i, j int) bool {
+		return ret[i].TypeCode < ret[j].TypeCode
+	})
+
+	return ret
+}
+
+// IsEndOfRIBMarker checks if b is the End-of-RIB marker of IPv4 unicast, an UPDATE without any withdrawn routes, path
+// attributes and NLRI (RFC4724 section 2)
 func (b *BGPUpdate) IsEndOfRIBMarker() bool {
-	return b.WithdrawnRoutesLen == 0 && b.NLRI == nil
+	return b.WithdrawnRoutesLen == 0 && b.NLRI == nil && b.PathAttributes == nil
 }
@@ -0,0 +1,145 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+// updateOfLength creates an update that serializes to exactly l bytes
+func updateOfLength(l int) *BGPUpdate {
+	// Header, withdrawn routes length, total path attribute length and the attributes header
+	overhead := MinLen + 2 + 2 + 4
+
+	return &BGPUpdate{
+		PathAttributes: &PathAttribute{
+			Optional:       true,
+			Transitive:     true,
+			ExtendedLength: true,
+			TypeCode:       200,
+			Value:          make([]byte, l-overhead),
+		},
+	}
+}
+
+func TestSerializeUpdateMaxLen(t *testing.T) {
+	tests := []struct {
+		name            string
+		length          int
+		extendedMessage bool
+		wantFail        bool
+	}{
+		{
+			name:   "maximum length",
+			length: MaxLen,
+		},
+		{
+			name:     "exceeding maximum length",
+			length:   MaxLen + 1,
+			wantFail: true,
+		},
+		{
+			name:            "extended message",
+			length:          MaxLen + 1,
+			extendedMessage: true,
+		},
+		{
+			name:            "extended message of maximum length",
+			length:          MaxExtendedLen,
+			extendedMessage: true,
+		},
+		{
+			name:            "exceeding maximum extended message length",
+			length:          MaxExtendedLen + 1,
+			extendedMessage: true,
+			wantFail:        true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := updateOfLength(test.length).SerializeUpdate(&EncodeOptions{
+				ExtendedMessage: test.extendedMessage,
+			})
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.length, len(res))
+
+			msg, err := Decode(bytes.NewBuffer(res), &DecodeOptions{
+				ExtendedMessage: test.extendedMessage,
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, uint16(test.length), msg.Header.Length)
+
+			_, err = Decode(bytes.NewBuffer(res), &DecodeOptions{})
+			assert.Equal(t, test.length > MaxLen, err != nil, "decoding without extended message support")
+		})
+	}
+}
+
+// unorderedUpdate returns an UPDATE with path attributes, communities and large communities in non canonical order
+func unorderedUpdate() []byte {
+	pathAttrs := []byte{
+		0xc0, CommunitiesAttr, 8, 0xfd, 0xe8, 0, 100, 0xfd, 0xe8, 0, 1,
+		0x40, NextHopAttr, 4, 10, 0, 0, 1,
+		0x40, ASPathAttr, 6, 2, 2, 0xfd, 0xe8, 0xfd, 0xe9,
+		0x40, OriginAttr, 1, 0,
+		0xc0, LargeCommunitiesAttr, 24,
+		0, 0, 0xfd, 0xe8, 0, 0, 0, 2, 0, 0, 0, 0,
+		0, 0, 0xfd, 0xe8, 0, 0, 0, 1, 0, 0, 0, 5,
+		0x80, MEDAttr, 4, 0, 0, 0, 10,
+	}
+	nlri := []byte{8, 10}
+
+	buf := bytes.NewBuffer(nil)
+	serializeHeader(buf, uint16(MinLen+2+2+len(pathAttrs)+len(nlri)), UpdateMsg)
+	buf.Write([]byte{0, 0, 0, uint8(len(pathAttrs))})
+	buf.Write(pathAttrs)
+	buf.Write(nlri)
+
+	return buf.Bytes()
+}
+
+func TestSerializeUpdateCanonicalOrder(t *testing.T) {
+	msg, err := Decode(bytes.NewBuffer(unorderedUpdate()), &DecodeOptions{})
+	assert.NoError(t, err)
+
+	update := msg.Body.(*BGPUpdate)
+	first, err := update.SerializeUpdate(&EncodeOptions{})
+	assert.NoError(t, err)
+
+	msg, err = Decode(bytes.NewBuffer(first), &DecodeOptions{})
+	assert.NoError(t, err)
+
+	typeCodes := make([]uint8, 0)
+	for pa := msg.Body.(*BGPUpdate).PathAttributes; pa != nil; pa = pa.Next {
+		typeCodes = append(typeCodes, pa.TypeCode)
+
+		switch pa.TypeCode {
+		case CommunitiesAttr:
+			assert.Equal(t, &types.Communities{0xfde80001, 0xfde80064}, pa.Value)
+		case LargeCommunitiesAttr:
+			assert.Equal(t, &types.LargeCommunities{
+				{GlobalAdministrator: 65000, DataPart1: 1, DataPart2: 5},
+				{GlobalAdministrator: 65000, DataPart1: 2, DataPart2: 0},
+			}, pa.Value)
+		}
+	}
+	assert.Equal(t, []uint8{OriginAttr, ASPathAttr, NextHopAttr, MEDAttr, CommunitiesAttr, LargeCommunitiesAttr}, typeCodes)
+
+	second, err := msg.Body.(*BGPUpdate).SerializeUpdate(&EncodeOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, first, second, "re-encoding a decoded UPDATE must be byte stable")
+
+	for pa := update.PathAttributes; pa != nil; pa = pa.Next {
+		if pa.TypeCode == CommunitiesAttr {
+			assert.Equal(t, &types.Communities{0xfde80064, 0xfde80001}, pa.Value, "serializing must not modify the communities")
+		}
+	}
+}
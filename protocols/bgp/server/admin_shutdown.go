@@ -0,0 +1,79 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/bio-routing/bio-rd/util/log"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+// adminShutdowns holds the addresses of peers shut down via the API. It outlives the peers, so a shutdown survives
+// configuration reloads recreating a peer.
+type adminShutdowns struct {
+	mu    sync.Mutex
+	addrs map[bnet.IP]struct{}
+}
+
+func (a *adminShutdowns) add(addr *bnet.IP) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.addrs == nil {
+		a.addrs = make(map[bnet.IP]struct{})
+	}
+
+	a.addrs[*addr] = struct{}{}
+}
+
+func (a *adminShutdowns) remove(addr *bnet.IP) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	delete(a.addrs, *addr)
+}
+
+func (a *adminShutdowns) contains(addr *bnet.IP) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	_, found := a.addrs[*addr]
+	return found
+}
+
+// EnablePeer brings a peer administratively shut down by ShutdownPeer or kept down by a prefix limit up again
+func (b *bgpServer) EnablePeer(addr *bnet.IP) error {
+	p := b.peers.get(addr)
+	if p == nil {
+		return fmt.Errorf("peer %q not found", addr.String())
+	}
+
+	b.adminShut.remove(addr)
+	p.resetPrefixLimitRestarts()
+	if !p.adminDown.Swap(false) {
+		return nil
+	}
+
+	log.WithFields(log.Fields{
+		"peer": addr.String(),
+	}).Info("Administratively enabling BGP session")
+	p.enable()
+
+	return nil
+}
+
+// enable starts the sessions of a peer that has been administratively shut down. Passive peers wait for the next
+// incoming connection instead.
+func (p *peer) enable() {
+	if p.passive {
+		return
+	}
+
+	p.fsmsMu.Lock()
+	defer p.fsmsMu.Unlock()
+
+	for _, fsm := range p.fsms {
+		go fsm.activate()
+	}
+}
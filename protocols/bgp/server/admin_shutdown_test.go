@@ -0,0 +1,87 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+	"github.com/stretchr/testify/assert"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+func TestAdminShutdownSurvivesReload(t *testing.T) {
+	v, _ := vrf.New("admin_shutdown", 169)
+	defer vrf.GetGlobalRegistry().UnregisterVRF(v)
+	s := newBGPServer(0, nil)
+
+	peerAddr := bnet.IPv4FromOctets(10, 0, 0, 1).Dedup()
+	cfg := PeerConfig{
+		PeerAddress:  peerAddr,
+		LocalAddress: bnet.IPv4FromOctets(10, 0, 0, 0).Dedup(),
+		LocalAS:      65000,
+		PeerAS:       65001,
+		Passive:      true,
+		VRF:          v,
+		IPv4: &AddressFamilyConfig{
+			ImportFilterChain: filter.NewAcceptAllFilterChain(),
+			ExportFilterChain: filter.NewAcceptAllFilterChain(),
+		},
+	}
+
+	// Passive peers have no FSM before a connection is accepted
+	start := func() *peer {
+		assert.NoError(t, s.AddPeer(cfg))
+		return s.peers.get(peerAddr)
+	}
+
+	p := start()
+	assert.False(t, p.adminDown.Load())
+	assert.NoError(t, s.ShutdownPeer(peerAddr, "maintenance", false))
+	assert.True(t, p.adminDown.Load())
+
+	// A reload not changing the peer only replaces its filters
+	assert.False(t, s.GetPeerConfig(peerAddr).NeedsRestart(&cfg))
+	assert.NoError(t, s.ReplaceImportFilterChain(peerAddr, cfg.IPv4.ImportFilterChain))
+	assert.NoError(t, s.ReplaceExportFilterChain(peerAddr, cfg.IPv4.ExportFilterChain))
+	assert.Same(t, p, s.peers.get(peerAddr))
+	assert.True(t, p.adminDown.Load(), "peer must stay down after reload")
+
+	// A reload recreating the peer
+	s.DisposePeer(peerAddr)
+	p = start()
+	assert.True(t, p.adminDown.Load(), "recreated peer must stay down")
+
+	assert.NoError(t, s.EnablePeer(peerAddr))
+	assert.False(t, p.adminDown.Load())
+
+	s.DisposePeer(peerAddr)
+	p = start()
+	assert.False(t, p.adminDown.Load(), "enabled peer must come up after reload")
+
+	assert.Error(t, s.EnablePeer(bnet.IPv4FromOctets(10, 0, 0, 2).Ptr()), "unknown peer")
+}
+
+func TestEnablePeer(t *testing.T) {
+	p := &peer{
+		addr: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+	}
+	fsm := &FSM{
+		peer:    p,
+		eventCh: make(chan int),
+	}
+	p.fsms = []*FSM{fsm}
+	p.adminDown.Store(true)
+
+	s := &bgpServer{
+		peers: testPeerManager(map[bnet.IP]*peer{
+			*p.addr: p,
+		}),
+	}
+	s.adminShut.add(p.addr)
+
+	assert.NoError(t, s.EnablePeer(p.addr))
+	assert.Equal(t, AutomaticStart, <-fsm.eventCh, "session must be started")
+	assert.False(t, p.adminDown.Load())
+	assert.False(t, s.adminShut.contains(p.addr))
+}
@@ -32,7 +32,7 @@ func (s *BGPAPIServer) DumpRIBIn(in *api.DumpRIBRequest, stream api.BgpService_D
 		return fmt.Errorf("unable to get AdjRIBIn")
 	}
 
-	for _, r := range r.Dump() {
+	for _, r := range r.Snapshot() {
 		x := r.ToProto()
 		err := stream.Send(x)
 		if err != nil {
@@ -50,7 +50,7 @@ func (s *BGPAPIServer) DumpRIBOut(in *api.DumpRIBRequest, stream api.BgpService_
 		return fmt.Errorf("unable to get AdjRIBOut")
 	}
 
-	for _, r := range r.Dump() {
+	for _, r := range r.Snapshot() {
 		x := r.ToProto()
 		err := stream.Send(x)
 		if err != nil {
@@ -60,3 +60,123 @@ func (s *BGPAPIServer) DumpRIBOut(in *api.DumpRIBRequest, stream api.BgpService_
 
 	return nil
 }
+
+// SoftRefreshIn refreshes the routes received from a peer for a given AFI/SAFI and reports the method used
+func (s *BGPAPIServer) SoftRefreshIn(ctx context.Context, in *api.SoftRefreshInRequest) (*api.SoftRefreshInResponse, error) {
+	method, err := s.srv.SoftRefreshIn(bnet.IPFromProtoIP(in.Peer).Ptr(), uint16(in.Afi), uint8(in.Safi))
+	if err != nil {
+		return nil, fmt.Errorf("soft refresh failed: %w", err)
+	}
+
+	ret := &api.SoftRefreshInResponse{}
+	switch method {
+	case SoftRefreshEnhancedRouteRefresh:
+		ret.Method = api.SoftRefreshInResponse_EnhancedRouteRefresh
+	case SoftRefreshRouteRefresh:
+		ret.Method = api.SoftRefreshInResponse_RouteRefresh
+	case SoftRefreshStoredRoutes:
+		ret.Method = api.SoftRefreshInResponse_StoredRoutes
+	}
+
+	return ret, nil
+}
+
+// GetSession gets details on the session with a peer
+func (s *BGPAPIServer) GetSession(ctx context.Context, in *api.GetSessionRequest) (*api.GetSessionResponse, error) {
+	d, err := s.srv.GetSession(bnet.IPFromProtoIP(in.Peer).Ptr())
+	if err != nil {
+		return nil, fmt.Errorf("unable to get session: %w", err)
+	}
+
+	return &api.GetSessionResponse{
+		Session: sessionDetailToProto(d),
+	}, nil
+}
+
+// ShutdownPeer administratively shuts down or resets the session with a peer
+func (s *BGPAPIServer) ShutdownPeer(ctx context.Context, in *api.ShutdownPeerRequest) (*api.ShutdownPeerResponse, error) {
+	err := s.srv.ShutdownPeer(bnet.IPFromProtoIP(in.Peer).Ptr(), in.Communication, in.Reset_)
+	if err != nil {
+		return nil, fmt.Errorf("shutdown failed: %w", err)
+	}
+
+	return &api.ShutdownPeerResponse{}, nil
+}
+
+// EnablePeer brings a peer administratively shut down by ShutdownPeer up again
+func (s *BGPAPIServer) EnablePeer(ctx context.Context, in *api.EnablePeerRequest) (*api.EnablePeerResponse, error) {
+	err := s.srv.EnablePeer(bnet.IPFromProtoIP(in.Peer).Ptr())
+	if err != nil {
+		return nil, fmt.Errorf("enable failed: %w", err)
+	}
+
+	return &api.EnablePeerResponse{}, nil
+}
+
+// EvaluatePolicy runs the paths of a prefix through the import or export policy of a peer and reports the decisions
+// and the modified paths. Nothing is applied or advertised.
+func (s *BGPAPIServer) EvaluatePolicy(ctx context.Context, in *api.EvaluatePolicyRequest) (*api.EvaluatePolicyResponse, error) {
+	dir := PolicyDirectionIn
+	if in.Direction == api.EvaluatePolicyRequest_Out {
+		dir = PolicyDirectionOut
+	}
+
+	evaluations, err := s.srv.EvaluatePolicy(bnet.IPFromProtoIP(in.Peer).Ptr(), uint16(in.Afi), uint8(in.Safi), dir, bnet.NewPrefixFromProtoPrefix(in.Pfx))
+	if err != nil {
+		return nil, fmt.Errorf("policy evaluation failed: %w", err)
+	}
+
+	ret := &api.EvaluatePolicyResponse{
+		Evaluations: make([]*api.PolicyEvaluation, 0, len(evaluations)),
+	}
+
+	for _, e := range evaluations {
+		pe := &api.PolicyEvaluation{
+			Path:     e.Path.ToProto(),
+			Decision: api.PolicyEvaluation_Permit,
+		}
+
+		if e.Reject {
+			pe.Decision = api.PolicyEvaluation_Deny
+			pe.RejectReason = e.RejectReason
+		} else {
+			pe.Result = e.Result.ToProto()
+		}
+
+		ret.Evaluations = append(ret.Evaluations, pe)
+	}
+
+	return ret, nil
+}
+
+func sessionDetailToProto(d *SessionDetail) *api.SessionDetail {
+	ret := &api.SessionDetail{
+		LocalAsn:                  d.LocalASN,
+		PeerAsn:                   d.PeerASN,
+		Status:                    api.Session_State(d.State),
+		LocalRouterId:             d.LocalRouterID,
+		RemoteRouterId:            d.RemoteRouterID,
+		AdvertisedHoldTimeSeconds: uint32(d.AdvertisedHoldTime.Seconds()),
+		NegotiatedHoldTimeSeconds: uint32(d.NegotiatedHoldTime.Seconds()),
+		AddressFamilies:           make([]*api.AddressFamily, 0, len(d.AddressFamilies)),
+		UptimeSeconds:             uint64(d.Uptime.Seconds()),
+		LastError:                 d.LastError,
+	}
+
+	if d.LocalAddress != nil {
+		ret.LocalAddress = d.LocalAddress.ToProto()
+	}
+
+	if d.NeighborAddress != nil {
+		ret.NeighborAddress = d.NeighborAddress.ToProto()
+	}
+
+	for _, af := range d.AddressFamilies {
+		ret.AddressFamilies = append(ret.AddressFamilies, &api.AddressFamily{
+			Afi:  uint32(af.AFI),
+			Safi: uint32(af.SAFI),
+		})
+	}
+
+	return ret
+}
@@ -1,8 +1,10 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"net"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +17,9 @@ import (
 	"github.com/bio-routing/bio-rd/routingtable/adjRIBIn"
 	"github.com/bio-routing/bio-rd/routingtable/adjRIBOut"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/filter/actions"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	btime "github.com/bio-routing/bio-rd/util/time"
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/test/bufconn"
@@ -42,9 +47,7 @@ func TestDumpRIBInOut(t *testing.T) {
 			name: "Test #0: Non existent peer",
 			apisrv: &BGPAPIServer{
 				srv: &bgpServer{
-					peers: &peerManager{
-						peers: map[bnet.IP]*peer{},
-					},
+					peers: testPeerManager(map[bnet.IP]*peer{}),
 				},
 			},
 			addRoutes: []*route.Route{},
@@ -60,20 +63,19 @@ func TestDumpRIBInOut(t *testing.T) {
 			name: "Test #1: No routes given",
 			apisrv: &BGPAPIServer{
 				srv: &bgpServer{
-					peers: &peerManager{
-						peers: map[bnet.IP]*peer{
-							bnet.IPv4FromOctets(10, 0, 0, 0): {
-								fsms: []*FSM{
-									0: {
-										ipv4Unicast: &fsmAddressFamily{
-											adjRIBIn:  adjRIBIn.New(filter.NewAcceptAllFilterChain(), nil, sessionAttrs),
-											adjRIBOut: adjRIBOut.New(nil, routingtable.SessionAttrs{Type: route.BGPPathType}, filter.NewAcceptAllFilterChain()),
-										},
+					peers: testPeerManager(map[bnet.IP]*peer{
+						bnet.IPv4FromOctets(10, 0, 0, 0): {
+							fsms: []*FSM{
+								0: {
+									ipv4Unicast: &fsmAddressFamily{
+										safi:      packet.SAFIUnicast,
+										adjRIBIn:  adjRIBIn.New(filter.NewAcceptAllFilterChain(), nil, sessionAttrs),
+										adjRIBOut: adjRIBOut.New(nil, routingtable.SessionAttrs{Type: route.BGPPathType}, filter.NewAcceptAllFilterChain()),
 									},
 								},
 							},
 						},
-					},
+					}),
 				},
 			},
 			addRoutes: []*route.Route{},
@@ -89,21 +91,20 @@ func TestDumpRIBInOut(t *testing.T) {
 			name: "Test #2: One simple routes given",
 			apisrv: &BGPAPIServer{
 				srv: &bgpServer{
-					peers: &peerManager{
-						peers: map[bnet.IP]*peer{
-							bnet.IPv4FromOctets(10, 0, 0, 0): {
-								addr: bnet.IPv4(123).Ptr(),
-								fsms: []*FSM{
-									0: {
-										ipv4Unicast: &fsmAddressFamily{
-											adjRIBIn:  adjRIBIn.New(filter.NewAcceptAllFilterChain(), nil, sessionAttrs),
-											adjRIBOut: adjRIBOut.New(nil, routingtable.SessionAttrs{Type: route.BGPPathType, RouteServerClient: true, PeerIP: bnet.IPv4(0).Ptr()}, filter.NewAcceptAllFilterChain()),
-										},
+					peers: testPeerManager(map[bnet.IP]*peer{
+						bnet.IPv4FromOctets(10, 0, 0, 0): {
+							addr: bnet.IPv4(123).Ptr(),
+							fsms: []*FSM{
+								0: {
+									ipv4Unicast: &fsmAddressFamily{
+										safi:      packet.SAFIUnicast,
+										adjRIBIn:  adjRIBIn.New(filter.NewAcceptAllFilterChain(), nil, sessionAttrs),
+										adjRIBOut: adjRIBOut.New(nil, routingtable.SessionAttrs{Type: route.BGPPathType, RouteServerClient: true, PeerIP: bnet.IPv4(0).Ptr()}, filter.NewAcceptAllFilterChain()),
 									},
 								},
 							},
 						},
-					},
+					}),
 				},
 			},
 			addRoutes: []*route.Route{
@@ -149,21 +150,20 @@ func TestDumpRIBInOut(t *testing.T) {
 			name: "Test #3: One complex route given",
 			apisrv: &BGPAPIServer{
 				srv: &bgpServer{
-					peers: &peerManager{
-						peers: map[bnet.IP]*peer{
-							bnet.IPv4FromOctets(10, 0, 0, 0): {
-								addr: bnet.IPv4(123).Ptr(),
-								fsms: []*FSM{
-									0: {
-										ipv4Unicast: &fsmAddressFamily{
-											adjRIBIn:  adjRIBIn.New(filter.NewAcceptAllFilterChain(), routingtable.NewContributingASNs(), sessionAttrs),
-											adjRIBOut: adjRIBOut.New(nil, routingtable.SessionAttrs{Type: route.BGPPathType, RouteServerClient: true, PeerIP: bnet.IPv4(123).Ptr()}, filter.NewAcceptAllFilterChain()),
-										},
+					peers: testPeerManager(map[bnet.IP]*peer{
+						bnet.IPv4FromOctets(10, 0, 0, 0): {
+							addr: bnet.IPv4(123).Ptr(),
+							fsms: []*FSM{
+								0: {
+									ipv4Unicast: &fsmAddressFamily{
+										safi:      packet.SAFIUnicast,
+										adjRIBIn:  adjRIBIn.New(filter.NewAcceptAllFilterChain(), routingtable.NewContributingASNs(), sessionAttrs),
+										adjRIBOut: adjRIBOut.New(nil, routingtable.SessionAttrs{Type: route.BGPPathType, RouteServerClient: true, PeerIP: bnet.IPv4(123).Ptr()}, filter.NewAcceptAllFilterChain()),
 									},
 								},
 							},
 						},
-					},
+					}),
 				},
 			},
 			addRoutes: []*route.Route{
@@ -258,7 +258,7 @@ func TestDumpRIBInOut(t *testing.T) {
 	for _, test := range tests {
 		for _, r := range test.addRoutes {
 			for _, p := range r.Paths() {
-				test.apisrv.srv.(*bgpServer).peers.peers[bnet.IPv4FromOctets(10, 0, 0, 0)].fsms[0].ipv4Unicast.adjRIBIn.AddPath(r.Prefix(), p)
+				test.apisrv.srv.(*bgpServer).peers.get(bnet.IPv4FromOctets(10, 0, 0, 0).Ptr()).fsms[0].ipv4Unicast.adjRIBIn.AddPath(r.Prefix(), p)
 			}
 		}
 
@@ -313,7 +313,7 @@ func TestDumpRIBInOut(t *testing.T) {
 	for _, test := range tests {
 		for _, r := range test.addRoutes {
 			for _, p := range r.Paths() {
-				test.apisrv.srv.(*bgpServer).peers.peers[bnet.IPv4FromOctets(10, 0, 0, 0)].fsms[0].ipv4Unicast.adjRIBOut.AddPath(r.Prefix(), p)
+				test.apisrv.srv.(*bgpServer).peers.get(bnet.IPv4FromOctets(10, 0, 0, 0).Ptr()).fsms[0].ipv4Unicast.adjRIBOut.AddPath(r.Prefix(), p)
 			}
 		}
 
@@ -364,3 +364,537 @@ func TestDumpRIBInOut(t *testing.T) {
 		assert.Equal(t, expected, results, test.name)
 	}
 }
+
+// reapplyMockAdjRIBIn records re-applications of the import filter chain
+type reapplyMockAdjRIBIn struct {
+	*routingtable.RTMockClient
+	reapplied bool
+}
+
+func (m *reapplyMockAdjRIBIn) ReapplyFilterChain() {
+	m.reapplied = true
+}
+
+func TestSoftRefreshIn(t *testing.T) {
+	tests := []struct {
+		name                 string
+		established          bool
+		routeRefresh         bool
+		enhancedRouteRefresh bool
+		expected             api.SoftRefreshInResponse_Method
+		expectRouteRefresh   bool
+		expectReapply        bool
+		wantFail             bool
+	}{
+		{
+			name:                 "Enhanced Route Refresh",
+			established:          true,
+			routeRefresh:         true,
+			enhancedRouteRefresh: true,
+			expected:             api.SoftRefreshInResponse_EnhancedRouteRefresh,
+			expectRouteRefresh:   true,
+		},
+		{
+			name:               "Route Refresh",
+			established:        true,
+			routeRefresh:       true,
+			expected:           api.SoftRefreshInResponse_RouteRefresh,
+			expectRouteRefresh: true,
+		},
+		{
+			name:          "Stored routes",
+			established:   true,
+			expected:      api.SoftRefreshInResponse_StoredRoutes,
+			expectReapply: true,
+		},
+		{
+			name:         "Session not established",
+			routeRefresh: true,
+			wantFail:     true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			con := &halfCloseConn{}
+			rib := &reapplyMockAdjRIBIn{
+				RTMockClient: routingtable.NewRTMockClient(),
+			}
+
+			fsm := &FSM{
+				con:                  con,
+				routeRefresh:         test.routeRefresh,
+				enhancedRouteRefresh: test.enhancedRouteRefresh,
+			}
+			fsm.state = newIdleState(fsm)
+			if test.established {
+				fsm.state = newEstablishedState(fsm)
+			}
+
+			fsm.ipv4Unicast = &fsmAddressFamily{
+				afi:      packet.AFIIPv4,
+				safi:     packet.SAFIUnicast,
+				fsm:      fsm,
+				adjRIBIn: rib,
+			}
+
+			apisrv := &BGPAPIServer{
+				srv: &bgpServer{
+					peers: testPeerManager(map[bnet.IP]*peer{
+						bnet.IPv4FromOctets(10, 0, 0, 0): {
+							fsms: []*FSM{fsm},
+						},
+					}),
+				},
+			}
+
+			res, err := apisrv.SoftRefreshIn(context.Background(), &api.SoftRefreshInRequest{
+				Peer: bnet.IPv4FromOctets(10, 0, 0, 0).ToProto(),
+				Afi:  packet.AFIIPv4,
+				Safi: packet.SAFIUnicast,
+			})
+			if test.wantFail {
+				assert.Error(t, err)
+				assert.Empty(t, con.received.Bytes())
+				assert.False(t, rib.reapplied)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, res.Method)
+			assert.Equal(t, test.expectReapply, rib.reapplied)
+
+			if !test.expectRouteRefresh {
+				assert.Empty(t, con.received.Bytes())
+				return
+			}
+
+			expected := packet.SerializeRouteRefreshMsg(&packet.BGPRouteRefresh{
+				AFI:     packet.AFIIPv4,
+				SAFI:    packet.SAFIUnicast,
+				Subtype: packet.RouteRefreshNormal,
+			})
+			assert.Equal(t, expected, con.received.Bytes())
+		})
+	}
+}
+
+func TestGetSession(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	p := &peer{
+		addr:                        bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+		localAddr:                   bnet.IPv4FromOctets(10, 0, 0, 0).Ptr(),
+		peerASN:                     65001,
+		localASN:                    65000,
+		routerID:                    100,
+		holdTime:                    90 * time.Second,
+		ipv4MultiProtocolAdvertised: true,
+	}
+
+	fsm := &FSM{
+		peer:            p,
+		clock:           clock,
+		neighborID:      200,
+		holdTime:        30 * time.Second,
+		establishedTime: time.Unix(900, 0),
+		lastError:       "Hold timer expired",
+		ipv4Unicast: &fsmAddressFamily{
+			afi:           packet.AFIIPv4,
+			safi:          packet.SAFIUnicast,
+			multiProtocol: true,
+		},
+		ipv6Unicast: &fsmAddressFamily{
+			afi:  packet.AFIIPv6,
+			safi: packet.SAFIUnicast,
+		},
+	}
+	fsm.state = newEstablishedState(fsm)
+	p.fsms = []*FSM{fsm}
+
+	apisrv := &BGPAPIServer{
+		srv: &bgpServer{
+			peers: testPeerManager(map[bnet.IP]*peer{
+				*p.addr: p,
+			}),
+		},
+	}
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	api.RegisterBgpServiceServer(s, apisrv)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			t.Logf("Server exited with error: %v", err)
+		}
+	}()
+	defer s.Stop()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+		return lis.Dial()
+	}), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := api.NewBgpServiceClient(conn)
+	res, err := client.GetSession(ctx, &api.GetSessionRequest{
+		Peer: p.addr.ToProto(),
+	})
+	if err != nil {
+		t.Fatalf("GetSession failed: %v", err)
+	}
+
+	expected := &api.SessionDetail{
+		LocalAddress:              bnet.IPv4FromOctets(10, 0, 0, 0).ToProto(),
+		NeighborAddress:           bnet.IPv4FromOctets(10, 0, 0, 1).ToProto(),
+		LocalAsn:                  65000,
+		PeerAsn:                   65001,
+		Status:                    api.Session_Established,
+		LocalRouterId:             100,
+		RemoteRouterId:            200,
+		AdvertisedHoldTimeSeconds: 90,
+		NegotiatedHoldTimeSeconds: 30,
+		AddressFamilies: []*api.AddressFamily{
+			{
+				Afi:  packet.AFIIPv4,
+				Safi: packet.SAFIUnicast,
+			},
+		},
+		UptimeSeconds: 100,
+		LastError:     "Hold timer expired",
+	}
+	assert.Equal(t, expected.String(), res.Session.String())
+
+	_, err = client.GetSession(ctx, &api.GetSessionRequest{
+		Peer: bnet.IPv4FromOctets(10, 0, 0, 2).ToProto(),
+	})
+	assert.Error(t, err, "unknown peer")
+}
+
+func TestShutdownPeer(t *testing.T) {
+	tests := []struct {
+		name              string
+		communication     string
+		reset             bool
+		expectedSubcode   uint8
+		expectedAdminDown bool
+		wantFail          bool
+	}{
+		{
+			name:              "Shutdown with communication",
+			communication:     "planned maintenance, back at 10:00 UTC",
+			expectedSubcode:   packet.AdminShut,
+			expectedAdminDown: true,
+		},
+		{
+			name:            "Reset with communication",
+			communication:   "applying new policy",
+			reset:           true,
+			expectedSubcode: packet.AdminReset,
+		},
+		{
+			name:              "Shutdown without communication",
+			expectedSubcode:   packet.AdminShut,
+			expectedAdminDown: true,
+		},
+		{
+			name:          "Communication too long",
+			communication: strings.Repeat("a", 256),
+			wantFail:      true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			con := &halfCloseConn{}
+			p := &peer{
+				addr: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			}
+
+			fsm := &FSM{
+				peer:    p,
+				con:     con,
+				eventCh: make(chan int),
+				clock:   btime.NewMockClock(time.Unix(1000, 0)),
+			}
+			fsm.state = newOpenConfirmState(fsm)
+			p.fsms = []*FSM{fsm}
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				if <-fsm.eventCh == ManualStop {
+					fsm.state.(*openConfirmState).manualStop()
+				}
+			}()
+
+			apisrv := &BGPAPIServer{
+				srv: &bgpServer{
+					peers: testPeerManager(map[bnet.IP]*peer{
+						*p.addr: p,
+					}),
+				},
+			}
+
+			_, err := apisrv.ShutdownPeer(context.Background(), &api.ShutdownPeerRequest{
+				Peer:          p.addr.ToProto(),
+				Communication: test.communication,
+				Reset_:        test.reset,
+			})
+			if test.wantFail {
+				close(fsm.eventCh)
+				assert.Error(t, err)
+				assert.Empty(t, con.received.Bytes())
+				assert.False(t, p.adminDown.Load())
+				return
+			}
+
+			assert.NoError(t, err)
+			<-done
+
+			msg, err := packet.Decode(bytes.NewBuffer(con.received.Bytes()), &packet.DecodeOptions{})
+			assert.NoError(t, err)
+
+			n := msg.Body.(*packet.BGPNotification)
+			assert.Equal(t, uint8(packet.Cease), n.ErrorCode)
+			assert.Equal(t, test.expectedSubcode, n.ErrorSubcode)
+
+			communication, _ := n.ShutdownCommunication()
+			assert.Equal(t, test.communication, communication)
+			assert.Equal(t, test.expectedAdminDown, p.adminDown.Load())
+		})
+	}
+}
+
+func TestShutdownPeerUnknownPeer(t *testing.T) {
+	apisrv := &BGPAPIServer{
+		srv: &bgpServer{
+			peers: testPeerManager(map[bnet.IP]*peer{}),
+		},
+	}
+
+	_, err := apisrv.ShutdownPeer(context.Background(), &api.ShutdownPeerRequest{
+		Peer:          bnet.IPv4FromOctets(10, 0, 0, 1).ToProto(),
+		Communication: "foo",
+	})
+	assert.Error(t, err)
+}
+
+func TestEvaluatePolicy(t *testing.T) {
+	peerIP := bnet.IPv4FromOctets(10, 0, 0, 1)
+	rejectedPfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 1, 0, 0), 16)
+	acceptedPfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 2, 0, 0), 16)
+	noExportPfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 3, 0, 0), 16)
+
+	newPath := func(source bnet.IP, communities ...uint32) *route.Path {
+		c := types.Communities(communities)
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				BGPPathA: &route.BGPPathA{
+					NextHop:   source.Ptr(),
+					Source:    source.Ptr(),
+					LocalPref: 100,
+					EBGP:      true,
+				},
+				ASPath: &types.ASPath{
+					{
+						Type: types.ASSequence,
+						ASNs: []uint32{65001},
+					},
+				},
+				Communities: &c,
+			},
+		}
+	}
+
+	importFilterChain := filter.Chain{
+		filter.NewFilter("import", []*filter.Term{
+			filter.NewTerm("reject", []*filter.TermCondition{
+				filter.NewTermConditionWithRouteFilters(filter.NewRouteFilter(rejectedPfx.Ptr(), filter.NewExactMatcher())),
+			}, []actions.Action{
+				actions.NewRejectAction(),
+			}),
+			filter.NewTerm("accept", nil, []actions.Action{
+				actions.NewSetLocalPrefAction(200),
+				actions.NewAcceptAction(),
+			}),
+		}),
+	}
+
+	exportFilterChain := filter.Chain{
+		filter.NewFilter("export", []*filter.Term{
+			filter.NewTerm("prepend", nil, []actions.Action{
+				actions.NewASPathPrependAction(65000, 1),
+				actions.NewAcceptAction(),
+			}),
+		}),
+	}
+
+	sessionAttrs := routingtable.SessionAttrs{
+		RouterID: 100,
+		Type:     route.BGPPathType,
+		PeerIP:   peerIP.Ptr(),
+		LocalIP:  bnet.IPv4FromOctets(10, 0, 0, 0).Ptr(),
+		LocalASN: 65000,
+		PeerASN:  65001,
+	}
+
+	rib := locRIB.New("inet.0")
+	ribIn := adjRIBIn.New(importFilterChain, rib.GetContributingASNs(), sessionAttrs)
+	ribIn.AddPath(rejectedPfx.Ptr(), newPath(peerIP))
+	ribIn.AddPath(acceptedPfx.Ptr(), newPath(peerIP))
+
+	otherPeer := bnet.IPv4FromOctets(10, 0, 0, 2)
+	rib.AddPath(acceptedPfx.Ptr(), newPath(otherPeer))
+	rib.AddPath(noExportPfx.Ptr(), newPath(otherPeer, types.WellKnownCommunityNoExport))
+
+	p := &peer{
+		addr: peerIP.Ptr(),
+	}
+	fsm := &FSM{
+		peer: p,
+		ipv4Unicast: &fsmAddressFamily{
+			afi:       packet.AFIIPv4,
+			safi:      packet.SAFIUnicast,
+			rib:       rib,
+			adjRIBIn:  ribIn,
+			adjRIBOut: adjRIBOut.New(rib, sessionAttrs, exportFilterChain),
+			addPathTX: routingtable.ClientOptions{
+				BestOnly: true,
+			},
+		},
+	}
+	fsm.state = newEstablishedState(fsm)
+	p.fsms = []*FSM{fsm}
+
+	apisrv := &BGPAPIServer{
+		srv: &bgpServer{
+			peers: testPeerManager(map[bnet.IP]*peer{
+				peerIP: p,
+			}),
+		},
+	}
+
+	lis := bufconn.Listen(1024 * 1024)
+	s := grpc.NewServer()
+	api.RegisterBgpServiceServer(s, apisrv)
+	go func() {
+		if err := s.Serve(lis); err != nil {
+			t.Logf("Server exited with error: %v", err)
+		}
+	}()
+	defer s.Stop()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+		return lis.Dial()
+	}), grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := api.NewBgpServiceClient(conn)
+
+	tests := []struct {
+		name              string
+		peer              bnet.IP
+		direction         api.EvaluatePolicyRequest_Direction
+		pfx               bnet.Prefix
+		expectedDecision  api.PolicyEvaluation_Decision
+		expectedReason    string
+		expectedLocalPref uint32
+		expectedASPath    string
+		wantFail          bool
+	}{
+		{
+			name:             "Import denied",
+			peer:             peerIP,
+			direction:        api.EvaluatePolicyRequest_In,
+			pfx:              rejectedPfx,
+			expectedDecision: api.PolicyEvaluation_Deny,
+			expectedReason:   `rejected by term "reject" of filter "import"`,
+		},
+		{
+			name:              "Import permitted with modified local pref",
+			peer:              peerIP,
+			direction:         api.EvaluatePolicyRequest_In,
+			pfx:               acceptedPfx,
+			expectedDecision:  api.PolicyEvaluation_Permit,
+			expectedLocalPref: 200,
+			expectedASPath:    "65001",
+		},
+		{
+			name:              "Export permitted with prepended AS path",
+			peer:              peerIP,
+			direction:         api.EvaluatePolicyRequest_Out,
+			pfx:               acceptedPfx,
+			expectedDecision:  api.PolicyEvaluation_Permit,
+			expectedLocalPref: 100,
+			expectedASPath:    "65000 65000 65001",
+		},
+		{
+			name:             "Export denied by NO_EXPORT",
+			peer:             peerIP,
+			direction:        api.EvaluatePolicyRequest_Out,
+			pfx:              noExportPfx,
+			expectedDecision: api.PolicyEvaluation_Deny,
+			expectedReason:   "not eligible for advertisement to the peer",
+		},
+		{
+			name:      "Prefix not received",
+			peer:      peerIP,
+			direction: api.EvaluatePolicyRequest_In,
+			pfx:       noExportPfx,
+			wantFail:  true,
+		},
+		{
+			name:      "Unknown peer",
+			peer:      otherPeer,
+			direction: api.EvaluatePolicyRequest_In,
+			pfx:       acceptedPfx,
+			wantFail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := client.EvaluatePolicy(ctx, &api.EvaluatePolicyRequest{
+				Peer:      test.peer.ToProto(),
+				Afi:       packet.AFIIPv4,
+				Safi:      packet.SAFIUnicast,
+				Direction: test.direction,
+				Pfx:       test.pfx.ToProto(),
+			})
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			if !assert.NoError(t, err) || !assert.Len(t, res.Evaluations, 1) {
+				return
+			}
+
+			e := res.Evaluations[0]
+			assert.Equal(t, test.expectedDecision, e.Decision)
+			assert.Equal(t, test.expectedReason, e.RejectReason)
+			assert.NotNil(t, e.Path)
+			if test.expectedDecision == api.PolicyEvaluation_Deny {
+				assert.Nil(t, e.Result)
+				return
+			}
+
+			result := route.BGPPathFromProtoBGPPath(e.Result.BgpPath, false)
+			assert.Equal(t, test.expectedLocalPref, result.BGPPathA.LocalPref)
+			assert.Equal(t, test.expectedASPath, result.ASPath.String())
+		})
+	}
+
+	// Nothing has been applied by the evaluation
+	assert.Equal(t, uint32(100), ribIn.Get(acceptedPfx.Ptr()).Paths()[0].BGPPath.BGPPathA.LocalPref)
+	assert.Equal(t, "65001", rib.Get(acceptedPfx.Ptr()).BestPath().BGPPath.ASPath.String())
+	assert.Equal(t, int64(0), fsm.ipv4Unicast.adjRIBOut.RouteCount())
+}
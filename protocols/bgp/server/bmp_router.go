@@ -15,6 +15,7 @@ import (
 	"github.com/bio-routing/bio-rd/routingtable/filter"
 	"github.com/bio-routing/bio-rd/routingtable/vrf"
 	"github.com/bio-routing/bio-rd/util/log"
+	btime "github.com/bio-routing/bio-rd/util/time"
 	"github.com/bio-routing/tflow2/convert"
 )
 
@@ -23,6 +24,7 @@ type RouterInterface interface {
 	Address() net.IP
 	GetVRF(vrfID uint64) *vrf.VRF
 	GetVRFs() []*vrf.VRF
+	CreateVRFIfNotExists(rd uint64) *vrf.VRF
 	Ready(vrf uint64, afi uint16) bool
 }
 
@@ -155,6 +157,11 @@ func (r *Router) GetVRFs() []*vrf.VRF {
 	return r.vrfRegistry.List()
 }
 
+// CreateVRFIfNotExists gets a VRF and creates it if it does not exist yet
+func (r *Router) CreateVRFIfNotExists(rd uint64) *vrf.VRF {
+	return r.vrfRegistry.CreateVRFIfNotExists(fmt.Sprintf("%d", rd), rd)
+}
+
 // Name gets a routers name
 func (r *Router) Name() string {
 	r.nameMu.RLock()
@@ -400,6 +407,7 @@ func (r *Router) processPeerUpNotification(msg *bmppkt.PeerUpNotification) error
 		isBMP:            true,
 		ribsInitialized:  true,
 		bmpRouterAddress: r.address,
+		clock:            btime.NewBIOClock(),
 		peer: &peer{
 			routerID:        sentOpen.BGPIdentifier,
 			addr:            peerAddress.Dedup(),
@@ -408,7 +416,7 @@ func (r *Router) processPeerUpNotification(msg *bmppkt.PeerUpNotification) error
 			localASN:        uint32(sentOpen.ASN),
 			ipv4:            &peerAddressFamily{},
 			ipv6:            &peerAddressFamily{},
-			vrf:             r.vrfRegistry.CreateVRFIfNotExists(fmt.Sprintf("%d", msg.PerPeerHeader.PeerDistinguisher), msg.PerPeerHeader.PeerDistinguisher),
+			vrf:             r.CreateVRFIfNotExists(msg.PerPeerHeader.PeerDistinguisher),
 			adjRIBInFactory: r.adjRIBInFactory,
 		},
 	}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+)
+
+// overridableCapabilities are the capabilities that can be forced or suppressed, in the order forced ones are added
+var overridableCapabilities = []uint8{
+	packet.RouteRefreshCapabilityCode,
+	packet.ASN4CapabilityCode,
+	packet.AddPathCapabilityCode,
+	packet.GracefulRestartCapabilityCode,
+}
+
+// CapabilityOverrides forces (true) or suppresses (false) the advertisement of capabilities in our OPEN message
+// regardless of the configured features, e.g. to work around peers breaking on a capability. Keys are capability codes.
+type CapabilityOverrides map[uint8]bool
+
+// Equal compares two CapabilityOverrides
+func (c CapabilityOverrides) Equal(x CapabilityOverrides) bool {
+	if len(c) != len(x) {
+		return false
+	}
+
+	for code, forced := range c {
+		xForced, ok := x[code]
+		if !ok || xForced != forced {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c CapabilityOverrides) validate() error {
+	for code := range c {
+		if !isOverridableCapability(code) {
+			return fmt.Errorf("capability %d can not be overridden", code)
+		}
+	}
+
+	return nil
+}
+
+func isOverridableCapability(code uint8) bool {
+	for _, c := range overridableCapabilities {
+		if c == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c CapabilityOverrides) forced(code uint8) bool {
+	return c[code]
+}
+
+func (c CapabilityOverrides) suppressed(code uint8) bool {
+	forced, ok := c[code]
+	return ok && !forced
+}
+
+// applyCapabilityFeatures aligns the features depending on a capability with its forced or suppressed advertisement
+func (p *peer) applyCapabilityFeatures() {
+	if p.capabilityOverrides.forced(packet.RouteRefreshCapabilityCode) {
+		p.routeRefresh = true
+	}
+
+	// Enhanced route refresh requires the route refresh capability (RFC7313)
+	if p.capabilityOverrides.suppressed(packet.RouteRefreshCapabilityCode) {
+		p.routeRefresh = false
+		p.enhancedRouteRefresh = false
+	}
+
+	// A forced ADD-PATH capability is only generated if ADD-PATH is not configured for any address family
+	if p.capabilityOverrides.forced(packet.AddPathCapabilityCode) && len(addPathCapabilities(*p.config)) == 0 {
+		for _, f := range []*peerAddressFamily{p.ipv4, p.ipv6} {
+			if f != nil {
+				f.addPathReceive = true
+			}
+		}
+	}
+}
+
+// applyCapabilityOverrides removes suppressed capabilities from caps and appends forced ones not advertised yet
+func (p *peer) applyCapabilityOverrides(caps packet.Capabilities, localASN uint32) packet.Capabilities {
+	if len(p.capabilityOverrides) == 0 {
+		return caps
+	}
+
+	ret := make(packet.Capabilities, 0, len(caps))
+	advertised := make(map[uint8]struct{})
+	for _, cap := range caps {
+		if p.capabilityOverrides.suppressed(cap.Code) {
+			continue
+		}
+
+		ret = append(ret, cap)
+		advertised[cap.Code] = struct{}{}
+	}
+
+	for _, code := range overridableCapabilities {
+		if _, exists := advertised[code]; exists || !p.capabilityOverrides.forced(code) {
+			continue
+		}
+
+		ret = append(ret, p.forcedCapability(code, localASN))
+	}
+
+	return ret
+}
+
+// forcedCapability returns the capability advertised for code if it is forced but the feature is not configured
+func (p *peer) forcedCapability(code uint8, localASN uint32) packet.Capability {
+	switch code {
+	case packet.RouteRefreshCapabilityCode:
+		return routeRefreshCapability()
+	case packet.ASN4CapabilityCode:
+		return asn4Capability(localASN)
+	case packet.AddPathCapabilityCode:
+		return p.forcedAddPathCapability()
+	default:
+		// Without any AFI/SAFI we only announce to be able to act as graceful restart receiving speaker
+		return packet.Capability{
+			Code:  packet.GracefulRestartCapabilityCode,
+			Value: packet.GracefulRestartCapability{},
+		}
+	}
+}
+
+// forcedAddPathCapability announces to receive multiple paths for all configured address families.
+// Receiving them is enabled by applyCapabilityFeatures.
+func (p *peer) forcedAddPathCapability() packet.Capability {
+	tuples := make(packet.AddPathCapability, 0)
+	for _, afi := range []uint16{packet.AFIIPv4, packet.AFIIPv6} {
+		if p.addressFamily(afi, packet.SAFIUnicast) == nil {
+			continue
+		}
+
+		tuples = append(tuples, packet.AddPathCapabilityTuple{
+			AFI:         afi,
+			SAFI:        packet.SAFIUnicast,
+			SendReceive: packet.AddPathReceive,
+		})
+	}
+
+	return packet.Capability{
+		Code:  packet.AddPathCapabilityCode,
+		Value: tuples,
+	}
+}
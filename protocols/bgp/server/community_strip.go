@@ -0,0 +1,8 @@
+package server
+
+// communityStrip selects the community attributes removed from paths advertised to a peer. They are removed by the
+// AdjRIBOut, so the paths shown for it match the ones sent.
+type communityStrip struct {
+	communities      bool
+	largeCommunities bool
+}
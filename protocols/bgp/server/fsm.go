@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net"
@@ -12,6 +13,7 @@ import (
 	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
 	"github.com/bio-routing/bio-rd/util/log"
+	btime "github.com/bio-routing/bio-rd/util/time"
 )
 
 const (
@@ -23,6 +25,7 @@ const (
 	AutomaticStartWithPassiveTcpEstablishment = 5
 	AutomaticStop                             = 8
 	Cease                                     = 100
+	ConnectionCollision                       = 101
 	stateNameIdle                             = "idle"
 	stateNameConnect                          = "connect"
 	stateNameActive                           = "active"
@@ -30,6 +33,11 @@ const (
 	stateNameOpenConfirm                      = "openConfirm"
 	stateNameEstablished                      = "established"
 	stateNameCease                            = "cease"
+
+	defaultInboundQueueSize = 256
+
+	// closeLingerTime bounds how long we wait for the peer to close its side of a connection we are closing
+	closeLingerTime = 3 * time.Second
 )
 
 type state interface {
@@ -58,32 +66,70 @@ type FSM struct {
 	connectRetryTimer   *time.Timer
 	connectRetryCounter int
 
+	// holdTime is the negotiated hold time. Zero disables the hold timer and keepalives (RFC4271 section 4.2).
 	holdTime              time.Duration
 	lastUpdateOrKeepalive time.Time
 
 	keepaliveTime  time.Duration
-	keepaliveTimer *time.Timer
+	keepaliveTimer btime.Timer
+
+	// routeRefreshTimer delays re-advertising on ROUTE-REFRESH requests so bursts are handled once. nil if no refresh is pending.
+	routeRefreshTimer btime.Timer
+
+	// clock is the source of time for hold and keepalive timers
+	clock btime.Clock
 
+	// msgRecvCh queues received messages. Once full, the receiver stops reading from the connection.
 	msgRecvCh     chan []byte
-	msgRecvFailCh chan error
+	msgRecvFailCh chan msgRecvFailure
 	stopMsgRecvCh chan struct{}
 
+	// local is the address outgoing connections are bound to, dial establishes them
 	local net.IP
+	dial  dialer
 
 	ribsInitialized bool
 	ipv4Unicast     *fsmAddressFamily
 	ipv6Unicast     *fsmAddressFamily
 
+	// linkStateNegotiated indicates both sides advertised the BGP-LS address family (RFC7752)
+	linkStateNegotiated bool
+	linkState           *linkStateSender
+
 	supports4OctetASN bool
 
+	// extendedMessage indicates both sides support messages up to 65535 bytes (RFC8654)
+	extendedMessage bool
+
+	// routeRefresh and enhancedRouteRefresh indicate both sides support ROUTE-REFRESH (RFC2918)
+	// and its BoRR/EoRR demarcation (RFC7313)
+	routeRefresh         bool
+	enhancedRouteRefresh bool
+
+	// peerGracefulRestart is the graceful restart capability received from the peer (RFC4724). Only set if we act as
+	// receiving speaker for the peer.
+	peerGracefulRestart *packet.GracefulRestartCapability
+
+	// dualASFallback indicates the real local ASN is presented to a dual-as peer instead of the alternate one on the
+	// current connection
+	dualASFallback bool
+
 	neighborID uint32
 	state      state
 	stateMu    sync.RWMutex
 	reason     string
 	active     bool
 
+	// lastError is the reason the session last fell back to idle state. Guarded by stateMu.
+	lastError string
+
 	establishedTime time.Time
 
+	// stopNotification is the NOTIFICATION sent on the next ManualStop event. It is cleared once a ManualStop event was
+	// processed, even in states without a session to send it on. Guarded by stopNotificationMu.
+	stopNotification   *packet.BGPNotification
+	stopNotificationMu sync.Mutex
+
 	connectionCancelFunc context.CancelFunc
 }
 
@@ -111,60 +157,118 @@ func newFSM(peer *peer) *FSM {
 		conCh:            make(chan net.Conn),
 		conErrCh:         make(chan error),
 		initiateCon:      make(chan struct{}),
-		msgRecvCh:        make(chan []byte),
-		msgRecvFailCh:    make(chan error),
+		msgRecvCh:        make(chan []byte, peer.inboundQueueCap()),
+		msgRecvFailCh:    make(chan msgRecvFailure),
 		stopMsgRecvCh:    make(chan struct{}),
 		counters:         fsmCounters{},
+		clock:            btime.NewBIOClock(),
+		dial:             tcpDial,
+		dualASFallback:   peer.dualASFallback.Load(),
+	}
+
+	if peer.config != nil && peer.config.UpdateSource != nil {
+		f.local = peer.config.UpdateSource.ToNetIP()
 	}
 
+	peer.policyMu.RLock()
+	defer peer.policyMu.RUnlock()
+
 	if peer.ipv4 != nil {
-		f.ipv4Unicast = newFSMAddressFamily(packet.AFIIPv4, packet.SAFIUnicast, peer.ipv4, f)
+		f.ipv4Unicast = newFSMAddressFamily(packet.AFIIPv4, peer.ipv4.safi(), peer.ipv4, f)
+		f.ipv4Unicast.policyVersion = peer.policyVersion
 	}
 
 	if peer.ipv6 != nil {
-		f.ipv6Unicast = newFSMAddressFamily(packet.AFIIPv6, packet.SAFIUnicast, peer.ipv6, f)
+		f.ipv6Unicast = newFSMAddressFamily(packet.AFIIPv6, peer.ipv6.safi(), peer.ipv6, f)
+		f.ipv6Unicast.policyVersion = peer.policyVersion
 	}
 
 	return f
 }
 
-func (fsm *FSM) replaceImportFilterChain(c filter.Chain) {
+func (fsm *FSM) replaceImportFilterChain(c filter.Chain, version uint64) {
 	if fsm.ipv4Unicast != nil {
-		fsm.ipv4Unicast.replaceImportFilterChain(c)
+		fsm.ipv4Unicast.replaceImportFilterChain(c, version)
 	}
 
 	if fsm.ipv6Unicast != nil {
-		fsm.ipv6Unicast.replaceImportFilterChain(c)
+		fsm.ipv6Unicast.replaceImportFilterChain(c, version)
 	}
 }
 
-func (fsm *FSM) replaceExportFilterChain(c filter.Chain) {
+func (fsm *FSM) replaceExportFilterChain(c filter.Chain, version uint64) {
 	if fsm.ipv4Unicast != nil {
-		fsm.ipv4Unicast.replaceExportFilterChain(c)
+		fsm.ipv4Unicast.replaceExportFilterChain(c, version)
 	}
 
 	if fsm.ipv6Unicast != nil {
-		fsm.ipv6Unicast.replaceExportFilterChain(c)
+		fsm.ipv6Unicast.replaceExportFilterChain(c, version)
 	}
 }
 
 func (fsm *FSM) updateLastUpdateOrKeepalive() {
-	fsm.lastUpdateOrKeepalive = time.Now()
+	fsm.lastUpdateOrKeepalive = fsm.clock.Now()
 }
 
-func (fsm *FSM) addressFamily(afi uint16, safi uint8) *fsmAddressFamily {
-	if safi != packet.SAFIUnicast {
+// holdTimerExpired returns if no update or keepalive has been received within the hold time
+func (fsm *FSM) holdTimerExpired() bool {
+	if fsm.holdTime == 0 {
+		return false
+	}
+
+	return fsm.clock.Now().Sub(fsm.lastUpdateOrKeepalive) > fsm.holdTime
+}
+
+// keepaliveTimerC returns the channel of the keepalive timer. The returned channel is nil (blocks forever) if keepalives are disabled.
+func (fsm *FSM) keepaliveTimerC() <-chan time.Time {
+	if fsm.keepaliveTimer == nil {
 		return nil
 	}
 
+	return fsm.keepaliveTimer.C()
+}
+
+// startKeepaliveTimer starts the hold and keepalive timers for the negotiated hold time
+func (fsm *FSM) startKeepaliveTimer() {
+	if fsm.keepaliveTimer != nil {
+		fsm.keepaliveTimer.Stop()
+		fsm.keepaliveTimer = nil
+	}
+
+	fsm.keepaliveTime = 0
+	if fsm.holdTime == 0 {
+		return
+	}
+
+	fsm.updateLastUpdateOrKeepalive()
+	fsm.keepaliveTime = fsm.holdTime / 3
+	fsm.keepaliveTimer = fsm.clock.NewTimer(fsm.keepaliveTime)
+}
+
+func (fsm *FSM) addressFamily(afi uint16, safi uint8) *fsmAddressFamily {
+	var f *fsmAddressFamily
 	switch afi {
 	case packet.AFIIPv4:
-		return fsm.ipv4Unicast
+		f = fsm.ipv4Unicast
 	case packet.AFIIPv6:
-		return fsm.ipv6Unicast
-	default:
+		f = fsm.ipv6Unicast
+	}
+
+	if f == nil || f.safi != safi {
 		return nil
 	}
+
+	return f
+}
+
+// negotiated checks if the address family was negotiated in the session. IPv4 unicast is implied unless we advertised
+// the multiprotocol capability for IPv4.
+func (f *fsmAddressFamily) negotiated() bool {
+	if f.multiProtocol {
+		return true
+	}
+
+	return f.afi == packet.AFIIPv4 && f.safi == packet.SAFIUnicast && !f.fsm.peer.ipv4MultiProtocolAdvertised
 }
 
 func (fsm *FSM) start() {
@@ -197,14 +301,18 @@ func (fsm *FSM) run() {
 		}
 
 		if newState == stateNameCease {
+			fsm.peer.removeFSM(fsm)
 			return
 		}
 
 		if oldState != newState && newState == stateNameEstablished {
-			fsm.establishedTime = time.Now()
+			fsm.establishedTime = fsm.clock.Now()
 		}
 
 		fsm.stateMu.Lock()
+		if oldState != newState && newState == stateNameIdle {
+			fsm.lastError = reason
+		}
 		fsm.state = next
 		fsm.stateMu.Unlock()
 
@@ -243,16 +351,13 @@ func (fsm *FSM) cease() {
 	fsm.eventCh <- Cease
 }
 
-func (fsm *FSM) sockSettings(c net.Conn) error {
-	ttl := fsm.peer.ttl
-	setNoRoute := false
-	if ttl == 0 {
-		if fsm.peer.isEBGP() {
-			ttl = 1
-			setNoRoute = true
-		}
-	}
+// collisionCease closes the connection of the FSM as it lost the connection collision resolution
+func (fsm *FSM) collisionCease() {
+	fsm.eventCh <- ConnectionCollision
+}
 
+func (fsm *FSM) sockSettings(c net.Conn) error {
+	ttl, setNoRoute := fsm.peer.connectionTTL()
 	if setNoRoute {
 		err := setDontRoute(c)
 		if err != nil {
@@ -267,14 +372,34 @@ func (fsm *FSM) sockSettings(c net.Conn) error {
 		}
 	}
 
+	if fsm.peer.minTTL != 0 {
+		err := setMinTTL(c, fsm.peer.minTTL)
+		if err != nil {
+			return fmt.Errorf("unable to set minimum TTL: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// dialer establishes an outgoing TCP connection
+type dialer func(laddr, raddr *net.TCPAddr, ttl uint8, md5Secret string, noRoute bool) (net.Conn, error)
+
+func tcpDial(laddr, raddr *net.TCPAddr, ttl uint8, md5Secret string, noRoute bool) (net.Conn, error) {
+	c, err := tcp.Dial(laddr, raddr, ttl, md5Secret, noRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
 func (fsm *FSM) tcpConnector(ctx context.Context) {
 	for {
 		select {
 		case <-fsm.initiateCon:
-			c, err := tcp.Dial(&net.TCPAddr{IP: fsm.local}, &net.TCPAddr{IP: fsm.peer.addr.ToNetIP(), Port: BGPPORT}, fsm.peer.ttl, fsm.peer.config.AuthenticationKey, fsm.peer.ttl == 0)
+			ttl, noRoute := fsm.peer.connectionTTL()
+			c, err := fsm.dial(&net.TCPAddr{IP: fsm.local}, &net.TCPAddr{IP: fsm.peer.addr.ToNetIP(), Port: BGPPORT}, ttl, fsm.peer.config.AuthenticationKey, noRoute)
 			if err != nil {
 				select {
 				case fsm.conErrCh <- err:
@@ -301,20 +426,47 @@ func (fsm *FSM) tcpConnect() {
 	fsm.initiateCon <- struct{}{}
 }
 
+// msgRecvFailure is an error reading from connection con
+type msgRecvFailure struct {
+	con net.Conn
+	err error
+}
+
 func (fsm *FSM) msgReceiver() error {
+	con := fsm.con
 	for {
-		msg, err := recvMsg(fsm.con)
+		msg, err := recvMsg(con)
 		if err != nil {
-			fsm.msgRecvFailCh <- err
+			fsm.msgRecvFailCh <- msgRecvFailure{
+				con: con,
+				err: err,
+			}
 			return nil
 		}
 		fsm.msgRecvCh <- msg
 	}
 }
 
+// discardQueuedMsgs drops messages left over in the inbound queue from a previous connection
+func (fsm *FSM) discardQueuedMsgs() {
+	for {
+		select {
+		case <-fsm.msgRecvCh:
+		default:
+			return
+		}
+	}
+}
+
+// inboundQueueDepth returns the number of received messages waiting to be processed
+func (fsm *FSM) inboundQueueDepth() int {
+	return len(fsm.msgRecvCh)
+}
+
 func (fsm *FSM) decodeOptions() *packet.DecodeOptions {
 	ret := &packet.DecodeOptions{
-		Use32BitASN: fsm.supports4OctetASN,
+		Use32BitASN:     fsm.supports4OctetASN,
+		ExtendedMessage: fsm.extendedMessage,
 	}
 
 	ipv4unicast := fsm.addressFamily(packet.AFIIPv4, packet.SAFIUnicast)
@@ -344,6 +496,7 @@ func (fsm *FSM) resetConnectRetryCounter() {
 }
 
 func (fsm *FSM) sendOpen() error {
+	fsm.dualASFallback = fsm.peer.dualASFallback.Load()
 	msg := packet.SerializeOpenMsg(fsm.openMessage())
 
 	_, err := fsm.con.Write(msg)
@@ -360,23 +513,37 @@ func (fsm *FSM) openMessage() *packet.BGPOpen {
 		ASN:           fsm.local16BitASN(),
 		HoldTime:      uint16(fsm.peer.holdTime / time.Second),
 		BGPIdentifier: fsm.peer.routerID,
-		OptParams:     fsm.peer.optOpenParams,
+		OptParams:     fsm.optOpenParams(),
 	}
 }
 
 func (fsm *FSM) local16BitASN() uint16 {
-	if fsm.peer.localASN > uint32(^uint16(0)) {
+	asn := fsm.openASN()
+	if asn > uint32(^uint16(0)) {
 		return packet.ASTransASN
 	}
 
-	return uint16(fsm.peer.localASN)
+	return uint16(asn)
 }
 
 func (fsm *FSM) sendNotification(errorCode uint8, errorSubCode uint8) error {
-	msg := packet.SerializeNotificationMsg(&packet.BGPNotification{
+	return fsm.sendNotificationMsg(&packet.BGPNotification{
 		ErrorCode:    errorCode,
 		ErrorSubcode: errorSubCode,
 	})
+}
+
+func (fsm *FSM) sendNotificationMsg(n *packet.BGPNotification) error {
+	fields := log.Fields{
+		"error_code":    n.ErrorCode,
+		"error_subcode": n.ErrorSubcode,
+	}
+	if fsm.peer.addr != nil {
+		fields["peer"] = fsm.peer.addr.String()
+	}
+	log.WithFields(fields).Infof("Sending NOTIFICATION: %s", n.String())
+
+	msg := packet.SerializeNotificationMsg(n)
 
 	_, err := fsm.con.Write(msg)
 	if err != nil {
@@ -386,6 +553,61 @@ func (fsm *FSM) sendNotification(errorCode uint8, errorSubCode uint8) error {
 	return nil
 }
 
+// sendDecodeErrorNotification sends the NOTIFICATION indicated by the error decoding a received message. Nothing is
+// sent for errors not indicating a NOTIFICATION, e.g. errors decoding a NOTIFICATION.
+func (fsm *FSM) sendDecodeErrorNotification(err error) {
+	var bgpErr packet.BGPError
+	if errors.As(err, &bgpErr) {
+		fsm.sendNotification(bgpErr.ErrorCode, bgpErr.ErrorSubCode)
+	}
+}
+
+// closeWriter is implemented by connections supporting a half-close, e.g. *net.TCPConn
+type closeWriter interface {
+	CloseWrite() error
+}
+
+// closeConnection closes the connection in an orderly manner. The sending side is shut down first,
+// so a previously written NOTIFICATION is followed by a FIN. Remaining inbound data is consumed by msgReceiver until
+// the peer closes its side, as closing a socket with unread data makes the kernel send a RST. The connection is closed
+// closeLingerTime later without blocking the FSM.
+func (fsm *FSM) closeConnection() {
+	con := fsm.con
+	if con == nil {
+		return
+	}
+
+	if cw, ok := con.(closeWriter); ok {
+		if err := cw.CloseWrite(); err == nil {
+			linger := fsm.clock.After(closeLingerTime)
+			go func() {
+				<-linger
+				con.Close()
+			}()
+			return
+		}
+	}
+
+	con.Close()
+}
+
+func (fsm *FSM) sendRouteRefresh(afi uint16, safi uint8, subtype uint8) error {
+	return fsm.sendRouteRefreshMsg(&packet.BGPRouteRefresh{
+		AFI:     afi,
+		Subtype: subtype,
+		SAFI:    safi,
+	})
+}
+
+func (fsm *FSM) sendRouteRefreshMsg(rr *packet.BGPRouteRefresh) error {
+	_, err := fsm.con.Write(packet.SerializeRouteRefreshMsg(rr))
+	if err != nil {
+		return fmt.Errorf("unable to send ROUTE-REFRESH message: %w", err)
+	}
+
+	return nil
+}
+
 func (fsm *FSM) sendKeepalive() error {
 	msg := packet.SerializeKeepaliveMsg()
 
@@ -398,15 +620,21 @@ func (fsm *FSM) sendKeepalive() error {
 }
 
 func recvMsg(c net.Conn) (msg []byte, err error) {
-	buffer := make([]byte, packet.MaxLen)
-	_, err = io.ReadFull(c, buffer[0:packet.MinLen])
+	hdr := make([]byte, packet.MinLen)
+	_, err = io.ReadFull(c, hdr)
 	if err != nil {
 		return nil, fmt.Errorf("Read failed: %w", err)
 	}
 
-	l := int(buffer[16])*256 + int(buffer[17])
-	toRead := l
-	_, err = io.ReadFull(c, buffer[packet.MinLen:toRead])
+	// The length is validated against the negotiated maximum by the decoder
+	l := int(hdr[16])*256 + int(hdr[17])
+	if l < packet.MinLen {
+		l = packet.MinLen
+	}
+
+	buffer := make([]byte, l)
+	copy(buffer, hdr)
+	_, err = io.ReadFull(c, buffer[packet.MinLen:])
 	if err != nil {
 		return nil, fmt.Errorf("Read failed: %w", err)
 	}
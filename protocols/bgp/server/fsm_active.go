@@ -36,6 +36,7 @@ func (s activeState) run() (state, string) {
 }
 
 func (s *activeState) manualStop() (state, string) {
+	s.fsm.takeStopNotification()
 	s.fsm.con.Close()
 	s.fsm.resetConnectRetryCounter()
 	stopTimer(s.fsm.connectRetryTimer)
@@ -49,6 +50,10 @@ func (s *activeState) cease() (state, string) {
 
 func (s *activeState) connectRetryTimerExpired() (state, string) {
 	s.fsm.resetConnectRetryTimer()
+	if s.fsm.peer.passive {
+		return newActiveState(s.fsm), "Connect retry timer expired, waiting for passive TCP establishment"
+	}
+
 	s.fsm.tcpConnect()
 	return newConnectState(s.fsm), "Connect retry timer expired"
 }
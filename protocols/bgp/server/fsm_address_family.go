@@ -13,6 +13,7 @@ import (
 	"github.com/bio-routing/bio-rd/routingtable/adjRIBOut"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
 	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/bio-routing/bio-rd/util/log"
 )
 
 // fsmAddressFamily holds RIBs and the UpdateSender of an peer for an AFI/SAFI combination
@@ -28,6 +29,22 @@ type fsmAddressFamily struct {
 	importFilterChain filter.Chain
 	exportFilterChain filter.Chain
 
+	// policyVersion is the policy version of the peer the filter chains belong to
+	policyVersion uint64
+
+	defaultOriginate            bool
+	defaultOriginateFilterChain filter.Chain
+
+	suppressFIBFailures bool
+
+	prefixLimit *PrefixLimit
+
+	nextHopValidation *NextHopValidation
+
+	// orfPrefixList is pushed to the peer if orfSend was negotiated
+	orfPrefixList ORFPrefixList
+	orfSend       bool
+
 	updateSender *UpdateSender
 
 	addPathTX routingtable.ClientOptions
@@ -35,6 +52,12 @@ type fsmAddressFamily struct {
 
 	multiProtocol bool
 
+	// refreshPending is set while a ROUTE-REFRESH request waits for routeRefreshTimer of the FSM
+	refreshPending bool
+
+	// adjRIBInRetained is set if the Adj-RIB-In was handed over to the graceful restart helper on session loss
+	adjRIBInRetained bool
+
 	initialized            bool
 	endOfRIBMarkerReceived atomic.Bool
 }
@@ -47,28 +70,46 @@ func newFSMAddressFamily(afi uint16, safi uint8, family *peerAddressFamily, fsm
 		rib:               family.rib,
 		importFilterChain: family.importFilterChain,
 		exportFilterChain: family.exportFilterChain,
+
+		defaultOriginate:            family.defaultOriginate,
+		defaultOriginateFilterChain: family.defaultOriginateFilterChain,
+		suppressFIBFailures:         family.suppressFIBFailures,
+		prefixLimit:                 family.prefixLimit,
+		nextHopValidation:           family.nextHopValidation,
+		orfPrefixList:               family.orfPrefixList,
+
 		addPathTX: routingtable.ClientOptions{
 			BestOnly: true,
 		},
 	}
 }
 
-func (f *fsmAddressFamily) replaceImportFilterChain(c filter.Chain) {
+// replaceImportFilterChain re-evaluates the paths stored in the Adj-RIB-In against the filter chain of a new policy
+// version. Only paths the decision changed for are propagated.
+func (f *fsmAddressFamily) replaceImportFilterChain(c filter.Chain, version uint64) {
+	f.policyVersion = version
 	if c.Equal(f.importFilterChain) {
 		return
 	}
 
 	f.importFilterChain = c
-	f.adjRIBIn.ReplaceFilterChain(c)
+	if f.adjRIBIn != nil {
+		f.adjRIBIn.ReplaceFilterChain(c)
+	}
 }
 
-func (f *fsmAddressFamily) replaceExportFilterChain(c filter.Chain) {
+// replaceExportFilterChain re-evaluates the paths of the RIB against the export filter chain of a new policy version.
+// Only paths the decision changed for are advertised or withdrawn.
+func (f *fsmAddressFamily) replaceExportFilterChain(c filter.Chain, version uint64) {
+	f.policyVersion = version
 	if c.Equal(f.exportFilterChain) {
 		return
 	}
 
 	f.exportFilterChain = c
-	f.adjRIBOut.ReplaceFilterChain(c)
+	if f.adjRIBOut != nil {
+		f.adjRIBOut.ReplaceFilterChain(c)
+	}
 }
 
 func (f *fsmAddressFamily) dumpRIBOut() []*route.Route {
@@ -93,22 +134,39 @@ func (f *fsmAddressFamily) init() {
 	contributingASNs := f.rib.GetContributingASNs()
 	sessionAttrs := f.getSessionAttrs()
 
-	f.adjRIBIn = f.fsm.peer.adjRIBInFactory.New(f.importFilterChain, contributingASNs, sessionAttrs)
+	f.adjRIBIn = f.adoptRetainedAdjRIBIn()
+	if f.adjRIBIn == nil {
+		f.adjRIBIn = f.fsm.peer.adjRIBInFactory.New(f.importFilterChain, contributingASNs, sessionAttrs)
+		f.adjRIBIn.Register(f.rib)
+	}
 	contributingASNs.Add(f.fsm.peer.localASN)
 
-	f.adjRIBIn.Register(f.rib)
-
-	f.adjRIBOut = adjRIBOut.New(f.rib, sessionAttrs, f.exportFilterChain)
+	ribOut := adjRIBOut.New(f.rib, sessionAttrs, f.exportFilterChain)
+	f.adjRIBOut = ribOut
 
 	f.updateSender = newUpdateSender(f)
 	f.updateSender.Start(time.Millisecond * 5)
 
 	f.adjRIBOut.Register(f.updateSender)
 
-	f.rib.RegisterWithOptions(f.adjRIBOut, f.addPathTX)
+	if f.defaultOriginate {
+		ribOut.OriginateDefault(defaultPrefix(f.afi), f.defaultOriginateFilterChain)
+	}
+
+	opts := f.addPathTX
+	opts.SuppressFIBFailures = f.suppressFIBFailures
+	f.rib.RegisterWithOptions(f.adjRIBOut, opts)
 	f.initialized = true
 }
 
+func defaultPrefix(afi uint16) *bnet.Prefix {
+	if afi == packet.AFIIPv6 {
+		return bnet.NewPfx(bnet.IPv6(0, 0), 0).Dedup()
+	}
+
+	return bnet.NewPfx(bnet.IPv4(0), 0).Dedup()
+}
+
 func (f *fsmAddressFamily) getSessionAttrs() routingtable.SessionAttrs {
 	rip, _ := bnet.IPFromBytes(f.fsm.bmpRouterAddress)
 
@@ -120,11 +178,18 @@ func (f *fsmAddressFamily) getSessionAttrs() routingtable.SessionAttrs {
 		IBGP:                 f.fsm.peer.localASN == f.fsm.peer.peerASN,
 		LocalASN:             f.fsm.peer.localASN,
 		PeerASN:              f.fsm.peer.peerASN,
+		LocalASOverride:      f.fsm.localASOverride(),
+		LocalASReplace:       f.fsm.peer.localASOverride != nil && f.fsm.peer.localASOverride.ReplaceAS,
 		RouteServerClient:    f.fsm.peer.routeServerClient,
+		NextHopSelf:          f.fsm.peer.nextHopSelf,
 		RouteReflectorClient: f.fsm.peer.routeReflectorClient,
 		ClusterID:            f.fsm.peer.clusterID,
 		AddPathRX:            f.addPathRX,
 		AddPathTX:            !f.addPathTX.BestOnly,
+		LogASLoops:           f.fsm.peer.logASLoops,
+
+		StripCommunities:      f.fsm.peer.strip.communities,
+		StripLargeCommunities: f.fsm.peer.strip.largeCommunities,
 
 		PeerRoleEnabled:    f.fsm.peer.peerRoleEnabled,
 		PeerRoleStrictMode: f.fsm.peer.peerRoleStrictMode,
@@ -163,26 +228,82 @@ func (f *fsmAddressFamily) dispose() {
 	}
 
 	f.rib.GetContributingASNs().Remove(f.fsm.peer.localASN)
-	f.adjRIBIn.Unregister(f.rib)
+	if f.adjRIBInRetained {
+		f.adjRIBInRetained = false
+	} else {
+		if f.fsm.peer.gracefulRestart != nil {
+			f.fsm.peer.gracefulRestart.forget(f)
+		}
+
+		f.adjRIBIn.Unregister(f.rib)
+	}
 	f.rib.Unregister(f.adjRIBOut)
 	f.adjRIBOut.Unregister(f.updateSender)
 	f.updateSender.Destroy()
 
 	f.adjRIBIn = nil
 	f.adjRIBOut = nil
+	f.endOfRIBMarkerReceived.Store(false)
 
 	f.initialized = false
 }
 
+// refresh re-advertises the Adj-RIB-Out on a ROUTE-REFRESH request (RFC2918)
+func (f *fsmAddressFamily) refresh() {
+	if !f.initialized {
+		return
+	}
+
+	f.updateSender.refresh(f.adjRIBOut.Dump(), f.fsm.enhancedRouteRefresh)
+}
+
+// softRefreshIn gets the routes received from the peer refreshed using the best method available
+func (f *fsmAddressFamily) softRefreshIn() (SoftRefreshMethod, error) {
+	if f.fsm.enhancedRouteRefresh {
+		return SoftRefreshEnhancedRouteRefresh, f.fsm.sendRouteRefresh(f.afi, f.safi, packet.RouteRefreshNormal)
+	}
+
+	if f.fsm.routeRefresh {
+		return SoftRefreshRouteRefresh, f.fsm.sendRouteRefresh(f.afi, f.safi, packet.RouteRefreshNormal)
+	}
+
+	f.adjRIBIn.ReapplyFilterChain()
+	return SoftRefreshStoredRoutes, nil
+}
+
+// beginRouteRefresh marks all paths received from the peer stale when it starts re-advertising them (RFC7313)
+func (f *fsmAddressFamily) beginRouteRefresh() {
+	if !f.initialized {
+		return
+	}
+
+	f.adjRIBIn.MarkStale()
+}
+
+// endRouteRefresh removes all paths the peer did not re-advertise since the beginning of the route refresh
+func (f *fsmAddressFamily) endRouteRefresh() {
+	if !f.initialized {
+		return
+	}
+
+	n := f.adjRIBIn.RemoveStale()
+	if n > 0 {
+		log.Infof("Removed %d stale %s paths of %s after route refresh", n, packet.AFIName(f.afi), f.fsm.peer.addr.String())
+	}
+}
+
 func (f *fsmAddressFamily) processUpdate(u *packet.BGPUpdate, bmpPostPolicy bool, timestamp uint32) {
-	if f.safi != packet.SAFIUnicast {
+	if f.safi != packet.SAFIUnicast && f.safi != packet.SAFILabeledUnicast {
 		return
 	}
 
-	f.multiProtocolUpdates(u, bmpPostPolicy, timestamp)
-	if f.afi == packet.AFIIPv4 {
+	if f.multiProtocolUpdates(u, bmpPostPolicy, timestamp) {
+		f.endOfRIBReceived()
+	}
+
+	if f.afi == packet.AFIIPv4 && f.safi == packet.SAFIUnicast {
 		if u.IsEndOfRIBMarker() {
-			f.endOfRIBMarkerReceived.Store(true)
+			f.endOfRIBReceived()
 		}
 
 		f.withdraws(u, bmpPostPolicy, timestamp)
@@ -190,6 +311,14 @@ func (f *fsmAddressFamily) processUpdate(u *packet.BGPUpdate, bmpPostPolicy bool
 	}
 }
 
+func (f *fsmAddressFamily) endOfRIBReceived() {
+	f.endOfRIBMarkerReceived.Store(true)
+
+	if f.fsm.peer.gracefulRestart != nil {
+		f.fsm.peer.gracefulRestart.endOfRIB(f.afi)
+	}
+}
+
 func (f *fsmAddressFamily) withdraws(u *packet.BGPUpdate, bmpPostPolicy bool, timestamp uint32) {
 	for r := u.WithdrawnRoutes; r != nil; r = r.Next {
 		f.adjRIBIn.RemovePath(r.Prefix, &route.Path{
@@ -206,15 +335,19 @@ func (f *fsmAddressFamily) updates(u *packet.BGPUpdate, bmpPostPolicy bool, time
 	for r := u.NLRI; r != nil; r = r.Next {
 		path := f.newRoutePath(bmpPostPolicy, timestamp)
 		f.processAttributes(u.PathAttributes, path)
+		f.prependLocalASOverride(path)
 		path.BGPPath.PathIdentifier = u.NLRI.PathIdentifier
 
-		f.adjRIBIn.AddPath(r.Prefix, path)
+		f.addPath(r.Prefix, path)
 	}
 }
 
-func (f *fsmAddressFamily) multiProtocolUpdates(u *packet.BGPUpdate, bmpPostPolicy bool, timestamp uint32) {
+// multiProtocolUpdates processes the MP_REACH_NLRI and MP_UNREACH_NLRI attributes of u. It returns true if u is the
+// End-of-RIB marker of the address family, an UPDATE with an empty MP_UNREACH_NLRI attribute (RFC4724 section 2).
+func (f *fsmAddressFamily) multiProtocolUpdates(u *packet.BGPUpdate, bmpPostPolicy bool, timestamp uint32) bool {
 	path := f.newRoutePath(bmpPostPolicy, timestamp)
 	f.processAttributes(u.PathAttributes, path)
+	f.prependLocalASOverride(path)
 
 	mpReachNLRI, mpUnreachNLRI := getMPReachAndUnreachNLRIs(u)
 
@@ -226,11 +359,11 @@ func (f *fsmAddressFamily) multiProtocolUpdates(u *packet.BGPUpdate, bmpPostPoli
 		f.multiProtocolWithdraw(path, *mpUnreachNLRI)
 	}
 
-	if mpReachNLRI != nil && mpUnreachNLRI != nil {
-		if mpReachNLRI.NLRI == nil && mpUnreachNLRI.NLRI == nil {
-			f.endOfRIBMarkerReceived.Store(true)
-		}
+	if mpUnreachNLRI == nil || mpUnreachNLRI.NLRI != nil || mpUnreachNLRI.AFI != f.afi || mpUnreachNLRI.SAFI != f.safi {
+		return false
 	}
+
+	return mpReachNLRI == nil || mpReachNLRI.NLRI == nil
 }
 
 func getMPReachAndUnreachNLRIs(u *packet.BGPUpdate) (reach *packet.MultiProtocolReachNLRI, unreach *packet.MultiProtocolUnreachNLRI) {
@@ -272,10 +405,23 @@ func (f *fsmAddressFamily) multiProtocolUpdate(path *route.Path, nlri packet.Mul
 	path.BGPPath.BGPPathA.NextHop = nlri.NextHop
 
 	for n := nlri.NLRI; n != nil; n = n.Next {
-		f.adjRIBIn.AddPath(n.Prefix, path)
+		if nlri.SAFI == packet.SAFILabeledUnicast {
+			f.addPath(n.Prefix, labeledPath(path, n))
+			continue
+		}
+
+		f.addPath(n.Prefix, path)
 	}
 }
 
+// labeledPath returns a copy of path carrying the label stack of NLRI n, as labels are per prefix.
+func labeledPath(path *route.Path, n *packet.NLRI) *route.Path {
+	p := path.Copy()
+	p.BGPPath.LabelStack = packet.LabelStackFromEntries(n.LabelStack)
+
+	return p
+}
+
 func (f *fsmAddressFamily) multiProtocolWithdraw(path *route.Path, nlri packet.MultiProtocolUnreachNLRI) {
 	if f.afi != nlri.AFI || f.safi != nlri.SAFI {
 		return
@@ -291,6 +437,7 @@ func (f *fsmAddressFamily) multiProtocolWithdraw(path *route.Path, nlri packet.M
 }
 
 func (f *fsmAddressFamily) processAttributes(attrs *packet.PathAttribute, path *route.Path) {
+	var as4Aggregator *types.Aggregator
 	for pa := attrs; pa != nil; pa = pa.Next {
 		switch pa.TypeCode {
 		case packet.OriginAttr:
@@ -299,6 +446,7 @@ func (f *fsmAddressFamily) processAttributes(attrs *packet.PathAttribute, path *
 			path.BGPPath.BGPPathA.LocalPref = pa.Value.(uint32)
 		case packet.MEDAttr:
 			path.BGPPath.BGPPathA.MED = pa.Value.(uint32)
+			path.BGPPath.BGPPathA.MEDPresent = true
 		case packet.NextHopAttr:
 			path.BGPPath.BGPPathA.NextHop = pa.Value.(*bnet.IP)
 		case packet.ASPathAttr:
@@ -307,6 +455,9 @@ func (f *fsmAddressFamily) processAttributes(attrs *packet.PathAttribute, path *
 		case packet.AggregatorAttr:
 			aggr := pa.Value.(types.Aggregator)
 			path.BGPPath.BGPPathA.Aggregator = &aggr
+		case packet.AS4AggregatorAttr:
+			aggr := pa.Value.(types.Aggregator)
+			as4Aggregator = &aggr
 		case packet.AtomicAggrAttr:
 			path.BGPPath.BGPPathA.AtomicAggregate = true
 		case packet.CommunitiesAttr:
@@ -317,6 +468,12 @@ func (f *fsmAddressFamily) processAttributes(attrs *packet.PathAttribute, path *
 			path.BGPPath.BGPPathA.OriginatorID = pa.Value.(uint32)
 		case packet.ClusterListAttr:
 			path.BGPPath.ClusterList = pa.Value.(*types.ClusterList)
+		case packet.PMSITunnelAttr:
+			path.BGPPath.PMSITunnel = pa.Value.(*types.PMSITunnel)
+		case packet.TunnelEncapsulationAttr:
+			path.BGPPath.TunnelEncapsulation = pa.Value.(*types.TunnelEncapsulation)
+		case packet.PrefixSIDAttr:
+			path.BGPPath.PrefixSID = pa.Value.(*types.PrefixSID)
 		case packet.MultiProtocolReachNLRIAttr:
 		case packet.MultiProtocolUnreachNLRIAttr:
 		default:
@@ -326,8 +483,21 @@ func (f *fsmAddressFamily) processAttributes(attrs *packet.PathAttribute, path *
 			}
 		}
 	}
+
+	// The AS4_AGGREGATOR attribute only replaces an AGGREGATOR carrying AS_TRANS received from a peer not supporting
+	// 4 octet ASNs and is ignored otherwise (RFC6793)
+	if as4Aggregator != nil && !f.fsm.supports4OctetASN {
+		aggr := path.BGPPath.BGPPathA.Aggregator
+		if aggr != nil && aggr.ASN == types.ASTrans {
+			path.BGPPath.BGPPathA.Aggregator = as4Aggregator
+		}
+	}
+
+	path.BGPPath.NormalizeCommunities()
 }
 
+// processUnknownAttribute keeps unrecognized transitive attributes for propagation to other peers (RFC 4271, section 5).
+// Unrecognized non-transitive attributes are quietly ignored.
 func (f *fsmAddressFamily) processUnknownAttribute(attr *packet.PathAttribute) *types.UnknownPathAttribute {
 	if !attr.Transitive {
 		return nil
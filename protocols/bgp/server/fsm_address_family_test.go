@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"testing"
 
+	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
 	"github.com/bio-routing/bio-rd/protocols/bgp/types"
 	"github.com/bio-routing/bio-rd/route"
@@ -117,3 +118,310 @@ func TestProcessAttributes(t *testing.T) {
 
 	assert.Equal(t, 2, i, "Count")
 }
+
+func TestUnknownAttributePassThrough(t *testing.T) {
+	nonTransitive := &packet.PathAttribute{
+		Optional:   true,
+		Transitive: false,
+		TypeCode:   150,
+		Value:      []byte{20},
+	}
+
+	transitive := &packet.PathAttribute{
+		Optional:   true,
+		Transitive: true,
+		TypeCode:   200,
+		Value:      []byte{5, 6, 7},
+		Next:       nonTransitive,
+	}
+
+	asPath := &packet.PathAttribute{
+		Transitive: true,
+		TypeCode:   packet.ASPathAttr,
+		Value:      &types.ASPath{},
+		Next:       transitive,
+	}
+
+	f := &fsmAddressFamily{}
+	p := &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			BGPPathA: route.NewBGPPathA(),
+		},
+	}
+	f.processAttributes(asPath, p)
+
+	assert.Equal(t, []types.UnknownPathAttribute{
+		{
+			Optional:   true,
+			Transitive: true,
+			TypeCode:   200,
+			Value:      []byte{5, 6, 7},
+		},
+	}, p.BGPPath.UnknownAttributes)
+
+	withoutUnknown := p.Copy()
+	withoutUnknown.BGPPath.UnknownAttributes = nil
+	assert.NotEqual(t, withoutUnknown.BGPPath.ComputeHash(), p.BGPPath.ComputeHash())
+
+	attrs, err := packet.PathAttributes(p, false, false)
+	assert.NoError(t, err)
+
+	unknown := make([]*packet.PathAttribute, 0)
+	for pa := attrs; pa != nil; pa = pa.Next {
+		if pa.TypeCode == 150 || pa.TypeCode == 200 {
+			unknown = append(unknown, pa)
+		}
+	}
+
+	if !assert.Len(t, unknown, 1) {
+		return
+	}
+
+	buf := bytes.NewBuffer(nil)
+	unknown[0].Serialize(buf, &packet.EncodeOptions{})
+	assert.Equal(t, []byte{
+		224,     // Optional, transitive, partial
+		200,     // Type
+		3,       // Length
+		5, 6, 7, // Payload
+	}, buf.Bytes())
+}
+
+func TestAggregatorPassThrough(t *testing.T) {
+	tests := []struct {
+		name              string
+		supports4OctetASN bool
+		aggregator        types.Aggregator
+		as4Aggregator     *types.Aggregator
+		expected          types.Aggregator
+	}{
+		{
+			name:              "4 octet ASN session",
+			supports4OctetASN: true,
+			aggregator:        types.Aggregator{ASN: 200000, Address: 100},
+			expected:          types.Aggregator{ASN: 200000, Address: 100},
+		},
+		{
+			name:              "AS4_AGGREGATOR is ignored on 4 octet ASN sessions",
+			supports4OctetASN: true,
+			aggregator:        types.Aggregator{ASN: types.ASTrans, Address: 100},
+			as4Aggregator:     &types.Aggregator{ASN: 200000, Address: 100},
+			expected:          types.Aggregator{ASN: types.ASTrans, Address: 100},
+		},
+		{
+			name:          "AS4_AGGREGATOR replaces AS_TRANS",
+			aggregator:    types.Aggregator{ASN: types.ASTrans, Address: 100},
+			as4Aggregator: &types.Aggregator{ASN: 200000, Address: 100},
+			expected:      types.Aggregator{ASN: 200000, Address: 100},
+		},
+		{
+			name:          "AS4_AGGREGATOR is ignored without AS_TRANS",
+			aggregator:    types.Aggregator{ASN: 65000, Address: 100},
+			as4Aggregator: &types.Aggregator{ASN: 200000, Address: 100},
+			expected:      types.Aggregator{ASN: 65000, Address: 100},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attrs := &packet.PathAttribute{
+				Transitive: true,
+				TypeCode:   packet.AtomicAggrAttr,
+				Next: &packet.PathAttribute{
+					Optional:   true,
+					Transitive: true,
+					TypeCode:   packet.AggregatorAttr,
+					Value:      test.aggregator,
+				},
+			}
+
+			if test.as4Aggregator != nil {
+				attrs.Next.Next = &packet.PathAttribute{
+					Optional:   true,
+					Transitive: true,
+					TypeCode:   packet.AS4AggregatorAttr,
+					Value:      *test.as4Aggregator,
+				}
+			}
+
+			f := &fsmAddressFamily{
+				fsm: &FSM{
+					supports4OctetASN: test.supports4OctetASN,
+				},
+			}
+			p := &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: route.NewBGPPathA(),
+					ASPath:   &types.ASPath{},
+				},
+			}
+			f.processAttributes(attrs, p)
+
+			assert.True(t, p.BGPPath.BGPPathA.AtomicAggregate)
+			assert.Equal(t, &test.expected, p.BGPPath.BGPPathA.Aggregator)
+			assert.Empty(t, p.BGPPath.UnknownAttributes)
+
+			out, err := packet.PathAttributes(p, false, false)
+			assert.NoError(t, err)
+
+			typeCodes := make([]uint8, 0)
+			for pa := out; pa != nil; pa = pa.Next {
+				typeCodes = append(typeCodes, pa.TypeCode)
+				if pa.TypeCode == packet.AggregatorAttr {
+					assert.Equal(t, test.expected, pa.Value)
+				}
+			}
+
+			assert.Contains(t, typeCodes, uint8(packet.AtomicAggrAttr))
+			assert.Contains(t, typeCodes, uint8(packet.AggregatorAttr))
+		})
+	}
+}
+
+func TestMEDPassThrough(t *testing.T) {
+	tests := []struct {
+		name  string
+		attrs *packet.PathAttribute
+	}{
+		{
+			name: "MED 0",
+			attrs: &packet.PathAttribute{
+				Optional: true,
+				TypeCode: packet.MEDAttr,
+				Value:    uint32(0),
+			},
+		},
+		{
+			name: "No MED",
+			attrs: &packet.PathAttribute{
+				Transitive: true,
+				TypeCode:   packet.AtomicAggrAttr,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := &fsmAddressFamily{
+				fsm: &FSM{},
+			}
+			p := &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: route.NewBGPPathA(),
+					ASPath:   &types.ASPath{},
+				},
+			}
+			f.processAttributes(test.attrs, p)
+
+			medPresent := test.attrs.TypeCode == packet.MEDAttr
+			assert.Equal(t, medPresent, p.BGPPath.BGPPathA.MEDPresent)
+
+			out, err := packet.PathAttributes(p, false, false)
+			assert.NoError(t, err)
+
+			med := false
+			for pa := out; pa != nil; pa = pa.Next {
+				if pa.TypeCode == packet.MEDAttr {
+					med = true
+					assert.Equal(t, uint32(0), pa.Value)
+				}
+			}
+
+			assert.Equal(t, medPresent, med)
+		})
+	}
+}
+
+func TestPrependLocalASOverride(t *testing.T) {
+	tests := []struct {
+		name            string
+		localASOverride *LocalASOverride
+		dualASFallback  bool
+		expected        []uint32
+	}{
+		{
+			name:     "no override",
+			expected: []uint32{65100},
+		},
+		{
+			name: "override",
+			localASOverride: &LocalASOverride{
+				ASN: 65001,
+			},
+			expected: []uint32{65001, 65100},
+		},
+		{
+			name: "override with no-prepend",
+			localASOverride: &LocalASOverride{
+				ASN:       65001,
+				NoPrepend: true,
+			},
+			expected: []uint32{65100},
+		},
+		{
+			name: "dual-as using real ASN",
+			localASOverride: &LocalASOverride{
+				ASN:    65001,
+				DualAS: true,
+			},
+			dualASFallback: true,
+			expected:       []uint32{65100},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := &fsmAddressFamily{
+				fsm: &FSM{
+					peer: &peer{
+						localASN:        65000,
+						localASOverride: test.localASOverride,
+					},
+					dualASFallback: test.dualASFallback,
+				},
+			}
+
+			p := &route.Path{
+				BGPPath: &route.BGPPath{},
+			}
+			f.processAttributes(&packet.PathAttribute{
+				TypeCode: packet.ASPathAttr,
+				Value: &types.ASPath{
+					types.ASPathSegment{
+						Type: types.ASSequence,
+						ASNs: []uint32{65100},
+					},
+				},
+			}, p)
+			f.prependLocalASOverride(p)
+
+			assert.Equal(t, test.expected, (*p.BGPPath.ASPath)[0].ASNs)
+			assert.Equal(t, uint16(len(test.expected)), p.BGPPath.ASPathLen)
+		})
+	}
+}
+
+func TestLabeledPath(t *testing.T) {
+	path := &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			BGPPathA: &route.BGPPathA{
+				NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+			},
+		},
+	}
+
+	p := labeledPath(path, &packet.NLRI{
+		LabelStack: []packet.LabelStackEntry{
+			packet.NewLabelStackEntry(16001),
+			packet.NewLabelStackEntry(types.LabelImplicitNull),
+		},
+	})
+
+	assert.Equal(t, &types.LabelStack{16001, types.LabelImplicitNull}, p.BGPPath.LabelStack)
+	assert.Equal(t, path.BGPPath.BGPPathA.NextHop, p.BGPPath.BGPPathA.NextHop)
+	assert.Nil(t, path.BGPPath.LabelStack, "original path must not be modified")
+}
@@ -57,6 +57,7 @@ func (s *connectState) connectRetryTimerExpired() {
 }
 
 func (s *connectState) manualStop() (state, string) {
+	s.fsm.takeStopNotification()
 	s.fsm.resetConnectRetryCounter()
 	stopTimer(s.fsm.connectRetryTimer)
 	return newIdleState(s.fsm), "Manual stop event"
@@ -1,11 +1,15 @@
 package server
 
 type fsmCounters struct {
-	updatesReceived uint64
-	updatesSent     uint64
+	updatesReceived         uint64
+	updatesSent             uint64
+	routeRefreshesReceived  uint64
+	routeRefreshesCoalesced uint64
 }
 
 func (c *fsmCounters) reset() {
 	c.updatesReceived = 0
 	c.updatesSent = 0
+	c.routeRefreshesReceived = 0
+	c.routeRefreshesCoalesced = 0
 }
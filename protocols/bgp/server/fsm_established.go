@@ -42,18 +42,28 @@ func (s establishedState) run() (state, string) {
 			default:
 				continue
 			}
-		case <-s.fsm.keepaliveTimer.C:
+		case <-s.fsm.keepaliveTimerC():
 			return s.keepaliveTimerExpired()
-		case <-time.After(time.Second):
+		case <-s.fsm.routeRefreshTimerC():
+			s.fsm.runPendingRefreshes()
+			return newEstablishedState(s.fsm), s.fsm.reason
+		case <-s.fsm.clock.After(time.Second):
 			return s.checkHoldtimer()
 		case recvMsg := <-s.fsm.msgRecvCh:
-			return s.msgReceived(recvMsg, opt, false, uint32(time.Now().Unix()))
+			return s.msgReceived(recvMsg, opt, false, uint32(s.fsm.clock.Now().Unix()))
+		case f := <-s.fsm.msgRecvFailCh:
+			// Failures of connections closed before are left over in the channel
+			if f.con != s.fsm.con {
+				continue
+			}
+
+			return s.tcpConnectionFails(f.err)
 		}
 	}
 }
 
 func (s *establishedState) checkHoldtimer() (state, string) {
-	if time.Since(s.fsm.lastUpdateOrKeepalive) > s.fsm.holdTime {
+	if s.fsm.holdTimerExpired() {
 		return s.holdTimerExpired()
 	}
 
@@ -63,10 +73,21 @@ func (s *establishedState) checkHoldtimer() (state, string) {
 func (s *establishedState) init() error {
 	if s.fsm.ipv4Unicast != nil {
 		s.fsm.ipv4Unicast.init()
+		s.fsm.ipv4Unicast.sendORFs()
 	}
 
 	if s.fsm.ipv6Unicast != nil {
 		s.fsm.ipv6Unicast.init()
+		s.fsm.ipv6Unicast.sendORFs()
+	}
+
+	if s.fsm.linkStateNegotiated {
+		s.fsm.linkState = newLinkStateSender(s.fsm, s.fsm.peer.linkState)
+		s.fsm.linkState.start()
+	}
+
+	if s.fsm.peer.gracefulRestart != nil {
+		s.fsm.peer.gracefulRestart.restarted()
 	}
 
 	s.fsm.ribsInitialized = true
@@ -82,16 +103,22 @@ func (s *establishedState) uninit() {
 		s.fsm.ipv6Unicast.dispose()
 	}
 
+	if s.fsm.linkState != nil {
+		s.fsm.linkState.stop()
+		s.fsm.linkState = nil
+	}
+
+	s.fsm.cancelPendingRefreshes()
 	s.fsm.counters.reset()
 
 	s.fsm.ribsInitialized = false
 }
 
 func (s *establishedState) manualStop() (state, string) {
-	s.fsm.sendNotification(packet.Cease, 0)
+	s.fsm.sendStopNotification()
 	s.uninit()
 	stopTimer(s.fsm.connectRetryTimer)
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	s.fsm.connectRetryCounter = 0
 	return newIdleState(s.fsm), "Manual stop event"
 }
@@ -100,7 +127,7 @@ func (s *establishedState) automaticStop() (state, string) {
 	s.fsm.sendNotification(packet.Cease, 0)
 	s.uninit()
 	stopTimer(s.fsm.connectRetryTimer)
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	s.fsm.connectRetryCounter++
 	return newIdleState(s.fsm), "Automatic stop event"
 }
@@ -108,7 +135,7 @@ func (s *establishedState) automaticStop() (state, string) {
 func (s *establishedState) cease() (state, string) {
 	s.fsm.sendNotification(packet.Cease, 0)
 	s.uninit()
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	return newCeaseState(), "Cease"
 }
 
@@ -116,17 +143,27 @@ func (s *establishedState) holdTimerExpired() (state, string) {
 	s.fsm.sendNotification(packet.HoldTimeExpired, 0)
 	s.uninit()
 	stopTimer(s.fsm.connectRetryTimer)
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	s.fsm.connectRetryCounter++
 	return newIdleState(s.fsm), "Holdtimer expired"
 }
 
+// tcpConnectionFails retains the paths received from a graceful restart capable peer as it might be restarting
+func (s *establishedState) tcpConnectionFails(err error) (state, string) {
+	s.fsm.retainStalePaths()
+	s.uninit()
+	stopTimer(s.fsm.connectRetryTimer)
+	s.fsm.closeConnection()
+	s.fsm.connectRetryCounter++
+	return newIdleState(s.fsm), fmt.Sprintf("TCP connection failure: %v", err)
+}
+
 func (s *establishedState) keepaliveTimerExpired() (state, string) {
 	err := s.fsm.sendKeepalive()
 	if err != nil {
 		s.uninit()
 		stopTimer(s.fsm.connectRetryTimer)
-		s.fsm.con.Close()
+		s.fsm.closeConnection()
 		s.fsm.connectRetryCounter++
 		return newIdleState(s.fsm), fmt.Sprintf("Failed to send keepalive: %v", err)
 	}
@@ -138,37 +175,33 @@ func (s *establishedState) keepaliveTimerExpired() (state, string) {
 func (s *establishedState) msgReceived(data []byte, opt *packet.DecodeOptions, bmpPostPolicy bool, timestamp uint32) (state, string) {
 	msg, err := packet.Decode(bytes.NewBuffer(data), opt)
 	if err != nil {
-		switch bgperr := err.(type) {
-		case packet.BGPError:
-			s.fsm.sendNotification(bgperr.ErrorCode, bgperr.ErrorSubCode)
-		}
+		s.fsm.sendDecodeErrorNotification(err)
 		stopTimer(s.fsm.connectRetryTimer)
-		if s.fsm.con != nil {
-			s.fsm.con.Close()
-		}
+		s.fsm.closeConnection()
 		s.fsm.connectRetryCounter++
 		return newIdleState(s.fsm), fmt.Sprintf("Failed to decode BGP message: %v", err)
 	}
 
 	switch msg.Header.Type {
 	case packet.NotificationMsg:
-		fmt.Println(data)
-		return s.notification()
+		return s.notification(msg.Body.(*packet.BGPNotification))
 	case packet.UpdateMsg:
 		return s.update(msg.Body.(*packet.BGPUpdate), bmpPostPolicy, timestamp)
 	case packet.KeepaliveMsg:
 		return s.keepaliveReceived()
+	case packet.RouteRefreshMsg:
+		return s.routeRefresh(msg.Body.(*packet.BGPRouteRefresh))
 	default:
 		return s.unexpectedMessage()
 	}
 }
 
-func (s *establishedState) notification() (state, string) {
+func (s *establishedState) notification(n *packet.BGPNotification) (state, string) {
 	stopTimer(s.fsm.connectRetryTimer)
 	s.uninit()
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	s.fsm.connectRetryCounter++
-	return newIdleState(s.fsm), "Received NOTIFICATION"
+	return newIdleState(s.fsm), s.fsm.receivedNotificationReason(n)
 }
 
 func (s *establishedState) update(u *packet.BGPUpdate, bmpPostPolicy bool, timestemp uint32) (state, string) {
@@ -186,29 +219,34 @@ func (s *establishedState) update(u *packet.BGPUpdate, bmpPostPolicy bool, times
 		s.fsm.ipv6Unicast.processUpdate(u, bmpPostPolicy, timestemp)
 	}
 
+	if f := s.fsm.prefixLimitExceeded(); f != nil {
+		return s.prefixLimitExceeded(f)
+	}
+
 	afi, safi := s.updateAddressFamily(u)
 
-	if safi != packet.SAFIUnicast {
-		// only unicast support, so other SAFIs are ignored
+	if safi != packet.SAFIUnicast && safi != packet.SAFILabeledUnicast {
+		// only (labeled) unicast support, so other SAFIs are ignored
 		return newEstablishedState(s.fsm), s.fsm.reason
 	}
 
-	switch afi {
-	case packet.AFIIPv4:
-		if s.fsm.ipv4Unicast == nil {
-			log.Info("Received update for family IPv4 unicast, but this family is not configured.")
-		}
-
-	case packet.AFIIPv6:
-		if s.fsm.ipv6Unicast == nil {
-			log.Info("Received update for family IPv6 unicast, but this family is not configured.")
-		}
-
+	if s.fsm.addressFamily(afi, safi) == nil {
+		log.Infof("Received update for family AFI %d SAFI %d, but this family is not configured.", afi, safi)
 	}
 
 	return newEstablishedState(s.fsm), s.fsm.reason
 }
 
+func (s *establishedState) prefixLimitExceeded(f *fsmAddressFamily) (state, string) {
+	s.fsm.sendNotificationMsg(f.prefixLimitNotification())
+	s.uninit()
+	stopTimer(s.fsm.connectRetryTimer)
+	s.fsm.closeConnection()
+	s.fsm.connectRetryCounter++
+	s.fsm.peer.prefixLimitShutdown(f.prefixLimit, s.fsm.establishedTime)
+	return newIdleState(s.fsm), fmt.Sprintf("Prefix limit of %d exceeded for AFI %d SAFI %d", f.prefixLimit.Limit, f.afi, f.safi)
+}
+
 func (s *establishedState) updateAddressFamily(u *packet.BGPUpdate) (afi uint16, safi uint8) {
 	if u.WithdrawnRoutes != nil || u.NLRI != nil {
 		return packet.AFIIPv4, packet.SAFIUnicast
@@ -236,11 +274,37 @@ func (s *establishedState) keepaliveReceived() (state, string) {
 	return newEstablishedState(s.fsm), s.fsm.reason
 }
 
+func (s *establishedState) routeRefresh(rr *packet.BGPRouteRefresh) (state, string) {
+	f := s.fsm.addressFamily(rr.AFI, rr.SAFI)
+	if f == nil || !s.fsm.routeRefresh {
+		// RFC2918: A request for an AFI/SAFI not advertised to the peer is ignored
+		log.Infof("Ignoring ROUTE-REFRESH for %s from %s", packet.AFIName(rr.AFI), s.fsm.peer.addr.String())
+		return newEstablishedState(s.fsm), s.fsm.reason
+	}
+
+	switch rr.Subtype {
+	case packet.RouteRefreshNormal:
+		s.fsm.requestRefresh(f)
+	case packet.RouteRefreshBoRR:
+		if s.fsm.enhancedRouteRefresh {
+			f.beginRouteRefresh()
+		}
+	case packet.RouteRefreshEoRR:
+		if s.fsm.enhancedRouteRefresh {
+			f.endRouteRefresh()
+		}
+	default:
+		// RFC7313: Messages with unknown subtypes must be ignored
+	}
+
+	return newEstablishedState(s.fsm), s.fsm.reason
+}
+
 func (s *establishedState) unexpectedMessage() (state, string) {
-	s.fsm.sendNotification(packet.FiniteStateMachineError, 0)
+	s.fsm.sendNotification(packet.FiniteStateMachineError, packet.UnexpectedMessageInEstablishedState)
 	s.uninit()
 	stopTimer(s.fsm.connectRetryTimer)
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	s.fsm.connectRetryCounter++
 	return newIdleState(s.fsm), "FSM Error"
 }
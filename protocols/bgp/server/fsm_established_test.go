@@ -0,0 +1,318 @@
+package server
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/adjRIBIn"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	btesting "github.com/bio-routing/bio-rd/testing"
+	btime "github.com/bio-routing/bio-rd/util/time"
+	"github.com/stretchr/testify/assert"
+)
+
+func newHoldTimerTestFSM(clock *btime.MockClock) *FSM {
+	fsm := newFSM(&peer{
+		addr: bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+	})
+	fsm.clock = clock
+	fsm.con = btesting.NewMockConn()
+	fsm.holdTime = 3 * time.Second
+	fsm.keepaliveTime = time.Second
+	fsm.keepaliveTimer = clock.NewTimer(time.Hour)
+	fsm.ribsInitialized = true
+	fsm.updateLastUpdateOrKeepalive()
+
+	return fsm
+}
+
+func TestEstablishedCheckHoldtimer(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	fsm := newHoldTimerTestFSM(clock)
+	s := newEstablishedState(fsm)
+
+	clock.Advance(2 * time.Second)
+	next, _ := s.checkHoldtimer()
+	assert.IsType(t, &establishedState{}, next)
+
+	clock.Advance(2 * time.Second)
+	next, reason := s.checkHoldtimer()
+	assert.IsType(t, &idleState{}, next)
+	assert.Equal(t, "Holdtimer expired", reason)
+	assert.Equal(t, 1, fsm.connectRetryCounter)
+	assert.True(t, fsm.con.(*btesting.MockConn).Closed)
+}
+
+func TestEstablishedHoldTimerExpiry(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	fsm := newHoldTimerTestFSM(clock)
+
+	type result struct {
+		next   state
+		reason string
+	}
+
+	var s state = newEstablishedState(fsm)
+	for i := 0; i < 5; i++ {
+		resCh := make(chan result)
+		go func() {
+			next, reason := s.run()
+			resCh <- result{next: next, reason: reason}
+		}()
+
+		// Wait for the state to wait for the keepalive timer and the hold timer check
+		for clock.PendingTimers() < 2 {
+			runtime.Gosched()
+		}
+
+		clock.Advance(time.Second)
+		res := <-resCh
+		s = res.next
+
+		if i < 3 {
+			assert.IsType(t, &establishedState{}, res.next, "after %d seconds", i+1)
+			continue
+		}
+
+		assert.IsType(t, &idleState{}, res.next, "after %d seconds", i+1)
+		assert.Equal(t, "Holdtimer expired", res.reason)
+		return
+	}
+
+	t.Errorf("hold timer did not expire")
+}
+
+func TestHoldTimeZero(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	fsm := newFSM(&peer{
+		addr:     bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+		peerASN:  65001,
+		holdTime: 90 * time.Second,
+	})
+	fsm.clock = clock
+	con := btesting.NewMockConn()
+	fsm.con = con
+
+	s := &openSentState{
+		fsm: fsm,
+	}
+
+	next, _ := s.handleOpenMessage(&packet.BGPOpen{
+		Version:       4,
+		ASN:           65001,
+		HoldTime:      0,
+		BGPIdentifier: 1,
+	})
+	assert.IsType(t, &openConfirmState{}, next)
+	assert.Equal(t, time.Duration(0), fsm.holdTime)
+	assert.Nil(t, fsm.keepaliveTimer)
+
+	fsm.msgRecvCh <- packet.SerializeKeepaliveMsg()
+	next, _ = next.run()
+	assert.IsType(t, &establishedState{}, next)
+
+	// Expire the hold timer check left behind by the OpenConfirm state
+	clock.Advance(time.Second)
+
+	fsm.ribsInitialized = true
+	for i := 0; i < 10; i++ {
+		resCh := make(chan state)
+		go func() {
+			res, _ := next.run()
+			resCh <- res
+		}()
+
+		// Wait for the state to wait for the hold timer check. There must not be a keepalive timer.
+		for clock.PendingTimers() < 1 {
+			runtime.Gosched()
+		}
+		assert.Equal(t, 1, clock.PendingTimers())
+
+		clock.Advance(time.Minute)
+		next = <-resCh
+		assert.IsType(t, &establishedState{}, next, "after %d minutes", i+1)
+	}
+
+	assert.Equal(t, 0, con.Buf.Len(), "no keepalive must be sent")
+	assert.False(t, con.Closed)
+}
+
+func TestUnacceptableHoldTime(t *testing.T) {
+	fsm := newFSM(&peer{
+		peerASN:  65001,
+		holdTime: 90 * time.Second,
+	})
+	con := btesting.NewMockConn()
+	fsm.con = con
+
+	s := &openSentState{
+		fsm: fsm,
+	}
+
+	next, reason := s.handleOpenMessage(&packet.BGPOpen{
+		Version:       4,
+		ASN:           65001,
+		HoldTime:      2,
+		BGPIdentifier: 1,
+	})
+	assert.IsType(t, &idleState{}, next)
+	assert.Equal(t, "Unacceptable hold time 2", reason)
+
+	msg, err := packet.Decode(con.Buf, &packet.DecodeOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(packet.UnacceptableHoldTime), msg.Body.(*packet.BGPNotification).ErrorSubcode)
+}
+
+func newRouteRefreshTestFSM(enhanced bool) *FSM {
+	fsm := newFSM(&peer{
+		addr:     bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+		routerID: bnet.IPv4FromOctets(1, 1, 1, 1).Ptr().ToUint32(),
+		localASN: 65000,
+		peerASN:  65001,
+		ipv4: &peerAddressFamily{
+			rib:               locRIB.New("inet.0"),
+			importFilterChain: filter.NewAcceptAllFilterChain(),
+			exportFilterChain: filter.NewAcceptAllFilterChain(),
+		},
+	})
+	fsm.con = btesting.NewMockConn()
+	fsm.routeRefresh = true
+	fsm.enhancedRouteRefresh = enhanced
+
+	f := fsm.ipv4Unicast
+	f.adjRIBIn = adjRIBIn.New(filter.NewAcceptAllFilterChain(), routingtable.NewContributingASNs(), f.getSessionAttrs())
+	f.adjRIBIn.Register(f.rib)
+	f.initialized = true
+
+	return fsm
+}
+
+func routeRefreshTestUpdate(t *testing.T, pfxs ...*bnet.Prefix) []byte {
+	u := &packet.BGPUpdate{
+		PathAttributes: &packet.PathAttribute{
+			TypeCode: packet.OriginAttr,
+			Value:    uint8(packet.IGP),
+			Next: &packet.PathAttribute{
+				TypeCode: packet.ASPathAttr,
+				Value: &types.ASPath{
+					{
+						Type: types.ASSequence,
+						ASNs: []uint32{65001},
+					},
+				},
+				Next: &packet.PathAttribute{
+					TypeCode: packet.NextHopAttr,
+					Value:    bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+				},
+			},
+		},
+	}
+
+	for _, pfx := range pfxs {
+		u.NLRI = &packet.NLRI{
+			Prefix: pfx,
+			Next:   u.NLRI,
+		}
+	}
+
+	b, err := u.SerializeUpdate(&packet.EncodeOptions{})
+	assert.NoError(t, err)
+	return b
+}
+
+func TestEstablishedEnhancedRouteRefresh(t *testing.T) {
+	pfx1 := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	pfx2 := bnet.NewPfx(bnet.IPv4FromOctets(11, 0, 0, 0), 8).Ptr()
+	pfx3 := bnet.NewPfx(bnet.IPv4FromOctets(12, 0, 0, 0), 8).Ptr()
+
+	tests := []struct {
+		name       string
+		enhanced   bool
+		subtype    uint8
+		afi        uint16
+		expected   int64
+		expectStay []*bnet.Prefix
+	}{
+		{
+			name:       "stale routes are removed after EoRR",
+			enhanced:   true,
+			afi:        packet.AFIIPv4,
+			expected:   2,
+			expectStay: []*bnet.Prefix{pfx1, pfx3},
+		},
+		{
+			name:       "demarcation is ignored without enhanced route refresh",
+			afi:        packet.AFIIPv4,
+			expected:   3,
+			expectStay: []*bnet.Prefix{pfx1, pfx2, pfx3},
+		},
+		{
+			name:       "demarcation for unconfigured AFI is ignored",
+			enhanced:   true,
+			afi:        packet.AFIIPv6,
+			expected:   3,
+			expectStay: []*bnet.Prefix{pfx1, pfx2, pfx3},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fsm := newRouteRefreshTestFSM(test.enhanced)
+			f := fsm.ipv4Unicast
+			s := newEstablishedState(fsm)
+
+			receive := func(msg []byte) {
+				next, _ := s.msgReceived(msg, fsm.decodeOptions(), false, 0)
+				assert.IsType(t, &establishedState{}, next)
+			}
+
+			receive(routeRefreshTestUpdate(t, pfx1, pfx2, pfx3))
+			assert.Equal(t, int64(3), f.rib.RouteCount())
+
+			receive(packet.SerializeRouteRefreshMsg(&packet.BGPRouteRefresh{
+				AFI:     test.afi,
+				Subtype: packet.RouteRefreshBoRR,
+				SAFI:    packet.SAFIUnicast,
+			}))
+
+			// The peer re-advertises all routes but 11.0.0.0/8
+			receive(routeRefreshTestUpdate(t, pfx1, pfx3))
+			assert.Equal(t, int64(3), f.rib.RouteCount(), "routes must not be removed before EoRR")
+
+			receive(packet.SerializeRouteRefreshMsg(&packet.BGPRouteRefresh{
+				AFI:     test.afi,
+				Subtype: packet.RouteRefreshEoRR,
+				SAFI:    packet.SAFIUnicast,
+			}))
+
+			assert.Equal(t, test.expected, f.rib.RouteCount())
+			for _, pfx := range test.expectStay {
+				assert.NotNil(t, f.rib.Get(pfx), pfx.String())
+			}
+		})
+	}
+}
+
+func TestEstablishedRouteRefreshUnknownSubtype(t *testing.T) {
+	fsm := newRouteRefreshTestFSM(true)
+	s := newEstablishedState(fsm)
+
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	next, _ := s.msgReceived(routeRefreshTestUpdate(t, pfx), fsm.decodeOptions(), false, 0)
+	assert.IsType(t, &establishedState{}, next)
+
+	next, _ = s.msgReceived(packet.SerializeRouteRefreshMsg(&packet.BGPRouteRefresh{
+		AFI:     packet.AFIIPv4,
+		Subtype: 3,
+		SAFI:    packet.SAFIUnicast,
+	}), fsm.decodeOptions(), false, 0)
+	assert.IsType(t, &establishedState{}, next, "unknown subtypes must be ignored")
+	assert.Equal(t, 0, fsm.con.(*btesting.MockConn).Buf.Len(), "nothing must be sent for unknown subtypes")
+	assert.Equal(t, int64(1), fsm.ipv4Unicast.rib.RouteCount())
+}
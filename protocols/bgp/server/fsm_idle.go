@@ -16,7 +16,8 @@ func newIdleState(fsm *FSM) *idleState {
 }
 
 func (s idleState) run() (state, string) {
-	if s.fsm.peer.reconnectInterval != 0 {
+	// Passive peers wait for the next incoming connection instead of reconnecting
+	if s.fsm.peer.reconnectInterval != 0 && !s.fsm.peer.passive && !s.fsm.peer.adminDown.Load() {
 		time.Sleep(s.fsm.peer.reconnectInterval)
 		go s.fsm.activate()
 	}
@@ -27,6 +28,9 @@ func (s idleState) run() (state, string) {
 			return s.manualStart()
 		case AutomaticStart:
 			return s.automaticStart()
+		case ManualStop:
+			s.fsm.takeStopNotification()
+			continue
 		case Cease:
 			return newCeaseState(), "Cease"
 		default:
@@ -48,6 +52,10 @@ func (s *idleState) automaticStart() (state, string) {
 func (s *idleState) start() (state, string) {
 	s.fsm.resetConnectRetryCounter()
 	s.fsm.startConnectRetryTimer()
+	if s.fsm.peer.passive {
+		return newActiveState(s.fsm), s.newStateReason
+	}
+
 	go s.fsm.tcpConnect()
 
 	return newConnectState(s.fsm), s.newStateReason
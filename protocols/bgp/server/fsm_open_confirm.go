@@ -29,12 +29,14 @@ func (s openConfirmState) run() (state, string) {
 				return s.manualStop()
 			case Cease:
 				return s.cease()
+			case ConnectionCollision:
+				return s.connectionCollision()
 			default:
 				continue
 			}
-		case <-time.After(time.Second):
+		case <-s.fsm.clock.After(time.Second):
 			return s.checkHoldtimer()
-		case <-s.fsm.keepaliveTimer.C:
+		case <-s.fsm.keepaliveTimerC():
 			return s.keepaliveTimerExpired()
 		case recvMsg := <-s.fsm.msgRecvCh:
 			return s.msgReceived(recvMsg, opt)
@@ -43,7 +45,7 @@ func (s openConfirmState) run() (state, string) {
 }
 
 func (s *openConfirmState) checkHoldtimer() (state, string) {
-	if time.Since(s.fsm.lastUpdateOrKeepalive) > s.fsm.holdTime {
+	if s.fsm.holdTimerExpired() {
 		return s.holdTimerExpired()
 	}
 
@@ -51,23 +53,30 @@ func (s *openConfirmState) checkHoldtimer() (state, string) {
 }
 
 func (s *openConfirmState) manualStop() (state, string) {
-	s.fsm.sendNotification(packet.Cease, 0)
+	s.fsm.sendStopNotification()
 	stopTimer(s.fsm.connectRetryTimer)
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	s.fsm.resetConnectRetryCounter()
 	return newIdleState(s.fsm), "Manual stop event"
 }
 
 func (s *openConfirmState) cease() (state, string) {
 	s.fsm.sendNotification(packet.Cease, 0)
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	return newCeaseState(), "Cease"
 }
 
+func (s *openConfirmState) connectionCollision() (state, string) {
+	s.fsm.sendNotification(packet.Cease, packet.ConnectionCollisionResolution)
+	stopTimer(s.fsm.connectRetryTimer)
+	s.fsm.closeConnection()
+	return newCeaseState(), "Connection collision"
+}
+
 func (s *openConfirmState) holdTimerExpired() (state, string) {
 	s.fsm.sendNotification(packet.HoldTimeExpired, 0)
 	stopTimer(s.fsm.connectRetryTimer)
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	s.fsm.connectRetryCounter++
 	return newIdleState(s.fsm), "Holdtimer expired"
 }
@@ -76,7 +85,7 @@ func (s *openConfirmState) keepaliveTimerExpired() (state, string) {
 	err := s.fsm.sendKeepalive()
 	if err != nil {
 		stopTimer(s.fsm.connectRetryTimer)
-		s.fsm.con.Close()
+		s.fsm.closeConnection()
 		s.fsm.connectRetryCounter++
 		return newIdleState(s.fsm), fmt.Sprintf("Failed to send keepalive: %v", err)
 	}
@@ -87,12 +96,9 @@ func (s *openConfirmState) keepaliveTimerExpired() (state, string) {
 func (s *openConfirmState) msgReceived(data []byte, opt *packet.DecodeOptions) (state, string) {
 	msg, err := packet.Decode(bytes.NewBuffer(data), opt)
 	if err != nil {
-		switch bgperr := err.(type) {
-		case packet.BGPError:
-			s.fsm.sendNotification(bgperr.ErrorCode, bgperr.ErrorSubCode)
-		}
+		s.fsm.sendDecodeErrorNotification(err)
 		stopTimer(s.fsm.connectRetryTimer)
-		s.fsm.con.Close()
+		s.fsm.closeConnection()
 		s.fsm.connectRetryCounter++
 		return newIdleState(s.fsm), fmt.Sprintf("Failed to decode BGP message: %v", err)
 	}
@@ -108,13 +114,15 @@ func (s *openConfirmState) msgReceived(data []byte, opt *packet.DecodeOptions) (
 
 func (s *openConfirmState) notification(msg *packet.BGPMessage) (state, string) {
 	stopTimer(s.fsm.connectRetryTimer)
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	nMsg := msg.Body.(*packet.BGPNotification)
 	if nMsg.ErrorCode != packet.UnsupportedVersionNumber {
 		s.fsm.connectRetryCounter++
 	}
 
-	return newIdleState(s.fsm), "Received NOTIFICATION"
+	s.fsm.handleBadPeerAS(nMsg)
+
+	return newIdleState(s.fsm), s.fsm.receivedNotificationReason(nMsg)
 }
 
 func (s *openConfirmState) keepaliveReceived() (state, string) {
@@ -123,9 +131,9 @@ func (s *openConfirmState) keepaliveReceived() (state, string) {
 }
 
 func (s *openConfirmState) unexpectedMessage() (state, string) {
-	s.fsm.sendNotification(packet.FiniteStateMachineError, 0)
+	s.fsm.sendNotification(packet.FiniteStateMachineError, packet.UnexpectedMessageInOpenConfirmState)
 	stopTimer(s.fsm.connectRetryTimer)
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	s.fsm.connectRetryCounter++
 	return newIdleState(s.fsm), "FSM Error"
 }
@@ -22,6 +22,7 @@ func newOpenSentState(fsm *FSM) *openSentState {
 }
 
 func (s openSentState) run() (state, string) {
+	s.fsm.discardQueuedMsgs()
 	go s.fsm.msgReceiver()
 
 	opt := s.fsm.decodeOptions()
@@ -39,7 +40,7 @@ func (s openSentState) run() (state, string) {
 			default:
 				continue
 			}
-		case <-time.After(time.Second):
+		case <-s.fsm.clock.After(time.Second):
 			return s.checkHoldtimer()
 		case recvMsg := <-s.fsm.msgRecvCh:
 			return s.msgReceived(recvMsg, opt)
@@ -48,7 +49,7 @@ func (s openSentState) run() (state, string) {
 }
 
 func (s *openSentState) checkHoldtimer() (state, string) {
-	if time.Since(s.fsm.lastUpdateOrKeepalive) > s.fsm.holdTime {
+	if s.fsm.holdTimerExpired() {
 		return s.holdTimerExpired()
 	}
 
@@ -56,9 +57,9 @@ func (s *openSentState) checkHoldtimer() (state, string) {
 }
 
 func (s *openSentState) manualStop() (state, string) {
-	s.fsm.sendNotification(packet.Cease, 0)
+	s.fsm.sendStopNotification()
 	s.fsm.resetConnectRetryTimer()
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	s.fsm.resetConnectRetryCounter()
 	return newIdleState(s.fsm), "Manual stop event"
 }
@@ -66,21 +67,27 @@ func (s *openSentState) manualStop() (state, string) {
 func (s *openSentState) automaticStop() (state, string) {
 	s.fsm.sendNotification(packet.Cease, 0)
 	s.fsm.resetConnectRetryTimer()
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	s.fsm.connectRetryCounter++
 	return newIdleState(s.fsm), "Automatic stop event"
 }
 
 func (s *openSentState) cease() (state, string) {
 	s.fsm.sendNotification(packet.Cease, 0)
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	return newCeaseState(), "Cease"
 }
 
+func (s *openSentState) connectionCollision() (state, string) {
+	s.fsm.sendNotification(packet.Cease, packet.ConnectionCollisionResolution)
+	s.fsm.closeConnection()
+	return newCeaseState(), "Connection collision"
+}
+
 func (s *openSentState) holdTimerExpired() (state, string) {
 	s.fsm.sendNotification(packet.HoldTimeExpired, 0)
 	stopTimer(s.fsm.connectRetryTimer)
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	s.fsm.connectRetryCounter++
 	return newIdleState(s.fsm), "Holdtimer expired"
 }
@@ -88,12 +95,9 @@ func (s *openSentState) holdTimerExpired() (state, string) {
 func (s *openSentState) msgReceived(data []byte, opt *packet.DecodeOptions) (state, string) {
 	msg, err := packet.Decode(bytes.NewBuffer(data), opt)
 	if err != nil {
-		switch bgperr := err.(type) {
-		case packet.BGPError:
-			s.fsm.sendNotification(bgperr.ErrorCode, bgperr.ErrorSubCode)
-		}
+		s.fsm.sendDecodeErrorNotification(err)
 		stopTimer(s.fsm.connectRetryTimer)
-		s.fsm.con.Close()
+		s.fsm.closeConnection()
 		s.fsm.connectRetryCounter++
 		return newIdleState(s.fsm), fmt.Sprintf("Failed to decode BGP message: %v", err)
 	}
@@ -108,9 +112,9 @@ func (s *openSentState) msgReceived(data []byte, opt *packet.DecodeOptions) (sta
 }
 
 func (s *openSentState) unexpectedMessage() (state, string) {
-	s.fsm.sendNotification(packet.FiniteStateMachineError, 0)
+	s.fsm.sendNotification(packet.FiniteStateMachineError, packet.UnexpectedMessageInOpenSentState)
 	stopTimer(s.fsm.connectRetryTimer)
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	s.fsm.connectRetryCounter++
 	return newIdleState(s.fsm), "FSM Error"
 }
@@ -126,7 +130,7 @@ func (s *openSentState) openMsgReceived(openMsg *packet.BGPOpen) (state, string)
 
 	stopTimer(s.fsm.connectRetryTimer)
 	if s.fsm.peer.collisionHandling(s.fsm) {
-		return s.cease()
+		return s.connectionCollision()
 	}
 	err := s.fsm.sendKeepalive()
 	if err != nil {
@@ -137,14 +141,28 @@ func (s *openSentState) openMsgReceived(openMsg *packet.BGPOpen) (state, string)
 }
 
 func (s *openSentState) handleOpenMessage(openMsg *packet.BGPOpen) (state, string) {
-	s.fsm.holdTime = time.Duration(math.Min(float64(s.fsm.peer.holdTime), float64(time.Duration(openMsg.HoldTime)*time.Second)))
-	if s.fsm.holdTime != 0 {
-		s.fsm.updateLastUpdateOrKeepalive()
-		s.fsm.keepaliveTime = s.fsm.holdTime / 3
-		s.fsm.keepaliveTimer = time.NewTimer(s.fsm.keepaliveTime)
+	// A hold time must be either zero or at least three seconds (RFC4271 section 4.2)
+	if openMsg.HoldTime == 1 || openMsg.HoldTime == 2 {
+		s.fsm.sendNotification(packet.OpenMessageError, packet.UnacceptableHoldTime)
+		return newIdleState(s.fsm), fmt.Sprintf("Unacceptable hold time %d", openMsg.HoldTime)
 	}
 
+	s.fsm.holdTime = time.Duration(math.Min(float64(s.fsm.peer.holdTime), float64(time.Duration(openMsg.HoldTime)*time.Second)))
+	s.fsm.startKeepaliveTimer()
+
 	s.peerASNRcvd = uint32(openMsg.ASN)
+	s.fsm.extendedMessage = false
+	s.fsm.routeRefresh = false
+	s.fsm.enhancedRouteRefresh = false
+	s.fsm.peerGracefulRestart = nil
+	s.fsm.linkStateNegotiated = false
+	// The role advertised in a previous session must not be taken for the role advertised in this one
+	s.fsm.peer.peerRoleAdvByPeer = false
+	for _, f := range []*fsmAddressFamily{s.fsm.ipv4Unicast, s.fsm.ipv6Unicast} {
+		if f != nil {
+			f.orfSend = false
+		}
+	}
 	s.processOpenOptions(openMsg.OptParams)
 
 	if s.peerASNRcvd != s.fsm.peer.peerASN {
@@ -165,7 +183,7 @@ func (s *openSentState) handleOpenMessage(openMsg *packet.BGPOpen) (state, strin
 }
 
 func (s *openSentState) tcpFailure() (state, string) {
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	s.fsm.resetConnectRetryTimer()
 	return newActiveState(s.fsm), "TCP connection failure"
 }
@@ -187,6 +205,11 @@ func (s *openSentState) processCapabilities(caps packet.Capabilities) {
 }
 
 func (s *openSentState) processCapability(cap packet.Capability) {
+	// A capability we did not advertise must not be used, even if the peer did advertise it
+	if s.fsm.peer.capabilityOverrides.suppressed(cap.Code) {
+		return
+	}
+
 	switch cap.Code {
 	case packet.AddPathCapabilityCode:
 		s.processAddPathCapability(cap.Value.(packet.AddPathCapability))
@@ -196,11 +219,39 @@ func (s *openSentState) processCapability(cap packet.Capability) {
 		s.processMultiProtocolCapability(cap.Value.(packet.MultiProtocolCapability))
 	case packet.PeerRoleCapabilityCode:
 		s.processPeerRoleCapability(cap.Value.(packet.PeerRoleCapability))
+	case packet.ExtendedMessageCapabilityCode:
+		s.processExtendedMessageCapability()
+	case packet.RouteRefreshCapabilityCode:
+		s.fsm.routeRefresh = s.fsm.peer.routeRefresh
+	case packet.EnhancedRouteRefreshCapabilityCode:
+		s.fsm.enhancedRouteRefresh = s.fsm.peer.enhancedRouteRefresh
+	case packet.OutboundRouteFilteringCapabilityCode:
+		s.processORFCapability(cap.Value.(packet.ORFCapability))
+	case packet.GracefulRestartCapabilityCode:
+		s.processGracefulRestartCapability(cap.Value.(packet.GracefulRestartCapability))
 	}
 }
 
+func (s *openSentState) processGracefulRestartCapability(cap packet.GracefulRestartCapability) {
+	if s.fsm.peer.gracefulRestart == nil {
+		return
+	}
+
+	s.fsm.peerGracefulRestart = &cap
+}
+
+func (s *openSentState) processExtendedMessageCapability() {
+	// Extended messages may only be used if both sides advertised the capability
+	s.fsm.extendedMessage = s.fsm.peer.extendedMessage
+}
+
 func (s *openSentState) processMultiProtocolCapability(cap packet.MultiProtocolCapability) {
-	if cap.SAFI != packet.SAFIUnicast {
+	if cap.AFI == packet.AFILinkState && cap.SAFI == packet.SAFILinkState {
+		s.fsm.linkStateNegotiated = s.fsm.peer.linkState != nil
+		return
+	}
+
+	if cap.SAFI != packet.SAFIUnicast && cap.SAFI != packet.SAFILabeledUnicast {
 		return
 	}
 
@@ -325,11 +376,13 @@ func isPeerRelationshipPeerPeer(localRole uint8, remoteRole uint8) bool {
 
 func (s *openSentState) notification(msg *packet.BGPMessage) (state, string) {
 	stopTimer(s.fsm.connectRetryTimer)
-	s.fsm.con.Close()
+	s.fsm.closeConnection()
 	nMsg := msg.Body.(*packet.BGPNotification)
 	if nMsg.ErrorCode != packet.UnsupportedVersionNumber {
 		s.fsm.connectRetryCounter++
 	}
 
-	return newIdleState(s.fsm), "Received NOTIFICATION"
+	s.fsm.handleBadPeerAS(nMsg)
+
+	return newIdleState(s.fsm), s.fsm.receivedNotificationReason(nMsg)
 }
@@ -1,8 +1,10 @@
 package server
 
 import (
+	"bytes"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/bio-routing/bio-rd/routingtable"
 
@@ -110,6 +112,7 @@ func TestProcessMultiProtocolCapability(t *testing.T) {
 		caps                    []packet.MultiProtocolCapability
 		expectIPv4MultiProtocol bool
 		expectIPv6MultiProtocol bool
+		expectLinkState         bool
 	}{
 		{
 			name: "IPv4 only without multi protocol configuration",
@@ -188,6 +191,31 @@ func TestProcessMultiProtocolCapability(t *testing.T) {
 			expectIPv4MultiProtocol: true,
 			expectIPv6MultiProtocol: true,
 		},
+		{
+			name: "Link state configured",
+			peer: &peer{
+				linkState: &LinkStateConfig{},
+			},
+			caps: []packet.MultiProtocolCapability{
+				{
+					AFI:  packet.AFILinkState,
+					SAFI: packet.SAFILinkState,
+				},
+			},
+			expectLinkState: true,
+		},
+		{
+			name: "Link state not configured",
+			peer: &peer{
+				ipv4: &peerAddressFamily{},
+			},
+			caps: []packet.MultiProtocolCapability{
+				{
+					AFI:  packet.AFILinkState,
+					SAFI: packet.SAFILinkState,
+				},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -210,6 +238,8 @@ func TestProcessMultiProtocolCapability(t *testing.T) {
 			if fsm.ipv6Unicast != nil {
 				assert.Equal(t, test.expectIPv6MultiProtocol, fsm.ipv6Unicast.multiProtocol)
 			}
+
+			assert.Equal(t, test.expectLinkState, fsm.linkStateNegotiated)
 		})
 	}
 }
@@ -1257,3 +1287,294 @@ func TestProcessPeerRoleCapability(t *testing.T) {
 		})
 	}
 }
+
+func peerRoleOpen(role uint8) *packet.BGPOpen {
+	return &packet.BGPOpen{
+		HoldTime:      90,
+		BGPIdentifier: 1,
+		Version:       4,
+		ASN:           23456,
+		OptParmLen:    1,
+		OptParams: []packet.OptParam{
+			{
+				Type:   packet.CapabilitiesParamType,
+				Length: 6,
+				Value: packet.Capabilities{
+					packet.Capability{
+						Code:   packet.PeerRoleCapabilityCode,
+						Length: 1,
+						Value: packet.PeerRoleCapability{
+							PeerRole: role,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestPeerRoleRenegotiation(t *testing.T) {
+	fsm := newFSM(&peer{
+		peerASN:            23456,
+		peerRoleEnabled:    true,
+		peerRoleStrictMode: true,
+		peerRoleLocal:      packet.PeerRoleRoleProvider,
+	})
+
+	tests := []struct {
+		name     string
+		msg      *packet.BGPOpen
+		wantIdle bool
+		errmsg   string
+	}{
+		{
+			name: "Customer",
+			msg:  peerRoleOpen(packet.PeerRoleRoleCustomer),
+		},
+		{
+			name:     "Peer in new session",
+			msg:      peerRoleOpen(packet.PeerRoleRolePeer),
+			wantIdle: true,
+			errmsg:   "role misatch error: Local role Provider incompatible to remote role Peer",
+		},
+		{
+			name: "No role in new session",
+			msg: &packet.BGPOpen{
+				HoldTime:      90,
+				BGPIdentifier: 1,
+				Version:       4,
+				ASN:           23456,
+			},
+			wantIdle: true,
+			errmsg:   "role misatch error: Strict mode configured but peer didn't advertise a BGP role",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			con := btesting.NewMockConn()
+			fsm.con = con
+			s := &openSentState{
+				fsm: fsm,
+			}
+
+			state, errmsg := s.handleOpenMessage(test.msg)
+			if !test.wantIdle {
+				assert.IsType(t, &openConfirmState{}, state, errmsg)
+				return
+			}
+
+			assert.IsType(t, &idleState{}, state, "state")
+			assert.Equal(t, test.errmsg, errmsg, "errmsg")
+
+			msg, err := packet.Decode(con.Buf, &packet.DecodeOptions{})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, &packet.BGPNotification{
+				ErrorCode:    packet.OpenMessageError,
+				ErrorSubcode: packet.RoleMismatchError,
+			}, msg.Body, "notification")
+		})
+	}
+}
+
+func TestProcessExtendedMessageCapability(t *testing.T) {
+	tests := []struct {
+		name            string
+		localAdvertised bool
+		peerAdvertised  bool
+		expected        bool
+	}{
+		{
+			name:            "both sides advertise",
+			localAdvertised: true,
+			peerAdvertised:  true,
+			expected:        true,
+		},
+		{
+			name:            "only advertised locally",
+			localAdvertised: true,
+			expected:        false,
+		},
+		{
+			name:           "only advertised by peer",
+			peerAdvertised: true,
+			expected:       false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fsm := newFSM(&peer{
+				extendedMessage: test.localAdvertised,
+			})
+
+			caps := packet.Capabilities{}
+			if test.peerAdvertised {
+				caps = append(caps, extendedMessageCapability())
+			}
+
+			s := &openSentState{
+				fsm: fsm,
+			}
+			s.processCapabilities(caps)
+
+			assert.Equal(t, test.expected, fsm.extendedMessage)
+			assert.Equal(t, test.expected, fsm.decodeOptions().ExtendedMessage)
+		})
+	}
+}
+
+func TestProcessRouteRefreshCapabilities(t *testing.T) {
+	tests := []struct {
+		name             string
+		local            PeerConfig
+		peerCaps         packet.Capabilities
+		expected         bool
+		expectedEnhanced bool
+	}{
+		{
+			name:     "route refresh",
+			local:    PeerConfig{RouteRefresh: true},
+			peerCaps: packet.Capabilities{routeRefreshCapability(), enhancedRouteRefreshCapability()},
+			expected: true,
+		},
+		{
+			name:             "enhanced route refresh",
+			local:            PeerConfig{EnhancedRouteRefresh: true},
+			peerCaps:         packet.Capabilities{routeRefreshCapability(), enhancedRouteRefreshCapability()},
+			expected:         true,
+			expectedEnhanced: true,
+		},
+		{
+			name:     "enhanced route refresh not supported by peer",
+			local:    PeerConfig{EnhancedRouteRefresh: true},
+			peerCaps: packet.Capabilities{routeRefreshCapability()},
+			expected: true,
+		},
+		{
+			name:     "not configured locally",
+			peerCaps: packet.Capabilities{routeRefreshCapability(), enhancedRouteRefreshCapability()},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fsm := newFSM(&peer{
+				routeRefresh:         test.local.RouteRefresh || test.local.EnhancedRouteRefresh,
+				enhancedRouteRefresh: test.local.EnhancedRouteRefresh,
+			})
+
+			s := &openSentState{
+				fsm: fsm,
+			}
+			s.processCapabilities(test.peerCaps)
+
+			assert.Equal(t, test.expected, fsm.routeRefresh)
+			assert.Equal(t, test.expectedEnhanced, fsm.enhancedRouteRefresh)
+		})
+	}
+}
+
+func TestProcessSuppressedCapability(t *testing.T) {
+	fsm := newFSM(&peer{
+		peerASN: 4200000000,
+		capabilityOverrides: CapabilityOverrides{
+			packet.ASN4CapabilityCode: false,
+		},
+	})
+
+	s := &openSentState{
+		fsm:         fsm,
+		peerASNRcvd: packet.ASTransASN,
+	}
+	s.processCapabilities(packet.Capabilities{asn4Capability(4200000000)})
+
+	assert.False(t, fsm.supports4OctetASN, "4 octet ASNs must not be used if we suppressed the capability")
+	assert.Equal(t, uint32(packet.ASTransASN), s.peerASNRcvd)
+}
+
+func TestSimultaneousConnect(t *testing.T) {
+	tests := []struct {
+		name                   string
+		localID                uint32
+		remoteID               uint32
+		expectLocallyInitiated bool
+	}{
+		{
+			name:                   "higher local ID keeps locally initiated connection",
+			localID:                200,
+			remoteID:               100,
+			expectLocallyInitiated: true,
+		},
+		{
+			name:                   "higher remote ID keeps connection initiated by peer",
+			localID:                100,
+			remoteID:               200,
+			expectLocallyInitiated: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &peer{
+				routerID: test.localID,
+				peerASN:  65001,
+				holdTime: 90 * time.Second,
+			}
+
+			open := &packet.BGPOpen{
+				Version:       4,
+				ASN:           65001,
+				HoldTime:      90,
+				BGPIdentifier: test.remoteID,
+			}
+
+			outgoing := newFSM(p)
+			outgoing.active = true
+			outgoing.con = &halfCloseConn{}
+			incoming := newFSM(p)
+			incoming.con = &halfCloseConn{}
+			p.fsms = []*FSM{outgoing, incoming}
+
+			// The OPEN on the outgoing connection arrives first, the one on the incoming connection collides with it
+			first, reason := newOpenSentState(outgoing).openMsgReceived(open)
+			assert.IsType(t, &openConfirmState{}, first, reason)
+			outgoing.state = first
+
+			firstNext := make(chan state)
+			go func() {
+				next, _ := first.run()
+				firstNext <- next
+			}()
+
+			second, reason := newOpenSentState(incoming).openMsgReceived(open)
+
+			winner, loser := outgoing, incoming
+			if test.expectLocallyInitiated {
+				assert.IsType(t, &ceaseState{}, second, reason)
+			} else {
+				winner, loser = incoming, outgoing
+				assert.IsType(t, &ceaseState{}, <-firstNext)
+				assert.IsType(t, &openConfirmState{}, second, reason)
+			}
+
+			msgs := loser.con.(*halfCloseConn).received.Bytes()
+			notification := msgs[len(msgs)-len(packet.SerializeNotificationMsg(&packet.BGPNotification{})):]
+			msg, err := packet.Decode(bytes.NewBuffer(notification), &packet.DecodeOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, &packet.BGPNotification{
+				ErrorCode:    packet.Cease,
+				ErrorSubcode: packet.ConnectionCollisionResolution,
+			}, msg.Body)
+			assert.NotContains(t, winner.con.(*halfCloseConn).events, "closeWrite", "surviving connection has been closed")
+
+			if test.expectLocallyInitiated {
+				outgoing.eventCh <- ManualStop
+				<-firstNext
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// routeRefreshCoalesceInterval is how long ROUTE-REFRESH requests are collected before re-advertising
+const routeRefreshCoalesceInterval = time.Second
+
+// requestRefresh schedules re-advertising the Adj-RIB-Out of f. Requests for an address family arriving
+// while a refresh of it is pending are coalesced into that refresh.
+func (fsm *FSM) requestRefresh(f *fsmAddressFamily) {
+	atomic.AddUint64(&fsm.counters.routeRefreshesReceived, 1)
+
+	if f.refreshPending {
+		atomic.AddUint64(&fsm.counters.routeRefreshesCoalesced, 1)
+		return
+	}
+
+	f.refreshPending = true
+	if fsm.routeRefreshTimer == nil {
+		fsm.routeRefreshTimer = fsm.clock.NewTimer(routeRefreshCoalesceInterval)
+	}
+}
+
+// routeRefreshTimerC returns the channel of the route refresh timer. The returned channel is nil (blocks forever) if no refresh is pending.
+func (fsm *FSM) routeRefreshTimerC() <-chan time.Time {
+	if fsm.routeRefreshTimer == nil {
+		return nil
+	}
+
+	return fsm.routeRefreshTimer.C()
+}
+
+// runPendingRefreshes re-advertises the Adj-RIB-Out of all address families with a pending ROUTE-REFRESH request
+func (fsm *FSM) runPendingRefreshes() {
+	fsm.routeRefreshTimer = nil
+
+	for _, f := range []*fsmAddressFamily{fsm.ipv4Unicast, fsm.ipv6Unicast} {
+		if f == nil || !f.refreshPending {
+			continue
+		}
+
+		f.refreshPending = false
+		f.refresh()
+	}
+}
+
+// cancelPendingRefreshes drops all pending ROUTE-REFRESH requests
+func (fsm *FSM) cancelPendingRefreshes() {
+	if fsm.routeRefreshTimer != nil {
+		fsm.routeRefreshTimer.Stop()
+		fsm.routeRefreshTimer = nil
+	}
+
+	for _, f := range []*fsmAddressFamily{fsm.ipv4Unicast, fsm.ipv6Unicast} {
+		if f != nil {
+			f.refreshPending = false
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable"
+	btesting "github.com/bio-routing/bio-rd/testing"
+	btime "github.com/bio-routing/bio-rd/util/time"
+	"github.com/stretchr/testify/assert"
+)
+
+type dumpCountingAdjRIBOut struct {
+	*routingtable.RTMockClient
+	dumps int
+}
+
+func (d *dumpCountingAdjRIBOut) Dump() []*route.Route {
+	d.dumps++
+	return nil
+}
+
+func TestRouteRefreshCoalescing(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	fsm := newFSM(&peer{
+		addr: bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+		ipv4: &peerAddressFamily{},
+	})
+	fsm.clock = clock
+	fsm.con = btesting.NewMockConn()
+	fsm.routeRefresh = true
+
+	ribOut := &dumpCountingAdjRIBOut{RTMockClient: routingtable.NewRTMockClient()}
+	fsm.ipv4Unicast.adjRIBOut = ribOut
+	fsm.ipv4Unicast.updateSender = newUpdateSender(fsm.ipv4Unicast)
+	fsm.ipv4Unicast.initialized = true
+
+	s := newEstablishedState(fsm)
+	for i := 0; i < 5; i++ {
+		s.routeRefresh(&packet.BGPRouteRefresh{
+			AFI:     packet.AFIIPv4,
+			SAFI:    packet.SAFIUnicast,
+			Subtype: packet.RouteRefreshNormal,
+		})
+	}
+
+	assert.Equal(t, 0, ribOut.dumps, "re-advertised before the coalesce interval passed")
+	assert.Equal(t, uint64(5), fsm.counters.routeRefreshesReceived)
+	assert.Equal(t, uint64(4), fsm.counters.routeRefreshesCoalesced)
+
+	clock.Advance(routeRefreshCoalesceInterval)
+	<-fsm.routeRefreshTimerC()
+	fsm.runPendingRefreshes()
+
+	assert.Equal(t, 1, ribOut.dumps)
+	assert.Nil(t, fsm.routeRefreshTimerC())
+
+	s.routeRefresh(&packet.BGPRouteRefresh{
+		AFI:     packet.AFIIPv4,
+		SAFI:    packet.SAFIUnicast,
+		Subtype: packet.RouteRefreshNormal,
+	})
+	assert.NotNil(t, fsm.routeRefreshTimerC(), "new request after re-advertisement must be scheduled again")
+	assert.Equal(t, uint64(4), fsm.counters.routeRefreshesCoalesced)
+}
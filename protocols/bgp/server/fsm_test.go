@@ -1,7 +1,12 @@
 package server
 
 import (
+	"bytes"
+	"context"
+	"io"
+	"net"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -9,6 +14,8 @@ import (
 	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
 	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	btesting "github.com/bio-routing/bio-rd/testing"
+	btime "github.com/bio-routing/bio-rd/util/time"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,7 +33,7 @@ func TestFSM255UpdatesIPv4(t *testing.T) {
 	})
 
 	fsmA.holdTime = time.Second * 180
-	fsmA.keepaliveTimer = time.NewTimer(time.Second * 30)
+	fsmA.keepaliveTimer = fsmA.clock.NewTimer(time.Second * 30)
 	fsmA.connectRetryTimer = time.NewTimer(time.Second * 120)
 	fsmA.state = newEstablishedState(fsmA)
 
@@ -146,7 +153,7 @@ func TestFSM255UpdatesIPv6(t *testing.T) {
 
 	fsmA.ipv6Unicast.multiProtocol = true
 	fsmA.holdTime = time.Second * 180
-	fsmA.keepaliveTimer = time.NewTimer(time.Second * 30)
+	fsmA.keepaliveTimer = fsmA.clock.NewTimer(time.Second * 30)
 	fsmA.connectRetryTimer = time.NewTimer(time.Second * 120)
 	fsmA.state = newEstablishedState(fsmA)
 
@@ -334,3 +341,534 @@ func TestOpenMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestOpenMessageLocalASOverride(t *testing.T) {
+	badPeerAS := &packet.BGPNotification{
+		ErrorCode:    packet.OpenMessageError,
+		ErrorSubcode: packet.BadPeerAS,
+	}
+
+	tests := []struct {
+		name             string
+		localASOverride  *LocalASOverride
+		notification     *packet.BGPNotification
+		expectedASN      uint16
+		expectedASN4     uint32
+		expectedOverride uint32
+	}{
+		{
+			name:         "no override",
+			expectedASN:  65000,
+			expectedASN4: 65000,
+		},
+		{
+			name: "override",
+			localASOverride: &LocalASOverride{
+				ASN: 202739,
+			},
+			expectedASN:      packet.ASTransASN,
+			expectedASN4:     202739,
+			expectedOverride: 202739,
+		},
+		{
+			name: "override, bad peer AS without dual-as",
+			localASOverride: &LocalASOverride{
+				ASN: 65001,
+			},
+			notification:     badPeerAS,
+			expectedASN:      65001,
+			expectedASN4:     65001,
+			expectedOverride: 65001,
+		},
+		{
+			name: "dual-as, bad peer AS",
+			localASOverride: &LocalASOverride{
+				ASN:    65001,
+				DualAS: true,
+			},
+			notification: badPeerAS,
+			expectedASN:  65000,
+			expectedASN4: 65000,
+		},
+		{
+			name: "dual-as, other notification",
+			localASOverride: &LocalASOverride{
+				ASN:    65001,
+				DualAS: true,
+			},
+			notification: &packet.BGPNotification{
+				ErrorCode: packet.Cease,
+			},
+			expectedASN:      65001,
+			expectedASN4:     65001,
+			expectedOverride: 65001,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := newPeer(PeerConfig{
+				LocalAS:         65000,
+				LocalASOverride: test.localASOverride,
+				PeerAS:          65100,
+				Passive:         true,
+				HoldTime:        time.Second * 90,
+			}, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			fsm := newFSM(p)
+			if test.notification != nil {
+				fsm.handleBadPeerAS(test.notification)
+			}
+
+			msg := fsm.openMessage()
+			assert.Equal(t, test.expectedASN, msg.ASN)
+			assert.Equal(t, test.expectedOverride, fsm.localASOverride())
+
+			caps := msg.OptParams[0].Value.(packet.Capabilities)
+			assert.Contains(t, caps, asn4Capability(test.expectedASN4))
+		})
+	}
+}
+
+func TestMsgReceiverBackpressure(t *testing.T) {
+	local, remote := net.Pipe()
+	defer remote.Close()
+
+	fsm := newFSM(&peer{
+		inboundQueueSize: 2,
+	})
+	fsm.con = local
+	go fsm.msgReceiver()
+
+	written := atomic.Int32{}
+	go func() {
+		for i := 0; i < 10; i++ {
+			_, err := remote.Write(packet.SerializeKeepaliveMsg())
+			if err != nil {
+				return
+			}
+
+			written.Add(1)
+		}
+	}()
+
+	// Two messages are queued, a third one is read and waits for space in the queue
+	assert.Eventually(t, func() bool {
+		return written.Load() == 3
+	}, time.Second, time.Millisecond)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(3), written.Load(), "reading did not pause")
+	assert.Equal(t, 2, fsm.inboundQueueDepth())
+
+	for i := 0; i < 10; i++ {
+		select {
+		case <-fsm.msgRecvCh:
+		case <-time.After(time.Second):
+			t.Fatalf("reading did not resume after draining the queue")
+		}
+	}
+
+	assert.Eventually(t, func() bool {
+		return written.Load() == 10
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, 0, fsm.inboundQueueDepth())
+}
+
+func TestInboundQueueCap(t *testing.T) {
+	assert.Equal(t, defaultInboundQueueSize, cap(newFSM(&peer{}).msgRecvCh))
+	assert.Equal(t, 16, cap(newFSM(&peer{inboundQueueSize: 16}).msgRecvCh))
+}
+
+// halfCloseConn is an in-memory connection supporting a half-close. It records what the peer received and in which order the connection was shut down.
+type halfCloseConn struct {
+	net.Conn
+	mu          sync.Mutex
+	received    bytes.Buffer
+	writeClosed bool
+	events      []string
+	atClose     int
+}
+
+func (c *halfCloseConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.writeClosed {
+		return 0, io.ErrClosedPipe
+	}
+
+	c.events = append(c.events, "write")
+	return c.received.Write(b)
+}
+
+func (c *halfCloseConn) CloseWrite() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.events = append(c.events, "closeWrite")
+	c.writeClosed = true
+	return nil
+}
+
+// Read emulates a peer closing its side once it read our FIN
+func (c *halfCloseConn) Read(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.writeClosed {
+		return 0, io.EOF
+	}
+
+	return 0, nil
+}
+
+func (c *halfCloseConn) SetReadDeadline(time.Time) error {
+	return nil
+}
+
+func (c *halfCloseConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.events = append(c.events, "close")
+	c.atClose = c.received.Len()
+	return nil
+}
+
+func TestNotificationDeliveredBeforeClose(t *testing.T) {
+	con := &halfCloseConn{}
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	fsm := newFSM(&peer{})
+	fsm.clock = clock
+	fsm.con = con
+
+	s := newOpenSentState(fsm)
+	_, reason := s.holdTimerExpired()
+	assert.Equal(t, "Holdtimer expired", reason)
+
+	expected := packet.SerializeNotificationMsg(&packet.BGPNotification{
+		ErrorCode: packet.HoldTimeExpired,
+	})
+
+	// Closing must not wait for the peer
+	con.mu.Lock()
+	assert.Equal(t, []string{"write", "closeWrite"}, con.events)
+	con.mu.Unlock()
+
+	clock.Advance(closeLingerTime)
+	assert.Eventually(t, func() bool {
+		con.mu.Lock()
+		defer con.mu.Unlock()
+
+		return len(con.events) == 3
+	}, time.Second, time.Millisecond)
+	assert.Equal(t, []string{"write", "closeWrite", "close"}, con.events)
+	assert.Equal(t, len(expected), con.atClose, "NOTIFICATION not fully written before close")
+	assert.Equal(t, expected, con.received.Bytes())
+
+	msg, err := packet.Decode(bytes.NewBuffer(con.received.Bytes()), &packet.DecodeOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(packet.NotificationMsg), msg.Header.Type)
+	assert.Equal(t, uint8(packet.HoldTimeExpired), msg.Body.(*packet.BGPNotification).ErrorCode)
+}
+
+func TestReceivedShutdownCommunication(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *packet.BGPNotification
+		expected string
+	}{
+		{
+			name: "Shutdown communication",
+			input: &packet.BGPNotification{
+				ErrorCode:    packet.Cease,
+				ErrorSubcode: packet.AdminShut,
+				Data:         []byte{3, 'f', 'o', 'o'},
+			},
+			expected: "Received NOTIFICATION with shutdown communication \"foo\"",
+		},
+		{
+			name: "No shutdown communication",
+			input: &packet.BGPNotification{
+				ErrorCode:    packet.Cease,
+				ErrorSubcode: packet.AdminShut,
+			},
+			expected: "Received NOTIFICATION",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fsm := newFSM(&peer{
+				addr: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			})
+			fsm.con = &halfCloseConn{}
+
+			s := newOpenConfirmState(fsm)
+			_, reason := s.notification(&packet.BGPMessage{
+				Body: test.input,
+			})
+			assert.Equal(t, test.expected, reason)
+		})
+	}
+}
+
+func TestStopNotificationDiscardedWithoutSession(t *testing.T) {
+	tests := []struct {
+		name       string
+		manualStop func(fsm *FSM) (state, string)
+	}{
+		{
+			name: "Connect",
+			manualStop: func(fsm *FSM) (state, string) {
+				return newConnectState(fsm).manualStop()
+			},
+		},
+		{
+			name: "Active",
+			manualStop: func(fsm *FSM) (state, string) {
+				return newActiveState(fsm).manualStop()
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fsm := newFSM(&peer{})
+			fsm.con = &halfCloseConn{}
+			fsm.connectRetryTimer = time.NewTimer(time.Hour)
+			fsm.stopNotification = &packet.BGPNotification{
+				ErrorCode:    packet.Cease,
+				ErrorSubcode: packet.AdminShut,
+			}
+
+			_, reason := test.manualStop(fsm)
+			assert.Equal(t, "Manual stop event", reason)
+			assert.Nil(t, fsm.stopNotification, "NOTIFICATION must not be kept for a later session")
+		})
+	}
+}
+
+func TestTCPConnectorUpdateSource(t *testing.T) {
+	tests := []struct {
+		name         string
+		updateSource *bnet.IP
+		expected     net.IP
+	}{
+		{
+			name:         "Update source",
+			updateSource: bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+			expected:     net.IP{10, 0, 0, 2},
+		},
+		{
+			name: "No update source",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fsm := newFSM(&peer{
+				addr: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+				config: &PeerConfig{
+					UpdateSource: test.updateSource,
+				},
+			})
+
+			var laddr, raddr *net.TCPAddr
+			con := &halfCloseConn{}
+			fsm.dial = func(l, r *net.TCPAddr, ttl uint8, md5Secret string, noRoute bool) (net.Conn, error) {
+				laddr, raddr = l, r
+				return con, nil
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go fsm.tcpConnector(ctx)
+
+			fsm.tcpConnect()
+			assert.Equal(t, con, <-fsm.conCh)
+			assert.Equal(t, test.expected, laddr.IP)
+			assert.Equal(t, &net.TCPAddr{IP: net.IP{10, 0, 0, 1}, Port: BGPPORT}, raddr)
+		})
+	}
+}
+
+func TestTCPConnectorEBGPMultihop(t *testing.T) {
+	tests := []struct {
+		name              string
+		peerAS            uint32
+		ttl               uint8
+		expectedTTL       uint8
+		expectedDontRoute bool
+	}{
+		{
+			name:              "eBGP",
+			peerAS:            65100,
+			expectedTTL:       1,
+			expectedDontRoute: true,
+		},
+		{
+			name:        "eBGP multihop",
+			peerAS:      65100,
+			ttl:         3,
+			expectedTTL: 3,
+		},
+		{
+			name:   "iBGP",
+			peerAS: 65000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fsm := newFSM(&peer{
+				addr:     bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+				config:   &PeerConfig{},
+				localASN: 65000,
+				peerASN:  test.peerAS,
+				ttl:      test.ttl,
+			})
+
+			var ttl uint8
+			var dontRoute bool
+			con := &halfCloseConn{}
+			fsm.dial = func(l, r *net.TCPAddr, t uint8, md5Secret string, noRoute bool) (net.Conn, error) {
+				ttl, dontRoute = t, noRoute
+				return con, nil
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go fsm.tcpConnector(ctx)
+
+			fsm.tcpConnect()
+			assert.Equal(t, con, <-fsm.conCh)
+			assert.Equal(t, test.expectedTTL, ttl)
+			assert.Equal(t, test.expectedDontRoute, dontRoute)
+		})
+	}
+}
+
+func TestTeardownNotifications(t *testing.T) {
+	open := packet.SerializeOpenMsg(&packet.BGPOpen{
+		Version:       4,
+		ASN:           65001,
+		HoldTime:      90,
+		BGPIdentifier: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr().ToUint32(),
+	})
+	keepalive := packet.SerializeKeepaliveMsg()
+
+	badType := packet.SerializeKeepaliveMsg()
+	badType[packet.MarkerLen+2] = 42
+
+	tests := []struct {
+		name     string
+		run      func(fsm *FSM) (state, string)
+		expected *packet.BGPNotification
+	}{
+		{
+			name: "Hold timer expired in OpenSent",
+			run: func(fsm *FSM) (state, string) {
+				return newOpenSentState(fsm).holdTimerExpired()
+			},
+			expected: &packet.BGPNotification{
+				ErrorCode: packet.HoldTimeExpired,
+			},
+		},
+		{
+			name: "Hold timer expired in OpenConfirm",
+			run: func(fsm *FSM) (state, string) {
+				return newOpenConfirmState(fsm).holdTimerExpired()
+			},
+			expected: &packet.BGPNotification{
+				ErrorCode: packet.HoldTimeExpired,
+			},
+		},
+		{
+			name: "Hold timer expired in Established",
+			run: func(fsm *FSM) (state, string) {
+				return newEstablishedState(fsm).holdTimerExpired()
+			},
+			expected: &packet.BGPNotification{
+				ErrorCode: packet.HoldTimeExpired,
+			},
+		},
+		{
+			name: "KEEPALIVE in OpenSent",
+			run: func(fsm *FSM) (state, string) {
+				return newOpenSentState(fsm).msgReceived(keepalive, &packet.DecodeOptions{})
+			},
+			expected: &packet.BGPNotification{
+				ErrorCode:    packet.FiniteStateMachineError,
+				ErrorSubcode: packet.UnexpectedMessageInOpenSentState,
+			},
+		},
+		{
+			name: "OPEN in OpenConfirm",
+			run: func(fsm *FSM) (state, string) {
+				return newOpenConfirmState(fsm).msgReceived(open, &packet.DecodeOptions{})
+			},
+			expected: &packet.BGPNotification{
+				ErrorCode:    packet.FiniteStateMachineError,
+				ErrorSubcode: packet.UnexpectedMessageInOpenConfirmState,
+			},
+		},
+		{
+			name: "OPEN in Established",
+			run: func(fsm *FSM) (state, string) {
+				return newEstablishedState(fsm).msgReceived(open, &packet.DecodeOptions{}, false, 0)
+			},
+			expected: &packet.BGPNotification{
+				ErrorCode:    packet.FiniteStateMachineError,
+				ErrorSubcode: packet.UnexpectedMessageInEstablishedState,
+			},
+		},
+		{
+			name: "Bad message type in Established",
+			run: func(fsm *FSM) (state, string) {
+				return newEstablishedState(fsm).msgReceived(badType, &packet.DecodeOptions{}, false, 0)
+			},
+			expected: &packet.BGPNotification{
+				ErrorCode:    packet.MessageHeaderError,
+				ErrorSubcode: packet.BadMessageType,
+			},
+		},
+		{
+			name: "Truncated OPEN in OpenSent",
+			run: func(fsm *FSM) (state, string) {
+				truncated := append([]byte(nil), open[:packet.MinLen+4]...)
+				truncated[packet.MarkerLen+1] = byte(len(truncated))
+				return newOpenSentState(fsm).msgReceived(truncated, &packet.DecodeOptions{})
+			},
+			expected: &packet.BGPNotification{
+				ErrorCode:    packet.MessageHeaderError,
+				ErrorSubcode: packet.BadMessageLength,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fsm := newFSM(&peer{
+				addr: bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+			})
+			con := btesting.NewMockConn()
+			fsm.con = con
+
+			next, _ := test.run(fsm)
+			assert.IsType(t, &idleState{}, next)
+			assert.True(t, con.Closed)
+
+			msg, err := packet.Decode(con.Buf, &packet.DecodeOptions{})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, test.expected, msg.Body)
+		})
+	}
+}
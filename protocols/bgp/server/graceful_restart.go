@@ -0,0 +1,309 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+const (
+	// maxGracefulRestartTime is the largest restart time the 12 bit field of the capability can carry (RFC4724)
+	maxGracefulRestartTime = 4095 * time.Second
+
+	// maxStalePathTime bounds how long stale paths of a restarting peer are retained
+	maxStalePathTime = time.Hour
+)
+
+// GracefulRestart enables the graceful restart receiving speaker (helper) mode for a peer (RFC4724). Paths received
+// from a peer advertising graceful restart are retained as stale if the TCP connection fails.
+type GracefulRestart struct {
+	// RestartTime is advertised to the peer as the time it takes us to re-establish the session after a restart
+	RestartTime time.Duration
+
+	// StalePathTime is the time stale paths are retained after the session was re-established. Stale paths the peer
+	// did not advertise again are removed once it sent the End-of-RIB marker or StalePathTime elapsed.
+	StalePathTime time.Duration
+}
+
+// Equal compares two GracefulRestart configs
+func (g *GracefulRestart) Equal(x *GracefulRestart) bool {
+	if g == nil || x == nil {
+		return g == x
+	}
+
+	return *g == *x
+}
+
+func (g *GracefulRestart) validate() error {
+	if g == nil {
+		return nil
+	}
+
+	if g.RestartTime < time.Second || g.RestartTime > maxGracefulRestartTime {
+		return fmt.Errorf("restart time must be between 1s and %v", maxGracefulRestartTime)
+	}
+
+	if g.StalePathTime < time.Second || g.StalePathTime > maxStalePathTime {
+		return fmt.Errorf("stale path time must be between 1s and %v", maxStalePathTime)
+	}
+
+	return nil
+}
+
+// gracefulRestartCapability announces to act as receiving speaker. As we do not preserve our forwarding state
+// across restarts, no AFI/SAFI is included (RFC4724 section 3).
+func gracefulRestartCapability(g *GracefulRestart) packet.Capability {
+	return packet.Capability{
+		Code: packet.GracefulRestartCapabilityCode,
+		Value: packet.GracefulRestartCapability{
+			RestartTime: uint16(g.RestartTime / time.Second),
+		},
+	}
+}
+
+// gracefulRestartHelper retains the Adj-RIBs-In of a peer while it restarts
+type gracefulRestartHelper struct {
+	config GracefulRestart
+	peer   *peer
+
+	mu   sync.Mutex
+	ribs map[uint16]*retainedAdjRIBIn
+
+	// cancel is closed to cancel the pending removal of the retained paths
+	cancel chan struct{}
+}
+
+// retainedAdjRIBIn is the Adj-RIB-In of an address family holding the stale paths of the previous session
+type retainedAdjRIBIn struct {
+	adjRIBIn          routingtable.AdjRIBIn
+	rib               *locRIB.LocRIB
+	importFilterChain filter.Chain
+	addPathRX         bool
+
+	// adopted is set once a re-established session took over the Adj-RIB-In
+	adopted bool
+}
+
+func newGracefulRestartHelper(p *peer, c GracefulRestart) *gracefulRestartHelper {
+	return &gracefulRestartHelper{
+		config: c,
+		peer:   p,
+		ribs:   make(map[uint16]*retainedAdjRIBIn),
+	}
+}
+
+// retain keeps the paths of the Adj-RIB-In of f as stale until the session is re-established within restartTime
+func (g *gracefulRestartHelper) retain(f *fsmAddressFamily, restartTime time.Duration) {
+	f.adjRIBIn.MarkStale()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.ribs[f.afi] = &retainedAdjRIBIn{
+		adjRIBIn:          f.adjRIBIn,
+		rib:               f.rib,
+		importFilterChain: f.importFilterChain,
+		addPathRX:         f.addPathRX,
+	}
+
+	log.WithFields(log.Fields{
+		"peer":         g.peer.addr.String(),
+		"afi":          packet.AFIName(f.afi),
+		"restart_time": restartTime,
+	}).Info("Retaining stale paths of restarting BGP peer")
+	g.schedulePurge(restartTime)
+}
+
+// adopt hands the retained Adj-RIB-In of f over to the re-established session. nil is returned if there is none or
+// it can not be used with the newly negotiated ADD-PATH mode.
+func (g *gracefulRestartHelper) adopt(f *fsmAddressFamily) routingtable.AdjRIBIn {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	r := g.ribs[f.afi]
+	if r == nil || r.adopted {
+		return nil
+	}
+
+	if r.addPathRX != f.addPathRX {
+		g.purge(f.afi)
+		return nil
+	}
+
+	if !r.importFilterChain.Equal(f.importFilterChain) {
+		r.adjRIBIn.ReplaceFilterChain(f.importFilterChain)
+	}
+
+	r.adopted = true
+	return r.adjRIBIn
+}
+
+// restarted removes the retained paths of address families the peer did not preserve across its restart and
+// schedules the removal of the remaining stale paths after the stale path time
+func (g *gracefulRestartHelper) restarted() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	for afi, r := range g.ribs {
+		if !r.adopted {
+			g.purge(afi)
+		}
+	}
+
+	if len(g.ribs) == 0 {
+		g.cancelPurge()
+		return
+	}
+
+	g.schedulePurge(g.config.StalePathTime)
+}
+
+// endOfRIB removes the paths of an address family the peer did not advertise again after its restart
+func (g *gracefulRestartHelper) endOfRIB(afi uint16) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	r := g.ribs[afi]
+	if r == nil || !r.adopted {
+		return
+	}
+
+	g.purge(afi)
+	if len(g.ribs) == 0 {
+		g.cancelPurge()
+	}
+}
+
+// forget drops the retained Adj-RIB-In adopted by f without removing its paths as the session is torn down
+func (g *gracefulRestartHelper) forget(f *fsmAddressFamily) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	r := g.ribs[f.afi]
+	if r == nil || r.adjRIBIn != f.adjRIBIn {
+		return
+	}
+
+	delete(g.ribs, f.afi)
+	if len(g.ribs) == 0 {
+		g.cancelPurge()
+	}
+}
+
+// purge removes the stale paths of an address family. The paths of an Adj-RIB-In no session took over are all stale.
+func (g *gracefulRestartHelper) purge(afi uint16) {
+	r := g.ribs[afi]
+	delete(g.ribs, afi)
+
+	n := r.adjRIBIn.RemoveStale()
+	if !r.adopted {
+		r.adjRIBIn.Unregister(r.rib)
+	}
+
+	log.WithFields(log.Fields{
+		"peer":  g.peer.addr.String(),
+		"afi":   packet.AFIName(afi),
+		"paths": n,
+	}).Info("Removed stale paths of restarted BGP peer")
+}
+
+func (g *gracefulRestartHelper) schedulePurge(d time.Duration) {
+	g.cancelPurge()
+
+	cancel := make(chan struct{})
+	g.cancel = cancel
+	t := g.peer.clock.NewTimer(d)
+
+	go func() {
+		select {
+		case <-t.C():
+			g.expire(cancel)
+		case <-cancel:
+			t.Stop()
+		}
+	}()
+}
+
+func (g *gracefulRestartHelper) cancelPurge() {
+	if g.cancel != nil {
+		close(g.cancel)
+		g.cancel = nil
+	}
+}
+
+// expire removes all retained stale paths unless the removal was canceled or rescheduled meanwhile
+func (g *gracefulRestartHelper) expire(cancel chan struct{}) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.cancel != cancel {
+		return
+	}
+
+	g.cancel = nil
+	for afi := range g.ribs {
+		g.purge(afi)
+	}
+}
+
+// peerGracefulRestartFamily checks if the peer advertised graceful restart for an address family in its OPEN message.
+// It is only taken into account if we act as receiving speaker for the peer.
+func (fsm *FSM) peerGracefulRestartFamily(afi uint16, safi uint8) (found bool, forwardingState bool) {
+	if fsm.peerGracefulRestart == nil {
+		return false, false
+	}
+
+	for _, t := range fsm.peerGracefulRestart.Tuples {
+		if t.AFI == afi && t.SAFI == safi {
+			return true, t.Flags&packet.GracefulRestartForwardingState != 0
+		}
+	}
+
+	return false, false
+}
+
+// retainStalePaths keeps the paths received on a failed session for all address families the peer advertised
+// graceful restart for (RFC4724 section 4.2)
+func (fsm *FSM) retainStalePaths() {
+	if fsm.peer.gracefulRestart == nil || fsm.peerGracefulRestart == nil {
+		return
+	}
+
+	restartTime := time.Duration(fsm.peerGracefulRestart.RestartTime) * time.Second
+	if restartTime == 0 {
+		return
+	}
+
+	for _, f := range []*fsmAddressFamily{fsm.ipv4Unicast, fsm.ipv6Unicast} {
+		if f == nil || !f.initialized {
+			continue
+		}
+
+		if found, _ := fsm.peerGracefulRestartFamily(f.afi, f.safi); !found {
+			continue
+		}
+
+		fsm.peer.gracefulRestart.retain(f, restartTime)
+		f.adjRIBInRetained = true
+	}
+}
+
+// adoptRetainedAdjRIBIn takes over the Adj-RIB-In retained from the previous session if the peer preserved its
+// forwarding state for the address family. Otherwise, the retained paths are removed on restarted().
+func (f *fsmAddressFamily) adoptRetainedAdjRIBIn() routingtable.AdjRIBIn {
+	if f.fsm.peer.gracefulRestart == nil {
+		return nil
+	}
+
+	if _, forwardingState := f.fsm.peerGracefulRestartFamily(f.afi, f.safi); !forwardingState {
+		return nil
+	}
+
+	return f.fsm.peer.gracefulRestart.adopt(f)
+}
@@ -0,0 +1,334 @@
+package server
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+	btesting "github.com/bio-routing/bio-rd/testing"
+	btime "github.com/bio-routing/bio-rd/util/time"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	grTestPfxA = bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()
+	grTestPfxB = bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+)
+
+func TestGracefulRestartValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		gr      *GracefulRestart
+		wantErr bool
+	}{
+		{
+			name: "Disabled",
+		},
+		{
+			name: "Valid",
+			gr: &GracefulRestart{
+				RestartTime:   120 * time.Second,
+				StalePathTime: 360 * time.Second,
+			},
+		},
+		{
+			name: "Maximum restart time",
+			gr: &GracefulRestart{
+				RestartTime:   4095 * time.Second,
+				StalePathTime: time.Hour,
+			},
+		},
+		{
+			name: "Restart time exceeding capability field",
+			gr: &GracefulRestart{
+				RestartTime:   4096 * time.Second,
+				StalePathTime: 360 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "No restart time",
+			gr: &GracefulRestart{
+				StalePathTime: 360 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "No stale path time",
+			gr: &GracefulRestart{
+				RestartTime: 120 * time.Second,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Stale path time too long",
+			gr: &GracefulRestart{
+				RestartTime:   120 * time.Second,
+				StalePathTime: time.Hour + time.Second,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newPeer(PeerConfig{
+				LocalAS:         65000,
+				PeerAS:          65000,
+				PeerAddress:     bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+				Passive:         true,
+				GracefulRestart: test.gr,
+			}, nil)
+			assert.Equal(t, test.wantErr, err != nil, "unexpected error: %v", err)
+		})
+	}
+}
+
+func TestGracefulRestartCapability(t *testing.T) {
+	p, err := newPeer(PeerConfig{
+		LocalAS:  65000,
+		PeerAS:   65000,
+		Passive:  true,
+		HoldTime: 90 * time.Second,
+		GracefulRestart: &GracefulRestart{
+			RestartTime:   300 * time.Second,
+			StalePathTime: 600 * time.Second,
+		},
+	}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	fsm := newFSM(p)
+	caps := fsm.openMessage().OptParams[0].Value.(packet.Capabilities)
+	assert.Contains(t, caps, packet.Capability{
+		Code: packet.GracefulRestartCapabilityCode,
+		Value: packet.GracefulRestartCapability{
+			RestartTime: 300,
+		},
+	})
+
+	peerCap := packet.GracefulRestartCapability{
+		RestartTime: 90,
+		Tuples: []packet.GracefulRestartCapabilityTuple{
+			{
+				AFI:   packet.AFIIPv4,
+				SAFI:  packet.SAFIUnicast,
+				Flags: packet.GracefulRestartForwardingState,
+			},
+		},
+	}
+	newOpenSentState(fsm).processCapability(packet.Capability{
+		Code:  packet.GracefulRestartCapabilityCode,
+		Value: peerCap,
+	})
+	assert.Equal(t, &peerCap, fsm.peerGracefulRestart)
+}
+
+func TestGracefulRestartCapabilityNotConfigured(t *testing.T) {
+	p, err := newPeer(PeerConfig{
+		LocalAS:  65000,
+		PeerAS:   65000,
+		Passive:  true,
+		HoldTime: 90 * time.Second,
+	}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	fsm := newFSM(p)
+	for _, c := range fsm.openMessage().OptParams[0].Value.(packet.Capabilities) {
+		assert.NotEqual(t, uint8(packet.GracefulRestartCapabilityCode), c.Code)
+	}
+
+	newOpenSentState(fsm).processCapability(packet.Capability{
+		Code:  packet.GracefulRestartCapabilityCode,
+		Value: packet.GracefulRestartCapability{RestartTime: 90},
+	})
+	assert.Nil(t, fsm.peerGracefulRestart, "peer capability must be ignored without helper mode")
+}
+
+func newGracefulRestartTestPeer(t *testing.T, clock *btime.MockClock) (*peer, *locRIB.LocRIB) {
+	v := vrf.NewVRFRegistry().CreateVRFIfNotExists("inet.0", 0)
+	p, err := newPeer(PeerConfig{
+		LocalAS:     65000,
+		PeerAS:      65000,
+		PeerAddress: bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+		Passive:     true,
+		HoldTime:    90 * time.Second,
+		VRF:         v,
+		IPv4: &AddressFamilyConfig{
+			ImportFilterChain: filter.NewAcceptAllFilterChain(),
+			ExportFilterChain: filter.NewAcceptAllFilterChain(),
+		},
+		GracefulRestart: &GracefulRestart{
+			RestartTime:   120 * time.Second,
+			StalePathTime: 360 * time.Second,
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to create peer: %v", err)
+	}
+
+	p.routerID = bnet.IPv4FromOctets(10, 0, 0, 1).Ptr().ToUint32()
+	p.clock = clock
+	return p, v.IPv4UnicastRIB()
+}
+
+// establishGracefulRestartSession brings up a session with a peer advertising graceful restart for IPv4 unicast
+func establishGracefulRestartSession(t *testing.T, p *peer, forwardingState bool) *establishedState {
+	fsm := newFSM(p)
+	fsm.con = btesting.NewMockConn()
+	fsm.peerGracefulRestart = &packet.GracefulRestartCapability{
+		RestartTime: 90,
+		Tuples: []packet.GracefulRestartCapabilityTuple{
+			{
+				AFI:  packet.AFIIPv4,
+				SAFI: packet.SAFIUnicast,
+			},
+		},
+	}
+	if forwardingState {
+		fsm.peerGracefulRestart.Tuples[0].Flags = packet.GracefulRestartForwardingState
+	}
+
+	s := newEstablishedState(fsm)
+	assert.NoError(t, s.init())
+	return s
+}
+
+func announce(s *establishedState, pfxs ...*bnet.Prefix) {
+	for _, pfx := range pfxs {
+		s.update(&packet.BGPUpdate{
+			PathAttributes: &packet.PathAttribute{
+				TypeCode: packet.OriginAttr,
+				Value:    uint8(0),
+				Next: &packet.PathAttribute{
+					TypeCode: packet.ASPathAttr,
+					Value:    &types.ASPath{},
+					Next: &packet.PathAttribute{
+						TypeCode: packet.NextHopAttr,
+						Value:    bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+					},
+				},
+			},
+			NLRI: &packet.NLRI{
+				Prefix: pfx,
+			},
+		}, false, 0)
+	}
+}
+
+func ribPrefixes(rib *locRIB.LocRIB) []*bnet.Prefix {
+	ret := make([]*bnet.Prefix, 0)
+	for _, r := range rib.Snapshot() {
+		if len(r.Paths()) != 0 {
+			ret = append(ret, r.Prefix())
+		}
+	}
+
+	return ret
+}
+
+func assertRIBPrefixes(t *testing.T, rib *locRIB.LocRIB, expected ...*bnet.Prefix) {
+	assert.Eventually(t, func() bool {
+		return assert.ObjectsAreEqual(len(expected), len(ribPrefixes(rib)))
+	}, time.Second, time.Millisecond)
+	assert.ElementsMatch(t, expected, ribPrefixes(rib))
+}
+
+func TestGracefulRestartStalePathTime(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	p, rib := newGracefulRestartTestPeer(t, clock)
+
+	s := establishGracefulRestartSession(t, p, true)
+	announce(s, grTestPfxA, grTestPfxB)
+	assertRIBPrefixes(t, rib, grTestPfxA, grTestPfxB)
+
+	next, reason := s.tcpConnectionFails(io.EOF)
+	assert.IsType(t, &idleState{}, next)
+	assert.Equal(t, "TCP connection failure: EOF", reason)
+	assertRIBPrefixes(t, rib, grTestPfxA, grTestPfxB)
+
+	// The restarted peer only advertises pfxA again and never sends the End-of-RIB marker
+	clock.Advance(60 * time.Second)
+	s = establishGracefulRestartSession(t, p, true)
+	announce(s, grTestPfxA)
+
+	clock.Advance(359 * time.Second)
+	time.Sleep(10 * time.Millisecond)
+	assertRIBPrefixes(t, rib, grTestPfxA, grTestPfxB)
+
+	clock.Advance(time.Second)
+	assertRIBPrefixes(t, rib, grTestPfxA)
+	s.uninit()
+}
+
+func TestGracefulRestartEndOfRIB(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	p, rib := newGracefulRestartTestPeer(t, clock)
+
+	s := establishGracefulRestartSession(t, p, true)
+	announce(s, grTestPfxA, grTestPfxB)
+	s.tcpConnectionFails(io.EOF)
+
+	s = establishGracefulRestartSession(t, p, true)
+	announce(s, grTestPfxA)
+	assertRIBPrefixes(t, rib, grTestPfxA, grTestPfxB)
+
+	s.update(&packet.BGPUpdate{}, false, 0)
+	assertRIBPrefixes(t, rib, grTestPfxA)
+	assert.Eventually(t, func() bool {
+		return clock.PendingTimers() == 0
+	}, time.Second, time.Millisecond, "stale path timer must be stopped")
+	s.uninit()
+}
+
+func TestGracefulRestartRestartTimeExpired(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	p, rib := newGracefulRestartTestPeer(t, clock)
+
+	s := establishGracefulRestartSession(t, p, true)
+	announce(s, grTestPfxA, grTestPfxB)
+	s.tcpConnectionFails(io.EOF)
+
+	// Stale paths are kept for the restart time advertised by the peer
+	clock.Advance(89 * time.Second)
+	time.Sleep(10 * time.Millisecond)
+	assertRIBPrefixes(t, rib, grTestPfxA, grTestPfxB)
+
+	clock.Advance(time.Second)
+	assertRIBPrefixes(t, rib)
+}
+
+func TestGracefulRestartForwardingStateLost(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	p, rib := newGracefulRestartTestPeer(t, clock)
+
+	s := establishGracefulRestartSession(t, p, true)
+	announce(s, grTestPfxA, grTestPfxB)
+	s.tcpConnectionFails(io.EOF)
+
+	s = establishGracefulRestartSession(t, p, false)
+	assertRIBPrefixes(t, rib)
+	s.uninit()
+}
+
+func TestGracefulRestartNotification(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	p, rib := newGracefulRestartTestPeer(t, clock)
+
+	s := establishGracefulRestartSession(t, p, true)
+	announce(s, grTestPfxA, grTestPfxB)
+	s.notification(&packet.BGPNotification{
+		ErrorCode: packet.Cease,
+	})
+	assertRIBPrefixes(t, rib)
+	assert.Equal(t, 0, clock.PendingTimers())
+}
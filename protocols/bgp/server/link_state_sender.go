@@ -0,0 +1,231 @@
+package server
+
+import (
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+const (
+	defaultLinkStateUpdateInterval = 10 * time.Second
+
+	// linkStateUpdateOverhead is the space reserved for the header and the ORIGIN, AS_PATH,
+	// LOCAL_PREF and MP_(UN)REACH_NLRI attribute headers of a BGP-LS update
+	linkStateUpdateOverhead = 64
+)
+
+// LinkStateSource provides the link state NLRIs to be advertised to BGP-LS peers, e.g. derived from an IGP
+type LinkStateSource interface {
+	LinkStateNLRIs() []*packet.LinkStateNLRI
+}
+
+// LinkStateConfig represents the configuration of the BGP-LS address family (RFC7752)
+type LinkStateConfig struct {
+	Source LinkStateSource
+
+	// UpdateInterval is the interval Source is polled for changes. Defaults to 10s.
+	UpdateInterval time.Duration
+}
+
+// linkStateSender advertises the NLRIs of a LinkStateSource via BGP-LS and withdraws vanished ones
+type linkStateSender struct {
+	fsm        *FSM
+	source     LinkStateSource
+	interval   time.Duration
+	options    *packet.EncodeOptions
+	advertised map[string]*packet.LinkStateNLRI
+	stopCh     chan struct{}
+	wg         sync.WaitGroup
+}
+
+func newLinkStateSender(fsm *FSM, c *LinkStateConfig) *linkStateSender {
+	interval := c.UpdateInterval
+	if interval == 0 {
+		interval = defaultLinkStateUpdateInterval
+	}
+
+	return &linkStateSender{
+		fsm:        fsm,
+		source:     c.Source,
+		interval:   interval,
+		advertised: make(map[string]*packet.LinkStateNLRI),
+		stopCh:     make(chan struct{}),
+		options: &packet.EncodeOptions{
+			Use32BitASN:     fsm.supports4OctetASN,
+			ExtendedMessage: fsm.extendedMessage,
+		},
+	}
+}
+
+func (l *linkStateSender) start() {
+	l.wg.Add(1)
+	go l.run()
+}
+
+func (l *linkStateSender) stop() {
+	close(l.stopCh)
+	l.wg.Wait()
+}
+
+func (l *linkStateSender) run() {
+	defer l.wg.Done()
+
+	ticker := time.NewTicker(l.interval)
+	defer ticker.Stop()
+
+	for {
+		err := l.sync(l.fsm.con)
+		if err != nil {
+			log.Errorf("unable to send BGP-LS update: %v", err)
+		}
+
+		select {
+		case <-l.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// sync advertises all NLRIs of the source not yet sent and withdraws the ones that disappeared
+func (l *linkStateSender) sync(out io.Writer) error {
+	current := make(map[string]*packet.LinkStateNLRI)
+	for _, n := range l.source.LinkStateNLRIs() {
+		current[n.Key()] = n
+	}
+
+	reach := make([]string, 0)
+	for k := range current {
+		if _, exists := l.advertised[k]; !exists {
+			reach = append(reach, k)
+		}
+	}
+
+	unreach := make([]string, 0)
+	for k := range l.advertised {
+		if _, exists := current[k]; !exists {
+			unreach = append(unreach, k)
+		}
+	}
+
+	for _, keys := range l.chunk(unreach) {
+		err := serializeAndSendUpdate(out, l.withdrawUpdate(nlrisForKeys(l.advertised, keys)), l.options)
+		if err != nil {
+			return err
+		}
+
+		for _, k := range keys {
+			delete(l.advertised, k)
+		}
+	}
+
+	for _, keys := range l.chunk(reach) {
+		err := serializeAndSendUpdate(out, l.advertiseUpdate(nlrisForKeys(current, keys)), l.options)
+		if err != nil {
+			return err
+		}
+
+		for _, k := range keys {
+			l.advertised[k] = current[k]
+		}
+	}
+
+	return nil
+}
+
+// chunk splits keys into groups fitting into a single update message
+func (l *linkStateSender) chunk(keys []string) [][]string {
+	sort.Strings(keys)
+
+	ret := make([][]string, 0)
+	maxLen := l.options.MaxMessageLen() - linkStateUpdateOverhead
+
+	cur := make([]string, 0)
+	budget := maxLen
+	for _, k := range keys {
+		if len(k) > budget && len(cur) > 0 {
+			ret = append(ret, cur)
+			cur = make([]string, 0)
+			budget = maxLen
+		}
+
+		cur = append(cur, k)
+		budget -= len(k)
+	}
+
+	if len(cur) > 0 {
+		ret = append(ret, cur)
+	}
+
+	return ret
+}
+
+func nlrisForKeys(nlris map[string]*packet.LinkStateNLRI, keys []string) []*packet.LinkStateNLRI {
+	ret := make([]*packet.LinkStateNLRI, 0, len(keys))
+	for _, k := range keys {
+		ret = append(ret, nlris[k])
+	}
+
+	return ret
+}
+
+func (l *linkStateSender) advertiseUpdate(nlris []*packet.LinkStateNLRI) *packet.BGPUpdate {
+	iBGP := l.fsm.peer.localASN == l.fsm.peer.peerASN
+
+	asPath := types.ASPath{}
+	if !iBGP {
+		asPath = append(asPath, types.ASPathSegment{
+			Type: types.ASSequence,
+			ASNs: []uint32{l.fsm.openASN()},
+		})
+	}
+
+	attrs := &packet.PathAttribute{
+		TypeCode: packet.MultiProtocolReachNLRIAttr,
+		Value: packet.MultiProtocolReachNLRI{
+			AFI:            packet.AFILinkState,
+			SAFI:           packet.SAFILinkState,
+			LinkStateNLRIs: nlris,
+		},
+	}
+
+	origin := &packet.PathAttribute{
+		TypeCode: packet.OriginAttr,
+		Value:    uint8(packet.IGP),
+	}
+	attrs.Next = origin
+
+	origin.Next = &packet.PathAttribute{
+		TypeCode: packet.ASPathAttr,
+		Value:    &asPath,
+	}
+
+	if iBGP {
+		origin.Next.Next = &packet.PathAttribute{
+			TypeCode: packet.LocalPrefAttr,
+			Value:    uint32(100),
+		}
+	}
+
+	return &packet.BGPUpdate{
+		PathAttributes: attrs,
+	}
+}
+
+func (l *linkStateSender) withdrawUpdate(nlris []*packet.LinkStateNLRI) *packet.BGPUpdate {
+	return &packet.BGPUpdate{
+		PathAttributes: &packet.PathAttribute{
+			TypeCode: packet.MultiProtocolUnreachNLRIAttr,
+			Value: packet.MultiProtocolUnreachNLRI{
+				AFI:            packet.AFILinkState,
+				SAFI:           packet.SAFILinkState,
+				LinkStateNLRIs: nlris,
+			},
+		},
+	}
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/stretchr/testify/assert"
+)
+
+type linkStateSourceMock struct {
+	nlris []*packet.LinkStateNLRI
+}
+
+func (m *linkStateSourceMock) LinkStateNLRIs() []*packet.LinkStateNLRI {
+	return m.nlris
+}
+
+func linkStateNodeNLRI(sysID byte) *packet.LinkStateNLRI {
+	return &packet.LinkStateNLRI{
+		Type:       packet.LinkStateNodeNLRIType,
+		ProtocolID: packet.LinkStateProtocolISISL2,
+		LocalNode: &packet.LinkStateNodeDescriptor{
+			ASN:         65000,
+			IGPRouterID: []byte{0, 0, 0, 0, 0, sysID},
+		},
+	}
+}
+
+func decodeLinkStateUpdates(t *testing.T, b []byte) (reach []*packet.LinkStateNLRI, unreach []*packet.LinkStateNLRI) {
+	buf := bytes.NewBuffer(b)
+	for buf.Len() > 0 {
+		msg, err := packet.Decode(buf, &packet.DecodeOptions{Use32BitASN: true})
+		if err != nil {
+			t.Fatalf("unable to decode update: %v", err)
+		}
+
+		for pa := msg.Body.(*packet.BGPUpdate).PathAttributes; pa != nil; pa = pa.Next {
+			switch pa.TypeCode {
+			case packet.MultiProtocolReachNLRIAttr:
+				reach = append(reach, pa.Value.(packet.MultiProtocolReachNLRI).LinkStateNLRIs...)
+			case packet.MultiProtocolUnreachNLRIAttr:
+				unreach = append(unreach, pa.Value.(packet.MultiProtocolUnreachNLRI).LinkStateNLRIs...)
+			}
+		}
+	}
+
+	return reach, unreach
+}
+
+func TestLinkStateSenderSync(t *testing.T) {
+	src := &linkStateSourceMock{
+		nlris: []*packet.LinkStateNLRI{
+			linkStateNodeNLRI(1),
+			linkStateNodeNLRI(2),
+		},
+	}
+
+	fsm := &FSM{
+		peer: &peer{
+			localASN: 65000,
+			peerASN:  65001,
+		},
+		supports4OctetASN: true,
+	}
+	l := newLinkStateSender(fsm, &LinkStateConfig{Source: src})
+
+	buf := bytes.NewBuffer(nil)
+	assert.NoError(t, l.sync(buf))
+	reach, unreach := decodeLinkStateUpdates(t, buf.Bytes())
+	assert.Equal(t, src.nlris, reach)
+	assert.Empty(t, unreach)
+
+	// Nothing changed
+	buf.Reset()
+	assert.NoError(t, l.sync(buf))
+	assert.Equal(t, 0, buf.Len())
+
+	src.nlris = []*packet.LinkStateNLRI{
+		linkStateNodeNLRI(2),
+		linkStateNodeNLRI(3),
+	}
+
+	buf.Reset()
+	assert.NoError(t, l.sync(buf))
+	reach, unreach = decodeLinkStateUpdates(t, buf.Bytes())
+	assert.Equal(t, []*packet.LinkStateNLRI{linkStateNodeNLRI(3)}, reach)
+	assert.Equal(t, []*packet.LinkStateNLRI{linkStateNodeNLRI(1)}, unreach)
+}
+
+func TestLinkStateSenderChunk(t *testing.T) {
+	l := newLinkStateSender(&FSM{peer: &peer{}}, &LinkStateConfig{})
+
+	keys := make([]string, 0)
+	for i := 0; i < 200; i++ {
+		keys = append(keys, linkStateNodeNLRI(byte(i)).Key())
+	}
+
+	chunks := l.chunk(keys)
+	assert.Greater(t, len(chunks), 1)
+
+	total := 0
+	for _, c := range chunks {
+		length := 0
+		for _, k := range c {
+			length += len(k)
+		}
+
+		assert.LessOrEqual(t, length, packet.MaxLen-linkStateUpdateOverhead)
+		total += len(c)
+	}
+
+	assert.Equal(t, len(keys), total)
+}
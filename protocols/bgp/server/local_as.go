@@ -0,0 +1,93 @@
+package server
+
+import (
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// LocalASOverride configures an alternate ASN presented to a peer instead of the real local ASN (local-as),
+// e.g. during AS migrations
+type LocalASOverride struct {
+	// ASN is the alternate ASN used in the OPEN message
+	ASN uint32
+
+	// NoPrepend disables prepending ASN to the AS path of routes received from the peer
+	NoPrepend bool
+
+	// ReplaceAS only prepends ASN instead of ASN and the real local ASN to routes sent to the peer
+	ReplaceAS bool
+
+	// DualAS allows the peer to be configured with either ASN or the real local ASN. We alternate between both
+	// in our OPEN message whenever the peer rejects it with a Bad Peer AS notification. The ASN accepted by the
+	// peer is kept for all further connections, active and passive ones.
+	DualAS bool
+}
+
+// Equal compares two LocalASOverrides
+func (l *LocalASOverride) Equal(x *LocalASOverride) bool {
+	if l == nil || x == nil {
+		return l == x
+	}
+
+	return *l == *x
+}
+
+// openASN returns the ASN we present to the peer
+func (fsm *FSM) openASN() uint32 {
+	asn := fsm.localASOverride()
+	if asn != 0 {
+		return asn
+	}
+
+	return fsm.peer.localASN
+}
+
+// localASOverride returns the alternate ASN in use for the current connection or 0 if the real local ASN is used
+func (fsm *FSM) localASOverride() uint32 {
+	if fsm.peer.localASOverride == nil || fsm.dualASFallback {
+		return 0
+	}
+
+	return fsm.peer.localASOverride.ASN
+}
+
+func (fsm *FSM) optOpenParams() []packet.OptParam {
+	if fsm.dualASFallback {
+		return fsm.peer.optOpenParamsRealASN
+	}
+
+	return fsm.peer.optOpenParams
+}
+
+// handleBadPeerAS switches between alternate and real local ASN for dual-as peers rejecting our OPEN message
+func (fsm *FSM) handleBadPeerAS(n *packet.BGPNotification) {
+	if fsm.peer.localASOverride == nil || !fsm.peer.localASOverride.DualAS {
+		return
+	}
+
+	if n.ErrorCode != packet.OpenMessageError || n.ErrorSubcode != packet.BadPeerAS {
+		return
+	}
+
+	// The peer rejected the ASN presented on this connection, so the next connection presents the other one
+	fsm.dualASFallback = !fsm.dualASFallback
+	fsm.peer.dualASFallback.Store(fsm.dualASFallback)
+}
+
+// prependLocalASOverride prepends the alternate ASN to the AS path of a path received from the peer
+func (f *fsmAddressFamily) prependLocalASOverride(p *route.Path) {
+	if f.fsm == nil || p.BGPPath.ASPath == nil {
+		return
+	}
+
+	if f.fsm.peer.localASOverride == nil || f.fsm.peer.localASOverride.NoPrepend {
+		return
+	}
+
+	asn := f.fsm.localASOverride()
+	if asn == 0 {
+		return
+	}
+
+	p.BGPPath.Prepend(asn, 1)
+}
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/adjRIBOut"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	btesting "github.com/bio-routing/bio-rd/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalASOverrideModifiers(t *testing.T) {
+	badPeerAS := &packet.BGPNotification{
+		ErrorCode:    packet.OpenMessageError,
+		ErrorSubcode: packet.BadPeerAS,
+	}
+
+	tests := []struct {
+		name            string
+		localASOverride *LocalASOverride
+		peerRejected    bool
+		expectedOpenASN uint32
+		expectedIn      []uint32
+		expectedOut     []uint32
+	}{
+		{
+			name:            "no local-as",
+			expectedOpenASN: 65000,
+			expectedIn:      []uint32{65100},
+			expectedOut:     []uint32{65000, 65200},
+		},
+		{
+			name: "local-as",
+			localASOverride: &LocalASOverride{
+				ASN: 65001,
+			},
+			expectedOpenASN: 65001,
+			expectedIn:      []uint32{65001, 65100},
+			expectedOut:     []uint32{65001, 65000, 65200},
+		},
+		{
+			name: "local-as no-prepend",
+			localASOverride: &LocalASOverride{
+				ASN:       65001,
+				NoPrepend: true,
+			},
+			expectedOpenASN: 65001,
+			expectedIn:      []uint32{65100},
+			expectedOut:     []uint32{65001, 65000, 65200},
+		},
+		{
+			name: "local-as replace-as",
+			localASOverride: &LocalASOverride{
+				ASN:       65001,
+				ReplaceAS: true,
+			},
+			expectedOpenASN: 65001,
+			expectedIn:      []uint32{65001, 65100},
+			expectedOut:     []uint32{65001, 65200},
+		},
+		{
+			name: "local-as no-prepend replace-as",
+			localASOverride: &LocalASOverride{
+				ASN:       65001,
+				NoPrepend: true,
+				ReplaceAS: true,
+			},
+			expectedOpenASN: 65001,
+			expectedIn:      []uint32{65100},
+			expectedOut:     []uint32{65001, 65200},
+		},
+		{
+			name: "local-as no-prepend replace-as rejected by a peer without dual-as",
+			localASOverride: &LocalASOverride{
+				ASN:       65001,
+				NoPrepend: true,
+				ReplaceAS: true,
+			},
+			peerRejected:    true,
+			expectedOpenASN: 65001,
+			expectedIn:      []uint32{65100},
+			expectedOut:     []uint32{65001, 65200},
+		},
+		{
+			name: "local-as dual-as",
+			localASOverride: &LocalASOverride{
+				ASN:    65001,
+				DualAS: true,
+			},
+			expectedOpenASN: 65001,
+			expectedIn:      []uint32{65001, 65100},
+			expectedOut:     []uint32{65001, 65000, 65200},
+		},
+		{
+			name: "local-as dual-as with peer configured for the real ASN",
+			localASOverride: &LocalASOverride{
+				ASN:    65001,
+				DualAS: true,
+			},
+			peerRejected:    true,
+			expectedOpenASN: 65000,
+			expectedIn:      []uint32{65100},
+			expectedOut:     []uint32{65000, 65200},
+		},
+		{
+			name: "local-as no-prepend replace-as dual-as",
+			localASOverride: &LocalASOverride{
+				ASN:       65001,
+				NoPrepend: true,
+				ReplaceAS: true,
+				DualAS:    true,
+			},
+			expectedOpenASN: 65001,
+			expectedIn:      []uint32{65100},
+			expectedOut:     []uint32{65001, 65200},
+		},
+		{
+			name: "local-as no-prepend replace-as dual-as with peer configured for the real ASN",
+			localASOverride: &LocalASOverride{
+				ASN:       65001,
+				NoPrepend: true,
+				ReplaceAS: true,
+				DualAS:    true,
+			},
+			peerRejected:    true,
+			expectedOpenASN: 65000,
+			expectedIn:      []uint32{65100},
+			expectedOut:     []uint32{65000, 65200},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &peer{
+				addr:            bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+				localAddr:       bnet.IPv4FromOctets(10, 0, 0, 0).Ptr(),
+				localASN:        65000,
+				peerASN:         65100,
+				localASOverride: test.localASOverride,
+				routerID:        100,
+			}
+
+			fsm := newFSM(p)
+			if test.peerRejected {
+				fsm.handleBadPeerAS(badPeerAS)
+
+				// The peer reconnects on a new FSM
+				fsm = newFSM(p)
+			}
+
+			assert.Equal(t, test.expectedOpenASN, fsm.openASN())
+			assert.Equal(t, uint16(test.expectedOpenASN), fsm.openMessage().ASN)
+
+			// The peer presents its own ASN no matter which of our ASNs it is configured with
+			fsm.con = btesting.NewMockConn()
+			next, _ := newOpenSentState(fsm).handleOpenMessage(&packet.BGPOpen{
+				Version: BGPVersion,
+				ASN:     65100,
+			})
+			assert.IsType(t, &openConfirmState{}, next)
+
+			f := &fsmAddressFamily{
+				fsm: fsm,
+			}
+
+			in := &route.Path{
+				BGPPath: &route.BGPPath{},
+			}
+			f.processAttributes(&packet.PathAttribute{
+				TypeCode: packet.ASPathAttr,
+				Value: &types.ASPath{
+					types.ASPathSegment{
+						Type: types.ASSequence,
+						ASNs: []uint32{65100},
+					},
+				},
+			}, in)
+			f.prependLocalASOverride(in)
+			assert.Equal(t, test.expectedIn, (*in.BGPPath.ASPath)[0].ASNs)
+
+			ribOut := adjRIBOut.New(locRIB.New("inet.0"), f.getSessionAttrs(), filter.NewAcceptAllFilterChain())
+			res := ribOut.EvaluatePath(bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr(), &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{
+						NextHop: bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+						Source:  bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+						EBGP:    true,
+					},
+					ASPath: &types.ASPath{
+						types.ASPathSegment{
+							Type: types.ASSequence,
+							ASNs: []uint32{65200},
+						},
+					},
+				},
+			})
+			if !assert.False(t, res.Reject) {
+				return
+			}
+
+			assert.Equal(t, test.expectedOut, (*res.Path.BGPPath.ASPath)[0].ASNs)
+		})
+	}
+}
+
+func TestDualASFallbackKeptAcrossFSMs(t *testing.T) {
+	badPeerAS := &packet.BGPNotification{
+		ErrorCode:    packet.OpenMessageError,
+		ErrorSubcode: packet.BadPeerAS,
+	}
+
+	p := &peer{
+		localASN: 65000,
+		peerASN:  65100,
+		localASOverride: &LocalASOverride{
+			ASN:    65001,
+			DualAS: true,
+		},
+	}
+
+	active := newFSM(p)
+	assert.Equal(t, uint32(65001), active.openASN())
+
+	// The peer rejects the alternate ASN on a passive connection
+	passive := newFSM(p)
+	passive.handleBadPeerAS(badPeerAS)
+	assert.Equal(t, uint32(65000), newFSM(p).openASN(), "new passive FSM")
+
+	// An existing FSM picks up the ASN accepted by the peer when sending its next OPEN message
+	active.con = btesting.NewMockConn()
+	assert.NoError(t, active.sendOpen())
+	assert.Equal(t, uint32(65000), active.openASN(), "existing active FSM")
+
+	// Rejecting the real ASN switches back to the alternate one
+	active.handleBadPeerAS(badPeerAS)
+	assert.Equal(t, uint32(65001), newFSM(p).openASN())
+}
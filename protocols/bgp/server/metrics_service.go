@@ -32,9 +32,13 @@ func metricsForPeer(peer *peer) *metrics.BGPPeerMetrics {
 		IP:              peer.addr,
 		AddressFamilies: make([]*metrics.BGPAddressFamilyMetrics, 0),
 		VRF:             peer.vrf.Name(),
+		PolicyVersion:   peer.getPolicyVersion(),
 	}
 
-	var fsms = peer.fsms
+	peer.fsmsMu.Lock()
+	fsms := peer.fsms
+	peer.fsmsMu.Unlock()
+
 	if len(fsms) == 0 {
 		return m
 	}
@@ -44,10 +48,14 @@ func metricsForPeer(peer *peer) *metrics.BGPPeerMetrics {
 
 	if m.State == metrics.StateEstablished {
 		m.Since = fsm.establishedTime
+		m.HoldTime = fsm.holdTime
 	}
 
 	m.UpdatesReceived = fsm.counters.updatesReceived
 	m.UpdatesSent = fsm.counters.updatesSent
+	m.InboundQueueDepth = uint64(fsm.inboundQueueDepth())
+	m.RouteRefreshesReceived = fsm.counters.routeRefreshesReceived
+	m.RouteRefreshesCoalesced = fsm.counters.routeRefreshesCoalesced
 
 	fsm.stateMu.RLock()
 	defer fsm.stateMu.RUnlock()
@@ -70,6 +78,9 @@ func metricsForFamily(family *fsmAddressFamily) *metrics.BGPAddressFamilyMetrics
 		AFI:                    family.afi,
 		SAFI:                   family.safi,
 		RoutesReceived:         uint64(family.adjRIBIn.RouteCount()),
+		PrePolicyPaths:         family.adjRIBIn.PrePolicyPathCount(),
+		PrePolicyBytes:         family.adjRIBIn.PrePolicyBytes(),
+		ASLoops:                family.adjRIBIn.ASLoopCount(),
 		EndOfRIBMarkerReceived: family.endOfRIBMarkerReceived.Load(),
 	}
 
@@ -60,6 +60,7 @@ func TestMetrics(t *testing.T) {
 						VRF:             "inet.0",
 						State:           metrics.StateEstablished,
 						Since:           establishedTime,
+						HoldTime:        90 * time.Second,
 						AddressFamilies: []*metrics.BGPAddressFamilyMetrics{
 							{
 								AFI:            packet.AFIIPv4,
@@ -240,6 +241,7 @@ func TestMetrics(t *testing.T) {
 				fsm.ipv6Unicast.adjRIBOut = &routingtable.RTMockClient{FakeRouteCount: test.ipv6RoutesSent}
 
 				fsm.establishedTime = establishedTime
+				fsm.holdTime = 90 * time.Second
 			}
 
 			s := newBGPServer(0, nil)
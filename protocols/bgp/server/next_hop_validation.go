@@ -0,0 +1,123 @@
+package server
+
+import (
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// NextHopValidation validates the next hop of paths received from a peer before they enter the AdjRIBIn. Paths with
+// an unspecified, loopback, multicast or otherwise reserved next hop or with our own address as next hop are invalid.
+// Paths are checked once on receipt only.
+type NextHopValidation struct {
+	// Resolve additionally requires the next hop to be resolvable via the RIB at the time the path is received. This is
+	// a sanity check on receipt, not next hop tracking: Paths are not checked again when the RIB changes later, so a
+	// path stays hidden (or stays eligible) until the peer sends it again or the session is reset.
+	Resolve bool
+
+	// Reject treats paths with an invalid next hop as withdrawn (RFC7606). Otherwise they are kept hidden in the
+	// AdjRIBIn and are not eligible for path selection.
+	Reject bool
+}
+
+// Equal compares two NextHopValidations
+func (v *NextHopValidation) Equal(x *NextHopValidation) bool {
+	if v == nil || x == nil {
+		return v == x
+	}
+
+	return *v == *x
+}
+
+func (c *AddressFamilyConfig) nextHopValidation() *NextHopValidation {
+	if c == nil {
+		return nil
+	}
+
+	return c.NextHopValidation
+}
+
+// martianNextHops are the networks no valid next hop is part of
+var martianNextHops = []bnet.Prefix{
+	bnet.NewPfx(bnet.IPv4FromOctets(0, 0, 0, 0), 8),
+	bnet.NewPfx(bnet.IPv4FromOctets(127, 0, 0, 0), 8),
+	bnet.NewPfx(bnet.IPv4FromOctets(224, 0, 0, 0), 4),
+	bnet.NewPfx(bnet.IPv4FromOctets(240, 0, 0, 0), 4),
+	bnet.NewPfx(bnet.IPv6(0, 0), 128),
+	bnet.NewPfx(bnet.IPv6(0, 1), 128),
+	bnet.NewPfx(bnet.IPv6FromBlocks(0xff00, 0, 0, 0, 0, 0, 0, 0), 8),
+}
+
+func isMartianNextHop(nh *bnet.IP) bool {
+	addr := bnet.NewPfx(*nh, 128)
+	if nh.IsIPv4() {
+		addr = bnet.NewPfx(*nh, 32)
+	}
+
+	for i := range martianNextHops {
+		if martianNextHops[i].Equal(&addr) || martianNextHops[i].Contains(&addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateNextHop returns the reason path has to be hidden for due to its next hop or HiddenReasonNone if it is valid
+func (f *fsmAddressFamily) validateNextHop(path *route.Path) uint8 {
+	nh := path.BGPPath.BGPPathA.NextHop
+	if nh == nil || isMartianNextHop(nh) {
+		return route.HiddenReasonInvalidNextHop
+	}
+
+	if f.fsm.peer.localAddr != nil && nh.Compare(f.fsm.peer.localAddr) == 0 {
+		return route.HiddenReasonInvalidNextHop
+	}
+
+	if f.nextHopValidation.Resolve && f.rib != nil {
+		_, err := f.rib.ResolveNextHop(nh)
+		if err != nil {
+			return route.HiddenReasonNextHopUnreachable
+		}
+	}
+
+	return route.HiddenReasonNone
+}
+
+// setIGPMetric sets the interior cost to the next hop of path used by the best path selection. Like the next hop
+// validation it is determined once on receipt, paths are not updated when the IGP metric changes later.
+func (f *fsmAddressFamily) setIGPMetric(path *route.Path) {
+	if f.fsm.isBMP || f.rib == nil || path.BGPPath.BGPPathA.NextHop == nil {
+		return
+	}
+
+	metric, err := f.rib.NextHopIGPMetric(path.BGPPath.BGPPathA.NextHop)
+	if err != nil {
+		return
+	}
+
+	path.BGPPath.IGPMetric = metric
+}
+
+// addPath adds a path received from the peer to the AdjRIBIn unless its next hop fails validation
+func (f *fsmAddressFamily) addPath(pfx *bnet.Prefix, path *route.Path) {
+	f.setIGPMetric(path)
+
+	if f.nextHopValidation == nil {
+		f.adjRIBIn.AddPath(pfx, path)
+		return
+	}
+
+	reason := f.validateNextHop(path)
+	if reason == route.HiddenReasonNone {
+		f.adjRIBIn.AddPath(pfx, path)
+		return
+	}
+
+	if f.nextHopValidation.Reject {
+		f.adjRIBIn.RemovePath(pfx, path)
+		return
+	}
+
+	path.HiddenReason = reason
+	f.adjRIBIn.AddPath(pfx, path)
+}
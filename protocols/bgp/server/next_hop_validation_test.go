@@ -0,0 +1,236 @@
+package server
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/adjRIBIn"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextHopValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		validation *NextHopValidation
+		nextHop    *bnet.IP
+		existing   bool
+		wantRoute  bool
+		wantHidden uint8
+	}{
+		{
+			name:      "validation disabled accepts zero next hop",
+			nextHop:   bnet.IPv4(0).Ptr(),
+			wantRoute: true,
+		},
+		{
+			name:       "zero next hop is rejected",
+			validation: &NextHopValidation{Reject: true},
+			nextHop:    bnet.IPv4(0).Ptr(),
+		},
+		{
+			name:       "zero next hop is hidden",
+			validation: &NextHopValidation{},
+			nextHop:    bnet.IPv4(0).Ptr(),
+			wantRoute:  true,
+			wantHidden: route.HiddenReasonInvalidNextHop,
+		},
+		{
+			name:       "zero next hop withdraws the previous path",
+			validation: &NextHopValidation{Reject: true},
+			nextHop:    bnet.IPv4(0).Ptr(),
+			existing:   true,
+		},
+		{
+			name:       "loopback next hop is rejected",
+			validation: &NextHopValidation{Reject: true},
+			nextHop:    bnet.IPv4FromOctets(127, 0, 0, 1).Ptr(),
+		},
+		{
+			name:       "our own address as next hop is hidden",
+			validation: &NextHopValidation{},
+			nextHop:    bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+			wantRoute:  true,
+			wantHidden: route.HiddenReasonInvalidNextHop,
+		},
+		{
+			name:       "unresolvable next hop is rejected",
+			validation: &NextHopValidation{Resolve: true, Reject: true},
+			nextHop:    bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+		},
+		{
+			name:       "unresolvable next hop is hidden",
+			validation: &NextHopValidation{Resolve: true},
+			nextHop:    bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+			wantRoute:  true,
+			wantHidden: route.HiddenReasonNextHopUnreachable,
+		},
+		{
+			name:       "unresolvable next hop is accepted without resolution",
+			validation: &NextHopValidation{Reject: true},
+			nextHop:    bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+			wantRoute:  true,
+		},
+		{
+			name:       "valid next hop is accepted",
+			validation: &NextHopValidation{Resolve: true, Reject: true},
+			nextHop:    bnet.IPv4FromOctets(10, 1, 1, 1).Ptr(),
+			wantRoute:  true,
+		},
+	}
+
+	for _, test := range tests {
+		rib := locRIB.New("inet.0")
+		rib.AddPath(bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), &route.Path{
+			Type: route.StaticPathType,
+			StaticPath: &route.StaticPath{
+				NextHop: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			},
+		})
+
+		ribIn := adjRIBIn.New(filter.NewAcceptAllFilterChain(), routingtable.NewContributingASNs(), routingtable.SessionAttrs{
+			RouterID: 100,
+		})
+		f := &fsmAddressFamily{
+			afi:  packet.AFIIPv4,
+			safi: packet.SAFIUnicast,
+			fsm: &FSM{
+				peer: &peer{
+					addr:      bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+					localAddr: bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+				},
+			},
+			rib:               rib,
+			adjRIBIn:          ribIn,
+			nextHopValidation: test.validation,
+		}
+
+		pfx := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+		if test.existing {
+			f.adjRIBIn.AddPath(pfx, &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{
+						NextHop: bnet.IPv4FromOctets(10, 1, 1, 1).Ptr(),
+						Source:  f.fsm.peer.addr,
+					},
+				},
+			})
+		}
+
+		f.updates(&packet.BGPUpdate{
+			PathAttributes: &packet.PathAttribute{
+				TypeCode: packet.NextHopAttr,
+				Value:    test.nextHop,
+			},
+			NLRI: &packet.NLRI{
+				Prefix: pfx,
+			},
+		}, false, 0)
+
+		r := ribIn.Get(pfx)
+		if !test.wantRoute {
+			if r != nil {
+				assert.Equal(t, 0, len(r.Paths()), test.name)
+			}
+			continue
+		}
+
+		if !assert.NotNil(t, r, test.name) || !assert.Equal(t, 1, len(r.Paths()), test.name) {
+			continue
+		}
+
+		assert.Equal(t, test.nextHop, r.Paths()[0].NextHop(), test.name)
+		assert.Equal(t, test.wantHidden, r.Paths()[0].HiddenReason, test.name)
+	}
+}
+
+func TestIGPMetric(t *testing.T) {
+	rib := locRIB.New("inet.0")
+	rib.AddPath(bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 1), 32).Ptr(), &route.Path{
+		Type: route.ISISPathType,
+		ISISPath: &route.ISISPath{
+			NextHop: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			Metric:  30,
+		},
+	})
+
+	ribIn := adjRIBIn.New(filter.NewAcceptAllFilterChain(), routingtable.NewContributingASNs(), routingtable.SessionAttrs{
+		RouterID: 100,
+	})
+	f := &fsmAddressFamily{
+		afi:  packet.AFIIPv4,
+		safi: packet.SAFIUnicast,
+		fsm: &FSM{
+			peer: &peer{
+				addr: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			},
+		},
+		rib:      rib,
+		adjRIBIn: ribIn,
+	}
+
+	tests := []struct {
+		name     string
+		nextHop  *bnet.IP
+		expected uint32
+	}{
+		{
+			name:     "next hop resolved via IS-IS",
+			nextHop:  bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+			expected: 30,
+		},
+		{
+			name:     "unresolvable next hop",
+			nextHop:  bnet.IPv4FromOctets(198, 51, 100, 1).Ptr(),
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		pfx := bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24).Ptr()
+		f.updates(&packet.BGPUpdate{
+			PathAttributes: &packet.PathAttribute{
+				TypeCode: packet.NextHopAttr,
+				Value:    test.nextHop,
+			},
+			NLRI: &packet.NLRI{
+				Prefix: pfx,
+			},
+		}, false, 0)
+
+		r := ribIn.Get(pfx)
+		if !assert.NotNil(t, r, test.name) || !assert.Equal(t, 1, len(r.Paths()), test.name) {
+			continue
+		}
+
+		assert.Equal(t, test.expected, r.Paths()[0].BGPPath.IGPMetric, test.name)
+	}
+}
+
+func TestIsMartianNextHop(t *testing.T) {
+	tests := []struct {
+		addr     bnet.IP
+		expected bool
+	}{
+		{addr: bnet.IPv4(0), expected: true},
+		{addr: bnet.IPv4FromOctets(0, 1, 2, 3), expected: true},
+		{addr: bnet.IPv4FromOctets(127, 0, 0, 1), expected: true},
+		{addr: bnet.IPv4FromOctets(224, 0, 0, 5), expected: true},
+		{addr: bnet.IPv4FromOctets(255, 255, 255, 255), expected: true},
+		{addr: bnet.IPv4FromOctets(192, 0, 2, 1)},
+		{addr: bnet.IPv6(0, 0), expected: true},
+		{addr: bnet.IPv6(0, 1), expected: true},
+		{addr: bnet.IPv6FromBlocks(0xff02, 0, 0, 0, 0, 0, 0, 1), expected: true},
+		{addr: bnet.IPv6FromBlocks(0xfe80, 0, 0, 0, 0, 0, 0, 1)},
+		{addr: bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1)},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, isMartianNextHop(&test.addr), test.addr.String())
+	}
+}
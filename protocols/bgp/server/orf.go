@@ -0,0 +1,204 @@
+package server
+
+import (
+	"fmt"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// ORFPrefixListEntry is an entry of the inbound prefix list pushed to a peer as Address Prefix ORF (RFC5292)
+type ORFPrefixListEntry struct {
+	Prefix *bnet.Prefix
+
+	// MinLen and MaxLen optionally extend the entry to more specifics of Prefix of at least MinLen and at most MaxLen
+	// bits. Zero leaves the respective bound at the length of Prefix and the length of an address.
+	MinLen uint8
+	MaxLen uint8
+
+	// Deny asks the peer not to send routes matching the entry
+	Deny bool
+}
+
+// ORFPrefixList is the inbound prefix list of an address family we push to a peer supporting Outbound Route Filtering
+// (RFC5291) so that it only sends us routes we are interested in. It does not replace the import filter chain as the
+// peer might not apply it.
+type ORFPrefixList []ORFPrefixListEntry
+
+// Equal compares two ORFPrefixLists
+func (l ORFPrefixList) Equal(x ORFPrefixList) bool {
+	if len(l) != len(x) {
+		return false
+	}
+
+	for i := range l {
+		if !l[i].Prefix.Equal(x[i].Prefix) || l[i].MinLen != x[i].MinLen || l[i].MaxLen != x[i].MaxLen || l[i].Deny != x[i].Deny {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *AddressFamilyConfig) orfPrefixList() ORFPrefixList {
+	if c == nil {
+		return nil
+	}
+
+	return c.ORFPrefixList
+}
+
+func (l ORFPrefixList) validate(afi uint16) error {
+	addrLen := uint8(32)
+	if afi == packet.AFIIPv6 {
+		addrLen = 128
+	}
+
+	for _, e := range l {
+		if e.Prefix == nil {
+			return fmt.Errorf("ORF prefix list entry without prefix")
+		}
+
+		if e.Prefix.Addr().IsIPv4() != (afi == packet.AFIIPv4) {
+			return fmt.Errorf("ORF prefix list entry %s does not match the address family", e.Prefix)
+		}
+
+		if e.MinLen != 0 && (e.MinLen <= e.Prefix.Len() || e.MinLen > addrLen) {
+			return fmt.Errorf("ORF prefix list entry %s: minimum length %d must be longer than the prefix and at most %d", e.Prefix, e.MinLen, addrLen)
+		}
+
+		if e.MaxLen != 0 && (e.MaxLen <= e.Prefix.Len() || e.MaxLen > addrLen || e.MaxLen < e.MinLen) {
+			return fmt.Errorf("ORF prefix list entry %s: maximum length %d must be longer than the prefix and minimum length and at most %d", e.Prefix, e.MaxLen, addrLen)
+		}
+	}
+
+	return nil
+}
+
+// addressPrefixORFEntries encodes the prefix list as Address Prefix ORF entries replacing all entries the peer holds
+// for us. Entries are sequenced in the order of the list.
+func (l ORFPrefixList) addressPrefixORFEntries() []packet.AddressPrefixORFEntry {
+	entries := make([]packet.AddressPrefixORFEntry, 0, len(l)+1)
+	entries = append(entries, packet.AddressPrefixORFEntry{
+		Action: packet.ORFActionRemoveAll,
+	})
+
+	for i, e := range l {
+		match := uint8(packet.ORFMatchPermit)
+		if e.Deny {
+			match = packet.ORFMatchDeny
+		}
+
+		entries = append(entries, packet.AddressPrefixORFEntry{
+			Action:   packet.ORFActionAdd,
+			Match:    match,
+			Sequence: uint32(i + 1),
+			MinLen:   e.MinLen,
+			MaxLen:   e.MaxLen,
+			Prefix:   e.Prefix,
+		})
+	}
+
+	return entries
+}
+
+// orfRouteRefreshMsgs distributes ORF entries over as many ROUTE-REFRESH messages of at most maxLen bytes as needed.
+// All but the last message defer the re-advertisement of routes by the peer until all entries arrived.
+func orfRouteRefreshMsgs(afi uint16, safi uint8, entries []packet.AddressPrefixORFEntry, maxLen int) []*packet.BGPRouteRefresh {
+	budget := maxLen - packet.RouteRefreshLen - 1 - packet.ORFEntriesHeaderLen
+
+	ret := make([]*packet.BGPRouteRefresh, 0, 1)
+	for len(entries) > 0 {
+		n := 0
+		for size := 0; n < len(entries) && size+entries[n].Len() <= budget; n++ {
+			size += entries[n].Len()
+		}
+
+		ret = append(ret, &packet.BGPRouteRefresh{
+			AFI:           afi,
+			Subtype:       packet.RouteRefreshNormal,
+			SAFI:          safi,
+			WhenToRefresh: packet.ORFDefer,
+			ORFs: []packet.ORFEntries{
+				{
+					Type:                 packet.AddressPrefixORFType,
+					AddressPrefixEntries: entries[:n],
+				},
+			},
+		})
+
+		entries = entries[n:]
+	}
+
+	if len(ret) > 0 {
+		ret[len(ret)-1].WhenToRefresh = packet.ORFImmediate
+	}
+
+	return ret
+}
+
+func orfCapability(c PeerConfig) (bool, packet.Capability) {
+	orfCap := make(packet.ORFCapability, 0)
+	for _, f := range []struct {
+		afi uint16
+		cfg *AddressFamilyConfig
+	}{
+		{afi: packet.AFIIPv4, cfg: c.IPv4},
+		{afi: packet.AFIIPv6, cfg: c.IPv6},
+	} {
+		if len(f.cfg.orfPrefixList()) == 0 {
+			continue
+		}
+
+		orfCap = append(orfCap, packet.ORFCapabilityTuple{
+			AFI:  f.afi,
+			SAFI: packet.SAFIUnicast,
+			ORFs: []packet.ORFTypeCapability{
+				{
+					Type:        packet.AddressPrefixORFType,
+					SendReceive: packet.ORFSend,
+				},
+			},
+		})
+	}
+
+	return len(orfCap) > 0, packet.Capability{
+		Code:  packet.OutboundRouteFilteringCapabilityCode,
+		Value: orfCap,
+	}
+}
+
+func (s *openSentState) processORFCapability(orfCap packet.ORFCapability) {
+	for _, t := range orfCap {
+		f := s.fsm.addressFamily(t.AFI, t.SAFI)
+		if f == nil || len(f.orfPrefixList) == 0 {
+			continue
+		}
+
+		for _, o := range t.ORFs {
+			if o.Type == packet.AddressPrefixORFType && o.SendReceive&packet.ORFReceive != 0 {
+				f.orfSend = true
+			}
+		}
+	}
+}
+
+// sendORFs pushes the inbound prefix list to the peer if it is able to receive it
+func (f *fsmAddressFamily) sendORFs() {
+	if !f.orfSend {
+		return
+	}
+
+	opt := &packet.EncodeOptions{
+		ExtendedMessage: f.fsm.extendedMessage,
+	}
+
+	for _, msg := range orfRouteRefreshMsgs(f.afi, f.safi, f.orfPrefixList.addressPrefixORFEntries(), opt.MaxMessageLen()) {
+		err := f.fsm.sendRouteRefreshMsg(msg)
+		if err != nil {
+			log.WithError(err).Errorf("Unable to send %s ORF to %s", packet.AFIName(f.afi), f.fsm.peer.addr.String())
+			return
+		}
+	}
+}
@@ -0,0 +1,278 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	btesting "github.com/bio-routing/bio-rd/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddressPrefixORFEntries(t *testing.T) {
+	l := ORFPrefixList{
+		{
+			Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(),
+			MaxLen: 24,
+		},
+		{
+			Prefix: bnet.NewPfx(bnet.IPv4FromOctets(192, 168, 0, 0), 16).Ptr(),
+			Deny:   true,
+		},
+	}
+
+	assert.Equal(t, []packet.AddressPrefixORFEntry{
+		{
+			Action: packet.ORFActionRemoveAll,
+		},
+		{
+			Action:   packet.ORFActionAdd,
+			Match:    packet.ORFMatchPermit,
+			Sequence: 1,
+			MaxLen:   24,
+			Prefix:   bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(),
+		},
+		{
+			Action:   packet.ORFActionAdd,
+			Match:    packet.ORFMatchDeny,
+			Sequence: 2,
+			Prefix:   bnet.NewPfx(bnet.IPv4FromOctets(192, 168, 0, 0), 16).Ptr(),
+		},
+	}, l.addressPrefixORFEntries())
+}
+
+func TestORFPrefixListValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		afi      uint16
+		entry    ORFPrefixListEntry
+		wantFail bool
+	}{
+		{
+			name: "IPv4 with length range",
+			afi:  packet.AFIIPv4,
+			entry: ORFPrefixListEntry{
+				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(),
+				MinLen: 16,
+				MaxLen: 24,
+			},
+		},
+		{
+			name: "IPv6 entry for IPv4",
+			afi:  packet.AFIIPv4,
+			entry: ORFPrefixListEntry{
+				Prefix: bnet.NewPfx(bnet.IPv6(0x20010db800000000, 0), 32).Ptr(),
+			},
+			wantFail: true,
+		},
+		{
+			name: "Minimum length not longer than prefix",
+			afi:  packet.AFIIPv4,
+			entry: ORFPrefixListEntry{
+				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(),
+				MinLen: 8,
+			},
+			wantFail: true,
+		},
+		{
+			name: "Maximum length shorter than minimum length",
+			afi:  packet.AFIIPv6,
+			entry: ORFPrefixListEntry{
+				Prefix: bnet.NewPfx(bnet.IPv6(0x20010db800000000, 0), 32).Ptr(),
+				MinLen: 64,
+				MaxLen: 48,
+			},
+			wantFail: true,
+		},
+		{
+			name: "Maximum length exceeding address length",
+			afi:  packet.AFIIPv4,
+			entry: ORFPrefixListEntry{
+				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(),
+				MaxLen: 33,
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ORFPrefixList{test.entry}.validate(test.afi)
+			assert.Equal(t, test.wantFail, err != nil)
+		})
+	}
+}
+
+func TestORFRouteRefreshMsgs(t *testing.T) {
+	l := make(ORFPrefixList, 0)
+	for i := 0; i < 1000; i++ {
+		l = append(l, ORFPrefixListEntry{
+			Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, uint8(i>>8), uint8(i), 0), 24).Ptr(),
+		})
+	}
+
+	entries := l.addressPrefixORFEntries()
+	msgs := orfRouteRefreshMsgs(packet.AFIIPv4, packet.SAFIUnicast, entries, packet.MaxLen)
+	if !assert.Len(t, msgs, 3) {
+		return
+	}
+
+	sent := make([]packet.AddressPrefixORFEntry, 0, len(entries))
+	for i, msg := range msgs {
+		expectedWhenToRefresh := uint8(packet.ORFDefer)
+		if i == len(msgs)-1 {
+			expectedWhenToRefresh = packet.ORFImmediate
+		}
+
+		assert.Equal(t, expectedWhenToRefresh, msg.WhenToRefresh)
+		assert.LessOrEqual(t, len(packet.SerializeRouteRefreshMsg(msg)), packet.MaxLen)
+		sent = append(sent, msg.ORFs[0].AddressPrefixEntries...)
+	}
+
+	assert.Equal(t, entries, sent)
+}
+
+func TestSendORFs(t *testing.T) {
+	newTestFSM := func() *FSM {
+		fsm := newFSM(&peer{
+			addr: bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+			ipv4: &peerAddressFamily{
+				orfPrefixList: ORFPrefixList{
+					{
+						Prefix: bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr(),
+					},
+				},
+			},
+			ipv6: &peerAddressFamily{},
+		})
+		fsm.con = btesting.NewMockConn()
+		return fsm
+	}
+
+	tests := []struct {
+		name        string
+		sendReceive uint8
+		expected    bool
+	}{
+		{
+			name:        "Peer receives ORFs",
+			sendReceive: packet.ORFReceive,
+			expected:    true,
+		},
+		{
+			name:        "Peer sends and receives ORFs",
+			sendReceive: packet.ORFSendReceive,
+			expected:    true,
+		},
+		{
+			name:        "Peer only sends ORFs",
+			sendReceive: packet.ORFSend,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fsm := newTestFSM()
+			s := newOpenSentState(fsm)
+			s.processCapability(packet.Capability{
+				Code: packet.OutboundRouteFilteringCapabilityCode,
+				Value: packet.ORFCapability{
+					{
+						AFI:  packet.AFIIPv4,
+						SAFI: packet.SAFIUnicast,
+						ORFs: []packet.ORFTypeCapability{
+							{
+								Type:        packet.AddressPrefixORFType,
+								SendReceive: test.sendReceive,
+							},
+						},
+					},
+					{
+						AFI:  packet.AFIIPv6,
+						SAFI: packet.SAFIUnicast,
+						ORFs: []packet.ORFTypeCapability{
+							{
+								Type:        packet.AddressPrefixORFType,
+								SendReceive: packet.ORFSendReceive,
+							},
+						},
+					},
+				},
+			})
+
+			assert.Equal(t, test.expected, fsm.ipv4Unicast.orfSend)
+			assert.False(t, fsm.ipv6Unicast.orfSend, "no ORF must be sent for an address family without prefix list")
+
+			fsm.ipv4Unicast.sendORFs()
+			fsm.ipv6Unicast.sendORFs()
+
+			buf := fsm.con.(*btesting.MockConn).Buf
+			if !test.expected {
+				assert.Zero(t, buf.Len())
+				return
+			}
+
+			msg, err := packet.Decode(bytes.NewBuffer(buf.Bytes()), &packet.DecodeOptions{})
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			assert.Equal(t, &packet.BGPRouteRefresh{
+				AFI:           packet.AFIIPv4,
+				Subtype:       packet.RouteRefreshNormal,
+				SAFI:          packet.SAFIUnicast,
+				WhenToRefresh: packet.ORFImmediate,
+				ORFs: []packet.ORFEntries{
+					{
+						Type: packet.AddressPrefixORFType,
+						AddressPrefixEntries: []packet.AddressPrefixORFEntry{
+							{
+								Action: packet.ORFActionRemoveAll,
+							},
+							{
+								Action:   packet.ORFActionAdd,
+								Sequence: 1,
+								Prefix:   bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Dedup(),
+							},
+						},
+					},
+				},
+			}, msg.Body)
+			assert.Equal(t, len(packet.SerializeRouteRefreshMsg(msg.Body.(*packet.BGPRouteRefresh))), buf.Len(), "only one message expected")
+		})
+	}
+}
+
+func TestORFCapability(t *testing.T) {
+	enabled, _ := orfCapability(PeerConfig{
+		IPv4: &AddressFamilyConfig{},
+	})
+	assert.False(t, enabled)
+
+	enabled, cap := orfCapability(PeerConfig{
+		IPv6: &AddressFamilyConfig{
+			ORFPrefixList: ORFPrefixList{
+				{
+					Prefix: bnet.NewPfx(bnet.IPv6(0x20010db800000000, 0), 32).Ptr(),
+				},
+			},
+		},
+	})
+	assert.True(t, enabled)
+	assert.Equal(t, packet.Capability{
+		Code: packet.OutboundRouteFilteringCapabilityCode,
+		Value: packet.ORFCapability{
+			{
+				AFI:  packet.AFIIPv6,
+				SAFI: packet.SAFIUnicast,
+				ORFs: []packet.ORFTypeCapability{
+					{
+						Type:        packet.AddressPrefixORFType,
+						SendReceive: packet.ORFSend,
+					},
+				},
+			},
+		},
+	}, cap)
+}
@@ -3,6 +3,7 @@ package server
 import (
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	bnet "github.com/bio-routing/bio-rd/net"
@@ -12,6 +13,7 @@ import (
 	"github.com/bio-routing/bio-rd/routingtable/filter"
 	"github.com/bio-routing/bio-rd/routingtable/locRIB"
 	"github.com/bio-routing/bio-rd/routingtable/vrf"
+	btime "github.com/bio-routing/bio-rd/util/time"
 )
 
 type peer struct {
@@ -20,22 +22,53 @@ type peer struct {
 	addr      *bnet.IP
 	localAddr *bnet.IP
 	ttl       uint8
+	minTTL    uint8
 	passive   bool
+	active    bool
 	peerASN   uint32
 	localASN  uint32
 
+	localASOverride *LocalASOverride
+
+	// dualASFallback indicates the real local ASN is to be presented to a dual-as peer. It is kept by the peer as a
+	// peer rejecting our OPEN message usually reconnects via a new passive FSM.
+	dualASFallback atomic.Bool
+
+	// adminDown is set while the peer is administratively shut down. No sessions are established then.
+	adminDown atomic.Bool
+
+	// prefixLimitRestarts tracks the automatic restarts after the peer exceeded a prefix limit
+	prefixLimitRestarts prefixLimitRestarts
+
+	// clock is the source of time for prefix limit restarts
+	clock btime.Clock
+
+	// createdTime is when the peer was configured. Used to decide if initial convergence has timed out.
+	createdTime time.Time
+
 	// guarded by fsmsMu
 	fsms   []*FSM
 	fsmsMu sync.Mutex
 
+	// policyVersion is incremented on every change of the import or export filter chain. The filter chains of the
+	// address families and policyVersion are guarded by policyMu.
+	policyVersion uint64
+	policyMu      sync.RWMutex
+
 	routerID                    uint32
 	reconnectInterval           time.Duration
 	keepaliveTime               time.Duration
 	holdTime                    time.Duration
 	optOpenParams               []packet.OptParam
+	optOpenParamsRealASN        []packet.OptParam
 	routeServerClient           bool
+	nextHopSelf                 bool
 	routeReflectorClient        bool
 	ipv4MultiProtocolAdvertised bool
+	extendedMessage             bool
+	routeRefresh                bool
+	enhancedRouteRefresh        bool
+	capabilityOverrides         CapabilityOverrides
 	clusterID                   uint32
 	peerRoleEnabled             bool
 	peerRoleStrictMode          bool
@@ -47,6 +80,17 @@ type peer struct {
 	ipv4 *peerAddressFamily
 	ipv6 *peerAddressFamily
 
+	linkState *LinkStateConfig
+
+	// gracefulRestart is nil unless we act as graceful restart receiving speaker for the peer
+	gracefulRestart *gracefulRestartHelper
+
+	inboundQueueSize uint32
+	mrai             time.Duration
+	advDelay         time.Duration
+	strip            communityStrip
+	logASLoops       bool
+
 	adjRIBInFactory adjRIBInFactoryI
 }
 
@@ -60,20 +104,59 @@ type PeerConfig struct {
 	LocalAddress               *bnet.IP
 	PeerAddress                *bnet.IP
 	TTL                        uint8
+	TTLSecurityHops            uint8
 	LocalAS                    uint32
+	LocalASOverride            *LocalASOverride
 	PeerAS                     uint32
 	Passive                    bool
+	Active                     bool
 	RouterID                   uint32
 	RouteServerClient          bool
+	NextHopSelf                bool
 	RouteReflectorClient       bool
 	RouteReflectorClusterID    uint32
 	AdvertiseIPv4MultiProtocol bool
+	ExtendedMessage            bool
+	RouteRefresh               bool
+	EnhancedRouteRefresh       bool
+	CapabilityOverrides        CapabilityOverrides
 	PeerRole                   uint8
 	PeerRoleStrictMode         bool
 	IPv4                       *AddressFamilyConfig
 	IPv6                       *AddressFamilyConfig
+	LinkState                  *LinkStateConfig
 	VRF                        *vrf.VRF
 	Description                string
+
+	// InboundQueueSize is the number of received messages waiting to be processed at which reading from
+	// the TCP connection is paused, leaving the peer to TCP flow control. Defaults to 256.
+	InboundQueueSize uint32
+
+	// UpdateSource is the local address outgoing TCP connections to the peer are bound to, e.g. a loopback
+	// address for iBGP sessions. The kernel chooses the source address if unset.
+	UpdateSource *bnet.IP
+
+	// MinRouteAdvertisementInterval is the minimum time between two advertisements of the same prefix (MRAI).
+	// Advertisements within the interval are collapsed into the latest one, withdrawals are sent immediately.
+	// Zero disables the MRAI.
+	MinRouteAdvertisementInterval time.Duration
+
+	// AdvertisementDelay holds back all advertisements to the peer for the given time after the session came up,
+	// e.g. to give the IGP time to converge and the next hops of our routes to become reachable. Advertisements
+	// are collected meanwhile and sent at once with the End-of-RIB marker. Zero disables the delay.
+	AdvertisementDelay time.Duration
+
+	// StripCommunities removes the COMMUNITIES attribute from all paths advertised to the peer
+	StripCommunities bool
+
+	// StripLargeCommunities removes the LARGE_COMMUNITIES attribute from all paths advertised to the peer
+	StripLargeCommunities bool
+
+	// LogASLoops logs samples of received paths hidden as one of our ASNs is part of their AS path
+	LogASLoops bool
+
+	// GracefulRestart optionally enables the graceful restart receiving speaker mode (RFC4724)
+	GracefulRestart *GracefulRestart
 }
 
 // AddressFamilyConfig represents all configuration parameters specific for an address family
@@ -82,6 +165,37 @@ type AddressFamilyConfig struct {
 	ExportFilterChain filter.Chain
 	AddPathSend       routingtable.ClientOptions
 	AddPathRecv       bool
+
+	// DefaultOriginate enables advertising a default route towards the peer regardless of the Loc-RIB
+	DefaultOriginate bool
+
+	// DefaultOriginateFilterChain is an optional route-map conditioning the default route on Loc-RIB routes it accepts and setting its attributes
+	DefaultOriginateFilterChain filter.Chain
+
+	// SuppressFIBFailures withholds routes from the peer that failed to be installed into the FIB
+	SuppressFIBFailures bool
+
+	// PrefixLimit optionally limits the number of prefixes accepted from the peer
+	PrefixLimit *PrefixLimit
+
+	// NextHopValidation optionally validates the next hop of paths received from the peer
+	NextHopValidation *NextHopValidation
+
+	// ORFPrefixList is optionally pushed to the peer as Address Prefix ORF if it supports receiving it
+	ORFPrefixList ORFPrefixList
+
+	// LabeledUnicast exchanges the routes of the address family as labeled unicast (RFC8277, SAFI 4) instead of unicast.
+	// ADD-PATH is not supported for labeled unicast.
+	LabeledUnicast bool
+}
+
+// safi gets the SAFI the address family is exchanged with
+func (c *AddressFamilyConfig) safi() uint8 {
+	if c.LabeledUnicast {
+		return packet.SAFILabeledUnicast
+	}
+
+	return packet.SAFIUnicast
 }
 
 // NeedsRestart determines if the peer needs a restart on cfg change
@@ -94,6 +208,10 @@ func (pc *PeerConfig) NeedsRestart(x *PeerConfig) bool {
 		return true
 	}
 
+	if !pc.LocalASOverride.Equal(x.LocalASOverride) {
+		return true
+	}
+
 	if pc.PeerAS != x.PeerAS {
 		return true
 	}
@@ -102,6 +220,10 @@ func (pc *PeerConfig) NeedsRestart(x *PeerConfig) bool {
 		return true
 	}
 
+	if pc.UpdateSource != x.UpdateSource {
+		return true
+	}
+
 	if pc.HoldTime != x.HoldTime {
 		return true
 	}
@@ -114,6 +236,50 @@ func (pc *PeerConfig) NeedsRestart(x *PeerConfig) bool {
 		return true
 	}
 
+	if pc.NextHopSelf != x.NextHopSelf {
+		return true
+	}
+
+	if pc.ExtendedMessage != x.ExtendedMessage {
+		return true
+	}
+
+	if pc.RouteRefresh != x.RouteRefresh || pc.EnhancedRouteRefresh != x.EnhancedRouteRefresh {
+		return true
+	}
+
+	if !pc.CapabilityOverrides.Equal(x.CapabilityOverrides) {
+		return true
+	}
+
+	if (pc.LinkState == nil) != (x.LinkState == nil) {
+		return true
+	}
+
+	if pc.InboundQueueSize != x.InboundQueueSize {
+		return true
+	}
+
+	if pc.MinRouteAdvertisementInterval != x.MinRouteAdvertisementInterval {
+		return true
+	}
+
+	if pc.AdvertisementDelay != x.AdvertisementDelay {
+		return true
+	}
+
+	if pc.StripCommunities != x.StripCommunities || pc.StripLargeCommunities != x.StripLargeCommunities {
+		return true
+	}
+
+	if pc.LogASLoops != x.LogASLoops {
+		return true
+	}
+
+	if !pc.GracefulRestart.Equal(x.GracefulRestart) {
+		return true
+	}
+
 	if peerRoleEnabled(pc.PeerRole) {
 		if pc.PeerRole != x.PeerRole {
 			return true
@@ -124,6 +290,18 @@ func (pc *PeerConfig) NeedsRestart(x *PeerConfig) bool {
 		}
 	}
 
+	if !pc.IPv4.prefixLimit().Equal(x.IPv4.prefixLimit()) || !pc.IPv6.prefixLimit().Equal(x.IPv6.prefixLimit()) {
+		return true
+	}
+
+	if !pc.IPv4.nextHopValidation().Equal(x.IPv4.nextHopValidation()) || !pc.IPv6.nextHopValidation().Equal(x.IPv6.nextHopValidation()) {
+		return true
+	}
+
+	if !pc.IPv4.orfPrefixList().Equal(x.IPv4.orfPrefixList()) || !pc.IPv6.orfPrefixList().Equal(x.IPv6.orfPrefixList()) {
+		return true
+	}
+
 	if pc.VRF != x.VRF {
 		return true
 	}
@@ -136,35 +314,111 @@ func (pc *PeerConfig) NeedsRestart(x *PeerConfig) bool {
 		return true
 	}
 
+	if pc.Active != x.Active {
+		return true
+	}
+
 	return false
 }
 
-// replaceImportFilterChain replaces a peers import filter chain
+// replaceImportFilterChain replaces a peers import filter chain. A change of the chain creates a new policy version
+// the paths received on all sessions are re-evaluated against.
 func (p *peer) replaceImportFilterChain(c filter.Chain) {
 	p.fsmsMu.Lock()
 	defer p.fsmsMu.Unlock()
 
+	version, changed := p.newPolicyVersion(func(f *peerAddressFamily) *filter.Chain {
+		return &f.importFilterChain
+	}, c)
+	if !changed {
+		return
+	}
+
 	for _, fsm := range p.fsms {
-		fsm.replaceImportFilterChain(c)
+		fsm.replaceImportFilterChain(c, version)
 	}
 }
 
-// replaceExportFilterChain replaces a peers import filter chain
+// replaceExportFilterChain replaces a peers export filter chain. A change of the chain creates a new policy version
+// the paths advertised on all sessions are re-evaluated against.
 func (p *peer) replaceExportFilterChain(c filter.Chain) {
 	p.fsmsMu.Lock()
 	defer p.fsmsMu.Unlock()
 
+	version, changed := p.newPolicyVersion(func(f *peerAddressFamily) *filter.Chain {
+		return &f.exportFilterChain
+	}, c)
+	if !changed {
+		return
+	}
+
 	for _, fsm := range p.fsms {
-		fsm.replaceExportFilterChain(c)
+		fsm.replaceExportFilterChain(c, version)
 	}
 }
 
-func (p *peer) dumpRIBIn(afi uint16, safi uint8) []*route.Route {
+// newPolicyVersion stores c as the filter chain selected by chain of all address families so that future sessions
+// use it as well. The policy version is incremented if any chain changed.
+func (p *peer) newPolicyVersion(chain func(*peerAddressFamily) *filter.Chain, c filter.Chain) (uint64, bool) {
+	p.policyMu.Lock()
+	defer p.policyMu.Unlock()
+
+	changed := false
+	for _, f := range []*peerAddressFamily{p.ipv4, p.ipv6} {
+		if f == nil || c.Equal(*chain(f)) {
+			continue
+		}
+
+		*chain(f) = c
+		changed = true
+	}
+
+	if changed {
+		p.policyVersion++
+	}
+
+	return p.policyVersion, changed
+}
+
+// getPolicyVersion gets the current policy version of the peer
+func (p *peer) getPolicyVersion() uint64 {
+	p.policyMu.RLock()
+	defer p.policyMu.RUnlock()
+
+	return p.policyVersion
+}
+
+// removeFSM removes a terminated FSM from the peer
+func (p *peer) removeFSM(fsm *FSM) {
+	p.fsmsMu.Lock()
+	defer p.fsmsMu.Unlock()
+
+	for i, f := range p.fsms {
+		if f == fsm {
+			p.fsms = append(p.fsms[:i], p.fsms[i+1:]...)
+			return
+		}
+	}
+}
+
+// soleFSM returns the FSM of the peer or nil if there is none or a connection collision is ongoing
+func (p *peer) soleFSM() *FSM {
+	p.fsmsMu.Lock()
+	defer p.fsmsMu.Unlock()
+
 	if len(p.fsms) != 1 {
 		return nil
 	}
 
-	fsm := p.fsms[0]
+	return p.fsms[0]
+}
+
+func (p *peer) dumpRIBIn(afi uint16, safi uint8) []*route.Route {
+	fsm := p.soleFSM()
+	if fsm == nil {
+		return nil
+	}
+
 	f := fsm.addressFamily(afi, safi)
 	if f == nil {
 		return nil
@@ -174,11 +428,11 @@ func (p *peer) dumpRIBIn(afi uint16, safi uint8) []*route.Route {
 }
 
 func (p *peer) dumpRIBOut(afi uint16, safi uint8) []*route.Route {
-	if len(p.fsms) != 1 {
+	fsm := p.soleFSM()
+	if fsm == nil {
 		return nil
 	}
 
-	fsm := p.fsms[0]
 	f := fsm.addressFamily(afi, safi)
 	if f == nil {
 		return nil
@@ -195,21 +449,44 @@ type peerAddressFamily struct {
 
 	addPathSend    routingtable.ClientOptions
 	addPathReceive bool
+
+	defaultOriginate            bool
+	defaultOriginateFilterChain filter.Chain
+
+	suppressFIBFailures bool
+
+	prefixLimit *PrefixLimit
+
+	nextHopValidation *NextHopValidation
+
+	orfPrefixList ORFPrefixList
+
+	labeledUnicast bool
 }
 
-func (p *peer) addressFamily(afi uint16, safi uint8) *peerAddressFamily {
-	if safi != packet.SAFIUnicast {
-		return nil
+// safi gets the SAFI the address family is exchanged with
+func (f *peerAddressFamily) safi() uint8 {
+	if f.labeledUnicast {
+		return packet.SAFILabeledUnicast
 	}
 
+	return packet.SAFIUnicast
+}
+
+func (p *peer) addressFamily(afi uint16, safi uint8) *peerAddressFamily {
+	var f *peerAddressFamily
 	switch afi {
 	case packet.AFIIPv4:
-		return p.ipv4
+		f = p.ipv4
 	case packet.AFIIPv6:
-		return p.ipv6
-	default:
+		f = p.ipv6
+	}
+
+	if f == nil || f.safi() != safi {
 		return nil
 	}
+
+	return f
 }
 
 func (p *peer) collisionHandling(callingFSM *FSM) bool {
@@ -226,6 +503,7 @@ func (p *peer) collisionHandling(callingFSM *FSM) bool {
 		isOpenConfirm := isOpenConfirmState(fsm.state)
 		fsm.stateMu.RUnlock()
 
+		// A new connection colliding with an established session is always closed (RFC4271 section 6.8)
 		if isEstablished {
 			return true
 		}
@@ -234,8 +512,10 @@ func (p *peer) collisionHandling(callingFSM *FSM) bool {
 			continue
 		}
 
-		if p.routerID < callingFSM.neighborID {
-			fsm.cease()
+		// The connection initiated by the BGP speaker with the higher BGP identifier survives (RFC4271 section 6.8)
+		keepLocallyInitiated := p.routerID > callingFSM.neighborID
+		if callingFSM.active == keepLocallyInitiated {
+			fsm.collisionCease()
 		} else {
 			return true
 		}
@@ -246,7 +526,7 @@ func (p *peer) collisionHandling(callingFSM *FSM) bool {
 
 func isOpenConfirmState(s state) bool {
 	switch s.(type) {
-	case openConfirmState:
+	case *openConfirmState:
 		return true
 	}
 
@@ -255,7 +535,7 @@ func isOpenConfirmState(s state) bool {
 
 func isEstablishedState(s state) bool {
 	switch s.(type) {
-	case establishedState:
+	case *establishedState:
 		return true
 	}
 
@@ -265,27 +545,81 @@ func isEstablishedState(s state) bool {
 // NewPeer creates a new peer with the given config. If an connection is established, the adjRIBIN of the peer is connected
 // to the given rib. To actually connect the peer, call Start() on the returned peer.
 func newPeer(c PeerConfig, server *bgpServer) (*peer, error) {
+	// A route server is transparent to its clients and must never put itself into the forwarding path
+	if c.RouteServerClient && c.NextHopSelf {
+		return nil, fmt.Errorf("next-hop-self must not be enabled for route server client %s", c.PeerAddress)
+	}
+
+	// Passive peers never initiate connections while active peers only initiate them
+	if c.Passive && c.Active {
+		return nil, fmt.Errorf("passive and active must not both be enabled for %s", c.PeerAddress)
+	}
+
+	err := c.CapabilityOverrides.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid capability overrides for %s: %w", c.PeerAddress, err)
+	}
+
+	err = c.validateTTLSecurity()
+	if err != nil {
+		return nil, fmt.Errorf("invalid TTL security for %s: %w", c.PeerAddress, err)
+	}
+
+	err = c.GracefulRestart.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid graceful restart config for %s: %w", c.PeerAddress, err)
+	}
+
+	err = c.IPv4.orfPrefixList().validate(packet.AFIIPv4)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IPv4 ORF prefix list for %s: %w", c.PeerAddress, err)
+	}
+
+	err = c.IPv6.orfPrefixList().validate(packet.AFIIPv6)
+	if err != nil {
+		return nil, fmt.Errorf("invalid IPv6 ORF prefix list for %s: %w", c.PeerAddress, err)
+	}
+
 	p := &peer{
 		server:               server,
 		config:               &c,
 		addr:                 c.PeerAddress,
 		ttl:                  c.TTL,
 		passive:              c.Passive,
+		active:               c.Active,
 		peerASN:              c.PeerAS,
 		localASN:             c.LocalAS,
+		localASOverride:      c.LocalASOverride,
 		fsms:                 make([]*FSM, 0),
 		reconnectInterval:    c.ReconnectInterval,
 		keepaliveTime:        c.KeepAlive,
 		holdTime:             c.HoldTime,
-		optOpenParams:        make([]packet.OptParam, 0),
 		routeServerClient:    c.RouteServerClient,
+		nextHopSelf:          c.NextHopSelf,
 		routeReflectorClient: c.RouteReflectorClient,
 		clusterID:            c.RouteReflectorClusterID,
+		extendedMessage:      c.ExtendedMessage,
+		routeRefresh:         c.RouteRefresh || c.EnhancedRouteRefresh,
+		enhancedRouteRefresh: c.EnhancedRouteRefresh,
+		capabilityOverrides:  c.CapabilityOverrides,
 		peerRoleEnabled:      peerRoleEnabled(c.PeerRole),
 		peerRoleStrictMode:   c.PeerRoleStrictMode,
 		peerRoleLocal:        translatePeerRole(c.PeerRole),
 		vrf:                  c.VRF,
+		linkState:            c.LinkState,
+		inboundQueueSize:     c.InboundQueueSize,
+		mrai:                 c.MinRouteAdvertisementInterval,
+		advDelay:             c.AdvertisementDelay,
+		strip:                communityStrip{communities: c.StripCommunities, largeCommunities: c.StripLargeCommunities},
+		logASLoops:           c.LogASLoops,
 		adjRIBInFactory:      adjRIBInFactory{},
+		createdTime:          time.Now(),
+		clock:                btime.NewBIOClock(),
+	}
+	p.applyTTLSecurity(c.TTLSecurityHops)
+
+	if c.GracefulRestart != nil {
+		p.gracefulRestart = newGracefulRestartHelper(p, *c.GracefulRestart)
 	}
 
 	if c.IPv4 != nil {
@@ -295,6 +629,14 @@ func newPeer(c PeerConfig, server *bgpServer) (*peer, error) {
 			exportFilterChain: filterOrDefault(c.IPv4.ExportFilterChain),
 			addPathReceive:    c.IPv4.AddPathRecv,
 			addPathSend:       c.IPv4.AddPathSend,
+
+			defaultOriginate:            c.IPv4.DefaultOriginate,
+			defaultOriginateFilterChain: c.IPv4.DefaultOriginateFilterChain,
+			suppressFIBFailures:         c.IPv4.SuppressFIBFailures,
+			prefixLimit:                 c.IPv4.PrefixLimit,
+			nextHopValidation:           c.IPv4.NextHopValidation,
+			orfPrefixList:               c.IPv4.ORFPrefixList,
+			labeledUnicast:              c.IPv4.LabeledUnicast,
 		}
 
 		if p.ipv4.rib == nil {
@@ -307,17 +649,6 @@ func newPeer(c PeerConfig, server *bgpServer) (*peer, error) {
 		p.clusterID = c.RouterID
 	}
 
-	caps := make(packet.Capabilities, 0)
-
-	caps = append(caps, addPathCapabilities(c)...)
-
-	caps = append(caps, asn4Capability(c))
-
-	if c.IPv4 != nil && c.AdvertiseIPv4MultiProtocol {
-		caps = append(caps, multiProtocolCapability(packet.AFIIPv4))
-		p.ipv4MultiProtocolAdvertised = true
-	}
-
 	if c.IPv6 != nil {
 		p.ipv6 = &peerAddressFamily{
 			rib:               c.VRF.IPv6UnicastRIB(),
@@ -325,23 +656,33 @@ func newPeer(c PeerConfig, server *bgpServer) (*peer, error) {
 			exportFilterChain: filterOrDefault(c.IPv6.ExportFilterChain),
 			addPathReceive:    c.IPv6.AddPathRecv,
 			addPathSend:       c.IPv6.AddPathSend,
+
+			defaultOriginate:            c.IPv6.DefaultOriginate,
+			defaultOriginateFilterChain: c.IPv6.DefaultOriginateFilterChain,
+			suppressFIBFailures:         c.IPv6.SuppressFIBFailures,
+			prefixLimit:                 c.IPv6.PrefixLimit,
+			nextHopValidation:           c.IPv6.NextHopValidation,
+			orfPrefixList:               c.IPv6.ORFPrefixList,
+			labeledUnicast:              c.IPv6.LabeledUnicast,
 		}
-		caps = append(caps, multiProtocolCapability(packet.AFIIPv6))
 
 		if p.ipv6.rib == nil {
 			return nil, fmt.Errorf("no RIB for IPv6 unicast configured")
 		}
 	}
 
-	// Activate Peer Role capability for eBGP neighbors if configured
-	if p.localASN != p.peerASN && peerRoleEnabled(c.PeerRole) {
-		caps = append(caps, peerRoleCapability(c))
-	}
+	// Labeled unicast can only be exchanged using multi protocol extensions
+	p.ipv4MultiProtocolAdvertised = c.IPv4 != nil && (c.AdvertiseIPv4MultiProtocol || c.IPv4.LabeledUnicast)
+	p.applyCapabilityFeatures()
 
-	p.optOpenParams = append(p.optOpenParams, packet.OptParam{
-		Type:  packet.CapabilitiesParamType,
-		Value: caps,
-	})
+	if c.LocalASOverride == nil {
+		p.optOpenParams = p.openParams(c, c.LocalAS)
+	} else {
+		p.optOpenParams = p.openParams(c, c.LocalASOverride.ASN)
+		if c.LocalASOverride.DualAS {
+			p.optOpenParamsRealASN = p.openParams(c, c.LocalAS)
+		}
+	}
 
 	if !p.passive {
 		p.fsms = append(p.fsms, NewActiveFSM(p))
@@ -350,21 +691,98 @@ func newPeer(c PeerConfig, server *bgpServer) (*peer, error) {
 	return p, nil
 }
 
-func asn4Capability(c PeerConfig) packet.Capability {
+// openParams builds the optional parameters of our OPEN message presenting localASN to the peer
+func (p *peer) openParams(c PeerConfig, localASN uint32) []packet.OptParam {
+	caps := make(packet.Capabilities, 0)
+
+	caps = append(caps, addPathCapabilities(c)...)
+
+	caps = append(caps, asn4Capability(localASN))
+
+	if p.ipv4MultiProtocolAdvertised {
+		caps = append(caps, multiProtocolCapability(packet.AFIIPv4, c.IPv4.safi()))
+	}
+
+	if c.IPv6 != nil {
+		caps = append(caps, multiProtocolCapability(packet.AFIIPv6, c.IPv6.safi()))
+	}
+
+	if c.LinkState != nil {
+		caps = append(caps, multiProtocolCapability(packet.AFILinkState, packet.SAFILinkState))
+	}
+
+	if c.ExtendedMessage {
+		caps = append(caps, extendedMessageCapability())
+	}
+
+	if enabled, cap := orfCapability(c); enabled {
+		caps = append(caps, cap)
+	}
+
+	// Enhanced route refresh is an extension of route refresh, so it implies the route refresh capability (RFC7313)
+	if p.routeRefresh {
+		caps = append(caps, routeRefreshCapability())
+	}
+
+	if p.enhancedRouteRefresh {
+		caps = append(caps, enhancedRouteRefreshCapability())
+	}
+
+	if c.GracefulRestart != nil {
+		caps = append(caps, gracefulRestartCapability(c.GracefulRestart))
+	}
+
+	// Activate Peer Role capability for eBGP neighbors if configured
+	if p.localASN != p.peerASN && peerRoleEnabled(c.PeerRole) {
+		caps = append(caps, peerRoleCapability(c))
+	}
+
+	caps = p.applyCapabilityOverrides(caps, localASN)
+
+	return []packet.OptParam{
+		{
+			Type:  packet.CapabilitiesParamType,
+			Value: caps,
+		},
+	}
+}
+
+func asn4Capability(localASN uint32) packet.Capability {
 	return packet.Capability{
 		Code: packet.ASN4CapabilityCode,
 		Value: packet.ASN4Capability{
-			ASN4: c.LocalAS,
+			ASN4: localASN,
 		},
 	}
 }
 
-func multiProtocolCapability(afi uint16) packet.Capability {
+func extendedMessageCapability() packet.Capability {
+	return packet.Capability{
+		Code:  packet.ExtendedMessageCapabilityCode,
+		Value: packet.ExtendedMessageCapability{},
+	}
+}
+
+func routeRefreshCapability() packet.Capability {
+	return packet.Capability{
+		Code:  packet.RouteRefreshCapabilityCode,
+		Value: packet.RouteRefreshCapability{},
+	}
+}
+
+func enhancedRouteRefreshCapability() packet.Capability {
+	return packet.Capability{
+		Code:  packet.EnhancedRouteRefreshCapabilityCode,
+		Value: packet.EnhancedRouteRefreshCapability{},
+	}
+}
+
+func multiProtocolCapability(afi uint16, safi uint8) packet.Capability {
 	return packet.Capability{
 		Code: packet.MultiProtocolCapabilityCode,
 		Value: packet.MultiProtocolCapability{
 			AFI:  afi,
-			SAFI: packet.SAFIUnicast,
+			SAFI: safi,
 		},
 	}
 }
@@ -386,7 +804,7 @@ func addPathCapabilities(c PeerConfig) []packet.Capability {
 }
 
 func addPathCapabilityForFamily(f *AddressFamilyConfig, afi uint16, safi uint8) (enabled bool, cap packet.Capability) {
-	if f == nil {
+	if f == nil || f.LabeledUnicast {
 		return false, packet.Capability{}
 	}
 
@@ -450,6 +868,39 @@ func (p *peer) stop() {
 	}
 }
 
+// stopWithNotification stops all BGP sessions of the peer sending n
+func (p *peer) stopWithNotification(n *packet.BGPNotification) {
+	p.fsmsMu.Lock()
+	defer p.fsmsMu.Unlock()
+
+	for _, fsm := range p.fsms {
+		fsm.manualStopWithNotification(n)
+	}
+}
+
+// inboundQueueCap returns the number of received messages buffered before reading from the connection pauses
+func (p *peer) inboundQueueCap() int {
+	if p.inboundQueueSize == 0 {
+		return defaultInboundQueueSize
+	}
+
+	return int(p.inboundQueueSize)
+}
+
 func (p *peer) isEBGP() bool {
 	return p.localASN != p.peerASN
 }
+
+// connectionTTL returns the TTL of packets sent to the peer and whether the peer has to be directly connected.
+// Unless a TTL is configured (ebgp-multihop), eBGP peers are expected to be directly connected.
+func (p *peer) connectionTTL() (ttl uint8, dontRoute bool) {
+	if p.ttl != 0 {
+		return p.ttl, false
+	}
+
+	if p.isEBGP() {
+		return 1, true
+	}
+
+	return 0, false
+}
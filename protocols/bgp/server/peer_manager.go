@@ -2,50 +2,65 @@ package server
 
 import (
 	"sync"
+	"sync/atomic"
 
 	bnet "github.com/bio-routing/bio-rd/net"
 )
 
+// peerManager keeps track of all peers. Peers are stored in an immutable map that is replaced on
+// every change, so lookups and dumps never wait for writers or each other.
 type peerManager struct {
-	peers   map[bnet.IP]*peer
-	peersMu sync.RWMutex
+	peers   atomic.Value // map[bnet.IP]*peer
+	peersMu sync.Mutex   // serializes writers
 }
 
 func newPeerManager() *peerManager {
-	return &peerManager{
-		peers: make(map[bnet.IP]*peer),
-	}
+	m := &peerManager{}
+	m.peers.Store(make(map[bnet.IP]*peer))
+	return m
 }
 
-func (m *peerManager) add(p *peer) {
+func (m *peerManager) snapshot() map[bnet.IP]*peer {
+	return m.peers.Load().(map[bnet.IP]*peer)
+}
+
+// update replaces the peer map by a modified copy of the current one
+func (m *peerManager) update(modify func(peers map[bnet.IP]*peer)) {
 	m.peersMu.Lock()
 	defer m.peersMu.Unlock()
 
-	m.peers[*p.GetAddr()] = p
+	current := m.snapshot()
+	peers := make(map[bnet.IP]*peer, len(current)+1)
+	for addr, p := range current {
+		peers[addr] = p
+	}
+
+	modify(peers)
+	m.peers.Store(peers)
 }
 
-func (m *peerManager) remove(neighborIP *bnet.IP) {
-	m.peersMu.Lock()
-	defer m.peersMu.Unlock()
+func (m *peerManager) add(p *peer) {
+	m.update(func(peers map[bnet.IP]*peer) {
+		peers[*p.GetAddr()] = p
+	})
+}
 
-	delete(m.peers, *neighborIP)
+func (m *peerManager) remove(neighborIP *bnet.IP) {
+	m.update(func(peers map[bnet.IP]*peer) {
+		delete(peers, *neighborIP)
+	})
 }
 
 func (m *peerManager) get(neighborIP *bnet.IP) *peer {
-	m.peersMu.RLock()
-	defer m.peersMu.RUnlock()
-
-	p := m.peers[*neighborIP]
-	return p
+	return m.snapshot()[*neighborIP]
 }
 
 func (m *peerManager) list() []*peer {
-	m.peersMu.RLock()
-	defer m.peersMu.RUnlock()
+	peers := m.snapshot()
 
-	res := make([]*peer, len(m.peers))
+	res := make([]*peer, len(peers))
 	i := 0
-	for _, p := range m.peers {
+	for _, p := range peers {
 		res[i] = p
 		i++
 	}
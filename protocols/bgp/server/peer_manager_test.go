@@ -1,6 +1,8 @@
 package server
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,7 +19,7 @@ func TestAdd(t *testing.T) {
 	m := newPeerManager()
 	m.add(p)
 
-	found := m.peers[*ip]
+	found := m.snapshot()[*ip]
 	assert.Exactly(t, p, found)
 }
 
@@ -28,11 +30,11 @@ func TestRemove(t *testing.T) {
 	}
 
 	m := newPeerManager()
-	m.peers[*ip] = p
+	m.add(p)
 
 	m.remove(ip)
 
-	assert.Empty(t, m.peers)
+	assert.Empty(t, m.snapshot())
 }
 
 func TestGet(t *testing.T) {
@@ -42,7 +44,7 @@ func TestGet(t *testing.T) {
 	}
 
 	m := newPeerManager()
-	m.peers[*ip] = p
+	m.add(p)
 
 	found := m.get(ip)
 	assert.Exactly(t, p, found)
@@ -57,10 +59,102 @@ func TestList(t *testing.T) {
 	}
 
 	m := newPeerManager()
-	m.peers[*p1.GetAddr()] = p1
-	m.peers[*p2.GetAddr()] = p2
+	m.add(p1)
+	m.add(p2)
 
 	list := m.list()
 	assert.Contains(t, list, p1)
 	assert.Contains(t, list, p2)
 }
+
+func TestListSnapshot(t *testing.T) {
+	p1 := &peer{
+		addr: bnet.IPv4FromOctets(192, 168, 0, 1).Ptr(),
+	}
+
+	m := newPeerManager()
+	m.add(p1)
+
+	snapshot := m.snapshot()
+	m.add(&peer{
+		addr: bnet.IPv4FromOctets(192, 168, 0, 2).Ptr(),
+	})
+	m.remove(p1.addr)
+
+	assert.Equal(t, 1, len(snapshot), "snapshot must not change")
+	assert.Exactly(t, p1, snapshot[*p1.addr])
+	assert.Equal(t, 1, len(m.list()))
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	m := newPeerManager()
+	peers := testPeers(256)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(offset int) {
+			defer wg.Done()
+
+			for j := offset; j < len(peers); j += 8 {
+				m.add(peers[j])
+				assert.Exactly(t, peers[j], m.get(peers[j].addr))
+				m.list()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, len(peers), len(m.list()))
+}
+
+func testPeers(n int) []*peer {
+	res := make([]*peer, n)
+	for i := range res {
+		res[i] = &peer{
+			addr: bnet.IPv4FromOctets(10, 0, uint8(i>>8), uint8(i)).Dedup(),
+		}
+	}
+
+	return res
+}
+
+func BenchmarkPeerManagerGet(b *testing.B) {
+	for _, n := range []int{16, 256, 1024} {
+		m := newPeerManager()
+		peers := testPeers(n)
+		for _, p := range peers {
+			m.add(p)
+		}
+
+		b.Run(fmt.Sprintf("Peers-%d", n), func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					m.get(peers[i%n].addr)
+					i++
+				}
+			})
+		})
+
+		b.Run(fmt.Sprintf("PeersWithList-%d", n), func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					if i%64 == 0 {
+						m.list()
+					}
+
+					m.get(peers[i%n].addr)
+					i++
+				}
+			})
+		})
+	}
+}
+
+func testPeerManager(peers map[bnet.IP]*peer) *peerManager {
+	m := newPeerManager()
+	m.peers.Store(peers)
+	return m
+}
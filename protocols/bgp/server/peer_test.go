@@ -0,0 +1,397 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/vrf"
+)
+
+func TestNewPeerRouteServerClientNextHopSelf(t *testing.T) {
+	tests := []struct {
+		name              string
+		routeServerClient bool
+		nextHopSelf       bool
+		wantFail          bool
+	}{
+		{
+			name:              "route server client",
+			routeServerClient: true,
+		},
+		{
+			name:        "next-hop-self",
+			nextHopSelf: true,
+		},
+		{
+			name:              "route server client with next-hop-self",
+			routeServerClient: true,
+			nextHopSelf:       true,
+			wantFail:          true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newPeer(PeerConfig{
+				PeerAddress:       bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+				LocalAS:           65000,
+				PeerAS:            65100,
+				Passive:           true,
+				RouteServerClient: test.routeServerClient,
+				NextHopSelf:       test.nextHopSelf,
+			}, nil)
+
+			assert.Equal(t, test.wantFail, err != nil)
+		})
+	}
+}
+
+// openCapabilityCodes returns the codes of all capabilities in the serialized OPEN message of fsm
+func openCapabilityCodes(t *testing.T, fsm *FSM) []uint8 {
+	msg, err := packet.Decode(bytes.NewBuffer(packet.SerializeOpenMsg(fsm.openMessage())), &packet.DecodeOptions{})
+	if !assert.NoError(t, err) {
+		return nil
+	}
+
+	codes := make([]uint8, 0)
+	for _, param := range msg.Body.(*packet.BGPOpen).OptParams {
+		for _, cap := range param.Value.(packet.Capabilities) {
+			codes = append(codes, cap.Code)
+		}
+	}
+
+	return codes
+}
+
+func TestOpenCapabilityOverrides(t *testing.T) {
+	tests := []struct {
+		name         string
+		cfg          PeerConfig
+		expected     []uint8
+		routeRefresh bool
+		wantFail     bool
+	}{
+		{
+			name: "no overrides",
+			cfg: PeerConfig{
+				EnhancedRouteRefresh: true,
+			},
+			expected: []uint8{
+				packet.ASN4CapabilityCode,
+				packet.RouteRefreshCapabilityCode,
+				packet.EnhancedRouteRefreshCapabilityCode,
+			},
+			routeRefresh: true,
+		},
+		{
+			name: "suppress route refresh",
+			cfg: PeerConfig{
+				EnhancedRouteRefresh: true,
+				CapabilityOverrides: CapabilityOverrides{
+					packet.RouteRefreshCapabilityCode: false,
+				},
+			},
+			expected: []uint8{
+				packet.ASN4CapabilityCode,
+			},
+		},
+		{
+			name: "suppress 4 octet ASN",
+			cfg: PeerConfig{
+				CapabilityOverrides: CapabilityOverrides{
+					packet.ASN4CapabilityCode: false,
+				},
+			},
+			expected: []uint8{},
+		},
+		{
+			name: "force route refresh and graceful restart",
+			cfg: PeerConfig{
+				CapabilityOverrides: CapabilityOverrides{
+					packet.RouteRefreshCapabilityCode:    true,
+					packet.GracefulRestartCapabilityCode: true,
+					packet.ASN4CapabilityCode:            true,
+				},
+			},
+			expected: []uint8{
+				packet.ASN4CapabilityCode,
+				packet.RouteRefreshCapabilityCode,
+				packet.GracefulRestartCapabilityCode,
+			},
+			routeRefresh: true,
+		},
+		{
+			name: "force add path without address families",
+			cfg: PeerConfig{
+				CapabilityOverrides: CapabilityOverrides{
+					packet.AddPathCapabilityCode: true,
+				},
+			},
+			expected: []uint8{
+				packet.ASN4CapabilityCode,
+				packet.AddPathCapabilityCode,
+			},
+		},
+		{
+			name: "capability can not be overridden",
+			cfg: PeerConfig{
+				CapabilityOverrides: CapabilityOverrides{
+					packet.MultiProtocolCapabilityCode: false,
+				},
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.cfg.PeerAddress = bnet.IPv4FromOctets(192, 0, 2, 1).Ptr()
+			test.cfg.LocalAS = 65000
+			test.cfg.PeerAS = 65100
+			test.cfg.Passive = true
+
+			p, err := newPeer(test.cfg, nil)
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+
+			p.routerID = bnet.IPv4FromOctets(192, 0, 2, 2).Ptr().ToUint32()
+			assert.Equal(t, test.expected, openCapabilityCodes(t, newFSM(p)))
+			assert.Equal(t, test.routeRefresh, p.routeRefresh)
+		})
+	}
+}
+
+func TestNewPeerConnectionMode(t *testing.T) {
+	tests := []struct {
+		name         string
+		passive      bool
+		active       bool
+		expectedFSMs int
+		wantFail     bool
+	}{
+		{
+			name:         "default",
+			expectedFSMs: 1,
+		},
+		{
+			name:         "passive",
+			passive:      true,
+			expectedFSMs: 0,
+		},
+		{
+			name:         "active",
+			active:       true,
+			expectedFSMs: 1,
+		},
+		{
+			name:     "passive and active",
+			passive:  true,
+			active:   true,
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := newPeer(PeerConfig{
+				PeerAddress: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+				LocalAS:     65000,
+				PeerAS:      65100,
+				Passive:     test.passive,
+				Active:      test.active,
+			}, nil)
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, p.fsms, test.expectedFSMs)
+		})
+	}
+}
+
+func TestPassivePeerDoesNotDial(t *testing.T) {
+	p, err := newPeer(PeerConfig{
+		PeerAddress: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+		LocalAS:     65000,
+		PeerAS:      65100,
+		Passive:     true,
+	}, nil)
+	assert.NoError(t, err)
+
+	fsm := newFSM(p)
+	next, _ := newIdleState(fsm).automaticStart()
+	assert.IsType(t, &activeState{}, next)
+
+	next, _ = newActiveState(fsm).connectRetryTimerExpired()
+	assert.IsType(t, &activeState{}, next)
+
+	select {
+	case <-fsm.initiateCon:
+		t.Errorf("passive peer initiated a connection")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestCollisionHandling(t *testing.T) {
+	tests := []struct {
+		name          string
+		localID       uint32
+		remoteID      uint32
+		callingActive bool
+		expectedClose bool
+	}{
+		{
+			name:          "higher local ID, calling FSM initiated locally",
+			localID:       200,
+			remoteID:      100,
+			callingActive: true,
+			expectedClose: false,
+		},
+		{
+			name:          "higher local ID, calling FSM initiated by peer",
+			localID:       200,
+			remoteID:      100,
+			callingActive: false,
+			expectedClose: true,
+		},
+		{
+			name:          "higher remote ID, calling FSM initiated locally",
+			localID:       100,
+			remoteID:      200,
+			callingActive: true,
+			expectedClose: true,
+		},
+		{
+			name:          "higher remote ID, calling FSM initiated by peer",
+			localID:       100,
+			remoteID:      200,
+			callingActive: false,
+			expectedClose: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &peer{
+				routerID: test.localID,
+			}
+
+			calling := newFSM(p)
+			calling.active = test.callingActive
+			calling.neighborID = test.remoteID
+
+			other := newFSM(p)
+			other.active = !test.callingActive
+			other.neighborID = test.remoteID
+			other.eventCh = make(chan int, 1)
+			other.state = newOpenConfirmState(other)
+
+			p.fsms = []*FSM{other, calling}
+
+			assert.Equal(t, test.expectedClose, p.collisionHandling(calling))
+
+			select {
+			case e := <-other.eventCh:
+				assert.False(t, test.expectedClose, "surviving connection must not be ceased")
+				assert.Equal(t, ConnectionCollision, e)
+			default:
+				assert.True(t, test.expectedClose, "colliding connection has not been ceased")
+			}
+		})
+	}
+}
+
+func TestReplaceFilterChainPolicyVersion(t *testing.T) {
+	v, _ := vrf.New("policy_version", 170)
+	defer vrf.GetGlobalRegistry().UnregisterVRF(v)
+
+	p, err := newPeer(PeerConfig{
+		PeerAddress: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+		LocalAS:     65000,
+		PeerAS:      65100,
+		Passive:     true,
+		VRF:         v,
+		IPv4: &AddressFamilyConfig{
+			ImportFilterChain: filter.NewAcceptAllFilterChain(),
+			ExportFilterChain: filter.NewAcceptAllFilterChain(),
+		},
+	}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	established := newFSM(p)
+	p.fsms = []*FSM{established}
+	assert.Equal(t, uint64(0), established.ipv4Unicast.policyVersion)
+
+	p.replaceImportFilterChain(filter.NewAcceptAllFilterChain())
+	assert.Equal(t, uint64(0), p.getPolicyVersion(), "unchanged chain must not create a new version")
+
+	p.replaceImportFilterChain(filter.NewDrainFilterChain())
+	assert.Equal(t, uint64(1), p.getPolicyVersion())
+	assert.Equal(t, uint64(1), established.ipv4Unicast.policyVersion)
+	assert.True(t, filter.NewDrainFilterChain().Equal(established.ipv4Unicast.importFilterChain))
+
+	p.replaceExportFilterChain(filter.NewDrainFilterChain())
+	assert.Equal(t, uint64(2), p.getPolicyVersion())
+	assert.Equal(t, uint64(2), established.ipv4Unicast.policyVersion)
+
+	// Sessions established after the change use the new chains
+	reconnected := newFSM(p)
+	assert.Equal(t, uint64(2), reconnected.ipv4Unicast.policyVersion)
+	assert.True(t, filter.NewDrainFilterChain().Equal(reconnected.ipv4Unicast.importFilterChain))
+	assert.True(t, filter.NewDrainFilterChain().Equal(reconnected.ipv4Unicast.exportFilterChain))
+}
+
+func TestNewPeerLabeledUnicast(t *testing.T) {
+	v, _ := vrf.New("labeled_unicast", 170)
+	p, err := newPeer(PeerConfig{
+		PeerAddress: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+		LocalAS:     65000,
+		PeerAS:      65100,
+		Passive:     true,
+		VRF:         v,
+		IPv4: &AddressFamilyConfig{
+			AddPathRecv:    true,
+			LabeledUnicast: true,
+		},
+	}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.NotNil(t, p.addressFamily(packet.AFIIPv4, packet.SAFILabeledUnicast))
+	assert.Nil(t, p.addressFamily(packet.AFIIPv4, packet.SAFIUnicast))
+	assert.True(t, p.ipv4MultiProtocolAdvertised, "labeled unicast requires multi protocol extensions")
+
+	caps := make(packet.Capabilities, 0)
+	for _, param := range p.optOpenParams {
+		caps = append(caps, param.Value.(packet.Capabilities)...)
+	}
+
+	assert.Contains(t, caps, multiProtocolCapability(packet.AFIIPv4, packet.SAFILabeledUnicast))
+	for _, cap := range caps {
+		assert.NotEqual(t, uint8(packet.AddPathCapabilityCode), cap.Code, "ADD-PATH is not supported for labeled unicast")
+	}
+
+	fsm := newFSM(p)
+	assert.Equal(t, uint8(packet.SAFILabeledUnicast), fsm.ipv4Unicast.safi)
+
+	s := newOpenSentState(fsm)
+	s.processMultiProtocolCapability(packet.MultiProtocolCapability{AFI: packet.AFIIPv4, SAFI: packet.SAFIUnicast})
+	assert.False(t, fsm.ipv4Unicast.multiProtocol)
+	s.processMultiProtocolCapability(packet.MultiProtocolCapability{AFI: packet.AFIIPv4, SAFI: packet.SAFILabeledUnicast})
+	assert.True(t, fsm.ipv4Unicast.multiProtocol)
+}
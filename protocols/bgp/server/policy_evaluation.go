@@ -0,0 +1,141 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/adjRIBIn"
+	"github.com/bio-routing/bio-rd/routingtable/adjRIBOut"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/util/math"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+// PolicyDirection selects the import or the export policy of a peer
+type PolicyDirection uint8
+
+const (
+	// PolicyDirectionIn evaluates the paths received from the peer against its import policy
+	PolicyDirectionIn PolicyDirection = iota
+
+	// PolicyDirectionOut evaluates the paths of the RIB advertised to the peer against its export policy
+	PolicyDirectionOut
+)
+
+// PolicyEvaluation is the outcome of running a path through the policy of a peer
+type PolicyEvaluation struct {
+	// Path is the path as found in the RIB
+	Path *route.Path
+
+	// Reject is set if the policy denies the path
+	Reject bool
+
+	// Result is the path as modified by the policy. It is nil if the path is rejected.
+	Result *route.Path
+
+	// RejectReason describes why the path is rejected, e.g. the rejecting term of the policy
+	RejectReason string
+}
+
+// EvaluatePolicy runs the paths of a prefix through the import or export policy of a peer without applying or
+// advertising the result
+func (b *bgpServer) EvaluatePolicy(peerIP *bnet.IP, afi uint16, safi uint8, dir PolicyDirection, pfx *bnet.Prefix) ([]*PolicyEvaluation, error) {
+	p := b.peers.get(peerIP)
+	if p == nil {
+		return nil, fmt.Errorf("peer %q not found", peerIP.String())
+	}
+
+	fsm := p.soleFSM()
+	if fsm == nil {
+		return nil, fmt.Errorf("peer %q has no single session", peerIP.String())
+	}
+
+	fsm.stateMu.RLock()
+	established := isEstablishedState(fsm.state)
+	fsm.stateMu.RUnlock()
+	if !established {
+		return nil, fmt.Errorf("session with peer %q is not established", peerIP.String())
+	}
+
+	f := fsm.addressFamily(afi, safi)
+	if f == nil {
+		return nil, fmt.Errorf("address family %d/%d not configured for peer %q", afi, safi, peerIP.String())
+	}
+
+	switch dir {
+	case PolicyDirectionIn:
+		return f.evaluateImportPolicy(pfx)
+	case PolicyDirectionOut:
+		return f.evaluateExportPolicy(pfx)
+	}
+
+	return nil, fmt.Errorf("invalid policy direction %d", dir)
+}
+
+// evaluateImportPolicy runs the paths of a prefix received from the peer through the import filter chain
+func (f *fsmAddressFamily) evaluateImportPolicy(pfx *bnet.Prefix) ([]*PolicyEvaluation, error) {
+	ribIn, ok := f.adjRIBIn.(*adjRIBIn.AdjRIBIn)
+	if !ok {
+		return nil, fmt.Errorf("unable to get AdjRIBIn")
+	}
+
+	r := ribIn.Get(pfx)
+	if r == nil {
+		return nil, fmt.Errorf("prefix %s not received from peer", pfx.String())
+	}
+
+	ret := make([]*PolicyEvaluation, 0)
+	for _, path := range r.Paths() {
+		e := newPolicyEvaluation(path, ribIn.EvaluatePath(pfx, path))
+		if path.IsHidden() {
+			e.RejectReason = path.HiddenReasonString()
+		}
+
+		ret = append(ret, e)
+	}
+
+	return ret, nil
+}
+
+// evaluateExportPolicy runs the paths of a prefix the RIB offers to the peer through the export filter chain
+func (f *fsmAddressFamily) evaluateExportPolicy(pfx *bnet.Prefix) ([]*PolicyEvaluation, error) {
+	ribOut, ok := f.adjRIBOut.(*adjRIBOut.AdjRIBOut)
+	if !ok {
+		return nil, fmt.Errorf("unable to get AdjRIBOut")
+	}
+
+	r := f.rib.Get(pfx)
+	if r == nil {
+		return nil, fmt.Errorf("prefix %s not found in RIB", pfx.String())
+	}
+
+	paths := r.Paths()
+	limit := math.Min(int(f.addPathTX.GetMaxPaths(r.ECMPPathCount())), len(paths))
+
+	ret := make([]*PolicyEvaluation, 0, limit)
+	for _, path := range paths[:limit] {
+		e := newPolicyEvaluation(path, ribOut.EvaluatePath(pfx, path))
+		if e.Reject && e.RejectReason == "" {
+			e.RejectReason = "not eligible for advertisement to the peer"
+		}
+
+		ret = append(ret, e)
+	}
+
+	return ret, nil
+}
+
+func newPolicyEvaluation(p *route.Path, res filter.ChainResult) *PolicyEvaluation {
+	e := &PolicyEvaluation{
+		Path:         p,
+		Reject:       res.Reject,
+		RejectReason: res.RejectReason(),
+	}
+
+	if !res.Reject {
+		e.Result = res.Path
+	}
+
+	return e
+}
@@ -0,0 +1,174 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/util/log"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+// PrefixLimit limits the number of prefixes accepted from a peer for an address family. Exceeding the limit tears
+// down the session with a Cease NOTIFICATION (RFC4486) and keeps the peer down.
+type PrefixLimit struct {
+	// Limit is the maximum number of prefixes accepted
+	Limit uint32
+
+	// RestartInterval is the time after which the session is reestablished automatically. Zero keeps the peer down
+	// until it is enabled via the API.
+	RestartInterval time.Duration
+
+	// MaxRestarts is the number of automatic restarts after which the peer stays down until it is enabled via the API.
+	// Zero allows unlimited restarts. The restarts are counted from zero again once a session stayed established for an
+	// hour.
+	MaxRestarts uint32
+}
+
+// Equal compares two PrefixLimits
+func (l *PrefixLimit) Equal(x *PrefixLimit) bool {
+	if l == nil || x == nil {
+		return l == x
+	}
+
+	return *l == *x
+}
+
+func (c *AddressFamilyConfig) prefixLimit() *PrefixLimit {
+	if c == nil {
+		return nil
+	}
+
+	return c.PrefixLimit
+}
+
+// prefixLimitStableTime is the time a session has to stay established before exceeding a prefix limit for the
+// automatic restarts to be counted from zero again
+const prefixLimitStableTime = time.Hour
+
+// prefixLimitRestarts holds the automatic restarts of a peer after it exceeded a prefix limit
+type prefixLimitRestarts struct {
+	mu    sync.Mutex
+	count uint32
+
+	// cancel is closed to cancel a pending restart
+	cancel chan struct{}
+}
+
+// prefixLimitExceeded returns the first address family the peer sent more prefixes than its limit for
+func (fsm *FSM) prefixLimitExceeded() *fsmAddressFamily {
+	for _, f := range []*fsmAddressFamily{fsm.ipv4Unicast, fsm.ipv6Unicast} {
+		if f != nil && f.prefixLimitExceeded() {
+			return f
+		}
+	}
+
+	return nil
+}
+
+func (f *fsmAddressFamily) prefixLimitExceeded() bool {
+	if f.prefixLimit == nil || f.adjRIBIn == nil {
+		return false
+	}
+
+	return f.adjRIBIn.RouteCount() > int64(f.prefixLimit.Limit)
+}
+
+// prefixLimitNotification creates the Cease NOTIFICATION carrying the address family and the limit (RFC4486)
+func (f *fsmAddressFamily) prefixLimitNotification() *packet.BGPNotification {
+	data := make([]byte, 0, 7)
+	data = append(data, convert.Uint16Byte(f.afi)...)
+	data = append(data, f.safi)
+	data = append(data, convert.Uint32Byte(f.prefixLimit.Limit)...)
+
+	return &packet.BGPNotification{
+		ErrorCode:    packet.Cease,
+		ErrorSubcode: packet.MaxPrefReached,
+		Data:         data,
+	}
+}
+
+// prefixLimitShutdown keeps the peer down after it exceeded l in a session established at establishedTime and
+// schedules an automatic restart if l allows one. Restarts are only counted towards MaxRestarts while the session
+// flaps, i.e. they are reset once a session stayed established for prefixLimitStableTime.
+func (p *peer) prefixLimitShutdown(l *PrefixLimit, establishedTime time.Time) {
+	p.adminDown.Store(true)
+
+	r := &p.prefixLimitRestarts
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if p.clock.Now().Sub(establishedTime) >= prefixLimitStableTime {
+		r.count = 0
+	}
+
+	if l.RestartInterval == 0 || (l.MaxRestarts != 0 && r.count >= l.MaxRestarts) {
+		log.WithFields(log.Fields{
+			"peer":     p.addr.String(),
+			"limit":    l.Limit,
+			"restarts": r.count,
+		}).Error("BGP peer exceeded prefix limit. Session stays down until enabled.")
+		return
+	}
+
+	r.count++
+	cancel := make(chan struct{})
+	r.cancel = cancel
+	t := p.clock.NewTimer(l.RestartInterval)
+
+	log.WithFields(log.Fields{
+		"peer":     p.addr.String(),
+		"limit":    l.Limit,
+		"restart":  l.RestartInterval,
+		"restarts": r.count,
+	}).Info("BGP peer exceeded prefix limit. Scheduling automatic restart.")
+
+	go func() {
+		select {
+		case <-t.C():
+			p.prefixLimitRestart(cancel)
+		case <-cancel:
+			t.Stop()
+		}
+	}()
+}
+
+// prefixLimitRestart brings the peer up again unless the restart was canceled or the peer was shut down via the API
+func (p *peer) prefixLimitRestart(cancel chan struct{}) {
+	r := &p.prefixLimitRestarts
+	r.mu.Lock()
+	if r.cancel != cancel {
+		r.mu.Unlock()
+		return
+	}
+
+	r.cancel = nil
+	r.mu.Unlock()
+
+	if p.server != nil && p.server.adminShut.contains(p.addr) {
+		return
+	}
+
+	if !p.adminDown.Swap(false) {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"peer": p.addr.String(),
+	}).Info("Restarting BGP session after prefix limit was exceeded")
+	p.enable()
+}
+
+// resetPrefixLimitRestarts cancels a pending automatic restart and resets the number of restarts
+func (p *peer) resetPrefixLimitRestarts() {
+	r := &p.prefixLimitRestarts
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.cancel != nil {
+		close(r.cancel)
+		r.cancel = nil
+	}
+
+	r.count = 0
+}
@@ -0,0 +1,234 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/adjRIBIn"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	btesting "github.com/bio-routing/bio-rd/testing"
+	btime "github.com/bio-routing/bio-rd/util/time"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPrefixLimitTestPeer(clock *btime.MockClock) (*peer, *FSM) {
+	p := &peer{
+		addr:    bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+		peerASN: 65001,
+		clock:   clock,
+	}
+	fsm := &FSM{
+		peer:    p,
+		eventCh: make(chan int),
+	}
+	p.fsms = []*FSM{fsm}
+
+	return p, fsm
+}
+
+func TestPrefixLimitExceeded(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	p, _ := newPrefixLimitTestPeer(clock)
+	fsm := newFSM(p)
+	fsm.clock = clock
+	con := btesting.NewMockConn()
+	fsm.con = con
+	fsm.ipv4Unicast = &fsmAddressFamily{
+		afi:         packet.AFIIPv4,
+		safi:        packet.SAFIUnicast,
+		fsm:         fsm,
+		adjRIBIn:    adjRIBIn.New(filter.NewAcceptAllFilterChain(), routingtable.NewContributingASNs(), routingtable.SessionAttrs{}),
+		prefixLimit: &PrefixLimit{Limit: 2},
+	}
+
+	s := newEstablishedState(fsm)
+	for i := uint8(0); i < 2; i++ {
+		next, _ := s.update(&packet.BGPUpdate{
+			NLRI: &packet.NLRI{
+				Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, i, 0, 0), 16).Ptr(),
+			},
+		}, false, 0)
+		assert.IsType(t, &establishedState{}, next, "prefix %d within limit", i+1)
+	}
+
+	next, reason := s.update(&packet.BGPUpdate{
+		NLRI: &packet.NLRI{
+			Prefix: bnet.NewPfx(bnet.IPv4FromOctets(10, 2, 0, 0), 16).Ptr(),
+		},
+	}, false, 0)
+	assert.IsType(t, &idleState{}, next)
+	assert.Equal(t, "Prefix limit of 2 exceeded for AFI 1 SAFI 1", reason)
+	assert.True(t, con.Closed)
+	assert.True(t, p.adminDown.Load(), "peer must stay down")
+	assert.Equal(t, 0, clock.PendingTimers(), "no restart configured")
+
+	msg, err := packet.Decode(bytes.NewBuffer(con.Buf.Bytes()), &packet.DecodeOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, &packet.BGPNotification{
+		ErrorCode:    packet.Cease,
+		ErrorSubcode: packet.MaxPrefReached,
+		Data:         []byte{0, 1, 1, 0, 0, 0, 2},
+	}, msg.Body)
+}
+
+func TestPrefixLimitRestart(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	p, fsm := newPrefixLimitTestPeer(clock)
+	s := &bgpServer{
+		peers: testPeerManager(map[bnet.IP]*peer{
+			*p.addr: p,
+		}),
+	}
+	p.server = s
+
+	l := &PrefixLimit{
+		Limit:           100,
+		RestartInterval: 5 * time.Minute,
+	}
+
+	for i := 0; i < 3; i++ {
+		p.prefixLimitShutdown(l, clock.Now())
+		assert.True(t, p.adminDown.Load())
+		assert.Equal(t, 1, clock.PendingTimers())
+
+		clock.Advance(4 * time.Minute)
+		assert.True(t, p.adminDown.Load(), "restart %d must wait for the restart interval", i+1)
+
+		clock.Advance(time.Minute)
+		assert.Equal(t, AutomaticStart, <-fsm.eventCh, "restart %d must start the session", i+1)
+		assert.False(t, p.adminDown.Load())
+	}
+
+	assert.Equal(t, uint32(3), p.prefixLimitRestarts.count, "unlimited restarts")
+}
+
+func TestPrefixLimitMaxRestarts(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	p, fsm := newPrefixLimitTestPeer(clock)
+	s := &bgpServer{
+		peers: testPeerManager(map[bnet.IP]*peer{
+			*p.addr: p,
+		}),
+	}
+	p.server = s
+
+	l := &PrefixLimit{
+		Limit:           100,
+		RestartInterval: time.Minute,
+		MaxRestarts:     2,
+	}
+
+	for i := 0; i < 2; i++ {
+		p.prefixLimitShutdown(l, clock.Now())
+		clock.Advance(time.Minute)
+		assert.Equal(t, AutomaticStart, <-fsm.eventCh, "restart %d must start the session", i+1)
+	}
+
+	p.prefixLimitShutdown(l, clock.Now())
+	assert.Equal(t, 0, clock.PendingTimers(), "retry cap reached")
+	assert.True(t, p.adminDown.Load(), "peer must stay down until enabled")
+
+	// Enabling the peer manually resets the restarts
+	assert.NoError(t, s.EnablePeer(p.addr))
+	assert.Equal(t, AutomaticStart, <-fsm.eventCh)
+	assert.False(t, p.adminDown.Load())
+
+	p.prefixLimitShutdown(l, clock.Now())
+	assert.Equal(t, 1, clock.PendingTimers(), "restart must be scheduled again")
+	clock.Advance(time.Minute)
+	assert.Equal(t, AutomaticStart, <-fsm.eventCh)
+}
+
+func TestPrefixLimitRestartsResetAfterStableSession(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	p, fsm := newPrefixLimitTestPeer(clock)
+	p.server = &bgpServer{
+		peers: testPeerManager(map[bnet.IP]*peer{
+			*p.addr: p,
+		}),
+	}
+
+	l := &PrefixLimit{
+		Limit:           100,
+		RestartInterval: time.Minute,
+		MaxRestarts:     1,
+	}
+
+	p.prefixLimitShutdown(l, clock.Now())
+	clock.Advance(time.Minute)
+	assert.Equal(t, AutomaticStart, <-fsm.eventCh)
+
+	established := clock.Now()
+	clock.Advance(prefixLimitStableTime)
+	p.prefixLimitShutdown(l, established)
+	assert.Equal(t, 1, clock.PendingTimers(), "restart must be scheduled after a stable session")
+	assert.Equal(t, uint32(1), p.prefixLimitRestarts.count)
+}
+
+func TestPrefixLimitRestartCanceled(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	p, _ := newPrefixLimitTestPeer(clock)
+	s := &bgpServer{
+		peers: testPeerManager(map[bnet.IP]*peer{
+			*p.addr: p,
+		}),
+	}
+	p.server = s
+
+	l := &PrefixLimit{
+		Limit:           100,
+		RestartInterval: time.Minute,
+	}
+
+	// A shutdown via the API takes precedence over the automatic restart
+	p.prefixLimitShutdown(l, clock.Now())
+	s.adminShut.add(p.addr)
+	p.prefixLimitRestart(p.prefixLimitRestarts.cancel)
+	assert.True(t, p.adminDown.Load(), "peer shut down via the API must stay down")
+
+	s.adminShut.remove(p.addr)
+	p.prefixLimitShutdown(l, clock.Now())
+	cancel := p.prefixLimitRestarts.cancel
+	p.resetPrefixLimitRestarts()
+	p.prefixLimitRestart(cancel)
+	assert.Nil(t, p.prefixLimitRestarts.cancel)
+	assert.Equal(t, uint32(0), p.prefixLimitRestarts.count)
+	assert.True(t, p.adminDown.Load(), "canceling the restart must keep the peer down")
+}
+
+func TestPrefixLimitEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        *PrefixLimit
+		b        *PrefixLimit
+		expected bool
+	}{
+		{
+			name:     "both nil",
+			expected: true,
+		},
+		{
+			name: "one nil",
+			a:    &PrefixLimit{Limit: 10},
+		},
+		{
+			name:     "equal",
+			a:        &PrefixLimit{Limit: 10, RestartInterval: time.Minute, MaxRestarts: 3},
+			b:        &PrefixLimit{Limit: 10, RestartInterval: time.Minute, MaxRestarts: 3},
+			expected: true,
+		},
+		{
+			name: "different restart interval",
+			a:    &PrefixLimit{Limit: 10, RestartInterval: time.Minute},
+			b:    &PrefixLimit{Limit: 10, RestartInterval: 2 * time.Minute},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.a.Equal(test.b), test.name)
+	}
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"time"
+)
+
+// Ready reports whether initial convergence has completed. That is the case once every configured peer
+// has established its session and received End-of-RIB for all address families negotiated in it. Peers that fail
+// to do so within deadline after they were configured and administratively shut down peers are ignored.
+func (b *bgpServer) Ready(deadline time.Duration) bool {
+	for _, p := range b.peers.list() {
+		if p.adminDown.Load() {
+			continue
+		}
+
+		if p.converged() {
+			continue
+		}
+
+		if time.Since(p.createdTime) >= deadline {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func (p *peer) converged() bool {
+	p.fsmsMu.Lock()
+	fsms := p.fsms
+	p.fsmsMu.Unlock()
+
+	for _, fsm := range fsms {
+		if fsm.converged() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (fsm *FSM) converged() bool {
+	fsm.stateMu.RLock()
+	defer fsm.stateMu.RUnlock()
+
+	if !isEstablishedState(fsm.state) || !fsm.ribsInitialized {
+		return false
+	}
+
+	// The peer does not send End-of-RIB for address families not negotiated in the session
+	for _, f := range []*fsmAddressFamily{fsm.ipv4Unicast, fsm.ipv6Unicast} {
+		if f != nil && f.negotiated() && !f.endOfRIBMarkerReceived.Load() {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+func TestServerReady(t *testing.T) {
+	s := newBGPServer(0, nil)
+
+	peers := make([]*peer, 0)
+	for i := 1; i <= 2; i++ {
+		p := &peer{
+			addr:        bnet.IPv4(uint32(i)).Ptr(),
+			ipv4:        &peerAddressFamily{},
+			ipv6:        &peerAddressFamily{},
+			createdTime: time.Now(),
+		}
+
+		fsm := newFSM(p)
+		fsm.state = &establishedState{}
+		fsm.ribsInitialized = true
+		fsm.ipv6Unicast.multiProtocol = true
+		p.fsms = append(p.fsms, fsm)
+
+		s.peers.add(p)
+		peers = append(peers, p)
+	}
+
+	assert.False(t, s.Ready(time.Hour), "no End-of-RIB received")
+
+	peers[0].fsms[0].ipv4Unicast.endOfRIBMarkerReceived.Store(true)
+	peers[0].fsms[0].ipv6Unicast.endOfRIBMarkerReceived.Store(true)
+	peers[1].fsms[0].ipv4Unicast.endOfRIBMarkerReceived.Store(true)
+	assert.False(t, s.Ready(time.Hour), "End-of-RIB for IPv6 missing from second peer")
+
+	peers[1].fsms[0].ipv6Unicast.endOfRIBMarkerReceived.Store(true)
+	assert.True(t, s.Ready(time.Hour), "End-of-RIB received from all peers")
+}
+
+func TestServerReadyDeadline(t *testing.T) {
+	s := newBGPServer(0, nil)
+
+	p := &peer{
+		addr:        bnet.IPv4(1).Ptr(),
+		ipv4:        &peerAddressFamily{},
+		createdTime: time.Now().Add(-time.Minute),
+	}
+	p.fsms = append(p.fsms, newFSM(p))
+	s.peers.add(p)
+
+	assert.False(t, s.Ready(time.Hour), "deadline not yet passed")
+	assert.True(t, s.Ready(time.Second), "deadline passed")
+
+	p.createdTime = time.Now()
+	p.adminDown.Store(true)
+	assert.True(t, s.Ready(time.Hour), "peer is administratively down")
+}
+
+func TestFSMConvergedIgnoresFamiliesNotNegotiated(t *testing.T) {
+	p := &peer{
+		addr: bnet.IPv4(1).Ptr(),
+		ipv4: &peerAddressFamily{},
+		ipv6: &peerAddressFamily{},
+	}
+
+	fsm := newFSM(p)
+	fsm.state = &establishedState{}
+	fsm.ribsInitialized = true
+	assert.False(t, fsm.converged(), "no End-of-RIB received")
+
+	fsm.ipv4Unicast.endOfRIBMarkerReceived.Store(true)
+	assert.True(t, fsm.converged(), "IPv6 was not negotiated")
+}
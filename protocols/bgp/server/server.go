@@ -3,6 +3,7 @@ package server
 import (
 	"fmt"
 	"net"
+	"time"
 
 	"github.com/bio-routing/bio-rd/routingtable/adjRIBOut"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
@@ -11,6 +12,7 @@ import (
 
 	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/protocols/bgp/metrics"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
 	"github.com/bio-routing/bio-rd/util/log"
 	bnetutils "github.com/bio-routing/bio-rd/util/net"
 )
@@ -26,6 +28,7 @@ type bgpServer struct {
 	peers       *peerManager
 	routerID    uint32
 	metrics     *metricsService
+	adminShut   adminShutdowns
 }
 
 type BGPServer interface {
@@ -34,6 +37,8 @@ type BGPServer interface {
 	AddPeer(PeerConfig) error
 	GetPeerConfig(*bnet.IP) *PeerConfig
 	DisposePeer(*bnet.IP)
+	ShutdownPeer(addr *bnet.IP, communication string, reset bool) error
+	EnablePeer(addr *bnet.IP) error
 	GetPeers() []*bnet.IP
 	Metrics() (*metrics.BGPMetrics, error)
 	GetRIBIn(peerIP *bnet.IP, afi uint16, safi uint8) *adjRIBIn.AdjRIBIn
@@ -41,8 +46,26 @@ type BGPServer interface {
 	ConnectMockPeer(peer PeerConfig, con net.Conn)
 	ReplaceImportFilterChain(peer *bnet.IP, c filter.Chain) error
 	ReplaceExportFilterChain(peer *bnet.IP, c filter.Chain) error
+	SoftRefreshIn(peer *bnet.IP, afi uint16, safi uint8) (SoftRefreshMethod, error)
+	GetSession(peer *bnet.IP) (*SessionDetail, error)
+	EvaluatePolicy(peer *bnet.IP, afi uint16, safi uint8, dir PolicyDirection, pfx *bnet.Prefix) ([]*PolicyEvaluation, error)
+	Ready(deadline time.Duration) bool
 }
 
+// SoftRefreshMethod is the method used to get the routes received from a peer refreshed
+type SoftRefreshMethod uint8
+
+const (
+	// SoftRefreshEnhancedRouteRefresh requests the routes again from the peer using Enhanced Route Refresh (RFC7313)
+	SoftRefreshEnhancedRouteRefresh SoftRefreshMethod = iota
+
+	// SoftRefreshRouteRefresh requests the routes again from the peer using Route Refresh (RFC2918)
+	SoftRefreshRouteRefresh
+
+	// SoftRefreshStoredRoutes runs the routes stored in the Adj-RIB-In through the import filters again
+	SoftRefreshStoredRoutes
+)
+
 // NewBGPServer creates a new instance of bgpServer
 func NewBGPServer(routerID uint32, addrs []string) BGPServer {
 	return newBGPServer(routerID, addrs)
@@ -114,17 +137,44 @@ func (b *bgpServer) ReplaceExportFilterChain(peerIP *bnet.IP, c filter.Chain) er
 	return nil
 }
 
+// SoftRefreshIn refreshes the routes received from a peer for an AFI/SAFI using the best method the session supports
+func (b *bgpServer) SoftRefreshIn(peerIP *bnet.IP, afi uint16, safi uint8) (SoftRefreshMethod, error) {
+	p := b.peers.get(peerIP)
+	if p == nil {
+		return 0, fmt.Errorf("peer %q not found", peerIP.String())
+	}
+
+	fsm := p.soleFSM()
+	if fsm == nil {
+		return 0, fmt.Errorf("peer %q has no single session", peerIP.String())
+	}
+
+	fsm.stateMu.RLock()
+	established := isEstablishedState(fsm.state)
+	fsm.stateMu.RUnlock()
+	if !established {
+		return 0, fmt.Errorf("session with peer %q is not established", peerIP.String())
+	}
+
+	f := fsm.addressFamily(afi, safi)
+	if f == nil {
+		return 0, fmt.Errorf("address family %d/%d not configured for peer %q", afi, safi, peerIP.String())
+	}
+
+	return f.softRefreshIn()
+}
+
 func (b *bgpServer) GetRIBIn(peerIP *bnet.IP, afi uint16, safi uint8) *adjRIBIn.AdjRIBIn {
 	p := b.peers.get(peerIP)
 	if p == nil {
 		return nil
 	}
 
-	if len(p.fsms) != 1 {
+	fsm := p.soleFSM()
+	if fsm == nil {
 		return nil
 	}
 
-	fsm := p.fsms[0]
 	f := fsm.addressFamily(afi, safi)
 	if f == nil {
 		return nil
@@ -139,11 +189,11 @@ func (b *bgpServer) GetRIBOut(peerIP *bnet.IP, afi uint16, safi uint8) *adjRIBOu
 		return nil
 	}
 
-	if len(p.fsms) != 1 {
+	fsm := p.soleFSM()
+	if fsm == nil {
 		return nil
 	}
 
-	fsm := p.fsms[0]
 	f := fsm.addressFamily(afi, safi)
 	if f == nil {
 		return nil
@@ -166,6 +216,22 @@ func (b *bgpServer) incomingConnectionWorker() {
 			continue
 		}
 
+		if peer.adminDown.Load() {
+			c.Close()
+			log.WithFields(log.Fields{
+				"source": c.RemoteAddr(),
+			}).Info("Rejecting incoming TCP connection from administratively shut down peer")
+			continue
+		}
+
+		if peer.active {
+			c.Close()
+			log.WithFields(log.Fields{
+				"source": c.RemoteAddr(),
+			}).Info("Rejecting incoming TCP connection from active peer")
+			continue
+		}
+
 		log.WithFields(log.Fields{
 			"source": c.RemoteAddr(),
 		}).Info("Incoming TCP connection")
@@ -173,7 +239,7 @@ func (b *bgpServer) incomingConnectionWorker() {
 		log.WithFields(log.Fields{
 			"peer": peerAddr,
 		}).Debug("Sending incoming TCP connection to fsm for peer")
-		fsm := NewActiveFSM(peer)
+		fsm := newFSM(peer)
 		fsm.state = newActiveState(fsm)
 		fsm.startConnectRetryTimer()
 
@@ -197,6 +263,9 @@ func (b *bgpServer) ConnectMockPeer(peer PeerConfig, con net.Conn) {
 func (b *bgpServer) AddPeer(c PeerConfig) error {
 	c.LocalAddress = c.LocalAddress.Dedup()
 	c.PeerAddress = c.PeerAddress.Dedup()
+	if c.UpdateSource != nil {
+		c.UpdateSource = c.UpdateSource.Dedup()
+	}
 
 	peer, err := newPeer(c, b)
 	if err != nil {
@@ -213,6 +282,13 @@ func (b *bgpServer) AddPeer(c PeerConfig) error {
 	}
 
 	peer.routerID = c.RouterID
+	if b.adminShut.contains(c.PeerAddress) {
+		peer.adminDown.Store(true)
+		log.WithFields(log.Fields{
+			"peer_address": c.PeerAddress,
+		}).Info("BGP peer remains administratively shut down")
+	}
+
 	b.peers.add(peer)
 	if !c.Passive {
 		peer.Start()
@@ -245,10 +321,48 @@ func (b *bgpServer) DisposePeer(addr *bnet.IP) {
 	}
 
 	log.Infof("disposing BGP session with %s", addr.String())
-	p.stop()
+	p.resetPrefixLimitRestarts()
+	p.stopWithNotification(&packet.BGPNotification{
+		ErrorCode:    packet.Cease,
+		ErrorSubcode: packet.PeerDeconfigured,
+	})
 	b.peers.remove(addr)
 }
 
+// ShutdownPeer administratively shuts down the sessions with a peer sending a Cease NOTIFICATION carrying
+// communication (RFC8203). The peer stays down, even if it is recreated by a configuration reload, until EnablePeer
+// is called. If reset is set the session is reset instead and reestablished afterwards.
+func (b *bgpServer) ShutdownPeer(addr *bnet.IP, communication string, reset bool) error {
+	p := b.peers.get(addr)
+	if p == nil {
+		return fmt.Errorf("peer %q not found", addr.String())
+	}
+
+	subcode := uint8(packet.AdminShut)
+	if reset {
+		subcode = packet.AdminReset
+	}
+
+	n, err := packet.NewShutdownNotification(subcode, communication)
+	if err != nil {
+		return fmt.Errorf("invalid shutdown communication: %w", err)
+	}
+
+	if !reset {
+		b.adminShut.add(addr)
+		p.adminDown.Store(true)
+	}
+
+	log.WithFields(log.Fields{
+		"peer":          addr.String(),
+		"reset":         reset,
+		"communication": communication,
+	}).Info("Administratively shutting down BGP session")
+	p.stopWithNotification(n)
+
+	return nil
+}
+
 func (b *bgpServer) Metrics() (*metrics.BGPMetrics, error) {
 	if b.metrics == nil {
 		return nil, fmt.Errorf("server not started yet")
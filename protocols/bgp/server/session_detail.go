@@ -0,0 +1,103 @@
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/metrics"
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+// AddressFamily identifies an AFI/SAFI combination
+type AddressFamily struct {
+	AFI  uint16
+	SAFI uint8
+}
+
+// SessionDetail describes the state a BGP session negotiated with a peer
+type SessionDetail struct {
+	LocalAddress    *bnet.IP
+	NeighborAddress *bnet.IP
+	LocalASN        uint32
+	PeerASN         uint32
+
+	// State of the BGP session (see metrics.State*)
+	State uint8
+
+	// LocalRouterID is the BGP identifier we advertise in our OPEN message
+	LocalRouterID uint32
+
+	// RemoteRouterID is the BGP identifier received in the peers OPEN message
+	RemoteRouterID uint32
+
+	// AdvertisedHoldTime is the hold time we advertise in our OPEN message
+	AdvertisedHoldTime time.Duration
+
+	// NegotiatedHoldTime is the hold time in use for an established session. Zero means the hold timer is disabled.
+	NegotiatedHoldTime time.Duration
+
+	// AddressFamilies are the AFI/SAFIs negotiated for an established session
+	AddressFamilies []AddressFamily
+
+	// Uptime is the time since the session was established
+	Uptime time.Duration
+
+	// LastError is the reason the session last went down
+	LastError string
+}
+
+// GetSession gets details on the session with a peer
+func (b *bgpServer) GetSession(peerIP *bnet.IP) (*SessionDetail, error) {
+	p := b.peers.get(peerIP)
+	if p == nil {
+		return nil, fmt.Errorf("peer %q not found", peerIP.String())
+	}
+
+	fsm := p.soleFSM()
+	if fsm == nil {
+		return nil, fmt.Errorf("peer %q has no single session", peerIP.String())
+	}
+
+	return fsm.sessionDetail(), nil
+}
+
+func (fsm *FSM) sessionDetail() *SessionDetail {
+	fsm.stateMu.RLock()
+	defer fsm.stateMu.RUnlock()
+
+	d := &SessionDetail{
+		LocalAddress:       fsm.peer.localAddr,
+		NeighborAddress:    fsm.peer.addr,
+		LocalASN:           fsm.peer.localASN,
+		PeerASN:            fsm.peer.peerASN,
+		State:              statusFromFSM(fsm),
+		LocalRouterID:      fsm.peer.routerID,
+		AdvertisedHoldTime: fsm.peer.holdTime,
+		AddressFamilies:    make([]AddressFamily, 0),
+		LastError:          fsm.lastError,
+	}
+
+	if d.State != metrics.StateEstablished {
+		return d
+	}
+
+	d.RemoteRouterID = fsm.neighborID
+	d.NegotiatedHoldTime = fsm.holdTime
+	d.Uptime = fsm.clock.Now().Sub(fsm.establishedTime)
+
+	if fsm.ipv4Unicast != nil && fsm.ipv4Unicast.negotiated() {
+		d.AddressFamilies = append(d.AddressFamilies, AddressFamily{AFI: packet.AFIIPv4, SAFI: fsm.ipv4Unicast.safi})
+	}
+
+	if fsm.ipv6Unicast != nil && fsm.ipv6Unicast.negotiated() {
+		d.AddressFamilies = append(d.AddressFamilies, AddressFamily{AFI: packet.AFIIPv6, SAFI: fsm.ipv6Unicast.safi})
+	}
+
+	if fsm.linkStateNegotiated {
+		d.AddressFamilies = append(d.AddressFamilies, AddressFamily{AFI: packet.AFILinkState, SAFI: packet.SAFILinkState})
+	}
+
+	return d
+}
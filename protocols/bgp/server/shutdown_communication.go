@@ -0,0 +1,55 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// manualStopWithNotification stops the FSM sending n instead of a plain Cease NOTIFICATION
+func (fsm *FSM) manualStopWithNotification(n *packet.BGPNotification) {
+	fsm.stopNotificationMu.Lock()
+	fsm.stopNotification = n
+	fsm.stopNotificationMu.Unlock()
+
+	fsm.eventCh <- ManualStop
+}
+
+// takeStopNotification gets and clears the NOTIFICATION requested for a manual stop. States without a session to send
+// it on discard it this way, so it is not sent on a later session.
+func (fsm *FSM) takeStopNotification() *packet.BGPNotification {
+	fsm.stopNotificationMu.Lock()
+	defer fsm.stopNotificationMu.Unlock()
+
+	n := fsm.stopNotification
+	fsm.stopNotification = nil
+	return n
+}
+
+// sendStopNotification sends the NOTIFICATION requested for a manual stop or a plain Cease NOTIFICATION otherwise
+func (fsm *FSM) sendStopNotification() error {
+	n := fsm.takeStopNotification()
+	if n == nil {
+		return fsm.sendNotification(packet.Cease, 0)
+	}
+
+	return fsm.sendNotificationMsg(n)
+}
+
+// receivedNotificationReason logs the shutdown communication (RFC8203) of a received NOTIFICATION
+// and returns the reason for the resulting state change
+func (fsm *FSM) receivedNotificationReason(n *packet.BGPNotification) string {
+	communication, ok := n.ShutdownCommunication()
+	if !ok {
+		return "Received NOTIFICATION"
+	}
+
+	log.WithFields(log.Fields{
+		"peer":          fsm.peer.addr.String(),
+		"error_subcode": n.ErrorSubcode,
+		"communication": communication,
+	}).Info("Received shutdown communication")
+
+	return fmt.Sprintf("Received NOTIFICATION with shutdown communication %q", communication)
+}
@@ -2,28 +2,48 @@ package server
 
 import (
 	"net"
-
-	"github.com/bio-routing/bio-rd/net/tcp"
 )
 
+// ttlSetter is implemented by connections that support setting the TTL of outgoing packets
+type ttlSetter interface {
+	SetTTL(ttl uint8) error
+}
+
+// minTTLSetter is implemented by connections that support discarding packets below a minimum TTL
+type minTTLSetter interface {
+	SetMinTTL(ttl uint8) error
+}
+
+// dontRouteSetter is implemented by connections that support setting SO_DONTROUTE
+type dontRouteSetter interface {
+	SetDontRoute() error
+}
+
 func setTTL(c net.Conn, ttl uint8) error {
 	// as c is an interface for testability reason we're checking here if the concrete type
-	// is a real TCP connection as only that supports setting a TTL
-	switch c.(type) {
-	case *tcp.Conn:
-		return c.(*tcp.Conn).SetTTL(ttl)
-	default:
+	// supports setting a TTL (e.g. a real TCP connection)
+	s, ok := c.(ttlSetter)
+	if !ok {
 		return nil
 	}
+
+	return s.SetTTL(ttl)
+}
+
+func setMinTTL(c net.Conn, ttl uint8) error {
+	s, ok := c.(minTTLSetter)
+	if !ok {
+		return nil
+	}
+
+	return s.SetMinTTL(ttl)
 }
 
 func setDontRoute(c net.Conn) error {
-	// as c is an interface for testability reason we're checking here if the concrete type
-	// is a real TCP connection as only that supports setting SetDontRoute()
-	switch c.(type) {
-	case *tcp.Conn:
-		return c.(*tcp.Conn).SetDontRoute()
-	default:
+	s, ok := c.(dontRouteSetter)
+	if !ok {
 		return nil
 	}
+
+	return s.SetDontRoute()
 }
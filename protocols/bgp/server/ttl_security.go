@@ -0,0 +1,32 @@
+package server
+
+import "fmt"
+
+// gtsmTTL is the TTL packets are sent with when the Generalized TTL Security Mechanism (GTSM, RFC5082) is enabled
+const gtsmTTL = 255
+
+func (pc *PeerConfig) validateTTLSecurity() error {
+	if pc.TTLSecurityHops == 0 {
+		return nil
+	}
+
+	if pc.TTL != 0 {
+		return fmt.Errorf("TTL security and TTL (ebgp-multihop) are mutually exclusive")
+	}
+
+	if pc.TTLSecurityHops >= gtsmTTL {
+		return fmt.Errorf("TTL security hops must be less than %d", gtsmTTL)
+	}
+
+	return nil
+}
+
+// applyTTLSecurity sends packets with the maximum TTL and discards received packets from peers more than TTLSecurityHops away
+func (p *peer) applyTTLSecurity(hops uint8) {
+	if hops == 0 {
+		return
+	}
+
+	p.ttl = gtsmTTL
+	p.minTTL = gtsmTTL - hops
+}
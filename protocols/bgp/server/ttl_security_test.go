@@ -0,0 +1,145 @@
+package server
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/stretchr/testify/assert"
+)
+
+// sockoptConn is a fake socket recording the options set on it
+type sockoptConn struct {
+	halfCloseConn
+	ttl       uint8
+	minTTL    uint8
+	dontRoute bool
+}
+
+func (c *sockoptConn) SetTTL(ttl uint8) error {
+	c.ttl = ttl
+	return nil
+}
+
+func (c *sockoptConn) SetMinTTL(ttl uint8) error {
+	c.minTTL = ttl
+	return nil
+}
+
+func (c *sockoptConn) SetDontRoute() error {
+	c.dontRoute = true
+	return nil
+}
+
+func TestNewPeerTTLSecurity(t *testing.T) {
+	tests := []struct {
+		name           string
+		ttl            uint8
+		hops           uint8
+		wantFail       bool
+		expectedTTL    uint8
+		expectedMinTTL uint8
+	}{
+		{
+			name:        "ebgp-multihop",
+			ttl:         3,
+			expectedTTL: 3,
+		},
+		{
+			name:           "TTL security",
+			hops:           1,
+			expectedTTL:    255,
+			expectedMinTTL: 254,
+		},
+		{
+			name:     "TTL security with ebgp-multihop",
+			ttl:      3,
+			hops:     1,
+			wantFail: true,
+		},
+		{
+			name:     "TTL security hops out of range",
+			hops:     255,
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := newPeer(PeerConfig{
+				PeerAddress:     bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+				LocalAS:         65000,
+				PeerAS:          65100,
+				Passive:         true,
+				TTL:             test.ttl,
+				TTLSecurityHops: test.hops,
+			}, nil)
+
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedTTL, p.ttl)
+			assert.Equal(t, test.expectedMinTTL, p.minTTL)
+		})
+	}
+}
+
+func TestSockSettingsTTLSecurity(t *testing.T) {
+	tests := []struct {
+		name              string
+		peerAS            uint32
+		ttl               uint8
+		hops              uint8
+		expectedTTL       uint8
+		expectedMinTTL    uint8
+		expectedDontRoute bool
+	}{
+		{
+			name:              "eBGP",
+			peerAS:            65100,
+			expectedTTL:       1,
+			expectedDontRoute: true,
+		},
+		{
+			name:        "eBGP multihop",
+			peerAS:      65100,
+			ttl:         3,
+			expectedTTL: 3,
+		},
+		{
+			name:           "eBGP with TTL security",
+			peerAS:         65100,
+			hops:           2,
+			expectedTTL:    255,
+			expectedMinTTL: 253,
+		},
+		{
+			name:   "iBGP",
+			peerAS: 65000,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := newPeer(PeerConfig{
+				PeerAddress:     bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+				LocalAS:         65000,
+				PeerAS:          test.peerAS,
+				Passive:         true,
+				TTL:             test.ttl,
+				TTLSecurityHops: test.hops,
+			}, nil)
+			assert.NoError(t, err)
+
+			c := &sockoptConn{}
+			err = newFSM(p).sockSettings(c)
+			assert.NoError(t, err)
+
+			assert.Equal(t, test.expectedTTL, c.ttl)
+			assert.Equal(t, test.expectedMinTTL, c.minTTL)
+			assert.Equal(t, test.expectedDontRoute, c.dontRoute)
+		})
+	}
+}
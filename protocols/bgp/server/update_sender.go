@@ -10,6 +10,7 @@ import (
 
 	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
 	"github.com/bio-routing/bio-rd/route"
 	"github.com/bio-routing/bio-rd/routingtable"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
@@ -28,6 +29,24 @@ type UpdateSender struct {
 	toSend        map[string]*pathPfxs
 	destroyCh     chan struct{}
 	wg            sync.WaitGroup
+
+	// mrai is the minimum route advertisement interval (RFC4271 section 9.2.1.1). Advertisements of a prefix
+	// within the interval are held back in mraiPending and only the latest path is sent once it elapsed.
+	// Guarded by toSendMu.
+	mrai           time.Duration
+	lastAdvertised map[mraiKey]time.Time
+	mraiPending    map[mraiKey]*route.Path
+	lastMRAISweep  time.Time
+
+	// holdUntil is the end of the advertisement delay after the session came up. Advertisements are queued
+	// until then and the End-of-RIB marker is deferred by setting endOfRIBHeld. Guarded by toSendMu.
+	holdUntil    time.Time
+	endOfRIBHeld bool
+}
+
+type mraiKey struct {
+	pfx    bnet.Prefix
+	pathID uint32
 }
 
 type pathPfxs struct {
@@ -43,13 +62,24 @@ func newUpdateSender(f *fsmAddressFamily) *UpdateSender {
 		rrClient:      f.fsm.peer.routeReflectorClient,
 		destroyCh:     make(chan struct{}),
 		toSend:        make(map[string]*pathPfxs),
+		mrai:          f.fsm.peer.mrai,
 		options: &packet.EncodeOptions{
-			Use32BitASN: f.fsm.supports4OctetASN,
-			UseAddPath:  !f.addPathTX.BestOnly,
+			Use32BitASN:     f.fsm.supports4OctetASN,
+			UseAddPath:      !f.addPathTX.BestOnly,
+			ExtendedMessage: f.fsm.extendedMessage,
 		},
 	}
 	u.clientManager = routingtable.NewClientManager(u)
 
+	if u.mrai > 0 {
+		u.lastAdvertised = make(map[mraiKey]time.Time)
+		u.mraiPending = make(map[mraiKey]*route.Path)
+	}
+
+	if f.fsm.peer.advDelay > 0 {
+		u.holdUntil = f.fsm.clock.Now().Add(f.fsm.peer.advDelay)
+	}
+
 	return u
 }
 
@@ -78,10 +108,101 @@ func (u *UpdateSender) AddPath(pfx *bnet.Prefix, p *route.Path) error {
 	u.toSendMu.Lock()
 	defer u.toSendMu.Unlock()
 
+	if u.mrai > 0 && u._holdBackForMRAI(pfx, p) {
+		return nil
+	}
+
+	u._addPath(pfx, p)
+	return nil
+}
+
+// _holdBackForMRAI defers the advertisement of pfx if it has been advertised within the MRAI. Returns true if held back.
+func (u *UpdateSender) _holdBackForMRAI(pfx *bnet.Prefix, p *route.Path) bool {
+	k := mraiKey{
+		pfx:    *pfx,
+		pathID: p.BGPPath.PathIdentifier,
+	}
+
+	if _, pending := u.mraiPending[k]; pending {
+		u.mraiPending[k] = p
+		return true
+	}
+
+	now := u.fsm.clock.Now()
+	if last, exists := u.lastAdvertised[k]; exists && now.Sub(last) < u.mrai {
+		u.mraiPending[k] = p
+		return true
+	}
+
+	u.lastAdvertised[k] = now
+	return false
+}
+
+// _releaseMRAIPending queues held back advertisements whose MRAI elapsed
+func (u *UpdateSender) _releaseMRAIPending() {
+	now := u.fsm.clock.Now()
+	for k, p := range u.mraiPending {
+		if now.Sub(u.lastAdvertised[k]) < u.mrai {
+			continue
+		}
+
+		delete(u.mraiPending, k)
+		u.lastAdvertised[k] = now
+		pfx := k.pfx
+		u._addPath(&pfx, p)
+	}
+
+	if now.Sub(u.lastMRAISweep) < u.mrai {
+		return
+	}
+
+	u.lastMRAISweep = now
+	for k, last := range u.lastAdvertised {
+		if now.Sub(last) >= u.mrai {
+			delete(u.lastAdvertised, k)
+		}
+	}
+}
+
+// _holdAdvertisements returns if advertisements are held back as the advertisement delay did not elapse yet
+func (u *UpdateSender) _holdAdvertisements() bool {
+	if u.holdUntil.IsZero() {
+		return false
+	}
+
+	if u.fsm.clock.Now().Before(u.holdUntil) {
+		return true
+	}
+
+	u.holdUntil = time.Time{}
+	return false
+}
+
+// _removeQueued removes pfx from the queued advertisements
+func (u *UpdateSender) _removeQueued(pfx *bnet.Prefix, pathID uint32) {
+	for key, pathNLRIs := range u.toSend {
+		if pathNLRIs.path.BGPPath.PathIdentifier != pathID {
+			continue
+		}
+
+		for i := range pathNLRIs.pfxs {
+			if pathNLRIs.pfxs[i].Equal(pfx) {
+				pathNLRIs.pfxs = append(pathNLRIs.pfxs[:i], pathNLRIs.pfxs[i+1:]...)
+				break
+			}
+		}
+
+		if len(pathNLRIs.pfxs) == 0 {
+			delete(u.toSend, key)
+		}
+	}
+}
+
+func (u *UpdateSender) _addPath(pfx *bnet.Prefix, p *route.Path) {
 	hash := p.BGPPath.ComputeHashWithPathID()
 	if _, exists := u.toSend[hash]; exists {
 		u.toSend[hash].pfxs = append(u.toSend[hash].pfxs, pfx)
-		return nil
+		return
 	}
 
 	u.toSend[hash] = &pathPfxs{
@@ -90,8 +211,41 @@ func (u *UpdateSender) AddPath(pfx *bnet.Prefix, p *route.Path) error {
 			pfx,
 		},
 	}
+}
 
-	return nil
+// refresh re-advertises routes in response to a ROUTE-REFRESH request. With enhanced route refresh
+// the re-advertisement is enclosed by BoRR and EoRR messages (RFC7313).
+func (u *UpdateSender) refresh(routes []*route.Route, demarcate bool) {
+	u.toSendMu.Lock()
+	defer u.toSendMu.Unlock()
+
+	// Nothing has been advertised during the advertisement delay. All routes are sent once it elapsed.
+	if u._holdAdvertisements() {
+		return
+	}
+
+	if demarcate {
+		u.sendRouteRefreshDemarcation(packet.RouteRefreshBoRR)
+	}
+
+	for _, r := range routes {
+		for _, p := range r.Paths() {
+			u._addPath(r.Prefix(), p)
+		}
+	}
+
+	u._flush()
+
+	if demarcate {
+		u.sendRouteRefreshDemarcation(packet.RouteRefreshEoRR)
+	}
+}
+
+func (u *UpdateSender) sendRouteRefreshDemarcation(subtype uint8) {
+	err := u.fsm.sendRouteRefresh(u.addressFamily.afi, u.addressFamily.safi, subtype)
+	if err != nil {
+		log.Errorf("Failed to send route refresh demarcation: %v", err)
+	}
 }
 
 // Dump is here to fulfill an interface
@@ -103,6 +257,11 @@ func (u *UpdateSender) EndOfRIB() {
 	u.toSendMu.Lock()
 	defer u.toSendMu.Unlock()
 
+	if u._holdAdvertisements() {
+		u.endOfRIBHeld = true
+		return
+	}
+
 	u._flush()
 	u.sendEndOfRIB()
 }
@@ -129,18 +288,38 @@ func (u *UpdateSender) sender(aggrTime time.Duration) {
 		case <-ticker.C:
 		}
 
-		u.toSendMu.Lock()
-		for key, pathNLRIs := range u.toSend {
-			pathAttrs, updatesPrefixes, pathID := u._getUpdateInformation(pathNLRIs)
+		u.sendQueued()
+	}
+}
 
-			delete(u.toSend, key)
-			u.toSendMu.Unlock()
+// sendQueued sends all queued advertisements
+func (u *UpdateSender) sendQueued() {
+	u.toSendMu.Lock()
+	if u._holdAdvertisements() {
+		u.toSendMu.Unlock()
+		return
+	}
 
-			u.sendUpdates(pathAttrs, updatesPrefixes, pathID)
-			u.toSendMu.Lock()
-		}
+	if u.endOfRIBHeld {
+		u.endOfRIBHeld = false
+		u._flush()
+		u.sendEndOfRIB()
+	}
+
+	if u.mrai > 0 {
+		u._releaseMRAIPending()
+	}
+
+	for key, pathNLRIs := range u.toSend {
+		pathAttrs, updatesPrefixes, pathID := u._getUpdateInformation(pathNLRIs)
+
+		delete(u.toSend, key)
 		u.toSendMu.Unlock()
+
+		u.sendUpdates(pathAttrs, updatesPrefixes, pathID, pathNLRIs.path.BGPPath.LabelStack)
+		u.toSendMu.Lock()
 	}
+	u.toSendMu.Unlock()
 }
 
 func (u *UpdateSender) _getUpdateInformation(pathNLRIs *pathPfxs) (*packet.PathAttribute, [][]*bnet.Prefix, uint32) {
@@ -161,6 +340,10 @@ func (u *UpdateSender) _getUpdateInformation(pathNLRIs *pathPfxs) (*packet.PathA
 			budget -= packet.PathIdentifierLen
 		}
 
+		if u.addressFamily.safi == packet.SAFILabeledUnicast {
+			budget -= labelStackLen(pathNLRIs.path.BGPPath.LabelStack)
+		}
+
 		if budget < 0 {
 			updatesPrefixes = append(updatesPrefixes, prefixes)
 			prefixes = make([]*bnet.Prefix, 0, 1)
@@ -181,16 +364,21 @@ func (u *UpdateSender) _flush() {
 		pathAttrs, updatesPrefixes, pathID := u._getUpdateInformation(pathNLRIs)
 		delete(u.toSend, key)
 
-		u.sendUpdates(pathAttrs, updatesPrefixes, pathID)
+		u.sendUpdates(pathAttrs, updatesPrefixes, pathID, pathNLRIs.path.BGPPath.LabelStack)
 	}
 }
 
+// plainIPv4 checks if IPv4 unicast is exchanged without multi protocol extensions
+func (u *UpdateSender) plainIPv4() bool {
+	return u.addressFamily.afi == packet.AFIIPv4 && u.addressFamily.safi == packet.SAFIUnicast && !u.addressFamily.multiProtocol
+}
+
 func (u *UpdateSender) getBudget(pathNLRIs *pathPfxs) int {
-	return packet.MaxLen - packet.HeaderLen - packet.MinUpdateLen - int(pathNLRIs.path.BGPPath.Length()) - u.updateOverhead()
+	return u.options.MaxMessageLen() - packet.HeaderLen - packet.MinUpdateLen - int(pathNLRIs.path.BGPPath.Length()) - u.updateOverhead()
 }
 
 func (u *UpdateSender) updateOverhead() int {
-	if u.addressFamily.afi == packet.AFIIPv4 && !u.addressFamily.multiProtocol {
+	if u.plainIPv4() {
 		return 0
 	}
 
@@ -203,11 +391,20 @@ func (u *UpdateSender) updateOverhead() int {
 	return packet.AFILen + packet.SAFILen + 1 + addrLen - packet.IPv4Len + 1
 }
 
-func (u *UpdateSender) sendUpdates(pathAttrs *packet.PathAttribute, updatePrefixes [][]*bnet.Prefix, pathID uint32) {
+// labelStackLen returns the number of bytes a label stack takes in a labeled unicast NLRI
+func labelStackLen(labels *types.LabelStack) int {
+	if labels == nil || len(*labels) == 0 {
+		return packet.BytesPerLabel
+	}
+
+	return len(*labels) * packet.BytesPerLabel
+}
+
+func (u *UpdateSender) sendUpdates(pathAttrs *packet.PathAttribute, updatePrefixes [][]*bnet.Prefix, pathID uint32, labels *types.LabelStack) {
 	var err error
 
 	for _, prefixes := range updatePrefixes {
-		update := u.updateMessageForPrefixes(prefixes, pathAttrs, pathID)
+		update := u.updateMessageForPrefixes(prefixes, pathAttrs, pathID, labels)
 		if update == nil {
 			log.Errorf("Failed to create update: Neighbor does not support multi protocol.")
 			return
@@ -221,13 +418,13 @@ func (u *UpdateSender) sendUpdates(pathAttrs *packet.PathAttribute, updatePrefix
 	}
 }
 
-func (u *UpdateSender) updateMessageForPrefixes(pfxs []*bnet.Prefix, pa *packet.PathAttribute, pathID uint32) *packet.BGPUpdate {
-	if u.addressFamily.afi == packet.AFIIPv4 && !u.addressFamily.multiProtocol {
+func (u *UpdateSender) updateMessageForPrefixes(pfxs []*bnet.Prefix, pa *packet.PathAttribute, pathID uint32, labels *types.LabelStack) *packet.BGPUpdate {
+	if u.plainIPv4() {
 		return u.bgpUpdate(pfxs, pa, pathID)
 	}
 
 	if u.addressFamily.multiProtocol {
-		return u.bgpUpdateMultiProtocol(pfxs, pa, pathID)
+		return u.bgpUpdateMultiProtocol(pfxs, pa, pathID, labels)
 	}
 
 	return nil
@@ -252,7 +449,7 @@ func (u *UpdateSender) bgpUpdate(pfxs []*bnet.Prefix, pa *packet.PathAttribute,
 	return update
 }
 
-func (u *UpdateSender) bgpUpdateMultiProtocol(pfxs []*bnet.Prefix, pa *packet.PathAttribute, pathID uint32) *packet.BGPUpdate {
+func (u *UpdateSender) bgpUpdateMultiProtocol(pfxs []*bnet.Prefix, pa *packet.PathAttribute, pathID uint32, labels *types.LabelStack) *packet.BGPUpdate {
 	pa, nextHop := u.copyAttributesWithoutNextHop(pa)
 
 	attrs := &packet.PathAttribute{
@@ -261,7 +458,7 @@ func (u *UpdateSender) bgpUpdateMultiProtocol(pfxs []*bnet.Prefix, pa *packet.Pa
 			AFI:     u.addressFamily.afi,
 			SAFI:    u.addressFamily.safi,
 			NextHop: nextHop,
-			NLRI:    u.nlriForPrefixes(pfxs, pathID),
+			NLRI:    u.nlriForPrefixes(pfxs, pathID, labels),
 		},
 	}
 	attrs.Next = pa
@@ -272,12 +469,18 @@ func (u *UpdateSender) bgpUpdateMultiProtocol(pfxs []*bnet.Prefix, pa *packet.Pa
 	}
 }
 
-func (u *UpdateSender) nlriForPrefixes(pfxs []*bnet.Prefix, pathID uint32) *packet.NLRI {
+func (u *UpdateSender) nlriForPrefixes(pfxs []*bnet.Prefix, pathID uint32, labels *types.LabelStack) *packet.NLRI {
+	var labelStack []packet.LabelStackEntry
+	if u.addressFamily.safi == packet.SAFILabeledUnicast {
+		labelStack = packet.LabelStackEntriesFromLabelStack(labels)
+	}
+
 	var prev, res *packet.NLRI
 	for _, pfx := range pfxs {
 		cur := &packet.NLRI{
 			Prefix:         pfx,
 			PathIdentifier: pathID,
+			LabelStack:     labelStack,
 		}
 
 		if res == nil {
@@ -313,8 +516,36 @@ func (u *UpdateSender) copyAttributesWithoutNextHop(pa *packet.PathAttribute) (a
 	return attrs, nextHop
 }
 
-// RemovePath withdraws prefix `pfx` from a peer
+// RemovePath withdraws prefix `pfx` from a peer. Withdrawals are not subject to the MRAI and reset it, so a path
+// replacing the withdrawn one is advertised right away instead of leaving the prefix unreachable during the MRAI.
+// During the advertisement delay nothing has been advertised to the peer yet, so only the queued advertisement is
+// discarded.
 func (u *UpdateSender) RemovePath(pfx *bnet.Prefix, p *route.Path) bool {
+	if p.BGPPath != nil {
+		u.toSendMu.Lock()
+		holding := u._holdAdvertisements()
+		if holding {
+			u._removeQueued(pfx, p.BGPPath.PathIdentifier)
+		}
+		u.toSendMu.Unlock()
+
+		if holding {
+			return true
+		}
+	}
+
+	if u.mrai > 0 && p.BGPPath != nil {
+		k := mraiKey{
+			pfx:    *pfx,
+			pathID: p.BGPPath.PathIdentifier,
+		}
+
+		u.toSendMu.Lock()
+		delete(u.mraiPending, k)
+		delete(u.lastAdvertised, k)
+		u.toSendMu.Unlock()
+	}
+
 	err := u.withdrawPrefix(u.fsm.con, pfx, p)
 	if err != nil {
 		log.Errorf("unable to withdraw prefix: %v", err)
@@ -333,7 +564,7 @@ func (u *UpdateSender) withdrawPrefix(out io.Writer, pfx *bnet.Prefix, p *route.
 		return errors.New("got nil BGPPath")
 	}
 
-	if u.addressFamily.afi == packet.AFIIPv4 && !u.addressFamily.multiProtocol {
+	if u.plainIPv4() {
 		return u.withdrawPrefixIPv4(out, pfx, p)
 	}
 
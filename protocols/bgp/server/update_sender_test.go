@@ -15,6 +15,7 @@ import (
 	"github.com/bio-routing/bio-rd/routingtable/filter"
 	"github.com/bio-routing/bio-rd/routingtable/locRIB"
 	btest "github.com/bio-routing/bio-rd/testing"
+	btime "github.com/bio-routing/bio-rd/util/time"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -78,13 +79,13 @@ func TestSender(t *testing.T) {
 					255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
 					0, 52,
 					2,
-					0, 0, 0, 21, 64, 2, 0, 64, 1, 1, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 200,
+					0, 0, 0, 21, 64, 1, 1, 0, 64, 2, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 200,
 					8, 23, 8, 22, 8, 21, 8, 20,
 
 					255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
 					0, 55,
 					2,
-					0, 0, 0, 21, 64, 2, 0, 64, 1, 1, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
+					0, 0, 0, 21, 64, 1, 1, 0, 64, 2, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
 					32, 13, 0, 0, 0, 8, 12, 8, 11, 8, 10,
 
 					255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
@@ -94,13 +95,13 @@ func TestSender(t *testing.T) {
 					255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
 					0, 55,
 					2,
-					0, 0, 0, 21, 64, 2, 0, 64, 1, 1, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
+					0, 0, 0, 21, 64, 1, 1, 0, 64, 2, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
 					32, 13, 0, 0, 0, 8, 12, 8, 11, 8, 10,
 
 					255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
 					0, 52,
 					2,
-					0, 0, 0, 21, 64, 2, 0, 64, 1, 1, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 200,
+					0, 0, 0, 21, 64, 1, 1, 0, 64, 2, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 200,
 					8, 23, 8, 22, 8, 21, 8, 20,
 
 					255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
@@ -157,26 +158,26 @@ func TestSender(t *testing.T) {
 					255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
 					0, 52,
 					2,
-					0, 0, 0, 21, 64, 2, 0, 64, 1, 1, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 200,
+					0, 0, 0, 21, 64, 1, 1, 0, 64, 2, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 200,
 					8, 23, 8, 22, 8, 21, 8, 20,
 
 					255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
 					0, 55,
 					2,
-					0, 0, 0, 21, 64, 2, 0, 64, 1, 1, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
+					0, 0, 0, 21, 64, 1, 1, 0, 64, 2, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
 					32, 13, 0, 0, 0, 8, 12, 8, 11, 8, 10,
 				},
 				{
 					255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
 					0, 55,
 					2,
-					0, 0, 0, 21, 64, 2, 0, 64, 1, 1, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
+					0, 0, 0, 21, 64, 1, 1, 0, 64, 2, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
 					32, 13, 0, 0, 0, 8, 12, 8, 11, 8, 10,
 
 					255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
 					0, 52,
 					2,
-					0, 0, 0, 21, 64, 2, 0, 64, 1, 1, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 200,
+					0, 0, 0, 21, 64, 1, 1, 0, 64, 2, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 200,
 					8, 23, 8, 22, 8, 21, 8, 20,
 				},
 			},
@@ -231,7 +232,7 @@ func TestSender(t *testing.T) {
 					0, 71,
 					2,
 					0, 0,
-					0, 21, 64, 2, 0, 64, 1, 1, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
+					0, 21, 64, 1, 1, 0, 64, 2, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
 					0, 0, 0, 0, 32, 13, 0, 0, 0,
 					0, 0, 0, 0, 8, 12,
 					0, 0, 0, 0, 8, 11,
@@ -241,7 +242,7 @@ func TestSender(t *testing.T) {
 					0, 68,
 					2,
 					0, 0,
-					0, 21, 64, 2, 0, 64, 1, 1, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 200,
+					0, 21, 64, 1, 1, 0, 64, 2, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 200,
 					0, 0, 0, 0, 8, 23,
 					0, 0, 0, 0, 8, 22,
 					0, 0, 0, 0, 8, 21,
@@ -252,7 +253,7 @@ func TestSender(t *testing.T) {
 					0, 68,
 					2,
 					0, 0,
-					0, 21, 64, 2, 0, 64, 1, 1, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 200,
+					0, 21, 64, 1, 1, 0, 64, 2, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 200,
 					0, 0, 0, 0, 8, 23,
 					0, 0, 0, 0, 8, 22,
 					0, 0, 0, 0, 8, 21,
@@ -262,7 +263,7 @@ func TestSender(t *testing.T) {
 					0, 71,
 					2,
 					0, 0,
-					0, 21, 64, 2, 0, 64, 1, 1, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
+					0, 21, 64, 1, 1, 0, 64, 2, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
 					0, 0, 0, 0, 32, 13, 0, 0, 0,
 					0, 0, 0, 0, 8, 12,
 					0, 0, 0, 0, 8, 11,
@@ -293,7 +294,7 @@ func TestSender(t *testing.T) {
 			expectedUpdates: [][]byte{
 				{
 					255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
-					15, 239, 2, 0, 0, 0, 21, 64, 2, 0, 64, 1, 1, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
+					15, 239, 2, 0, 0, 0, 21, 64, 1, 1, 0, 64, 2, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
 					32, 10, 0, 3, 35, 32, 10, 0, 3, 34, 32, 10, 0, 3, 33, 32, 10, 0, 3, 32, 32, 10, 0, 3, 31, 32, 10, 0, 3, 30, 32, 10, 0, 3, 29,
 					32, 10, 0, 3, 28, 32, 10, 0, 3, 27, 32, 10, 0, 3, 26, 32, 10, 0, 3, 25, 32, 10, 0, 3, 24, 32, 10, 0, 3, 23, 32, 10, 0, 3, 22,
 					32, 10, 0, 3, 21, 32, 10, 0, 3, 20, 32, 10, 0, 3, 19, 32, 10, 0, 3, 18, 32, 10, 0, 3, 17, 32, 10, 0, 3, 16, 32, 10, 0, 3, 15,
@@ -412,7 +413,7 @@ func TestSender(t *testing.T) {
 					255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255, 255,
 					3, 241,
 					2, 0, 0, 0, 21,
-					64, 2, 0, 64, 1, 1, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
+					64, 1, 1, 0, 64, 2, 0, 64, 3, 4, 0, 0, 0, 0, 64, 5, 4, 0, 0, 0, 100,
 					32, 10, 0, 3, 228, 32, 10, 0, 3, 227, 32, 10, 0, 3, 226, 32, 10, 0, 3, 225, 32, 10, 0, 3, 224, 32, 10, 0, 3, 223, 32, 10, 0, 3, 222,
 					32, 10, 0, 3, 221, 32, 10, 0, 3, 220, 32, 10, 0, 3, 219, 32, 10, 0, 3, 218, 32, 10, 0, 3, 217, 32, 10, 0, 3, 216, 32, 10, 0, 3, 215,
 					32, 10, 0, 3, 214, 32, 10, 0, 3, 213, 32, 10, 0, 3, 212, 32, 10, 0, 3, 211, 32, 10, 0, 3, 210, 32, 10, 0, 3, 209, 32, 10, 0, 3, 208,
@@ -466,9 +467,10 @@ func TestSender(t *testing.T) {
 			generateNLRIs: 1000,
 			expectedUpdates: [][]byte{
 				{
-					0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
-					0xff, 0xff, 0xff, 0xf, 0xf0, 0x2, 0x0, 0x0, 0xf, 0xd9, 0x90, 0xe, 0xf, 0xc7,
-					0x0, 0x2, 0x1, 0x10, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x0, 0x0, 0x0, 0x0, 0x0,
+					0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+					0xff, 0xff, 0xf, 0xf0, 0x2, 0x0, 0x0, 0xf, 0xd9, 0x40, 0x1, 0x1, 0x0, 0x40,
+					0x2, 0x0, 0x40, 0x5, 0x4, 0x0, 0x0, 0x0, 0x64, 0x90, 0xe, 0xf, 0xc7, 0x0,
+					0x2, 0x1, 0x10, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x0, 0x0, 0x0, 0x0, 0x0,
 					0x0, 0x0, 0x0, 0x0, 0x2, 0x0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30,
 					0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30,
 					0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30,
@@ -756,16 +758,11 @@ func TestSender(t *testing.T) {
 					0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30,
 					0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30,
 					0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30,
-					0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x40, 0x2, 0x0, 0x40, 0x1, 0x1, 0x0, 0x40,
-					0x5, 0x4, 0x0, 0x0, 0x0, 0x64, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
+					0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff,
 					0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xb, 0xe4, 0x2, 0x0, 0x0, 0xb,
-					0xcd, 0x90, 0xe, 0xb, 0xbb, 0x0, 0x2, 0x1, 0x10, 0x20, 0x1, 0x6, 0x78, 0x1,
-					0xe0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x2, 0x0, 0x30, 0x20, 0x1,
-					0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1,
-					0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1,
-					0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1,
-					0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1,
-					0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20,
+					0xcd, 0x40, 0x1, 0x1, 0x0, 0x40, 0x2, 0x0, 0x40, 0x5, 0x4, 0x0, 0x0, 0x0,
+					0x64, 0x90, 0xe, 0xb, 0xbb, 0x0, 0x2, 0x1, 0x10, 0x20, 0x1, 0x6, 0x78, 0x1,
+					0xe0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x2, 0x0, 0x30, 0x20,
 					0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20,
 					0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20,
 					0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20,
@@ -973,8 +970,12 @@ func TestSender(t *testing.T) {
 					0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20,
 					0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20,
 					0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20,
-					0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x40, 0x2,
-					0x0, 0x40, 0x1, 0x1, 0x0, 0x40, 0x5, 0x4, 0x0, 0x0, 0x0, 0x64,
+					0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20,
+					0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20,
+					0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20,
+					0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20,
+					0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20,
+					0x1, 0x6, 0x78, 0x1, 0xe0, 0x30, 0x20, 0x1, 0x6, 0x78, 0x1, 0xe0,
 				},
 			},
 		},
@@ -1003,7 +1004,7 @@ func TestSender(t *testing.T) {
 			fsmA.ipv4Unicast.addPathTX = test.addPath
 		}
 
-		fsmA.keepaliveTimer = time.NewTimer(time.Second * 30)
+		fsmA.keepaliveTimer = fsmA.clock.NewTimer(time.Second * 30)
 		fsmA.connectRetryTimer = time.NewTimer(time.Second * 120)
 		fsmA.state = newEstablishedState(fsmA)
 		fsmA.con = btest.NewMockConn()
@@ -1234,3 +1235,329 @@ func TestWithdrawPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestGetUpdateInformationExtendedMessage(t *testing.T) {
+	tests := []struct {
+		name            string
+		extendedMessage bool
+		expectedUpdates int
+	}{
+		{
+			name:            "standard message size",
+			expectedUpdates: 2,
+		},
+		{
+			name:            "extended message size",
+			extendedMessage: true,
+			expectedUpdates: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fsm := newFSM(&peer{
+				addr: bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+			})
+			fsm.extendedMessage = test.extendedMessage
+			fsm.ipv4Unicast = newFSMAddressFamily(packet.AFIIPv4, packet.SAFIUnicast, &peerAddressFamily{
+				rib:               locRIB.New("inet.0"),
+				importFilterChain: filter.NewAcceptAllFilterChain(),
+				exportFilterChain: filter.NewAcceptAllFilterChain(),
+			}, fsm)
+
+			u := newUpdateSender(fsm.ipv4Unicast)
+
+			pathPfxs := &pathPfxs{
+				path: &route.Path{
+					Type: route.BGPPathType,
+					BGPPath: &route.BGPPath{
+						BGPPathA: &route.BGPPathA{
+							NextHop: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+						},
+						ASPath: &types.ASPath{},
+					},
+				},
+			}
+
+			// 1000 /32 prefixes need 5000 bytes of NLRI
+			for i := 0; i < 1000; i++ {
+				pathPfxs.pfxs = append(pathPfxs.pfxs, bnet.NewPfx(bnet.IPv4FromOctets(10, 1, uint8(i/256), uint8(i%256)), 32).Ptr())
+			}
+
+			_, updatesPrefixes, _ := u._getUpdateInformation(pathPfxs)
+			assert.Equal(t, test.expectedUpdates, len(updatesPrefixes))
+		})
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	tests := []struct {
+		name             string
+		demarcate        bool
+		expected         []uint8
+		expectedSubtypes []uint8
+	}{
+		{
+			name:             "route refresh",
+			expected:         []uint8{packet.UpdateMsg},
+			expectedSubtypes: []uint8{},
+		},
+		{
+			name:             "enhanced route refresh",
+			demarcate:        true,
+			expected:         []uint8{packet.RouteRefreshMsg, packet.UpdateMsg, packet.RouteRefreshMsg},
+			expectedSubtypes: []uint8{packet.RouteRefreshBoRR, packet.RouteRefreshEoRR},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fsm := newFSM(&peer{
+				addr: bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+			})
+			fsm.con = btest.NewMockConn()
+			fsm.ipv4Unicast = newFSMAddressFamily(packet.AFIIPv4, packet.SAFIUnicast, &peerAddressFamily{
+				rib:               locRIB.New("inet.0"),
+				importFilterChain: filter.NewAcceptAllFilterChain(),
+				exportFilterChain: filter.NewAcceptAllFilterChain(),
+			}, fsm)
+
+			p := &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{
+						NextHop: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+						Source:  bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+					},
+					ASPath: &types.ASPath{},
+				},
+			}
+
+			u := newUpdateSender(fsm.ipv4Unicast)
+			u.refresh([]*route.Route{
+				route.NewRoute(bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), p),
+				route.NewRoute(bnet.NewPfx(bnet.IPv4FromOctets(11, 0, 0, 0), 8).Ptr(), p),
+			}, test.demarcate)
+			assert.Empty(t, u.toSend, "re-advertisement must be sent before EoRR")
+
+			buf := fsm.con.(*btest.MockConn).Buf
+			msgTypes := make([]uint8, 0)
+			subtypes := make([]uint8, 0)
+			for buf.Len() > 0 {
+				msg, err := packet.Decode(buf, &packet.DecodeOptions{})
+				assert.NoError(t, err)
+				msgTypes = append(msgTypes, msg.Header.Type)
+
+				if msg.Header.Type == packet.UpdateMsg {
+					n := 0
+					for nlri := msg.Body.(*packet.BGPUpdate).NLRI; nlri != nil; nlri = nlri.Next {
+						n++
+					}
+					assert.Equal(t, 2, n)
+				}
+
+				if msg.Header.Type == packet.RouteRefreshMsg {
+					rr := msg.Body.(*packet.BGPRouteRefresh)
+					assert.Equal(t, uint16(packet.AFIIPv4), rr.AFI)
+					assert.Equal(t, uint8(packet.SAFIUnicast), rr.SAFI)
+					subtypes = append(subtypes, rr.Subtype)
+				}
+			}
+
+			assert.Equal(t, test.expected, msgTypes)
+			assert.Equal(t, test.expectedSubtypes, subtypes)
+		})
+	}
+}
+
+func TestMRAI(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	fsm := newFSM(&peer{
+		addr: bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+		mrai: 30 * time.Second,
+	})
+	fsm.clock = clock
+	fsm.con = btest.NewMockConn()
+	fsm.ipv4Unicast = newFSMAddressFamily(packet.AFIIPv4, packet.SAFIUnicast, &peerAddressFamily{
+		rib:               locRIB.New("inet.0"),
+		importFilterChain: filter.NewAcceptAllFilterChain(),
+		exportFilterChain: filter.NewAcceptAllFilterChain(),
+	}, fsm)
+
+	newPath := func(nh uint8) *route.Path {
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				BGPPathA: &route.BGPPathA{
+					NextHop: bnet.IPv4FromOctets(10, 0, 0, nh).Ptr(),
+					Source:  bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+				},
+				ASPath: &types.ASPath{},
+			},
+		}
+	}
+
+	// sent returns the next hops advertised and the number of withdrawals sent since the last call
+	buf := fsm.con.(*btest.MockConn).Buf
+	sent := func() ([]uint8, int) {
+		nextHops := make([]uint8, 0)
+		withdraws := 0
+		for buf.Len() > 0 {
+			msg, err := packet.Decode(buf, &packet.DecodeOptions{})
+			assert.NoError(t, err)
+
+			update := msg.Body.(*packet.BGPUpdate)
+			if update.WithdrawnRoutes != nil {
+				withdraws++
+				continue
+			}
+
+			for pa := update.PathAttributes; pa != nil; pa = pa.Next {
+				if pa.TypeCode == packet.NextHopAttr {
+					nextHops = append(nextHops, pa.Value.(*bnet.IP).Bytes()[3])
+				}
+			}
+		}
+
+		return nextHops, withdraws
+	}
+
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	u := newUpdateSender(fsm.ipv4Unicast)
+
+	// The first advertisement is not delayed
+	u.AddPath(pfx, newPath(1))
+	u.AddPath(pfx, newPath(2))
+	u.AddPath(pfx, newPath(3))
+	u.sendQueued()
+	nextHops, _ := sent()
+	assert.Equal(t, []uint8{1}, nextHops)
+
+	clock.Advance(29 * time.Second)
+	u.sendQueued()
+	nextHops, _ = sent()
+	assert.Equal(t, []uint8{}, nextHops, "re-advertisement within MRAI")
+
+	// Only the latest path is sent once the MRAI elapsed
+	clock.Advance(time.Second)
+	u.sendQueued()
+	nextHops, _ = sent()
+	assert.Equal(t, []uint8{3}, nextHops)
+
+	// Withdrawals are sent immediately and discard held back advertisements
+	u.AddPath(pfx, newPath(4))
+	u.RemovePath(pfx, newPath(4))
+	nextHops, withdraws := sent()
+	assert.Equal(t, []uint8{}, nextHops)
+	assert.Equal(t, 1, withdraws)
+
+	// A path replacing a withdrawn one is not held back
+	u.AddPath(pfx, newPath(5))
+	u.sendQueued()
+	nextHops, _ = sent()
+	assert.Equal(t, []uint8{5}, nextHops)
+
+	clock.Advance(time.Minute)
+	u.sendQueued()
+	nextHops, _ = sent()
+	assert.Equal(t, []uint8{}, nextHops)
+	assert.Empty(t, u.lastAdvertised)
+}
+
+func TestAdvertisementDelay(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	fsm := newFSM(&peer{
+		addr:     bnet.IPv4FromOctets(169, 254, 100, 100).Ptr(),
+		advDelay: 10 * time.Second,
+	})
+	fsm.clock = clock
+	fsm.con = btest.NewMockConn()
+	fsm.ipv4Unicast = newFSMAddressFamily(packet.AFIIPv4, packet.SAFIUnicast, &peerAddressFamily{
+		rib:               locRIB.New("inet.0"),
+		importFilterChain: filter.NewAcceptAllFilterChain(),
+		exportFilterChain: filter.NewAcceptAllFilterChain(),
+	}, fsm)
+
+	newPath := func(nh uint8) *route.Path {
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				BGPPathA: &route.BGPPathA{
+					NextHop: bnet.IPv4FromOctets(10, 0, 0, nh).Ptr(),
+					Source:  bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+				},
+				ASPath: &types.ASPath{},
+			},
+		}
+	}
+
+	// sent returns the prefixes advertised, the number of withdrawals and if an End-of-RIB marker was sent since the last call
+	buf := fsm.con.(*btest.MockConn).Buf
+	sent := func() ([]*bnet.Prefix, int, bool) {
+		pfxs := make([]*bnet.Prefix, 0)
+		withdraws := 0
+		endOfRIB := false
+		for buf.Len() > 0 {
+			msg, err := packet.Decode(buf, &packet.DecodeOptions{})
+			assert.NoError(t, err)
+
+			update := msg.Body.(*packet.BGPUpdate)
+			if update.IsEndOfRIBMarker() {
+				endOfRIB = true
+				continue
+			}
+
+			if update.WithdrawnRoutes != nil {
+				withdraws++
+				continue
+			}
+
+			for n := update.NLRI; n != nil; n = n.Next {
+				pfxs = append(pfxs, n.Prefix)
+			}
+		}
+
+		return pfxs, withdraws, endOfRIB
+	}
+
+	pfxA := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	pfxB := bnet.NewPfx(bnet.IPv4FromOctets(192, 168, 0, 0), 16).Ptr()
+	u := newUpdateSender(fsm.ipv4Unicast)
+
+	// The initial dump is held back
+	u.AddPathInitialDump(pfxA, newPath(1))
+	u.AddPathInitialDump(pfxB, newPath(1))
+	u.EndOfRIB()
+	u.refresh([]*route.Route{route.NewRoute(pfxA, newPath(1))}, false)
+	u.sendQueued()
+	pfxs, _, endOfRIB := sent()
+	assert.Empty(t, pfxs, "advertisements within the delay")
+	assert.False(t, endOfRIB, "End-of-RIB marker within the delay")
+
+	// Withdrawals within the delay discard the queued advertisement
+	u.RemovePath(pfxB, newPath(1))
+	clock.Advance(9 * time.Second)
+	u.sendQueued()
+	pfxs, withdraws, endOfRIB := sent()
+	assert.Empty(t, pfxs)
+	assert.Equal(t, 0, withdraws)
+	assert.False(t, endOfRIB)
+
+	// Everything is sent at once when the delay elapsed
+	clock.Advance(time.Second)
+	u.sendQueued()
+	pfxs, _, endOfRIB = sent()
+	assert.Equal(t, []*bnet.Prefix{pfxA}, pfxs)
+	assert.True(t, endOfRIB)
+
+	// Later changes are advertised without delay
+	u.AddPath(pfxB, newPath(2))
+	u.sendQueued()
+	pfxs, _, endOfRIB = sent()
+	assert.Equal(t, []*bnet.Prefix{pfxB}, pfxs)
+	assert.False(t, endOfRIB)
+
+	u.RemovePath(pfxB, newPath(2))
+	_, withdraws, _ = sent()
+	assert.Equal(t, 1, withdraws)
+}
@@ -1,7 +1,10 @@
 package types
 
-// Aggregator represents an AGGREGATOR attribute (type code 7) as in RFC4271
+// ASTrans is the reserved 2 octet ASN standing in for 4 octet ASNs towards BGP speakers not supporting them (RFC6793)
+const ASTrans = 23456
+
+// Aggregator represents an AGGREGATOR attribute (type code 7) as in RFC4271. The ASN is 4 octets long (RFC6793).
 type Aggregator struct {
 	Address uint32
-	ASN     uint16
+	ASN     uint32
 }
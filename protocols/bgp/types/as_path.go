@@ -15,6 +15,12 @@ const (
 	// ASSequence is tha AS Path type used to indicate an AS Sequence (RFC4271)
 	ASSequence = 2
 
+	// ASConfedSequence is the AS Path type used to indicate an ordered set of Member ASNs of a confederation (RFC5065)
+	ASConfedSequence = 3
+
+	// ASConfedSet is the AS Path type used to indicate an unordered set of Member ASNs of a confederation (RFC5065)
+	ASConfedSet = 4
+
 	// MaxASNsSegment is the maximum number of ASNs in an AS segment
 	MaxASNsSegment = 255
 )
@@ -120,10 +126,14 @@ func (pa ASPath) ToProto() []*api.ASPathSegment {
 			Asns: make([]uint32, len(pa[i].ASNs)),
 		}
 
-		if pa[i].Type == ASSequence {
+		if pa[i].Type == ASSequence || pa[i].Type == ASConfedSequence {
 			ret[i].AsSequence = true
 		}
 
+		if pa[i].isConfed() {
+			ret[i].Confed = true
+		}
+
 		copy(ret[i].Asns, pa[i].ASNs)
 	}
 
@@ -140,8 +150,13 @@ func ASPathFromProtoASPath(segments []*api.ASPathSegment) *ASPath {
 			ASNs: make([]uint32, len(segments[i].Asns)),
 		}
 
-		if segments[i].AsSequence {
+		switch {
+		case segments[i].AsSequence && segments[i].Confed:
+			s.Type = ASConfedSequence
+		case segments[i].AsSequence:
 			s.Type = ASSequence
+		case segments[i].Confed:
+			s.Type = ASConfedSet
 		}
 
 		copy(s.ASNs, segments[i].Asns)
@@ -169,27 +184,112 @@ func (a *ASPath) String() string {
 			continue
 		}
 
-		if p.Type == ASSet {
-			setParts := make([]string, len(p.ASNs))
-			for i, asn := range p.ASNs {
-				setParts[i] = strconv.Itoa(int(asn))
-			}
+		setParts := make([]string, len(p.ASNs))
+		for i, asn := range p.ASNs {
+			setParts[i] = strconv.Itoa(int(asn))
+		}
+
+		switch p.Type {
+		case ASSet:
 			parts = append(parts, "("+strings.Join(setParts, " ")+")")
+		case ASConfedSequence:
+			parts = append(parts, "["+strings.Join(setParts, " ")+"]")
+		case ASConfedSet:
+			parts = append(parts, "{"+strings.Join(setParts, " ")+"}")
 		}
 	}
 
 	return strings.Join(parts, " ")
 }
 
-// Length returns the AS path length as used by path selection
+// IsPrivateASN checks if asn is reserved for private use (RFC6996)
+func IsPrivateASN(asn uint32) bool {
+	return (asn >= 64512 && asn <= 65534) || (asn >= 4200000000 && asn <= 4294967294)
+}
+
+// ContainsPrivateASN checks if the AS path contains a private ASN. The left most ASN of the path (the neighbor) is not
+// considered wherever it appears, e.g. when prepended by the neighbor.
+func (pa ASPath) ContainsPrivateASN() bool {
+	neighbor := pa.neighborASN()
+	for _, seg := range pa {
+		for _, asn := range seg.ASNs {
+			if IsPrivateASN(asn) && (neighbor == nil || asn != *neighbor) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// neighborASN gets the left most ASN of the path
+func (pa ASPath) neighborASN() *uint32 {
+	if len(pa) == 0 {
+		return nil
+	}
+
+	return pa[0].GetFirstASN()
+}
+
+// RemovePrivateASNs returns a copy of the AS path without private ASNs except the left most ASN of the path (the
+// neighbor) wherever it appears. Segments left empty are removed.
+func (pa ASPath) RemovePrivateASNs() ASPath {
+	neighbor := pa.neighborASN()
+	ret := make(ASPath, 0, len(pa))
+	for _, seg := range pa {
+		asns := make([]uint32, 0, len(seg.ASNs))
+		for _, asn := range seg.ASNs {
+			if IsPrivateASN(asn) && (neighbor == nil || asn != *neighbor) {
+				continue
+			}
+
+			asns = append(asns, asn)
+		}
+
+		if len(asns) == 0 {
+			continue
+		}
+
+		ret = append(ret, ASPathSegment{
+			Type: seg.Type,
+			ASNs: asns,
+		})
+	}
+
+	return ret
+}
+
+// Length returns the AS path length as used by path selection. An AS_SET counts as one regardless of the number of
+// its members (RFC4271), confederation segments are not counted at all (RFC5065).
 func (pa ASPath) Length() (ret uint16) {
 	for _, p := range pa {
-		if p.Type == ASSet {
+		switch p.Type {
+		case ASSet:
 			ret++
-			continue
+		case ASConfedSequence, ASConfedSet:
+		default:
+			ret += uint16(len(p.ASNs))
 		}
-		ret += uint16(len(p.ASNs))
 	}
 
 	return
 }
+
+// RemoveConfedSegments returns a copy of the AS path without confederation segments. They must not be sent to peers
+// outside of the confederation (RFC5065).
+func (pa ASPath) RemoveConfedSegments() ASPath {
+	ret := make(ASPath, 0, len(pa))
+	for _, seg := range pa {
+		if seg.isConfed() {
+			continue
+		}
+
+		ret = append(ret, seg)
+	}
+
+	return ret
+}
+
+func (s ASPathSegment) isConfed() bool {
+	return s.Type == ASConfedSequence || s.Type == ASConfedSet
+}
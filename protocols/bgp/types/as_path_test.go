@@ -331,6 +331,23 @@ func TestASPathString(t *testing.T) {
 				},
 			},
 			expected: "(1 2)",
+		}, {
+			name: "test confed segments",
+			asPath: &ASPath{
+				ASPathSegment{
+					Type: ASConfedSequence,
+					ASNs: []uint32{65001, 65002},
+				},
+				ASPathSegment{
+					Type: ASConfedSet,
+					ASNs: []uint32{65003, 65004},
+				},
+				ASPathSegment{
+					Type: ASSequence,
+					ASNs: []uint32{3},
+				},
+			},
+			expected: "[65001 65002] {65003 65004} 3",
 		}, {
 			name:     "test empty",
 			asPath:   &ASPath{},
@@ -352,22 +369,141 @@ func TestASPathStringNil(t *testing.T) {
 }
 
 func TestASPathLength(t *testing.T) {
-	a := &ASPath{
+	tests := []struct {
+		name     string
+		asPath   ASPath
+		expected uint16
+	}{
+		{
+			name: "Sequences and Set",
+			asPath: ASPath{
+				ASPathSegment{
+					Type: ASSequence,
+					ASNs: []uint32{3, 4},
+				},
+				ASPathSegment{
+					Type: ASSequence,
+					ASNs: []uint32{5, 6},
+				},
+				ASPathSegment{
+					Type: ASSet,
+					ASNs: []uint32{1, 2},
+				},
+			},
+			expected: 5,
+		},
+		{
+			name: "Set with many members",
+			asPath: ASPath{
+				ASPathSegment{
+					Type: ASSet,
+					ASNs: []uint32{1, 2, 3, 4, 5},
+				},
+			},
+			expected: 1,
+		},
+		{
+			name: "Confed segments",
+			asPath: ASPath{
+				ASPathSegment{
+					Type: ASConfedSequence,
+					ASNs: []uint32{65001, 65002},
+				},
+				ASPathSegment{
+					Type: ASConfedSet,
+					ASNs: []uint32{65003, 65004},
+				},
+				ASPathSegment{
+					Type: ASSequence,
+					ASNs: []uint32{3, 4},
+				},
+			},
+			expected: 2,
+		},
+		{
+			name: "Confed segments and Set",
+			asPath: ASPath{
+				ASPathSegment{
+					Type: ASConfedSequence,
+					ASNs: []uint32{65001},
+				},
+				ASPathSegment{
+					Type: ASSequence,
+					ASNs: []uint32{3, 4, 5},
+				},
+				ASPathSegment{
+					Type: ASSet,
+					ASNs: []uint32{6, 7, 8},
+				},
+			},
+			expected: 4,
+		},
+		{
+			name: "Confed segments only",
+			asPath: ASPath{
+				ASPathSegment{
+					Type: ASConfedSequence,
+					ASNs: []uint32{65001, 65002, 65003},
+				},
+			},
+			expected: 0,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, test.asPath.Length(), test.name)
+	}
+}
+
+func TestASPathRemoveConfedSegments(t *testing.T) {
+	a := ASPath{
+		ASPathSegment{
+			Type: ASConfedSequence,
+			ASNs: []uint32{65001, 65002},
+		},
 		ASPathSegment{
 			Type: ASSequence,
 			ASNs: []uint32{3, 4},
 		},
+		ASPathSegment{
+			Type: ASConfedSet,
+			ASNs: []uint32{65003},
+		},
+		ASPathSegment{
+			Type: ASSet,
+			ASNs: []uint32{5, 6},
+		},
+	}
 
+	assert.Equal(t, ASPath{
 		ASPathSegment{
 			Type: ASSequence,
-			ASNs: []uint32{5, 6},
+			ASNs: []uint32{3, 4},
 		},
 		ASPathSegment{
 			Type: ASSet,
-			ASNs: []uint32{1, 2},
+			ASNs: []uint32{5, 6},
 		},
+	}, a.RemoveConfedSegments())
+	assert.Len(t, a, 4)
+}
+
+func TestIsPrivateASN(t *testing.T) {
+	tests := []struct {
+		asn      uint32
+		expected bool
+	}{
+		{asn: 64511},
+		{asn: 64512, expected: true},
+		{asn: 65534, expected: true},
+		{asn: 65535},
+		{asn: 4199999999},
+		{asn: 4200000000, expected: true},
+		{asn: 4294967294, expected: true},
+		{asn: 4294967295},
 	}
 
-	actual := a.Length()
-	assert.Equal(t, uint16(5), actual)
+	for _, test := range tests {
+		assert.Equal(t, test.expected, IsPrivateASN(test.asn), "ASN %d", test.asn)
+	}
 }
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -11,6 +12,10 @@ const (
 	WellKnownCommunityNoExport = 0xFFFFFF01
 	// WellKnownCommunityNoAdvertise is the well known no advertise BGP community (RFC1997)
 	WellKnownCommunityNoAdvertise = 0xFFFFFF02
+	// WellKnownCommunityNoExportSubConfed is the well known no export subconfed BGP community (RFC1997)
+	WellKnownCommunityNoExportSubConfed = 0xFFFFFF03
+	// WellKnownCommunityBlackhole is the well known BLACKHOLE community 65535:666 (RFC7999)
+	WellKnownCommunityBlackhole = 0xFFFF029A
 )
 
 // CommunityStringForUint32 transforms a community into a human readable representation
@@ -21,10 +26,13 @@ func CommunityStringForUint32(v uint32) string {
 	return fmt.Sprintf("(%d,%d)", e1, e2)
 }
 
-// ParseCommunityString parses human readable community representation
+// ParseCommunityString parses human readable community representation, e.g. (65535,666) or 65535:666
 func ParseCommunityString(s string) (uint32, error) {
 	s = strings.Trim(s, "()")
 	t := strings.Split(s, ",")
+	if len(t) == 1 {
+		t = strings.Split(s, ":")
+	}
 
 	if len(t) != 2 {
 		return 0, fmt.Errorf("can not parse community %s", s)
@@ -59,3 +67,33 @@ func (c *Communities) String() string {
 
 	return strings.Join(cStrings, " ")
 }
+
+// Normalize sorts the communities in ascending order and removes duplicates so equal sets have equal representations
+func (c *Communities) Normalize() {
+	if c == nil || c.normalized() {
+		return
+	}
+
+	sort.Slice(*c, func(i, j int) bool {
+		return (*c)[i] < (*c)[j]
+	})
+
+	ret := (*c)[:1]
+	for _, x := range (*c)[1:] {
+		if x != ret[len(ret)-1] {
+			ret = append(ret, x)
+		}
+	}
+
+	*c = ret
+}
+
+func (c *Communities) normalized() bool {
+	for i := 1; i < len(*c); i++ {
+		if (*c)[i-1] >= (*c)[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -58,6 +58,11 @@ func TestParseCommunityString(t *testing.T) {
 			expected: 131072,
 			value:    "(2,0)",
 		},
+		{
+			name:     "colon notation",
+			expected: WellKnownCommunityBlackhole,
+			value:    "65535:666",
+		},
 		{
 			name:     "too big",
 			value:    "(131072,256)",
@@ -126,3 +131,37 @@ func TestCommunityToString(t *testing.T) {
 		})
 	}
 }
+
+func TestCommunitiesNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    *Communities
+		expected *Communities
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:     "empty",
+			value:    &Communities{},
+			expected: &Communities{},
+		},
+		{
+			name:     "already normalized",
+			value:    &Communities{100, 200},
+			expected: &Communities{100, 200},
+		},
+		{
+			name:     "unordered with duplicates",
+			value:    &Communities{300, 100, 300, 200, 100},
+			expected: &Communities{100, 200, 300},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.value.Normalize()
+			assert.Equal(t, test.expected, test.value)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Reserved MPLS label values (RFC3032)
+const (
+	LabelIPv4ExplicitNull = 0
+	LabelIPv6ExplicitNull = 2
+	LabelImplicitNull     = 3
+)
+
+// LabelStack is the stack of MPLS labels of a labeled unicast route (RFC8277). The first label is the top of the stack.
+type LabelStack []uint32
+
+// Copy creates a deep copy of a LabelStack
+func (l *LabelStack) Copy() *LabelStack {
+	if l == nil {
+		return nil
+	}
+
+	cp := make(LabelStack, len(*l))
+	copy(cp, *l)
+
+	return &cp
+}
+
+// Compare checks if two LabelStacks are equal
+func (l *LabelStack) Compare(x *LabelStack) bool {
+	if l == nil || x == nil {
+		return l == x
+	}
+
+	if len(*l) != len(*x) {
+		return false
+	}
+
+	for i := range *l {
+		if (*l)[i] != (*x)[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// String returns a human readable representation of a LabelStack
+func (l *LabelStack) String() string {
+	if l == nil {
+		return ""
+	}
+
+	s := make([]string, len(*l))
+	for i, label := range *l {
+		s[i] = labelString(label)
+	}
+
+	return strings.Join(s, " ")
+}
+
+func labelString(label uint32) string {
+	switch label {
+	case LabelIPv4ExplicitNull:
+		return "ipv4-explicit-null"
+	case LabelIPv6ExplicitNull:
+		return "ipv6-explicit-null"
+	case LabelImplicitNull:
+		return "implicit-null"
+	}
+
+	return strconv.Itoa(int(label))
+}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelStackString(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    *LabelStack
+		expected string
+	}{
+		{
+			name:     "nil",
+			value:    nil,
+			expected: "",
+		},
+		{
+			name:     "one label",
+			value:    &LabelStack{299824},
+			expected: "299824",
+		},
+		{
+			name:     "two labels",
+			value:    &LabelStack{299824, 16001},
+			expected: "299824 16001",
+		},
+		{
+			name:     "reserved labels",
+			value:    &LabelStack{LabelIPv4ExplicitNull, LabelIPv6ExplicitNull, LabelImplicitNull},
+			expected: "ipv4-explicit-null ipv6-explicit-null implicit-null",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(te *testing.T) {
+			assert.Equal(te, test.expected, test.value.String())
+		})
+	}
+}
+
+func TestLabelStackCompareAndCopy(t *testing.T) {
+	l := &LabelStack{299824, 16001}
+	cp := l.Copy()
+
+	assert.True(t, l.Compare(cp))
+	(*cp)[1] = 16002
+	assert.False(t, l.Compare(cp))
+	assert.Equal(t, uint32(16001), (*l)[1])
+
+	assert.False(t, l.Compare(&LabelStack{299824}))
+	assert.False(t, l.Compare(nil))
+	assert.True(t, (*LabelStack)(nil).Compare(nil))
+	assert.Nil(t, (*LabelStack)(nil).Copy())
+}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -23,6 +24,36 @@ func (lc *LargeCommunities) String() string {
 	return strings.Join(lcStrings, " ")
 }
 
+// Normalize sorts the large communities in ascending order and removes duplicates so equal sets have equal representations
+func (lc *LargeCommunities) Normalize() {
+	if lc == nil || lc.normalized() {
+		return
+	}
+
+	sort.Slice(*lc, func(i, j int) bool {
+		return (*lc)[i].Less((*lc)[j])
+	})
+
+	ret := (*lc)[:1]
+	for _, x := range (*lc)[1:] {
+		if x != ret[len(ret)-1] {
+			ret = append(ret, x)
+		}
+	}
+
+	*lc = ret
+}
+
+func (lc *LargeCommunities) normalized() bool {
+	for i := 1; i < len(*lc); i++ {
+		if !(*lc)[i-1].Less((*lc)[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // LargeCommunity represents a large community (RFC8195)
 type LargeCommunity struct {
 	GlobalAdministrator uint32
@@ -48,6 +79,19 @@ func LargeCommunityFromProtoCommunity(alc *api.LargeCommunity) LargeCommunity {
 	}
 }
 
+// Less compares the global administrator, then both data parts
+func (c LargeCommunity) Less(o LargeCommunity) bool {
+	if c.GlobalAdministrator != o.GlobalAdministrator {
+		return c.GlobalAdministrator < o.GlobalAdministrator
+	}
+
+	if c.DataPart1 != o.DataPart1 {
+		return c.DataPart1 < o.DataPart1
+	}
+
+	return c.DataPart2 < o.DataPart2
+}
+
 // String transitions a large community to it's human readable representation
 func (c *LargeCommunity) String() string {
 	if c == nil {
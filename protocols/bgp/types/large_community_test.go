@@ -178,3 +178,32 @@ func TestLargeCommunitiesToString(t *testing.T) {
 		})
 	}
 }
+
+func TestLargeCommunitiesNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    *LargeCommunities
+		expected *LargeCommunities
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name:     "already normalized",
+			value:    &LargeCommunities{{1, 2, 3}, {1, 2, 4}},
+			expected: &LargeCommunities{{1, 2, 3}, {1, 2, 4}},
+		},
+		{
+			name:     "unordered with duplicates",
+			value:    &LargeCommunities{{2, 0, 0}, {1, 2, 4}, {1, 3, 0}, {1, 2, 4}, {1, 2, 3}},
+			expected: &LargeCommunities{{1, 2, 3}, {1, 2, 4}, {1, 3, 0}, {2, 0, 0}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.value.Normalize()
+			assert.Equal(t, test.expected, test.value)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package types
+
+import (
+	"fmt"
+)
+
+// PMSI tunnel types (RFC6514, RFC7432)
+const (
+	PMSITunnelTypeNoTunnelInfo       = 0
+	PMSITunnelTypeIngressReplication = 6
+)
+
+// PMSITunnel represents a P-Multicast Service Interface Tunnel attribute (RFC6514)
+type PMSITunnel struct {
+	Flags      uint8
+	TunnelType uint8
+
+	// Label is the raw 3 octet MPLS Label field. It carries an MPLS label in its high-order 20 bits
+	// or a VNI for VXLAN (RFC8365).
+	Label uint32
+
+	// TunnelID is the tunnel identifier, e.g. the IP address of the replicator for ingress replication
+	TunnelID []byte
+}
+
+// Copy creates a deep copy of a PMSITunnel
+func (p *PMSITunnel) Copy() *PMSITunnel {
+	if p == nil {
+		return nil
+	}
+
+	cp := *p
+	cp.TunnelID = make([]byte, len(p.TunnelID))
+	copy(cp.TunnelID, p.TunnelID)
+
+	return &cp
+}
+
+// Compare checks if two PMSITunnels are equal
+func (p *PMSITunnel) Compare(x *PMSITunnel) bool {
+	if p == nil || x == nil {
+		return p == x
+	}
+
+	return p.Flags == x.Flags && p.TunnelType == x.TunnelType && p.Label == x.Label && string(p.TunnelID) == string(x.TunnelID)
+}
+
+// String returns a human readable representation of a PMSITunnel
+func (p *PMSITunnel) String() string {
+	if p == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("flags=%d type=%d label=%d id=%x", p.Flags, p.TunnelType, p.Label, p.TunnelID)
+}
@@ -0,0 +1,468 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route/api"
+)
+
+// PrefixSID represents a BGP Prefix-SID attribute (RFC8669, RFC9252)
+type PrefixSID struct {
+	// LabelIndex is the Label-Index TLV. It is only meaningful for labeled unicast routes.
+	LabelIndex *PrefixSIDLabelIndex
+
+	// SRv6L3Service is the SRv6 L3 Service TLV
+	SRv6L3Service *SRv6Service
+
+	// TLVs are all TLVs not represented by the fields above, e.g. the Originator SRGB TLV
+	TLVs []PrefixSIDTLV
+}
+
+// PrefixSIDLabelIndex represents the Label-Index TLV of a Prefix-SID attribute
+type PrefixSIDLabelIndex struct {
+	Flags uint16
+	Index uint32
+}
+
+// SRv6Service represents an SRv6 Service TLV of a Prefix-SID attribute
+type SRv6Service struct {
+	// SIDs are the values of the SRv6 SID Information sub-TLVs
+	SIDs []*SRv6SIDInformation
+
+	// SubTLVs are all sub-TLVs other than SRv6 SID Information sub-TLVs
+	SubTLVs []PrefixSIDTLV
+}
+
+// SRv6SIDInformation represents an SRv6 SID Information sub-TLV of an SRv6 Service TLV
+type SRv6SIDInformation struct {
+	SID              bnet.IP
+	Flags            uint8
+	EndpointBehavior uint16
+
+	// Structure is the SRv6 SID Structure sub-sub-TLV. It is nil if the sub-sub-TLV is not present.
+	Structure *SRv6SIDStructure
+
+	// SubSubTLVs are all sub-sub-TLVs other than the SRv6 SID Structure sub-sub-TLV
+	SubSubTLVs []PrefixSIDTLV
+}
+
+// SRv6SIDStructure represents an SRv6 SID Structure sub-sub-TLV
+type SRv6SIDStructure struct {
+	LocatorBlockLen     uint8
+	LocatorNodeLen      uint8
+	FunctionLen         uint8
+	ArgumentLen         uint8
+	TranspositionLen    uint8
+	TranspositionOffset uint8
+}
+
+// PrefixSIDTLV represents a TLV, sub-TLV or sub-sub-TLV of a Prefix-SID attribute not interpreted by us
+type PrefixSIDTLV struct {
+	Type  uint8
+	Value []byte
+}
+
+// Copy creates a deep copy of a PrefixSID
+func (p *PrefixSID) Copy() *PrefixSID {
+	if p == nil {
+		return nil
+	}
+
+	cp := *p
+
+	if p.LabelIndex != nil {
+		li := *p.LabelIndex
+		cp.LabelIndex = &li
+	}
+
+	if p.SRv6L3Service != nil {
+		cp.SRv6L3Service = p.SRv6L3Service.Copy()
+	}
+
+	cp.TLVs = copyPrefixSIDTLVs(p.TLVs)
+	return &cp
+}
+
+// Compare checks if two PrefixSIDs are equal
+func (p *PrefixSID) Compare(x *PrefixSID) bool {
+	if p == nil || x == nil {
+		return p == x
+	}
+
+	if p.LabelIndex != nil || x.LabelIndex != nil {
+		if p.LabelIndex == nil || x.LabelIndex == nil || *p.LabelIndex != *x.LabelIndex {
+			return false
+		}
+	}
+
+	if p.SRv6L3Service != nil || x.SRv6L3Service != nil {
+		if p.SRv6L3Service == nil || x.SRv6L3Service == nil || !p.SRv6L3Service.Compare(x.SRv6L3Service) {
+			return false
+		}
+	}
+
+	return comparePrefixSIDTLVs(p.TLVs, x.TLVs)
+}
+
+// ToProto converts PrefixSID to proto PrefixSID
+func (p *PrefixSID) ToProto() *api.PrefixSID {
+	if p == nil {
+		return nil
+	}
+
+	a := &api.PrefixSID{
+		Tlvs: prefixSIDTLVsToProto(p.TLVs),
+	}
+
+	if p.LabelIndex != nil {
+		a.LabelIndex = &api.PrefixSIDLabelIndex{
+			Flags: uint32(p.LabelIndex.Flags),
+			Index: p.LabelIndex.Index,
+		}
+	}
+
+	if p.SRv6L3Service != nil {
+		a.Srv6L3Service = p.SRv6L3Service.ToProto()
+	}
+
+	return a
+}
+
+// PrefixSIDFromProtoPrefixSID converts a proto PrefixSID to PrefixSID
+func PrefixSIDFromProtoPrefixSID(a *api.PrefixSID) *PrefixSID {
+	if a == nil {
+		return nil
+	}
+
+	p := &PrefixSID{
+		TLVs: prefixSIDTLVsFromProto(a.Tlvs),
+	}
+
+	if a.LabelIndex != nil {
+		p.LabelIndex = &PrefixSIDLabelIndex{
+			Flags: uint16(a.LabelIndex.Flags),
+			Index: a.LabelIndex.Index,
+		}
+	}
+
+	if a.Srv6L3Service != nil {
+		p.SRv6L3Service = srv6ServiceFromProto(a.Srv6L3Service)
+	}
+
+	return p
+}
+
+// WireLength returns the number of bytes the attribute needs on the wire
+func (p *PrefixSID) WireLength() uint16 {
+	length := uint16(0)
+
+	if p.LabelIndex != nil {
+		length += 3 + 7
+	}
+
+	for _, t := range p.TLVs {
+		length += 3 + uint16(len(t.Value))
+	}
+
+	if p.SRv6L3Service != nil {
+		length += 3 + p.SRv6L3Service.wireLength()
+	}
+
+	if length > 255 {
+		length++ // Extended length
+	}
+
+	return length + 3
+}
+
+// String returns a human readable representation of a PrefixSID
+func (p *PrefixSID) String() string {
+	if p == nil {
+		return ""
+	}
+
+	attrs := make([]string, 0)
+
+	if p.LabelIndex != nil {
+		attrs = append(attrs, fmt.Sprintf("label-index=%d", p.LabelIndex.Index))
+		if p.LabelIndex.Flags != 0 {
+			attrs = append(attrs, fmt.Sprintf("label-index-flags=%#04x", p.LabelIndex.Flags))
+		}
+	}
+
+	if p.SRv6L3Service != nil {
+		attrs = append(attrs, fmt.Sprintf("srv6-l3-service(%s)", p.SRv6L3Service.String()))
+	}
+
+	for _, t := range p.TLVs {
+		attrs = append(attrs, fmt.Sprintf("tlv%d=%x", t.Type, t.Value))
+	}
+
+	return strings.Join(attrs, " ")
+}
+
+// Copy creates a deep copy of an SRv6Service
+func (s *SRv6Service) Copy() *SRv6Service {
+	cp := *s
+
+	if s.SIDs != nil {
+		cp.SIDs = make([]*SRv6SIDInformation, len(s.SIDs))
+		for i, sid := range s.SIDs {
+			cp.SIDs[i] = sid.Copy()
+		}
+	}
+
+	cp.SubTLVs = copyPrefixSIDTLVs(s.SubTLVs)
+	return &cp
+}
+
+// Compare checks if two SRv6Services are equal
+func (s *SRv6Service) Compare(x *SRv6Service) bool {
+	if len(s.SIDs) != len(x.SIDs) {
+		return false
+	}
+
+	for i := range s.SIDs {
+		if !s.SIDs[i].Compare(x.SIDs[i]) {
+			return false
+		}
+	}
+
+	return comparePrefixSIDTLVs(s.SubTLVs, x.SubTLVs)
+}
+
+// ToProto converts SRv6Service to proto SRv6Service
+func (s *SRv6Service) ToProto() *api.SRv6Service {
+	a := &api.SRv6Service{
+		SubTlvs: prefixSIDTLVsToProto(s.SubTLVs),
+	}
+
+	for _, sid := range s.SIDs {
+		a.Sids = append(a.Sids, sid.ToProto())
+	}
+
+	return a
+}
+
+func srv6ServiceFromProto(a *api.SRv6Service) *SRv6Service {
+	s := &SRv6Service{
+		SubTLVs: prefixSIDTLVsFromProto(a.SubTlvs),
+	}
+
+	for _, sid := range a.Sids {
+		s.SIDs = append(s.SIDs, srv6SIDInformationFromProto(sid))
+	}
+
+	return s
+}
+
+func (s *SRv6Service) wireLength() uint16 {
+	length := uint16(1) // Reserved
+
+	for _, sid := range s.SIDs {
+		length += 3 + sid.wireLength()
+	}
+
+	for _, t := range s.SubTLVs {
+		length += 3 + uint16(len(t.Value))
+	}
+
+	return length
+}
+
+// String returns a human readable representation of an SRv6Service
+func (s *SRv6Service) String() string {
+	attrs := make([]string, 0)
+
+	for _, sid := range s.SIDs {
+		attrs = append(attrs, sid.String())
+	}
+
+	for _, t := range s.SubTLVs {
+		attrs = append(attrs, fmt.Sprintf("subtlv%d=%x", t.Type, t.Value))
+	}
+
+	return strings.Join(attrs, " ")
+}
+
+// Copy creates a deep copy of an SRv6SIDInformation
+func (s *SRv6SIDInformation) Copy() *SRv6SIDInformation {
+	cp := *s
+
+	if s.Structure != nil {
+		st := *s.Structure
+		cp.Structure = &st
+	}
+
+	cp.SubSubTLVs = copyPrefixSIDTLVs(s.SubSubTLVs)
+	return &cp
+}
+
+// Compare checks if two SRv6SIDInformations are equal
+func (s *SRv6SIDInformation) Compare(x *SRv6SIDInformation) bool {
+	if !s.SID.Equal(x.SID) || s.Flags != x.Flags || s.EndpointBehavior != x.EndpointBehavior {
+		return false
+	}
+
+	if s.Structure != nil || x.Structure != nil {
+		if s.Structure == nil || x.Structure == nil || *s.Structure != *x.Structure {
+			return false
+		}
+	}
+
+	return comparePrefixSIDTLVs(s.SubSubTLVs, x.SubSubTLVs)
+}
+
+// ToProto converts SRv6SIDInformation to proto SRv6SIDInformation
+func (s *SRv6SIDInformation) ToProto() *api.SRv6SIDInformation {
+	a := &api.SRv6SIDInformation{
+		Sid:              s.SID.ToProto(),
+		Flags:            uint32(s.Flags),
+		EndpointBehavior: uint32(s.EndpointBehavior),
+		SubSubTlvs:       prefixSIDTLVsToProto(s.SubSubTLVs),
+	}
+
+	if s.Structure != nil {
+		a.Structure = &api.SRv6SIDStructure{
+			LocatorBlockLen:     uint32(s.Structure.LocatorBlockLen),
+			LocatorNodeLen:      uint32(s.Structure.LocatorNodeLen),
+			FunctionLen:         uint32(s.Structure.FunctionLen),
+			ArgumentLen:         uint32(s.Structure.ArgumentLen),
+			TranspositionLen:    uint32(s.Structure.TranspositionLen),
+			TranspositionOffset: uint32(s.Structure.TranspositionOffset),
+		}
+	}
+
+	return a
+}
+
+func srv6SIDInformationFromProto(a *api.SRv6SIDInformation) *SRv6SIDInformation {
+	s := &SRv6SIDInformation{
+		Flags:            uint8(a.Flags),
+		EndpointBehavior: uint16(a.EndpointBehavior),
+		SubSubTLVs:       prefixSIDTLVsFromProto(a.SubSubTlvs),
+	}
+
+	if a.Sid != nil {
+		s.SID = bnet.IPFromProtoIP(a.Sid)
+	}
+
+	if a.Structure != nil {
+		s.Structure = &SRv6SIDStructure{
+			LocatorBlockLen:     uint8(a.Structure.LocatorBlockLen),
+			LocatorNodeLen:      uint8(a.Structure.LocatorNodeLen),
+			FunctionLen:         uint8(a.Structure.FunctionLen),
+			ArgumentLen:         uint8(a.Structure.ArgumentLen),
+			TranspositionLen:    uint8(a.Structure.TranspositionLen),
+			TranspositionOffset: uint8(a.Structure.TranspositionOffset),
+		}
+	}
+
+	return s
+}
+
+func (s *SRv6SIDInformation) wireLength() uint16 {
+	length := uint16(21)
+
+	if s.Structure != nil {
+		length += 3 + 6
+	}
+
+	for _, t := range s.SubSubTLVs {
+		length += 3 + uint16(len(t.Value))
+	}
+
+	return length
+}
+
+// String returns a human readable representation of an SRv6SIDInformation
+func (s *SRv6SIDInformation) String() string {
+	attrs := []string{
+		fmt.Sprintf("sid=%s", s.SID.String()),
+		fmt.Sprintf("behavior=%d", s.EndpointBehavior),
+	}
+
+	if s.Flags != 0 {
+		attrs = append(attrs, fmt.Sprintf("flags=%#02x", s.Flags))
+	}
+
+	if s.Structure != nil {
+		st := s.Structure
+		attrs = append(attrs, fmt.Sprintf("structure=%d/%d/%d/%d", st.LocatorBlockLen, st.LocatorNodeLen, st.FunctionLen, st.ArgumentLen))
+		if st.TranspositionLen != 0 || st.TranspositionOffset != 0 {
+			attrs = append(attrs, fmt.Sprintf("transposition=%d/%d", st.TranspositionLen, st.TranspositionOffset))
+		}
+	}
+
+	for _, t := range s.SubSubTLVs {
+		attrs = append(attrs, fmt.Sprintf("subsubtlv%d=%x", t.Type, t.Value))
+	}
+
+	return strings.Join(attrs, " ")
+}
+
+func prefixSIDTLVsToProto(tlvs []PrefixSIDTLV) []*api.PrefixSIDTLV {
+	if tlvs == nil {
+		return nil
+	}
+
+	ret := make([]*api.PrefixSIDTLV, len(tlvs))
+	for i, t := range tlvs {
+		ret[i] = &api.PrefixSIDTLV{
+			Type:  uint32(t.Type),
+			Value: make([]byte, len(t.Value)),
+		}
+		copy(ret[i].Value, t.Value)
+	}
+
+	return ret
+}
+
+func prefixSIDTLVsFromProto(tlvs []*api.PrefixSIDTLV) []PrefixSIDTLV {
+	if len(tlvs) == 0 {
+		return nil
+	}
+
+	ret := make([]PrefixSIDTLV, len(tlvs))
+	for i, t := range tlvs {
+		ret[i] = PrefixSIDTLV{
+			Type:  uint8(t.Type),
+			Value: t.Value,
+		}
+	}
+
+	return ret
+}
+
+func copyPrefixSIDTLVs(tlvs []PrefixSIDTLV) []PrefixSIDTLV {
+	if tlvs == nil {
+		return nil
+	}
+
+	cp := make([]PrefixSIDTLV, len(tlvs))
+	for i, t := range tlvs {
+		cp[i] = PrefixSIDTLV{
+			Type:  t.Type,
+			Value: make([]byte, len(t.Value)),
+		}
+		copy(cp[i].Value, t.Value)
+	}
+
+	return cp
+}
+
+func comparePrefixSIDTLVs(a []PrefixSIDTLV, b []PrefixSIDTLV) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i].Type != b[i].Type || string(a[i].Value) != string(b[i].Value) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,253 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+// Tunnel types (RFC9012)
+const (
+	TunnelTypeVXLAN  = 8
+	TunnelTypeNVGRE  = 9
+	TunnelTypeMPLS   = 10
+	TunnelTypeGENEVE = 19
+)
+
+// TunnelEncapsulation represents a Tunnel Encapsulation attribute (RFC9012)
+type TunnelEncapsulation []*Tunnel
+
+// Tunnel represents a Tunnel TLV of a Tunnel Encapsulation attribute
+type Tunnel struct {
+	Type uint16
+
+	// Endpoint is the address of the Tunnel Egress Endpoint sub-TLV. It is nil if the sub-TLV is not present
+	// or carries no address (address family 0).
+	Endpoint *bnet.IP
+
+	// Colors are the values of the Color sub-TLVs
+	Colors []uint32
+
+	// VXLAN is the Encapsulation sub-TLV of a VXLAN tunnel
+	VXLAN *VXLANEncapsulation
+
+	// SubTLVs are all sub-TLVs not represented by the fields above
+	SubTLVs []TunnelSubTLV
+}
+
+// VXLANEncapsulation represents the Encapsulation sub-TLV for VXLAN tunnels
+type VXLANEncapsulation struct {
+	VNIDValid bool
+	MACValid  bool
+	VNID      uint32
+	MAC       [6]byte
+}
+
+// TunnelSubTLV represents a sub-TLV of a Tunnel TLV
+type TunnelSubTLV struct {
+	Type  uint8
+	Value []byte
+}
+
+// Copy creates a deep copy of a TunnelEncapsulation
+func (t *TunnelEncapsulation) Copy() *TunnelEncapsulation {
+	if t == nil {
+		return nil
+	}
+
+	cp := make(TunnelEncapsulation, len(*t))
+	for i, tun := range *t {
+		cp[i] = tun.Copy()
+	}
+
+	return &cp
+}
+
+// Compare checks if two TunnelEncapsulations are equal
+func (t *TunnelEncapsulation) Compare(x *TunnelEncapsulation) bool {
+	if t == nil || x == nil {
+		return t == x
+	}
+
+	if len(*t) != len(*x) {
+		return false
+	}
+
+	for i := range *t {
+		if !(*t)[i].Compare((*x)[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WireLength returns the number of bytes the attribute needs on the wire
+func (t *TunnelEncapsulation) WireLength() uint16 {
+	length := uint16(0)
+	for _, tun := range *t {
+		length += tun.wireLength()
+	}
+
+	if length > 255 {
+		length++ // Extended length
+	}
+
+	return length + 3
+}
+
+// String returns a human readable representation of a TunnelEncapsulation
+func (t *TunnelEncapsulation) String() string {
+	if t == nil {
+		return ""
+	}
+
+	s := make([]string, len(*t))
+	for i, tun := range *t {
+		s[i] = tun.String()
+	}
+
+	return strings.Join(s, " ")
+}
+
+// Copy creates a deep copy of a Tunnel
+func (t *Tunnel) Copy() *Tunnel {
+	cp := *t
+
+	if t.Endpoint != nil {
+		cp.Endpoint = t.Endpoint.Dedup()
+	}
+
+	if t.Colors != nil {
+		cp.Colors = make([]uint32, len(t.Colors))
+		copy(cp.Colors, t.Colors)
+	}
+
+	if t.VXLAN != nil {
+		vxlan := *t.VXLAN
+		cp.VXLAN = &vxlan
+	}
+
+	if t.SubTLVs != nil {
+		cp.SubTLVs = make([]TunnelSubTLV, len(t.SubTLVs))
+		for i, s := range t.SubTLVs {
+			cp.SubTLVs[i] = TunnelSubTLV{
+				Type:  s.Type,
+				Value: make([]byte, len(s.Value)),
+			}
+			copy(cp.SubTLVs[i].Value, s.Value)
+		}
+	}
+
+	return &cp
+}
+
+// Compare checks if two Tunnels are equal
+func (t *Tunnel) Compare(x *Tunnel) bool {
+	if t.Type != x.Type {
+		return false
+	}
+
+	if t.Endpoint != nil || x.Endpoint != nil {
+		if t.Endpoint == nil || x.Endpoint == nil || t.Endpoint.Compare(x.Endpoint) != 0 {
+			return false
+		}
+	}
+
+	if len(t.Colors) != len(x.Colors) {
+		return false
+	}
+
+	for i := range t.Colors {
+		if t.Colors[i] != x.Colors[i] {
+			return false
+		}
+	}
+
+	if t.VXLAN != nil || x.VXLAN != nil {
+		if t.VXLAN == nil || x.VXLAN == nil || *t.VXLAN != *x.VXLAN {
+			return false
+		}
+	}
+
+	if len(t.SubTLVs) != len(x.SubTLVs) {
+		return false
+	}
+
+	for i := range t.SubTLVs {
+		if t.SubTLVs[i].Type != x.SubTLVs[i].Type || string(t.SubTLVs[i].Value) != string(x.SubTLVs[i].Value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (t *Tunnel) wireLength() uint16 {
+	length := uint16(4)
+
+	if t.Endpoint != nil {
+		length += 2 + 6 + uint16(len(t.Endpoint.Bytes()))
+	}
+
+	if t.VXLAN != nil {
+		length += 2 + 12
+	}
+
+	length += uint16(len(t.Colors)) * (2 + 8)
+
+	for _, s := range t.SubTLVs {
+		length += 2 + uint16(len(s.Value))
+		if s.Type >= 128 {
+			length++
+		}
+	}
+
+	return length
+}
+
+// String returns a human readable representation of a Tunnel
+func (t *Tunnel) String() string {
+	attrs := make([]string, 0)
+
+	if t.Endpoint != nil {
+		attrs = append(attrs, fmt.Sprintf("endpoint=%s", t.Endpoint.String()))
+	}
+
+	for _, c := range t.Colors {
+		attrs = append(attrs, fmt.Sprintf("color=%d", c))
+	}
+
+	if t.VXLAN != nil {
+		if t.VXLAN.VNIDValid {
+			attrs = append(attrs, fmt.Sprintf("vnid=%d", t.VXLAN.VNID))
+		}
+
+		if t.VXLAN.MACValid {
+			m := t.VXLAN.MAC
+			attrs = append(attrs, fmt.Sprintf("mac=%02x:%02x:%02x:%02x:%02x:%02x", m[0], m[1], m[2], m[3], m[4], m[5]))
+		}
+	}
+
+	for _, s := range t.SubTLVs {
+		attrs = append(attrs, fmt.Sprintf("subtlv%d=%x", s.Type, s.Value))
+	}
+
+	return fmt.Sprintf("%s(%s)", tunnelTypeString(t.Type), strings.Join(attrs, " "))
+}
+
+func tunnelTypeString(t uint16) string {
+	switch t {
+	case TunnelTypeVXLAN:
+		return "vxlan"
+	case TunnelTypeNVGRE:
+		return "nvgre"
+	case TunnelTypeMPLS:
+		return "mpls"
+	case TunnelTypeGENEVE:
+		return "geneve"
+	}
+
+	return fmt.Sprintf("type%d", t)
+}
@@ -865,6 +865,511 @@ func (x *ExtendedISReachability) GetNeighborId() []byte {
 	return nil
 }
 
+type GetCountersRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetCountersRequest) Reset() {
+	*x = GetCountersRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCountersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCountersRequest) ProtoMessage() {}
+
+func (x *GetCountersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCountersRequest.ProtoReflect.Descriptor instead.
+func (*GetCountersRequest) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{11}
+}
+
+type GetCountersResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Global     *Counters            `protobuf:"bytes,1,opt,name=global,proto3" json:"global,omitempty"`
+	Interfaces []*InterfaceCounters `protobuf:"bytes,2,rep,name=interfaces,proto3" json:"interfaces,omitempty"`
+}
+
+func (x *GetCountersResponse) Reset() {
+	*x = GetCountersResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCountersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCountersResponse) ProtoMessage() {}
+
+func (x *GetCountersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCountersResponse.ProtoReflect.Descriptor instead.
+func (*GetCountersResponse) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetCountersResponse) GetGlobal() *Counters {
+	if x != nil {
+		return x.Global
+	}
+	return nil
+}
+
+func (x *GetCountersResponse) GetInterfaces() []*InterfaceCounters {
+	if x != nil {
+		return x.Interfaces
+	}
+	return nil
+}
+
+type InterfaceCounters struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Counters *Counters `protobuf:"bytes,2,opt,name=counters,proto3" json:"counters,omitempty"`
+}
+
+func (x *InterfaceCounters) Reset() {
+	*x = InterfaceCounters{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *InterfaceCounters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InterfaceCounters) ProtoMessage() {}
+
+func (x *InterfaceCounters) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InterfaceCounters.ProtoReflect.Descriptor instead.
+func (*InterfaceCounters) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *InterfaceCounters) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *InterfaceCounters) GetCounters() *Counters {
+	if x != nil {
+		return x.Counters
+	}
+	return nil
+}
+
+type Counters struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HellosSent             uint64 `protobuf:"varint,1,opt,name=hellos_sent,json=hellosSent,proto3" json:"hellos_sent,omitempty"`
+	HellosReceived         uint64 `protobuf:"varint,2,opt,name=hellos_received,json=hellosReceived,proto3" json:"hellos_received,omitempty"`
+	LspsSent               uint64 `protobuf:"varint,3,opt,name=lsps_sent,json=lspsSent,proto3" json:"lsps_sent,omitempty"`
+	LspsReceived           uint64 `protobuf:"varint,4,opt,name=lsps_received,json=lspsReceived,proto3" json:"lsps_received,omitempty"`
+	CsnpsSent              uint64 `protobuf:"varint,5,opt,name=csnps_sent,json=csnpsSent,proto3" json:"csnps_sent,omitempty"`
+	CsnpsReceived          uint64 `protobuf:"varint,6,opt,name=csnps_received,json=csnpsReceived,proto3" json:"csnps_received,omitempty"`
+	PsnpsSent              uint64 `protobuf:"varint,7,opt,name=psnps_sent,json=psnpsSent,proto3" json:"psnps_sent,omitempty"`
+	PsnpsReceived          uint64 `protobuf:"varint,8,opt,name=psnps_received,json=psnpsReceived,proto3" json:"psnps_received,omitempty"`
+	AuthenticationFailures uint64 `protobuf:"varint,9,opt,name=authentication_failures,json=authenticationFailures,proto3" json:"authentication_failures,omitempty"`
+	ChecksumErrors         uint64 `protobuf:"varint,10,opt,name=checksum_errors,json=checksumErrors,proto3" json:"checksum_errors,omitempty"`
+	AdjacencyChanges       uint64 `protobuf:"varint,11,opt,name=adjacency_changes,json=adjacencyChanges,proto3" json:"adjacency_changes,omitempty"`
+}
+
+func (x *Counters) Reset() {
+	*x = Counters{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Counters) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Counters) ProtoMessage() {}
+
+func (x *Counters) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Counters.ProtoReflect.Descriptor instead.
+func (*Counters) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Counters) GetHellosSent() uint64 {
+	if x != nil {
+		return x.HellosSent
+	}
+	return 0
+}
+
+func (x *Counters) GetHellosReceived() uint64 {
+	if x != nil {
+		return x.HellosReceived
+	}
+	return 0
+}
+
+func (x *Counters) GetLspsSent() uint64 {
+	if x != nil {
+		return x.LspsSent
+	}
+	return 0
+}
+
+func (x *Counters) GetLspsReceived() uint64 {
+	if x != nil {
+		return x.LspsReceived
+	}
+	return 0
+}
+
+func (x *Counters) GetCsnpsSent() uint64 {
+	if x != nil {
+		return x.CsnpsSent
+	}
+	return 0
+}
+
+func (x *Counters) GetCsnpsReceived() uint64 {
+	if x != nil {
+		return x.CsnpsReceived
+	}
+	return 0
+}
+
+func (x *Counters) GetPsnpsSent() uint64 {
+	if x != nil {
+		return x.PsnpsSent
+	}
+	return 0
+}
+
+func (x *Counters) GetPsnpsReceived() uint64 {
+	if x != nil {
+		return x.PsnpsReceived
+	}
+	return 0
+}
+
+func (x *Counters) GetAuthenticationFailures() uint64 {
+	if x != nil {
+		return x.AuthenticationFailures
+	}
+	return 0
+}
+
+func (x *Counters) GetChecksumErrors() uint64 {
+	if x != nil {
+		return x.ChecksumErrors
+	}
+	return 0
+}
+
+func (x *Counters) GetAdjacencyChanges() uint64 {
+	if x != nil {
+		return x.AdjacencyChanges
+	}
+	return 0
+}
+
+type DumpRoutesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level uint32 `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+}
+
+func (x *DumpRoutesRequest) Reset() {
+	*x = DumpRoutesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DumpRoutesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpRoutesRequest) ProtoMessage() {}
+
+func (x *DumpRoutesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpRoutesRequest.ProtoReflect.Descriptor instead.
+func (*DumpRoutesRequest) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DumpRoutesRequest) GetLevel() uint32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+type DumpRoutesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Routes []*Route `protobuf:"bytes,1,rep,name=routes,proto3" json:"routes,omitempty"`
+}
+
+func (x *DumpRoutesResponse) Reset() {
+	*x = DumpRoutesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DumpRoutesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DumpRoutesResponse) ProtoMessage() {}
+
+func (x *DumpRoutesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DumpRoutesResponse.ProtoReflect.Descriptor instead.
+func (*DumpRoutesResponse) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DumpRoutesResponse) GetRoutes() []*Route {
+	if x != nil {
+		return x.Routes
+	}
+	return nil
+}
+
+type Route struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Prefix   *api.Prefix `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Metric   uint32      `protobuf:"varint,2,opt,name=metric,proto3" json:"metric,omitempty"`
+	NextHops []*NextHop  `protobuf:"bytes,3,rep,name=next_hops,json=nextHops,proto3" json:"next_hops,omitempty"`
+	// up_down is set if the prefix has been leaked from level 2 into level 1
+	UpDown bool `protobuf:"varint,4,opt,name=up_down,json=upDown,proto3" json:"up_down,omitempty"`
+	// attached is set for the default route towards the closest attached level 1 level 2 IS
+	Attached bool `protobuf:"varint,5,opt,name=attached,proto3" json:"attached,omitempty"`
+}
+
+func (x *Route) Reset() {
+	*x = Route{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Route) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Route) ProtoMessage() {}
+
+func (x *Route) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Route.ProtoReflect.Descriptor instead.
+func (*Route) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *Route) GetPrefix() *api.Prefix {
+	if x != nil {
+		return x.Prefix
+	}
+	return nil
+}
+
+func (x *Route) GetMetric() uint32 {
+	if x != nil {
+		return x.Metric
+	}
+	return 0
+}
+
+func (x *Route) GetNextHops() []*NextHop {
+	if x != nil {
+		return x.NextHops
+	}
+	return nil
+}
+
+func (x *Route) GetUpDown() bool {
+	if x != nil {
+		return x.UpDown
+	}
+	return false
+}
+
+func (x *Route) GetAttached() bool {
+	if x != nil {
+		return x.Attached
+	}
+	return false
+}
+
+type NextHop struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SystemId []byte `protobuf:"bytes,1,opt,name=system_id,json=systemId,proto3" json:"system_id,omitempty"`
+	Hostname string `protobuf:"bytes,2,opt,name=hostname,proto3" json:"hostname,omitempty"`
+}
+
+func (x *NextHop) Reset() {
+	*x = NextHop{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_protocols_isis_api_isis_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NextHop) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NextHop) ProtoMessage() {}
+
+func (x *NextHop) ProtoReflect() protoreflect.Message {
+	mi := &file_protocols_isis_api_isis_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NextHop.ProtoReflect.Descriptor instead.
+func (*NextHop) Descriptor() ([]byte, []int) {
+	return file_protocols_isis_api_isis_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *NextHop) GetSystemId() []byte {
+	if x != nil {
+		return x.SystemId
+	}
+	return nil
+}
+
+func (x *NextHop) GetHostname() string {
+	if x != nil {
+		return x.Hostname
+	}
+	return ""
+}
+
 var File_protocols_isis_api_isis_proto protoreflect.FileDescriptor
 
 var file_protocols_isis_api_isis_proto_rawDesc = []byte{
@@ -990,22 +1495,95 @@ var file_protocols_isis_api_isis_proto_rawDesc = []byte{
 	0x74, 0x5f, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d,
 	0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x1f, 0x0a,
 	0x0b, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x0a, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x49, 0x64, 0x32, 0xa9,
-	0x01, 0x0a, 0x0b, 0x49, 0x73, 0x69, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x58,
-	0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63, 0x69, 0x65,
-	0x73, 0x12, 0x20, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x4c, 0x69, 0x73,
-	0x74, 0x41, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x4c,
-	0x69, 0x73, 0x74, 0x41, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65,
-	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x4c,
-	0x53, 0x44, 0x42, 0x12, 0x18, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x47,
-	0x65, 0x74, 0x4c, 0x53, 0x44, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
-	0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x53, 0x44, 0x42,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69,
-	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75,
-	0x74, 0x69, 0x6e, 0x67, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f, 0x69, 0x73, 0x69, 0x73, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x28, 0x0c, 0x52, 0x0a, 0x6e, 0x65, 0x69, 0x67, 0x68, 0x62, 0x6f, 0x72, 0x49, 0x64, 0x22, 0x14,
+	0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x7e, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x72, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x06, 0x67,
+	0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x62, 0x69,
+	0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52,
+	0x06, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x12, 0x3b, 0x0a, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x66, 0x61, 0x63, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x69,
+	0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65,
+	0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52, 0x0a, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66,
+	0x61, 0x63, 0x65, 0x73, 0x22, 0x57, 0x0a, 0x11, 0x49, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a,
+	0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x43, 0x6f, 0x75, 0x6e, 0x74,
+	0x65, 0x72, 0x73, 0x52, 0x08, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x22, 0xb1, 0x03,
+	0x0a, 0x08, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x68, 0x65,
+	0x6c, 0x6c, 0x6f, 0x73, 0x5f, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x73, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x68,
+	0x65, 0x6c, 0x6c, 0x6f, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x68, 0x65, 0x6c, 0x6c, 0x6f, 0x73, 0x52, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x73, 0x70, 0x73, 0x5f, 0x73, 0x65, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6c, 0x73, 0x70, 0x73, 0x53, 0x65, 0x6e,
+	0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6c, 0x73, 0x70, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x6c, 0x73, 0x70, 0x73, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x73, 0x6e, 0x70, 0x73, 0x5f,
+	0x73, 0x65, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x63, 0x73, 0x6e, 0x70,
+	0x73, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x73, 0x6e, 0x70, 0x73, 0x5f, 0x72,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x63,
+	0x73, 0x6e, 0x70, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a,
+	0x70, 0x73, 0x6e, 0x70, 0x73, 0x5f, 0x73, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x70, 0x73, 0x6e, 0x70, 0x73, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x70,
+	0x73, 0x6e, 0x70, 0x73, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0d, 0x70, 0x73, 0x6e, 0x70, 0x73, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76,
+	0x65, 0x64, 0x12, 0x37, 0x0a, 0x17, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x16, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x46, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63,
+	0x79, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x10, 0x61, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63, 0x79, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x73, 0x22, 0x29, 0x0a, 0x11, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x22, 0x3d, 0x0a, 0x12,
+	0x44, 0x75, 0x6d, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x52, 0x6f,
+	0x75, 0x74, 0x65, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x22, 0xad, 0x01, 0x0a, 0x05,
+	0x52, 0x6f, 0x75, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e,
+	0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x06, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x16,
+	0x0a, 0x06, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06,
+	0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x12, 0x2e, 0x0a, 0x09, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x68,
+	0x6f, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x62, 0x69, 0x6f, 0x2e,
+	0x69, 0x73, 0x69, 0x73, 0x2e, 0x4e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x52, 0x08, 0x6e, 0x65,
+	0x78, 0x74, 0x48, 0x6f, 0x70, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x70, 0x5f, 0x64, 0x6f, 0x77,
+	0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x75, 0x70, 0x44, 0x6f, 0x77, 0x6e, 0x12,
+	0x1a, 0x0a, 0x08, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x08, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x22, 0x42, 0x0a, 0x07, 0x4e,
+	0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x79, 0x73, 0x74, 0x65, 0x6d,
+	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x73, 0x79, 0x73, 0x74, 0x65,
+	0x6d, 0x49, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x6f, 0x73, 0x74, 0x6e, 0x61, 0x6d, 0x65, 0x32,
+	0xc2, 0x02, 0x0a, 0x0b, 0x49, 0x73, 0x69, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x58, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63, 0x69,
+	0x65, 0x73, 0x12, 0x20, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x41, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x41, 0x64, 0x6a, 0x61, 0x63, 0x65, 0x6e, 0x63, 0x69, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x07, 0x47, 0x65, 0x74,
+	0x4c, 0x53, 0x44, 0x42, 0x12, 0x18, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e,
+	0x47, 0x65, 0x74, 0x4c, 0x53, 0x44, 0x42, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x4c, 0x53, 0x44,
+	0x42, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0b, 0x47,
+	0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x12, 0x1c, 0x2e, 0x62, 0x69, 0x6f,
+	0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69,
+	0x73, 0x69, 0x73, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x65, 0x72, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x49, 0x0a, 0x0a, 0x44, 0x75, 0x6d,
+	0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x12, 0x1b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73,
+	0x69, 0x73, 0x2e, 0x44, 0x75, 0x6d, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x69, 0x73, 0x69, 0x73, 0x2e,
+	0x44, 0x75, 0x6d, 0x70, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x42, 0x32, 0x5a, 0x30, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
+	0x6f, 0x6d, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x62,
+	0x69, 0x6f, 0x2d, 0x72, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x73, 0x2f,
+	0x69, 0x73, 0x69, 0x73, 0x2f, 0x61, 0x70, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1021,7 +1599,7 @@ func file_protocols_isis_api_isis_proto_rawDescGZIP() []byte {
 }
 
 var file_protocols_isis_api_isis_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_protocols_isis_api_isis_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_protocols_isis_api_isis_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_protocols_isis_api_isis_proto_goTypes = []interface{}{
 	(Adjacency_State)(0),            // 0: bio.isis.Adjacency.State
 	(LSPDU_Protocol)(0),             // 1: bio.isis.LSPDU.Protocol
@@ -1036,11 +1614,20 @@ var file_protocols_isis_api_isis_proto_goTypes = []interface{}{
 	(*ExtendedIPReachability)(nil),  // 10: bio.isis.ExtendedIPReachability
 	(*IPv4NLRI)(nil),                // 11: bio.isis.IPv4NLRI
 	(*ExtendedISReachability)(nil),  // 12: bio.isis.ExtendedISReachability
-	(*api.IP)(nil),                  // 13: bio.net.IP
+	(*GetCountersRequest)(nil),      // 13: bio.isis.GetCountersRequest
+	(*GetCountersResponse)(nil),     // 14: bio.isis.GetCountersResponse
+	(*InterfaceCounters)(nil),       // 15: bio.isis.InterfaceCounters
+	(*Counters)(nil),                // 16: bio.isis.Counters
+	(*DumpRoutesRequest)(nil),       // 17: bio.isis.DumpRoutesRequest
+	(*DumpRoutesResponse)(nil),      // 18: bio.isis.DumpRoutesResponse
+	(*Route)(nil),                   // 19: bio.isis.Route
+	(*NextHop)(nil),                 // 20: bio.isis.NextHop
+	(*api.IP)(nil),                  // 21: bio.net.IP
+	(*api.Prefix)(nil),              // 22: bio.net.Prefix
 }
 var file_protocols_isis_api_isis_proto_depIdxs = []int32{
 	4,  // 0: bio.isis.ListAdjacenciesResponse.adjacencies:type_name -> bio.isis.Adjacency
-	13, // 1: bio.isis.Adjacency.ip_addresses:type_name -> bio.net.IP
+	21, // 1: bio.isis.Adjacency.ip_addresses:type_name -> bio.net.IP
 	0,  // 2: bio.isis.Adjacency.status:type_name -> bio.isis.Adjacency.State
 	7,  // 3: bio.isis.GetLSDBResponse.lsdb_entries:type_name -> bio.isis.LSDBEntry
 	8,  // 4: bio.isis.LSDBEntry.lsp:type_name -> bio.isis.LSPDU
@@ -1048,15 +1635,25 @@ var file_protocols_isis_api_isis_proto_depIdxs = []int32{
 	1,  // 6: bio.isis.LSPDU.protocols_supported:type_name -> bio.isis.LSPDU.Protocol
 	12, // 7: bio.isis.LSPDU.extended_is_reachabilities:type_name -> bio.isis.ExtendedISReachability
 	10, // 8: bio.isis.LSPDU.extended_ip_reachabilities:type_name -> bio.isis.ExtendedIPReachability
-	2,  // 9: bio.isis.IsisService.ListAdjacencies:input_type -> bio.isis.ListAdjacenciesRequest
-	5,  // 10: bio.isis.IsisService.GetLSDB:input_type -> bio.isis.GetLSDBRequest
-	3,  // 11: bio.isis.IsisService.ListAdjacencies:output_type -> bio.isis.ListAdjacenciesResponse
-	6,  // 12: bio.isis.IsisService.GetLSDB:output_type -> bio.isis.GetLSDBResponse
-	11, // [11:13] is the sub-list for method output_type
-	9,  // [9:11] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	16, // 9: bio.isis.GetCountersResponse.global:type_name -> bio.isis.Counters
+	15, // 10: bio.isis.GetCountersResponse.interfaces:type_name -> bio.isis.InterfaceCounters
+	16, // 11: bio.isis.InterfaceCounters.counters:type_name -> bio.isis.Counters
+	19, // 12: bio.isis.DumpRoutesResponse.routes:type_name -> bio.isis.Route
+	22, // 13: bio.isis.Route.prefix:type_name -> bio.net.Prefix
+	20, // 14: bio.isis.Route.next_hops:type_name -> bio.isis.NextHop
+	2,  // 15: bio.isis.IsisService.ListAdjacencies:input_type -> bio.isis.ListAdjacenciesRequest
+	5,  // 16: bio.isis.IsisService.GetLSDB:input_type -> bio.isis.GetLSDBRequest
+	13, // 17: bio.isis.IsisService.GetCounters:input_type -> bio.isis.GetCountersRequest
+	17, // 18: bio.isis.IsisService.DumpRoutes:input_type -> bio.isis.DumpRoutesRequest
+	3,  // 19: bio.isis.IsisService.ListAdjacencies:output_type -> bio.isis.ListAdjacenciesResponse
+	6,  // 20: bio.isis.IsisService.GetLSDB:output_type -> bio.isis.GetLSDBResponse
+	14, // 21: bio.isis.IsisService.GetCounters:output_type -> bio.isis.GetCountersResponse
+	18, // 22: bio.isis.IsisService.DumpRoutes:output_type -> bio.isis.DumpRoutesResponse
+	19, // [19:23] is the sub-list for method output_type
+	15, // [15:19] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_protocols_isis_api_isis_proto_init() }
@@ -1197,6 +1794,102 @@ func file_protocols_isis_api_isis_proto_init() {
 				return nil
 			}
 		}
+		file_protocols_isis_api_isis_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCountersRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCountersResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*InterfaceCounters); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Counters); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DumpRoutesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DumpRoutesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Route); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_protocols_isis_api_isis_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NextHop); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1204,7 +1897,7 @@ func file_protocols_isis_api_isis_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_protocols_isis_api_isis_proto_rawDesc,
 			NumEnums:      2,
-			NumMessages:   11,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
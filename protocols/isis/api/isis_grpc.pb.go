@@ -20,6 +20,8 @@ const _ = grpc.SupportPackageIsVersion7
 type IsisServiceClient interface {
 	ListAdjacencies(ctx context.Context, in *ListAdjacenciesRequest, opts ...grpc.CallOption) (*ListAdjacenciesResponse, error)
 	GetLSDB(ctx context.Context, in *GetLSDBRequest, opts ...grpc.CallOption) (*GetLSDBResponse, error)
+	GetCounters(ctx context.Context, in *GetCountersRequest, opts ...grpc.CallOption) (*GetCountersResponse, error)
+	DumpRoutes(ctx context.Context, in *DumpRoutesRequest, opts ...grpc.CallOption) (*DumpRoutesResponse, error)
 }
 
 type isisServiceClient struct {
@@ -48,12 +50,32 @@ func (c *isisServiceClient) GetLSDB(ctx context.Context, in *GetLSDBRequest, opt
 	return out, nil
 }
 
+func (c *isisServiceClient) GetCounters(ctx context.Context, in *GetCountersRequest, opts ...grpc.CallOption) (*GetCountersResponse, error) {
+	out := new(GetCountersResponse)
+	err := c.cc.Invoke(ctx, "/bio.isis.IsisService/GetCounters", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *isisServiceClient) DumpRoutes(ctx context.Context, in *DumpRoutesRequest, opts ...grpc.CallOption) (*DumpRoutesResponse, error) {
+	out := new(DumpRoutesResponse)
+	err := c.cc.Invoke(ctx, "/bio.isis.IsisService/DumpRoutes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // IsisServiceServer is the server API for IsisService service.
 // All implementations must embed UnimplementedIsisServiceServer
 // for forward compatibility
 type IsisServiceServer interface {
 	ListAdjacencies(context.Context, *ListAdjacenciesRequest) (*ListAdjacenciesResponse, error)
 	GetLSDB(context.Context, *GetLSDBRequest) (*GetLSDBResponse, error)
+	GetCounters(context.Context, *GetCountersRequest) (*GetCountersResponse, error)
+	DumpRoutes(context.Context, *DumpRoutesRequest) (*DumpRoutesResponse, error)
 	mustEmbedUnimplementedIsisServiceServer()
 }
 
@@ -67,6 +89,12 @@ func (UnimplementedIsisServiceServer) ListAdjacencies(context.Context, *ListAdja
 func (UnimplementedIsisServiceServer) GetLSDB(context.Context, *GetLSDBRequest) (*GetLSDBResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLSDB not implemented")
 }
+func (UnimplementedIsisServiceServer) GetCounters(context.Context, *GetCountersRequest) (*GetCountersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCounters not implemented")
+}
+func (UnimplementedIsisServiceServer) DumpRoutes(context.Context, *DumpRoutesRequest) (*DumpRoutesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DumpRoutes not implemented")
+}
 func (UnimplementedIsisServiceServer) mustEmbedUnimplementedIsisServiceServer() {}
 
 // UnsafeIsisServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -116,6 +144,42 @@ func _IsisService_GetLSDB_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IsisService_GetCounters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCountersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IsisServiceServer).GetCounters(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bio.isis.IsisService/GetCounters",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IsisServiceServer).GetCounters(ctx, req.(*GetCountersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IsisService_DumpRoutes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DumpRoutesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IsisServiceServer).DumpRoutes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bio.isis.IsisService/DumpRoutes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IsisServiceServer).DumpRoutes(ctx, req.(*DumpRoutesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // IsisService_ServiceDesc is the grpc.ServiceDesc for IsisService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -131,6 +195,14 @@ var IsisService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLSDB",
 			Handler:    _IsisService_GetLSDB_Handler,
 		},
+		{
+			MethodName: "GetCounters",
+			Handler:    _IsisService_GetCounters_Handler,
+		},
+		{
+			MethodName: "DumpRoutes",
+			Handler:    _IsisService_DumpRoutes_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "protocols/isis/api/isis.proto",
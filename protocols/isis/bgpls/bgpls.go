@@ -0,0 +1,148 @@
+package bgpls
+
+import (
+	"sort"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	bgppacket "github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/server"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+)
+
+// LSDBGetter provides a snapshot of the ISIS LSDB and the level it belongs to
+type LSDBGetter interface {
+	GetLSDB() []*server.LSDBEntry
+	LSDBLevel() int
+}
+
+// Source translates the ISIS LSDB into BGP-LS NLRIs (RFC7752)
+type Source struct {
+	lsdb            LSDBGetter
+	asn             uint32
+	bgplsIdentifier uint32
+}
+
+// NewSource creates a new BGP-LS source exporting the topology of lsdb. asn and bgplsIdentifier are
+// added to all node descriptors.
+func NewSource(lsdb LSDBGetter, asn uint32, bgplsIdentifier uint32) *Source {
+	return &Source{
+		lsdb:            lsdb,
+		asn:             asn,
+		bgplsIdentifier: bgplsIdentifier,
+	}
+}
+
+// LinkStateNLRIs returns the node and link NLRIs describing the current LSDB
+func (s *Source) LinkStateNLRIs() []*bgppacket.LinkStateNLRI {
+	entries := s.lsdb.GetLSDB()
+	lsps := make([]*packet.LSPDU, 0, len(entries))
+	for _, e := range entries {
+		lsps = append(lsps, e.LSPDU())
+	}
+
+	return Translate(lsps, protocolID(s.lsdb.LSDBLevel()), s.asn, s.bgplsIdentifier)
+}
+
+func protocolID(level int) uint8 {
+	if level == 1 {
+		return bgppacket.LinkStateProtocolISISL1
+	}
+
+	return bgppacket.LinkStateProtocolISISL2
+}
+
+// Translate converts ISIS LSPs into BGP-LS node and link NLRIs of protocol protocolID sorted by their wire
+// representation
+func Translate(lsps []*packet.LSPDU, protocolID uint8, asn uint32, bgplsIdentifier uint32) []*bgppacket.LinkStateNLRI {
+	nlris := make(map[string]*bgppacket.LinkStateNLRI)
+	add := func(n *bgppacket.LinkStateNLRI) {
+		nlris[n.Key()] = n
+	}
+
+	for _, lsp := range lsps {
+		// Skip LSPs we only know from SNPs and purged ones
+		if lsp.SequenceNumber == 0 || lsp.RemainingLifetime == 0 {
+			continue
+		}
+
+		local := nodeDescriptor(types.SourceID{
+			SystemID:  lsp.LSPID.SystemID,
+			CircuitID: lsp.LSPID.PseudonodeID,
+		}, asn, bgplsIdentifier)
+
+		add(&bgppacket.LinkStateNLRI{
+			Type:       bgppacket.LinkStateNodeNLRIType,
+			ProtocolID: protocolID,
+			LocalNode:  local,
+		})
+
+		for _, tlv := range lsp.TLVs {
+			reach, ok := tlv.(*packet.ExtendedISReachabilityTLV)
+			if !ok {
+				continue
+			}
+
+			for _, n := range reach.Neighbors {
+				add(&bgppacket.LinkStateNLRI{
+					Type:       bgppacket.LinkStateLinkNLRIType,
+					ProtocolID: protocolID,
+					LocalNode:  local,
+					RemoteNode: nodeDescriptor(n.NeighborID, asn, bgplsIdentifier),
+					Link:       linkDescriptor(n),
+				})
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(nlris))
+	for k := range nlris {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	ret := make([]*bgppacket.LinkStateNLRI, 0, len(keys))
+	for _, k := range keys {
+		ret = append(ret, nlris[k])
+	}
+
+	return ret
+}
+
+// nodeDescriptor builds the descriptor of a router or, if id has a circuit ID, a pseudonode
+func nodeDescriptor(id types.SourceID, asn uint32, bgplsIdentifier uint32) *bgppacket.LinkStateNodeDescriptor {
+	igpRouterID := make([]byte, 0, len(id.SystemID)+1)
+	igpRouterID = append(igpRouterID, id.SystemID[:]...)
+	if id.CircuitID != 0 {
+		igpRouterID = append(igpRouterID, id.CircuitID)
+	}
+
+	return &bgppacket.LinkStateNodeDescriptor{
+		ASN:             asn,
+		BGPLSIdentifier: bgplsIdentifier,
+		IGPRouterID:     igpRouterID,
+	}
+}
+
+func linkDescriptor(n *packet.ExtendedISReachabilityNeighbor) *bgppacket.LinkStateLinkDescriptor {
+	d := &bgppacket.LinkStateLinkDescriptor{}
+
+	for _, tlv := range n.SubTLVs {
+		switch t := tlv.(type) {
+		case *packet.LinkLocalRemoteIdentifiersSubTLV:
+			d.LinkIdentifiers = &bgppacket.LinkStateLinkIdentifiers{
+				Local:  t.Local,
+				Remote: t.Remote,
+			}
+		case *packet.IPv4AddressSubTLV:
+			switch t.TLVType {
+			case packet.IPv4InterfaceAddressSubTLVType:
+				d.IPv4InterfaceAddress = bnet.IPv4(t.Address).Dedup()
+			case packet.IPv4NeighborAddressSubTLVType:
+				d.IPv4NeighborAddress = bnet.IPv4(t.Address).Dedup()
+			}
+		}
+	}
+
+	return d
+}
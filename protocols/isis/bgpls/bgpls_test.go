@@ -0,0 +1,132 @@
+package bgpls
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	bgppacket "github.com/bio-routing/bio-rd/protocols/bgp/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTranslate(t *testing.T) {
+	r1 := types.SystemID{0, 0, 0, 0, 0, 1}
+	r2 := types.SystemID{0, 0, 0, 0, 0, 2}
+
+	isReach := func(neighbor types.SystemID, local uint32, remote uint32, ifAddr uint32, neighAddr uint32) *packet.ExtendedISReachabilityTLV {
+		n := packet.NewExtendedISReachabilityNeighbor(types.SourceID{SystemID: neighbor}, 10)
+		n.SubTLVs = append(n.SubTLVs,
+			packet.NewLinkLocalRemoteIdentifiersSubTLV(local, remote),
+			packet.NewIPv4InterfaceAddressSubTLV(ifAddr),
+			packet.NewIPv4NeighborAddressSubTLV(neighAddr),
+		)
+
+		tlv := packet.NewExtendedISReachabilityTLV()
+		tlv.Neighbors = append(tlv.Neighbors, n)
+		return tlv
+	}
+
+	lsps := []*packet.LSPDU{
+		{
+			RemainingLifetime: 1200,
+			SequenceNumber:    5,
+			LSPID:             packet.LSPID{SystemID: r2},
+			TLVs: []packet.TLV{
+				isReach(r1, 20, 10, 0x0a000002, 0x0a000001),
+			},
+		},
+		{
+			RemainingLifetime: 1200,
+			SequenceNumber:    3,
+			LSPID:             packet.LSPID{SystemID: r1},
+			TLVs: []packet.TLV{
+				packet.NewDynamicHostnameTLV([]byte("r1")),
+				isReach(r2, 10, 20, 0x0a000001, 0x0a000002),
+			},
+		},
+		{
+			// Only known from a CSNP
+			RemainingLifetime: 1200,
+			SequenceNumber:    0,
+			LSPID:             packet.LSPID{SystemID: types.SystemID{0, 0, 0, 0, 0, 3}},
+		},
+		{
+			// Purged
+			RemainingLifetime: 0,
+			SequenceNumber:    7,
+			LSPID:             packet.LSPID{SystemID: types.SystemID{0, 0, 0, 0, 0, 4}},
+		},
+	}
+
+	node := func(id types.SystemID) *bgppacket.LinkStateNodeDescriptor {
+		return &bgppacket.LinkStateNodeDescriptor{
+			ASN:             65000,
+			BGPLSIdentifier: 1,
+			IGPRouterID:     id[:],
+		}
+	}
+
+	expected := []*bgppacket.LinkStateNLRI{
+		{
+			Type:       bgppacket.LinkStateNodeNLRIType,
+			ProtocolID: bgppacket.LinkStateProtocolISISL2,
+			LocalNode:  node(r1),
+		},
+		{
+			Type:       bgppacket.LinkStateNodeNLRIType,
+			ProtocolID: bgppacket.LinkStateProtocolISISL2,
+			LocalNode:  node(r2),
+		},
+		{
+			Type:       bgppacket.LinkStateLinkNLRIType,
+			ProtocolID: bgppacket.LinkStateProtocolISISL2,
+			LocalNode:  node(r1),
+			RemoteNode: node(r2),
+			Link: &bgppacket.LinkStateLinkDescriptor{
+				LinkIdentifiers:      &bgppacket.LinkStateLinkIdentifiers{Local: 10, Remote: 20},
+				IPv4InterfaceAddress: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+				IPv4NeighborAddress:  bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+			},
+		},
+		{
+			Type:       bgppacket.LinkStateLinkNLRIType,
+			ProtocolID: bgppacket.LinkStateProtocolISISL2,
+			LocalNode:  node(r2),
+			RemoteNode: node(r1),
+			Link: &bgppacket.LinkStateLinkDescriptor{
+				LinkIdentifiers:      &bgppacket.LinkStateLinkIdentifiers{Local: 20, Remote: 10},
+				IPv4InterfaceAddress: bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+				IPv4NeighborAddress:  bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			},
+		},
+	}
+
+	assert.Equal(t, expected, Translate(lsps, bgppacket.LinkStateProtocolISISL2, 65000, 1))
+}
+
+func TestTranslatePseudonode(t *testing.T) {
+	r1 := types.SystemID{0, 0, 0, 0, 0, 1}
+
+	n := packet.NewExtendedISReachabilityNeighbor(types.SourceID{SystemID: r1, CircuitID: 2}, 10)
+	tlv := packet.NewExtendedISReachabilityTLV()
+	tlv.Neighbors = append(tlv.Neighbors, n)
+
+	lsps := []*packet.LSPDU{
+		{
+			RemainingLifetime: 1200,
+			SequenceNumber:    1,
+			LSPID:             packet.LSPID{SystemID: r1},
+			TLVs:              []packet.TLV{tlv},
+		},
+	}
+
+	nlris := Translate(lsps, bgppacket.LinkStateProtocolISISL2, 0, 0)
+	assert.Len(t, nlris, 2)
+	assert.Equal(t, []byte{0, 0, 0, 0, 0, 1, 2}, nlris[1].RemoteNode.IGPRouterID)
+}
+
+func TestProtocolID(t *testing.T) {
+	assert.Equal(t, uint8(bgppacket.LinkStateProtocolISISL1), protocolID(1))
+	assert.Equal(t, uint8(bgppacket.LinkStateProtocolISISL2), protocolID(2))
+}
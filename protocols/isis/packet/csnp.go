@@ -39,47 +39,28 @@ func compareLSPIDs(lspIDA, lspIDB LSPID) bool {
 	return lspIDA.PseudonodeID < lspIDB.PseudonodeID
 }
 
-// NewCSNPs creates the necessary number of CSNP PDUs to carry all LSPEntries
+// NewCSNPs creates the necessary number of CSNP PDUs to carry all LSPEntries. The ranges of the CSNPs are
+// contiguous and cover all possible LSPIDs so that a receiver can tell for any LSP if we described it.
 func NewCSNPs(sourceID types.SourceID, lspEntries []*LSPEntry, maxPDULen int) []CSNP {
-	left := len(lspEntries)
-	lspsPerCSNP := (maxPDULen - CSNPMinLen) / LSPEntryLen
-	numCSNPs := int(math.Ceil(float64(left) / float64(lspsPerCSNP)))
-	if numCSNPs == 0 {
+	lspsPerCSNP := lspEntriesPerPDU(maxPDULen - CSNPMinLen)
+	if len(lspEntries) == 0 || lspsPerCSNP == 0 {
 		return nil
 	}
 
-	res := make([]CSNP, numCSNPs)
-
 	sort.Slice(lspEntries, func(a, b int) bool {
-		for i := 0; i < len(lspEntries[a].LSPID.SystemID); i++ {
-			if lspEntries[a].LSPID.SystemID[i] < lspEntries[b].LSPID.SystemID[i] {
-				return true
-			}
-			if lspEntries[a].LSPID.SystemID[i] > lspEntries[b].LSPID.SystemID[i] {
-				return false
-			}
-		}
-
-		return lspEntries[a].LSPID.PseudonodeID < lspEntries[b].LSPID.PseudonodeID
+		return lspEntries[a].LSPID.Compare(lspEntries[b].LSPID) < 0
 	})
 
-	for i := 0; i < numCSNPs; i++ {
-		start := i * lspsPerCSNP
-		end := umath.Min(lspsPerCSNP, left)
-
-		entries := lspEntries[start : start+end]
-		tlvs := []TLV{
-			NewLSPEntriesTLV(entries),
-		}
-		csnp := newCSNP(sourceID, entries[0].LSPID, entries[len(entries)-1].LSPID, tlvs)
-		if csnp == nil {
-			continue
-		}
+	res := make([]CSNP, 0, int(math.Ceil(float64(len(lspEntries))/float64(lspsPerCSNP))))
+	startLSPID := LSPID{}
+	for start := 0; start < len(lspEntries); start += lspsPerCSNP {
+		entries := lspEntries[start : start+umath.Min(lspsPerCSNP, len(lspEntries)-start)]
+		endLSPID := entries[len(entries)-1].LSPID
 
-		res[i] = *csnp
+		res = append(res, *newCSNP(sourceID, startLSPID, endLSPID, newLSPEntriesTLVs(entries)))
+		startLSPID = endLSPID.next()
 	}
 
-	res[0].StartLSPID = LSPID{}
 	res[len(res)-1].EndLSPID = LSPID{
 		SystemID:     types.SystemID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
 		PseudonodeID: 0xff,
@@ -89,6 +70,23 @@ func NewCSNPs(sourceID types.SourceID, lspEntries []*LSPEntry, maxPDULen int) []
 	return res
 }
 
+// lspEntriesPerPDU gets the number of LSP entries fitting into space bytes of LSP Entries TLVs
+func lspEntriesPerPDU(space int) int {
+	fullTLVs := space / (tlvBaseLen + LSPEntriesTLVMaxEntries*LSPEntryLen)
+	rest := space%(tlvBaseLen+LSPEntriesTLVMaxEntries*LSPEntryLen) - tlvBaseLen
+
+	return fullTLVs*LSPEntriesTLVMaxEntries + umath.Max(rest, 0)/LSPEntryLen
+}
+
+func newLSPEntriesTLVs(lspEntries []*LSPEntry) []TLV {
+	tlvs := make([]TLV, 0, len(lspEntries)/LSPEntriesTLVMaxEntries+1)
+	for start := 0; start < len(lspEntries); start += LSPEntriesTLVMaxEntries {
+		tlvs = append(tlvs, NewLSPEntriesTLV(lspEntries[start:start+umath.Min(LSPEntriesTLVMaxEntries, len(lspEntries)-start)]))
+	}
+
+	return tlvs
+}
+
 func newCSNP(sourceID types.SourceID, startLSPID LSPID, endLSPID LSPID, tlvs []TLV) *CSNP {
 	tlvsLen := uint16(0)
 	for i := range tlvs {
@@ -112,15 +110,16 @@ func (c *CSNP) GetLSPEntries() []*LSPEntry {
 }
 
 func getLSPEntries(tlvs []TLV) []*LSPEntry {
+	var ret []*LSPEntry
 	for _, tlv := range tlvs {
 		if tlv.Type() != LSPEntriesTLVType {
 			continue
 		}
 
-		return tlv.Value().(*LSPEntriesTLV).LSPEntries
+		ret = append(ret, tlv.Value().(*LSPEntriesTLV).LSPEntries...)
 	}
 
-	return nil
+	return ret
 }
 
 // RangeContainsLSPID checks if lspID is within the range of described LSPs of this CSNP
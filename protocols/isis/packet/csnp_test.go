@@ -173,7 +173,8 @@ func TestNewCSNPs(t *testing.T) {
 					},
 					StartLSPID: LSPID{
 						SystemID:     types.SystemID{10, 20, 30, 40, 50, 60},
-						PseudonodeID: 200,
+						PseudonodeID: 100,
+						LSPNumber:    1,
 					},
 					EndLSPID: LSPID{
 						SystemID:     types.SystemID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
@@ -266,7 +267,8 @@ func TestNewCSNPs(t *testing.T) {
 					},
 					StartLSPID: LSPID{
 						SystemID:     types.SystemID{10, 20, 30, 40, 50, 60},
-						PseudonodeID: 200,
+						PseudonodeID: 100,
+						LSPNumber:    1,
 					},
 					EndLSPID: LSPID{
 						SystemID:     types.SystemID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
@@ -301,6 +303,90 @@ func TestNewCSNPs(t *testing.T) {
 	}
 }
 
+func TestNewCSNPsRanges(t *testing.T) {
+	tests := []struct {
+		name         string
+		lsps         int
+		maxPDULength int
+		expectedLSPs []int
+	}{
+		{
+			name:         "Many LSPs with MTU sized CSNPs",
+			lsps:         200,
+			maxPDULength: 1492,
+			expectedLSPs: []int{90, 90, 20},
+		},
+		{
+			name:         "Two LSP Entries TLVs per CSNP",
+			lsps:         40,
+			maxPDULength: CSNPMinLen + 2*tlvBaseLen + 16*LSPEntryLen,
+			expectedLSPs: []int{16, 16, 8},
+		},
+		{
+			name:         "Odd number of LSPs",
+			lsps:         7,
+			maxPDULength: CSNPMinLen + tlvBaseLen + 3*LSPEntryLen,
+			expectedLSPs: []int{3, 3, 1},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lspEntries := make([]*LSPEntry, 0, test.lsps)
+			for i := test.lsps - 1; i >= 0; i-- {
+				lspEntries = append(lspEntries, &LSPEntry{
+					SequenceNumber: 1,
+					LSPID: LSPID{
+						SystemID:  types.SystemID{10, 20, 30, 40, 50, uint8(i / 4)},
+						LSPNumber: uint8(i % 4),
+					},
+				})
+			}
+
+			csnps := NewCSNPs(types.SourceID{}, lspEntries, test.maxPDULength)
+			if !assert.Len(t, csnps, len(test.expectedLSPs)) {
+				return
+			}
+
+			assert.Equal(t, LSPID{}, csnps[0].StartLSPID)
+			assert.Equal(t, LSPID{
+				SystemID:     types.SystemID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff},
+				PseudonodeID: 0xff,
+				LSPNumber:    0xff,
+			}, csnps[len(csnps)-1].EndLSPID)
+
+			prev := LSPID{}
+			for i := range csnps {
+				if i > 0 {
+					assert.Equal(t, csnps[i-1].EndLSPID.next(), csnps[i].StartLSPID, "ranges must be contiguous")
+				}
+
+				assert.LessOrEqual(t, int(csnps[i].PDULength), test.maxPDULength)
+
+				buf := bytes.NewBuffer(nil)
+				csnps[i].Serialize(buf)
+
+				decoded, err := DecodeCSNP(buf)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				entries := decoded.GetLSPEntries()
+				assert.Len(t, entries, test.expectedLSPs[i])
+				for _, e := range entries {
+					assert.Equal(t, 1, e.LSPID.Compare(prev), "LSP entries must be sorted")
+					assert.True(t, csnps[i].RangeContainsLSPID(e.LSPID))
+					if i > 0 {
+						assert.False(t, csnps[i-1].RangeContainsLSPID(e.LSPID))
+					}
+
+					prev = e.LSPID
+				}
+			}
+		})
+	}
+}
+
 func TestCSNPSerialize(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -14,6 +14,16 @@ const (
 	LSPIDLen    = 8
 	LSPDUMinLen = 27
 	MODX        = 5802
+
+	// LSPDUAttachedDefaultMetric is the ATT bit for the default metric in the type block of an LSPDU
+	LSPDUAttachedDefaultMetric = 0x08
+	lspduAttachedMask          = 0x78
+
+	// LSPDUISTypeL1 marks an LSPDU in the type block as originated by a level 1 IS
+	LSPDUISTypeL1 = 0x01
+
+	// LSPDUISTypeL1L2 marks an LSPDU in the type block as originated by a level 1 and level 2 IS
+	LSPDUISTypeL1L2 = 0x03
 )
 
 // LSPID represents a Link State Packet ID
@@ -54,9 +64,39 @@ func (l *LSPID) Compare(m LSPID) int {
 		return -1
 	}
 
+	if l.LSPNumber > m.LSPNumber {
+		return 1
+	}
+
+	if l.LSPNumber < m.LSPNumber {
+		return -1
+	}
+
 	return 0
 }
 
+// next gets the LSPID following l. The highest possible LSPID wraps around to the lowest.
+func (l LSPID) next() LSPID {
+	l.LSPNumber++
+	if l.LSPNumber != 0 {
+		return l
+	}
+
+	l.PseudonodeID++
+	if l.PseudonodeID != 0 {
+		return l
+	}
+
+	for i := len(l.SystemID) - 1; i >= 0; i-- {
+		l.SystemID[i]++
+		if l.SystemID[i] != 0 {
+			break
+		}
+	}
+
+	return l
+}
+
 // LSPDU represents a link state PDU
 type LSPDU struct {
 	Length            uint16
@@ -68,6 +108,11 @@ type LSPDU struct {
 	TLVs              []TLV
 }
 
+// Attached returns if one of the ATT bits is set. An attached level 1 level 2 IS can reach other areas.
+func (l *LSPDU) Attached() bool {
+	return l.TypeBlock&lspduAttachedMask != 0
+}
+
 func (l *LSPDU) Copy() *LSPDU {
 	ret := *l
 	ret.TLVs = make([]TLV, 0, len(l.TLVs))
@@ -130,6 +175,15 @@ func (l *LSPDU) SetChecksum() {
 	l.Checksum = csum(buf.Bytes())
 }
 
+// ChecksumValid checks if the checksum of an LSPDU matches its content
+func (l *LSPDU) ChecksumValid() bool {
+	c := *l
+	c.Checksum = 0
+	c.SetChecksum()
+
+	return c.Checksum == l.Checksum
+}
+
 // SerializeChecksumRelevant serializes all fields after the Remaining Lifetime field.
 func (l *LSPDU) SerializeChecksumRelevant(buf *bytes.Buffer) {
 	l.LSPID.Serialize(buf)
@@ -69,6 +69,20 @@ func TestLSPIDCompare(t *testing.T) {
 			},
 			expected: -1,
 		},
+		{
+			name: "LSP number",
+			a: LSPID{
+				SystemID:     types.SystemID{1, 2, 3, 4, 5, 7},
+				PseudonodeID: 100,
+				LSPNumber:    2,
+			},
+			b: LSPID{
+				SystemID:     types.SystemID{1, 2, 3, 4, 5, 7},
+				PseudonodeID: 100,
+				LSPNumber:    1,
+			},
+			expected: 1,
+		},
 	}
 
 	for _, test := range tests {
@@ -77,6 +91,40 @@ func TestLSPIDCompare(t *testing.T) {
 	}
 }
 
+func TestLSPIDNext(t *testing.T) {
+	tests := []struct {
+		name     string
+		lspID    LSPID
+		expected LSPID
+	}{
+		{
+			name:     "LSP number",
+			lspID:    LSPID{SystemID: types.SystemID{1, 2, 3, 4, 5, 6}, PseudonodeID: 1, LSPNumber: 1},
+			expected: LSPID{SystemID: types.SystemID{1, 2, 3, 4, 5, 6}, PseudonodeID: 1, LSPNumber: 2},
+		},
+		{
+			name:     "pseudonode ID",
+			lspID:    LSPID{SystemID: types.SystemID{1, 2, 3, 4, 5, 6}, PseudonodeID: 1, LSPNumber: 0xff},
+			expected: LSPID{SystemID: types.SystemID{1, 2, 3, 4, 5, 6}, PseudonodeID: 2},
+		},
+		{
+			name:     "system ID",
+			lspID:    LSPID{SystemID: types.SystemID{1, 2, 3, 4, 0xff, 0xff}, PseudonodeID: 0xff, LSPNumber: 0xff},
+			expected: LSPID{SystemID: types.SystemID{1, 2, 3, 5, 0, 0}},
+		},
+		{
+			name:     "wrap around",
+			lspID:    LSPID{SystemID: types.SystemID{0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, PseudonodeID: 0xff, LSPNumber: 0xff},
+			expected: LSPID{},
+		},
+	}
+
+	for _, test := range tests {
+		next := test.lspID.next()
+		assert.Equalf(t, test.expected, next, "Test %q", test.name)
+	}
+}
+
 func TestSerializeLSPDU(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -300,3 +348,43 @@ func TestSetChecksum(t *testing.T) {
 		assert.Equal(t, test.expected, test.lspdu.Checksum, test.name)
 	}
 }
+
+func TestChecksumValid(t *testing.T) {
+	lspdu := &LSPDU{
+		Length:            29,
+		RemainingLifetime: 3591,
+		LSPID: LSPID{
+			SystemID: types.SystemID{10, 20, 30, 40, 50, 60},
+		},
+		SequenceNumber: 1,
+		TypeBlock:      3,
+		TLVs: []TLV{
+			NewAreaAddressesTLV([]types.AreaID{{0x49, 0, 1, 0, 16}}),
+		},
+	}
+
+	assert.False(t, lspdu.ChecksumValid(), "zero checksum")
+
+	lspdu.SetChecksum()
+	assert.True(t, lspdu.ChecksumValid())
+
+	lspdu.SequenceNumber++
+	assert.False(t, lspdu.ChecksumValid(), "content changed")
+}
+
+func TestLSPDUAttached(t *testing.T) {
+	tests := []struct {
+		typeBlock uint8
+		expected  bool
+	}{
+		{typeBlock: LSPDUISTypeL1L2, expected: false},
+		{typeBlock: LSPDUISTypeL1L2 | LSPDUAttachedDefaultMetric, expected: true},
+		{typeBlock: LSPDUISTypeL1L2 | 0x40, expected: true}, // Error metric
+		{typeBlock: LSPDUISTypeL1L2 | 0x80 | 0x04, expected: false},
+	}
+
+	for _, test := range tests {
+		l := &LSPDU{TypeBlock: test.typeBlock}
+		assert.Equal(t, test.expected, l.Attached(), "type block %x", test.typeBlock)
+	}
+}
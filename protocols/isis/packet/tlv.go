@@ -77,6 +77,18 @@ func readTLV(buf *bytes.Buffer) (TLV, error) {
 		tlv, err = readISNeighborsTLV(buf, tlvType, tlvLength)
 	case LSPEntriesTLVType:
 		tlv, err = readLSPEntriesTLV(buf, tlvType, tlvLength)
+	case ExtendedISReachabilityType:
+		tlv, err = readExtendedISReachabilityTLV(buf, tlvType, tlvLength)
+	case ISReachabilityTLVType:
+		tlv, err = readISReachabilityTLV(buf, tlvType, tlvLength)
+	case IPInternalReachabilityTLVType:
+		tlv, err = readIPInternalReachabilityTLV(buf, tlvType, tlvLength)
+	case AuthenticationType:
+		tlv, err = readAuthenticationTLV(buf, tlvType, tlvLength)
+	case PurgeOriginatorIdentificationTLVType:
+		tlv, err = readPurgeOriginatorIdentificationTLV(buf, tlvType, tlvLength)
+	case RouterCapabilityTLVType:
+		tlv, err = readRouterCapabilityTLV(buf, tlvType, tlvLength)
 	default:
 		tlv, err = readUnknownTLV(buf, tlvType, tlvLength)
 	}
@@ -0,0 +1,87 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bio-routing/bio-rd/util/decode"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+const (
+	// AdministrativeTagSubTLVType is the type value of a 32-bit Administrative Tag Sub TLV of the Extended IP
+	// Reachability TLV (RFC5130)
+	AdministrativeTagSubTLVType = 1
+
+	administrativeTagLength = 4
+)
+
+// AdministrativeTagSubTLV is a 32-bit Administrative Tag Sub TLV carrying one or more tags of a prefix
+type AdministrativeTagSubTLV struct {
+	TLVType   uint8
+	TLVLength uint8
+	Tags      []uint32
+}
+
+// NewAdministrativeTagSubTLV creates a new AdministrativeTagSubTLV
+func NewAdministrativeTagSubTLV(tags ...uint32) *AdministrativeTagSubTLV {
+	return &AdministrativeTagSubTLV{
+		TLVType:   AdministrativeTagSubTLVType,
+		TLVLength: uint8(len(tags) * administrativeTagLength),
+		Tags:      tags,
+	}
+}
+
+func readAdministrativeTagSubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*AdministrativeTagSubTLV, error) {
+	if tlvLength == 0 || tlvLength%administrativeTagLength != 0 {
+		return nil, fmt.Errorf("invalid length %d of sub TLV type %d, expected a multiple of %d", tlvLength, tlvType, administrativeTagLength)
+	}
+
+	pdu := &AdministrativeTagSubTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+		Tags:      make([]uint32, tlvLength/administrativeTagLength),
+	}
+
+	fields := make([]interface{}, 0, len(pdu.Tags))
+	for i := range pdu.Tags {
+		fields = append(fields, &pdu.Tags[i])
+	}
+
+	err := decode.Decode(buf, fields)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	return pdu, nil
+}
+
+func (a *AdministrativeTagSubTLV) Copy() TLV {
+	ret := *a
+	ret.Tags = append(make([]uint32, 0, len(a.Tags)), a.Tags...)
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (a *AdministrativeTagSubTLV) Type() uint8 {
+	return a.TLVType
+}
+
+// Length gets the length of the TLV
+func (a *AdministrativeTagSubTLV) Length() uint8 {
+	return a.TLVLength
+}
+
+// Value returns the TLV itself
+func (a *AdministrativeTagSubTLV) Value() interface{} {
+	return a
+}
+
+// Serialize serializes an AdministrativeTagSubTLV
+func (a *AdministrativeTagSubTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(a.TLVType)
+	buf.WriteByte(a.TLVLength)
+	for _, tag := range a.Tags {
+		buf.Write(convert.Uint32Byte(tag))
+	}
+}
@@ -1,8 +1,18 @@
 package packet
 
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bio-routing/bio-rd/util/decode"
+)
+
 // AuthenticationType is the type value of an authentication TLV
 const AuthenticationType = 10
 
+// AuthenticationTypeCleartext is the authentication type of a cleartext password (ISO 10589)
+const AuthenticationTypeCleartext = 1
+
 // AuthenticationTLV represents an authentication TLV
 type AuthenticationTLV struct {
 	TLVType            uint8
@@ -10,3 +20,68 @@ type AuthenticationTLV struct {
 	AuthenticationType uint8
 	Password           []byte
 }
+
+// NewCleartextAuthenticationTLV creates a new authentication TLV carrying a cleartext password
+func NewCleartextAuthenticationTLV(password []byte) *AuthenticationTLV {
+	return &AuthenticationTLV{
+		TLVType:            AuthenticationType,
+		TLVLength:          uint8(len(password) + 1),
+		AuthenticationType: AuthenticationTypeCleartext,
+		Password:           password,
+	}
+}
+
+// Copy copies the TLV
+func (a *AuthenticationTLV) Copy() TLV {
+	ret := *a
+	ret.Password = make([]byte, len(a.Password))
+	copy(ret.Password, a.Password)
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (a *AuthenticationTLV) Type() uint8 {
+	return a.TLVType
+}
+
+// Length gets the length of the TLV
+func (a *AuthenticationTLV) Length() uint8 {
+	return a.TLVLength
+}
+
+// Value returns the TLV itself
+func (a *AuthenticationTLV) Value() interface{} {
+	return a
+}
+
+func readAuthenticationTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*AuthenticationTLV, error) {
+	if tlvLength < 1 {
+		return nil, fmt.Errorf("authentication TLV too short")
+	}
+
+	pdu := &AuthenticationTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+		Password:  make([]byte, tlvLength-1),
+	}
+
+	fields := []interface{}{
+		&pdu.AuthenticationType,
+		&pdu.Password,
+	}
+
+	err := decode.Decode(buf, fields)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	return pdu, nil
+}
+
+// Serialize serializes an authentication TLV
+func (a *AuthenticationTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(a.TLVType)
+	buf.WriteByte(a.TLVLength)
+	buf.WriteByte(a.AuthenticationType)
+	buf.Write(a.Password)
+}
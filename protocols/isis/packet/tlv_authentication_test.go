@@ -0,0 +1,65 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuthenticationTLVSerialize(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    *AuthenticationTLV
+		expected []byte
+	}{
+		{
+			name:     "Cleartext",
+			input:    NewCleartextAuthenticationTLV([]byte("secret")),
+			expected: []byte{10, 7, 1, 's', 'e', 'c', 'r', 'e', 't'},
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(nil)
+		test.input.Serialize(buf)
+
+		assert.Equalf(t, test.expected, buf.Bytes(), "Test %q", test.name)
+	}
+}
+
+func TestReadAuthenticationTLV(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []byte
+		wantFail bool
+		expected *AuthenticationTLV
+	}{
+		{
+			name:     "Cleartext",
+			input:    []byte{10, 7, 1, 's', 'e', 'c', 'r', 'e', 't'},
+			expected: NewCleartextAuthenticationTLV([]byte("secret")),
+		},
+		{
+			name:     "Empty",
+			input:    []byte{10, 0},
+			wantFail: true,
+		},
+		{
+			name:     "Incomplete",
+			input:    []byte{10, 7, 1, 's', 'e'},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		tlv, err := readTLV(bytes.NewBuffer(test.input))
+		if test.wantFail {
+			assert.Errorf(t, err, "Test %q", test.name)
+			continue
+		}
+
+		assert.NoErrorf(t, err, "Test %q", test.name)
+		assert.Equalf(t, test.expected, tlv, "Test %q", test.name)
+	}
+}
@@ -22,6 +22,11 @@ func TestDynamicHostnameTLVSerialize(t *testing.T) {
 			},
 			expected: []byte{137, 5, 1, 2, 3, 4, 5},
 		},
+		{
+			name:     "Hostname",
+			input:    NewDynamicHostnameTLV([]byte("core01")),
+			expected: []byte{137, 6, 'c', 'o', 'r', 'e', '0', '1'},
+		},
 	}
 
 	for _, test := range tests {
@@ -52,6 +57,12 @@ func TestReadDynamicHostnameTLV(t *testing.T) {
 				Hostname:  []byte{1, 2, 3, 4, 5},
 			},
 		},
+		{
+			name:     "Hostname",
+			input:    []byte("core01.example.com"),
+			tlvLen:   6,
+			expected: NewDynamicHostnameTLV([]byte("core01")),
+		},
 		{
 			name: "Incomplete",
 			input: []byte{
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 
+	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/util/decode"
 	"github.com/bio-routing/tflow2/convert"
 )
@@ -14,6 +15,9 @@ const (
 
 	// ExtendedIPReachabilityLength is the length of an Extended IP Reachability excluding Sub TLVs
 	ExtendedIPReachabilityLength = 9
+
+	upDownBit  = 0x80
+	subTLVsBit = 0x40
 )
 
 // ExtendedIPReachabilityTLV is an Extended IP Reachability TLV
@@ -72,18 +76,17 @@ func readExtendedIPReachabilityTLV(buf *bytes.Buffer, tlvType uint8, tlvLength u
 	pdu := NewExtendedIPReachabilityTLV()
 	pdu.TLVLength = tlvLength
 
-	toRead := tlvLength
-	for toRead > 0 {
-		extIPReach, err := readExtendedIPReachability(buf)
+	if buf.Len() < int(tlvLength) {
+		return nil, fmt.Errorf("TLV length %d exceeds remaining %d bytes", tlvLength, buf.Len())
+	}
+
+	tlvBuf := bytes.NewBuffer(buf.Next(int(tlvLength)))
+	for tlvBuf.Len() > 0 {
+		extIPReach, err := readExtendedIPReachability(tlvBuf)
 		if err != nil {
 			return nil, fmt.Errorf("unable to reach extended IP reachability: %w", err)
 		}
 
-		toRead -= ExtendedIPReachabilityLength
-		for i := range extIPReach.SubTLVs {
-			toRead -= extIPReach.SubTLVs[i].Length()
-		}
-
 		pdu.ExtendedIPReachabilities = append(pdu.ExtendedIPReachabilities, extIPReach)
 	}
 
@@ -95,6 +98,7 @@ type ExtendedIPReachability struct {
 	Metric         uint32
 	UDSubBitPfxLen uint8
 	Address        uint32
+	SubTLVLength   uint8
 	SubTLVs        []TLV
 }
 
@@ -121,8 +125,23 @@ func (e *ExtendedIPReachability) Copy() *ExtendedIPReachability {
 // AddExtendedIPReachability adds an extended IP reachability
 func (e *ExtendedIPReachabilityTLV) AddExtendedIPReachability(eipr *ExtendedIPReachability) {
 	e.ExtendedIPReachabilities = append(e.ExtendedIPReachabilities, eipr)
-	e.TLVLength += ExtendedIPReachabilityLength
-	// TODO: Add length of sub TLVs. They will be added as soon as we support for TE
+	e.TLVLength += eipr.Length()
+}
+
+// AddSubTLV adds a sub TLV to the ExtendedIPReachability and sets its sub TLV present bit
+func (e *ExtendedIPReachability) AddSubTLV(tlv TLV) {
+	e.UDSubBitPfxLen |= subTLVsBit
+	e.SubTLVLength += tlv.Length() + tlvBaseLen
+	e.SubTLVs = append(e.SubTLVs, tlv)
+}
+
+// Length returns the length of the ExtendedIPReachability including its sub TLVs
+func (e *ExtendedIPReachability) Length() uint8 {
+	if !e.hasSubTLVs() {
+		return ExtendedIPReachabilityLength
+	}
+
+	return ExtendedIPReachabilityLength + 1 + e.SubTLVLength
 }
 
 // Serialize serializes an ExtendedIPReachability
@@ -131,13 +150,28 @@ func (e *ExtendedIPReachability) Serialize(buf *bytes.Buffer) {
 	buf.WriteByte(e.UDSubBitPfxLen)
 	buf.Write(convert.Uint32Byte(e.Address))
 
+	if !e.hasSubTLVs() {
+		return
+	}
+
+	buf.WriteByte(e.SubTLVLength)
 	for i := range e.SubTLVs {
 		e.SubTLVs[i].Serialize(buf)
 	}
 }
 
+// UpDown returns if the up/down bit is set. Prefixes with the up/down bit set have been leaked from level 2 into level 1.
+func (e *ExtendedIPReachability) UpDown() bool {
+	return e.UDSubBitPfxLen&upDownBit != 0
+}
+
+// SetUpDown sets the up/down bit
+func (e *ExtendedIPReachability) SetUpDown() {
+	e.UDSubBitPfxLen |= upDownBit
+}
+
 func (e *ExtendedIPReachability) hasSubTLVs() bool {
-	return e.UDSubBitPfxLen&(uint8(1)<<6) == 64
+	return e.UDSubBitPfxLen&subTLVsBit != 0
 }
 
 // PfxLen returns the prefix length
@@ -163,16 +197,82 @@ func readExtendedIPReachability(buf *bytes.Buffer) (*ExtendedIPReachability, err
 		return e, nil
 	}
 
-	subTLVsLen := uint8(0)
-	err = decode.Decode(buf, []interface{}{&subTLVsLen})
+	err = decode.Decode(buf, []interface{}{&e.SubTLVLength})
 	if err != nil {
 		return nil, fmt.Errorf("unable to decode fields: %v", err)
 	}
 
-	toRead := subTLVsLen
-	for toRead > 0 {
-		// TODO: Read Sub TLVs
+	if buf.Len() < int(e.SubTLVLength) {
+		return nil, fmt.Errorf("sub TLV length %d exceeds remaining %d bytes", e.SubTLVLength, buf.Len())
+	}
+
+	subTLVBuf := bytes.NewBuffer(buf.Next(int(e.SubTLVLength)))
+	for subTLVBuf.Len() > 0 {
+		stlv, err := readExtendedIPReachabilitySubTLV(subTLVBuf)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read sub TLV: %w", err)
+		}
+
+		e.SubTLVs = append(e.SubTLVs, stlv)
 	}
 
 	return e, nil
 }
+
+func readExtendedIPReachabilitySubTLV(buf *bytes.Buffer) (TLV, error) {
+	tlvType := uint8(0)
+	tlvLength := uint8(0)
+
+	err := decode.Decode(buf, []interface{}{
+		&tlvType,
+		&tlvLength,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	switch tlvType {
+	case AdministrativeTagSubTLVType:
+		return readAdministrativeTagSubTLV(buf, tlvType, tlvLength)
+	case PrefixAttributeFlagsSubTLVType:
+		return readPrefixAttributeFlagsSubTLV(buf, tlvType, tlvLength)
+	case IPv4SourceRouterIDSubTLVType, IPv6SourceRouterIDSubTLVType:
+		return readSourceRouterIDSubTLV(buf, tlvType, tlvLength)
+	}
+
+	return readUnknownTLV(buf, tlvType, tlvLength)
+}
+
+// Tags returns the administrative tags of the ExtendedIPReachability (RFC5130)
+func (e *ExtendedIPReachability) Tags() []uint32 {
+	ret := make([]uint32, 0)
+	for _, stlv := range e.SubTLVs {
+		if t, ok := stlv.(*AdministrativeTagSubTLV); ok {
+			ret = append(ret, t.Tags...)
+		}
+	}
+
+	return ret
+}
+
+// PrefixAttributeFlags returns the flags of the Prefix Attribute Flags Sub TLV or 0 if there is none (RFC7794)
+func (e *ExtendedIPReachability) PrefixAttributeFlags() uint8 {
+	for _, stlv := range e.SubTLVs {
+		if f, ok := stlv.(*PrefixAttributeFlagsSubTLV); ok {
+			return f.Flags
+		}
+	}
+
+	return 0
+}
+
+// SourceRouterID returns the first IPv4 or IPv6 Source Router ID of the prefix or nil if there is none (RFC7794)
+func (e *ExtendedIPReachability) SourceRouterID() *bnet.IP {
+	for _, stlv := range e.SubTLVs {
+		if s, ok := stlv.(*SourceRouterIDSubTLV); ok {
+			return s.Address.Dedup()
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"testing"
 
+	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -104,6 +105,65 @@ func TestReadExtendedIPReachabilityTLV(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Two entries. First with administrative tag.",
+			input: []byte{
+				0, 0, 0, 100, // Metric
+				64 + 24,        // UDSubBitPfxLen (sub TLVs)
+				10, 20, 30, 40, // Address
+				6,                // Sub TLVs length
+				1, 4, 0, 0, 1, 0, // Administrative tag 256
+				0, 0, 0, 10, // Metric
+				32,          // UDSubBitPfxLen (no sub TLVs)
+				10, 0, 0, 1, // Address
+			},
+			expected: &ExtendedIPReachabilityTLV{
+				TLVType:   135,
+				TLVLength: 25,
+				ExtendedIPReachabilities: []*ExtendedIPReachability{
+					{
+						Metric:         100,
+						UDSubBitPfxLen: 88,
+						Address:        169090600,
+						SubTLVLength:   6,
+						SubTLVs: []TLV{
+							&AdministrativeTagSubTLV{
+								TLVType:   1,
+								TLVLength: 4,
+								Tags:      []uint32{256},
+							},
+						},
+					},
+					{
+						Metric:         10,
+						UDSubBitPfxLen: 32,
+						Address:        167772161,
+					},
+				},
+			},
+		},
+		{
+			name: "Sub TLVs length exceeds TLV",
+			input: []byte{
+				0, 0, 0, 100, // Metric
+				64 + 24,        // UDSubBitPfxLen (sub TLVs)
+				10, 20, 30, 40, // Address
+				8,                // Sub TLVs length
+				1, 4, 0, 0, 1, 0, // Administrative tag 256
+			},
+			wantFail: true,
+		},
+		{
+			name: "Invalid administrative tag length",
+			input: []byte{
+				0, 0, 0, 100, // Metric
+				64 + 24,        // UDSubBitPfxLen (sub TLVs)
+				10, 20, 30, 40, // Address
+				5,             // Sub TLVs length
+				1, 3, 0, 1, 0, // Administrative tag with 3 bytes
+			},
+			wantFail: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -126,3 +186,82 @@ func TestReadExtendedIPReachabilityTLV(t *testing.T) {
 		assert.Equal(t, test.expected, tlv, test.name)
 	}
 }
+
+func TestExtendedIPReachabilityUpDown(t *testing.T) {
+	e := NewExtendedIPReachability(10, 24, 169090560)
+	assert.False(t, e.UpDown())
+
+	e.SetUpDown()
+	assert.True(t, e.UpDown())
+	assert.Equal(t, uint8(24), e.PfxLen())
+	assert.False(t, e.hasSubTLVs())
+}
+
+func TestExtendedIPReachabilityTags(t *testing.T) {
+	e := NewExtendedIPReachability(10, 24, 169090560)
+	assert.Equal(t, uint8(ExtendedIPReachabilityLength), e.Length())
+	assert.Empty(t, e.Tags())
+
+	e.AddSubTLV(NewAdministrativeTagSubTLV(100, 200))
+	assert.True(t, e.hasSubTLVs())
+	assert.Equal(t, uint8(24), e.PfxLen())
+	assert.Equal(t, uint8(ExtendedIPReachabilityLength+1+10), e.Length())
+	assert.Equal(t, []uint32{100, 200}, e.Tags())
+
+	tlv := NewExtendedIPReachabilityTLV()
+	tlv.AddExtendedIPReachability(e)
+	assert.Equal(t, e.Length(), tlv.TLVLength)
+
+	buf := bytes.NewBuffer(nil)
+	tlv.Serialize(buf)
+	assert.Equal(t, []byte{
+		135, 20, // Type, Length
+		0, 0, 0, 10, // Metric
+		64 + 24,       // UDSubBitPfxLen (sub TLVs)
+		10, 20, 30, 0, // Address
+		10,                               // Sub TLVs length
+		1, 8, 0, 0, 0, 100, 0, 0, 0, 200, // Administrative tags
+	}, buf.Bytes())
+
+	buf.Next(tlvBaseLen)
+	res, err := readExtendedIPReachabilityTLV(buf, 135, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{100, 200}, res.ExtendedIPReachabilities[0].Tags())
+}
+
+func TestExtendedIPReachabilityPrefixAttributes(t *testing.T) {
+	e := NewExtendedIPReachability(10, 32, 167772161)
+	assert.Equal(t, uint8(0), e.PrefixAttributeFlags())
+	assert.Nil(t, e.SourceRouterID())
+
+	e.AddSubTLV(NewPrefixAttributeFlagsSubTLV(PrefixAttributeFlagNode | PrefixAttributeFlagReadvertisement))
+	e.AddSubTLV(NewSourceRouterIDSubTLV(bnet.IPv4FromOctets(10, 0, 0, 1)))
+	e.AddSubTLV(NewSourceRouterIDSubTLV(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1)))
+	assert.Equal(t, uint8(ExtendedIPReachabilityLength+1+3+6+18), e.Length())
+
+	tlv := NewExtendedIPReachabilityTLV()
+	tlv.AddExtendedIPReachability(e)
+
+	buf := bytes.NewBuffer(nil)
+	tlv.Serialize(buf)
+	assert.Equal(t, []byte{
+		135, 37, // Type, Length
+		0, 0, 0, 10, // Metric
+		64 + 32,     // UDSubBitPfxLen (sub TLVs)
+		10, 0, 0, 1, // Address
+		27,            // Sub TLVs length
+		4, 1, 64 + 32, // Prefix attribute flags (R, N)
+		11, 4, 10, 0, 0, 1, // IPv4 source router ID
+		12, 16, 0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, // IPv6 source router ID
+	}, buf.Bytes())
+
+	buf.Next(tlvBaseLen)
+	res, err := readExtendedIPReachabilityTLV(buf, 135, 37)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, tlv, res)
+	assert.Equal(t, uint8(PrefixAttributeFlagNode|PrefixAttributeFlagReadvertisement), res.ExtendedIPReachabilities[0].PrefixAttributeFlags())
+	assert.Equal(t, bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(), res.ExtendedIPReachabilities[0].SourceRouterID())
+}
@@ -2,8 +2,10 @@ package packet
 
 import (
 	"bytes"
+	"fmt"
 
 	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/bio-routing/bio-rd/util/decode"
 	"github.com/bio-routing/tflow2/convert"
 )
 
@@ -71,6 +73,102 @@ func (e *ExtendedISReachabilityTLV) Serialize(buf *bytes.Buffer) {
 	}
 }
 
+func readExtendedISReachabilityTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*ExtendedISReachabilityTLV, error) {
+	pdu := NewExtendedISReachabilityTLV()
+	pdu.TLVLength = tlvLength
+
+	if buf.Len() < int(tlvLength) {
+		return nil, fmt.Errorf("TLV length %d exceeds remaining %d bytes", tlvLength, buf.Len())
+	}
+
+	tlvBuf := bytes.NewBuffer(buf.Next(int(tlvLength)))
+	for tlvBuf.Len() > 0 {
+		n, err := readExtendedISReachabilityNeighbor(tlvBuf)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read extended IS reachability neighbor: %w", err)
+		}
+
+		pdu.Neighbors = append(pdu.Neighbors, n)
+	}
+
+	return pdu, nil
+}
+
+func readExtendedISReachabilityNeighbor(buf *bytes.Buffer) (*ExtendedISReachabilityNeighbor, error) {
+	n := &ExtendedISReachabilityNeighbor{
+		SubTLVs: make([]TLV, 0),
+	}
+
+	metric := [3]byte{}
+	fields := []interface{}{
+		&n.NeighborID.SystemID,
+		&n.NeighborID.CircuitID,
+		&metric,
+		&n.SubTLVLength,
+	}
+
+	err := decode.Decode(buf, fields)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	n.Metric = uint32(metric[0])<<16 + uint32(metric[1])<<8 + uint32(metric[2])
+
+	if buf.Len() < int(n.SubTLVLength) {
+		return nil, fmt.Errorf("sub TLV length %d exceeds remaining %d bytes", n.SubTLVLength, buf.Len())
+	}
+
+	subTLVBuf := bytes.NewBuffer(buf.Next(int(n.SubTLVLength)))
+	for subTLVBuf.Len() > 0 {
+		stlv, err := readExtendedISReachabilitySubTLV(subTLVBuf)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read sub TLV: %w", err)
+		}
+
+		n.SubTLVs = append(n.SubTLVs, stlv)
+	}
+
+	return n, nil
+}
+
+func readExtendedISReachabilitySubTLV(buf *bytes.Buffer) (TLV, error) {
+	tlvType := uint8(0)
+	tlvLength := uint8(0)
+
+	err := decode.Decode(buf, []interface{}{
+		&tlvType,
+		&tlvLength,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	switch tlvType {
+	case AdministrativeGroupSubTLVType:
+		return readAdministrativeGroupSubTLV(buf, tlvType, tlvLength)
+	case LinkLocalRemoteIdentifiersSubTLVType:
+		return readLinkLocalRemoteIdentifiersSubTLV(buf, tlvType, tlvLength)
+	case IPv4InterfaceAddressSubTLVType, IPv4NeighborAddressSubTLVType:
+		return readIPv4AddressSubTLV(buf, tlvType, tlvLength)
+	case MaximumLinkBandwidthSubTLVType, MaximumReservableLinkBandwidthSubTLVType:
+		return readBandwidthSubTLV(buf, tlvType, tlvLength)
+	case UnreservedBandwidthSubTLVType:
+		return readUnreservedBandwidthSubTLV(buf, tlvType, tlvLength)
+	case TEDefaultMetricSubTLVType:
+		return readTEDefaultMetricSubTLV(buf, tlvType, tlvLength)
+	}
+
+	return readUnknownTLV(buf, tlvType, tlvLength)
+}
+
+func checkSubTLVLength(tlvType uint8, tlvLength uint8, expected uint8) error {
+	if tlvLength != expected {
+		return fmt.Errorf("invalid length %d of sub TLV type %d, expected %d", tlvLength, tlvType, expected)
+	}
+
+	return nil
+}
+
 // ExtendedISReachabilityNeighbor is an extended IS Reachability Neighbor
 type ExtendedISReachabilityNeighbor struct {
 	NeighborID   types.SourceID
@@ -160,6 +258,28 @@ func (l *LinkLocalRemoteIdentifiersSubTLV) Serialize(buf *bytes.Buffer) {
 	buf.Write(convert.Uint32Byte(l.Remote))
 }
 
+func readLinkLocalRemoteIdentifiersSubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*LinkLocalRemoteIdentifiersSubTLV, error) {
+	err := checkSubTLVLength(tlvType, tlvLength, 8)
+	if err != nil {
+		return nil, err
+	}
+
+	pdu := &LinkLocalRemoteIdentifiersSubTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+	}
+
+	err = decode.Decode(buf, []interface{}{
+		&pdu.Local,
+		&pdu.Remote,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	return pdu, nil
+}
+
 // NewLinkLocalRemoteIdentifiersSubTLV creates a new LinkLocalRemoteIdentifiersSubTLV
 func NewLinkLocalRemoteIdentifiersSubTLV(local uint32, remote uint32) *LinkLocalRemoteIdentifiersSubTLV {
 	return &LinkLocalRemoteIdentifiersSubTLV{
@@ -204,6 +324,27 @@ func (s *IPv4AddressSubTLV) Serialize(buf *bytes.Buffer) {
 	buf.Write(convert.Uint32Byte(s.Address))
 }
 
+func readIPv4AddressSubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*IPv4AddressSubTLV, error) {
+	err := checkSubTLVLength(tlvType, tlvLength, 4)
+	if err != nil {
+		return nil, err
+	}
+
+	pdu := &IPv4AddressSubTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+	}
+
+	err = decode.Decode(buf, []interface{}{
+		&pdu.Address,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	return pdu, nil
+}
+
 // NewIPv4InterfaceAddressSubTLV creates a new IPv4 Interface Address Sub TLV
 func NewIPv4InterfaceAddressSubTLV(addr uint32) *IPv4AddressSubTLV {
 	return newIPv4AddressSubTLV(IPv4InterfaceAddressSubTLVType, addr)
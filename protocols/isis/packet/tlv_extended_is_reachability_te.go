@@ -0,0 +1,300 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/bio-routing/bio-rd/util/decode"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+// Traffic Engineering Sub TLVs of the Extended IS Reachability TLV (RFC5305)
+const (
+	// AdministrativeGroupSubTLVType is the type value of an Administrative Group Sub TLV
+	AdministrativeGroupSubTLVType = 3
+
+	// MaximumLinkBandwidthSubTLVType is the type value of a Maximum Link Bandwidth Sub TLV
+	MaximumLinkBandwidthSubTLVType = 9
+
+	// MaximumReservableLinkBandwidthSubTLVType is the type value of a Maximum Reservable Link Bandwidth Sub TLV
+	MaximumReservableLinkBandwidthSubTLVType = 10
+
+	// UnreservedBandwidthSubTLVType is the type value of an Unreserved Bandwidth Sub TLV
+	UnreservedBandwidthSubTLVType = 11
+
+	// TEDefaultMetricSubTLVType is the type value of a Traffic Engineering Default Metric Sub TLV
+	TEDefaultMetricSubTLVType = 18
+
+	// UnreservedBandwidthPriorities is the number of priority levels of an Unreserved Bandwidth Sub TLV
+	UnreservedBandwidthPriorities = 8
+)
+
+// AdministrativeGroupSubTLV is an Administrative Group (color) Sub TLV
+type AdministrativeGroupSubTLV struct {
+	TLVType    uint8
+	TLVLength  uint8
+	AdminGroup uint32
+}
+
+// NewAdministrativeGroupSubTLV creates a new AdministrativeGroupSubTLV
+func NewAdministrativeGroupSubTLV(adminGroup uint32) *AdministrativeGroupSubTLV {
+	return &AdministrativeGroupSubTLV{
+		TLVType:    AdministrativeGroupSubTLVType,
+		TLVLength:  4,
+		AdminGroup: adminGroup,
+	}
+}
+
+func readAdministrativeGroupSubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*AdministrativeGroupSubTLV, error) {
+	err := checkSubTLVLength(tlvType, tlvLength, 4)
+	if err != nil {
+		return nil, err
+	}
+
+	pdu := &AdministrativeGroupSubTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+	}
+
+	err = decode.Decode(buf, []interface{}{
+		&pdu.AdminGroup,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	return pdu, nil
+}
+
+func (a *AdministrativeGroupSubTLV) Copy() TLV {
+	ret := *a
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (a *AdministrativeGroupSubTLV) Type() uint8 {
+	return a.TLVType
+}
+
+// Length gets the length of the TLV
+func (a *AdministrativeGroupSubTLV) Length() uint8 {
+	return a.TLVLength
+}
+
+// Value returns the TLV itself
+func (a *AdministrativeGroupSubTLV) Value() interface{} {
+	return a
+}
+
+// Serialize serializes an AdministrativeGroupSubTLV
+func (a *AdministrativeGroupSubTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(a.TLVType)
+	buf.WriteByte(a.TLVLength)
+	buf.Write(convert.Uint32Byte(a.AdminGroup))
+}
+
+// BandwidthSubTLV is a bandwidth Sub TLV (used for both maximum and maximum reservable link bandwidth).
+// Bandwidth is expressed in bytes per second.
+type BandwidthSubTLV struct {
+	TLVType   uint8
+	TLVLength uint8
+	Bandwidth float32
+}
+
+// NewMaximumLinkBandwidthSubTLV creates a new Maximum Link Bandwidth Sub TLV
+func NewMaximumLinkBandwidthSubTLV(bw float32) *BandwidthSubTLV {
+	return newBandwidthSubTLV(MaximumLinkBandwidthSubTLVType, bw)
+}
+
+// NewMaximumReservableLinkBandwidthSubTLV creates a new Maximum Reservable Link Bandwidth Sub TLV
+func NewMaximumReservableLinkBandwidthSubTLV(bw float32) *BandwidthSubTLV {
+	return newBandwidthSubTLV(MaximumReservableLinkBandwidthSubTLVType, bw)
+}
+
+func newBandwidthSubTLV(tlvType uint8, bw float32) *BandwidthSubTLV {
+	return &BandwidthSubTLV{
+		TLVType:   tlvType,
+		TLVLength: 4,
+		Bandwidth: bw,
+	}
+}
+
+func readBandwidthSubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*BandwidthSubTLV, error) {
+	err := checkSubTLVLength(tlvType, tlvLength, 4)
+	if err != nil {
+		return nil, err
+	}
+
+	pdu := &BandwidthSubTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+	}
+
+	err = decode.Decode(buf, []interface{}{
+		&pdu.Bandwidth,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	return pdu, nil
+}
+
+func (b *BandwidthSubTLV) Copy() TLV {
+	ret := *b
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (b *BandwidthSubTLV) Type() uint8 {
+	return b.TLVType
+}
+
+// Length gets the length of the TLV
+func (b *BandwidthSubTLV) Length() uint8 {
+	return b.TLVLength
+}
+
+// Value returns the TLV itself
+func (b *BandwidthSubTLV) Value() interface{} {
+	return b
+}
+
+// Serialize serializes a BandwidthSubTLV
+func (b *BandwidthSubTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(b.TLVType)
+	buf.WriteByte(b.TLVLength)
+	buf.Write(convert.Uint32Byte(math.Float32bits(b.Bandwidth)))
+}
+
+// UnreservedBandwidthSubTLV is an Unreserved Bandwidth Sub TLV. It carries the bandwidth not yet reserved
+// for each of the eight priority levels in bytes per second.
+type UnreservedBandwidthSubTLV struct {
+	TLVType   uint8
+	TLVLength uint8
+	Bandwidth [UnreservedBandwidthPriorities]float32
+}
+
+// NewUnreservedBandwidthSubTLV creates a new UnreservedBandwidthSubTLV
+func NewUnreservedBandwidthSubTLV(bw [UnreservedBandwidthPriorities]float32) *UnreservedBandwidthSubTLV {
+	return &UnreservedBandwidthSubTLV{
+		TLVType:   UnreservedBandwidthSubTLVType,
+		TLVLength: 4 * UnreservedBandwidthPriorities,
+		Bandwidth: bw,
+	}
+}
+
+func readUnreservedBandwidthSubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*UnreservedBandwidthSubTLV, error) {
+	err := checkSubTLVLength(tlvType, tlvLength, 4*UnreservedBandwidthPriorities)
+	if err != nil {
+		return nil, err
+	}
+
+	pdu := &UnreservedBandwidthSubTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+	}
+
+	err = decode.Decode(buf, []interface{}{
+		&pdu.Bandwidth,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	return pdu, nil
+}
+
+func (u *UnreservedBandwidthSubTLV) Copy() TLV {
+	ret := *u
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (u *UnreservedBandwidthSubTLV) Type() uint8 {
+	return u.TLVType
+}
+
+// Length gets the length of the TLV
+func (u *UnreservedBandwidthSubTLV) Length() uint8 {
+	return u.TLVLength
+}
+
+// Value returns the TLV itself
+func (u *UnreservedBandwidthSubTLV) Value() interface{} {
+	return u
+}
+
+// Serialize serializes an UnreservedBandwidthSubTLV
+func (u *UnreservedBandwidthSubTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(u.TLVType)
+	buf.WriteByte(u.TLVLength)
+	for i := range u.Bandwidth {
+		buf.Write(convert.Uint32Byte(math.Float32bits(u.Bandwidth[i])))
+	}
+}
+
+// TEDefaultMetricSubTLV is a Traffic Engineering Default Metric Sub TLV
+type TEDefaultMetricSubTLV struct {
+	TLVType   uint8
+	TLVLength uint8
+	Metric    uint32
+}
+
+// NewTEDefaultMetricSubTLV creates a new TEDefaultMetricSubTLV
+func NewTEDefaultMetricSubTLV(metric uint32) *TEDefaultMetricSubTLV {
+	return &TEDefaultMetricSubTLV{
+		TLVType:   TEDefaultMetricSubTLVType,
+		TLVLength: 3,
+		Metric:    metric,
+	}
+}
+
+func readTEDefaultMetricSubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*TEDefaultMetricSubTLV, error) {
+	err := checkSubTLVLength(tlvType, tlvLength, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	metric := [3]byte{}
+	err = decode.Decode(buf, []interface{}{
+		&metric,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	return &TEDefaultMetricSubTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+		Metric:    uint32(metric[0])<<16 + uint32(metric[1])<<8 + uint32(metric[2]),
+	}, nil
+}
+
+func (t *TEDefaultMetricSubTLV) Copy() TLV {
+	ret := *t
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (t *TEDefaultMetricSubTLV) Type() uint8 {
+	return t.TLVType
+}
+
+// Length gets the length of the TLV
+func (t *TEDefaultMetricSubTLV) Length() uint8 {
+	return t.TLVLength
+}
+
+// Value returns the TLV itself
+func (t *TEDefaultMetricSubTLV) Value() interface{} {
+	return t
+}
+
+// Serialize serializes a TEDefaultMetricSubTLV
+func (t *TEDefaultMetricSubTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(t.TLVType)
+	buf.WriteByte(t.TLVLength)
+	buf.Write(convert.Uint32Byte(t.Metric)[1:])
+}
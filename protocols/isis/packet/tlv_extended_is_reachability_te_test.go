@@ -0,0 +1,156 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTESubTLVSerialize(t *testing.T) {
+	tests := []struct {
+		name     string
+		tlv      TLV
+		expected []byte
+	}{
+		{
+			name:     "Administrative Group",
+			tlv:      NewAdministrativeGroupSubTLV(0x80000001),
+			expected: []byte{3, 4, 0x80, 0, 0, 1},
+		},
+		{
+			name:     "Maximum Link Bandwidth",
+			tlv:      NewMaximumLinkBandwidthSubTLV(125000000),
+			expected: []byte{9, 4, 76, 238, 107, 40},
+		},
+		{
+			name:     "Maximum Reservable Link Bandwidth",
+			tlv:      NewMaximumReservableLinkBandwidthSubTLV(100000000),
+			expected: []byte{10, 4, 76, 190, 188, 32},
+		},
+		{
+			name: "Unreserved Bandwidth",
+			tlv: NewUnreservedBandwidthSubTLV([UnreservedBandwidthPriorities]float32{
+				100000000, 100000000, 100000000, 100000000, 12500000, 12500000, 12500000, 0,
+			}),
+			expected: []byte{
+				11, 32,
+				76, 190, 188, 32,
+				76, 190, 188, 32,
+				76, 190, 188, 32,
+				76, 190, 188, 32,
+				75, 62, 188, 32,
+				75, 62, 188, 32,
+				75, 62, 188, 32,
+				0, 0, 0, 0,
+			},
+		},
+		{
+			name:     "TE Default Metric",
+			tlv:      NewTEDefaultMetricSubTLV(70000),
+			expected: []byte{18, 3, 0x01, 0x11, 0x70},
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(nil)
+		test.tlv.Serialize(buf)
+		assert.Equal(t, test.expected, buf.Bytes(), test.name)
+	}
+}
+
+func TestReadExtendedISReachabilitySubTLV(t *testing.T) {
+	tests := []struct {
+		name     string
+		pkt      []byte
+		expected TLV
+		wantFail bool
+	}{
+		{
+			name:     "Administrative Group",
+			pkt:      []byte{3, 4, 0x80, 0, 0, 1},
+			expected: NewAdministrativeGroupSubTLV(0x80000001),
+		},
+		{
+			name:     "Administrative Group with invalid length",
+			pkt:      []byte{3, 3, 0x80, 0, 0},
+			wantFail: true,
+		},
+		{
+			name:     "IPv4 Interface Address",
+			pkt:      []byte{6, 4, 10, 0, 0, 1},
+			expected: NewIPv4InterfaceAddressSubTLV(167772161),
+		},
+		{
+			name:     "IPv4 Neighbor Address",
+			pkt:      []byte{8, 4, 10, 0, 0, 2},
+			expected: NewIPv4NeighborAddressSubTLV(167772162),
+		},
+		{
+			name:     "Maximum Link Bandwidth",
+			pkt:      []byte{9, 4, 76, 238, 107, 40},
+			expected: NewMaximumLinkBandwidthSubTLV(125000000),
+		},
+		{
+			name:     "Maximum Reservable Link Bandwidth",
+			pkt:      []byte{10, 4, 76, 190, 188, 32},
+			expected: NewMaximumReservableLinkBandwidthSubTLV(100000000),
+		},
+		{
+			name: "Unreserved Bandwidth",
+			pkt: []byte{
+				11, 32,
+				76, 190, 188, 32,
+				76, 190, 188, 32,
+				76, 190, 188, 32,
+				76, 190, 188, 32,
+				75, 62, 188, 32,
+				75, 62, 188, 32,
+				75, 62, 188, 32,
+				0, 0, 0, 0,
+			},
+			expected: NewUnreservedBandwidthSubTLV([UnreservedBandwidthPriorities]float32{
+				100000000, 100000000, 100000000, 100000000, 12500000, 12500000, 12500000, 0,
+			}),
+		},
+		{
+			name:     "Unreserved Bandwidth truncated",
+			pkt:      []byte{11, 32, 76, 190, 188, 32},
+			wantFail: true,
+		},
+		{
+			name:     "TE Default Metric",
+			pkt:      []byte{18, 3, 0x01, 0x11, 0x70},
+			expected: NewTEDefaultMetricSubTLV(70000),
+		},
+		{
+			name: "Unknown Sub TLV",
+			pkt:  []byte{250, 2, 1, 2},
+			expected: &UnknownTLV{
+				TLVType:   250,
+				TLVLength: 2,
+				TLVValue:  []byte{1, 2},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(test.pkt)
+		tlv, err := readExtendedISReachabilitySubTLV(buf)
+		if err != nil {
+			if test.wantFail {
+				continue
+			}
+
+			t.Errorf("Unexpected failure for test %q: %v", test.name, err)
+			continue
+		}
+
+		if test.wantFail {
+			t.Errorf("Unexpected success for test %q", test.name)
+			continue
+		}
+
+		assert.Equal(t, test.expected, tlv, test.name)
+	}
+}
@@ -142,3 +142,109 @@ func TestNewIPv4NeighborAddressSubTLV(t *testing.T) {
 
 	assert.Equal(t, expectred, tlv)
 }
+
+func TestReadExtendedISReachabilityTLV(t *testing.T) {
+	tests := []struct {
+		name      string
+		tlvLength uint8
+		pkt       []byte
+		expected  *ExtendedISReachabilityTLV
+		wantFail  bool
+	}{
+		{
+			name:      "Neighbor with TE sub TLVs",
+			tlvLength: 34,
+			pkt: []byte{
+				10, 20, 30, 40, 50, 60,
+				100,
+				0, 0, 123,
+				23,
+				3, 4, 0, 0, 0, 5,
+				9, 4, 76, 238, 107, 40,
+				18, 3, 0, 0, 10,
+				8, 4, 10, 0, 0, 2,
+			},
+			expected: &ExtendedISReachabilityTLV{
+				TLVType:   22,
+				TLVLength: 34,
+				Neighbors: []*ExtendedISReachabilityNeighbor{
+					{
+						NeighborID: types.SourceID{
+							SystemID:  types.SystemID{10, 20, 30, 40, 50, 60},
+							CircuitID: 100,
+						},
+						Metric:       123,
+						SubTLVLength: 23,
+						SubTLVs: []TLV{
+							NewAdministrativeGroupSubTLV(5),
+							NewMaximumLinkBandwidthSubTLV(125000000),
+							NewTEDefaultMetricSubTLV(10),
+							NewIPv4NeighborAddressSubTLV(167772162),
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "Two neighbors without sub TLVs",
+			tlvLength: 22,
+			pkt: []byte{
+				1, 2, 3, 4, 5, 6,
+				0,
+				0, 0, 10,
+				0,
+				6, 5, 4, 3, 2, 1,
+				1,
+				0, 0, 20,
+				0,
+			},
+			expected: &ExtendedISReachabilityTLV{
+				TLVType:   22,
+				TLVLength: 22,
+				Neighbors: []*ExtendedISReachabilityNeighbor{
+					{
+						NeighborID: types.NewSourceID(types.SystemID{1, 2, 3, 4, 5, 6}, 0),
+						Metric:     10,
+						SubTLVs:    []TLV{},
+					},
+					{
+						NeighborID: types.NewSourceID(types.SystemID{6, 5, 4, 3, 2, 1}, 1),
+						Metric:     20,
+						SubTLVs:    []TLV{},
+					},
+				},
+			},
+		},
+		{
+			name:      "Sub TLV length exceeding TLV",
+			tlvLength: 11,
+			pkt: []byte{
+				1, 2, 3, 4, 5, 6,
+				0,
+				0, 0, 10,
+				6,
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(test.pkt)
+		tlv, err := readExtendedISReachabilityTLV(buf, ExtendedISReachabilityType, test.tlvLength)
+		if err != nil {
+			if test.wantFail {
+				continue
+			}
+
+			t.Errorf("Unexpected failure for test %q: %v", test.name, err)
+			continue
+		}
+
+		if test.wantFail {
+			t.Errorf("Unexpected success for test %q", test.name)
+			continue
+		}
+
+		assert.Equal(t, test.expected, tlv, test.name)
+	}
+}
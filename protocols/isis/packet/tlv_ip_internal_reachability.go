@@ -0,0 +1,152 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+	"math/bits"
+
+	"github.com/bio-routing/bio-rd/util/decode"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+const (
+	// IPInternalReachabilityTLVType is the type value of an IP Internal Reachability Information TLV (RFC1195)
+	IPInternalReachabilityTLVType = 128
+
+	// IPReachabilityLength is the length of an entry within an IPInternalReachabilityTLV
+	IPReachabilityLength = 12
+)
+
+// IPInternalReachabilityTLV is an IP Internal Reachability Information TLV
+type IPInternalReachabilityTLV struct {
+	TLVType          uint8
+	TLVLength        uint8
+	IPReachabilities []IPReachability
+}
+
+// IPReachability is an entry of an IPInternalReachabilityTLV
+type IPReachability struct {
+	DefaultMetric uint8
+	DelayMetric   uint8
+	ExpenseMetric uint8
+	ErrorMetric   uint8
+	Address       uint32
+	SubnetMask    uint32
+}
+
+// NewIPInternalReachabilityTLV creates a new IPInternalReachabilityTLV
+func NewIPInternalReachabilityTLV() *IPInternalReachabilityTLV {
+	return &IPInternalReachabilityTLV{
+		TLVType:          IPInternalReachabilityTLVType,
+		TLVLength:        0,
+		IPReachabilities: make([]IPReachability, 0),
+	}
+}
+
+// NewIPReachability creates a new IPReachability. Metrics exceeding MaxNarrowMetric are capped.
+func NewIPReachability(metric uint32, pfxLen uint8, addr uint32) IPReachability {
+	return IPReachability{
+		DefaultMetric: narrowMetric(metric),
+		DelayMetric:   narrowMetricUnsupported,
+		ExpenseMetric: narrowMetricUnsupported,
+		ErrorMetric:   narrowMetricUnsupported,
+		Address:       addr,
+		SubnetMask:    ^uint32(0) << (32 - uint32(pfxLen)),
+	}
+}
+
+// Metric returns the default metric of the entry
+func (r *IPReachability) Metric() uint8 {
+	return r.DefaultMetric & narrowMetricMask
+}
+
+// UpDown returns if the up/down bit (RFC2966) is set. Prefixes with the up/down bit set have been leaked from level 2 into level 1.
+func (r *IPReachability) UpDown() bool {
+	return r.DefaultMetric&upDownBit != 0
+}
+
+// SetUpDown sets the up/down bit
+func (r *IPReachability) SetUpDown() {
+	r.DefaultMetric |= upDownBit
+}
+
+// PfxLen returns the prefix length
+func (r *IPReachability) PfxLen() uint8 {
+	return uint8(bits.LeadingZeros32(^r.SubnetMask))
+}
+
+// AddIPReachability adds an entry to the TLV
+func (i *IPInternalReachabilityTLV) AddIPReachability(r IPReachability) {
+	i.TLVLength += IPReachabilityLength
+	i.IPReachabilities = append(i.IPReachabilities, r)
+}
+
+func readIPInternalReachabilityTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*IPInternalReachabilityTLV, error) {
+	if tlvLength%IPReachabilityLength != 0 {
+		return nil, fmt.Errorf("invalid length %d of IP internal reachability TLV", tlvLength)
+	}
+
+	pdu := &IPInternalReachabilityTLV{
+		TLVType:          tlvType,
+		TLVLength:        tlvLength,
+		IPReachabilities: make([]IPReachability, tlvLength/IPReachabilityLength),
+	}
+
+	fields := make([]interface{}, 0, len(pdu.IPReachabilities)*6)
+	for i := range pdu.IPReachabilities {
+		fields = append(fields,
+			&pdu.IPReachabilities[i].DefaultMetric,
+			&pdu.IPReachabilities[i].DelayMetric,
+			&pdu.IPReachabilities[i].ExpenseMetric,
+			&pdu.IPReachabilities[i].ErrorMetric,
+			&pdu.IPReachabilities[i].Address,
+			&pdu.IPReachabilities[i].SubnetMask,
+		)
+	}
+
+	err := decode.Decode(buf, fields)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	return pdu, nil
+}
+
+// Copy copies the TLV
+func (i *IPInternalReachabilityTLV) Copy() TLV {
+	ret := *i
+	ret.IPReachabilities = make([]IPReachability, len(i.IPReachabilities))
+	copy(ret.IPReachabilities, i.IPReachabilities)
+
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (i *IPInternalReachabilityTLV) Type() uint8 {
+	return i.TLVType
+}
+
+// Length gets the length of the TLV
+func (i *IPInternalReachabilityTLV) Length() uint8 {
+	return i.TLVLength
+}
+
+// Value returns the TLV itself
+func (i *IPInternalReachabilityTLV) Value() interface{} {
+	return i
+}
+
+// Serialize serializes an IPInternalReachabilityTLV
+func (i *IPInternalReachabilityTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(i.TLVType)
+	buf.WriteByte(i.TLVLength)
+
+	for _, r := range i.IPReachabilities {
+		buf.WriteByte(r.DefaultMetric)
+		buf.WriteByte(r.DelayMetric)
+		buf.WriteByte(r.ExpenseMetric)
+		buf.WriteByte(r.ErrorMetric)
+		buf.Write(convert.Uint32Byte(r.Address))
+		buf.Write(convert.Uint32Byte(r.SubnetMask))
+	}
+}
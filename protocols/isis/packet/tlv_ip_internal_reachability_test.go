@@ -0,0 +1,84 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIPInternalReachabilityTLV(t *testing.T) {
+	tlv := NewIPInternalReachabilityTLV()
+	tlv.AddIPReachability(NewIPReachability(10, 24, 0x0a141e00))
+	tlv.AddIPReachability(NewIPReachability(1000, 32, 0x0a000001))
+
+	expected := []byte{
+		128, // Type
+		24,  // Length
+		10, 0x80, 0x80, 0x80, 10, 20, 30, 0, 255, 255, 255, 0,
+		63, 0x80, 0x80, 0x80, 10, 0, 0, 1, 255, 255, 255, 255, // Metric capped
+	}
+
+	buf := bytes.NewBuffer(nil)
+	tlv.Serialize(buf)
+	assert.Equal(t, expected, buf.Bytes())
+
+	buf = bytes.NewBuffer(expected)
+	res, err := readTLV(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, tlv, res)
+	assert.Equal(t, tlv, res.Copy())
+
+	r := res.(*IPInternalReachabilityTLV).IPReachabilities
+	assert.Equal(t, uint8(24), r[0].PfxLen())
+	assert.Equal(t, uint8(10), r[0].Metric())
+	assert.Equal(t, uint8(32), r[1].PfxLen())
+	assert.Equal(t, uint8(63), r[1].Metric())
+}
+
+func TestReadIPInternalReachabilityTLV(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []byte
+		tlvLength uint8
+		wantFail  bool
+	}{
+		{
+			name:      "Invalid length",
+			input:     []byte{10, 0x80, 0x80, 0x80, 10, 20, 30, 0, 255, 255, 255},
+			tlvLength: 11,
+			wantFail:  true,
+		},
+		{
+			name:      "Incomplete",
+			input:     []byte{10, 0x80, 0x80, 0x80, 10, 20, 30, 0, 255, 255, 255},
+			tlvLength: 12,
+			wantFail:  true,
+		},
+		{
+			name:      "Default route",
+			input:     []byte{10, 0x80, 0x80, 0x80, 0, 0, 0, 0, 0, 0, 0, 0},
+			tlvLength: 12,
+		},
+	}
+
+	for _, test := range tests {
+		tlv, err := readIPInternalReachabilityTLV(bytes.NewBuffer(test.input), 128, test.tlvLength)
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, uint8(0), tlv.IPReachabilities[0].PfxLen(), test.name)
+	}
+}
+
+func TestIPReachabilityUpDown(t *testing.T) {
+	r := NewIPReachability(10, 24, 169090560)
+	assert.False(t, r.UpDown())
+
+	r.SetUpDown()
+	assert.True(t, r.UpDown())
+	assert.Equal(t, uint8(10), r.Metric())
+}
@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"fmt"
 
-	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/util/decode"
 )
 
@@ -15,7 +15,7 @@ const ISNeighborsTLVType = 6
 type ISNeighborsTLV struct {
 	TLVType      uint8
 	TLVLength    uint8
-	NeighborSNPA types.SystemID
+	NeighborSNPA bnet.MACAddr
 }
 
 // ISNeighborsTLVLength is the length of an IS Neighbor TLV
@@ -62,5 +62,5 @@ func (i ISNeighborsTLV) Value() interface{} {
 func (i ISNeighborsTLV) Serialize(buf *bytes.Buffer) {
 	buf.WriteByte(i.TLVType)
 	buf.WriteByte(i.TLVLength)
-	buf.Write(i.NeighborSNPA[:])
+	i.NeighborSNPA.Serialize(buf)
 }
@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"testing"
 
-	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -12,7 +12,7 @@ func TestIsNeighborsTLV(t *testing.T) {
 	tlv := &ISNeighborsTLV{
 		TLVType:      6,
 		TLVLength:    6,
-		NeighborSNPA: types.SystemID{1, 2, 3, 4, 5, 6},
+		NeighborSNPA: bnet.MACAddr{1, 2, 3, 4, 5, 6},
 	}
 
 	assert.Equal(t, uint8(6), tlv.Type())
@@ -20,7 +20,7 @@ func TestIsNeighborsTLV(t *testing.T) {
 	assert.Equal(t, ISNeighborsTLV{
 		TLVType:      6,
 		TLVLength:    6,
-		NeighborSNPA: types.SystemID{1, 2, 3, 4, 5, 6},
+		NeighborSNPA: bnet.MACAddr{1, 2, 3, 4, 5, 6},
 	}, tlv.Value())
 }
 
@@ -40,7 +40,7 @@ func TestReadISNeighborsTLV(t *testing.T) {
 			expected: &ISNeighborsTLV{
 				TLVType:      6,
 				TLVLength:    6,
-				NeighborSNPA: types.SystemID{1, 2, 3, 4, 5, 6},
+				NeighborSNPA: bnet.MACAddr{1, 2, 3, 4, 5, 6},
 			},
 		},
 	}
@@ -77,7 +77,7 @@ func TestISNeighborsTLVSerialize(t *testing.T) {
 			input: &ISNeighborsTLV{
 				TLVType:      6,
 				TLVLength:    6,
-				NeighborSNPA: types.SystemID{1, 2, 3, 4, 5, 6},
+				NeighborSNPA: bnet.MACAddr{1, 2, 3, 4, 5, 6},
 			},
 			expected: []byte{6, 6, 1, 2, 3, 4, 5, 6},
 		},
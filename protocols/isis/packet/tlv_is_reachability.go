@@ -2,15 +2,29 @@ package packet
 
 import (
 	"bytes"
+	"fmt"
 	"unsafe"
 
 	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/bio-routing/bio-rd/util/decode"
 )
 
 // ISReachabilityTLVType is the type value of an IS reachability TLV
 const ISReachabilityTLVType = 2
 const defaultLegacyMetric = 63
 
+const (
+	// MaxNarrowMetric is the maximum metric of an old style (narrow) reachability entry
+	MaxNarrowMetric = 63
+
+	// ISNeighborLength is the length of a neighbor within an ISReachabilityTLV
+	ISNeighborLength = 11
+
+	// narrowMetricUnsupported is the S bit marking an optional narrow metric as unsupported
+	narrowMetricUnsupported = 0x80
+	narrowMetricMask        = 0x3f
+)
+
 // ISReachabilityTLV represents an IS reachability TLV
 type ISReachabilityTLV struct {
 	TLVType     uint8
@@ -53,6 +67,87 @@ func newISNeighbor(neighborID types.SourceID) ISNeighbor {
 	}
 }
 
+// NewISNeighbor creates a new neighbor of an IS reachability TLV. Metrics exceeding MaxNarrowMetric are capped.
+func NewISNeighbor(neighborID types.SourceID, metric uint32) ISNeighbor {
+	return ISNeighbor{
+		RIEDefaultMetric: narrowMetric(metric),
+		SIEDelayMetric:   narrowMetricUnsupported,
+		SIEExpenseMetric: narrowMetricUnsupported,
+		SIEErrorMetric:   narrowMetricUnsupported,
+		NeighborID:       neighborID,
+	}
+}
+
+func narrowMetric(metric uint32) uint8 {
+	if metric > MaxNarrowMetric {
+		return MaxNarrowMetric
+	}
+
+	return uint8(metric)
+}
+
+// Metric returns the default metric of the neighbor
+func (n *ISNeighbor) Metric() uint8 {
+	return n.RIEDefaultMetric & narrowMetricMask
+}
+
+// AddNeighbor adds a neighbor to the IS reachability TLV
+func (isrtlv *ISReachabilityTLV) AddNeighbor(n ISNeighbor) {
+	if len(isrtlv.Neighbors) == 0 {
+		isrtlv.TLVLength = 1 // Virtual Flag
+	}
+
+	isrtlv.TLVLength += ISNeighborLength
+	isrtlv.Neighbors = append(isrtlv.Neighbors, n)
+}
+
+func readISReachabilityTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*ISReachabilityTLV, error) {
+	if tlvLength == 0 || (tlvLength-1)%ISNeighborLength != 0 {
+		return nil, fmt.Errorf("invalid length %d of IS reachability TLV", tlvLength)
+	}
+
+	if buf.Len() < int(tlvLength) {
+		return nil, fmt.Errorf("TLV length %d exceeds remaining %d bytes", tlvLength, buf.Len())
+	}
+
+	pdu := &ISReachabilityTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+		Neighbors: make([]ISNeighbor, (tlvLength-1)/ISNeighborLength),
+	}
+
+	fields := []interface{}{
+		&pdu.VirtualFlag,
+	}
+
+	for i := range pdu.Neighbors {
+		fields = append(fields,
+			&pdu.Neighbors[i].RIEDefaultMetric,
+			&pdu.Neighbors[i].SIEDelayMetric,
+			&pdu.Neighbors[i].SIEExpenseMetric,
+			&pdu.Neighbors[i].SIEErrorMetric,
+			&pdu.Neighbors[i].NeighborID.SystemID,
+			&pdu.Neighbors[i].NeighborID.CircuitID,
+		)
+	}
+
+	err := decode.Decode(buf, fields)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	return pdu, nil
+}
+
+// Copy copies the TLV
+func (isrtlv *ISReachabilityTLV) Copy() TLV {
+	ret := *isrtlv
+	ret.Neighbors = make([]ISNeighbor, len(isrtlv.Neighbors))
+	copy(ret.Neighbors, isrtlv.Neighbors)
+
+	return &ret
+}
+
 // Type gets the type of the TLV
 func (isrtlv *ISReachabilityTLV) Type() uint8 {
 	return isrtlv.TLVType
@@ -72,7 +167,7 @@ func (isrtlv *ISReachabilityTLV) Value() interface{} {
 func (isrtlv *ISReachabilityTLV) Serialize(buf *bytes.Buffer) {
 	buf.WriteByte(isrtlv.TLVType)
 	buf.WriteByte(isrtlv.TLVLength)
-	buf.WriteByte(0) // Virtual Flag
+	buf.WriteByte(isrtlv.VirtualFlag)
 
 	for _, n := range isrtlv.Neighbors {
 		buf.WriteByte(n.RIEDefaultMetric)
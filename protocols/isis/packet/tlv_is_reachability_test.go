@@ -0,0 +1,96 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestISReachabilityTLV(t *testing.T) {
+	tlv := NewISReachabilityTLV(nil)
+	tlv.AddNeighbor(NewISNeighbor(types.NewSourceID(types.SystemID{1, 2, 3, 4, 5, 6}, 0), 10))
+	tlv.AddNeighbor(NewISNeighbor(types.NewSourceID(types.SystemID{1, 2, 3, 4, 5, 7}, 1), 100))
+
+	expected := []byte{
+		2,  // Type
+		23, // Length
+		0,  // Virtual Flag
+		10, 0x80, 0x80, 0x80, 1, 2, 3, 4, 5, 6, 0,
+		63, 0x80, 0x80, 0x80, 1, 2, 3, 4, 5, 7, 1, // Metric capped
+	}
+
+	buf := bytes.NewBuffer(nil)
+	tlv.Serialize(buf)
+	assert.Equal(t, expected, buf.Bytes())
+
+	buf = bytes.NewBuffer(expected)
+	res, err := readTLV(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, tlv, res)
+	assert.Equal(t, uint8(63), res.(*ISReachabilityTLV).Neighbors[1].Metric())
+	assert.Equal(t, tlv, res.Copy())
+}
+
+func TestReadISReachabilityTLV(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []byte
+		tlvLength uint8
+		wantFail  bool
+		expected  *ISReachabilityTLV
+	}{
+		{
+			name: "Single neighbor",
+			input: []byte{
+				0,
+				0x4a, 0x80, 0x80, 0x80, 1, 2, 3, 4, 5, 6, 0, // Internal metric bit set
+			},
+			tlvLength: 12,
+			expected: &ISReachabilityTLV{
+				TLVType:   2,
+				TLVLength: 12,
+				Neighbors: []ISNeighbor{
+					{
+						RIEDefaultMetric: 0x4a,
+						SIEDelayMetric:   0x80,
+						SIEExpenseMetric: 0x80,
+						SIEErrorMetric:   0x80,
+						NeighborID:       types.NewSourceID(types.SystemID{1, 2, 3, 4, 5, 6}, 0),
+					},
+				},
+			},
+		},
+		{
+			name: "Invalid length",
+			input: []byte{
+				0,
+				10, 0x80, 0x80, 0x80, 1, 2, 3, 4, 5, 6,
+			},
+			tlvLength: 11,
+			wantFail:  true,
+		},
+		{
+			name: "Incomplete",
+			input: []byte{
+				0,
+				10, 0x80, 0x80, 0x80, 1, 2, 3, 4, 5,
+			},
+			tlvLength: 12,
+			wantFail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		tlv, err := readISReachabilityTLV(bytes.NewBuffer(test.input), 2, test.tlvLength)
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, tlv, test.name)
+		assert.Equal(t, uint8(10), tlv.Neighbors[0].Metric(), test.name)
+	}
+}
@@ -8,6 +8,9 @@ import (
 const (
 	// LSPEntriesTLVType is the type value of an LSP Entries TLV
 	LSPEntriesTLVType = uint8(9)
+
+	// LSPEntriesTLVMaxEntries is the maximum number of LSP entries fitting into one LSP Entries TLV
+	LSPEntriesTLVMaxEntries = 255 / LSPEntryLen
 )
 
 // LSPEntriesTLV is an LSP Entries TLV carried in PSNP/CSNP
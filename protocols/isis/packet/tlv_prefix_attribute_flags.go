@@ -0,0 +1,92 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bio-routing/bio-rd/util/decode"
+)
+
+const (
+	// PrefixAttributeFlagsSubTLVType is the type value of a Prefix Attribute Flags Sub TLV (RFC7794)
+	PrefixAttributeFlagsSubTLVType = 4
+
+	// PrefixAttributeFlagExternal (X-Flag) is set if the prefix has been redistributed from another protocol
+	PrefixAttributeFlagExternal = 0x80
+
+	// PrefixAttributeFlagReadvertisement (R-Flag) is set if the prefix has been leaked from one level to another
+	PrefixAttributeFlagReadvertisement = 0x40
+
+	// PrefixAttributeFlagNode (N-Flag) is set if the prefix identifies the advertising router, e.g. a loopback address
+	PrefixAttributeFlagNode = 0x20
+)
+
+// PrefixAttributeFlagsSubTLV is a Prefix Attribute Flags Sub TLV of the Extended IP Reachability TLV
+type PrefixAttributeFlagsSubTLV struct {
+	TLVType   uint8
+	TLVLength uint8
+	Flags     uint8
+}
+
+// NewPrefixAttributeFlagsSubTLV creates a new PrefixAttributeFlagsSubTLV
+func NewPrefixAttributeFlagsSubTLV(flags uint8) *PrefixAttributeFlagsSubTLV {
+	return &PrefixAttributeFlagsSubTLV{
+		TLVType:   PrefixAttributeFlagsSubTLVType,
+		TLVLength: 1,
+		Flags:     flags,
+	}
+}
+
+// readPrefixAttributeFlagsSubTLV reads a PrefixAttributeFlagsSubTLV. The flags field is of variable length, flags
+// beyond the first octet are not defined and ignored.
+func readPrefixAttributeFlagsSubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*PrefixAttributeFlagsSubTLV, error) {
+	if tlvLength == 0 {
+		return nil, fmt.Errorf("invalid length 0 of sub TLV type %d", tlvType)
+	}
+
+	if buf.Len() < int(tlvLength) {
+		return nil, fmt.Errorf("sub TLV length %d exceeds remaining %d bytes", tlvLength, buf.Len())
+	}
+
+	pdu := &PrefixAttributeFlagsSubTLV{
+		TLVType:   tlvType,
+		TLVLength: 1,
+	}
+
+	err := decode.Decode(buf, []interface{}{
+		&pdu.Flags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	buf.Next(int(tlvLength) - 1)
+	return pdu, nil
+}
+
+func (p *PrefixAttributeFlagsSubTLV) Copy() TLV {
+	ret := *p
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (p *PrefixAttributeFlagsSubTLV) Type() uint8 {
+	return p.TLVType
+}
+
+// Length gets the length of the TLV
+func (p *PrefixAttributeFlagsSubTLV) Length() uint8 {
+	return p.TLVLength
+}
+
+// Value returns the TLV itself
+func (p *PrefixAttributeFlagsSubTLV) Value() interface{} {
+	return p
+}
+
+// Serialize serializes a PrefixAttributeFlagsSubTLV
+func (p *PrefixAttributeFlagsSubTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(p.TLVType)
+	buf.WriteByte(p.TLVLength)
+	buf.WriteByte(p.Flags)
+}
@@ -0,0 +1,69 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadPrefixAttributeFlagsSubTLV(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []byte
+		tlvLength uint8
+		wantFail  bool
+		expected  *PrefixAttributeFlagsSubTLV
+		remaining int
+	}{
+		{
+			name:      "N-Flag",
+			input:     []byte{PrefixAttributeFlagNode},
+			tlvLength: 1,
+			expected: &PrefixAttributeFlagsSubTLV{
+				TLVType:   PrefixAttributeFlagsSubTLVType,
+				TLVLength: 1,
+				Flags:     PrefixAttributeFlagNode,
+			},
+		},
+		{
+			name:      "Undefined flags octets are ignored",
+			input:     []byte{PrefixAttributeFlagReadvertisement, 0xff, 1},
+			tlvLength: 2,
+			expected: &PrefixAttributeFlagsSubTLV{
+				TLVType:   PrefixAttributeFlagsSubTLVType,
+				TLVLength: 1,
+				Flags:     PrefixAttributeFlagReadvertisement,
+			},
+			remaining: 1,
+		},
+		{
+			name:      "Empty flags",
+			input:     []byte{},
+			tlvLength: 0,
+			wantFail:  true,
+		},
+		{
+			name:      "Incomplete",
+			input:     []byte{PrefixAttributeFlagNode},
+			tlvLength: 2,
+			wantFail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(test.input)
+		res, err := readPrefixAttributeFlagsSubTLV(buf, PrefixAttributeFlagsSubTLVType, test.tlvLength)
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+
+		assert.Equal(t, test.expected, res, test.name)
+		assert.Equal(t, test.remaining, buf.Len(), test.name)
+	}
+}
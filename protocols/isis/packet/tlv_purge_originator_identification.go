@@ -0,0 +1,109 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/bio-routing/bio-rd/util/decode"
+)
+
+const (
+	// PurgeOriginatorIdentificationTLVType is the type value of a purge originator identification TLV (RFC6232)
+	PurgeOriginatorIdentificationTLVType = 13
+
+	systemIDLen = 6
+)
+
+// PurgeOriginatorIdentificationTLV identifies the IS that purged an LSP. If the purge was relayed by an IS not
+// supporting RFC6232 in the first place, ReceivedFrom is the system the purge was received from.
+type PurgeOriginatorIdentificationTLV struct {
+	TLVType      uint8
+	TLVLength    uint8
+	Originator   types.SystemID
+	ReceivedFrom *types.SystemID
+}
+
+// NewPurgeOriginatorIdentificationTLV creates a new purge originator identification TLV
+func NewPurgeOriginatorIdentificationTLV(originator types.SystemID) *PurgeOriginatorIdentificationTLV {
+	return &PurgeOriginatorIdentificationTLV{
+		TLVType:    PurgeOriginatorIdentificationTLVType,
+		TLVLength:  1 + systemIDLen,
+		Originator: originator,
+	}
+}
+
+func readPurgeOriginatorIdentificationTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*PurgeOriginatorIdentificationTLV, error) {
+	pdu := &PurgeOriginatorIdentificationTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+	}
+
+	count := uint8(0)
+	err := decode.DecodeUint8(buf, &count)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode number of system IDs: %v", err)
+	}
+
+	if count < 1 || count > 2 || int(tlvLength) != 1+int(count)*systemIDLen {
+		return nil, fmt.Errorf("invalid length %d for %d system IDs", tlvLength, count)
+	}
+
+	fields := []interface{}{
+		&pdu.Originator,
+	}
+
+	if count == 2 {
+		pdu.ReceivedFrom = &types.SystemID{}
+		fields = append(fields, pdu.ReceivedFrom)
+	}
+
+	err = decode.Decode(buf, fields)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	return pdu, nil
+}
+
+// Serialize serializes a purge originator identification TLV
+func (p *PurgeOriginatorIdentificationTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(p.TLVType)
+	buf.WriteByte(p.TLVLength)
+
+	if p.ReceivedFrom == nil {
+		buf.WriteByte(1)
+		buf.Write(p.Originator[:])
+		return
+	}
+
+	buf.WriteByte(2)
+	buf.Write(p.Originator[:])
+	buf.Write(p.ReceivedFrom[:])
+}
+
+// Copy copies the TLV
+func (p *PurgeOriginatorIdentificationTLV) Copy() TLV {
+	ret := *p
+	if p.ReceivedFrom != nil {
+		receivedFrom := *p.ReceivedFrom
+		ret.ReceivedFrom = &receivedFrom
+	}
+
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (p *PurgeOriginatorIdentificationTLV) Type() uint8 {
+	return p.TLVType
+}
+
+// Length gets the length of the TLV
+func (p *PurgeOriginatorIdentificationTLV) Length() uint8 {
+	return p.TLVLength
+}
+
+// Value returns the TLV itself
+func (p *PurgeOriginatorIdentificationTLV) Value() interface{} {
+	return p
+}
@@ -0,0 +1,124 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPurgeOriginatorIdentificationTLV(t *testing.T) {
+	tlv := NewPurgeOriginatorIdentificationTLV(types.SystemID{1, 2, 3, 4, 5, 6})
+
+	expected := &PurgeOriginatorIdentificationTLV{
+		TLVType:    13,
+		TLVLength:  7,
+		Originator: types.SystemID{1, 2, 3, 4, 5, 6},
+	}
+
+	assert.Equal(t, expected, tlv)
+}
+
+func TestPurgeOriginatorIdentificationTLVSerialize(t *testing.T) {
+	tests := []struct {
+		name     string
+		tlv      *PurgeOriginatorIdentificationTLV
+		expected []byte
+	}{
+		{
+			name:     "Originator only",
+			tlv:      NewPurgeOriginatorIdentificationTLV(types.SystemID{1, 2, 3, 4, 5, 6}),
+			expected: []byte{13, 7, 1, 1, 2, 3, 4, 5, 6},
+		},
+		{
+			name: "Relayed",
+			tlv: &PurgeOriginatorIdentificationTLV{
+				TLVType:      13,
+				TLVLength:    13,
+				Originator:   types.SystemID{1, 2, 3, 4, 5, 6},
+				ReceivedFrom: &types.SystemID{10, 20, 30, 40, 50, 60},
+			},
+			expected: []byte{13, 13, 2, 1, 2, 3, 4, 5, 6, 10, 20, 30, 40, 50, 60},
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(nil)
+		test.tlv.Serialize(buf)
+		assert.Equal(t, test.expected, buf.Bytes(), test.name)
+	}
+}
+
+func TestReadPurgeOriginatorIdentificationTLV(t *testing.T) {
+	tests := []struct {
+		name      string
+		tlvLength uint8
+		pkt       []byte
+		expected  *PurgeOriginatorIdentificationTLV
+		wantFail  bool
+	}{
+		{
+			name:      "Originator only",
+			tlvLength: 7,
+			pkt:       []byte{1, 1, 2, 3, 4, 5, 6},
+			expected:  NewPurgeOriginatorIdentificationTLV(types.SystemID{1, 2, 3, 4, 5, 6}),
+		},
+		{
+			name:      "Relayed",
+			tlvLength: 13,
+			pkt:       []byte{2, 1, 2, 3, 4, 5, 6, 10, 20, 30, 40, 50, 60},
+			expected: &PurgeOriginatorIdentificationTLV{
+				TLVType:      13,
+				TLVLength:    13,
+				Originator:   types.SystemID{1, 2, 3, 4, 5, 6},
+				ReceivedFrom: &types.SystemID{10, 20, 30, 40, 50, 60},
+			},
+		},
+		{
+			name:      "Invalid number of system IDs",
+			tlvLength: 19,
+			pkt:       []byte{3, 1, 2, 3, 4, 5, 6, 1, 2, 3, 4, 5, 6, 1, 2, 3, 4, 5, 6},
+			wantFail:  true,
+		},
+		{
+			name:      "Length mismatch",
+			tlvLength: 7,
+			pkt:       []byte{2, 1, 2, 3, 4, 5, 6},
+			wantFail:  true,
+		},
+		{
+			name:      "Incomplete",
+			tlvLength: 7,
+			pkt:       []byte{1, 1, 2, 3},
+			wantFail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(test.pkt)
+		tlv, err := readPurgeOriginatorIdentificationTLV(buf, 13, test.tlvLength)
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, tlv, test.name)
+	}
+}
+
+func TestPurgeOriginatorIdentificationTLVCopy(t *testing.T) {
+	tlv := &PurgeOriginatorIdentificationTLV{
+		TLVType:      13,
+		TLVLength:    13,
+		Originator:   types.SystemID{1, 2, 3, 4, 5, 6},
+		ReceivedFrom: &types.SystemID{10, 20, 30, 40, 50, 60},
+	}
+
+	cp := tlv.Copy().(*PurgeOriginatorIdentificationTLV)
+	assert.Equal(t, tlv, cp)
+
+	cp.ReceivedFrom[0] = 0
+	assert.Equal(t, uint8(10), tlv.ReceivedFrom[0])
+}
@@ -0,0 +1,169 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bio-routing/bio-rd/util/decode"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+const (
+	// RouterCapabilityTLVType is the type value of a Router Capability TLV (RFC7981)
+	RouterCapabilityTLVType = 242
+
+	// RouterCapabilityFlagS (S-Flag) is set if the TLV is flooded across the entire routing domain
+	RouterCapabilityFlagS = 0x01
+
+	// RouterCapabilityFlagD (D-Flag) is set if the TLV has been leaked from level 2 into level 1
+	RouterCapabilityFlagD = 0x02
+
+	// RouterCapabilityTLVMinLen is the length of a Router Capability TLV without sub TLVs
+	RouterCapabilityTLVMinLen = 5
+)
+
+// RouterCapabilityTLV is a Router Capability TLV advertising the capabilities of an IS in nested sub TLVs
+type RouterCapabilityTLV struct {
+	TLVType   uint8
+	TLVLength uint8
+	RouterID  uint32
+	Flags     uint8
+	SubTLVs   []TLV
+}
+
+// NewRouterCapabilityTLV creates a new RouterCapabilityTLV
+func NewRouterCapabilityTLV(routerID uint32, flags uint8) *RouterCapabilityTLV {
+	return &RouterCapabilityTLV{
+		TLVType:   RouterCapabilityTLVType,
+		TLVLength: RouterCapabilityTLVMinLen,
+		RouterID:  routerID,
+		Flags:     flags,
+		SubTLVs:   make([]TLV, 0),
+	}
+}
+
+// AddSubTLV adds a sub TLV to the RouterCapabilityTLV
+func (r *RouterCapabilityTLV) AddSubTLV(tlv TLV) {
+	r.TLVLength += tlv.Length() + tlvBaseLen
+	r.SubTLVs = append(r.SubTLVs, tlv)
+}
+
+func readRouterCapabilityTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*RouterCapabilityTLV, error) {
+	if tlvLength < RouterCapabilityTLVMinLen {
+		return nil, fmt.Errorf("invalid length %d of TLV type %d, expected at least %d", tlvLength, tlvType, RouterCapabilityTLVMinLen)
+	}
+
+	if buf.Len() < int(tlvLength) {
+		return nil, fmt.Errorf("TLV length %d exceeds remaining %d bytes", tlvLength, buf.Len())
+	}
+
+	pdu := &RouterCapabilityTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+		SubTLVs:   make([]TLV, 0),
+	}
+
+	tlvBuf := bytes.NewBuffer(buf.Next(int(tlvLength)))
+	err := decode.Decode(tlvBuf, []interface{}{
+		&pdu.RouterID,
+		&pdu.Flags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	for tlvBuf.Len() > 0 {
+		stlv, err := readRouterCapabilitySubTLV(tlvBuf)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read sub TLV: %w", err)
+		}
+
+		pdu.SubTLVs = append(pdu.SubTLVs, stlv)
+	}
+
+	return pdu, nil
+}
+
+func readRouterCapabilitySubTLV(buf *bytes.Buffer) (TLV, error) {
+	tlvType := uint8(0)
+	tlvLength := uint8(0)
+
+	err := decode.Decode(buf, []interface{}{
+		&tlvType,
+		&tlvLength,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	if buf.Len() < int(tlvLength) {
+		return nil, fmt.Errorf("sub TLV length %d exceeds remaining %d bytes", tlvLength, buf.Len())
+	}
+
+	switch tlvType {
+	case SRCapabilitiesSubTLVType:
+		return readSRCapabilitiesSubTLV(buf, tlvType, tlvLength)
+	case SRAlgorithmSubTLVType:
+		return readSRAlgorithmSubTLV(buf, tlvType, tlvLength)
+	}
+
+	return readUnknownTLV(buf, tlvType, tlvLength)
+}
+
+// SRCapabilities returns the SR-Capabilities sub TLV or nil if there is none
+func (r *RouterCapabilityTLV) SRCapabilities() *SRCapabilitiesSubTLV {
+	for _, stlv := range r.SubTLVs {
+		if c, ok := stlv.(*SRCapabilitiesSubTLV); ok {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// SRAlgorithms returns the algorithms of the SR-Algorithm sub TLV or nil if there is none
+func (r *RouterCapabilityTLV) SRAlgorithms() []uint8 {
+	for _, stlv := range r.SubTLVs {
+		if a, ok := stlv.(*SRAlgorithmSubTLV); ok {
+			return a.Algorithms
+		}
+	}
+
+	return nil
+}
+
+func (r *RouterCapabilityTLV) Copy() TLV {
+	ret := *r
+	ret.SubTLVs = make([]TLV, 0, len(r.SubTLVs))
+	for _, stlv := range r.SubTLVs {
+		ret.SubTLVs = append(ret.SubTLVs, stlv.Copy())
+	}
+
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (r *RouterCapabilityTLV) Type() uint8 {
+	return r.TLVType
+}
+
+// Length gets the length of the TLV
+func (r *RouterCapabilityTLV) Length() uint8 {
+	return r.TLVLength
+}
+
+// Value returns the TLV itself
+func (r *RouterCapabilityTLV) Value() interface{} {
+	return r
+}
+
+// Serialize serializes a RouterCapabilityTLV
+func (r *RouterCapabilityTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(r.TLVType)
+	buf.WriteByte(r.TLVLength)
+	buf.Write(convert.Uint32Byte(r.RouterID))
+	buf.WriteByte(r.Flags)
+	for i := range r.SubTLVs {
+		r.SubTLVs[i].Serialize(buf)
+	}
+}
@@ -0,0 +1,156 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRouterCapabilityTLV() *RouterCapabilityTLV {
+	srCap := NewSRCapabilitiesSubTLV(SRCapabilitiesFlagI)
+	srCap.AddDescriptor(8000, NewSIDLabelSubTLVLabel(16000))
+
+	tlv := NewRouterCapabilityTLV(0x0a000001, 0)
+	tlv.AddSubTLV(srCap)
+	tlv.AddSubTLV(NewSRAlgorithmSubTLV([]uint8{SRAlgorithmSPF, SRAlgorithmStrictSPF}))
+	return tlv
+}
+
+func TestRouterCapabilityTLVSerialize(t *testing.T) {
+	tests := []struct {
+		name     string
+		tlv      *RouterCapabilityTLV
+		expected []byte
+	}{
+		{
+			name:     "No sub TLVs",
+			tlv:      NewRouterCapabilityTLV(0x0a000001, RouterCapabilityFlagS|RouterCapabilityFlagD),
+			expected: []byte{242, 5, 10, 0, 0, 1, 3},
+		},
+		{
+			name: "SR-Capabilities and SR-Algorithm",
+			tlv:  testRouterCapabilityTLV(),
+			expected: []byte{
+				242, 20, // Type, Length
+				10, 0, 0, 1, // Router ID
+				0,          // Flags
+				2, 9, 0x80, // SR-Capabilities
+				0x00, 0x1f, 0x40, // Range
+				1, 3, 0x00, 0x3e, 0x80, // SID/Label
+				19, 2, 0, 1, // SR-Algorithm
+			},
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(nil)
+		test.tlv.Serialize(buf)
+		assert.Equal(t, test.expected, buf.Bytes(), test.name)
+	}
+}
+
+func TestRouterCapabilityTLVRoundTrip(t *testing.T) {
+	srCap := NewSRCapabilitiesSubTLV(SRCapabilitiesFlagI | SRCapabilitiesFlagV)
+	srCap.AddDescriptor(8000, NewSIDLabelSubTLVLabel(16000))
+	srCap.AddDescriptor(1000, NewSIDLabelSubTLVIndex(100000))
+
+	withIndex := NewRouterCapabilityTLV(0xc0000201, RouterCapabilityFlagD)
+	withIndex.AddSubTLV(srCap)
+	withIndex.AddSubTLV(NewSRAlgorithmSubTLV([]uint8{SRAlgorithmSPF}))
+
+	tests := []struct {
+		name string
+		tlv  *RouterCapabilityTLV
+	}{
+		{
+			name: "No sub TLVs",
+			tlv:  NewRouterCapabilityTLV(0x0a000001, RouterCapabilityFlagS),
+		},
+		{
+			name: "SR-Capabilities and SR-Algorithm",
+			tlv:  testRouterCapabilityTLV(),
+		},
+		{
+			name: "Multiple SRGB ranges with labels and indexes",
+			tlv:  withIndex,
+		},
+	}
+
+	for _, test := range tests {
+		buf := bytes.NewBuffer(nil)
+		test.tlv.Serialize(buf)
+
+		tlv, err := readTLV(buf)
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+
+		assert.Equal(t, test.tlv, tlv, test.name)
+		assert.Equal(t, 0, buf.Len(), test.name)
+	}
+
+	tlv := withIndex.Copy().(*RouterCapabilityTLV)
+	assert.Equal(t, withIndex, tlv)
+	assert.Equal(t, []uint8{SRAlgorithmSPF}, tlv.SRAlgorithms())
+	assert.Equal(t, []SRGBDescriptor{
+		{Range: 8000, SIDLabel: SIDLabelSubTLV{TLVType: 1, TLVLength: 3, SIDLabel: 16000}},
+		{Range: 1000, SIDLabel: SIDLabelSubTLV{TLVType: 1, TLVLength: 4, SIDLabel: 100000}},
+	}, tlv.SRCapabilities().Descriptors)
+}
+
+func TestReadRouterCapabilityTLV(t *testing.T) {
+	tests := []struct {
+		name     string
+		pkt      []byte
+		expected *RouterCapabilityTLV
+		wantFail bool
+	}{
+		{
+			name: "Unknown sub TLV",
+			pkt:  []byte{242, 9, 10, 0, 0, 1, 0, 23, 2, 1, 2},
+			expected: &RouterCapabilityTLV{
+				TLVType:   242,
+				TLVLength: 9,
+				RouterID:  0x0a000001,
+				SubTLVs: []TLV{
+					&UnknownTLV{TLVType: 23, TLVLength: 2, TLVValue: []byte{1, 2}},
+				},
+			},
+		},
+		{
+			name:     "Too short",
+			pkt:      []byte{242, 4, 10, 0, 0, 1},
+			wantFail: true,
+		},
+		{
+			name:     "Sub TLV exceeds TLV",
+			pkt:      []byte{242, 9, 10, 0, 0, 1, 0, 19, 3, 0, 1},
+			wantFail: true,
+		},
+		{
+			name:     "Invalid SID/Label length",
+			pkt:      []byte{242, 15, 10, 0, 0, 1, 0, 2, 8, 0x80, 0, 0, 1, 1, 2, 0, 1},
+			wantFail: true,
+		},
+		{
+			name:     "Unexpected SID/Label sub TLV type",
+			pkt:      []byte{242, 16, 10, 0, 0, 1, 0, 2, 9, 0x80, 0, 0, 1, 2, 3, 0, 0, 1},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		tlv, err := readTLV(bytes.NewBuffer(test.pkt))
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+
+		assert.Equal(t, test.expected, tlv, test.name)
+	}
+}
@@ -0,0 +1,283 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/bio-routing/bio-rd/util/decode"
+	"github.com/bio-routing/tflow2/convert"
+)
+
+// Segment Routing sub TLVs of the Router Capability TLV (RFC8667)
+const (
+	// SIDLabelSubTLVType is the type value of a SID/Label Sub TLV
+	SIDLabelSubTLVType = 1
+
+	// SRCapabilitiesSubTLVType is the type value of an SR-Capabilities Sub TLV
+	SRCapabilitiesSubTLVType = 2
+
+	// SRAlgorithmSubTLVType is the type value of an SR-Algorithm Sub TLV
+	SRAlgorithmSubTLVType = 19
+
+	// SRCapabilitiesFlagI (I-Flag) is set if the IS is capable of processing SR MPLS encapsulated IPv4 packets
+	SRCapabilitiesFlagI = 0x80
+
+	// SRCapabilitiesFlagV (V-Flag) is set if the IS is capable of processing SR MPLS encapsulated IPv6 packets
+	SRCapabilitiesFlagV = 0x40
+
+	// SRAlgorithmSPF is the Shortest Path First algorithm based on the IGP metric
+	SRAlgorithmSPF = 0
+
+	// SRAlgorithmStrictSPF is the Strict Shortest Path First algorithm
+	SRAlgorithmStrictSPF = 1
+
+	sidLabelLabelLen = 3
+	sidLabelIndexLen = 4
+	srgbRangeLen     = 3
+	maxLabel         = 0xfffff
+)
+
+// SIDLabelSubTLV is a SID/Label Sub TLV carrying either an MPLS label (length 3) or a SID index (length 4)
+type SIDLabelSubTLV struct {
+	TLVType   uint8
+	TLVLength uint8
+	SIDLabel  uint32
+}
+
+// NewSIDLabelSubTLVLabel creates a new SIDLabelSubTLV carrying an MPLS label
+func NewSIDLabelSubTLVLabel(label uint32) SIDLabelSubTLV {
+	return SIDLabelSubTLV{
+		TLVType:   SIDLabelSubTLVType,
+		TLVLength: sidLabelLabelLen,
+		SIDLabel:  label & maxLabel,
+	}
+}
+
+// NewSIDLabelSubTLVIndex creates a new SIDLabelSubTLV carrying a SID index
+func NewSIDLabelSubTLVIndex(index uint32) SIDLabelSubTLV {
+	return SIDLabelSubTLV{
+		TLVType:   SIDLabelSubTLVType,
+		TLVLength: sidLabelIndexLen,
+		SIDLabel:  index,
+	}
+}
+
+// IsLabel returns if the SIDLabelSubTLV carries an MPLS label rather than a SID index
+func (s *SIDLabelSubTLV) IsLabel() bool {
+	return s.TLVLength == sidLabelLabelLen
+}
+
+func readSIDLabelSubTLV(buf *bytes.Buffer) (SIDLabelSubTLV, error) {
+	s := SIDLabelSubTLV{}
+
+	err := decode.Decode(buf, []interface{}{
+		&s.TLVType,
+		&s.TLVLength,
+	})
+	if err != nil {
+		return s, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	if s.TLVType != SIDLabelSubTLVType {
+		return s, fmt.Errorf("unexpected sub TLV type %d, expected %d", s.TLVType, SIDLabelSubTLVType)
+	}
+
+	if s.TLVLength != sidLabelLabelLen && s.TLVLength != sidLabelIndexLen {
+		return s, fmt.Errorf("invalid length %d of sub TLV type %d, expected %d or %d", s.TLVLength, s.TLVType, sidLabelLabelLen, sidLabelIndexLen)
+	}
+
+	if buf.Len() < int(s.TLVLength) {
+		return s, fmt.Errorf("sub TLV length %d exceeds remaining %d bytes", s.TLVLength, buf.Len())
+	}
+
+	for _, b := range buf.Next(int(s.TLVLength)) {
+		s.SIDLabel = s.SIDLabel<<8 | uint32(b)
+	}
+
+	if s.IsLabel() {
+		s.SIDLabel &= maxLabel
+	}
+
+	return s, nil
+}
+
+// Serialize serializes a SIDLabelSubTLV
+func (s *SIDLabelSubTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(s.TLVType)
+	buf.WriteByte(s.TLVLength)
+	buf.Write(convert.Uint32Byte(s.SIDLabel)[4-s.TLVLength:])
+}
+
+// SRGBDescriptor describes a range of the Segment Routing Global Block (SRGB) starting at the label or index of SIDLabel
+type SRGBDescriptor struct {
+	Range    uint32
+	SIDLabel SIDLabelSubTLV
+}
+
+func (d *SRGBDescriptor) length() uint8 {
+	return srgbRangeLen + tlvBaseLen + d.SIDLabel.TLVLength
+}
+
+// SRCapabilitiesSubTLV is an SR-Capabilities Sub TLV advertising the SRGB of an IS
+type SRCapabilitiesSubTLV struct {
+	TLVType     uint8
+	TLVLength   uint8
+	Flags       uint8
+	Descriptors []SRGBDescriptor
+}
+
+// NewSRCapabilitiesSubTLV creates a new SRCapabilitiesSubTLV
+func NewSRCapabilitiesSubTLV(flags uint8) *SRCapabilitiesSubTLV {
+	return &SRCapabilitiesSubTLV{
+		TLVType:     SRCapabilitiesSubTLVType,
+		TLVLength:   1,
+		Flags:       flags,
+		Descriptors: make([]SRGBDescriptor, 0),
+	}
+}
+
+// AddDescriptor adds an SRGB range of rangeSize labels or indexes starting at sidLabel
+func (s *SRCapabilitiesSubTLV) AddDescriptor(rangeSize uint32, sidLabel SIDLabelSubTLV) {
+	d := SRGBDescriptor{
+		Range:    rangeSize,
+		SIDLabel: sidLabel,
+	}
+
+	s.TLVLength += d.length()
+	s.Descriptors = append(s.Descriptors, d)
+}
+
+func readSRCapabilitiesSubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*SRCapabilitiesSubTLV, error) {
+	if tlvLength < 1 {
+		return nil, fmt.Errorf("invalid length %d of sub TLV type %d", tlvLength, tlvType)
+	}
+
+	pdu := &SRCapabilitiesSubTLV{
+		TLVType:     tlvType,
+		TLVLength:   tlvLength,
+		Descriptors: make([]SRGBDescriptor, 0),
+	}
+
+	tlvBuf := bytes.NewBuffer(buf.Next(int(tlvLength)))
+	err := decode.Decode(tlvBuf, []interface{}{
+		&pdu.Flags,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode fields: %v", err)
+	}
+
+	for tlvBuf.Len() > 0 {
+		rangeSize := [srgbRangeLen]byte{}
+		err := decode.Decode(tlvBuf, []interface{}{
+			&rangeSize,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode SRGB range: %v", err)
+		}
+
+		sidLabel, err := readSIDLabelSubTLV(tlvBuf)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read SID/Label sub TLV: %w", err)
+		}
+
+		pdu.Descriptors = append(pdu.Descriptors, SRGBDescriptor{
+			Range:    uint32(rangeSize[0])<<16 + uint32(rangeSize[1])<<8 + uint32(rangeSize[2]),
+			SIDLabel: sidLabel,
+		})
+	}
+
+	return pdu, nil
+}
+
+func (s *SRCapabilitiesSubTLV) Copy() TLV {
+	ret := *s
+	ret.Descriptors = make([]SRGBDescriptor, len(s.Descriptors))
+	copy(ret.Descriptors, s.Descriptors)
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (s *SRCapabilitiesSubTLV) Type() uint8 {
+	return s.TLVType
+}
+
+// Length gets the length of the TLV
+func (s *SRCapabilitiesSubTLV) Length() uint8 {
+	return s.TLVLength
+}
+
+// Value returns the TLV itself
+func (s *SRCapabilitiesSubTLV) Value() interface{} {
+	return s
+}
+
+// Serialize serializes an SRCapabilitiesSubTLV
+func (s *SRCapabilitiesSubTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(s.TLVType)
+	buf.WriteByte(s.TLVLength)
+	buf.WriteByte(s.Flags)
+	for i := range s.Descriptors {
+		buf.Write(convert.Uint32Byte(s.Descriptors[i].Range)[1:])
+		s.Descriptors[i].SIDLabel.Serialize(buf)
+	}
+}
+
+// SRAlgorithmSubTLV is an SR-Algorithm Sub TLV advertising the algorithms an IS uses to compute reachability
+type SRAlgorithmSubTLV struct {
+	TLVType    uint8
+	TLVLength  uint8
+	Algorithms []uint8
+}
+
+// NewSRAlgorithmSubTLV creates a new SRAlgorithmSubTLV
+func NewSRAlgorithmSubTLV(algorithms []uint8) *SRAlgorithmSubTLV {
+	return &SRAlgorithmSubTLV{
+		TLVType:    SRAlgorithmSubTLVType,
+		TLVLength:  uint8(len(algorithms)),
+		Algorithms: algorithms,
+	}
+}
+
+func readSRAlgorithmSubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*SRAlgorithmSubTLV, error) {
+	if tlvLength < 1 {
+		return nil, fmt.Errorf("invalid length %d of sub TLV type %d", tlvLength, tlvType)
+	}
+
+	pdu := &SRAlgorithmSubTLV{
+		TLVType:    tlvType,
+		TLVLength:  tlvLength,
+		Algorithms: make([]uint8, tlvLength),
+	}
+
+	copy(pdu.Algorithms, buf.Next(int(tlvLength)))
+	return pdu, nil
+}
+
+func (s *SRAlgorithmSubTLV) Copy() TLV {
+	ret := *s
+	ret.Algorithms = make([]uint8, len(s.Algorithms))
+	copy(ret.Algorithms, s.Algorithms)
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (s *SRAlgorithmSubTLV) Type() uint8 {
+	return s.TLVType
+}
+
+// Length gets the length of the TLV
+func (s *SRAlgorithmSubTLV) Length() uint8 {
+	return s.TLVLength
+}
+
+// Value returns the TLV itself
+func (s *SRAlgorithmSubTLV) Value() interface{} {
+	return s
+}
+
+// Serialize serializes an SRAlgorithmSubTLV
+func (s *SRAlgorithmSubTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(s.TLVType)
+	buf.WriteByte(s.TLVLength)
+	buf.Write(s.Algorithms)
+}
@@ -0,0 +1,56 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSIDLabelSubTLV(t *testing.T) {
+	tests := []struct {
+		name     string
+		sidLabel SIDLabelSubTLV
+		label    bool
+		expected []byte
+	}{
+		{
+			name:     "Label",
+			sidLabel: NewSIDLabelSubTLVLabel(16000),
+			label:    true,
+			expected: []byte{1, 3, 0x00, 0x3e, 0x80},
+		},
+		{
+			name:     "Label exceeding 20 bits",
+			sidLabel: NewSIDLabelSubTLVLabel(0xf00001),
+			label:    true,
+			expected: []byte{1, 3, 0x00, 0x00, 0x01},
+		},
+		{
+			name:     "Index",
+			sidLabel: NewSIDLabelSubTLVIndex(0x01020304),
+			expected: []byte{1, 4, 1, 2, 3, 4},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.label, test.sidLabel.IsLabel(), test.name)
+
+		buf := bytes.NewBuffer(nil)
+		test.sidLabel.Serialize(buf)
+		assert.Equal(t, test.expected, buf.Bytes(), test.name)
+
+		s, err := readSIDLabelSubTLV(buf)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.sidLabel, s, test.name)
+	}
+}
+
+func TestReadSRAlgorithmSubTLV(t *testing.T) {
+	tlv, err := readRouterCapabilitySubTLV(bytes.NewBuffer([]byte{19, 2, 0, 1}))
+	assert.NoError(t, err)
+	assert.Equal(t, NewSRAlgorithmSubTLV([]uint8{SRAlgorithmSPF, SRAlgorithmStrictSPF}), tlv)
+
+	_, err = readRouterCapabilitySubTLV(bytes.NewBuffer([]byte{19, 0}))
+	assert.Error(t, err, "no algorithms")
+}
@@ -0,0 +1,93 @@
+package packet
+
+import (
+	"bytes"
+	"fmt"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+const (
+	// IPv4SourceRouterIDSubTLVType is the type value of an IPv4 Source Router ID Sub TLV (RFC7794)
+	IPv4SourceRouterIDSubTLVType = 11
+
+	// IPv6SourceRouterIDSubTLVType is the type value of an IPv6 Source Router ID Sub TLV (RFC7794)
+	IPv6SourceRouterIDSubTLVType = 12
+)
+
+// SourceRouterIDSubTLV is an IPv4 or IPv6 Source Router ID Sub TLV carrying the router ID of the originator of a prefix
+type SourceRouterIDSubTLV struct {
+	TLVType   uint8
+	TLVLength uint8
+	Address   bnet.IP
+}
+
+// NewSourceRouterIDSubTLV creates a new SourceRouterIDSubTLV. The address family of addr selects the type.
+func NewSourceRouterIDSubTLV(addr bnet.IP) *SourceRouterIDSubTLV {
+	if addr.IsIPv4() {
+		return &SourceRouterIDSubTLV{
+			TLVType:   IPv4SourceRouterIDSubTLVType,
+			TLVLength: 4,
+			Address:   addr,
+		}
+	}
+
+	return &SourceRouterIDSubTLV{
+		TLVType:   IPv6SourceRouterIDSubTLVType,
+		TLVLength: 16,
+		Address:   addr,
+	}
+}
+
+func readSourceRouterIDSubTLV(buf *bytes.Buffer, tlvType uint8, tlvLength uint8) (*SourceRouterIDSubTLV, error) {
+	expected := uint8(4)
+	if tlvType == IPv6SourceRouterIDSubTLVType {
+		expected = 16
+	}
+
+	if tlvLength != expected {
+		return nil, fmt.Errorf("invalid length %d of sub TLV type %d, expected %d", tlvLength, tlvType, expected)
+	}
+
+	if buf.Len() < int(tlvLength) {
+		return nil, fmt.Errorf("sub TLV length %d exceeds remaining %d bytes", tlvLength, buf.Len())
+	}
+
+	addr, err := bnet.IPFromBytes(buf.Next(int(tlvLength)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode address: %w", err)
+	}
+
+	return &SourceRouterIDSubTLV{
+		TLVType:   tlvType,
+		TLVLength: tlvLength,
+		Address:   addr,
+	}, nil
+}
+
+func (s *SourceRouterIDSubTLV) Copy() TLV {
+	ret := *s
+	return &ret
+}
+
+// Type gets the type of the TLV
+func (s *SourceRouterIDSubTLV) Type() uint8 {
+	return s.TLVType
+}
+
+// Length gets the length of the TLV
+func (s *SourceRouterIDSubTLV) Length() uint8 {
+	return s.TLVLength
+}
+
+// Value returns the TLV itself
+func (s *SourceRouterIDSubTLV) Value() interface{} {
+	return s
+}
+
+// Serialize serializes a SourceRouterIDSubTLV
+func (s *SourceRouterIDSubTLV) Serialize(buf *bytes.Buffer) {
+	buf.WriteByte(s.TLVType)
+	buf.WriteByte(s.TLVLength)
+	buf.Write(s.Address.Bytes())
+}
@@ -0,0 +1,61 @@
+package packet
+
+import (
+	"bytes"
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadSourceRouterIDSubTLV(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     []byte
+		tlvType   uint8
+		tlvLength uint8
+		wantFail  bool
+		expected  *SourceRouterIDSubTLV
+	}{
+		{
+			name:      "IPv4",
+			input:     []byte{10, 0, 0, 1},
+			tlvType:   IPv4SourceRouterIDSubTLVType,
+			tlvLength: 4,
+			expected:  NewSourceRouterIDSubTLV(bnet.IPv4FromOctets(10, 0, 0, 1)),
+		},
+		{
+			name:      "IPv6",
+			input:     []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+			tlvType:   IPv6SourceRouterIDSubTLVType,
+			tlvLength: 16,
+			expected:  NewSourceRouterIDSubTLV(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1)),
+		},
+		{
+			name:      "IPv4 with IPv6 length",
+			input:     []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+			tlvType:   IPv4SourceRouterIDSubTLVType,
+			tlvLength: 16,
+			wantFail:  true,
+		},
+		{
+			name:      "Incomplete",
+			input:     []byte{10, 0},
+			tlvType:   IPv4SourceRouterIDSubTLVType,
+			tlvLength: 4,
+			wantFail:  true,
+		},
+	}
+
+	for _, test := range tests {
+		res, err := readSourceRouterIDSubTLV(bytes.NewBuffer(test.input), test.tlvType, test.tlvLength)
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		if assert.NoError(t, err, test.name) {
+			assert.Equal(t, test.expected, res, test.name)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+)
+
+// keychain gets the keychain authenticating PDUs of pduType. nil is returned if these PDUs are not authenticated.
+func (lc *LevelConfig) keychain(pduType uint8) *Keychain {
+	if lc == nil {
+		return nil
+	}
+
+	switch pduType {
+	case packet.P2P_HELLO, packet.L1_LAN_HELLO_TYPE, packet.L2_LAN_HELLO_TYPE:
+		if lc.NoHelloAuthentication {
+			return nil
+		}
+	case packet.L1_CSNP_TYPE, packet.L2_CSNP_TYPE:
+		if lc.NoCSNPAuthentication {
+			return nil
+		}
+	case packet.L1_PSNP_TYPE, packet.L2_PSNP_TYPE:
+		if lc.NoPSNPAuthentication {
+			return nil
+		}
+	}
+
+	return lc.Keychain
+}
+
+// authenticationTLV creates the authentication TLV of a PDU of a level. nil is returned if the PDU is not authenticated.
+func (s *Server) authenticationTLV(level int, pduType uint8) *packet.AuthenticationTLV {
+	k := s.levelConfig(level).keychain(pduType)
+	if k == nil {
+		return nil
+	}
+
+	return k.authenticationTLV(s.clock.Now())
+}
+
+// authenticationLen gets the number of bytes the authentication TLV adds to a PDU of a level
+func (s *Server) authenticationLen(level int, pduType uint8) int {
+	a := s.authenticationTLV(level, pduType)
+	if a == nil {
+		return 0
+	}
+
+	return int(a.Length()) + 2
+}
+
+// authenticated checks if a received PDU of a level carries valid authentication
+func (s *Server) authenticated(level int, pduType uint8, tlvs []packet.TLV) bool {
+	k := s.levelConfig(level).keychain(pduType)
+	if k == nil {
+		return true
+	}
+
+	return k.authenticate(tlvs, s.clock.Now())
+}
+
+// helloLevel gets the level whose keychain authenticates the point-to-point hellos of the interface
+func (nifa *netIfa) helloLevel() int {
+	if nifa.cfg.Level1 != nil {
+		return 1
+	}
+
+	return 2
+}
+
+// pduAuthenticated checks if a received PDU carries valid authentication
+func (nifa *netIfa) pduAuthenticated(pkt *packet.ISISPacket) bool {
+	switch pkt.Header.PDUType {
+	case packet.P2P_HELLO:
+		return nifa.srv.authenticated(nifa.helloLevel(), pkt.Header.PDUType, pkt.Body.(*packet.P2PHello).TLVs)
+	case packet.L1_LS_PDU_TYPE, packet.L2_LS_PDU_TYPE:
+		return nifa.srv.authenticated(pduLevel(pkt.Header.PDUType), pkt.Header.PDUType, pkt.Body.(*packet.LSPDU).TLVs)
+	case packet.L1_CSNP_TYPE, packet.L2_CSNP_TYPE:
+		return nifa.srv.authenticated(pduLevel(pkt.Header.PDUType), pkt.Header.PDUType, pkt.Body.(*packet.CSNP).TLVs)
+	case packet.L1_PSNP_TYPE, packet.L2_PSNP_TYPE:
+		return nifa.srv.authenticated(pduLevel(pkt.Header.PDUType), pkt.Header.PDUType, pkt.Body.(*packet.PSNP).TLVs)
+	}
+
+	return true
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"sync/atomic"
+
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+)
+
+// Counters are the PDU and event counters of an interface or of the whole ISIS server
+type Counters struct {
+	HellosSent     uint64
+	HellosReceived uint64
+	LSPsSent       uint64
+	LSPsReceived   uint64
+	CSNPsSent      uint64
+	CSNPsReceived  uint64
+	PSNPsSent      uint64
+	PSNPsReceived  uint64
+
+	// AuthenticationFailures counts PDUs dropped due to failed authentication
+	AuthenticationFailures uint64
+
+	// ChecksumErrors counts received LSPs dropped due to an invalid checksum
+	ChecksumErrors uint64
+
+	// AdjacencyChanges counts adjacencies coming up or going down
+	AdjacencyChanges uint64
+}
+
+// InterfaceCounters are the counters of an interface
+type InterfaceCounters struct {
+	Name     string
+	Counters Counters
+}
+
+type counters struct {
+	hellosSent             atomic.Uint64
+	hellosReceived         atomic.Uint64
+	lspsSent               atomic.Uint64
+	lspsReceived           atomic.Uint64
+	csnpsSent              atomic.Uint64
+	csnpsReceived          atomic.Uint64
+	psnpsSent              atomic.Uint64
+	psnpsReceived          atomic.Uint64
+	authenticationFailures atomic.Uint64
+	checksumErrors         atomic.Uint64
+	adjacencyChanges       atomic.Uint64
+}
+
+func (c *counters) get() Counters {
+	return Counters{
+		HellosSent:             c.hellosSent.Load(),
+		HellosReceived:         c.hellosReceived.Load(),
+		LSPsSent:               c.lspsSent.Load(),
+		LSPsReceived:           c.lspsReceived.Load(),
+		CSNPsSent:              c.csnpsSent.Load(),
+		CSNPsReceived:          c.csnpsReceived.Load(),
+		PSNPsSent:              c.psnpsSent.Load(),
+		PSNPsReceived:          c.psnpsReceived.Load(),
+		AuthenticationFailures: c.authenticationFailures.Load(),
+		ChecksumErrors:         c.checksumErrors.Load(),
+		AdjacencyChanges:       c.adjacencyChanges.Load(),
+	}
+}
+
+func (c *counters) sent(pduType uint8) {
+	switch pduType {
+	case packet.P2P_HELLO:
+		c.hellosSent.Add(1)
+	case packet.L1_LS_PDU_TYPE, packet.L2_LS_PDU_TYPE:
+		c.lspsSent.Add(1)
+	case packet.L1_CSNP_TYPE, packet.L2_CSNP_TYPE:
+		c.csnpsSent.Add(1)
+	case packet.L1_PSNP_TYPE, packet.L2_PSNP_TYPE:
+		c.psnpsSent.Add(1)
+	}
+}
+
+func (c *counters) received(pduType uint8) {
+	switch pduType {
+	case packet.P2P_HELLO:
+		c.hellosReceived.Add(1)
+	case packet.L1_LS_PDU_TYPE, packet.L2_LS_PDU_TYPE:
+		c.lspsReceived.Add(1)
+	case packet.L1_CSNP_TYPE, packet.L2_CSNP_TYPE:
+		c.csnpsReceived.Add(1)
+	case packet.L1_PSNP_TYPE, packet.L2_PSNP_TYPE:
+		c.psnpsReceived.Add(1)
+	}
+}
+
+// countSent counts a PDU sent on the interface
+func (nifa *netIfa) countSent(pduType uint8) {
+	nifa.counters.sent(pduType)
+	nifa.srv.counters.sent(pduType)
+}
+
+// countReceived counts a PDU received on the interface
+func (nifa *netIfa) countReceived(pduType uint8) {
+	nifa.counters.received(pduType)
+	nifa.srv.counters.received(pduType)
+}
+
+func (nifa *netIfa) countChecksumError() {
+	nifa.counters.checksumErrors.Add(1)
+	nifa.srv.counters.checksumErrors.Add(1)
+}
+
+func (nifa *netIfa) countAuthenticationFailure() {
+	nifa.counters.authenticationFailures.Add(1)
+	nifa.srv.counters.authenticationFailures.Add(1)
+}
+
+func (nifa *netIfa) countAdjacencyChange() {
+	nifa.counters.adjacencyChanges.Add(1)
+	nifa.srv.counters.adjacencyChanges.Add(1)
+}
+
+// GetCounters gets the counters of the whole server
+func (s *Server) GetCounters() Counters {
+	return s.counters.get()
+}
+
+// GetInterfaceCounters gets the counters of all interfaces
+func (s *Server) GetInterfaceCounters() []*InterfaceCounters {
+	ret := make([]*InterfaceCounters, 0)
+	for _, nifa := range s.netIfaManager.getAllInterfaces() {
+		ret = append(ret, &InterfaceCounters{
+			Name:     nifa.name,
+			Counters: nifa.counters.get(),
+		})
+	}
+
+	return ret
+}
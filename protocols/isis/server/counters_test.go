@@ -0,0 +1,72 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/net/ethernet"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/stretchr/testify/assert"
+
+	btesting "github.com/bio-routing/bio-rd/testing"
+)
+
+// llcPDU serializes a PDU the way it is handed to processPkt, i.e. including the LLC header
+func llcPDU(pkt packet.Serializable, pduType uint8) []byte {
+	return append([]byte{0xfe, 0xfe, 0x03}, serializePDU(pkt, pduType)...)
+}
+
+func TestCounters(t *testing.T) {
+	s := newLeakTestServer(2)
+	s.lspLifetime = 1200
+	s.hostnames = newHostnameMap(spfTestSysA, "")
+	nifa := s.netIfaManager.netIfas["eth2"]
+	nifa.isP2PHelloCon = &countingConn{
+		MockConn: btesting.NewMockConn(),
+	}
+	nm := nifa.neighborManagerL2
+	src := ethernet.MACAddr{2}
+
+	n := &neighbor{
+		sysID: spfTestSysB,
+		nm:    nm,
+		state: packet.P2PAdjStateUp,
+	}
+	nm.neighbors[src] = n
+	nm.adjacencyUp(n)
+
+	valid := spfTestLSP(spfTestSysB, 0)
+	valid.SetChecksum()
+	assert.NoError(t, nifa.processPkt(src, llcPDU(valid, packet.L2_LS_PDU_TYPE)))
+
+	corrupt := spfTestLSP(spfTestSysC, 0)
+	corrupt.SetChecksum()
+	corrupt.Checksum++
+	assert.NoError(t, nifa.processPkt(src, llcPDU(corrupt, packet.L2_LS_PDU_TYPE)))
+
+	csnp := &packet.CSNP{
+		StartLSPID: packet.LSPID{},
+		EndLSPID:   packet.LSPID{SystemID: types.SystemID{255, 255, 255, 255, 255, 255}, PseudonodeID: 255, LSPNumber: 255},
+	}
+	assert.NoError(t, nifa.sendPDU(csnp, packet.L2_CSNP_TYPE))
+
+	expected := Counters{
+		LSPsReceived:     2,
+		CSNPsSent:        1,
+		ChecksumErrors:   1,
+		AdjacencyChanges: 1,
+	}
+
+	assert.Equal(t, expected, s.GetCounters())
+	assert.Equal(t, []*InterfaceCounters{
+		{
+			Name:     "eth2",
+			Counters: expected,
+		},
+	}, s.GetInterfaceCounters())
+
+	_, found := s.lsdbL2.lsps[valid.LSPID]
+	assert.True(t, found, "valid LSP must be installed")
+	_, found = s.lsdbL2.lsps[corrupt.LSPID]
+	assert.False(t, found, "LSP with invalid checksum must be dropped")
+}
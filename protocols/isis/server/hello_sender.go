@@ -31,7 +31,10 @@ func (nifa *netIfa) p2pHelloSender() {
 			_, err := nifa.isP2PHelloCon.Write(hdrBuf.Bytes())
 			if err != nil {
 				log.WithFields(nifa.fields()).WithError(err).Error("Unable to send hello packet")
+				continue
 			}
+
+			nifa.countSent(packet.P2P_HELLO)
 		}
 
 	}
@@ -82,10 +85,14 @@ func (nifa *netIfa) p2pHello() *packet.P2PHello {
 
 	areas := make([]types.AreaID, 0)
 	for _, net := range nifa.srv.nets {
-		areas = append(areas, append([]byte{net.AFI}, net.AreaID...))
+		areas = append(areas, net.AreaAddress())
 	}
 	h.TLVs = append(h.TLVs, packet.NewAreaAddressesTLV(areas))
 
+	if a := nifa.srv.authenticationTLV(nifa.helloLevel(), packet.P2P_HELLO); a != nil {
+		h.TLVs = append(h.TLVs, a)
+	}
+
 	return h
 }
 
@@ -0,0 +1,95 @@
+package server
+
+import (
+	"sync"
+
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+)
+
+// hostnameMap maps system IDs to the dynamic hostnames (RFC5301) advertised in their LSPs
+type hostnameMap struct {
+	localSysID    types.SystemID
+	localHostname string
+	names         map[types.SystemID]string
+	namesMu       sync.RWMutex
+}
+
+// newHostnameMap creates a new hostnameMap. The hostname of the local system is never altered by received LSPs.
+func newHostnameMap(localSysID types.SystemID, localHostname string) *hostnameMap {
+	return &hostnameMap{
+		localSysID:    localSysID,
+		localHostname: localHostname,
+		names:         make(map[types.SystemID]string),
+	}
+}
+
+// update learns the hostname of the originator of lspdu. Only the first fragment of the non-pseudonode LSP is
+// considered as this is where the dynamic hostname TLV is carried. An LSP without hostname TLV removes a known name.
+func (h *hostnameMap) update(lspdu *packet.LSPDU) {
+	if !h.learnsFrom(lspdu.LSPID) {
+		return
+	}
+
+	for _, tlv := range lspdu.TLVs {
+		if tlv.Type() != packet.DynamicHostNameTLVType {
+			continue
+		}
+
+		h.set(lspdu.LSPID.SystemID, string(tlv.(*packet.DynamicHostNameTLV).Hostname))
+		return
+	}
+
+	h.remove(lspdu.LSPID.SystemID)
+}
+
+func (h *hostnameMap) set(sysID types.SystemID, name string) {
+	h.namesMu.Lock()
+	defer h.namesMu.Unlock()
+
+	h.names[sysID] = name
+}
+
+func (h *hostnameMap) remove(sysID types.SystemID) {
+	h.namesMu.Lock()
+	defer h.namesMu.Unlock()
+
+	delete(h.names, sysID)
+}
+
+// expire removes the hostname learned from the LSP identified by lspID after it has been purged from the LSDB
+func (h *hostnameMap) expire(lspID packet.LSPID) {
+	if !h.learnsFrom(lspID) {
+		return
+	}
+
+	h.remove(lspID.SystemID)
+}
+
+func (h *hostnameMap) learnsFrom(lspID packet.LSPID) bool {
+	return lspID.PseudonodeID == 0 && lspID.LSPNumber == 0 && lspID.SystemID != h.localSysID
+}
+
+// get gets the hostname of sysID
+func (h *hostnameMap) get(sysID types.SystemID) (string, bool) {
+	if sysID == h.localSysID && h.localHostname != "" {
+		return h.localHostname, true
+	}
+
+	h.namesMu.RLock()
+	defer h.namesMu.RUnlock()
+
+	name, found := h.names[sysID]
+	return name, found
+}
+
+// resolve gets the hostname of sysID or the system ID itself if its hostname is unknown
+func (h *hostnameMap) resolve(sysID types.SystemID) string {
+	if h != nil {
+		if name, found := h.get(sysID); found {
+			return name
+		}
+	}
+
+	return sysID.String()
+}
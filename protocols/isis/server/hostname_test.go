@@ -0,0 +1,139 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+)
+
+func TestHostnameMap(t *testing.T) {
+	localSysID := types.SystemID{1, 1, 1, 1, 1, 1}
+	remoteSysID := types.SystemID{2, 2, 2, 2, 2, 2}
+	unknownSysID := types.SystemID{3, 3, 3, 3, 3, 3}
+
+	lspWithHostname := func(lspID packet.LSPID, name string) *packet.LSPDU {
+		return &packet.LSPDU{
+			LSPID: lspID,
+			TLVs: []packet.TLV{
+				packet.NewDynamicHostnameTLV([]byte(name)),
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		update    []*packet.LSPDU
+		expire    []packet.LSPID
+		lookup    types.SystemID
+		expected  string
+		wantFound bool
+	}{
+		{
+			name:      "Local hostname",
+			lookup:    localSysID,
+			expected:  "local",
+			wantFound: true,
+		},
+		{
+			name: "Remote hostname learned",
+			update: []*packet.LSPDU{
+				lspWithHostname(packet.LSPID{SystemID: remoteSysID}, "remote"),
+			},
+			lookup:    remoteSysID,
+			expected:  "remote",
+			wantFound: true,
+		},
+		{
+			name: "Remote hostname changed",
+			update: []*packet.LSPDU{
+				lspWithHostname(packet.LSPID{SystemID: remoteSysID}, "remote"),
+				lspWithHostname(packet.LSPID{SystemID: remoteSysID}, "remote-new"),
+			},
+			lookup:    remoteSysID,
+			expected:  "remote-new",
+			wantFound: true,
+		},
+		{
+			name: "Hostname TLV removed",
+			update: []*packet.LSPDU{
+				lspWithHostname(packet.LSPID{SystemID: remoteSysID}, "remote"),
+				{
+					LSPID: packet.LSPID{SystemID: remoteSysID},
+				},
+			},
+			lookup: remoteSysID,
+		},
+		{
+			name: "Fragments and pseudonode LSPs are ignored",
+			update: []*packet.LSPDU{
+				lspWithHostname(packet.LSPID{SystemID: remoteSysID, LSPNumber: 1}, "fragment"),
+				lspWithHostname(packet.LSPID{SystemID: remoteSysID, PseudonodeID: 1}, "pseudonode"),
+			},
+			lookup: remoteSysID,
+		},
+		{
+			name: "Local hostname is not overridden",
+			update: []*packet.LSPDU{
+				lspWithHostname(packet.LSPID{SystemID: localSysID}, "stale"),
+			},
+			expire: []packet.LSPID{
+				{SystemID: localSysID},
+			},
+			lookup:    localSysID,
+			expected:  "local",
+			wantFound: true,
+		},
+		{
+			name: "Expired LSP",
+			update: []*packet.LSPDU{
+				lspWithHostname(packet.LSPID{SystemID: remoteSysID}, "remote"),
+			},
+			expire: []packet.LSPID{
+				{SystemID: remoteSysID},
+			},
+			lookup: remoteSysID,
+		},
+		{
+			name:   "Unknown system ID",
+			lookup: unknownSysID,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h := newHostnameMap(localSysID, "local")
+			for _, lspdu := range test.update {
+				h.update(lspdu)
+			}
+
+			for _, lspID := range test.expire {
+				h.expire(lspID)
+			}
+
+			name, found := h.get(test.lookup)
+			assert.Equal(t, test.wantFound, found)
+			assert.Equal(t, test.expected, name)
+
+			if !test.wantFound {
+				assert.Equal(t, test.lookup.String(), h.resolve(test.lookup))
+			}
+		})
+	}
+}
+
+func TestLSDBEntryToProtoHostname(t *testing.T) {
+	e := &LSDBEntry{
+		lspdu: &packet.LSPDU{
+			LSPID: packet.LSPID{
+				SystemID:  types.SystemID{2, 2, 2, 2, 2, 2},
+				LSPNumber: 1,
+			},
+		},
+		hostname: "remote",
+	}
+
+	assert.Equal(t, "remote", lsdbEntryToProto(e).Lsp.Hostname)
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	netapi "github.com/bio-routing/bio-rd/net/api"
 	"github.com/bio-routing/bio-rd/protocols/isis/api"
 	"github.com/bio-routing/bio-rd/protocols/isis/packet"
@@ -63,6 +66,73 @@ func (s *ISISAPIServer) GetLSDB(context.Context, *api.GetLSDBRequest) (*api.GetL
 	return resp, nil
 }
 
+func (s *ISISAPIServer) GetCounters(context.Context, *api.GetCountersRequest) (*api.GetCountersResponse, error) {
+	resp := &api.GetCountersResponse{
+		Global:     countersToProto(s.srv.GetCounters()),
+		Interfaces: make([]*api.InterfaceCounters, 0),
+	}
+
+	for _, ic := range s.srv.GetInterfaceCounters() {
+		resp.Interfaces = append(resp.Interfaces, &api.InterfaceCounters{
+			Name:     ic.Name,
+			Counters: countersToProto(ic.Counters),
+		})
+	}
+
+	return resp, nil
+}
+
+func (s *ISISAPIServer) DumpRoutes(ctx context.Context, req *api.DumpRoutesRequest) (*api.DumpRoutesResponse, error) {
+	if req.Level != 1 && req.Level != 2 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid level %d", req.Level)
+	}
+
+	resp := &api.DumpRoutesResponse{
+		Routes: make([]*api.Route, 0),
+	}
+
+	for _, r := range s.srv.GetRoutes(int(req.Level)) {
+		resp.Routes = append(resp.Routes, routeToProto(r))
+	}
+
+	return resp, nil
+}
+
+func routeToProto(r *Route) *api.Route {
+	ret := &api.Route{
+		Prefix:   r.Prefix.ToProto(),
+		Metric:   r.Metric,
+		NextHops: make([]*api.NextHop, 0, len(r.NextHops)),
+		UpDown:   r.UpDown,
+		Attached: r.Attached,
+	}
+
+	for _, nh := range r.NextHops {
+		ret.NextHops = append(ret.NextHops, &api.NextHop{
+			SystemId: nh.SystemID[:],
+			Hostname: nh.Hostname,
+		})
+	}
+
+	return ret
+}
+
+func countersToProto(c Counters) *api.Counters {
+	return &api.Counters{
+		HellosSent:             c.HellosSent,
+		HellosReceived:         c.HellosReceived,
+		LspsSent:               c.LSPsSent,
+		LspsReceived:           c.LSPsReceived,
+		CsnpsSent:              c.CSNPsSent,
+		CsnpsReceived:          c.CSNPsReceived,
+		PsnpsSent:              c.PSNPsSent,
+		PsnpsReceived:          c.PSNPsReceived,
+		AuthenticationFailures: c.AuthenticationFailures,
+		ChecksumErrors:         c.ChecksumErrors,
+		AdjacencyChanges:       c.AdjacencyChanges,
+	}
+}
+
 func lsdbEntryToProto(e *LSDBEntry) *api.LSDBEntry {
 	l := &api.LSDBEntry{
 		Lsp:                   lspduToProto(e.lspdu),
@@ -73,6 +143,11 @@ func lsdbEntryToProto(e *LSDBEntry) *api.LSDBEntry {
 	copy(l.InterfacesWithSsnFlag, e.ssnFlags)
 	copy(l.InterfacesWithSrmFlag, e.srmFlags)
 
+	// Only the first fragment carries the dynamic hostname TLV. Annotate all others with the learned hostname.
+	if l.Lsp.Hostname == "" {
+		l.Lsp.Hostname = e.hostname
+	}
+
 	return l
 }
 
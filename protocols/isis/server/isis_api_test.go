@@ -0,0 +1,63 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/isis/api"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDumpRoutes(t *testing.T) {
+	pn := types.NewSourceID(spfTestSysA, 1)
+	pfxB := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 2, 0), 24)
+	pfxD := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 4, 0), 24)
+
+	// A, B and C share a LAN (pseudonode A.01). D is connected to B and C with equal metrics.
+	l := newSPFTestLSDB(MetricStyleWide,
+		spfTestLSP(spfTestSysA, 0, wideLinks(spfTestLink{to: pn, metric: 10})),
+		spfTestLSP(spfTestSysA, 1, wideLinks(link(spfTestSysA, 0), link(spfTestSysB, 0), link(spfTestSysC, 0))),
+		spfTestLSP(spfTestSysB, 0,
+			wideLinks(spfTestLink{to: pn, metric: 10}, link(spfTestSysD, 5)),
+			widePrefix(3, pfxB),
+		),
+		spfTestLSP(spfTestSysC, 0, wideLinks(spfTestLink{to: pn, metric: 10}, link(spfTestSysD, 5))),
+		spfTestLSP(spfTestSysD, 0,
+			wideLinks(link(spfTestSysB, 5), link(spfTestSysC, 5)),
+			widePrefix(1, pfxD),
+		),
+	)
+	l.srv.hostnames = newHostnameMap(spfTestSysA, "a")
+	l.srv.hostnames.set(spfTestSysB, "b")
+	l.runSPF()
+
+	s := NewISISAPIServer(l.srv)
+	resp, err := s.DumpRoutes(context.Background(), &api.DumpRoutesRequest{Level: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, &api.DumpRoutesResponse{
+		Routes: []*api.Route{
+			{
+				Prefix: pfxB.ToProto(),
+				Metric: 13,
+				NextHops: []*api.NextHop{
+					{SystemId: spfTestSysB[:], Hostname: "b"},
+				},
+			},
+			{
+				Prefix: pfxD.ToProto(),
+				Metric: 16,
+				NextHops: []*api.NextHop{
+					{SystemId: spfTestSysB[:], Hostname: "b"},
+					{SystemId: spfTestSysC[:]},
+				},
+			},
+		},
+	}, resp)
+
+	_, err = s.DumpRoutes(context.Background(), &api.DumpRoutesRequest{Level: 3})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
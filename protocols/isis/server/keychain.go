@@ -0,0 +1,137 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+)
+
+// maxKeySecretLen is the maximum length of a key fitting an authentication TLV
+const maxKeySecretLen = 254
+
+// Key is an authentication key of a keychain. A zero start or end time leaves the lifetime unbounded in that direction.
+type Key struct {
+	ID          uint16
+	Secret      []byte
+	SendStart   time.Time
+	SendEnd     time.Time
+	AcceptStart time.Time
+	AcceptEnd   time.Time
+}
+
+func (k *Key) sendValid(t time.Time) bool {
+	return lifetimeValid(k.SendStart, k.SendEnd, t)
+}
+
+func (k *Key) acceptValid(t time.Time) bool {
+	return lifetimeValid(k.AcceptStart, k.AcceptEnd, t)
+}
+
+func lifetimeValid(start time.Time, end time.Time, t time.Time) bool {
+	if !start.IsZero() && t.Before(start) {
+		return false
+	}
+
+	if !end.IsZero() && !t.Before(end) {
+		return false
+	}
+
+	return true
+}
+
+// Keychain is a set of authentication keys rotated by their send and accept lifetimes. Keys are sent as cleartext
+// passwords, so key IDs are not transmitted and only identify keys within the keychain.
+type Keychain struct {
+	keys []*Key
+}
+
+// NewKeychain creates a new keychain
+func NewKeychain(keys ...*Key) (*Keychain, error) {
+	ids := make(map[uint16]struct{})
+	for _, k := range keys {
+		if _, exists := ids[k.ID]; exists {
+			return nil, fmt.Errorf("duplicate key ID %d", k.ID)
+		}
+		ids[k.ID] = struct{}{}
+
+		if len(k.Secret) == 0 || len(k.Secret) > maxKeySecretLen {
+			return nil, fmt.Errorf("key %d: secret must be 1 to %d bytes long", k.ID, maxKeySecretLen)
+		}
+
+		if !k.SendStart.IsZero() && !k.SendEnd.IsZero() && !k.SendEnd.After(k.SendStart) {
+			return nil, fmt.Errorf("key %d: send lifetime ends before it starts", k.ID)
+		}
+
+		if !k.AcceptStart.IsZero() && !k.AcceptEnd.IsZero() && !k.AcceptEnd.After(k.AcceptStart) {
+			return nil, fmt.Errorf("key %d: accept lifetime ends before it starts", k.ID)
+		}
+	}
+
+	return &Keychain{
+		keys: keys,
+	}, nil
+}
+
+// SendKey gets the key used for sending at time t. If the send lifetimes of several keys overlap, the key
+// starting last is used, so a new key takes over as soon as its send lifetime starts. nil is returned if there is no valid key.
+func (k *Keychain) SendKey(t time.Time) *Key {
+	var ret *Key
+	for _, key := range k.keys {
+		if !key.sendValid(t) {
+			continue
+		}
+
+		if ret == nil || key.SendStart.After(ret.SendStart) || (key.SendStart.Equal(ret.SendStart) && key.ID > ret.ID) {
+			ret = key
+		}
+	}
+
+	return ret
+}
+
+// AcceptKeys gets all keys accepted on receipt at time t
+func (k *Keychain) AcceptKeys(t time.Time) []*Key {
+	ret := make([]*Key, 0)
+	for _, key := range k.keys {
+		if key.acceptValid(t) {
+			ret = append(ret, key)
+		}
+	}
+
+	return ret
+}
+
+// authenticationTLV creates the authentication TLV of the send key at time t. nil is returned if there is no valid key.
+func (k *Keychain) authenticationTLV(t time.Time) *packet.AuthenticationTLV {
+	key := k.SendKey(t)
+	if key == nil {
+		return nil
+	}
+
+	return packet.NewCleartextAuthenticationTLV(key.Secret)
+}
+
+// authenticate checks if tlvs carry an authentication TLV matching any key accepted at time t
+func (k *Keychain) authenticate(tlvs []packet.TLV, t time.Time) bool {
+	var a *packet.AuthenticationTLV
+	for _, tlv := range tlvs {
+		if x, ok := tlv.(*packet.AuthenticationTLV); ok {
+			a = x
+			break
+		}
+	}
+
+	if a == nil || a.AuthenticationType != packet.AuthenticationTypeCleartext {
+		return false
+	}
+
+	for _, key := range k.AcceptKeys(t) {
+		if bytes.Equal(key.Secret, a.Password) {
+			return true
+		}
+	}
+
+	return false
+}
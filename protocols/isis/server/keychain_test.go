@@ -0,0 +1,248 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bio-routing/bio-rd/net/ethernet"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/stretchr/testify/assert"
+
+	btesting "github.com/bio-routing/bio-rd/testing"
+)
+
+func TestNewKeychain(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     []*Key
+		wantFail bool
+	}{
+		{
+			name: "Valid",
+			keys: []*Key{
+				{ID: 1, Secret: []byte("old"), SendEnd: time.Unix(2000, 0)},
+				{ID: 2, Secret: []byte("new"), SendStart: time.Unix(2000, 0)},
+			},
+		},
+		{
+			name: "Duplicate key ID",
+			keys: []*Key{
+				{ID: 1, Secret: []byte("old")},
+				{ID: 1, Secret: []byte("new")},
+			},
+			wantFail: true,
+		},
+		{
+			name: "Empty secret",
+			keys: []*Key{
+				{ID: 1},
+			},
+			wantFail: true,
+		},
+		{
+			name: "Secret too long",
+			keys: []*Key{
+				{ID: 1, Secret: make([]byte, 255)},
+			},
+			wantFail: true,
+		},
+		{
+			name: "Send lifetime ends before it starts",
+			keys: []*Key{
+				{ID: 1, Secret: []byte("old"), SendStart: time.Unix(2000, 0), SendEnd: time.Unix(1000, 0)},
+			},
+			wantFail: true,
+		},
+		{
+			name: "Accept lifetime ends when it starts",
+			keys: []*Key{
+				{ID: 1, Secret: []byte("old"), AcceptStart: time.Unix(2000, 0), AcceptEnd: time.Unix(2000, 0)},
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		_, err := NewKeychain(test.keys...)
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		assert.NoError(t, err, test.name)
+	}
+}
+
+// rotationKeychain rotates from key 1 to key 2 at t=2000. Key 1 is still accepted until t=2300.
+func rotationKeychain(t *testing.T) *Keychain {
+	k, err := NewKeychain(
+		&Key{
+			ID:        1,
+			Secret:    []byte("old"),
+			SendEnd:   time.Unix(2000, 0),
+			AcceptEnd: time.Unix(2300, 0),
+		},
+		&Key{
+			ID:          2,
+			Secret:      []byte("new"),
+			SendStart:   time.Unix(2000, 0),
+			AcceptStart: time.Unix(1700, 0),
+		},
+		&Key{
+			ID:          3,
+			Secret:      []byte("future"),
+			SendStart:   time.Unix(5000, 0),
+			AcceptStart: time.Unix(4700, 0),
+		},
+	)
+	assert.NoError(t, err)
+
+	return k
+}
+
+func TestKeychainSendKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		time     int64
+		expected uint16
+	}{
+		{
+			name:     "Before rotation",
+			time:     1000,
+			expected: 1,
+		},
+		{
+			name:     "Last second of the old key",
+			time:     1999,
+			expected: 1,
+		},
+		{
+			name:     "Send lifetime of the new key starts",
+			time:     2000,
+			expected: 2,
+		},
+		{
+			name:     "After rotation",
+			time:     3000,
+			expected: 2,
+		},
+		{
+			name:     "Overlapping send lifetimes prefer the key starting last",
+			time:     5000,
+			expected: 3,
+		},
+	}
+
+	k := rotationKeychain(t)
+	for _, test := range tests {
+		key := k.SendKey(time.Unix(test.time, 0))
+		assert.Equal(t, test.expected, key.ID, test.name)
+	}
+
+	expired, err := NewKeychain(&Key{ID: 1, Secret: []byte("old"), SendEnd: time.Unix(2000, 0)})
+	assert.NoError(t, err)
+	assert.Nil(t, expired.SendKey(time.Unix(2000, 0)), "expired key must not be used")
+	assert.Nil(t, expired.authenticationTLV(time.Unix(2000, 0)))
+}
+
+func TestKeychainAccept(t *testing.T) {
+	tests := []struct {
+		name     string
+		time     int64
+		secret   string
+		expected bool
+	}{
+		{
+			name:     "Old key before rotation",
+			time:     1000,
+			secret:   "old",
+			expected: true,
+		},
+		{
+			name:   "New key before its accept lifetime",
+			time:   1000,
+			secret: "new",
+		},
+		{
+			name:     "New key accepted ahead of rotation",
+			time:     1700,
+			secret:   "new",
+			expected: true,
+		},
+		{
+			name:     "Overlapping old key accepted after rotation",
+			time:     2100,
+			secret:   "old",
+			expected: true,
+		},
+		{
+			name:     "New key after rotation",
+			time:     2100,
+			secret:   "new",
+			expected: true,
+		},
+		{
+			name:   "Old key after its accept lifetime",
+			time:   2300,
+			secret: "old",
+		},
+		{
+			name:   "Unknown key",
+			time:   2100,
+			secret: "foo",
+		},
+	}
+
+	k := rotationKeychain(t)
+	for _, test := range tests {
+		tlvs := []packet.TLV{
+			packet.NewCleartextAuthenticationTLV([]byte(test.secret)),
+		}
+
+		assert.Equal(t, test.expected, k.authenticate(tlvs, time.Unix(test.time, 0)), test.name)
+	}
+
+	assert.False(t, k.authenticate([]packet.TLV{}, time.Unix(2100, 0)), "PDU without authentication TLV")
+}
+
+func TestLSPAuthentication(t *testing.T) {
+	s := newLeakTestServer(2)
+	s.lspLifetime = 1200
+	s.hostnames = newHostnameMap(spfTestSysA, "")
+	s.levelConfigL2.Keychain = rotationKeychain(t)
+
+	nifa := s.netIfaManager.netIfas["eth2"]
+	nifa.isP2PHelloCon = &countingConn{
+		MockConn: btesting.NewMockConn(),
+	}
+	nm := nifa.neighborManagerL2
+	src := ethernet.MACAddr{2}
+
+	n := &neighbor{
+		sysID: spfTestSysB,
+		nm:    nm,
+		state: packet.P2PAdjStateUp,
+	}
+	nm.neighbors[src] = n
+	nm.adjacencyUp(n)
+
+	own := s.getOwnLSPDU(2)
+	assert.Contains(t, own.TLVs, packet.NewCleartextAuthenticationTLV([]byte("old")), "own LSP must be authenticated with the send key")
+
+	authenticated := spfTestLSP(spfTestSysB, 0, packet.NewCleartextAuthenticationTLV([]byte("old")))
+	authenticated.UpdateLength()
+	authenticated.SetChecksum()
+	assert.NoError(t, nifa.processPkt(src, llcPDU(authenticated, packet.L2_LS_PDU_TYPE)))
+
+	unauthenticated := spfTestLSP(spfTestSysC, 0)
+	unauthenticated.UpdateLength()
+	unauthenticated.SetChecksum()
+	assert.NoError(t, nifa.processPkt(src, llcPDU(unauthenticated, packet.L2_LS_PDU_TYPE)))
+
+	assert.Equal(t, uint64(1), s.GetCounters().AuthenticationFailures)
+
+	_, found := s.lsdbL2.lsps[authenticated.LSPID]
+	assert.True(t, found, "authenticated LSP must be installed")
+	_, found = s.lsdbL2.lsps[unauthenticated.LSPID]
+	assert.False(t, found, "LSP failing authentication must be dropped")
+}
@@ -1,8 +1,11 @@
 package server
 
 import (
+	"math"
 	"sync"
+	"time"
 
+	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/protocols/isis/packet"
 	"github.com/bio-routing/bio-rd/protocols/isis/types"
 	"github.com/bio-routing/bio-rd/util/log"
@@ -15,13 +18,29 @@ type lsdb struct {
 	lspsMu sync.RWMutex
 	done   chan struct{}
 	wg     sync.WaitGroup
+
+	// sending holds the interfaces LSPs are being sent on
+	sending   map[*netIfa]struct{}
+	sendingMu sync.Mutex
+
+	// lastDecrement is the point in time remaining lifetimes have been decremented up to
+	lastDecrement time.Time
+
+	// routes is the result of the last SPF or PRC run, tree the shortest path tree of the last SPF run
+	routes   map[bnet.Prefix]*spfRoute
+	tree     *spfTree
+	spfRuns  uint64
+	prcRuns  uint64
+	routesMu sync.RWMutex
 }
 
 func newLSDB(s *Server) *lsdb {
 	return &lsdb{
-		srv:  s,
-		lsps: make(map[packet.LSPID]*lsdbEntry),
-		done: make(chan struct{}),
+		srv:           s,
+		lsps:          make(map[packet.LSPID]*lsdbEntry),
+		done:          make(chan struct{}),
+		sending:       make(map[*netIfa]struct{}),
+		lastDecrement: s.clock.Now(),
 	}
 }
 
@@ -39,6 +58,13 @@ func (l *lsdb) fields() log.Fields {
 	}
 }
 
+func (l *lsdb) lspFields(lspID packet.LSPID) log.Fields {
+	f := l.fields()
+	f["lspID"] = lspID.String()
+	f["hostname"] = l.srv.hostnames.resolve(lspID.SystemID)
+	return f
+}
+
 func (l *lsdb) dispose() {
 	l.stop()
 	l.srv = nil
@@ -69,24 +95,89 @@ func (l *lsdb) decrementRemainingLifetimesRoutine(t btime.Ticker) {
 	for {
 		select {
 		case <-t.C():
-			l.decrementRemainingLifetimes()
+			if l.decrementRemainingLifetimes() {
+				l.srv.originateLSP(l.level())
+			}
 		case <-l.done:
 			return
 		}
 	}
 }
 
-func (l *lsdb) decrementRemainingLifetimes() {
+// decrementRemainingLifetimes ages all LSPs by the full seconds passed since the last call. Ticks delayed
+// or dropped by the scheduler thus don't extend the lifetime of LSPs. It returns if our LSP is due for a refresh.
+func (l *lsdb) decrementRemainingLifetimes() bool {
 	l.lspsMu.Lock()
 	defer l.lspsMu.Unlock()
 
+	elapsed := l.srv.clock.Now().Sub(l.lastDecrement) / time.Second
+	if elapsed <= 0 {
+		return false
+	}
+
+	l.lastDecrement = l.lastDecrement.Add(elapsed * time.Second)
+	if elapsed > math.MaxUint16 {
+		elapsed = math.MaxUint16
+	}
+
 	for lspid, lspdbEntry := range l.lsps {
-		if lspdbEntry.lspdu.RemainingLifetime <= 1 {
+		if lspdbEntry.lspdu.RemainingLifetime == 0 && lspdbEntry.zeroAge > uint16(elapsed) {
+			lspdbEntry.zeroAge -= uint16(elapsed)
+			continue
+		}
+
+		if lspdbEntry.lspdu.RemainingLifetime > uint16(elapsed) {
+			lspdbEntry.lspdu.RemainingLifetime -= uint16(elapsed)
+			continue
+		}
+
+		l.srv.hostnames.expire(lspid)
+		if lspdbEntry.lspdu.RemainingLifetime == 0 || lspdbEntry.lspdu.SequenceNumber == 0 {
 			delete(l.lsps, lspid)
 			continue
 		}
 
-		lspdbEntry.lspdu.RemainingLifetime--
+		l._purge(lspdbEntry)
+	}
+
+	return l._ownLSPRefreshDue()
+}
+
+// _ownLSPRefreshDue checks if our LSP has been aged by the refresh interval. Our LSP is reoriginated well before it
+// expires as the refresh interval is shorter than the LSP lifetime.
+func (l *lsdb) _ownLSPRefreshDue() bool {
+	if l.srv.lspLifetime == 0 {
+		return false
+	}
+
+	e, ok := l.lsps[packet.LSPID{SystemID: l.srv.nets[0].SystemID}]
+	if !ok || e.lspdu.RemainingLifetime == 0 || e.lspdu.SequenceNumber == 0 {
+		return false
+	}
+
+	return int(l.srv.lspLifetime)-int(e.lspdu.RemainingLifetime) >= int(l.srv.getLSPRefreshInterval())
+}
+
+// installOwnLSP replaces our LSP in the LSDB and flags it for flooding on interfaces ifas
+func (l *lsdb) installOwnLSP(lspdu *packet.LSPDU, ifas []*netIfa) {
+	e := newLSDBEntry(lspdu)
+	for _, ifa := range ifas {
+		e.setSRM(ifa)
+	}
+
+	l.lspsMu.Lock()
+	defer l.lspsMu.Unlock()
+
+	l.lsps[lspdu.LSPID] = e
+}
+
+// dropInterface clears the SRM and SSN flags of all LSPs for interface ifa
+func (l *lsdb) dropInterface(ifa *netIfa) {
+	l.lspsMu.RLock()
+	defer l.lspsMu.RUnlock()
+
+	for _, e := range l.lsps {
+		e.dropInterface(ifa)
 	}
 }
 
@@ -111,33 +202,89 @@ func (l *lsdb) sendLSPDUsRoutine(t btime.Ticker) {
 	}
 }
 
+// sendLSPDUs sends all LSPs with SRM flags set. Interfaces are served in parallel and without holding
+// lspsMu, so a slow interface neither delays flooding on other interfaces nor blocks the LSDB. Interfaces still
+// busy sending the LSPs of an earlier run are skipped. Their SRM flags stay set, so the LSPs are sent on a later run.
 func (l *lsdb) sendLSPDUs() {
+	for ifa, pdus := range l.serializeLSPDUsSRMSet() {
+		if !l.startSending(ifa) {
+			log.WithFields(ifa.fields()).Debug("Still sending LSPDUs, skipping interface")
+			continue
+		}
+
+		go func(ifa *netIfa, pdus [][]byte) {
+			defer l.stopSending(ifa)
+
+			for _, pdu := range pdus {
+				err := ifa.sendSerializedPDU(pdu, lspPDUType(l.level()))
+				if err != nil {
+					log.WithFields(ifa.fields()).WithError(err).Error("Unable to send LSPDU")
+					return
+				}
+			}
+		}(ifa, pdus)
+	}
+}
+
+// startSending marks ifa busy sending LSPs. It returns false if ifa is busy already.
+func (l *lsdb) startSending(ifa *netIfa) bool {
+	l.sendingMu.Lock()
+	defer l.sendingMu.Unlock()
+
+	if _, busy := l.sending[ifa]; busy {
+		return false
+	}
+
+	l.sending[ifa] = struct{}{}
+	return true
+}
+
+func (l *lsdb) stopSending(ifa *netIfa) {
+	l.sendingMu.Lock()
+	defer l.sendingMu.Unlock()
+
+	delete(l.sending, ifa)
+}
+
+// serializeLSPDUsSRMSet serializes all LSPs to be sent grouped by interface
+func (l *lsdb) serializeLSPDUsSRMSet() map[*netIfa][][]byte {
 	l.lspsMu.RLock()
 	defer l.lspsMu.RUnlock()
 
+	ret := make(map[*netIfa][][]byte)
 	for _, entry := range l.lsps {
+		var pdu []byte
 		for _, ifa := range entry.getInterfacesSRMSet() {
 			if ifa.cfg.Passive {
 				continue
 			}
 
-			ifa.sendLSPDU(entry.lspdu, l.level())
+			if pdu == nil {
+				pdu = serializeLSPDU(entry.lspdu, l.level())
+			}
+
+			ret[ifa] = append(ret[ifa], pdu)
 		}
 	}
+
+	return ret
 }
 
 func (l *lsdb) processCSNP(from *netIfa, csnp *packet.CSNP) {
 	l.lspsMu.Lock()
 	defer l.lspsMu.Unlock()
 
+	described := make(map[packet.LSPID]struct{})
 	for _, lspEntry := range csnp.GetLSPEntries() {
+		described[lspEntry.LSPID] = struct{}{}
 		l.processCSNPLSPEntry(lspEntry, from)
 	}
 
 	for lspID, lsdbEntry := range l.lsps {
 		// we need to check if we have LSPs the neighbor did not describe.
 		// For any that we have but our neighbor doesn't we set SRM flag so
-		// the entry gets propagated.
+		// the entry gets propagated. LSPs outside the range of the CSNP
+		// are described by other CSNPs of the neighbor.
 
 		if lsdbEntry.lspdu.RemainingLifetime <= 0 || lsdbEntry.lspdu.SequenceNumber <= 0 {
 			continue
@@ -147,7 +294,7 @@ func (l *lsdb) processCSNP(from *netIfa, csnp *packet.CSNP) {
 			continue
 		}
 
-		if !csnp.ContainsLSPEntry(lspID) {
+		if _, ok := described[lspID]; !ok {
 			lsdbEntry.setSRM(from)
 		}
 	}
@@ -224,7 +371,7 @@ func (l *lsdb) sendCSNPsRoutine(t btime.Ticker) {
 
 func (l *lsdb) sendCSNPss() {
 	for _, ifa := range l.srv.netIfaManager.getAllInterfaces() {
-		if len(ifa.neighborManagerL2.getNeighborsUp()) < 1 {
+		if !ifa.adjacencyUp(l.level()) {
 			continue
 		}
 
@@ -259,7 +406,8 @@ func (l *lsdb) sendPSNPss() {
 		}
 
 		lspdus := l._getLSPWithSSNSet(ifa)
-		for _, psnp := range packet.NewPSNPs(srcID, lspdus, ifa.ethHandler.GetMTU()) {
+		maxPDULen := ifa.ethHandler.GetMTU() - l.srv.authenticationLen(l.level(), psnpPDUType(l.level()))
+		for _, psnp := range packet.NewPSNPs(srcID, lspdus, maxPDULen) {
 			ifa.sendPSNP(&psnp, l.level())
 		}
 	}
@@ -303,7 +451,8 @@ func (l *lsdb) getCSNPs(ifa *netIfa) []packet.CSNP {
 		SystemID: l.srv.nets[0].SystemID,
 	}
 
-	return packet.NewCSNPs(srcID, l.getLSPEntries(), ifa.ethHandler.GetMTU())
+	maxPDULen := ifa.ethHandler.GetMTU() - l.srv.authenticationLen(l.level(), csnpPDUType(l.level()))
+	return packet.NewCSNPs(srcID, l.getLSPEntries(), maxPDULen)
 }
 
 func (l *lsdb) sendCSNPs(ifa *netIfa) {
@@ -314,36 +463,66 @@ func (l *lsdb) sendCSNPs(ifa *netIfa) {
 
 func (l *lsdb) processLSP(ifa *netIfa, lspdu *packet.LSPDU) {
 	log.Debug("Processing received LSP")
+	old, newer := l.storeLSP(ifa, lspdu)
+	if !newer {
+		return
+	}
+
+	if lspdu.RemainingLifetime == 0 {
+		l.logPurge(lspdu)
+	}
+
+	l.lspChanged(old, lspdu)
+}
+
+// storeLSP stores a received LSP if it is newer than the one in the LSDB. The replaced LSP is returned if so.
+func (l *lsdb) storeLSP(ifa *netIfa, lspdu *packet.LSPDU) (old *packet.LSPDU, newer bool) {
 	l.lspsMu.Lock()
 	defer l.lspsMu.Unlock()
 
 	existingLSDBEntry, exists := l.lsps[lspdu.LSPID]
 	if !exists || lspdu.SequenceNumber > existingLSDBEntry.lspdu.SequenceNumber {
-		log.Debugf("ISIS: Received newer LSPDU %v sequence number %d", lspdu.LSPID, lspdu.SequenceNumber)
 		l.processNewerLSPDU(ifa, lspdu)
-		return
+		log.WithFields(l.lspFields(lspdu.LSPID)).Debugf("ISIS: Received newer LSPDU sequence number %d", lspdu.SequenceNumber)
+		if exists {
+			old = existingLSDBEntry.lspdu
+		}
+
+		return old, true
 	}
 
 	if lspdu.SequenceNumber == existingLSDBEntry.lspdu.SequenceNumber {
-		log.Debugf("ISIS: Received same sequence LSPDU %v sequence number %d", lspdu.LSPID, lspdu.SequenceNumber)
+		log.WithFields(l.lspFields(lspdu.LSPID)).Debugf("ISIS: Received same sequence LSPDU sequence number %d", lspdu.SequenceNumber)
 		existingLSDBEntry.processSameLSPDU(ifa)
-		return
+		return nil, false
 	}
 
-	log.Debugf("ISIS: Received older LSPDU %v sequence number %d / %d", lspdu.LSPID, existingLSDBEntry.lspdu.SequenceNumber, lspdu.SequenceNumber)
+	log.WithFields(l.lspFields(lspdu.LSPID)).Debugf("ISIS: Received older LSPDU sequence number %d / %d", existingLSDBEntry.lspdu.SequenceNumber, lspdu.SequenceNumber)
 	existingLSDBEntry.newerLocalLSPDU(ifa)
+	return nil, false
 }
 
 func (l *lsdb) processNewerLSPDU(ifa *netIfa, lspdu *packet.LSPDU) {
 	lsdbEntry := newLSDBEntry(lspdu)
 
+	// Flooding is limited to the interfaces with an adjacency of our level up. This keeps level 1 LSPs within the area
+	// as level 1 adjacencies are only established with ISs of our area.
 	for _, i := range l.srv.netIfaManager.getAllInterfacesExcept(ifa) {
-		lsdbEntry.setSRM(i)
+		if i.adjacencyUp(l.level()) {
+			lsdbEntry.setSRM(i)
+		}
 	}
 
 	lsdbEntry.clearSRMFlag(ifa)
 	lsdbEntry.setSSN(ifa)
 
 	l.lsps[lspdu.LSPID] = lsdbEntry
-	return
+
+	// The dynamic hostname TLV of a purge names the IS that purged the LSP (RFC6232)
+	if lspdu.RemainingLifetime == 0 {
+		l.srv.hostnames.expire(lspdu.LSPID)
+		return
+	}
+
+	l.srv.hostnames.update(lspdu)
 }
@@ -11,12 +11,21 @@ type lsdbEntry struct {
 	srmFlags map[*netIfa]struct{}
 	ssnFlags map[*netIfa]struct{}
 	mutex    sync.RWMutex
+
+	// zeroAge is the number of seconds a purge we generated is kept in the LSDB for flooding
+	zeroAge uint16
 }
 
 type LSDBEntry struct {
 	lspdu    *packet.LSPDU
 	srmFlags []string
 	ssnFlags []string
+	hostname string
+}
+
+// LSPDU returns the LSP stored in the LSDB entry
+func (e *LSDBEntry) LSPDU() *packet.LSPDU {
+	return e.lspdu
 }
 
 func newLSDBEntry(lspdu *packet.LSPDU) *lsdbEntry {
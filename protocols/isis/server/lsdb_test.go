@@ -0,0 +1,378 @@
+package server
+
+import (
+	"bytes"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bio-routing/bio-rd/net/ethernet"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	btesting "github.com/bio-routing/bio-rd/testing"
+	btime "github.com/bio-routing/bio-rd/util/time"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecrementRemainingLifetimes(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	s := &Server{
+		nets: []*types.NET{
+			{SystemID: types.SystemID{1, 1, 1, 1, 1, 1}},
+		},
+		clock:     clock,
+		hostnames: newHostnameMap(types.SystemID{1, 1, 1, 1, 1, 1}, ""),
+	}
+	s.netIfaManager = newNetIfaManager(s)
+	l := newLSDB(s)
+
+	lspA := packet.LSPID{SystemID: types.SystemID{2, 2, 2, 2, 2, 2}}
+	lspB := packet.LSPID{SystemID: types.SystemID{3, 3, 3, 3, 3, 3}}
+	l.lsps[lspA] = newLSDBEntry(&packet.LSPDU{LSPID: lspA, RemainingLifetime: 10, SequenceNumber: 1})
+	l.lsps[lspB] = newLSDBEntry(&packet.LSPDU{LSPID: lspB, RemainingLifetime: 3, SequenceNumber: 1})
+
+	remaining := func() map[packet.LSPID]uint16 {
+		ret := make(map[packet.LSPID]uint16)
+		for id, e := range l.lsps {
+			ret[id] = e.lspdu.RemainingLifetime
+		}
+
+		return ret
+	}
+
+	// Less than a second passed
+	clock.Advance(500 * time.Millisecond)
+	l.decrementRemainingLifetimes()
+	assert.Equal(t, map[packet.LSPID]uint16{lspA: 10, lspB: 3}, remaining())
+
+	// Delayed tick: three seconds passed in total, lspB expired and is purged
+	clock.Advance(2500 * time.Millisecond)
+	l.decrementRemainingLifetimes()
+	assert.Equal(t, map[packet.LSPID]uint16{lspA: 7, lspB: 0}, remaining())
+
+	// Fractions of seconds are carried over
+	clock.Advance(1500 * time.Millisecond)
+	l.decrementRemainingLifetimes()
+	assert.Equal(t, map[packet.LSPID]uint16{lspA: 6, lspB: 0}, remaining())
+
+	clock.Advance(500 * time.Millisecond)
+	l.decrementRemainingLifetimes()
+	assert.Equal(t, map[packet.LSPID]uint16{lspA: 5, lspB: 0}, remaining())
+
+	// lspB reached its zero age lifetime, lspA expired and is purged
+	clock.Advance(time.Hour)
+	l.decrementRemainingLifetimes()
+	assert.Equal(t, map[packet.LSPID]uint16{lspA: 0}, remaining())
+
+	clock.Advance(defaultZeroAgeLifetime * time.Second)
+	l.decrementRemainingLifetimes()
+	assert.Empty(t, remaining())
+}
+
+func TestSetLSPTimers(t *testing.T) {
+	s := &Server{
+		lspLifetime: 1200,
+	}
+
+	assert.Equal(t, uint16(900), s.getLSPRefreshInterval())
+	assert.Equal(t, uint16(defaultZeroAgeLifetime), s.getZeroAgeLifetime())
+
+	assert.Error(t, s.SetLSPTimers(&LSPTimers{RefreshInterval: 1200}))
+	assert.NoError(t, s.SetLSPTimers(&LSPTimers{RefreshInterval: 600, ZeroAgeLifetime: 20}))
+	assert.Equal(t, uint16(600), s.getLSPRefreshInterval())
+	assert.Equal(t, uint16(20), s.getZeroAgeLifetime())
+}
+
+func TestOwnLSPRefresh(t *testing.T) {
+	s := newLeakTestServer(2)
+	s.lspLifetime = 1200
+	s.hostnames = newHostnameMap(spfTestSysA, "")
+	assert.NoError(t, s.SetLSPTimers(&LSPTimers{RefreshInterval: 600}))
+	clock := s.clock.(*btime.MockClock)
+
+	l := s.lsdbL2
+	s.originateLSP(2)
+
+	ownLSP := func() *packet.LSPDU {
+		l.lspsMu.RLock()
+		defer l.lspsMu.RUnlock()
+
+		return l.lsps[packet.LSPID{SystemID: spfTestSysA}].lspdu
+	}
+
+	clock.Advance(599 * time.Second)
+	assert.False(t, l.decrementRemainingLifetimes())
+	assert.Equal(t, uint16(601), ownLSP().RemainingLifetime)
+
+	ticker := btime.NewMockTicker()
+	l.wg.Add(1)
+	go l.decrementRemainingLifetimesRoutine(ticker)
+	defer l.stop()
+
+	clock.Advance(time.Second)
+	ticker.Tick()
+	assert.Eventually(t, func() bool {
+		return ownLSP().SequenceNumber == 2
+	}, time.Second, time.Millisecond, "LSP not reoriginated after the refresh interval")
+	assert.Equal(t, uint16(1200), ownLSP().RemainingLifetime)
+}
+
+func TestReceivedLSPAging(t *testing.T) {
+	clock := btime.NewMockClock(time.Unix(1000, 0))
+	s := &Server{
+		nets: []*types.NET{
+			{SystemID: types.SystemID{1, 1, 1, 1, 1, 1}},
+		},
+		lspLifetime: 1200,
+		clock:       clock,
+		hostnames:   newHostnameMap(types.SystemID{1, 1, 1, 1, 1, 1}, ""),
+	}
+	assert.NoError(t, s.SetLSPTimers(&LSPTimers{ZeroAgeLifetime: 10}))
+	s.netIfaManager = newNetIfaManager(s)
+	l := newLSDB(s)
+
+	lspID := packet.LSPID{SystemID: types.SystemID{2, 2, 2, 2, 2, 2}}
+	l.lsps[lspID] = newLSDBEntry(&packet.LSPDU{LSPID: lspID, RemainingLifetime: 5, SequenceNumber: 1})
+
+	clock.Advance(3 * time.Second)
+	l.decrementRemainingLifetimes()
+	assert.Equal(t, uint16(2), l.lsps[lspID].lspdu.RemainingLifetime)
+
+	// Expired, the purge is kept for the configured zero age lifetime
+	clock.Advance(2 * time.Second)
+	l.decrementRemainingLifetimes()
+	assert.Equal(t, uint16(0), l.lsps[lspID].lspdu.RemainingLifetime)
+	assert.Equal(t, uint16(10), l.lsps[lspID].zeroAge)
+
+	clock.Advance(9 * time.Second)
+	l.decrementRemainingLifetimes()
+	assert.Contains(t, l.lsps, lspID)
+
+	clock.Advance(time.Second)
+	l.decrementRemainingLifetimes()
+	assert.NotContains(t, l.lsps, lspID)
+}
+
+// slowConn blocks all writes until release is closed
+type slowConn struct {
+	*btesting.MockConn
+	writing chan struct{}
+	release chan struct{}
+}
+
+func (c *slowConn) Write(b []byte) (int, error) {
+	c.writing <- struct{}{}
+	<-c.release
+	return len(b), nil
+}
+
+// countingConn counts the written PDUs
+type countingConn struct {
+	*btesting.MockConn
+	writes atomic.Int32
+}
+
+func (c *countingConn) Write(b []byte) (int, error) {
+	c.writes.Add(1)
+	return len(b), nil
+}
+
+func TestSendLSPDUsSlowInterface(t *testing.T) {
+	s := &Server{
+		clock: btime.NewBIOClock(),
+	}
+	l := newLSDB(s)
+
+	slow := &slowConn{
+		MockConn: btesting.NewMockConn(),
+		writing:  make(chan struct{}, 10),
+		release:  make(chan struct{}),
+	}
+	fast := &countingConn{
+		MockConn: btesting.NewMockConn(),
+	}
+
+	slowIfa := &netIfa{
+		name:          "slow0",
+		srv:           s,
+		cfg:           &InterfaceConfig{},
+		isP2PHelloCon: slow,
+	}
+	fastIfa := &netIfa{
+		name:          "fast0",
+		srv:           s,
+		cfg:           &InterfaceConfig{},
+		isP2PHelloCon: fast,
+	}
+
+	for i := uint8(1); i <= 3; i++ {
+		lspID := packet.LSPID{SystemID: types.SystemID{i, i, i, i, i, i}}
+		e := newLSDBEntry(&packet.LSPDU{LSPID: lspID, RemainingLifetime: 1200, SequenceNumber: 1})
+		e.setSRM(slowIfa)
+		e.setSRM(fastIfa)
+		l.lsps[lspID] = e
+	}
+
+	// Sending must neither wait for the slow interface nor lock the LSDB while it is blocked
+	l.sendLSPDUs()
+	select {
+	case <-slow.writing:
+	case <-time.After(time.Second):
+		t.Fatalf("no LSPDU sent on slow interface")
+	}
+
+	l.lspsMu.Lock()
+	l.lspsMu.Unlock()
+
+	assert.Eventually(t, func() bool {
+		return fast.writes.Load() == 3
+	}, time.Second, time.Millisecond, "fast interface was blocked by slow interface")
+
+	// The next run serves the fast interface again while the slow interface is still busy
+	l.sendLSPDUs()
+	assert.Eventually(t, func() bool {
+		return fast.writes.Load() == 6
+	}, time.Second, time.Millisecond, "fast interface was blocked by slow interface")
+	assert.Empty(t, slow.writing, "busy interface must be skipped")
+
+	close(slow.release)
+	waitLSPDUsSent(t, l)
+}
+
+// waitLSPDUsSent waits until the LSPs are sent on all interfaces
+func waitLSPDUsSent(t *testing.T, l *lsdb) {
+	assert.Eventually(t, func() bool {
+		l.sendingMu.Lock()
+		defer l.sendingMu.Unlock()
+
+		return len(l.sending) == 0
+	}, time.Second, time.Millisecond, "LSPDUs not sent")
+}
+
+// addFloodTestIfa adds an interface with a level enabled to s. An adjacency of the level is brought up if up is set.
+func addFloodTestIfa(s *Server, name string, level int, up bool) *netIfa {
+	cfg := &InterfaceConfig{
+		Name: name,
+	}
+
+	if level == 1 {
+		cfg.Level1 = &InterfaceLevelConfig{}
+	} else {
+		cfg.Level2 = &InterfaceLevelConfig{}
+	}
+
+	nifa := &netIfa{
+		name:          name,
+		srv:           s,
+		cfg:           cfg,
+		isP2PHelloCon: btesting.NewMockConn(),
+	}
+	nifa.neighborManagerL1 = newNeighborManager(s, nifa, 1)
+	nifa.neighborManagerL2 = newNeighborManager(s, nifa, 2)
+	s.netIfaManager.netIfas[name] = nifa
+
+	if up {
+		nm := nifa.neighborManager(level)
+		nm.neighbors[ethernet.MACAddr{byte(len(s.netIfaManager.netIfas))}] = &neighbor{
+			sysID: spfTestSysB,
+			nm:    nm,
+			state: packet.P2PAdjStateUp,
+		}
+	}
+
+	return nifa
+}
+
+func TestFloodingWithinLevel(t *testing.T) {
+	s := newLeakTestServer()
+	s.hostnames = newHostnameMap(spfTestSysA, "")
+
+	in := addFloodTestIfa(s, "in0", 1, true)
+	l1Up := addFloodTestIfa(s, "l1up0", 1, true)
+	l1Down := addFloodTestIfa(s, "l1down0", 1, false)
+	l2Up := addFloodTestIfa(s, "l2up0", 2, true)
+
+	lsp := spfTestLSP(spfTestSysC, 0, areaTLV(leakTestArea))
+	lsp.UpdateLength()
+	s.lsdbL1.processLSP(in, lsp)
+
+	e := s.lsdbL1.lsps[lsp.LSPID]
+	if !assert.NotNil(t, e) {
+		return
+	}
+
+	assert.Equal(t, []*netIfa{l1Up}, e.getInterfacesSRMSet(), "level 1 LSP must only be flooded to interfaces with a level 1 adjacency")
+	assert.True(t, e.getSSN(in))
+	assert.Empty(t, s.lsdbL2.lsps, "level 1 LSP must not enter the level 2 LSDB")
+
+	s.lsdbL1.sendLSPDUs()
+	waitLSPDUsSent(t, s.lsdbL1)
+
+	for _, nifa := range []*netIfa{in, l1Down, l2Up} {
+		assert.Zero(t, nifa.isP2PHelloCon.(*btesting.MockConn).Buf.Len(), "no LSP sent on %s", nifa.name)
+	}
+
+	// The LLC header packet.Decode expects is only added by the ethernet connection
+	pdu := l1Up.isP2PHelloCon.(*btesting.MockConn).Buf.Bytes()
+	if !assert.Greater(t, len(pdu), 8) {
+		return
+	}
+
+	assert.Equal(t, uint8(packet.L1_LS_PDU_TYPE), pdu[4])
+	sent, err := packet.DecodeLSPDU(bytes.NewBuffer(pdu[8:]))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, lsp.LSPID, sent.LSPID)
+}
+
+func TestProcessCSNPRanges(t *testing.T) {
+	s := &Server{
+		clock: btime.NewBIOClock(),
+	}
+	l := newLSDB(s)
+	from := &netIfa{
+		name: "eth0",
+		srv:  s,
+		cfg:  &InterfaceConfig{},
+	}
+
+	lspID := func(i uint8) packet.LSPID {
+		return packet.LSPID{SystemID: types.SystemID{i, i, i, i, i, i}}
+	}
+
+	for i := uint8(1); i <= 6; i++ {
+		l.lsps[lspID(i)] = newLSDBEntry(&packet.LSPDU{LSPID: lspID(i), RemainingLifetime: 1200, SequenceNumber: 5})
+	}
+
+	// The neighbor lacks LSPs 3 and 6 and has an older version of LSP 4
+	neighborLSPs := []*packet.LSPEntry{
+		{LSPID: lspID(1), SequenceNumber: 5, RemainingLifetime: 1200},
+		{LSPID: lspID(2), SequenceNumber: 5, RemainingLifetime: 1200},
+		{LSPID: lspID(4), SequenceNumber: 4, RemainingLifetime: 1200},
+		{LSPID: lspID(5), SequenceNumber: 5, RemainingLifetime: 1200},
+	}
+
+	csnps := packet.NewCSNPs(types.SourceID{}, neighborLSPs, packet.CSNPMinLen+2+2*packet.LSPEntryLen)
+	if !assert.Len(t, csnps, 2) {
+		return
+	}
+
+	flooded := func() []packet.LSPID {
+		ret := make([]packet.LSPID, 0)
+		for i := uint8(1); i <= 6; i++ {
+			if len(l.lsps[lspID(i)].getInterfacesSRMSet()) > 0 {
+				ret = append(ret, lspID(i))
+			}
+		}
+
+		return ret
+	}
+
+	l.processCSNP(from, &csnps[0])
+	assert.Empty(t, flooded(), "LSPs outside the range of the CSNP must not be flooded")
+
+	l.processCSNP(from, &csnps[1])
+	assert.Equal(t, []packet.LSPID{lspID(3), lspID(4), lspID(6)}, flooded())
+}
@@ -1,6 +1,16 @@
 package server
 
-import "github.com/bio-routing/bio-rd/protocols/isis/packet"
+import (
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+const (
+	maxHostnameLen = 255
+	maxTLVLength   = 255
+)
 
 func (s *Server) getProtocolsSupportedTLV() packet.ProtocolsSupportedTLV {
 	return packet.NewProtocolsSupportedTLV([]uint8{
@@ -8,3 +18,374 @@ func (s *Server) getProtocolsSupportedTLV() packet.ProtocolsSupportedTLV {
 		packet.NLPIDIPv6,
 	})
 }
+
+// getDynamicHostnameTLV gets the dynamic hostname TLV to advertise. Returns nil if no hostname is configured.
+func (s *Server) getDynamicHostnameTLV() *packet.DynamicHostNameTLV {
+	if s.hostname == "" {
+		return nil
+	}
+
+	name := []byte(s.hostname)
+	if len(name) > maxHostnameLen {
+		name = name[:maxHostnameLen]
+	}
+
+	return packet.NewDynamicHostnameTLV(name)
+}
+
+// getRouterCapabilityTLV gets the router capability TLV advertising our SRGB and SR algorithms. Returns nil if
+// Segment Routing is not configured.
+func (s *Server) getRouterCapabilityTLV() *packet.RouterCapabilityTLV {
+	if s.segmentRouting == nil {
+		return nil
+	}
+
+	srCap := packet.NewSRCapabilitiesSubTLV(packet.SRCapabilitiesFlagI)
+	srCap.AddDescriptor(s.segmentRouting.SRGBRange, packet.NewSIDLabelSubTLVLabel(s.segmentRouting.SRGBBase))
+
+	rc := packet.NewRouterCapabilityTLV(s.routerID.ToUint32(), 0)
+	rc.AddSubTLV(srCap)
+	rc.AddSubTLV(packet.NewSRAlgorithmSubTLV([]uint8{packet.SRAlgorithmSPF}))
+
+	return rc
+}
+
+// nextSequenceNumber increments and returns the sequence number of our LSP of a level
+func (s *Server) nextSequenceNumber(level int) uint32 {
+	if level == 1 {
+		s.sequenceNumberL1Mu.Lock()
+		defer s.sequenceNumberL1Mu.Unlock()
+
+		s.sequenceNumberL1++
+		return s.sequenceNumberL1
+	}
+
+	s.sequenceNumberL2Mu.Lock()
+	defer s.sequenceNumberL2Mu.Unlock()
+
+	s.sequenceNumberL2++
+	return s.sequenceNumberL2
+}
+
+// getAreaAddressesTLV gets the area addresses TLV containing the areas of all our NETs
+func (s *Server) getAreaAddressesTLV() *packet.AreaAddressesTLV {
+	areas := make([]types.AreaID, 0, len(s.nets))
+	for _, net := range s.nets {
+		areas = append(areas, net.AreaAddress())
+	}
+
+	return packet.NewAreaAddressesTLV(areas)
+}
+
+// getOwnLSPDU creates our LSP of a level with a new sequence number
+func (s *Server) getOwnLSPDU(level int) *packet.LSPDU {
+	tlvs := []packet.TLV{
+		s.getProtocolsSupportedTLV(),
+		s.getAreaAddressesTLV(),
+	}
+
+	if hostname := s.getDynamicHostnameTLV(); hostname != nil {
+		tlvs = append(tlvs, hostname)
+	}
+
+	if rc := s.getRouterCapabilityTLV(); rc != nil {
+		tlvs = append(tlvs, rc)
+	}
+
+	tlvs = append(tlvs, s.getReachabilityTLVs(level)...)
+
+	if a := s.authenticationTLV(level, lspPDUType(level)); a != nil {
+		tlvs = append(tlvs, a)
+	}
+
+	lspdu := &packet.LSPDU{
+		RemainingLifetime: s.lspLifetime,
+		LSPID: packet.LSPID{
+			SystemID: s.nets[0].SystemID,
+		},
+		SequenceNumber: s.nextSequenceNumber(level),
+		TypeBlock:      s.getTypeBlock(level),
+		TLVs:           tlvs,
+	}
+	lspdu.UpdateLength()
+	lspdu.SetChecksum()
+
+	return lspdu
+}
+
+// originateLSP regenerates our LSP of a level and floods it to all interfaces with an adjacency up
+func (s *Server) originateLSP(level int) {
+	l := s.levelLSDB(level)
+	if l == nil {
+		return
+	}
+
+	ifas := make([]*netIfa, 0)
+	for _, nifa := range s.netIfaManager.getAllInterfaces() {
+		if nifa.adjacencyUp(level) {
+			ifas = append(ifas, nifa)
+		}
+	}
+
+	l.installOwnLSP(s.getOwnLSPDU(level), ifas)
+}
+
+// getReachabilityTLVs creates the IS and IP reachability TLVs of a level advertising all adjacencies in state up,
+// the IPv4 prefixes of all interfaces the level is enabled on and the routes leaked from the other level. The metric style of the level selects
+// whether old style (TLV 2 and 128) and/or new style (TLV 22 and 135) TLVs are created. Prefixes are subject to the
+// export policy of the level. New style TLVs carry the prefix attributes (RFC7794): Host prefixes of our interfaces
+// are advertised with the N-Flag and our router ID as source router ID, leaked routes with the R-Flag.
+func (s *Server) getReachabilityTLVs(level int) []packet.TLV {
+	metricStyle := s.levelConfig(level).MetricStyle
+
+	isr := make([]*packet.ISReachabilityTLV, 0)
+	eisr := make([]*packet.ExtendedISReachabilityTLV, 0)
+	ipr := make([]*packet.IPInternalReachabilityTLV, 0)
+	eipr := make([]*packet.ExtendedIPReachabilityTLV, 0)
+
+	addIPPrefix := func(pfx bnet.Prefix, metric uint32, upDown bool, attrs prefixAttributes) {
+		metric, tag, suppress := s.exportPrefix(level, &pfx, metric)
+		if suppress {
+			return
+		}
+
+		baseAddr := pfx.Addr()
+		addr := baseAddr.ToUint32()
+
+		if metricStyle.narrow() {
+			e := packet.NewIPReachability(metric, pfx.Len(), addr)
+			if upDown {
+				e.SetUpDown()
+			}
+
+			ipr = addIPReachability(ipr, e)
+		}
+
+		if metricStyle.wide() {
+			e := packet.NewExtendedIPReachability(metric, pfx.Len(), addr)
+			if upDown {
+				e.SetUpDown()
+			}
+
+			if tag != 0 {
+				e.AddSubTLV(packet.NewAdministrativeTagSubTLV(tag))
+			}
+
+			if attrs.flags != 0 {
+				e.AddSubTLV(packet.NewPrefixAttributeFlagsSubTLV(attrs.flags))
+			}
+
+			if attrs.sourceRouterID != nil {
+				e.AddSubTLV(packet.NewSourceRouterIDSubTLV(*attrs.sourceRouterID))
+			}
+
+			eipr = addExtendedIPReachability(eipr, e)
+		}
+	}
+
+	for _, nifa := range s.netIfaManager.getAllInterfaces() {
+		cfg := nifa.cfg.levelConfig(level)
+		if cfg == nil {
+			continue
+		}
+
+		for _, n := range nifa.neighborManager(level).getNeighborsUp() {
+			if metricStyle.narrow() {
+				isr = addISNeighbor(isr, packet.NewISNeighbor(types.NewSourceID(n.sysID, 0), cfg.Metric))
+			}
+
+			if metricStyle.wide() {
+				eisr = addExtendedISNeighbor(eisr, nifa.getExtendedISReachabilityNeighbor(n, cfg.Metric))
+			}
+		}
+
+		for _, pfx := range nifa.getIPv4Prefixes() {
+			addIPPrefix(bnet.NewPfx(pfx.BaseAddr(), pfx.Len()), cfg.Metric, false, s.interfacePrefixAttributes(pfx.Len()))
+		}
+	}
+
+	for _, r := range s.getLeakedRoutes(level) {
+		addIPPrefix(r.pfx, r.metric, r.upDown, r.prefixAttributes)
+	}
+
+	ret := make([]packet.TLV, 0, len(isr)+len(eisr)+len(ipr)+len(eipr))
+	for _, tlv := range isr {
+		ret = append(ret, tlv)
+	}
+
+	for _, tlv := range eisr {
+		ret = append(ret, tlv)
+	}
+
+	for _, tlv := range ipr {
+		ret = append(ret, tlv)
+	}
+
+	for _, tlv := range eipr {
+		ret = append(ret, tlv)
+	}
+
+	return ret
+}
+
+// interfacePrefixAttributes returns the prefix attributes of an interface prefix of length pfxLen. Host prefixes
+// identify us and are advertised with the N-Flag and our router ID, if known.
+func (s *Server) interfacePrefixAttributes(pfxLen uint8) prefixAttributes {
+	if pfxLen != 32 {
+		return prefixAttributes{}
+	}
+
+	return prefixAttributes{
+		flags:          packet.PrefixAttributeFlagNode,
+		sourceRouterID: s.routerID,
+	}
+}
+
+// exportPrefix runs a prefix originated into our LSP of a level through the export policy of the level. It returns
+// the metric and the administrative tag to advertise the prefix with or if the prefix is suppressed.
+func (s *Server) exportPrefix(level int, pfx *bnet.Prefix, metric uint32) (uint32, uint32, bool) {
+	policy := s.levelConfig(level).ExportPolicy
+	if policy == nil {
+		return metric, 0, false
+	}
+
+	p, reject := policy.Process(pfx, &route.Path{
+		Type: route.ISISPathType,
+		ISISPath: &route.ISISPath{
+			Metric: metric,
+			Level:  uint8(level),
+		},
+	})
+	if reject {
+		return 0, 0, true
+	}
+
+	return p.ISISPath.Metric, p.ISISPath.Tag, false
+}
+
+// addISNeighbor adds n to the last TLV of tlvs. A new TLV is appended if the last one is full.
+func addISNeighbor(tlvs []*packet.ISReachabilityTLV, n packet.ISNeighbor) []*packet.ISReachabilityTLV {
+	if len(tlvs) == 0 || int(tlvs[len(tlvs)-1].TLVLength)+packet.ISNeighborLength > maxTLVLength {
+		tlvs = append(tlvs, packet.NewISReachabilityTLV(nil))
+	}
+
+	tlvs[len(tlvs)-1].AddNeighbor(n)
+	return tlvs
+}
+
+// addExtendedISNeighbor adds n to the last TLV of tlvs. A new TLV is appended if the last one is full.
+func addExtendedISNeighbor(tlvs []*packet.ExtendedISReachabilityTLV, n *packet.ExtendedISReachabilityNeighbor) []*packet.ExtendedISReachabilityTLV {
+	if len(tlvs) == 0 || int(tlvs[len(tlvs)-1].TLVLength)+packet.ExtendedISReachabilityNeighborMinLen+int(n.SubTLVLength) > maxTLVLength {
+		tlvs = append(tlvs, packet.NewExtendedISReachabilityTLV())
+	}
+
+	tlvs[len(tlvs)-1].AddNeighbor(n)
+	return tlvs
+}
+
+// addIPReachability adds r to the last TLV of tlvs. A new TLV is appended if the last one is full.
+func addIPReachability(tlvs []*packet.IPInternalReachabilityTLV, r packet.IPReachability) []*packet.IPInternalReachabilityTLV {
+	if len(tlvs) == 0 || int(tlvs[len(tlvs)-1].TLVLength)+packet.IPReachabilityLength > maxTLVLength {
+		tlvs = append(tlvs, packet.NewIPInternalReachabilityTLV())
+	}
+
+	tlvs[len(tlvs)-1].AddIPReachability(r)
+	return tlvs
+}
+
+// addExtendedIPReachability adds r to the last TLV of tlvs. A new TLV is appended if the last one is full.
+func addExtendedIPReachability(tlvs []*packet.ExtendedIPReachabilityTLV, r *packet.ExtendedIPReachability) []*packet.ExtendedIPReachabilityTLV {
+	if len(tlvs) == 0 || int(tlvs[len(tlvs)-1].TLVLength)+int(r.Length()) > maxTLVLength {
+		tlvs = append(tlvs, packet.NewExtendedIPReachabilityTLV())
+	}
+
+	tlvs[len(tlvs)-1].AddExtendedIPReachability(r)
+	return tlvs
+}
+
+// getExtendedISReachabilityNeighbor creates the Extended IS Reachability entry for an adjacency
+// including the TE sub TLVs configured on its interface
+func (nifa *netIfa) getExtendedISReachabilityNeighbor(n *neighbor, metric uint32) *packet.ExtendedISReachabilityNeighbor {
+	e := packet.NewExtendedISReachabilityNeighbor(types.NewSourceID(n.sysID, 0), metric)
+
+	nifa.mu.RLock()
+	defer nifa.mu.RUnlock()
+
+	te := nifa.cfg.TE
+	if te == nil {
+		return e
+	}
+
+	e.AddSubTLV(packet.NewAdministrativeGroupSubTLV(te.AdminGroup))
+
+	if addr, ok := nifa._getIPv4Address(); ok {
+		e.AddSubTLV(packet.NewIPv4InterfaceAddressSubTLV(addr))
+	}
+
+	for i := range n.ipAddresses {
+		if n.ipAddresses[i].IsIPv4() {
+			e.AddSubTLV(packet.NewIPv4NeighborAddressSubTLV(n.ipAddresses[i].ToUint32()))
+			break
+		}
+	}
+
+	if te.MaxLinkBandwidth > 0 {
+		e.AddSubTLV(packet.NewMaximumLinkBandwidthSubTLV(te.MaxLinkBandwidth))
+	}
+
+	if te.MaxReservableBandwidth > 0 {
+		e.AddSubTLV(packet.NewMaximumReservableLinkBandwidthSubTLV(te.MaxReservableBandwidth))
+	}
+
+	if te.UnreservedBandwidth != nil {
+		e.AddSubTLV(packet.NewUnreservedBandwidthSubTLV(*te.UnreservedBandwidth))
+	} else if te.MaxReservableBandwidth > 0 {
+		unreserved := [packet.UnreservedBandwidthPriorities]float32{}
+		for i := range unreserved {
+			unreserved[i] = te.MaxReservableBandwidth
+		}
+
+		e.AddSubTLV(packet.NewUnreservedBandwidthSubTLV(unreserved))
+	}
+
+	if te.DefaultMetric != nil {
+		e.AddSubTLV(packet.NewTEDefaultMetricSubTLV(*te.DefaultMetric))
+	}
+
+	return e
+}
+
+// getIPv4Prefixes gets the IPv4 prefixes configured on the interface
+func (nifa *netIfa) getIPv4Prefixes() []*bnet.Prefix {
+	nifa.mu.RLock()
+	defer nifa.mu.RUnlock()
+
+	ret := make([]*bnet.Prefix, 0)
+	if nifa.devStatus == nil {
+		return ret
+	}
+
+	for _, pfx := range nifa.devStatus.GetAddrs() {
+		if pfx.Addr().IsIPv4() {
+			ret = append(ret, pfx)
+		}
+	}
+
+	return ret
+}
+
+// _getIPv4Address gets the first IPv4 address configured on the interface
+func (nifa *netIfa) _getIPv4Address() (uint32, bool) {
+	if nifa.devStatus == nil {
+		return 0, false
+	}
+
+	for _, pfx := range nifa.devStatus.GetAddrs() {
+		addr := pfx.Addr()
+		if addr.IsIPv4() {
+			return addr.ToUint32(), true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,335 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/net/ethernet"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/filter/actions"
+)
+
+func TestGetExtendedISReachabilityNeighbor(t *testing.T) {
+	defaultMetric := uint32(100)
+	sysID := types.SystemID{1, 2, 3, 4, 5, 6}
+
+	tests := []struct {
+		name     string
+		te       *InterfaceTEConfig
+		expected *packet.ExtendedISReachabilityNeighbor
+	}{
+		{
+			name:     "No TE config",
+			expected: packet.NewExtendedISReachabilityNeighbor(types.NewSourceID(sysID, 0), 10),
+		},
+		{
+			name: "Unreserved bandwidth defaulting to max reservable bandwidth",
+			te: &InterfaceTEConfig{
+				AdminGroup:             5,
+				MaxLinkBandwidth:       125000000,
+				MaxReservableBandwidth: 100000000,
+				DefaultMetric:          &defaultMetric,
+			},
+			expected: func() *packet.ExtendedISReachabilityNeighbor {
+				e := packet.NewExtendedISReachabilityNeighbor(types.NewSourceID(sysID, 0), 10)
+				e.AddSubTLV(packet.NewAdministrativeGroupSubTLV(5))
+				e.AddSubTLV(packet.NewIPv4InterfaceAddressSubTLV(3221225985))
+				e.AddSubTLV(packet.NewIPv4NeighborAddressSubTLV(3221225986))
+				e.AddSubTLV(packet.NewMaximumLinkBandwidthSubTLV(125000000))
+				e.AddSubTLV(packet.NewMaximumReservableLinkBandwidthSubTLV(100000000))
+				e.AddSubTLV(packet.NewUnreservedBandwidthSubTLV([packet.UnreservedBandwidthPriorities]float32{
+					100000000, 100000000, 100000000, 100000000, 100000000, 100000000, 100000000, 100000000,
+				}))
+				e.AddSubTLV(packet.NewTEDefaultMetricSubTLV(100))
+				return e
+			}(),
+		},
+		{
+			name: "Explicit unreserved bandwidth",
+			te: &InterfaceTEConfig{
+				UnreservedBandwidth: &[packet.UnreservedBandwidthPriorities]float32{1, 2, 3, 4, 5, 6, 7, 8},
+			},
+			expected: func() *packet.ExtendedISReachabilityNeighbor {
+				e := packet.NewExtendedISReachabilityNeighbor(types.NewSourceID(sysID, 0), 10)
+				e.AddSubTLV(packet.NewAdministrativeGroupSubTLV(0))
+				e.AddSubTLV(packet.NewIPv4InterfaceAddressSubTLV(3221225985))
+				e.AddSubTLV(packet.NewIPv4NeighborAddressSubTLV(3221225986))
+				e.AddSubTLV(packet.NewUnreservedBandwidthSubTLV([packet.UnreservedBandwidthPriorities]float32{1, 2, 3, 4, 5, 6, 7, 8}))
+				return e
+			}(),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nifa := &netIfa{
+				cfg: &InterfaceConfig{
+					Name: "eth0",
+					TE:   test.te,
+				},
+				devStatus: &mockDevice{
+					addrs: []*bnet.Prefix{
+						bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1), 64).Ptr(),
+						bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 1), 30).Ptr(),
+					},
+				},
+			}
+
+			n := &neighbor{
+				sysID: sysID,
+				ipAddresses: []bnet.IP{
+					bnet.IPv4FromOctets(192, 0, 2, 2),
+				},
+			}
+
+			assert.Equal(t, test.expected, nifa.getExtendedISReachabilityNeighbor(n, 10))
+		})
+	}
+}
+
+func TestGetReachabilityTLVs(t *testing.T) {
+	neighborID := types.NewSourceID(types.SystemID{1, 2, 3, 4, 5, 6}, 0)
+	addr := uint32(3221225984) // 192.0.2.0
+
+	narrowTLVs := func() []packet.TLV {
+		isr := packet.NewISReachabilityTLV(nil)
+		isr.AddNeighbor(packet.NewISNeighbor(neighborID, 10))
+		ipr := packet.NewIPInternalReachabilityTLV()
+		ipr.AddIPReachability(packet.NewIPReachability(10, 30, addr))
+		return []packet.TLV{isr, ipr}
+	}
+
+	wideTLVs := func() []packet.TLV {
+		eisr := packet.NewExtendedISReachabilityTLV()
+		eisr.AddNeighbor(packet.NewExtendedISReachabilityNeighbor(neighborID, 10))
+		eipr := packet.NewExtendedIPReachabilityTLV()
+		eipr.AddExtendedIPReachability(packet.NewExtendedIPReachability(10, 30, addr))
+		return []packet.TLV{eisr, eipr}
+	}
+
+	tests := []struct {
+		name        string
+		metricStyle MetricStyle
+		expected    []packet.TLV
+	}{
+		{
+			name:        "Narrow",
+			metricStyle: MetricStyleNarrow,
+			expected:    narrowTLVs(),
+		},
+		{
+			name:        "Wide",
+			metricStyle: MetricStyleWide,
+			expected:    wideTLVs(),
+		},
+		{
+			name:        "Transition",
+			metricStyle: MetricStyleTransition,
+			expected: []packet.TLV{
+				narrowTLVs()[0],
+				wideTLVs()[0],
+				narrowTLVs()[1],
+				wideTLVs()[1],
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Server{
+				levelConfigL2: LevelConfig{MetricStyle: test.metricStyle},
+			}
+			s.netIfaManager = newNetIfaManager(s)
+
+			nifa := &netIfa{
+				name: "eth0",
+				srv:  s,
+				cfg: &InterfaceConfig{
+					Name: "eth0",
+					Level2: &InterfaceLevelConfig{
+						Metric: 10,
+					},
+				},
+				devStatus: &mockDevice{
+					addrs: []*bnet.Prefix{
+						bnet.NewPfx(bnet.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1), 64).Ptr(),
+						bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 1), 30).Ptr(),
+					},
+				},
+			}
+			nifa.neighborManagerL2 = newNeighborManager(s, nifa, 2)
+			nifa.neighborManagerL2.neighbors[ethernet.MACAddr{1}] = &neighbor{
+				sysID: neighborID.SystemID,
+				state: packet.P2PAdjStateUp,
+			}
+			nifa.neighborManagerL2.neighbors[ethernet.MACAddr{2}] = &neighbor{
+				sysID: types.SystemID{1, 2, 3, 4, 5, 7},
+				state: packet.P2PAdjStateInit,
+			}
+			s.netIfaManager.netIfas[nifa.name] = nifa
+
+			assert.Equal(t, test.expected, s.getReachabilityTLVs(2))
+			assert.Empty(t, s.getReachabilityTLVs(1), "level 1 is not enabled on any interface")
+		})
+	}
+}
+
+func TestGetReachabilityTLVsNodePrefix(t *testing.T) {
+	routerID := bnet.IPv4FromOctets(192, 0, 2, 1)
+
+	s := &Server{
+		levelConfigL2: LevelConfig{MetricStyle: MetricStyleTransition},
+	}
+	s.SetRouterID(routerID)
+	s.netIfaManager = newNetIfaManager(s)
+
+	nifa := &netIfa{
+		name: "lo",
+		srv:  s,
+		cfg: &InterfaceConfig{
+			Name:    "lo",
+			Passive: true,
+			Level2:  &InterfaceLevelConfig{},
+		},
+		devStatus: &mockDevice{
+			addrs: []*bnet.Prefix{
+				bnet.NewPfx(routerID, 32).Ptr(),
+			},
+		},
+	}
+	nifa.neighborManagerL2 = newNeighborManager(s, nifa, 2)
+	s.netIfaManager.netIfas[nifa.name] = nifa
+
+	ipr := packet.NewIPInternalReachabilityTLV()
+	ipr.AddIPReachability(packet.NewIPReachability(0, 32, routerID.ToUint32()))
+
+	e := packet.NewExtendedIPReachability(0, 32, routerID.ToUint32())
+	e.AddSubTLV(packet.NewPrefixAttributeFlagsSubTLV(packet.PrefixAttributeFlagNode))
+	e.AddSubTLV(packet.NewSourceRouterIDSubTLV(routerID))
+	eipr := packet.NewExtendedIPReachabilityTLV()
+	eipr.AddExtendedIPReachability(e)
+
+	assert.Equal(t, []packet.TLV{ipr, eipr}, s.getReachabilityTLVs(2), "host prefixes are advertised with the N-Flag and our router ID using wide metrics only")
+}
+
+func TestGetRouterCapabilityTLV(t *testing.T) {
+	routerID := bnet.IPv4FromOctets(192, 0, 2, 1)
+
+	s := &Server{}
+	assert.Nil(t, s.getRouterCapabilityTLV(), "no router capability TLV without Segment Routing")
+
+	sr := &SegmentRoutingConfig{
+		SRGBBase:  16000,
+		SRGBRange: 8000,
+	}
+	assert.Error(t, s.SetSegmentRouting(sr), "router ID required")
+
+	s.SetRouterID(bnet.IPv4(0))
+	assert.Error(t, s.SetSegmentRouting(sr), "router ID must not be 0")
+
+	s.SetRouterID(routerID)
+	assert.NoError(t, s.SetSegmentRouting(sr))
+
+	srCap := packet.NewSRCapabilitiesSubTLV(packet.SRCapabilitiesFlagI)
+	srCap.AddDescriptor(8000, packet.NewSIDLabelSubTLVLabel(16000))
+	expected := packet.NewRouterCapabilityTLV(routerID.ToUint32(), 0)
+	expected.AddSubTLV(srCap)
+	expected.AddSubTLV(packet.NewSRAlgorithmSubTLV([]uint8{packet.SRAlgorithmSPF}))
+
+	assert.Equal(t, expected, s.getRouterCapabilityTLV())
+}
+
+func TestAddISNeighborSplitsTLVs(t *testing.T) {
+	tlvs := make([]*packet.ISReachabilityTLV, 0)
+	for i := 0; i < 24; i++ {
+		tlvs = addISNeighbor(tlvs, packet.NewISNeighbor(types.NewSourceID(types.SystemID{0, 0, 0, 0, 0, uint8(i)}, 0), 10))
+	}
+
+	assert.Len(t, tlvs, 2)
+	assert.Equal(t, uint8(254), tlvs[0].TLVLength)
+	assert.Len(t, tlvs[1].Neighbors, 1)
+}
+
+func TestExportPolicy(t *testing.T) {
+	pfxSuppressed := bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 30)
+	pfxTagged := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24)
+
+	s := &Server{
+		nets: []*types.NET{
+			{AFI: 0x49, AreaID: types.AreaID{0, 1}, SystemID: types.SystemID{1, 2, 3, 4, 5, 6}},
+		},
+		levelConfigL2: LevelConfig{
+			MetricStyle: MetricStyleWide,
+			ExportPolicy: filter.Chain{
+				filter.NewFilter("export", []*filter.Term{
+					filter.NewTerm("suppress", []*filter.TermCondition{
+						filter.NewTermConditionWithRouteFilters(filter.NewRouteFilter(pfxSuppressed.Ptr(), filter.NewExactMatcher())),
+					}, []actions.Action{actions.NewRejectAction()}),
+					filter.NewTerm("tag", []*filter.TermCondition{
+						filter.NewTermConditionWithRouteFilters(filter.NewRouteFilter(pfxTagged.Ptr(), filter.NewExactMatcher())),
+					}, []actions.Action{
+						actions.NewSetISISMetricAction(50),
+						actions.NewSetISISTagAction(100),
+						actions.NewAcceptAction(),
+					}),
+				}),
+				filter.NewAcceptAllFilter(),
+			},
+		},
+	}
+	s.netIfaManager = newNetIfaManager(s)
+	nifa := &netIfa{
+		name: "eth0",
+		srv:  s,
+		cfg: &InterfaceConfig{
+			Name: "eth0",
+			Level2: &InterfaceLevelConfig{
+				Metric: 10,
+			},
+		},
+		devStatus: &mockDevice{
+			addrs: []*bnet.Prefix{
+				bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 1), 30).Ptr(),
+				bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 1), 24).Ptr(),
+				bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 1), 24).Ptr(),
+			},
+		},
+	}
+	nifa.neighborManagerL2 = newNeighborManager(s, nifa, 2)
+	s.netIfaManager.netIfas[nifa.name] = nifa
+
+	lsp := s.getOwnLSPDU(2)
+
+	type reachability struct {
+		metric uint32
+		pfxLen uint8
+		addr   uint32
+		tags   []uint32
+	}
+
+	res := make([]reachability, 0)
+	for _, tlv := range lsp.TLVs {
+		eipr, ok := tlv.(*packet.ExtendedIPReachabilityTLV)
+		if !ok {
+			continue
+		}
+
+		for _, e := range eipr.ExtendedIPReachabilities {
+			res = append(res, reachability{
+				metric: e.Metric,
+				pfxLen: e.PfxLen(),
+				addr:   e.Address,
+				tags:   e.Tags(),
+			})
+		}
+	}
+
+	assert.Equal(t, []reachability{
+		{metric: 50, pfxLen: 24, addr: 0xc6336400, tags: []uint32{100}},
+		{metric: 10, pfxLen: 24, addr: 0xcb007100, tags: []uint32{}},
+	}, res, "192.0.2.0/30 must be suppressed and 198.51.100.0/24 tagged")
+}
@@ -0,0 +1,52 @@
+package server
+
+import "fmt"
+
+// MetricStyle defines which reachability TLVs are originated and used by SPF on a level
+type MetricStyle uint8
+
+const (
+	// MetricStyleWide originates and consumes new style reachability only (TLV 22 and 135, RFC5305)
+	MetricStyleWide MetricStyle = iota
+
+	// MetricStyleNarrow originates and consumes old style reachability only (TLV 2 and 128, RFC1195)
+	MetricStyleNarrow
+
+	// MetricStyleTransition originates and consumes both styles. Wide metrics are preferred by SPF (RFC5305 section 5).
+	MetricStyleTransition
+)
+
+// ParseMetricStyle parses a metric style. An empty string results in the default (wide).
+func ParseMetricStyle(s string) (MetricStyle, error) {
+	switch s {
+	case "", "wide":
+		return MetricStyleWide, nil
+	case "narrow":
+		return MetricStyleNarrow, nil
+	case "transition":
+		return MetricStyleTransition, nil
+	}
+
+	return 0, fmt.Errorf("unknown metric style %q", s)
+}
+
+func (m MetricStyle) String() string {
+	switch m {
+	case MetricStyleWide:
+		return "wide"
+	case MetricStyleNarrow:
+		return "narrow"
+	case MetricStyleTransition:
+		return "transition"
+	}
+
+	return fmt.Sprintf("unknown(%d)", m)
+}
+
+func (m MetricStyle) narrow() bool {
+	return m == MetricStyleNarrow || m == MetricStyleTransition
+}
+
+func (m MetricStyle) wide() bool {
+	return m == MetricStyleWide || m == MetricStyleTransition
+}
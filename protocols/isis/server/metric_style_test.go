@@ -0,0 +1,32 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMetricStyle(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected MetricStyle
+		wantFail bool
+	}{
+		{input: "", expected: MetricStyleWide},
+		{input: "wide", expected: MetricStyleWide},
+		{input: "narrow", expected: MetricStyleNarrow},
+		{input: "transition", expected: MetricStyleTransition},
+		{input: "old", wantFail: true},
+	}
+
+	for _, test := range tests {
+		res, err := ParseMetricStyle(test.input)
+		if test.wantFail {
+			assert.Error(t, err, test.input)
+			continue
+		}
+
+		assert.NoError(t, err, test.input)
+		assert.Equal(t, test.expected, res, test.input)
+	}
+}
@@ -104,8 +104,13 @@ func (nm *neighborManager) neighborFromP2PHello(hello *packet.P2PHello, addr eth
 }
 
 func (n *neighbor) down() {
+	wasUp := n.getState() == packet.P2PAdjStateUp
 	n.setState(packet.P2PAdjStateDown)
 	log.WithFields(n.fields()).Info("Adjacency changed state to DOWN")
+
+	if wasUp {
+		n.nm.adjacencyDown(n)
+	}
 }
 
 func (n *neighbor) dispose() {
@@ -207,8 +212,9 @@ func (n *neighbor) processP2PHello(hello *packet.P2PHello) error {
 	if n.getState() != packet.P2PAdjStateUp {
 		log.WithFields(n.fields()).Infof("Adjacency reaches up state")
 		n.setState(packet.P2PAdjStateUp)
+		n.nm.adjacencyUp(n)
 
-		// TODO: Generate LSP, send CSNP, etc, pp.
+		// TODO: Send CSNP, etc, pp.
 	}
 
 	return nil
@@ -39,14 +39,32 @@ func (nm *neighborManager) fields() log.Fields {
 }
 
 func (nm *neighborManager) netDown() {
-	nm.neighborsMu.Lock()
-	defer nm.neighborsMu.Unlock()
-
-	for _, n := range nm.neighbors {
+	for _, n := range nm.getNeighbors() {
 		n.down()
 	}
 }
 
+// adjacencyUp reoriginates our LSP including the new adjacency and reruns the SPF
+func (nm *neighborManager) adjacencyUp(n *neighbor) {
+	log.WithFields(nm.fields()).Debugf("Adjacency to %q up, reoriginating LSP", n.sysID.String())
+	nm.netIfa.countAdjacencyChange()
+	nm.server.adjacencyChanged(int(nm.level))
+}
+
+// adjacencyDown reoriginates our LSP without the lost adjacency and reruns the SPF. The flooding state
+// towards the interface is cleared once no adjacency is left on it.
+func (nm *neighborManager) adjacencyDown(n *neighbor) {
+	log.WithFields(nm.fields()).Debugf("Adjacency to %q down, reoriginating LSP", n.sysID.String())
+	nm.netIfa.countAdjacencyChange()
+	if len(nm.getNeighborsUp()) == 0 {
+		if l := nm.server.levelLSDB(int(nm.level)); l != nil {
+			l.dropInterface(nm.netIfa)
+		}
+	}
+
+	nm.server.adjacencyChanged(int(nm.level))
+}
+
 func (nm *neighborManager) getNeighbors() []*neighbor {
 	ret := make([]*neighbor, 0)
 	nm.neighborsMu.RLock()
@@ -186,7 +204,7 @@ func (nm *neighborManager) validateP2PHello(hello *packet.P2PHello) error {
 func (nifa *netIfa) validateAreasL1(receivedAreas []types.AreaID) bool {
 	localAreas := make([]types.AreaID, 0)
 	for _, net := range nifa.srv.nets {
-		localAreas = append(localAreas, append([]byte{net.AFI}, net.AreaID...))
+		localAreas = append(localAreas, net.AreaAddress())
 	}
 
 	for _, needle := range receivedAreas {
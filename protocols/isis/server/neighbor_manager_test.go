@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/net/ethernet"
 	"github.com/bio-routing/bio-rd/protocols/isis/packet"
 	"github.com/bio-routing/bio-rd/protocols/isis/types"
 	"github.com/stretchr/testify/assert"
@@ -187,3 +188,116 @@ func TestValidateIPv4Addresses(t *testing.T) {
 		assert.Equal(t, test.expected, res, test.name)
 	}
 }
+
+// ownISNeighbors gets the IS neighbors advertised in our level 2 LSP
+func ownISNeighbors(s *Server) []types.SystemID {
+	s.lsdbL2.lspsMu.RLock()
+	defer s.lsdbL2.lspsMu.RUnlock()
+
+	ret := make([]types.SystemID, 0)
+	e := s.lsdbL2.lsps[packet.LSPID{SystemID: spfTestSysA}]
+	if e == nil {
+		return ret
+	}
+
+	for _, tlv := range e.lspdu.TLVs {
+		if eisr, ok := tlv.(*packet.ExtendedISReachabilityTLV); ok {
+			for _, n := range eisr.Neighbors {
+				ret = append(ret, n.NeighborID.SystemID)
+			}
+		}
+	}
+
+	return ret
+}
+
+func TestAdjacencyDown(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 2, 0, 0), 16)
+
+	s := newLeakTestServer(2)
+	s.lspLifetime = 1200
+	s.levelConfigL2.MetricStyle = MetricStyleWide
+	nifa := s.netIfaManager.netIfas["eth2"]
+	nm := nifa.neighborManagerL2
+
+	lspB := spfTestLSP(spfTestSysB, 0, wideLinks(link(spfTestSysA, 10)), widePrefix(10, pfx))
+	addLSPs(s.lsdbL2, lspB)
+
+	n := &neighbor{
+		sysID: spfTestSysB,
+		nm:    nm,
+		state: packet.P2PAdjStateUp,
+	}
+	nm.neighbors[ethernet.MACAddr{2}] = n
+	nm.adjacencyUp(n)
+
+	assert.Equal(t, []types.SystemID{spfTestSysB}, ownISNeighbors(s))
+	assert.Contains(t, s.lsdbL2.getRoutes(), pfx)
+	assert.Equal(t, uint64(1), s.lsdbL2.spfRuns)
+
+	s.lsdbL2.lsps[lspB.LSPID].setSRM(nifa)
+	s.lsdbL2.lsps[lspB.LSPID].setSSN(nifa)
+
+	n.down()
+
+	assert.Empty(t, ownISNeighbors(s), "neighbor must be removed from our LSP")
+	assert.NotContains(t, s.lsdbL2.getRoutes(), pfx)
+	assert.Equal(t, uint64(2), s.lsdbL2.spfRuns)
+	assert.Empty(t, s.lsdbL2.lsps[lspB.LSPID].getInterfacesSRMSet(), "SRM flags must be cleared")
+	assert.False(t, s.lsdbL2.lsps[lspB.LSPID].getSSN(nifa), "SSN flags must be cleared")
+	assert.Empty(t, s.lsdbL2.lsps[packet.LSPID{SystemID: spfTestSysA}].getInterfacesSRMSet(), "our LSP must not be flooded without adjacency")
+}
+
+func TestL1AdjacencyArea(t *testing.T) {
+	tests := []struct {
+		name       string
+		remoteArea types.AreaID
+		expected   bool
+	}{
+		{
+			name:       "Matching area",
+			remoteArea: leakTestArea,
+			expected:   true,
+		},
+		{
+			name:       "Area mismatch",
+			remoteArea: types.AreaID{0x49, 0, 2},
+			expected:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newLeakTestServer(1)
+			nifa := s.netIfaManager.netIfas["eth1"]
+			nifa.devStatus = &mockDevice{
+				addrs: []*bnet.Prefix{
+					bnet.NewPfx(bnet.IPv4(110), 31).Ptr(),
+				},
+			}
+
+			protocols := packet.NewProtocolsSupportedTLV([]uint8{packet.NLPIDIPv4, packet.NLPIDIPv6})
+			hello := &packet.P2PHello{
+				CircuitType:  types.CircuitTypeL1,
+				SystemID:     spfTestSysB,
+				HoldingTimer: 27,
+				TLVs: []packet.TLV{
+					packet.NewP2PAdjacencyStateTLV(packet.P2PAdjStateDown, 1),
+					&protocols,
+					packet.NewIPInterfaceAddressesTLV([]uint32{111}),
+					areaTLV(test.remoteArea),
+				},
+			}
+
+			err := nifa.processP2PHello(ethernet.MACAddr{2}, hello)
+			assert.NoError(t, err)
+
+			neighbors := nifa.neighborManagerL1.getNeighbors()
+			for _, n := range neighbors {
+				n.dispose()
+			}
+
+			assert.Equal(t, test.expected, len(neighbors) == 1, "L1 neighbor")
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/bio-routing/bio-rd/net/ethernet"
 	"github.com/bio-routing/bio-rd/protocols/device"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
 	"github.com/bio-routing/bio-rd/util/log"
 	btime "github.com/bio-routing/bio-rd/util/time"
 )
@@ -23,9 +24,49 @@ type InterfaceConfig struct {
 	PointToPoint bool
 	Level1       *InterfaceLevelConfig
 	Level2       *InterfaceLevelConfig
+	TE           *InterfaceTEConfig
 	mock         bool
 }
 
+// InterfaceTEConfig represents the traffic engineering attributes of an interface (RFC5305).
+// Bandwidths are expressed in bytes per second.
+type InterfaceTEConfig struct {
+	AdminGroup             uint32
+	MaxLinkBandwidth       float32
+	MaxReservableBandwidth float32
+
+	// UnreservedBandwidth per priority level. Defaults to MaxReservableBandwidth for all priorities if nil.
+	UnreservedBandwidth *[packet.UnreservedBandwidthPriorities]float32
+
+	// DefaultMetric is the TE default metric. The IGP metric is used by TE if nil.
+	DefaultMetric *uint32
+}
+
+// Equal compares two InterfaceTEConfigs
+func (c *InterfaceTEConfig) Equal(x *InterfaceTEConfig) bool {
+	if c == nil || x == nil {
+		return c == x
+	}
+
+	if c.AdminGroup != x.AdminGroup || c.MaxLinkBandwidth != x.MaxLinkBandwidth || c.MaxReservableBandwidth != x.MaxReservableBandwidth {
+		return false
+	}
+
+	if (c.UnreservedBandwidth == nil) != (x.UnreservedBandwidth == nil) {
+		return false
+	}
+
+	if c.UnreservedBandwidth != nil && *c.UnreservedBandwidth != *x.UnreservedBandwidth {
+		return false
+	}
+
+	if (c.DefaultMetric == nil) != (x.DefaultMetric == nil) {
+		return false
+	}
+
+	return c.DefaultMetric == nil || *c.DefaultMetric == *x.DefaultMetric
+}
+
 // holdingTimer() picks the maximum holding timer from Level1 and Level2 config
 func (ifCfg *InterfaceConfig) holdingTimer() uint16 {
 	if ifCfg.Level1 != nil && ifCfg.Level2 == nil {
@@ -67,6 +108,37 @@ func (ifCfg *InterfaceConfig) getMinHelloInterval() uint16 {
 	return ifCfg.Level2.HelloInterval
 }
 
+func (ifCfg *InterfaceConfig) levelConfig(level int) *InterfaceLevelConfig {
+	if level == 1 {
+		return ifCfg.Level1
+	}
+
+	return ifCfg.Level2
+}
+
+// withoutDisabledLevels gets a copy of cfg without the levels disabled on the server. It fails if all levels
+// configured on the interface are disabled.
+func (s *Server) withoutDisabledLevels(cfg *InterfaceConfig) (*InterfaceConfig, error) {
+	if s.lsdbL1 != nil && s.lsdbL2 != nil {
+		return cfg, nil
+	}
+
+	ret := *cfg
+	if s.lsdbL1 == nil {
+		ret.Level1 = nil
+	}
+
+	if s.lsdbL2 == nil {
+		ret.Level2 = nil
+	}
+
+	if ret.Level1 == nil && ret.Level2 == nil && (cfg.Level1 != nil || cfg.Level2 != nil) {
+		return nil, fmt.Errorf("all levels of interface %q are disabled", cfg.Name)
+	}
+
+	return &ret, nil
+}
+
 // InterfaceLevelConfig is the ISIS level config of an interface
 type InterfaceLevelConfig struct {
 	HelloInterval uint16
@@ -94,6 +166,24 @@ type netIfa struct {
 	initialized       bool
 	devStatus         device.DeviceInterface
 	ethHandler        ethernet.HandlerInterface
+	counters          counters
+}
+
+func (nifa *netIfa) neighborManager(level int) *neighborManager {
+	if level == 1 {
+		return nifa.neighborManagerL1
+	}
+
+	return nifa.neighborManagerL2
+}
+
+// adjacencyUp returns if the interface has at least one adjacency of a level in state up
+func (nifa *netIfa) adjacencyUp(level int) bool {
+	if nifa.cfg.levelConfig(level) == nil {
+		return false
+	}
+
+	return len(nifa.neighborManager(level).getNeighborsUp()) > 0
 }
 
 func newNetIfa(srv *Server, cfg *InterfaceConfig) *netIfa {
@@ -122,6 +212,19 @@ func newNetIfa(srv *Server, cfg *InterfaceConfig) *netIfa {
 	return ret
 }
 
+// setTE replaces the TE config of the interface and returns if it changed
+func (nifa *netIfa) setTE(te *InterfaceTEConfig) bool {
+	nifa.mu.Lock()
+	defer nifa.mu.Unlock()
+
+	if nifa.cfg.TE.Equal(te) {
+		return false
+	}
+
+	nifa.cfg.TE = te
+	return true
+}
+
 func (nifa *netIfa) getName() string {
 	nifa.mu.RLock()
 	defer nifa.mu.RUnlock()
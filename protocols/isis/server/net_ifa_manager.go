@@ -27,6 +27,23 @@ func (s *Server) AddInterface(cfg *InterfaceConfig) error {
 	return s.netIfaManager.addInterface(cfg)
 }
 
+// UpdateInterfaceTE replaces the traffic engineering config of an interface. Our LSPs are reoriginated if it changed.
+func (s *Server) UpdateInterfaceTE(name string, te *InterfaceTEConfig) error {
+	nifa := s.netIfaManager.getInterface(name)
+	if nifa == nil {
+		return fmt.Errorf("IS-IS is not enabled on interface %q", name)
+	}
+
+	if !nifa.setTE(te) {
+		return nil
+	}
+
+	log.Infof("IS-IS: TE config of interface %s changed", name)
+	s.originateLSP(1)
+	s.originateLSP(2)
+	return nil
+}
+
 func (s *Server) RemoveInterface(name string) error {
 	log.Debugf("IS-IS: Removing interface %s", name)
 	return s.netIfaManager.removeInterface(name)
@@ -54,6 +71,11 @@ func (nima *netIfaManager) addInterface(cfg *InterfaceConfig) error {
 		return fmt.Errorf("ISIS is enabled on that interface already. Updating config is not supported yet")
 	}
 
+	cfg, err := nima.srv.withoutDisabledLevels(cfg)
+	if err != nil {
+		return err
+	}
+
 	ifa := newNetIfa(nima.srv, cfg)
 	nima.netIfas[cfg.Name] = ifa
 
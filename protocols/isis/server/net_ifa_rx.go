@@ -40,29 +40,55 @@ func (nifa *netIfa) processPkt(src ethernet.MACAddr, rawPkt []byte) error {
 		return fmt.Errorf("Decode failed: %w", err)
 	}
 
+	nifa.countReceived(pkt.Header.PDUType)
+
 	err = nifa.validatePkt(src, pkt)
 	if err != nil {
 		log.WithFields(nifa.fields()).WithError(err).Debug("Packet validation failed")
 		return nil
 	}
 
+	if !nifa.pduAuthenticated(pkt) {
+		nifa.countAuthenticationFailure()
+		log.WithFields(nifa.fields()).Debugf("Dropping PDU of type %d failing authentication", pkt.Header.PDUType)
+		return nil
+	}
+
 	switch pkt.Header.PDUType {
 	case packet.P2P_HELLO:
 		return nifa.processP2PHello(src, pkt.Body.(*packet.P2PHello))
-	case packet.L2_LS_PDU_TYPE:
-		nifa.srv.lsdbL2.processLSP(nifa, pkt.Body.(*packet.LSPDU))
+	case packet.L1_LS_PDU_TYPE, packet.L2_LS_PDU_TYPE:
+		lspdu := pkt.Body.(*packet.LSPDU)
+		// Purges may carry a zero checksum (RFC3719 section 7)
+		if lspdu.RemainingLifetime != 0 && !lspdu.ChecksumValid() {
+			nifa.countChecksumError()
+			log.WithFields(nifa.fields()).Debugf("Dropping LSPDU %s with invalid checksum", lspdu.LSPID.String())
+			return nil
+		}
+
+		nifa.srv.levelLSDB(pduLevel(pkt.Header.PDUType)).processLSP(nifa, lspdu)
 		return nil
-	case packet.L2_CSNP_TYPE:
-		nifa.srv.lsdbL2.processCSNP(nifa, pkt.Body.(*packet.CSNP))
+	case packet.L1_CSNP_TYPE, packet.L2_CSNP_TYPE:
+		nifa.srv.levelLSDB(pduLevel(pkt.Header.PDUType)).processCSNP(nifa, pkt.Body.(*packet.CSNP))
 		return nil
-	case packet.L2_PSNP_TYPE:
-		nifa.srv.lsdbL2.processPSNP(nifa, pkt.Body.(*packet.PSNP))
+	case packet.L1_PSNP_TYPE, packet.L2_PSNP_TYPE:
+		nifa.srv.levelLSDB(pduLevel(pkt.Header.PDUType)).processPSNP(nifa, pkt.Body.(*packet.PSNP))
 		return nil
 	}
 
 	return fmt.Errorf("Unknown PDU type %d", pkt.Header.PDUType)
 }
 
+// pduLevel gets the level of an LSP, CSNP or PSNP by its PDU type
+func pduLevel(pduType uint8) int {
+	switch pduType {
+	case packet.L1_LS_PDU_TYPE, packet.L1_CSNP_TYPE, packet.L1_PSNP_TYPE:
+		return 1
+	}
+
+	return 2
+}
+
 func (nifa *netIfa) validatePkt(src ethernet.MACAddr, pkt *packet.ISISPacket) error {
 	if pkt.Header.PDUType == packet.L2_LS_PDU_TYPE || pkt.Header.PDUType == packet.L2_CSNP_TYPE || pkt.Header.PDUType == packet.L2_PSNP_TYPE {
 		if nifa.neighborManagerL2 == nil {
@@ -6,34 +6,71 @@ import (
 	"github.com/bio-routing/bio-rd/protocols/isis/packet"
 )
 
-func (nifa *netIfa) sendLSPDU(lsp *packet.LSPDU, level int) error {
+// lspPDUType gets the PDU type of LSPs of a level
+func lspPDUType(level int) uint8 {
 	if level == 1 {
-		panic("L1 is not supported yet")
-		return nil
+		return packet.L1_LS_PDU_TYPE
 	}
 
-	return nifa.sendPDU(lsp, packet.L2_LS_PDU_TYPE)
+	return packet.L2_LS_PDU_TYPE
 }
 
-func (nifa *netIfa) sendPSNP(psnp *packet.PSNP, level int) error {
+// csnpPDUType gets the PDU type of CSNPs of a level
+func csnpPDUType(level int) uint8 {
 	if level == 1 {
-		panic("L1 is not supported yet")
-		return nil
+		return packet.L1_CSNP_TYPE
 	}
 
-	return nifa.sendPDU(psnp, packet.L2_PSNP_TYPE)
+	return packet.L2_CSNP_TYPE
 }
 
-func (nifa *netIfa) sendCSNP(csnp *packet.CSNP, level int) error {
+// psnpPDUType gets the PDU type of PSNPs of a level
+func psnpPDUType(level int) uint8 {
 	if level == 1 {
-		panic("L1 is not supported yet")
-		return nil
+		return packet.L1_PSNP_TYPE
+	}
+
+	return packet.L2_PSNP_TYPE
+}
+
+// serializeLSPDU serializes lsp including the ISIS header. The result can be sent on any interface.
+func serializeLSPDU(lsp *packet.LSPDU, level int) []byte {
+	return serializePDU(lsp, lspPDUType(level))
+}
+
+func (nifa *netIfa) sendPSNP(psnp *packet.PSNP, level int) error {
+	if a := nifa.srv.authenticationTLV(level, psnpPDUType(level)); a != nil {
+		psnp.TLVs = append(psnp.TLVs, a)
+		psnp.PDULength += uint16(a.Length()) + 2
+	}
+
+	return nifa.sendPDU(psnp, psnpPDUType(level))
+}
+
+func (nifa *netIfa) sendCSNP(csnp *packet.CSNP, level int) error {
+	if a := nifa.srv.authenticationTLV(level, csnpPDUType(level)); a != nil {
+		csnp.TLVs = append(csnp.TLVs, a)
+		csnp.PDULength += uint16(a.Length()) + 2
 	}
 
-	return nifa.sendPDU(csnp, packet.L2_CSNP_TYPE)
+	return nifa.sendPDU(csnp, csnpPDUType(level))
 }
 
 func (nifa *netIfa) sendPDU(pkt packet.Serializable, pduType uint8) error {
+	return nifa.sendSerializedPDU(serializePDU(pkt, pduType), pduType)
+}
+
+func (nifa *netIfa) sendSerializedPDU(pdu []byte, pduType uint8) error {
+	_, err := nifa.isP2PHelloCon.Write(pdu)
+	if err != nil {
+		return err
+	}
+
+	nifa.countSent(pduType)
+	return nil
+}
+
+func serializePDU(pkt packet.Serializable, pduType uint8) []byte {
 	buf := bytes.NewBuffer(nil)
 	pkt.Serialize(buf)
 
@@ -42,8 +79,7 @@ func (nifa *netIfa) sendPDU(pkt packet.Serializable, pduType uint8) error {
 	hdr.Serialize(hdrBuf)
 	hdrBuf.Write(buf.Bytes())
 
-	_, err := nifa.isP2PHelloCon.Write(hdrBuf.Bytes())
-	return err
+	return hdrBuf.Bytes()
 }
 
 func getHeader(pduType uint8) packet.ISISHeader {
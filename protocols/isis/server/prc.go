@@ -0,0 +1,175 @@
+package server
+
+import (
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+)
+
+// lspChanged updates the routes of the level after LSP old (nil if unknown) has been replaced by lspdu. Changes of the
+// topology trigger a full SPF run while changes of the IP reachability only trigger a partial route calculation (PRC)
+// of the affected prefixes reusing the shortest path tree of the last SPF run.
+func (l *lsdb) lspChanged(old *packet.LSPDU, lspdu *packet.LSPDU) {
+	metricStyle := l.srv.levelConfig(l.level()).MetricStyle
+	if l.getTree() == nil || topologyChanged(old, lspdu, metricStyle) {
+		l.runSPF()
+	} else {
+		pfxs := changedPrefixes(old, lspdu, metricStyle)
+		if len(pfxs) == 0 {
+			return
+		}
+
+		l.runPRC(types.NewSourceID(lspdu.LSPID.SystemID, lspdu.LSPID.PseudonodeID), pfxs)
+	}
+
+	// The ATT bit and the leaked routes of our level 1 LSP depend on the level 2 routes
+	if l.level() == 2 && l.srv.l1l2() {
+		l.srv.originateLSP(1)
+	}
+}
+
+// spfUsable returns if an LSP is considered by SPF
+func spfUsable(lspdu *packet.LSPDU) bool {
+	return lspdu != nil && lspdu.SequenceNumber != 0 && lspdu.RemainingLifetime != 0
+}
+
+// topologyChanged returns if replacing LSP old by lspdu affects the shortest path tree
+func topologyChanged(old *packet.LSPDU, lspdu *packet.LSPDU, metricStyle MetricStyle) bool {
+	if spfUsable(old) != spfUsable(lspdu) {
+		return true
+	}
+
+	if !spfUsable(old) {
+		return false
+	}
+
+	o := newSPFNode()
+	o.addLSPDU(old, metricStyle)
+	n := newSPFNode()
+	n.addLSPDU(lspdu, metricStyle)
+
+	return o.attached != n.attached ||
+		!equalLinks(o.narrowLinks, n.narrowLinks) ||
+		!equalLinks(o.wideLinks, n.wideLinks) ||
+		!equalAreas(o.areas, n.areas)
+}
+
+// changedPrefixes returns the prefixes advertised differently by LSP old and lspdu
+func changedPrefixes(old *packet.LSPDU, lspdu *packet.LSPDU, metricStyle MetricStyle) map[bnet.Prefix]struct{} {
+	o := newSPFNode()
+	o.addLSPDU(old, metricStyle)
+	n := newSPFNode()
+	n.addLSPDU(lspdu, metricStyle)
+
+	ret := make(map[bnet.Prefix]struct{})
+	addChangedPrefixes(ret, o.narrowPrefixes, n.narrowPrefixes)
+	addChangedPrefixes(ret, o.widePrefixes, n.widePrefixes)
+	return ret
+}
+
+func addChangedPrefixes(changed map[bnet.Prefix]struct{}, a map[bnet.Prefix]spfPrefix, b map[bnet.Prefix]spfPrefix) {
+	for pfx, p := range a {
+		if q, exists := b[pfx]; !exists || p != q {
+			changed[pfx] = struct{}{}
+		}
+	}
+
+	for pfx := range b {
+		if _, exists := a[pfx]; !exists {
+			changed[pfx] = struct{}{}
+		}
+	}
+}
+
+func equalLinks(a map[types.SourceID]uint32, b map[types.SourceID]uint32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for id, m := range a {
+		if n, exists := b[id]; !exists || m != n {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalAreas(a []types.AreaID, b []types.AreaID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// runPRC recomputes the routes to prefixes pfxs after the IP reachability of node id changed
+func (l *lsdb) runPRC(id types.SourceID, pfxs map[bnet.Prefix]struct{}) {
+	node := l.collectSPFNode(id)
+
+	l.routesMu.Lock()
+	defer l.routesMu.Unlock()
+
+	t := l.tree
+	t.nodes[id] = node
+
+	routes := make(map[bnet.Prefix]*spfRoute, len(l.routes))
+	for pfx, r := range l.routes {
+		if _, affected := pfxs[pfx]; !affected {
+			routes[pfx] = r
+		}
+	}
+
+	best := make(map[bnet.Prefix]spfPrefix)
+	for nodeID, d := range t.dist {
+		if nodeID == t.root {
+			continue
+		}
+
+		for pfx := range pfxs {
+			if p, exists := t.nodes[nodeID].prefix(pfx); exists {
+				t.addPrefixRoute(routes, best, nodeID, pfx, p, d)
+			}
+		}
+	}
+
+	if _, affected := pfxs[bnet.NewPfx(bnet.IPv4(0), 0)]; affected && l.level() == 1 && !l.srv.l1l2() {
+		t.addAttachedDefaultRoute(routes)
+	}
+
+	l.routes = routes
+	l.prcRuns++
+}
+
+// collectSPFNode collects the topology and prefix information of all LSP fragments of node id in the LSDB
+func (l *lsdb) collectSPFNode(id types.SourceID) *spfNode {
+	metricStyle := l.srv.levelConfig(l.level()).MetricStyle
+
+	l.lspsMu.RLock()
+	defer l.lspsMu.RUnlock()
+
+	n := newSPFNode()
+	for lspID, e := range l.lsps {
+		if lspID.SystemID != id.SystemID || lspID.PseudonodeID != id.CircuitID || !spfUsable(e.lspdu) {
+			continue
+		}
+
+		n.addLSPDU(e.lspdu, metricStyle)
+	}
+
+	return n
+}
+
+// getTree gets the shortest path tree computed by the last SPF run
+func (l *lsdb) getTree() *spfTree {
+	l.routesMu.RLock()
+	defer l.routesMu.RUnlock()
+
+	return l.tree
+}
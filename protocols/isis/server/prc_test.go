@@ -0,0 +1,138 @@
+package server
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func prcTestLSP(sysID types.SystemID, seq uint32, tlvs ...packet.TLV) *packet.LSPDU {
+	lsp := spfTestLSP(sysID, 0, tlvs...)
+	lsp.SequenceNumber = seq
+	return lsp
+}
+
+func TestTopologyChanged(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24)
+	base := prcTestLSP(spfTestSysB, 1, wideLinks(link(spfTestSysA, 10)), widePrefix(10, pfx))
+
+	tests := []struct {
+		name     string
+		old      *packet.LSPDU
+		new      *packet.LSPDU
+		expected bool
+	}{
+		{
+			name:     "Unknown LSP",
+			new:      base,
+			expected: true,
+		},
+		{
+			name: "Prefix metric changed",
+			old:  base,
+			new:  prcTestLSP(spfTestSysB, 2, wideLinks(link(spfTestSysA, 10)), widePrefix(20, pfx)),
+		},
+		{
+			name: "Prefix withdrawn",
+			old:  base,
+			new:  prcTestLSP(spfTestSysB, 2, wideLinks(link(spfTestSysA, 10))),
+		},
+		{
+			name:     "Link metric changed",
+			old:      base,
+			new:      prcTestLSP(spfTestSysB, 2, wideLinks(link(spfTestSysA, 20)), widePrefix(10, pfx)),
+			expected: true,
+		},
+		{
+			name:     "Link added",
+			old:      base,
+			new:      prcTestLSP(spfTestSysB, 2, wideLinks(link(spfTestSysA, 10), link(spfTestSysC, 10)), widePrefix(10, pfx)),
+			expected: true,
+		},
+		{
+			name: "Narrow link ignored with wide metrics",
+			old:  base,
+			new:  prcTestLSP(spfTestSysB, 2, wideLinks(link(spfTestSysA, 10)), widePrefix(10, pfx), narrowLinks(link(spfTestSysC, 10))),
+		},
+		{
+			name: "Purged",
+			old:  base,
+			new: &packet.LSPDU{
+				LSPID:          base.LSPID,
+				SequenceNumber: 2,
+			},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, topologyChanged(test.old, test.new, MetricStyleWide))
+		})
+	}
+}
+
+func TestPartialRouteCalculation(t *testing.T) {
+	pfx1 := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 1, 0), 24)
+	pfx2 := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 2, 0), 24)
+	pfx3 := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 3, 0), 24)
+
+	// A - B - C, B advertises pfx1 and C pfx2
+	s := newLeakTestServer(2)
+	s.hostnames = newHostnameMap(spfTestSysA, "")
+	l := s.lsdbL2
+	addLSPs(l,
+		prcTestLSP(spfTestSysA, 1, wideLinks(link(spfTestSysB, 10))),
+		prcTestLSP(spfTestSysB, 1, wideLinks(link(spfTestSysA, 10), link(spfTestSysC, 10)), widePrefix(5, pfx1)),
+		prcTestLSP(spfTestSysC, 1, wideLinks(link(spfTestSysB, 10)), widePrefix(5, pfx2)),
+	)
+	l.runSPF()
+	ifa := s.netIfaManager.netIfas["eth2"]
+
+	// C changes the metric of pfx2 and additionally advertises pfx1 with the same metric as B and pfx3
+	l.processLSP(ifa, prcTestLSP(spfTestSysC, 2,
+		wideLinks(link(spfTestSysB, 10)),
+		widePrefix(1, pfx2),
+		widePrefix(0, pfx1),
+		widePrefix(5, pfx3),
+	))
+
+	assert.Equal(t, uint64(1), l.spfRuns, "prefix only change must not trigger SPF")
+	assert.Equal(t, uint64(1), l.prcRuns)
+	expected := map[bnet.Prefix]*spfRoute{
+		pfx1: {metric: 15, nextHops: []types.SystemID{spfTestSysB}},
+		pfx2: {metric: 21, nextHops: []types.SystemID{spfTestSysB}},
+		pfx3: {metric: 25, nextHops: []types.SystemID{spfTestSysB}},
+	}
+	assert.Equal(t, expected, l.getRoutes())
+	assert.Equal(t, l.spf(), l.getRoutes(), "PRC result differs from full SPF")
+
+	// B withdraws pfx1 which is still reachable via C
+	l.processLSP(ifa, prcTestLSP(spfTestSysB, 2, wideLinks(link(spfTestSysA, 10), link(spfTestSysC, 10))))
+
+	assert.Equal(t, uint64(1), l.spfRuns)
+	assert.Equal(t, uint64(2), l.prcRuns)
+	expected[pfx1] = &spfRoute{metric: 20, nextHops: []types.SystemID{spfTestSysB}}
+	assert.Equal(t, expected, l.getRoutes())
+	assert.Equal(t, l.spf(), l.getRoutes(), "PRC result differs from full SPF")
+
+	// Refreshing an LSP without changes requires neither SPF nor PRC
+	l.processLSP(ifa, prcTestLSP(spfTestSysB, 3, wideLinks(link(spfTestSysA, 10), link(spfTestSysC, 10))))
+
+	assert.Equal(t, uint64(1), l.spfRuns)
+	assert.Equal(t, uint64(2), l.prcRuns)
+
+	// A topology change triggers a full SPF run
+	l.processLSP(ifa, prcTestLSP(spfTestSysB, 4, wideLinks(link(spfTestSysA, 10), link(spfTestSysC, 20))))
+
+	assert.Equal(t, uint64(2), l.spfRuns)
+	assert.Equal(t, uint64(2), l.prcRuns)
+	assert.Equal(t, map[bnet.Prefix]*spfRoute{
+		pfx1: {metric: 30, nextHops: []types.SystemID{spfTestSysB}},
+		pfx2: {metric: 31, nextHops: []types.SystemID{spfTestSysB}},
+		pfx3: {metric: 35, nextHops: []types.SystemID{spfTestSysB}},
+	}, l.getRoutes())
+}
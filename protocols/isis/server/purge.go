@@ -0,0 +1,69 @@
+package server
+
+import (
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/util/log"
+)
+
+// defaultZeroAgeLifetime is the default number of seconds a purge is kept in the LSDB after an LSP expired
+// (ISO 10589 section 7.3.16.4)
+const defaultZeroAgeLifetime = 60
+
+// getPurgeLSPDU creates a purge of an LSP of a level: the header of the LSP with zero remaining lifetime and without its TLVs.
+// Unless disabled the purge carries our system ID and hostname to identify us as its originator (RFC6232).
+func (s *Server) getPurgeLSPDU(level int, lspdu *packet.LSPDU) *packet.LSPDU {
+	tlvs := make([]packet.TLV, 0)
+	if !s.levelConfig(level).NoPurgeOriginatorIdentification {
+		tlvs = append(tlvs, packet.NewPurgeOriginatorIdentificationTLV(s.nets[0].SystemID))
+
+		if hostname := s.getDynamicHostnameTLV(); hostname != nil {
+			tlvs = append(tlvs, hostname)
+		}
+	}
+
+	if a := s.authenticationTLV(level, lspPDUType(level)); a != nil {
+		tlvs = append(tlvs, a)
+	}
+
+	purge := &packet.LSPDU{
+		LSPID:          lspdu.LSPID,
+		SequenceNumber: lspdu.SequenceNumber,
+		TypeBlock:      lspdu.TypeBlock,
+		TLVs:           tlvs,
+	}
+	purge.UpdateLength()
+
+	return purge
+}
+
+// _purge replaces the LSP of e by a purge and floods it to all interfaces with an adjacency of the level up
+func (l *lsdb) _purge(e *lsdbEntry) {
+	e.lspdu = l.srv.getPurgeLSPDU(l.level(), e.lspdu)
+	e.zeroAge = l.srv.getZeroAgeLifetime()
+
+	for _, ifa := range l.srv.netIfaManager.getAllInterfaces() {
+		if ifa.adjacencyUp(l.level()) {
+			e.setSRM(ifa)
+		}
+	}
+
+	log.WithFields(l.lspFields(e.lspdu.LSPID)).Info("ISIS: Purging expired LSP")
+}
+
+// logPurge logs a received purge including its originator if it carries a purge originator identification TLV (RFC6232)
+func (l *lsdb) logPurge(lspdu *packet.LSPDU) {
+	fields := l.lspFields(lspdu.LSPID)
+	for _, tlv := range lspdu.TLVs {
+		poi, ok := tlv.(*packet.PurgeOriginatorIdentificationTLV)
+		if !ok {
+			continue
+		}
+
+		fields["originator"] = l.srv.hostnames.resolve(poi.Originator)
+		if poi.ReceivedFrom != nil {
+			fields["receivedFrom"] = l.srv.hostnames.resolve(*poi.ReceivedFrom)
+		}
+	}
+
+	log.WithFields(fields).Info("ISIS: Received purge")
+}
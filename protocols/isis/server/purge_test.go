@@ -0,0 +1,90 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bio-routing/bio-rd/net/ethernet"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	btime "github.com/bio-routing/bio-rd/util/time"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPurgeExpiredLSP(t *testing.T) {
+	localSysID := types.SystemID{1, 1, 1, 1, 1, 1}
+	remoteLSP := packet.LSPID{SystemID: types.SystemID{2, 2, 2, 2, 2, 2}}
+
+	tests := []struct {
+		name        string
+		levelConfig LevelConfig
+		expected    []packet.TLV
+	}{
+		{
+			name: "Purge originator identification",
+			expected: []packet.TLV{
+				packet.NewPurgeOriginatorIdentificationTLV(localSysID),
+				packet.NewDynamicHostnameTLV([]byte("local")),
+			},
+		},
+		{
+			name: "Purge originator identification disabled",
+			levelConfig: LevelConfig{
+				NoPurgeOriginatorIdentification: true,
+			},
+			expected: []packet.TLV{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clock := btime.NewMockClock(time.Unix(1000, 0))
+			s := &Server{
+				nets: []*types.NET{
+					{SystemID: localSysID},
+				},
+				hostname:      "local",
+				levelConfigL2: test.levelConfig,
+				clock:         clock,
+				hostnames:     newHostnameMap(localSysID, "local"),
+			}
+			s.netIfaManager = newNetIfaManager(s)
+			nifa := &netIfa{
+				name: "eth0",
+				srv:  s,
+				cfg:  &InterfaceConfig{Name: "eth0", Level2: &InterfaceLevelConfig{}},
+			}
+			nifa.neighborManagerL2 = newNeighborManager(s, nifa, 2)
+			nifa.neighborManagerL2.neighbors[ethernet.MACAddr{2}] = &neighbor{
+				sysID: remoteLSP.SystemID,
+				nm:    nifa.neighborManagerL2,
+				state: packet.P2PAdjStateUp,
+			}
+			s.netIfaManager.netIfas[nifa.name] = nifa
+			s.lsdbL2 = newLSDB(s)
+			l := s.lsdbL2
+
+			l.lsps[remoteLSP] = newLSDBEntry(&packet.LSPDU{
+				LSPID:             remoteLSP,
+				RemainingLifetime: 1,
+				SequenceNumber:    5,
+				TLVs: []packet.TLV{
+					packet.NewDynamicHostnameTLV([]byte("remote")),
+				},
+			})
+
+			clock.Advance(time.Second)
+			l.decrementRemainingLifetimes()
+
+			e := l.lsps[remoteLSP]
+			if !assert.NotNil(t, e) {
+				return
+			}
+
+			assert.Equal(t, uint16(0), e.lspdu.RemainingLifetime)
+			assert.Equal(t, uint32(5), e.lspdu.SequenceNumber)
+			assert.Equal(t, test.expected, e.lspdu.TLVs)
+			assert.Equal(t, []*netIfa{nifa}, e.getInterfacesSRMSet(), "purge is flooded")
+		})
+	}
+}
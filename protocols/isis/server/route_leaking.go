@@ -0,0 +1,127 @@
+package server
+
+import (
+	"sort"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// levelEnabled returns if the level is enabled on at least one interface
+func (s *Server) levelEnabled(level int) bool {
+	for _, nifa := range s.netIfaManager.getAllInterfaces() {
+		if nifa.cfg.levelConfig(level) != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// l1l2 returns if we are a level 1 level 2 IS
+func (s *Server) l1l2() bool {
+	return s.lsdbL1 != nil && s.lsdbL2 != nil && s.levelEnabled(1) && s.levelEnabled(2)
+}
+
+// attached returns if we are a level 1 level 2 IS able to reach other areas via level 2.
+// The ATT bit is set in our level 1 LSP in this case unless suppressed by configuration.
+func (s *Server) attached() bool {
+	if !s.l1l2() || s.levelConfigL1.SuppressAttached {
+		return false
+	}
+
+	return s.lsdbL2.spfTree().reachesOtherArea(s.nets)
+}
+
+// getTypeBlock gets the type block of our LSP of a level
+func (s *Server) getTypeBlock(level int) uint8 {
+	if level == 2 || s.l1l2() {
+		typeBlock := uint8(packet.LSPDUISTypeL1L2)
+		if level == 1 && s.attached() {
+			typeBlock |= packet.LSPDUAttachedDefaultMetric
+		}
+
+		return typeBlock
+	}
+
+	return packet.LSPDUISTypeL1
+}
+
+// leakedRoute is a route of the other level advertised into a level
+type leakedRoute struct {
+	pfx    bnet.Prefix
+	metric uint32
+	upDown bool
+	prefixAttributes
+}
+
+// newLeakedRoute creates a leakedRoute for route r. The R-Flag is set while the other prefix attributes advertised
+// by the originator of the prefix are kept (RFC7794).
+func newLeakedRoute(pfx bnet.Prefix, r *spfRoute, upDown bool) leakedRoute {
+	return leakedRoute{
+		pfx:    pfx,
+		metric: r.metric,
+		upDown: upDown,
+		prefixAttributes: prefixAttributes{
+			flags:          r.flags | packet.PrefixAttributeFlagReadvertisement,
+			sourceRouterID: r.sourceRouterID,
+		},
+	}
+}
+
+// getLeakedRoutes gets the routes of the other level to advertise into level. Level 2 routes accepted by the
+// leak policy of level 1 are advertised into level 1 with the up/down bit set and the metric set by the policy. Level 1 routes are advertised
+// into level 2 unless they have the up/down bit set, i.e. have been leaked from level 2 before (RFC5302). The R-Flag
+// is no indication for that as it is also set on prefixes redistributed from other protocols. Leaked routes are
+// advertised with the R-Flag set (RFC7794).
+func (s *Server) getLeakedRoutes(level int) []leakedRoute {
+	ret := make([]leakedRoute, 0)
+	if !s.l1l2() {
+		return ret
+	}
+
+	if level == 1 {
+		policy := s.levelConfigL1.LeakPolicy
+		if policy == nil {
+			return ret
+		}
+
+		for pfx, r := range s.lsdbL2.spf() {
+			pfx := pfx
+			p, reject := policy.Process(&pfx, &route.Path{
+				Type: route.ISISPathType,
+				ISISPath: &route.ISISPath{
+					Metric: r.metric,
+					Level:  2,
+				},
+			})
+			if reject {
+				continue
+			}
+
+			lr := newLeakedRoute(pfx, r, true)
+			lr.metric = p.ISISPath.Metric
+			ret = append(ret, lr)
+		}
+	} else {
+		for pfx, r := range s.lsdbL1.spf() {
+			if r.upDown || r.attached {
+				continue
+			}
+
+			ret = append(ret, newLeakedRoute(pfx, r, false))
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		a, b := ret[i].pfx.Addr(), ret[j].pfx.Addr()
+		if c := a.Compare(&b); c != 0 {
+			return c < 0
+		}
+
+		return ret[i].pfx.Len() < ret[j].pfx.Len()
+	})
+
+	return ret
+}
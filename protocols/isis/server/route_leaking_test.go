@@ -0,0 +1,311 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/net/ethernet"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/filter/actions"
+	btime "github.com/bio-routing/bio-rd/util/time"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	leakTestArea      = types.AreaID{0x49, 0, 1}
+	leakTestOtherArea = types.AreaID{0x49, 0, 2}
+)
+
+// newLeakTestServer creates a server running the given levels on one interface each
+func newLeakTestServer(levels ...int) *Server {
+	s := &Server{
+		nets: []*types.NET{
+			{AFI: leakTestArea[0], AreaID: leakTestArea[1:], SystemID: spfTestSysA},
+		},
+		clock: btime.NewMockClock(time.Unix(1000, 0)),
+	}
+	s.netIfaManager = newNetIfaManager(s)
+	s.lsdbL1 = newLSDB(s)
+	s.lsdbL2 = newLSDB(s)
+
+	for _, level := range levels {
+		cfg := &InterfaceConfig{
+			Name: "eth" + string(rune('0'+level)),
+		}
+
+		if level == 1 {
+			cfg.Level1 = &InterfaceLevelConfig{}
+		} else {
+			cfg.Level2 = &InterfaceLevelConfig{}
+		}
+
+		nifa := &netIfa{
+			name: cfg.Name,
+			srv:  s,
+			cfg:  cfg,
+		}
+		nifa.neighborManagerL1 = newNeighborManager(s, nifa, 1)
+		nifa.neighborManagerL2 = newNeighborManager(s, nifa, 2)
+		s.netIfaManager.netIfas[cfg.Name] = nifa
+	}
+
+	return s
+}
+
+func addLSPs(l *lsdb, lsps ...*packet.LSPDU) {
+	for _, lsp := range lsps {
+		l.lsps[lsp.LSPID] = newLSDBEntry(lsp)
+	}
+}
+
+func areaTLV(area types.AreaID) packet.TLV {
+	return packet.NewAreaAddressesTLV([]types.AreaID{area})
+}
+
+func upDownPrefix(metric uint32, pfx bnet.Prefix) packet.TLV {
+	tlv := widePrefix(metric, pfx).(*packet.ExtendedIPReachabilityTLV)
+	tlv.ExtendedIPReachabilities[0].SetUpDown()
+	return tlv
+}
+
+func TestRouteLeaking(t *testing.T) {
+	pfxL1 := bnet.NewPfx(bnet.IPv4FromOctets(10, 1, 0, 0), 24)
+	pfxLeakedBefore := bnet.NewPfx(bnet.IPv4FromOctets(10, 9, 0, 0), 16)
+	pfxL2Leaked := bnet.NewPfx(bnet.IPv4FromOctets(10, 2, 0, 0), 16)
+	pfxL2 := bnet.NewPfx(bnet.IPv4FromOctets(10, 3, 0, 0), 16)
+
+	s := newLeakTestServer(1, 2)
+	s.levelConfigL1.LeakPolicy = filter.Chain{
+		filter.NewFilter("leak", []*filter.Term{
+			filter.NewTerm("10.2/16", []*filter.TermCondition{
+				filter.NewTermConditionWithRouteFilters(filter.NewRouteFilter(pfxL2Leaked.Ptr(), filter.NewExactMatcher())),
+			}, []actions.Action{actions.NewAcceptAction()}),
+		}),
+		filter.NewDrainFilter(),
+	}
+
+	// Y is a level 1 IS in our area. It advertises a prefix leaked from level 2 by another level 1 level 2 IS.
+	addLSPs(s.lsdbL1,
+		spfTestLSP(spfTestSysA, 0, wideLinks(link(spfTestSysB, 10))),
+		spfTestLSP(spfTestSysB, 0,
+			wideLinks(link(spfTestSysA, 10)),
+			widePrefix(1, pfxL1),
+			upDownPrefix(1, pfxLeakedBefore),
+		),
+	)
+
+	// C is a level 2 IS in another area
+	addLSPs(s.lsdbL2,
+		spfTestLSP(spfTestSysA, 0, areaTLV(leakTestArea), wideLinks(link(spfTestSysC, 20))),
+		spfTestLSP(spfTestSysC, 0,
+			areaTLV(leakTestOtherArea),
+			wideLinks(link(spfTestSysA, 20)),
+			widePrefix(5, pfxL2Leaked),
+			widePrefix(5, pfxL2),
+		),
+	)
+
+	intoL1 := packet.NewExtendedIPReachability(25, 16, 0x0a020000)
+	intoL1.SetUpDown()
+	intoL1.AddSubTLV(packet.NewPrefixAttributeFlagsSubTLV(packet.PrefixAttributeFlagReadvertisement))
+	expectedL1 := packet.NewExtendedIPReachabilityTLV()
+	expectedL1.AddExtendedIPReachability(intoL1)
+	assert.Equal(t, []packet.TLV{expectedL1}, s.getReachabilityTLVs(1), "only level 2 routes accepted by the policy are leaked into level 1 with the up/down bit set")
+	assert.True(t, expectedL1.ExtendedIPReachabilities[0].UpDown())
+
+	intoL2 := packet.NewExtendedIPReachability(11, 24, 0x0a010000)
+	intoL2.AddSubTLV(packet.NewPrefixAttributeFlagsSubTLV(packet.PrefixAttributeFlagReadvertisement))
+	expectedL2 := packet.NewExtendedIPReachabilityTLV()
+	expectedL2.AddExtendedIPReachability(intoL2)
+	assert.Equal(t, []packet.TLV{expectedL2}, s.getReachabilityTLVs(2), "prefixes with the up/down bit set must not be leaked into level 2")
+
+	s.levelConfigL1.LeakPolicy = nil
+	assert.Empty(t, s.getReachabilityTLVs(1), "nothing is leaked into level 1 without policy")
+}
+
+func TestRouteLeakingPolicyMetric(t *testing.T) {
+	pfxL2 := bnet.NewPfx(bnet.IPv4FromOctets(10, 3, 0, 0), 16)
+
+	s := newLeakTestServer(1, 2)
+	s.levelConfigL1.LeakPolicy = filter.Chain{
+		filter.NewFilter("leak", []*filter.Term{
+			filter.NewTerm("metric", []*filter.TermCondition{}, []actions.Action{
+				actions.NewSetISISMetricAction(100),
+				actions.NewAcceptAction(),
+			}),
+		}),
+	}
+
+	addLSPs(s.lsdbL2,
+		spfTestLSP(spfTestSysA, 0, wideLinks(link(spfTestSysC, 20))),
+		spfTestLSP(spfTestSysC, 0, wideLinks(link(spfTestSysA, 20)), widePrefix(5, pfxL2)),
+	)
+
+	leaked := s.getLeakedRoutes(1)
+	if assert.Len(t, leaked, 1) {
+		assert.Equal(t, uint32(100), leaked[0].metric, "metric set by the leak policy")
+	}
+}
+
+func TestRouteLeakingPrefixAttributes(t *testing.T) {
+	pfxNode := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 2), 32)
+	pfxReadvertised := bnet.NewPfx(bnet.IPv4FromOctets(10, 9, 0, 0), 16)
+	routerIDB := bnet.IPv4FromOctets(10, 0, 0, 2)
+
+	// B advertises its loopback with the N-Flag and a prefix redistributed from another protocol
+	node := widePrefix(1, pfxNode).(*packet.ExtendedIPReachabilityTLV)
+	node.ExtendedIPReachabilities[0].AddSubTLV(packet.NewPrefixAttributeFlagsSubTLV(packet.PrefixAttributeFlagNode))
+	node.ExtendedIPReachabilities[0].AddSubTLV(packet.NewSourceRouterIDSubTLV(routerIDB))
+	readvertised := widePrefix(1, pfxReadvertised).(*packet.ExtendedIPReachabilityTLV)
+	readvertised.ExtendedIPReachabilities[0].AddSubTLV(packet.NewPrefixAttributeFlagsSubTLV(packet.PrefixAttributeFlagReadvertisement))
+
+	s := newLeakTestServer(1, 2)
+	addLSPs(s.lsdbL1,
+		spfTestLSP(spfTestSysA, 0, wideLinks(link(spfTestSysB, 10))),
+		spfTestLSP(spfTestSysB, 0, wideLinks(link(spfTestSysA, 10)), node, readvertised),
+	)
+
+	e := packet.NewExtendedIPReachability(11, 32, 0x0a000002)
+	e.AddSubTLV(packet.NewPrefixAttributeFlagsSubTLV(packet.PrefixAttributeFlagNode | packet.PrefixAttributeFlagReadvertisement))
+	e.AddSubTLV(packet.NewSourceRouterIDSubTLV(routerIDB))
+	redistributed := packet.NewExtendedIPReachability(11, 16, 0x0a090000)
+	redistributed.AddSubTLV(packet.NewPrefixAttributeFlagsSubTLV(packet.PrefixAttributeFlagReadvertisement))
+	expected := packet.NewExtendedIPReachabilityTLV()
+	expected.AddExtendedIPReachability(e)
+	expected.AddExtendedIPReachability(redistributed)
+	assert.Equal(t, []packet.TLV{expected}, s.getReachabilityTLVs(2), "N-Flag and source router ID are kept, prefixes with the R-Flag but without the up/down bit set are leaked into level 2")
+}
+
+func TestRouteLeakingNarrow(t *testing.T) {
+	pfxL2 := bnet.NewPfx(bnet.IPv4FromOctets(10, 3, 0, 0), 16)
+
+	s := newLeakTestServer(1, 2)
+	s.levelConfigL1 = LevelConfig{
+		MetricStyle: MetricStyleNarrow,
+		LeakPolicy:  filter.NewAcceptAllFilterChain(),
+	}
+
+	addLSPs(s.lsdbL2,
+		spfTestLSP(spfTestSysA, 0, wideLinks(link(spfTestSysC, 70))),
+		spfTestLSP(spfTestSysC, 0, wideLinks(link(spfTestSysA, 70)), widePrefix(5, pfxL2)),
+	)
+
+	e := packet.NewIPReachability(75, 16, 0x0a030000)
+	e.SetUpDown()
+	expected := packet.NewIPInternalReachabilityTLV()
+	expected.AddIPReachability(e)
+	assert.Equal(t, []packet.TLV{expected}, s.getReachabilityTLVs(1))
+	assert.Equal(t, uint8(0xbf), expected.IPReachabilities[0].DefaultMetric, "metric capped, up/down bit set")
+}
+
+func TestGetTypeBlock(t *testing.T) {
+	tests := []struct {
+		name             string
+		levels           []int
+		remoteArea       types.AreaID
+		suppressAttached bool
+		expectedL1       uint8
+	}{
+		{
+			name:       "Level 1 only",
+			levels:     []int{1},
+			remoteArea: leakTestOtherArea,
+			expectedL1: packet.LSPDUISTypeL1,
+		},
+		{
+			name:       "Level 1 level 2 reaching another area",
+			levels:     []int{1, 2},
+			remoteArea: leakTestOtherArea,
+			expectedL1: packet.LSPDUISTypeL1L2 | packet.LSPDUAttachedDefaultMetric,
+		},
+		{
+			name:             "Level 1 level 2 reaching another area with ATT bit suppressed",
+			levels:           []int{1, 2},
+			remoteArea:       leakTestOtherArea,
+			suppressAttached: true,
+			expectedL1:       packet.LSPDUISTypeL1L2,
+		},
+		{
+			name:       "Level 1 level 2 reaching our area only",
+			levels:     []int{1, 2},
+			remoteArea: leakTestArea,
+			expectedL1: packet.LSPDUISTypeL1L2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := newLeakTestServer(test.levels...)
+			s.levelConfigL1.SuppressAttached = test.suppressAttached
+			addLSPs(s.lsdbL2,
+				spfTestLSP(spfTestSysA, 0, wideLinks(link(spfTestSysC, 20))),
+				spfTestLSP(spfTestSysC, 0, areaTLV(test.remoteArea), wideLinks(link(spfTestSysA, 20))),
+			)
+
+			assert.Equal(t, test.expectedL1, s.getTypeBlock(1))
+			assert.Equal(t, uint8(packet.LSPDUISTypeL1L2), s.getTypeBlock(2))
+		})
+	}
+}
+
+func TestSPFAttachedDefaultRoute(t *testing.T) {
+	attachedLSP := func(sysID types.SystemID, tlvs ...packet.TLV) *packet.LSPDU {
+		lsp := spfTestLSP(sysID, 0, tlvs...)
+		lsp.TypeBlock = packet.LSPDUISTypeL1L2 | packet.LSPDUAttachedDefaultMetric
+		return lsp
+	}
+
+	lsps := []*packet.LSPDU{
+		spfTestLSP(spfTestSysA, 0, wideLinks(link(spfTestSysB, 10), link(spfTestSysC, 5))),
+		attachedLSP(spfTestSysB, wideLinks(link(spfTestSysA, 10))),
+		attachedLSP(spfTestSysD, wideLinks(link(spfTestSysC, 10))),
+		spfTestLSP(spfTestSysC, 0, wideLinks(link(spfTestSysA, 5), link(spfTestSysD, 10))),
+	}
+	dflt := bnet.NewPfx(bnet.IPv4(0), 0)
+
+	s := newLeakTestServer(1)
+	addLSPs(s.lsdbL1, lsps...)
+	assert.Equal(t, map[bnet.Prefix]*spfRoute{
+		dflt: {metric: 10, nextHops: []types.SystemID{spfTestSysB}, attached: true},
+	}, s.lsdbL1.spf(), "default route towards the closest attached IS")
+
+	s = newLeakTestServer(1, 2)
+	addLSPs(s.lsdbL1, lsps...)
+	assert.Empty(t, s.lsdbL1.spf(), "level 1 level 2 ISs do not install a default route")
+
+	s = newLeakTestServer(1)
+	addLSPs(s.lsdbL1, lsps...)
+	addLSPs(s.lsdbL1, spfTestLSP(spfTestSysC, 0, wideLinks(link(spfTestSysA, 5), link(spfTestSysD, 10)), widePrefix(50, dflt)))
+	assert.Equal(t, map[bnet.Prefix]*spfRoute{
+		dflt: {metric: 55, nextHops: []types.SystemID{spfTestSysC}},
+	}, s.lsdbL1.spf(), "advertised default routes take precedence")
+}
+
+func TestAdjacencyChangedSetsAttachedBit(t *testing.T) {
+	for _, suppress := range []bool{false, true} {
+		s := newLeakTestServer(1, 2)
+		s.lspLifetime = 1200
+		s.levelConfigL1.SuppressAttached = suppress
+		s.levelConfigL2.MetricStyle = MetricStyleWide
+
+		n := &neighbor{
+			sysID: spfTestSysC,
+			nm:    s.netIfaManager.netIfas["eth2"].neighborManagerL2,
+			state: packet.P2PAdjStateUp,
+		}
+		n.nm.neighbors[ethernet.MACAddr{3}] = n
+		addLSPs(s.lsdbL2, spfTestLSP(spfTestSysC, 0, areaTLV(leakTestOtherArea), wideLinks(link(spfTestSysA, 20))))
+
+		s.adjacencyChanged(2)
+
+		own := s.lsdbL1.lsps[packet.LSPID{SystemID: spfTestSysA}]
+		if !assert.NotNil(t, own, "level 1 LSP must be reoriginated") {
+			continue
+		}
+
+		assert.Equal(t, !suppress, own.lspdu.Attached(), "suppressed=%v", suppress)
+	}
+}
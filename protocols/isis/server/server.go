@@ -9,6 +9,7 @@ import (
 	"github.com/bio-routing/bio-rd/net/ethernet"
 	"github.com/bio-routing/bio-rd/protocols/device"
 	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
 	btime "github.com/bio-routing/bio-rd/util/time"
 )
 
@@ -21,10 +22,15 @@ const (
 type ISISServer interface {
 	AddInterface(*InterfaceConfig) error
 	RemoveInterface(name string) error
+	UpdateInterfaceTE(name string, te *InterfaceTEConfig) error
 	GetInterfaceNames() []string
 	Start() error
 	GetAdjacencies() []*Adjacency
 	GetLSDB() []*LSDBEntry
+	LSDBLevel() int
+	GetCounters() Counters
+	GetInterfaceCounters() []*InterfaceCounters
+	GetRoutes(level int) []*Route
 }
 
 // Server represents an ISIS server
@@ -32,7 +38,15 @@ type Server struct {
 	running            bool
 	runningMu          sync.Mutex
 	nets               []*types.NET
+	hostname           string
+	hostnames          *hostnameMap
+	routerID           *bnet.IP
+	segmentRouting     *SegmentRoutingConfig
 	lspLifetime        uint16
+	lspRefreshInterval uint16
+	zeroAgeLifetime    uint16
+	levelConfigL1      LevelConfig
+	levelConfigL2      LevelConfig
 	sequenceNumberL1   uint32
 	sequenceNumberL1Mu sync.Mutex
 	sequenceNumberL2   uint32
@@ -42,6 +56,92 @@ type Server struct {
 	lsdbL2             *lsdb
 	stop               chan struct{}
 	ds                 device.Updater
+	clock              btime.Clock
+	counters           counters
+}
+
+// LevelConfig is the ISIS config of a level
+type LevelConfig struct {
+	// Disabled turns the level off for single level operation. No LSDB is kept for the level and no hellos, LSPs or
+	// SNPs of the level are sent or processed on any interface.
+	Disabled bool
+
+	MetricStyle MetricStyle
+
+	// LeakPolicy selects the level 2 routes leaked into level 1 by level 1 level 2 ISs and may set their metric.
+	// Nothing is leaked if nil. It is only used in the level 1 config.
+	LeakPolicy filter.Chain
+
+	// ExportPolicy filters the IP prefixes advertised in our LSP of the level and may set their metric and
+	// administrative tag. Tags are only advertised using wide metrics. All prefixes are advertised if nil.
+	ExportPolicy filter.Chain
+
+	// SuppressAttached keeps a level 1 level 2 IS from setting the ATT bit in its level 1 LSP.
+	// It is only used in the level 1 config.
+	SuppressAttached bool
+
+	// Keychain authenticates the PDUs of the level using cleartext passwords in the Authentication TLV. PDUs are not
+	// authenticated if nil.
+	Keychain *Keychain
+
+	// NoHelloAuthentication, NoCSNPAuthentication and NoPSNPAuthentication exclude hellos, CSNPs and PSNPs from authentication
+	NoHelloAuthentication bool
+	NoCSNPAuthentication  bool
+	NoPSNPAuthentication  bool
+
+	// NoPurgeOriginatorIdentification omits the purge originator identification TLV (RFC6232) from purges we generate
+	NoPurgeOriginatorIdentification bool
+}
+
+// LSPTimers are the timers controlling the refresh of our LSPs and the aging of the LSDB
+type LSPTimers struct {
+	// RefreshInterval is the number of seconds after which our LSPs are reoriginated. It has to be shorter than the
+	// lifetime of our LSPs. Defaults to three quarters of the LSP lifetime.
+	RefreshInterval uint16
+
+	// ZeroAgeLifetime is the number of seconds a purge is kept in the LSDB after an LSP expired. Defaults to 60.
+	ZeroAgeLifetime uint16
+}
+
+// SegmentRoutingConfig is the Segment Routing (RFC8667) config advertised in the Router Capability TLV of our LSPs
+type SegmentRoutingConfig struct {
+	// SRGBBase is the first MPLS label of the Segment Routing Global Block
+	SRGBBase uint32
+
+	// SRGBRange is the number of labels of the Segment Routing Global Block
+	SRGBRange uint32
+}
+
+func (s *Server) levelLSDB(level int) *lsdb {
+	if level == 1 {
+		return s.lsdbL1
+	}
+
+	return s.lsdbL2
+}
+
+func (s *Server) levelConfig(level int) *LevelConfig {
+	if level == 1 {
+		return &s.levelConfigL1
+	}
+
+	return &s.levelConfigL2
+}
+
+// adjacencyChanged reoriginates our LSP of a level and reruns the SPF after an adjacency changed state
+func (s *Server) adjacencyChanged(level int) {
+	l := s.levelLSDB(level)
+	if l == nil {
+		return
+	}
+
+	s.originateLSP(level)
+	l.runSPF()
+
+	// The ATT bit and the leaked routes of our level 1 LSP depend on the level 2 topology
+	if level == 2 && s.l1l2() {
+		s.originateLSP(1)
+	}
 }
 
 // Start starts the ISIS server
@@ -53,11 +153,17 @@ func (s *Server) Start() error {
 		s.running = true
 	}
 
-	decrementTicker := btime.NewBIOTicker(time.Second)
-	minLSPTransTicker := btime.NewBIOTicker(minimumLSPTransmissionInterval)
-	psnpTransTicker := btime.NewBIOTicker(time.Second * 5)
-	csnpTransTicker := btime.NewBIOTicker(csnpTransmissionInterval)
-	s.lsdbL2.start(decrementTicker, minLSPTransTicker, psnpTransTicker, csnpTransTicker)
+	for _, l := range []*lsdb{s.lsdbL1, s.lsdbL2} {
+		if l == nil {
+			continue
+		}
+
+		decrementTicker := btime.NewBIOTicker(time.Second)
+		minLSPTransTicker := btime.NewBIOTicker(minimumLSPTransmissionInterval)
+		psnpTransTicker := btime.NewBIOTicker(time.Second * 5)
+		csnpTransTicker := btime.NewBIOTicker(csnpTransmissionInterval)
+		l.start(decrementTicker, minLSPTransTicker, psnpTransTicker, csnpTransTicker)
+	}
 
 	return nil
 }
@@ -79,49 +185,165 @@ func (s *Server) GetAdjacencies() []*Adjacency {
 	ret := make([]*Adjacency, 0)
 
 	for _, ifa := range s.netIfaManager.getAllInterfaces() {
-		for _, n := range ifa.neighborManagerL2.getNeighbors() {
-			ret = append(ret, n.getAdjacency())
+		for _, level := range []int{1, 2} {
+			nm := ifa.neighborManager(level)
+			if nm == nil {
+				continue
+			}
+
+			for _, n := range nm.getNeighbors() {
+				ret = append(ret, n.getAdjacency())
+			}
 		}
 	}
 
 	return ret
 }
 
+// LSDBLevel gets the level of the LSDB returned by GetLSDB
+func (s *Server) LSDBLevel() int {
+	if s.lsdbL2 == nil {
+		return 1
+	}
+
+	return 2
+}
+
+// GetLSDB gets the level 2 LSDB or the level 1 LSDB if level 2 is disabled
 func (s *Server) GetLSDB() []*LSDBEntry {
-	s.lsdbL2.lspsMu.RLock()
-	defer s.lsdbL2.lspsMu.RUnlock()
+	l := s.levelLSDB(s.LSDBLevel())
+
+	l.lspsMu.RLock()
+	defer l.lspsMu.RUnlock()
 
 	ret := make([]*LSDBEntry, 0)
-	for _, lspEntry := range s.lsdbL2.lsps {
-		ret = append(ret, lspEntry.Export())
+	for lspID, lspEntry := range l.lsps {
+		e := lspEntry.Export()
+		e.hostname, _ = s.hostnames.get(lspID.SystemID)
+		ret = append(ret, e)
 	}
 
 	return ret
 }
 
-// New creates a new ISIS server
-func New(nets []*types.NET, ds device.Updater, lspLifetime uint16) (*Server, error) {
-	if len(nets) == 0 {
-		return nil, fmt.Errorf("No NETs given. One is minimum")
+// New creates a new ISIS server. hostname is advertised in the dynamic hostname TLV if not empty.
+// Defaults are used for levels without config (nil). At most one of the levels can be disabled.
+func New(nets []*types.NET, ds device.Updater, lspLifetime uint16, hostname string, level1 *LevelConfig, level2 *LevelConfig) (*Server, error) {
+	err := validateNETs(nets)
+	if err != nil {
+		return nil, err
 	}
 
-	if !netsCompatible(nets) {
-		return nil, fmt.Errorf("Incompatible NETs. System IDs must be equal")
+	if level1 != nil && level1.Disabled && level2 != nil && level2.Disabled {
+		return nil, fmt.Errorf("level 1 and level 2 must not both be disabled")
 	}
 
 	s := &Server{
 		nets:        nets,
+		hostname:    hostname,
+		hostnames:   newHostnameMap(nets[0].SystemID, hostname),
 		lspLifetime: lspLifetime,
 		ds:          ds,
 		stop:        make(chan struct{}),
+		clock:       btime.NewBIOClock(),
+	}
+
+	if level1 != nil {
+		s.levelConfigL1 = *level1
+	}
+
+	if level2 != nil {
+		s.levelConfigL2 = *level2
 	}
 
 	s.netIfaManager = newNetIfaManager(s)
-	s.lsdbL2 = newLSDB(s)
+	if !s.levelConfigL1.Disabled {
+		s.lsdbL1 = newLSDB(s)
+	}
+
+	if !s.levelConfigL2.Disabled {
+		s.lsdbL2 = newLSDB(s)
+	}
 
 	return s, nil
 }
 
+// SetRouterID sets the router ID advertised as source router ID of our host prefixes (RFC7794). It has to be
+// called before Start.
+func (s *Server) SetRouterID(routerID bnet.IP) {
+	s.routerID = routerID.Dedup()
+}
+
+// SetSegmentRouting enables the advertisement of our Segment Routing capabilities. It has to be called after
+// SetRouterID and before Start as the router capability TLV carries our router ID.
+func (s *Server) SetSegmentRouting(cfg *SegmentRoutingConfig) error {
+	if s.routerID == nil || s.routerID.ToUint32() == 0 {
+		return fmt.Errorf("segment routing requires a router ID")
+	}
+
+	s.segmentRouting = cfg
+	return nil
+}
+
+// SetLSPTimers sets the refresh interval of our LSPs and the zero age lifetime of purges. Unset (0) timers keep their
+// default. It has to be called before Start.
+func (s *Server) SetLSPTimers(t *LSPTimers) error {
+	if t.RefreshInterval != 0 && t.RefreshInterval >= s.lspLifetime {
+		return fmt.Errorf("LSP refresh interval %d must be shorter than the LSP lifetime %d", t.RefreshInterval, s.lspLifetime)
+	}
+
+	s.lspRefreshInterval = t.RefreshInterval
+	s.zeroAgeLifetime = t.ZeroAgeLifetime
+	return nil
+}
+
+// getLSPRefreshInterval gets the number of seconds after which our LSPs are reoriginated
+func (s *Server) getLSPRefreshInterval() uint16 {
+	if s.lspRefreshInterval != 0 {
+		return s.lspRefreshInterval
+	}
+
+	return s.lspLifetime - s.lspLifetime/4
+}
+
+// getZeroAgeLifetime gets the number of seconds a purge is kept in the LSDB after an LSP expired
+func (s *Server) getZeroAgeLifetime() uint16 {
+	if s.zeroAgeLifetime != 0 {
+		return s.zeroAgeLifetime
+	}
+
+	return defaultZeroAgeLifetime
+}
+
+// validateNETs checks that nets are usable as the NETs of a single IS
+func validateNETs(nets []*types.NET) error {
+	if len(nets) == 0 {
+		return fmt.Errorf("No NETs given. One is minimum")
+	}
+
+	if len(nets) > types.MaxAreaAddresses {
+		return fmt.Errorf("Too many NETs. At most %d area addresses are supported", types.MaxAreaAddresses)
+	}
+
+	if !netsCompatible(nets) {
+		return fmt.Errorf("Incompatible NETs. System IDs must be equal")
+	}
+
+	for i, net := range nets {
+		if net.SEL != 0 {
+			return fmt.Errorf("Invalid NET %s. Selector must be 00", net)
+		}
+
+		for _, other := range nets[:i] {
+			if net.AreaAddress().Equal(other.AreaAddress()) {
+				return fmt.Errorf("Duplicate area address in NETs %s and %s", other, net)
+			}
+		}
+	}
+
+	return nil
+}
+
 // netsCompatible verifies if the system id is equal in all NETs
 func netsCompatible(nets []*types.NET) bool {
 	first := nets[0].SystemID
@@ -2,8 +2,13 @@ package server
 
 import (
 	"testing"
+	"time"
 
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/net/ethernet"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
 	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	btime "github.com/bio-routing/bio-rd/util/time"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -43,3 +48,237 @@ func TestNETsCompatible(t *testing.T) {
 		assert.Equalf(t, test.expected, netsCompatible(test.input), test.name)
 	}
 }
+
+func TestValidateNETs(t *testing.T) {
+	net := func(s string) *types.NET {
+		n, err := types.ParseNETString(s)
+		if err != nil {
+			t.Fatalf("unable to parse NET %q: %v", s, err)
+		}
+
+		return n
+	}
+
+	tests := []struct {
+		name     string
+		input    []*types.NET
+		wantFail bool
+	}{
+		{
+			name:     "No NETs",
+			input:    []*types.NET{},
+			wantFail: true,
+		},
+		{
+			name: "Three areas",
+			input: []*types.NET{
+				net("49.0001.0100.0000.0001.00"),
+				net("49.0002.0100.0000.0001.00"),
+				net("39.0001.0100.0000.0001.00"),
+			},
+		},
+		{
+			name: "Too many areas",
+			input: []*types.NET{
+				net("49.0001.0100.0000.0001.00"),
+				net("49.0002.0100.0000.0001.00"),
+				net("49.0003.0100.0000.0001.00"),
+				net("49.0004.0100.0000.0001.00"),
+			},
+			wantFail: true,
+		},
+		{
+			name: "Different system IDs",
+			input: []*types.NET{
+				net("49.0001.0100.0000.0001.00"),
+				net("49.0002.0100.0000.0002.00"),
+			},
+			wantFail: true,
+		},
+		{
+			name: "Duplicate area",
+			input: []*types.NET{
+				net("49.0001.0100.0000.0001.00"),
+				net("49.0001.0100.0000.0001.00"),
+			},
+			wantFail: true,
+		},
+		{
+			name: "Selector not 00",
+			input: []*types.NET{
+				{
+					AFI:      0x49,
+					SystemID: types.SystemID{1, 0, 0, 0, 0, 1},
+					SEL:      1,
+				},
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		err := validateNETs(test.input)
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		assert.NoError(t, err, test.name)
+	}
+}
+
+func TestGetAreaAddressesTLVMultiArea(t *testing.T) {
+	nets := make([]*types.NET, 0)
+	for _, s := range []string{"49.0001.0100.0000.0001.00", "49.0002.0100.0000.0001.00", "39.0100.0000.0001.00"} {
+		n, err := types.ParseNETString(s)
+		if err != nil {
+			t.Fatalf("unable to parse NET %q: %v", s, err)
+		}
+
+		nets = append(nets, n)
+	}
+
+	s := &Server{
+		nets: nets,
+	}
+
+	assert.Equal(t, packet.NewAreaAddressesTLV([]types.AreaID{
+		{0x49, 0x00, 0x01},
+		{0x49, 0x00, 0x02},
+		{0x39},
+	}), s.getAreaAddressesTLV())
+}
+
+func TestNewLevelsDisabled(t *testing.T) {
+	nets := []*types.NET{
+		{AFI: leakTestArea[0], AreaID: leakTestArea[1:], SystemID: spfTestSysA},
+	}
+
+	_, err := New(nets, newMockDeviceUpdater(), 1200, "", &LevelConfig{Disabled: true}, &LevelConfig{Disabled: true})
+	assert.Error(t, err, "both levels disabled")
+
+	s, err := New(nets, newMockDeviceUpdater(), 1200, "", &LevelConfig{Disabled: true}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Nil(t, s.lsdbL1)
+	assert.NotNil(t, s.lsdbL2)
+	assert.Equal(t, 2, s.LSDBLevel())
+
+	s, err = New(nets, newMockDeviceUpdater(), 1200, "", nil, &LevelConfig{Disabled: true})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Nil(t, s.lsdbL2)
+	assert.Equal(t, 1, s.LSDBLevel(), "level 1 LSDB exported if level 2 is disabled")
+}
+
+func TestLevel2Only(t *testing.T) {
+	nets := []*types.NET{
+		{AFI: leakTestArea[0], AreaID: leakTestArea[1:], SystemID: spfTestSysA},
+	}
+
+	s, err := New(nets, newMockDeviceUpdater(), 1200, "", &LevelConfig{Disabled: true}, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	s.clock = btime.NewMockClock(time.Unix(1000, 0))
+	s.netIfaManager.useMockTicker = true
+
+	err = s.AddInterface(&InterfaceConfig{
+		Name:   "eth1",
+		Level1: &InterfaceLevelConfig{},
+	})
+	assert.Error(t, err, "interface with level 1 only")
+
+	err = s.AddInterface(&InterfaceConfig{
+		Name:   "eth0",
+		Level1: &InterfaceLevelConfig{},
+		Level2: &InterfaceLevelConfig{},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	nifa := s.netIfaManager.getInterface("eth0")
+	nifa.devStatus = &mockDevice{
+		addrs: []*bnet.Prefix{
+			bnet.NewPfx(bnet.IPv4(110), 31).Ptr(),
+		},
+	}
+	assert.Nil(t, nifa.neighborManagerL1)
+	assert.Equal(t, uint8(types.CircuitTypeL2), nifa.p2pHello().CircuitType, "no level 1 hellos")
+
+	protocols := packet.NewProtocolsSupportedTLV([]uint8{packet.NLPIDIPv4, packet.NLPIDIPv6})
+	hello := &packet.P2PHello{
+		CircuitType:  types.CircuitTypeL1L2,
+		SystemID:     spfTestSysB,
+		HoldingTimer: 27,
+		TLVs: []packet.TLV{
+			packet.NewP2PAdjacencyStateTLV(packet.P2PAdjStateDown, 1),
+			&protocols,
+			packet.NewIPInterfaceAddressesTLV([]uint32{111}),
+			areaTLV(leakTestArea),
+		},
+	}
+	assert.NoError(t, nifa.processP2PHello(ethernet.MACAddr{2}, hello))
+
+	adjacencies := s.GetAdjacencies()
+	if assert.Len(t, adjacencies, 1) {
+		assert.Equal(t, uint8(2), adjacencies[0].Level, "no level 1 adjacency")
+	}
+
+	err = nifa.validatePkt(ethernet.MACAddr{2}, &packet.ISISPacket{
+		Header: &packet.ISISHeader{PDUType: packet.L1_LS_PDU_TYPE},
+	})
+	assert.Error(t, err, "level 1 LSPs must not be processed")
+
+	s.adjacencyChanged(1)
+	s.adjacencyChanged(2)
+	assert.Nil(t, s.lsdbL1, "no level 1 LSDB")
+	assert.Contains(t, s.lsdbL2.lsps, packet.LSPID{SystemID: spfTestSysA}, "our level 2 LSP")
+	assert.Len(t, s.GetLSDB(), 1)
+
+	for _, n := range nifa.neighborManagerL2.getNeighbors() {
+		n.dispose()
+	}
+}
+
+func TestUpdateInterfaceTE(t *testing.T) {
+	nets := []*types.NET{
+		{AFI: leakTestArea[0], AreaID: leakTestArea[1:], SystemID: spfTestSysA},
+	}
+
+	s, err := New(nets, newMockDeviceUpdater(), 1200, "", nil, nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	s.clock = btime.NewMockClock(time.Unix(1000, 0))
+	s.netIfaManager.useMockTicker = true
+
+	err = s.AddInterface(&InterfaceConfig{
+		Name:   "eth0",
+		Level2: &InterfaceLevelConfig{},
+		TE: &InterfaceTEConfig{
+			AdminGroup: 1,
+		},
+	})
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Error(t, s.UpdateInterfaceTE("eth1", nil), "unknown interface")
+
+	ownLSP := packet.LSPID{SystemID: spfTestSysA}
+	delete(s.lsdbL2.lsps, ownLSP)
+	assert.NoError(t, s.UpdateInterfaceTE("eth0", &InterfaceTEConfig{AdminGroup: 1}))
+	assert.NotContains(t, s.lsdbL2.lsps, ownLSP, "TE config unchanged")
+
+	assert.NoError(t, s.UpdateInterfaceTE("eth0", &InterfaceTEConfig{AdminGroup: 2}))
+	assert.Contains(t, s.lsdbL2.lsps, ownLSP, "LSP reoriginated")
+	assert.Equal(t, uint32(2), s.netIfaManager.getInterface("eth0").cfg.TE.AdminGroup)
+}
@@ -0,0 +1,493 @@
+package server
+
+import (
+	"bytes"
+	"sort"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+)
+
+// spfRoute is a route to a prefix computed by SPF
+type spfRoute struct {
+	metric   uint32
+	nextHops []types.SystemID
+
+	// upDown is set if the prefix has been leaked from level 2 into level 1
+	upDown bool
+
+	// attached is set for the default route towards the closest attached level 1 level 2 IS
+	attached bool
+
+	prefixAttributes
+}
+
+// prefixAttributes are the attributes of a prefix advertised using the Prefix Attribute Flags and Source Router ID
+// sub TLVs (RFC7794). They are only available for prefixes advertised using wide metrics.
+type prefixAttributes struct {
+	flags          uint8
+	sourceRouterID *bnet.IP
+}
+
+// spfPrefix is a prefix advertised by an IS
+type spfPrefix struct {
+	metric uint32
+	upDown bool
+	prefixAttributes
+}
+
+// better returns if p is preferred over q. Prefixes not leaked from level 2 are preferred regardless of their metric (RFC5302).
+func (p spfPrefix) better(q spfPrefix) bool {
+	if p.upDown != q.upDown {
+		return !p.upDown
+	}
+
+	return p.metric < q.metric
+}
+
+// spfNode is the topology and prefix information of all LSP fragments of a system or pseudonode
+type spfNode struct {
+	narrowLinks    map[types.SourceID]uint32
+	wideLinks      map[types.SourceID]uint32
+	narrowPrefixes map[bnet.Prefix]spfPrefix
+	widePrefixes   map[bnet.Prefix]spfPrefix
+	areas          []types.AreaID
+	attached       bool
+}
+
+func newSPFNode() *spfNode {
+	return &spfNode{
+		narrowLinks:    make(map[types.SourceID]uint32),
+		wideLinks:      make(map[types.SourceID]uint32),
+		narrowPrefixes: make(map[bnet.Prefix]spfPrefix),
+		widePrefixes:   make(map[bnet.Prefix]spfPrefix),
+	}
+}
+
+func addMinLink(links map[types.SourceID]uint32, id types.SourceID, metric uint32) {
+	if m, exists := links[id]; !exists || metric < m {
+		links[id] = metric
+	}
+}
+
+func addBestPrefix(pfxs map[bnet.Prefix]spfPrefix, pfx bnet.Prefix, p spfPrefix) {
+	if x, exists := pfxs[pfx]; !exists || p.better(x) {
+		pfxs[pfx] = p
+	}
+}
+
+func (n *spfNode) addLSPDU(lspdu *packet.LSPDU, metricStyle MetricStyle) {
+	if lspdu.LSPID.LSPNumber == 0 {
+		n.attached = lspdu.Attached()
+	}
+
+	for _, tlv := range lspdu.TLVs {
+		switch t := tlv.(type) {
+		case *packet.AreaAddressesTLV:
+			n.areas = append(n.areas, t.AreaIDs...)
+		case *packet.ISReachabilityTLV:
+			if !metricStyle.narrow() {
+				continue
+			}
+
+			for i := range t.Neighbors {
+				addMinLink(n.narrowLinks, t.Neighbors[i].NeighborID, uint32(t.Neighbors[i].Metric()))
+			}
+		case *packet.ExtendedISReachabilityTLV:
+			if !metricStyle.wide() {
+				continue
+			}
+
+			for _, nb := range t.Neighbors {
+				addMinLink(n.wideLinks, nb.NeighborID, nb.Metric)
+			}
+		case *packet.IPInternalReachabilityTLV:
+			if !metricStyle.narrow() {
+				continue
+			}
+
+			for i := range t.IPReachabilities {
+				r := &t.IPReachabilities[i]
+				addBestPrefix(n.narrowPrefixes, bnet.NewPfx(bnet.IPv4(r.Address), r.PfxLen()), spfPrefix{
+					metric: uint32(r.Metric()),
+					upDown: r.UpDown(),
+				})
+			}
+		case *packet.ExtendedIPReachabilityTLV:
+			if !metricStyle.wide() {
+				continue
+			}
+
+			for _, r := range t.ExtendedIPReachabilities {
+				addBestPrefix(n.widePrefixes, bnet.NewPfx(bnet.IPv4(r.Address), r.PfxLen()), spfPrefix{
+					metric: r.Metric,
+					upDown: r.UpDown(),
+					prefixAttributes: prefixAttributes{
+						flags:          r.PrefixAttributeFlags(),
+						sourceRouterID: r.SourceRouterID(),
+					},
+				})
+			}
+		}
+	}
+}
+
+// links returns the adjacencies of the node. Wide metrics take precedence over narrow ones.
+func (n *spfNode) links() map[types.SourceID]uint32 {
+	ret := make(map[types.SourceID]uint32, len(n.wideLinks))
+	for id, m := range n.narrowLinks {
+		if _, exists := n.wideLinks[id]; !exists {
+			ret[id] = m
+		}
+	}
+
+	for id, m := range n.wideLinks {
+		ret[id] = m
+	}
+
+	return ret
+}
+
+// prefixes returns the prefixes reachable via the node. Wide metrics take precedence over narrow ones.
+func (n *spfNode) prefixes() map[bnet.Prefix]spfPrefix {
+	ret := make(map[bnet.Prefix]spfPrefix, len(n.widePrefixes))
+	for pfx, p := range n.narrowPrefixes {
+		if _, exists := n.widePrefixes[pfx]; !exists {
+			ret[pfx] = p
+		}
+	}
+
+	for pfx, p := range n.widePrefixes {
+		ret[pfx] = p
+	}
+
+	return ret
+}
+
+// prefix returns prefix pfx if reachable via the node. Wide metrics take precedence over narrow ones.
+func (n *spfNode) prefix(pfx bnet.Prefix) (spfPrefix, bool) {
+	if p, exists := n.widePrefixes[pfx]; exists {
+		return p, true
+	}
+
+	p, exists := n.narrowPrefixes[pfx]
+	return p, exists
+}
+
+// spfNodes collects the topology of all valid LSPs in the LSDB in the metric style of the level
+func (l *lsdb) spfNodes() map[types.SourceID]*spfNode {
+	metricStyle := l.srv.levelConfig(l.level()).MetricStyle
+
+	l.lspsMu.RLock()
+	defer l.lspsMu.RUnlock()
+
+	nodes := make(map[types.SourceID]*spfNode)
+	for lspID, e := range l.lsps {
+		if !spfUsable(e.lspdu) {
+			continue
+		}
+
+		id := types.NewSourceID(lspID.SystemID, lspID.PseudonodeID)
+		if _, exists := nodes[id]; !exists {
+			nodes[id] = newSPFNode()
+		}
+
+		nodes[id].addLSPDU(e.lspdu, metricStyle)
+	}
+
+	return nodes
+}
+
+// spfTree is the result of the shortest path computation
+type spfTree struct {
+	root     types.SourceID
+	nodes    map[types.SourceID]*spfNode
+	dist     map[types.SourceID]uint32
+	nextHops map[types.SourceID][]types.SystemID
+}
+
+// spfTree computes the shortest paths to all ISs in the LSDB (ISO 10589 Annex C.2).
+// Links are only used if both ends report each other.
+func (l *lsdb) spfTree() *spfTree {
+	nodes := l.spfNodes()
+	links := make(map[types.SourceID]map[types.SourceID]uint32, len(nodes))
+	for id, n := range nodes {
+		links[id] = n.links()
+	}
+
+	root := types.NewSourceID(l.srv.nets[0].SystemID, 0)
+	dist := map[types.SourceID]uint32{root: 0}
+	nextHops := map[types.SourceID][]types.SystemID{root: nil}
+	done := make(map[types.SourceID]struct{})
+
+	for {
+		u, found := closestTentative(dist, done)
+		if !found {
+			break
+		}
+
+		done[u] = struct{}{}
+		for v, metric := range links[u] {
+			if _, twoWay := links[v][u]; !twoWay {
+				continue
+			}
+
+			if _, isDone := done[v]; isDone {
+				continue
+			}
+
+			nh := nextHops[u]
+			if len(nh) == 0 && v.CircuitID == 0 {
+				// v is adjacent to the root, directly or via a pseudonode
+				nh = []types.SystemID{v.SystemID}
+			}
+
+			d, reached := dist[v]
+			if !reached || dist[u]+metric < d {
+				dist[v] = dist[u] + metric
+				nextHops[v] = nh
+			} else if dist[u]+metric == d {
+				nextHops[v] = mergeNextHops(nextHops[v], nh)
+			}
+		}
+	}
+
+	return &spfTree{
+		root:     root,
+		nodes:    nodes,
+		dist:     dist,
+		nextHops: nextHops,
+	}
+}
+
+// spf computes the routes to all prefixes known in the LSDB. Prefixes of the local system are not included.
+// Level 1 only ISs add a default route towards the closest attached level 1 level 2 IS.
+func (l *lsdb) spf() map[bnet.Prefix]*spfRoute {
+	_, routes := l.spfWithTree()
+	return routes
+}
+
+// spfWithTree computes the routes like spf and returns the shortest path tree they are based on
+func (l *lsdb) spfWithTree() (*spfTree, map[bnet.Prefix]*spfRoute) {
+	t := l.spfTree()
+
+	routes := make(map[bnet.Prefix]*spfRoute)
+	best := make(map[bnet.Prefix]spfPrefix)
+	for id, d := range t.dist {
+		if id == t.root {
+			continue
+		}
+
+		for pfx, p := range t.nodes[id].prefixes() {
+			t.addPrefixRoute(routes, best, id, pfx, p, d)
+		}
+	}
+
+	if l.level() == 1 && !l.srv.l1l2() {
+		t.addAttachedDefaultRoute(routes)
+	}
+
+	return t, routes
+}
+
+// addPrefixRoute adds the route to prefix pfx advertised by node id at distance d if it is better than or equal to the best known one
+func (t *spfTree) addPrefixRoute(routes map[bnet.Prefix]*spfRoute, best map[bnet.Prefix]spfPrefix, id types.SourceID, pfx bnet.Prefix, p spfPrefix, d uint32) {
+	p.metric += d
+	r, exists := routes[pfx]
+	if !exists || p.better(best[pfx]) {
+		best[pfx] = p
+		routes[pfx] = &spfRoute{
+			metric:           p.metric,
+			nextHops:         t.nextHops[id],
+			upDown:           p.upDown,
+			prefixAttributes: p.prefixAttributes,
+		}
+	} else if !best[pfx].better(p) {
+		r.nextHops = mergeNextHops(r.nextHops, t.nextHops[id])
+	}
+}
+
+// runSPF recomputes the routes of the level
+func (l *lsdb) runSPF() {
+	t, routes := l.spfWithTree()
+
+	l.routesMu.Lock()
+	defer l.routesMu.Unlock()
+
+	l.tree = t
+	l.routes = routes
+	l.spfRuns++
+}
+
+// getRoutes gets the routes computed by the last SPF run
+func (l *lsdb) getRoutes() map[bnet.Prefix]*spfRoute {
+	l.routesMu.RLock()
+	defer l.routesMu.RUnlock()
+
+	return l.routes
+}
+
+// Route is a route computed by the SPF of a level
+type Route struct {
+	Prefix   bnet.Prefix
+	Metric   uint32
+	NextHops []*RouteNextHop
+
+	// UpDown is set if the prefix has been leaked from level 2 into level 1
+	UpDown bool
+
+	// Attached is set for the default route towards the closest attached level 1 level 2 IS
+	Attached bool
+}
+
+// RouteNextHop is a neighbor a route points to
+type RouteNextHop struct {
+	SystemID types.SystemID
+
+	// Hostname is the dynamic hostname of the neighbor. It is empty if unknown.
+	Hostname string
+}
+
+// GetRoutes gets the routes computed by the last SPF run of a level sorted by prefix
+func (s *Server) GetRoutes(level int) []*Route {
+	l := s.levelLSDB(level)
+	if l == nil {
+		return nil
+	}
+
+	routes := l.getRoutes()
+	ret := make([]*Route, 0, len(routes))
+	for pfx, r := range routes {
+		rt := &Route{
+			Prefix:   pfx,
+			Metric:   r.metric,
+			NextHops: make([]*RouteNextHop, 0, len(r.nextHops)),
+			UpDown:   r.upDown,
+			Attached: r.attached,
+		}
+
+		for _, nh := range r.nextHops {
+			hostname := ""
+			if s.hostnames != nil {
+				hostname, _ = s.hostnames.get(nh)
+			}
+
+			rt.NextHops = append(rt.NextHops, &RouteNextHop{
+				SystemID: nh,
+				Hostname: hostname,
+			})
+		}
+
+		ret = append(ret, rt)
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		a, b := ret[i].Prefix.Addr(), ret[j].Prefix.Addr()
+		if c := a.Compare(&b); c != 0 {
+			return c < 0
+		}
+
+		return ret[i].Prefix.Len() < ret[j].Prefix.Len()
+	})
+
+	return ret
+}
+
+// addAttachedDefaultRoute adds a default route towards the closest ISs setting the ATT bit unless there is a default route already
+func (t *spfTree) addAttachedDefaultRoute(routes map[bnet.Prefix]*spfRoute) {
+	dflt := bnet.NewPfx(bnet.IPv4(0), 0)
+	if _, exists := routes[dflt]; exists {
+		return
+	}
+
+	var r *spfRoute
+	for id, d := range t.dist {
+		if id == t.root || id.CircuitID != 0 || !t.nodes[id].attached {
+			continue
+		}
+
+		if r == nil || d < r.metric {
+			r = &spfRoute{
+				metric:   d,
+				nextHops: t.nextHops[id],
+				attached: true,
+			}
+		} else if d == r.metric {
+			r.nextHops = mergeNextHops(r.nextHops, t.nextHops[id])
+		}
+	}
+
+	if r != nil {
+		routes[dflt] = r
+	}
+}
+
+// reachesOtherArea returns if an IS in an area we are not part of is reachable
+func (t *spfTree) reachesOtherArea(nets []*types.NET) bool {
+	for id := range t.dist {
+		if id == t.root || id.CircuitID != 0 || len(t.nodes[id].areas) == 0 {
+			continue
+		}
+
+		if !sharesArea(t.nodes[id].areas, nets) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sharesArea(areas []types.AreaID, nets []*types.NET) bool {
+	for _, a := range areas {
+		for _, n := range nets {
+			if a.Equal(n.AreaAddress()) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func closestTentative(dist map[types.SourceID]uint32, done map[types.SourceID]struct{}) (types.SourceID, bool) {
+	var ret types.SourceID
+	found := false
+	for id, d := range dist {
+		if _, isDone := done[id]; isDone {
+			continue
+		}
+
+		if !found || d < dist[ret] {
+			ret = id
+			found = true
+		}
+	}
+
+	return ret, found
+}
+
+// mergeNextHops returns the sorted union of a and b
+func mergeNextHops(a []types.SystemID, b []types.SystemID) []types.SystemID {
+	ret := make([]types.SystemID, 0, len(a)+len(b))
+	ret = append(ret, a...)
+	for _, x := range b {
+		contained := false
+		for _, y := range ret {
+			if x == y {
+				contained = true
+				break
+			}
+		}
+
+		if !contained {
+			ret = append(ret, x)
+		}
+	}
+
+	sort.Slice(ret, func(i, j int) bool {
+		return bytes.Compare(ret[i][:], ret[j][:]) < 0
+	})
+
+	return ret
+}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"testing"
+	"time"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/isis/packet"
+	"github.com/bio-routing/bio-rd/protocols/isis/types"
+	btime "github.com/bio-routing/bio-rd/util/time"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	spfTestSysA = types.SystemID{0, 0, 0, 0, 0, 1}
+	spfTestSysB = types.SystemID{0, 0, 0, 0, 0, 2}
+	spfTestSysC = types.SystemID{0, 0, 0, 0, 0, 3}
+	spfTestSysD = types.SystemID{0, 0, 0, 0, 0, 4}
+)
+
+func newSPFTestLSDB(metricStyle MetricStyle, lsps ...*packet.LSPDU) *lsdb {
+	s := &Server{
+		nets: []*types.NET{
+			{SystemID: spfTestSysA},
+		},
+		levelConfigL2: LevelConfig{MetricStyle: metricStyle},
+		clock:         btime.NewMockClock(time.Unix(1000, 0)),
+	}
+	s.lsdbL2 = newLSDB(s)
+
+	for _, lsp := range lsps {
+		s.lsdbL2.lsps[lsp.LSPID] = newLSDBEntry(lsp)
+	}
+
+	return s.lsdbL2
+}
+
+func spfTestLSP(sysID types.SystemID, pseudonodeID uint8, tlvs ...packet.TLV) *packet.LSPDU {
+	return &packet.LSPDU{
+		LSPID: packet.LSPID{
+			SystemID:     sysID,
+			PseudonodeID: pseudonodeID,
+		},
+		RemainingLifetime: 1200,
+		SequenceNumber:    1,
+		TLVs:              tlvs,
+	}
+}
+
+type spfTestLink struct {
+	to     types.SourceID
+	metric uint32
+}
+
+func narrowLinks(links ...spfTestLink) packet.TLV {
+	tlv := packet.NewISReachabilityTLV(nil)
+	for _, l := range links {
+		tlv.AddNeighbor(packet.NewISNeighbor(l.to, l.metric))
+	}
+
+	return tlv
+}
+
+func wideLinks(links ...spfTestLink) packet.TLV {
+	tlv := packet.NewExtendedISReachabilityTLV()
+	for _, l := range links {
+		tlv.AddNeighbor(packet.NewExtendedISReachabilityNeighbor(l.to, l.metric))
+	}
+
+	return tlv
+}
+
+func link(sysID types.SystemID, metric uint32) spfTestLink {
+	return spfTestLink{
+		to:     types.NewSourceID(sysID, 0),
+		metric: metric,
+	}
+}
+
+func narrowPrefix(metric uint32, pfx bnet.Prefix) packet.TLV {
+	addr := pfx.Addr()
+	tlv := packet.NewIPInternalReachabilityTLV()
+	tlv.AddIPReachability(packet.NewIPReachability(metric, pfx.Len(), addr.ToUint32()))
+	return tlv
+}
+
+func widePrefix(metric uint32, pfx bnet.Prefix) packet.TLV {
+	addr := pfx.Addr()
+	tlv := packet.NewExtendedIPReachabilityTLV()
+	tlv.AddExtendedIPReachability(packet.NewExtendedIPReachability(metric, pfx.Len(), addr.ToUint32()))
+	return tlv
+}
+
+func TestSPFMetricStyle(t *testing.T) {
+	pfxP := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24)
+	pfxQ := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 1, 0), 24)
+
+	// B is closer to A using narrow metrics, C using wide metrics.
+	// B announces Q in the old style only, both announce P in both styles.
+	lsps := []*packet.LSPDU{
+		spfTestLSP(spfTestSysA, 0,
+			narrowLinks(link(spfTestSysB, 1), link(spfTestSysC, 30)),
+			wideLinks(link(spfTestSysB, 50), link(spfTestSysC, 10)),
+		),
+		spfTestLSP(spfTestSysB, 0,
+			narrowLinks(link(spfTestSysA, 1)),
+			wideLinks(link(spfTestSysA, 50)),
+			narrowPrefix(0, pfxP),
+			widePrefix(0, pfxP),
+			narrowPrefix(5, pfxQ),
+		),
+		spfTestLSP(spfTestSysC, 0,
+			narrowLinks(link(spfTestSysA, 30)),
+			wideLinks(link(spfTestSysA, 10)),
+			narrowPrefix(0, pfxP),
+			widePrefix(0, pfxP),
+		),
+	}
+
+	tests := []struct {
+		name        string
+		metricStyle MetricStyle
+		expected    map[bnet.Prefix]*spfRoute
+	}{
+		{
+			name:        "Narrow",
+			metricStyle: MetricStyleNarrow,
+			expected: map[bnet.Prefix]*spfRoute{
+				pfxP: {metric: 1, nextHops: []types.SystemID{spfTestSysB}},
+				pfxQ: {metric: 6, nextHops: []types.SystemID{spfTestSysB}},
+			},
+		},
+		{
+			name:        "Wide",
+			metricStyle: MetricStyleWide,
+			expected: map[bnet.Prefix]*spfRoute{
+				pfxP: {metric: 10, nextHops: []types.SystemID{spfTestSysC}},
+			},
+		},
+		{
+			name:        "Transition prefers wide metrics",
+			metricStyle: MetricStyleTransition,
+			expected: map[bnet.Prefix]*spfRoute{
+				pfxP: {metric: 10, nextHops: []types.SystemID{spfTestSysC}},
+				pfxQ: {metric: 55, nextHops: []types.SystemID{spfTestSysB}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l := newSPFTestLSDB(test.metricStyle, lsps...)
+			assert.Equal(t, test.expected, l.spf())
+		})
+	}
+}
+
+func TestSPF(t *testing.T) {
+	pn := types.NewSourceID(spfTestSysA, 1)
+	pfxD := bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 4, 0), 24)
+
+	// A, B and C share a LAN (pseudonode A.01). D is connected to B and C with equal metrics.
+	// The link A - D is only reported by A and must not be used.
+	l := newSPFTestLSDB(MetricStyleWide,
+		spfTestLSP(spfTestSysA, 0, wideLinks(spfTestLink{to: pn, metric: 10}, link(spfTestSysD, 1))),
+		spfTestLSP(spfTestSysA, 1, wideLinks(link(spfTestSysA, 0), link(spfTestSysB, 0), link(spfTestSysC, 0))),
+		spfTestLSP(spfTestSysB, 0, wideLinks(spfTestLink{to: pn, metric: 10}, link(spfTestSysD, 5))),
+		spfTestLSP(spfTestSysC, 0, wideLinks(spfTestLink{to: pn, metric: 10}, link(spfTestSysD, 5))),
+		spfTestLSP(spfTestSysD, 0,
+			wideLinks(link(spfTestSysB, 5), link(spfTestSysC, 5)),
+			widePrefix(1, pfxD),
+		),
+	)
+
+	assert.Equal(t, map[bnet.Prefix]*spfRoute{
+		pfxD: {metric: 16, nextHops: []types.SystemID{spfTestSysB, spfTestSysC}},
+	}, l.spf())
+}
@@ -1,10 +1,17 @@
 package types
 
-import "fmt"
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
 
 const (
 	minNETLen = 8
 	maxNETLen = 20
+
+	// MaxAreaAddresses is the maximum number of area addresses an IS may have (ISO 10589 maximumAreaAddresses)
+	MaxAreaAddresses = 3
 )
 
 // NET represents an ISO network entity title
@@ -15,7 +22,22 @@ type NET struct {
 	SEL      byte
 }
 
-// ParseNET parses an network entity title
+// ParseNETString parses a network entity title in dotted hex notation, e.g. 49.0001.0100.0000.0001.00
+func ParseNETString(s string) (*NET, error) {
+	digits := strings.ReplaceAll(s, ".", "")
+	if len(digits)%2 != 0 {
+		return nil, fmt.Errorf("odd number of hex digits")
+	}
+
+	addr, err := hex.DecodeString(digits)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hex digits: %w", err)
+	}
+
+	return ParseNET(addr)
+}
+
+// ParseNET parses an network entity title. A NET consists of an area address of 1 to 13 bytes, a 6 byte system id and the selector 00.
 func ParseNET(addr []byte) (*NET, error) {
 	addrLen := len(addr)
 
@@ -27,6 +49,10 @@ func ParseNET(addr []byte) (*NET, error) {
 		return nil, fmt.Errorf("NET too long")
 	}
 
+	if addr[addrLen-1] != 0 {
+		return nil, fmt.Errorf("NET selector must be 00, got %02x", addr[addrLen-1])
+	}
+
 	areaID := []byte{}
 
 	for i := 0; i < addrLen-systemIDLen-2; i++ { // -2 for SEL and "off by one"
@@ -49,3 +75,27 @@ func ParseNET(addr []byte) (*NET, error) {
 		SEL:      addr[addrLen-1],
 	}, nil
 }
+
+// AreaAddress gets the area address of the NET as advertised in the area addresses TLV (AFI followed by the area id)
+func (n *NET) AreaAddress() AreaID {
+	return append(AreaID{n.AFI}, n.AreaID...)
+}
+
+// String returns the dotted hex notation of the NET
+func (n *NET) String() string {
+	area := hex.EncodeToString(n.AreaID)
+	groups := []string{fmt.Sprintf("%02x", n.AFI)}
+	for i := 0; i < len(area); i += 4 {
+		end := i + 4
+		if end > len(area) {
+			end = len(area)
+		}
+
+		groups = append(groups, area[i:end])
+	}
+
+	sysID := hex.EncodeToString(n.SystemID[:])
+	groups = append(groups, sysID[0:4], sysID[4:8], sysID[8:12], fmt.Sprintf("%02x", n.SEL))
+
+	return strings.Join(groups, ".")
+}
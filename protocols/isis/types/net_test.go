@@ -0,0 +1,91 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNETString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantFail bool
+		expected *NET
+	}{
+		{
+			name:  "Area with AFI and area id",
+			input: "49.0001.0100.0000.0001.00",
+			expected: &NET{
+				AFI:      0x49,
+				AreaID:   AreaID{0x00, 0x01},
+				SystemID: SystemID{0x01, 0x00, 0x00, 0x00, 0x00, 0x01},
+			},
+		},
+		{
+			name:  "Area of AFI only",
+			input: "49.0100.0000.0002.00",
+			expected: &NET{
+				AFI:      0x49,
+				AreaID:   AreaID{},
+				SystemID: SystemID{0x01, 0x00, 0x00, 0x00, 0x00, 0x02},
+			},
+		},
+		{
+			name:  "Longest area",
+			input: "49.0001.0203.0405.0607.0809.0a0b.0100.0000.0003.00",
+			expected: &NET{
+				AFI:      0x49,
+				AreaID:   AreaID{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11},
+				SystemID: SystemID{0x01, 0x00, 0x00, 0x00, 0x00, 0x03},
+			},
+		},
+		{
+			name:     "Selector not 00",
+			input:    "49.0001.0100.0000.0001.01",
+			wantFail: true,
+		},
+		{
+			name:     "Missing system id",
+			input:    "49.0001.00",
+			wantFail: true,
+		},
+		{
+			name:     "Area too long",
+			input:    "49.0001.0203.0405.0607.0809.0a0b.0c01.0000.0000.0100",
+			wantFail: true,
+		},
+		{
+			name:     "Odd number of digits",
+			input:    "49.0001.0100.0000.0001.0",
+			wantFail: true,
+		},
+		{
+			name:     "Invalid digits",
+			input:    "49.000g.0100.0000.0001.00",
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		n, err := ParseNETString(test.input)
+		if test.wantFail {
+			assert.Error(t, err, test.name)
+			continue
+		}
+
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, n, test.name)
+		assert.Equal(t, test.input, n.String(), test.name)
+	}
+}
+
+func TestNETAreaAddress(t *testing.T) {
+	n := &NET{
+		AFI:    0x49,
+		AreaID: AreaID{0x00, 0x01},
+	}
+
+	assert.Equal(t, AreaID{0x49, 0x00, 0x01}, n.AreaAddress())
+	assert.Equal(t, AreaID{0x00, 0x01}, n.AreaID)
+}
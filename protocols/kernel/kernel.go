@@ -1,18 +1,44 @@
 package kernel
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/route"
 	"github.com/bio-routing/bio-rd/routingtable"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/util/log"
 )
 
 type Kernel struct {
 	osKernel osKernel
+	resolver NextHopResolver
+
+	// recursive holds the BGP routes installed via a resolved next hop by their BGP next hop
+	recursive   map[net.IP]*recursiveRoutes
+	recursiveMu sync.Mutex
+}
+
+// recursiveRoutes are BGP routes sharing a next hop which has to be resolved through the RIB
+type recursiveRoutes struct {
+	// resolved is the next hop the routes are installed via. nil if the next hop is unreachable.
+	resolved *net.IP
+	routes   map[net.Prefix]recursiveRoute
+}
+
+type recursiveRoute struct {
+	pfx  *net.Prefix
+	path *route.Path
+}
+
+// NextHopResolver resolves next hops which might not be directly connected, e.g. of multihop BGP routes
+type NextHopResolver interface {
+	ResolveNextHop(nh *net.IP) (*net.IP, error)
 }
 
 type osKernel interface {
-	AddPath(pfx *net.Prefix, path *route.Path) error
+	AddPath(pfx *net.Prefix, path *route.Path, nextHop *net.IP) error
 	RemovePath(pfx *net.Prefix, path *route.Path) bool
 	uninit() error
 }
@@ -31,16 +57,137 @@ func (k *Kernel) AddPathInitialDump(pfx *net.Prefix, path *route.Path) error {
 	return k.AddPath(pfx, path)
 }
 
+// SetNextHopResolver makes the kernel install BGP routes via their recursively resolved next hop. Next hops are
+// resolved again whenever a route covering them changes.
+func (k *Kernel) SetNextHopResolver(r NextHopResolver) {
+	k.resolver = r
+}
+
 func (k *Kernel) AddPath(pfx *net.Prefix, path *route.Path) error {
-	return k.osKernel.AddPath(pfx, path)
+	k.recursiveMu.Lock()
+	defer k.recursiveMu.Unlock()
+
+	defer k.reresolve(pfx)
+	k.untrack(pfx)
+
+	nh, err := k.nextHop(path)
+	if k.isRecursive(path) {
+		k.track(pfx, path, nh)
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to resolve next hop for %s: %w", pfx.String(), err)
+	}
+
+	return k.osKernel.AddPath(pfx, path, nh)
+}
+
+func (k *Kernel) isRecursive(path *route.Path) bool {
+	return k.resolver != nil && !path.Discard && path.Type == route.BGPPathType && path.NextHop() != nil
+}
+
+func (k *Kernel) nextHop(path *route.Path) (*net.IP, error) {
+	if path.Discard {
+		return nil, nil
+	}
+
+	if k.resolver == nil || path.Type != route.BGPPathType {
+		return path.NextHop(), nil
+	}
+
+	return k.resolver.ResolveNextHop(path.NextHop())
 }
 
 func (k *Kernel) EndOfRIB() {}
 
 func (k *Kernel) RemovePath(pfx *net.Prefix, path *route.Path) bool {
+	k.recursiveMu.Lock()
+	defer k.recursiveMu.Unlock()
+
+	defer k.reresolve(pfx)
+	k.untrack(pfx)
+
 	return k.osKernel.RemovePath(pfx, path)
 }
 
+// track remembers a recursive route installed via resolved (nil if unreachable) for re-resolution
+func (k *Kernel) track(pfx *net.Prefix, path *route.Path, resolved *net.IP) {
+	if k.recursive == nil {
+		k.recursive = make(map[net.IP]*recursiveRoutes)
+	}
+
+	nh := *path.NextHop()
+	rr := k.recursive[nh]
+	if rr == nil {
+		rr = &recursiveRoutes{
+			routes: make(map[net.Prefix]recursiveRoute),
+		}
+		k.recursive[nh] = rr
+	}
+
+	rr.resolved = resolved
+	rr.routes[*pfx] = recursiveRoute{
+		pfx:  pfx,
+		path: path,
+	}
+}
+
+func (k *Kernel) untrack(pfx *net.Prefix) {
+	for nh, rr := range k.recursive {
+		if _, ok := rr.routes[*pfx]; !ok {
+			continue
+		}
+
+		delete(rr.routes, *pfx)
+		if len(rr.routes) == 0 {
+			delete(k.recursive, nh)
+		}
+
+		return
+	}
+}
+
+// reresolve resolves the next hops covered by changed again as the route they were resolved through might have
+// changed. Routes the resolution changed for are reinstalled via their new next hop or removed if it became unreachable.
+func (k *Kernel) reresolve(changed *net.Prefix) {
+	for nh, rr := range k.recursive {
+		if !covers(changed, nh) {
+			continue
+		}
+
+		resolved, err := k.resolver.ResolveNextHop(nh.Ptr())
+		if err != nil {
+			resolved = nil
+		}
+
+		if resolved == rr.resolved || (resolved != nil && rr.resolved != nil && resolved.Equal(*rr.resolved)) {
+			continue
+		}
+
+		rr.resolved = resolved
+		for _, r := range rr.routes {
+			if resolved == nil {
+				k.osKernel.RemovePath(r.pfx, r.path)
+				continue
+			}
+
+			err := k.osKernel.AddPath(r.pfx, r.path, resolved)
+			if err != nil {
+				log.WithError(err).Errorf("Unable to reinstall %s via %s", r.pfx.String(), resolved.String())
+			}
+		}
+	}
+}
+
+func covers(pfx *net.Prefix, addr net.IP) bool {
+	host := net.NewPfx(addr, 128)
+	if addr.IsIPv4() {
+		host = net.NewPfx(addr, 32)
+	}
+
+	return pfx.Equal(&host) || pfx.Contains(&host)
+}
+
 func (k *Kernel) UpdateNewClient(routingtable.RouteTableClient) error {
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/bio-routing/bio-rd/route"
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 
 	bnet "github.com/bio-routing/bio-rd/net"
 )
@@ -29,7 +30,7 @@ func (k *Kernel) init() error {
 
 type linuxKernel struct {
 	h      *netlink.Handle
-	routes map[*bnet.Prefix]struct{}
+	routes map[*bnet.Prefix]*netlink.Route
 }
 
 func newLinuxKernel() (*linuxKernel, error) {
@@ -40,7 +41,7 @@ func newLinuxKernel() (*linuxKernel, error) {
 
 	return &linuxKernel{
 		h:      h,
-		routes: make(map[*bnet.Prefix]struct{}),
+		routes: make(map[*bnet.Prefix]*netlink.Route),
 	}, nil
 }
 
@@ -77,20 +78,32 @@ func (lk *linuxKernel) cleanup() error {
 	return nil
 }
 
-func (lk *linuxKernel) AddPath(pfx *bnet.Prefix, path *route.Path) error {
+// netlinkRoute creates the kernel route for a path via nextHop. Discard paths are installed as blackhole routes.
+func netlinkRoute(pfx *bnet.Prefix, path *route.Path, nextHop *bnet.IP) *netlink.Route {
 	r := &netlink.Route{
 		Protocol: protoBio,
 		Dst:      pfx.GetIPNet(),
-		Gw:       path.NextHop().ToNetIP(),
 	}
 
+	if path.Discard {
+		r.Type = unix.RTN_BLACKHOLE
+		return r
+	}
+
+	r.Gw = nextHop.ToNetIP()
+	return r
+}
+
+func (lk *linuxKernel) AddPath(pfx *bnet.Prefix, path *route.Path, nextHop *bnet.IP) error {
+	r := netlinkRoute(pfx, path, nextHop)
+
 	if _, found := lk.routes[pfx]; !found {
 		err := lk.h.RouteAdd(r)
 		if err != nil {
 			return fmt.Errorf("unable to add route: %w", err)
 		}
 
-		lk.routes[pfx] = struct{}{}
+		lk.routes[pfx] = r
 		return nil
 	}
 
@@ -99,20 +112,17 @@ func (lk *linuxKernel) AddPath(pfx *bnet.Prefix, path *route.Path) error {
 		return fmt.Errorf("unable to replace route: %w", err)
 	}
 
+	lk.routes[pfx] = r
 	return nil
 }
 
+// RemovePath removes the route installed for pfx as the next hop of path might have been resolved differently
 func (lk *linuxKernel) RemovePath(pfx *bnet.Prefix, path *route.Path) bool {
-	if _, found := lk.routes[pfx]; !found {
+	r, found := lk.routes[pfx]
+	if !found {
 		return false
 	}
 
-	r := &netlink.Route{
-		Protocol: protoBio,
-		Dst:      pfx.GetIPNet(),
-		Gw:       path.NextHop().ToNetIP(),
-	}
-
 	err := lk.h.RouteDel(r)
 	if err != nil {
 		return false
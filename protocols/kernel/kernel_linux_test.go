@@ -0,0 +1,59 @@
+package kernel
+
+import (
+	"net"
+	"testing"
+
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+func TestNetlinkRoute(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 1), 32).Ptr()
+
+	tests := []struct {
+		name     string
+		path     *route.Path
+		expected *netlink.Route
+	}{
+		{
+			name: "Unicast route",
+			path: &route.Path{
+				Type: route.StaticPathType,
+				StaticPath: &route.StaticPath{
+					NextHop: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+				},
+			},
+			expected: &netlink.Route{
+				Protocol: protoBio,
+				Dst:      pfx.GetIPNet(),
+				Gw:       net.IP{10, 0, 0, 1},
+			},
+		},
+		{
+			name: "Blackhole route",
+			path: &route.Path{
+				Type:    route.BGPPathType,
+				Discard: true,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{
+						NextHop: bnet.IPv4FromOctets(192, 0, 2, 255).Ptr(),
+					},
+				},
+			},
+			expected: &netlink.Route{
+				Protocol: protoBio,
+				Dst:      pfx.GetIPNet(),
+				Type:     unix.RTN_BLACKHOLE,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, netlinkRoute(pfx, test.path, test.path.NextHop()), test.name)
+	}
+}
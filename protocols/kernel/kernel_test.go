@@ -0,0 +1,186 @@
+package kernel
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+type mockOSKernel struct {
+	nextHops map[bnet.Prefix]*bnet.IP
+}
+
+func (m *mockOSKernel) AddPath(pfx *bnet.Prefix, path *route.Path, nextHop *bnet.IP) error {
+	m.nextHops[*pfx] = nextHop
+	return nil
+}
+
+func (m *mockOSKernel) RemovePath(pfx *bnet.Prefix, path *route.Path) bool {
+	delete(m.nextHops, *pfx)
+	return true
+}
+
+func (m *mockOSKernel) uninit() error {
+	return nil
+}
+
+type mockResolver struct {
+	nextHops map[bnet.IP]*bnet.IP
+}
+
+func (m *mockResolver) ResolveNextHop(nh *bnet.IP) (*bnet.IP, error) {
+	if r, ok := m.nextHops[*nh]; ok {
+		return r, nil
+	}
+
+	return nil, errors.New("unreachable")
+}
+
+func TestAddPathNextHopResolution(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	resolver := &mockResolver{
+		nextHops: map[bnet.IP]*bnet.IP{
+			bnet.IPv4FromOctets(192, 0, 2, 1): bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		resolver NextHopResolver
+		path     *route.Path
+		expected *bnet.IP
+		wantFail bool
+	}{
+		{
+			name:     "Multihop BGP route",
+			resolver: resolver,
+			path: &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{
+						NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+					},
+				},
+			},
+			expected: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+		},
+		{
+			name: "BGP route without resolver",
+			path: &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{
+						NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+					},
+				},
+			},
+			expected: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+		},
+		{
+			name:     "Static route",
+			resolver: resolver,
+			path: &route.Path{
+				Type: route.StaticPathType,
+				StaticPath: &route.StaticPath{
+					NextHop: bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+				},
+			},
+			expected: bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+		},
+		{
+			name:     "Unresolvable next hop",
+			resolver: resolver,
+			path: &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{
+						NextHop: bnet.IPv4FromOctets(192, 0, 2, 2).Ptr(),
+					},
+				},
+			},
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			osKernel := &mockOSKernel{
+				nextHops: make(map[bnet.Prefix]*bnet.IP),
+			}
+			k := &Kernel{
+				osKernel: osKernel,
+			}
+			if test.resolver != nil {
+				k.SetNextHopResolver(test.resolver)
+			}
+
+			err := k.AddPath(pfx, test.path)
+			if test.wantFail {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, osKernel.nextHops[*pfx])
+		})
+	}
+}
+
+func TestNextHopReresolution(t *testing.T) {
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	loopback := bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 1), 32).Ptr()
+	igpPath := &route.Path{
+		Type: route.ISISPathType,
+		ISISPath: &route.ISISPath{
+			NextHop: bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+		},
+	}
+
+	osKernel := &mockOSKernel{
+		nextHops: make(map[bnet.Prefix]*bnet.IP),
+	}
+	resolver := &mockResolver{
+		nextHops: map[bnet.IP]*bnet.IP{
+			bnet.IPv4FromOctets(192, 0, 2, 1): bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+		},
+	}
+	k := &Kernel{
+		osKernel: osKernel,
+	}
+	k.SetNextHopResolver(resolver)
+
+	err := k.AddPath(pfx, &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			BGPPathA: &route.BGPPathA{
+				NextHop: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(), osKernel.nextHops[*pfx])
+
+	// Routes not covering the next hop do not change its resolution
+	resolver.nextHops[bnet.IPv4FromOctets(192, 0, 2, 1)] = bnet.IPv4FromOctets(10, 0, 0, 2).Ptr()
+	k.AddPath(bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24).Ptr(), igpPath)
+	assert.Equal(t, bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(), osKernel.nextHops[*pfx])
+
+	k.AddPath(loopback, igpPath)
+	assert.Equal(t, bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(), osKernel.nextHops[*pfx], "route must follow the covering route")
+
+	delete(resolver.nextHops, bnet.IPv4FromOctets(192, 0, 2, 1))
+	k.RemovePath(loopback, igpPath)
+	_, found := osKernel.nextHops[*pfx]
+	assert.False(t, found, "route with unreachable next hop must be removed")
+
+	resolver.nextHops[bnet.IPv4FromOctets(192, 0, 2, 1)] = bnet.IPv4FromOctets(10, 0, 0, 3).Ptr()
+	k.AddPath(bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr(), igpPath)
+	assert.Equal(t, bnet.IPv4FromOctets(10, 0, 0, 3).Ptr(), osKernel.nextHops[*pfx], "route must be installed once its next hop is reachable again")
+
+	k.RemovePath(pfx, nil)
+	assert.Empty(t, k.recursive)
+}
@@ -77,6 +77,7 @@ const (
 	Path_HiddenReasonOurOriginatorID    Path_HiddenReason = 4
 	Path_HiddenReasonClusterLoop        Path_HiddenReason = 5
 	Path_HiddenReasonOTCMismatch        Path_HiddenReason = 6
+	Path_HiddenReasonInvalidNextHop     Path_HiddenReason = 7
 )
 
 // Enum value maps for Path_HiddenReason.
@@ -89,6 +90,7 @@ var (
 		4: "HiddenReasonOurOriginatorID",
 		5: "HiddenReasonClusterLoop",
 		6: "HiddenReasonOTCMismatch",
+		7: "HiddenReasonInvalidNextHop",
 	}
 	Path_HiddenReason_value = map[string]int32{
 		"HiddenReasonNone":               0,
@@ -98,6 +100,7 @@ var (
 		"HiddenReasonOurOriginatorID":    4,
 		"HiddenReasonClusterLoop":        5,
 		"HiddenReasonOTCMismatch":        6,
+		"HiddenReasonInvalidNextHop":     7,
 	}
 )
 
@@ -128,6 +131,85 @@ func (Path_HiddenReason) EnumDescriptor() ([]byte, []int) {
 	return file_route_api_route_proto_rawDescGZIP(), []int{1, 1}
 }
 
+type Path_SelectionReason int32
+
+const (
+	Path_SelectionReasonNone              Path_SelectionReason = 0
+	Path_SelectionReasonProtocol          Path_SelectionReason = 1
+	Path_SelectionReasonWeight            Path_SelectionReason = 2
+	Path_SelectionReasonLocalPref         Path_SelectionReason = 3
+	Path_SelectionReasonASPathLength      Path_SelectionReason = 4
+	Path_SelectionReasonOrigin            Path_SelectionReason = 5
+	Path_SelectionReasonMED               Path_SelectionReason = 6
+	Path_SelectionReasonEBGP              Path_SelectionReason = 7
+	Path_SelectionReasonRouterID          Path_SelectionReason = 8
+	Path_SelectionReasonClusterListLength Path_SelectionReason = 9
+	Path_SelectionReasonPeerAddress       Path_SelectionReason = 10
+	Path_SelectionReasonNextHop           Path_SelectionReason = 11
+	Path_SelectionReasonIGPMetric         Path_SelectionReason = 12
+)
+
+// Enum value maps for Path_SelectionReason.
+var (
+	Path_SelectionReason_name = map[int32]string{
+		0:  "SelectionReasonNone",
+		1:  "SelectionReasonProtocol",
+		2:  "SelectionReasonWeight",
+		3:  "SelectionReasonLocalPref",
+		4:  "SelectionReasonASPathLength",
+		5:  "SelectionReasonOrigin",
+		6:  "SelectionReasonMED",
+		7:  "SelectionReasonEBGP",
+		8:  "SelectionReasonRouterID",
+		9:  "SelectionReasonClusterListLength",
+		10: "SelectionReasonPeerAddress",
+		11: "SelectionReasonNextHop",
+		12: "SelectionReasonIGPMetric",
+	}
+	Path_SelectionReason_value = map[string]int32{
+		"SelectionReasonNone":              0,
+		"SelectionReasonProtocol":          1,
+		"SelectionReasonWeight":            2,
+		"SelectionReasonLocalPref":         3,
+		"SelectionReasonASPathLength":      4,
+		"SelectionReasonOrigin":            5,
+		"SelectionReasonMED":               6,
+		"SelectionReasonEBGP":              7,
+		"SelectionReasonRouterID":          8,
+		"SelectionReasonClusterListLength": 9,
+		"SelectionReasonPeerAddress":       10,
+		"SelectionReasonNextHop":           11,
+		"SelectionReasonIGPMetric":         12,
+	}
+)
+
+func (x Path_SelectionReason) Enum() *Path_SelectionReason {
+	p := new(Path_SelectionReason)
+	*p = x
+	return p
+}
+
+func (x Path_SelectionReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Path_SelectionReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_route_api_route_proto_enumTypes[2].Descriptor()
+}
+
+func (Path_SelectionReason) Type() protoreflect.EnumType {
+	return &file_route_api_route_proto_enumTypes[2]
+}
+
+func (x Path_SelectionReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Path_SelectionReason.Descriptor instead.
+func (Path_SelectionReason) EnumDescriptor() ([]byte, []int) {
+	return file_route_api_route_proto_rawDescGZIP(), []int{1, 2}
+}
+
 type Route struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -193,6 +275,14 @@ type Path struct {
 	BgpPath      *BGPPath          `protobuf:"bytes,3,opt,name=bgp_path,json=bgpPath,proto3" json:"bgp_path,omitempty"`
 	HiddenReason Path_HiddenReason `protobuf:"varint,4,opt,name=hidden_reason,json=hiddenReason,proto3,enum=bio.route.Path_HiddenReason" json:"hidden_reason,omitempty"`
 	TimeLearned  uint32            `protobuf:"varint,5,opt,name=time_learned,json=timeLearned,proto3" json:"time_learned,omitempty"`
+	// best is set on the path selected as best path of the route
+	Best bool `protobuf:"varint,6,opt,name=best,proto3" json:"best,omitempty"`
+	// selection_reason is the path selection step preferring the best path over the next best one
+	SelectionReason Path_SelectionReason `protobuf:"varint,7,opt,name=selection_reason,json=selectionReason,proto3,enum=bio.route.Path_SelectionReason" json:"selection_reason,omitempty"`
+	// discard is set on blackhole paths. Traffic matching them is dropped instead of forwarded to the next hop.
+	Discard bool `protobuf:"varint,8,opt,name=discard,proto3" json:"discard,omitempty"`
+	// stale is set on paths restored from a snapshot and not yet confirmed by their source
+	Stale bool `protobuf:"varint,9,opt,name=stale,proto3" json:"stale,omitempty"`
 }
 
 func (x *Path) Reset() {
@@ -262,6 +352,34 @@ func (x *Path) GetTimeLearned() uint32 {
 	return 0
 }
 
+func (x *Path) GetBest() bool {
+	if x != nil {
+		return x.Best
+	}
+	return false
+}
+
+func (x *Path) GetSelectionReason() Path_SelectionReason {
+	if x != nil {
+		return x.SelectionReason
+	}
+	return Path_SelectionReasonNone
+}
+
+func (x *Path) GetDiscard() bool {
+	if x != nil {
+		return x.Discard
+	}
+	return false
+}
+
+func (x *Path) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
 type StaticPath struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -330,6 +448,12 @@ type BGPPath struct {
 	UnknownAttributes []*UnknownPathAttribute `protobuf:"bytes,14,rep,name=unknown_attributes,json=unknownAttributes,proto3" json:"unknown_attributes,omitempty"`
 	BmpPostPolicy     bool                    `protobuf:"varint,15,opt,name=bmp_post_policy,json=bmpPostPolicy,proto3" json:"bmp_post_policy,omitempty"`
 	OnlyToCustomer    uint32                  `protobuf:"varint,16,opt,name=only_to_customer,json=onlyToCustomer,proto3" json:"only_to_customer,omitempty"`
+	// med_present is set if the path carries a MED
+	MedPresent bool `protobuf:"varint,17,opt,name=med_present,json=medPresent,proto3" json:"med_present,omitempty"`
+	// labels is the MPLS label stack of a labeled unicast path, top of the stack first
+	Labels []uint32 `protobuf:"varint,18,rep,packed,name=labels,proto3" json:"labels,omitempty"`
+	// prefix_sid is the BGP Prefix-SID attribute (RFC8669, RFC9252) if present
+	PrefixSid *PrefixSID `protobuf:"bytes,19,opt,name=prefix_sid,json=prefixSid,proto3" json:"prefix_sid,omitempty"`
 }
 
 func (x *BGPPath) Reset() {
@@ -476,6 +600,426 @@ func (x *BGPPath) GetOnlyToCustomer() uint32 {
 	return 0
 }
 
+func (x *BGPPath) GetMedPresent() bool {
+	if x != nil {
+		return x.MedPresent
+	}
+	return false
+}
+
+func (x *BGPPath) GetLabels() []uint32 {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *BGPPath) GetPrefixSid() *PrefixSID {
+	if x != nil {
+		return x.PrefixSid
+	}
+	return nil
+}
+
+type PrefixSID struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LabelIndex    *PrefixSIDLabelIndex `protobuf:"bytes,1,opt,name=label_index,json=labelIndex,proto3" json:"label_index,omitempty"`
+	Srv6L3Service *SRv6Service         `protobuf:"bytes,2,opt,name=srv6_l3_service,json=srv6L3Service,proto3" json:"srv6_l3_service,omitempty"`
+	// tlvs are all TLVs not represented by the fields above, e.g. the Originator SRGB TLV
+	Tlvs []*PrefixSIDTLV `protobuf:"bytes,3,rep,name=tlvs,proto3" json:"tlvs,omitempty"`
+}
+
+func (x *PrefixSID) Reset() {
+	*x = PrefixSID{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_api_route_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrefixSID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefixSID) ProtoMessage() {}
+
+func (x *PrefixSID) ProtoReflect() protoreflect.Message {
+	mi := &file_route_api_route_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefixSID.ProtoReflect.Descriptor instead.
+func (*PrefixSID) Descriptor() ([]byte, []int) {
+	return file_route_api_route_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PrefixSID) GetLabelIndex() *PrefixSIDLabelIndex {
+	if x != nil {
+		return x.LabelIndex
+	}
+	return nil
+}
+
+func (x *PrefixSID) GetSrv6L3Service() *SRv6Service {
+	if x != nil {
+		return x.Srv6L3Service
+	}
+	return nil
+}
+
+func (x *PrefixSID) GetTlvs() []*PrefixSIDTLV {
+	if x != nil {
+		return x.Tlvs
+	}
+	return nil
+}
+
+type PrefixSIDLabelIndex struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Flags uint32 `protobuf:"varint,1,opt,name=flags,proto3" json:"flags,omitempty"`
+	Index uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (x *PrefixSIDLabelIndex) Reset() {
+	*x = PrefixSIDLabelIndex{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_api_route_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrefixSIDLabelIndex) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefixSIDLabelIndex) ProtoMessage() {}
+
+func (x *PrefixSIDLabelIndex) ProtoReflect() protoreflect.Message {
+	mi := &file_route_api_route_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefixSIDLabelIndex.ProtoReflect.Descriptor instead.
+func (*PrefixSIDLabelIndex) Descriptor() ([]byte, []int) {
+	return file_route_api_route_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PrefixSIDLabelIndex) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *PrefixSIDLabelIndex) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+type SRv6Service struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sids []*SRv6SIDInformation `protobuf:"bytes,1,rep,name=sids,proto3" json:"sids,omitempty"`
+	// sub_tlvs are all sub-TLVs other than SRv6 SID Information sub-TLVs
+	SubTlvs []*PrefixSIDTLV `protobuf:"bytes,2,rep,name=sub_tlvs,json=subTlvs,proto3" json:"sub_tlvs,omitempty"`
+}
+
+func (x *SRv6Service) Reset() {
+	*x = SRv6Service{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_api_route_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SRv6Service) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SRv6Service) ProtoMessage() {}
+
+func (x *SRv6Service) ProtoReflect() protoreflect.Message {
+	mi := &file_route_api_route_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SRv6Service.ProtoReflect.Descriptor instead.
+func (*SRv6Service) Descriptor() ([]byte, []int) {
+	return file_route_api_route_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *SRv6Service) GetSids() []*SRv6SIDInformation {
+	if x != nil {
+		return x.Sids
+	}
+	return nil
+}
+
+func (x *SRv6Service) GetSubTlvs() []*PrefixSIDTLV {
+	if x != nil {
+		return x.SubTlvs
+	}
+	return nil
+}
+
+type SRv6SIDInformation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Sid              *api.IP `protobuf:"bytes,1,opt,name=sid,proto3" json:"sid,omitempty"`
+	Flags            uint32  `protobuf:"varint,2,opt,name=flags,proto3" json:"flags,omitempty"`
+	EndpointBehavior uint32  `protobuf:"varint,3,opt,name=endpoint_behavior,json=endpointBehavior,proto3" json:"endpoint_behavior,omitempty"`
+	// structure is the SRv6 SID Structure sub-sub-TLV if present
+	Structure *SRv6SIDStructure `protobuf:"bytes,4,opt,name=structure,proto3" json:"structure,omitempty"`
+	// sub_sub_tlvs are all sub-sub-TLVs other than the SRv6 SID Structure sub-sub-TLV
+	SubSubTlvs []*PrefixSIDTLV `protobuf:"bytes,5,rep,name=sub_sub_tlvs,json=subSubTlvs,proto3" json:"sub_sub_tlvs,omitempty"`
+}
+
+func (x *SRv6SIDInformation) Reset() {
+	*x = SRv6SIDInformation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_api_route_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SRv6SIDInformation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SRv6SIDInformation) ProtoMessage() {}
+
+func (x *SRv6SIDInformation) ProtoReflect() protoreflect.Message {
+	mi := &file_route_api_route_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SRv6SIDInformation.ProtoReflect.Descriptor instead.
+func (*SRv6SIDInformation) Descriptor() ([]byte, []int) {
+	return file_route_api_route_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SRv6SIDInformation) GetSid() *api.IP {
+	if x != nil {
+		return x.Sid
+	}
+	return nil
+}
+
+func (x *SRv6SIDInformation) GetFlags() uint32 {
+	if x != nil {
+		return x.Flags
+	}
+	return 0
+}
+
+func (x *SRv6SIDInformation) GetEndpointBehavior() uint32 {
+	if x != nil {
+		return x.EndpointBehavior
+	}
+	return 0
+}
+
+func (x *SRv6SIDInformation) GetStructure() *SRv6SIDStructure {
+	if x != nil {
+		return x.Structure
+	}
+	return nil
+}
+
+func (x *SRv6SIDInformation) GetSubSubTlvs() []*PrefixSIDTLV {
+	if x != nil {
+		return x.SubSubTlvs
+	}
+	return nil
+}
+
+type SRv6SIDStructure struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	LocatorBlockLen     uint32 `protobuf:"varint,1,opt,name=locator_block_len,json=locatorBlockLen,proto3" json:"locator_block_len,omitempty"`
+	LocatorNodeLen      uint32 `protobuf:"varint,2,opt,name=locator_node_len,json=locatorNodeLen,proto3" json:"locator_node_len,omitempty"`
+	FunctionLen         uint32 `protobuf:"varint,3,opt,name=function_len,json=functionLen,proto3" json:"function_len,omitempty"`
+	ArgumentLen         uint32 `protobuf:"varint,4,opt,name=argument_len,json=argumentLen,proto3" json:"argument_len,omitempty"`
+	TranspositionLen    uint32 `protobuf:"varint,5,opt,name=transposition_len,json=transpositionLen,proto3" json:"transposition_len,omitempty"`
+	TranspositionOffset uint32 `protobuf:"varint,6,opt,name=transposition_offset,json=transpositionOffset,proto3" json:"transposition_offset,omitempty"`
+}
+
+func (x *SRv6SIDStructure) Reset() {
+	*x = SRv6SIDStructure{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_api_route_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SRv6SIDStructure) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SRv6SIDStructure) ProtoMessage() {}
+
+func (x *SRv6SIDStructure) ProtoReflect() protoreflect.Message {
+	mi := &file_route_api_route_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SRv6SIDStructure.ProtoReflect.Descriptor instead.
+func (*SRv6SIDStructure) Descriptor() ([]byte, []int) {
+	return file_route_api_route_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SRv6SIDStructure) GetLocatorBlockLen() uint32 {
+	if x != nil {
+		return x.LocatorBlockLen
+	}
+	return 0
+}
+
+func (x *SRv6SIDStructure) GetLocatorNodeLen() uint32 {
+	if x != nil {
+		return x.LocatorNodeLen
+	}
+	return 0
+}
+
+func (x *SRv6SIDStructure) GetFunctionLen() uint32 {
+	if x != nil {
+		return x.FunctionLen
+	}
+	return 0
+}
+
+func (x *SRv6SIDStructure) GetArgumentLen() uint32 {
+	if x != nil {
+		return x.ArgumentLen
+	}
+	return 0
+}
+
+func (x *SRv6SIDStructure) GetTranspositionLen() uint32 {
+	if x != nil {
+		return x.TranspositionLen
+	}
+	return 0
+}
+
+func (x *SRv6SIDStructure) GetTranspositionOffset() uint32 {
+	if x != nil {
+		return x.TranspositionOffset
+	}
+	return 0
+}
+
+// PrefixSIDTLV is a TLV, sub-TLV or sub-sub-TLV of a Prefix-SID attribute not interpreted by bio-rd
+type PrefixSIDTLV struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type  uint32 `protobuf:"varint,1,opt,name=type,proto3" json:"type,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *PrefixSIDTLV) Reset() {
+	*x = PrefixSIDTLV{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_route_api_route_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PrefixSIDTLV) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PrefixSIDTLV) ProtoMessage() {}
+
+func (x *PrefixSIDTLV) ProtoReflect() protoreflect.Message {
+	mi := &file_route_api_route_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PrefixSIDTLV.ProtoReflect.Descriptor instead.
+func (*PrefixSIDTLV) Descriptor() ([]byte, []int) {
+	return file_route_api_route_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PrefixSIDTLV) GetType() uint32 {
+	if x != nil {
+		return x.Type
+	}
+	return 0
+}
+
+func (x *PrefixSIDTLV) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
 type ASPathSegment struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -483,12 +1027,14 @@ type ASPathSegment struct {
 
 	AsSequence bool     `protobuf:"varint,1,opt,name=as_sequence,json=asSequence,proto3" json:"as_sequence,omitempty"`
 	Asns       []uint32 `protobuf:"varint,2,rep,packed,name=asns,proto3" json:"asns,omitempty"`
+	// confed is set on AS_CONFED_SEQUENCE and AS_CONFED_SET segments
+	Confed bool `protobuf:"varint,3,opt,name=confed,proto3" json:"confed,omitempty"`
 }
 
 func (x *ASPathSegment) Reset() {
 	*x = ASPathSegment{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_route_api_route_proto_msgTypes[4]
+		mi := &file_route_api_route_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -501,7 +1047,7 @@ func (x *ASPathSegment) String() string {
 func (*ASPathSegment) ProtoMessage() {}
 
 func (x *ASPathSegment) ProtoReflect() protoreflect.Message {
-	mi := &file_route_api_route_proto_msgTypes[4]
+	mi := &file_route_api_route_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -514,7 +1060,7 @@ func (x *ASPathSegment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ASPathSegment.ProtoReflect.Descriptor instead.
 func (*ASPathSegment) Descriptor() ([]byte, []int) {
-	return file_route_api_route_proto_rawDescGZIP(), []int{4}
+	return file_route_api_route_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ASPathSegment) GetAsSequence() bool {
@@ -531,6 +1077,13 @@ func (x *ASPathSegment) GetAsns() []uint32 {
 	return nil
 }
 
+func (x *ASPathSegment) GetConfed() bool {
+	if x != nil {
+		return x.Confed
+	}
+	return false
+}
+
 type LargeCommunity struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -544,7 +1097,7 @@ type LargeCommunity struct {
 func (x *LargeCommunity) Reset() {
 	*x = LargeCommunity{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_route_api_route_proto_msgTypes[5]
+		mi := &file_route_api_route_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -557,7 +1110,7 @@ func (x *LargeCommunity) String() string {
 func (*LargeCommunity) ProtoMessage() {}
 
 func (x *LargeCommunity) ProtoReflect() protoreflect.Message {
-	mi := &file_route_api_route_proto_msgTypes[5]
+	mi := &file_route_api_route_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -570,7 +1123,7 @@ func (x *LargeCommunity) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LargeCommunity.ProtoReflect.Descriptor instead.
 func (*LargeCommunity) Descriptor() ([]byte, []int) {
-	return file_route_api_route_proto_rawDescGZIP(), []int{5}
+	return file_route_api_route_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *LargeCommunity) GetGlobalAdministrator() uint32 {
@@ -609,7 +1162,7 @@ type UnknownPathAttribute struct {
 func (x *UnknownPathAttribute) Reset() {
 	*x = UnknownPathAttribute{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_route_api_route_proto_msgTypes[6]
+		mi := &file_route_api_route_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -622,7 +1175,7 @@ func (x *UnknownPathAttribute) String() string {
 func (*UnknownPathAttribute) ProtoMessage() {}
 
 func (x *UnknownPathAttribute) ProtoReflect() protoreflect.Message {
-	mi := &file_route_api_route_proto_msgTypes[6]
+	mi := &file_route_api_route_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -635,7 +1188,7 @@ func (x *UnknownPathAttribute) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UnknownPathAttribute.ProtoReflect.Descriptor instead.
 func (*UnknownPathAttribute) Descriptor() ([]byte, []int) {
-	return file_route_api_route_proto_rawDescGZIP(), []int{6}
+	return file_route_api_route_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *UnknownPathAttribute) GetOptional() bool {
@@ -684,7 +1237,7 @@ var file_route_api_route_proto_rawDesc = []byte{
 	0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x52, 0x03, 0x70, 0x66,
 	0x78, 0x12, 0x25, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
 	0x32, 0x0f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x50, 0x61, 0x74,
-	0x68, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x22, 0xfa, 0x03, 0x0a, 0x04, 0x50, 0x61, 0x74,
+	0x68, 0x52, 0x05, 0x70, 0x61, 0x74, 0x68, 0x73, 0x22, 0xb7, 0x08, 0x0a, 0x04, 0x50, 0x61, 0x74,
 	0x68, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32,
 	0x14, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x68,
 	0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x36, 0x0a, 0x0b, 0x73,
@@ -700,9 +1253,18 @@ var file_route_api_route_proto_rawDesc = []byte{
 	0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x0c, 0x68, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52,
 	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6c, 0x65,
 	0x61, 0x72, 0x6e, 0x65, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x74, 0x69, 0x6d,
-	0x65, 0x4c, 0x65, 0x61, 0x72, 0x6e, 0x65, 0x64, 0x22, 0x1b, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65,
+	0x65, 0x4c, 0x65, 0x61, 0x72, 0x6e, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x65, 0x73, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x62, 0x65, 0x73, 0x74, 0x12, 0x4a, 0x0a, 0x10,
+	0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x2e, 0x50, 0x61, 0x74, 0x68, 0x2e, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x0f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x69, 0x73, 0x63,
+	0x61, 0x72, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x64, 0x69, 0x73, 0x63, 0x61,
+	0x72, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x73, 0x74, 0x61, 0x6c, 0x65, 0x22, 0x1b, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65,
 	0x12, 0x0a, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03,
-	0x42, 0x47, 0x50, 0x10, 0x01, 0x22, 0xdd, 0x01, 0x0a, 0x0c, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e,
+	0x42, 0x47, 0x50, 0x10, 0x01, 0x22, 0xfd, 0x01, 0x0a, 0x0c, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e,
 	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x10, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e,
 	0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4e, 0x6f, 0x6e, 0x65, 0x10, 0x00, 0x12, 0x22, 0x0a, 0x1e,
 	0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4e, 0x65, 0x78, 0x74,
@@ -716,77 +1278,171 @@ var file_route_api_route_proto_rawDesc = []byte{
 	0x69, 0x64, 0x64, 0x65, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x43, 0x6c, 0x75, 0x73, 0x74,
 	0x65, 0x72, 0x4c, 0x6f, 0x6f, 0x70, 0x10, 0x05, 0x12, 0x1b, 0x0a, 0x17, 0x48, 0x69, 0x64, 0x64,
 	0x65, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4f, 0x54, 0x43, 0x4d, 0x69, 0x73, 0x6d, 0x61,
-	0x74, 0x63, 0x68, 0x10, 0x06, 0x22, 0x34, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x50,
-	0x61, 0x74, 0x68, 0x12, 0x26, 0x0a, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x68, 0x6f, 0x70, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e,
-	0x49, 0x50, 0x52, 0x07, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x22, 0x8a, 0x05, 0x0a, 0x07,
-	0x42, 0x47, 0x50, 0x50, 0x61, 0x74, 0x68, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x61, 0x74, 0x68, 0x5f,
-	0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x0e, 0x70, 0x61, 0x74, 0x68, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72,
-	0x12, 0x26, 0x0a, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x68, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01,
+	0x74, 0x63, 0x68, 0x10, 0x06, 0x12, 0x1e, 0x0a, 0x1a, 0x48, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x49, 0x6e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x4e, 0x65, 0x78, 0x74,
+	0x48, 0x6f, 0x70, 0x10, 0x07, 0x22, 0x8a, 0x03, 0x0a, 0x0f, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4e, 0x6f, 0x6e, 0x65,
+	0x10, 0x00, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x10, 0x01, 0x12,
+	0x19, 0x0a, 0x15, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x65,
+	0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4c, 0x6f, 0x63,
+	0x61, 0x6c, 0x50, 0x72, 0x65, 0x66, 0x10, 0x03, 0x12, 0x1f, 0x0a, 0x1b, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x41, 0x53, 0x50, 0x61, 0x74,
+	0x68, 0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x10, 0x04, 0x12, 0x19, 0x0a, 0x15, 0x53, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4f, 0x72, 0x69, 0x67,
+	0x69, 0x6e, 0x10, 0x05, 0x12, 0x16, 0x0a, 0x12, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4d, 0x45, 0x44, 0x10, 0x06, 0x12, 0x17, 0x0a, 0x13,
+	0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x45,
+	0x42, 0x47, 0x50, 0x10, 0x07, 0x12, 0x1b, 0x0a, 0x17, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x52, 0x6f, 0x75, 0x74, 0x65, 0x72, 0x49, 0x44,
+	0x10, 0x08, 0x12, 0x24, 0x0a, 0x20, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x61, 0x73, 0x6f, 0x6e, 0x43, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4c, 0x69, 0x73, 0x74,
+	0x4c, 0x65, 0x6e, 0x67, 0x74, 0x68, 0x10, 0x09, 0x12, 0x1e, 0x0a, 0x1a, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x50, 0x65, 0x65, 0x72, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x10, 0x0a, 0x12, 0x1a, 0x0a, 0x16, 0x53, 0x65, 0x6c, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x4e, 0x65, 0x78, 0x74, 0x48,
+	0x6f, 0x70, 0x10, 0x0b, 0x12, 0x1c, 0x0a, 0x18, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x49, 0x47, 0x50, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x10, 0x0c, 0x22, 0x34, 0x0a, 0x0a, 0x53, 0x74, 0x61, 0x74, 0x69, 0x63, 0x50, 0x61, 0x74, 0x68,
+	0x12, 0x26, 0x0a, 0x08, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x68, 0x6f, 0x70, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49, 0x50, 0x52,
-	0x07, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x63, 0x61,
-	0x6c, 0x5f, 0x70, 0x72, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6c, 0x6f,
-	0x63, 0x61, 0x6c, 0x50, 0x72, 0x65, 0x66, 0x12, 0x31, 0x0a, 0x07, 0x61, 0x73, 0x5f, 0x70, 0x61,
-	0x74, 0x68, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72,
-	0x6f, 0x75, 0x74, 0x65, 0x2e, 0x41, 0x53, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65, 0x67, 0x6d, 0x65,
-	0x6e, 0x74, 0x52, 0x06, 0x61, 0x73, 0x50, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72,
-	0x69, 0x67, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67,
-	0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x03, 0x6d, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x62, 0x67, 0x70, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x04, 0x65, 0x62, 0x67, 0x70, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x67, 0x70, 0x5f,
-	0x69, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d,
-	0x52, 0x0d, 0x62, 0x67, 0x70, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12,
-	0x23, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49, 0x50, 0x52, 0x06, 0x73, 0x6f,
-	0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74,
-	0x69, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x75,
-	0x6e, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x11, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x5f,
-	0x63, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x19, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x4c, 0x61,
-	0x72, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74, 0x79, 0x52, 0x10, 0x6c, 0x61,
-	0x72, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x23,
-	0x0a, 0x0d, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18,
-	0x0c, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f,
-	0x72, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6c,
-	0x69, 0x73, 0x74, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74,
-	0x65, 0x72, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x4e, 0x0a, 0x12, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77,
-	0x6e, 0x5f, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x55,
-	0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62,
-	0x75, 0x74, 0x65, 0x52, 0x11, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x41, 0x74, 0x74, 0x72,
-	0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x62, 0x6d, 0x70, 0x5f, 0x70, 0x6f,
-	0x73, 0x74, 0x5f, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52,
-	0x0d, 0x62, 0x6d, 0x70, 0x50, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x28,
-	0x0a, 0x10, 0x6f, 0x6e, 0x6c, 0x79, 0x5f, 0x74, 0x6f, 0x5f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d,
-	0x65, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6f, 0x6e, 0x6c, 0x79, 0x54, 0x6f,
-	0x43, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x22, 0x44, 0x0a, 0x0d, 0x41, 0x53, 0x50, 0x61,
-	0x74, 0x68, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x73, 0x5f,
-	0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a,
-	0x61, 0x73, 0x53, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x73,
-	0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x04, 0x61, 0x73, 0x6e, 0x73, 0x22, 0x81,
-	0x01, 0x0a, 0x0e, 0x4c, 0x61, 0x72, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74,
-	0x79, 0x12, 0x31, 0x0a, 0x14, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x5f, 0x61, 0x64, 0x6d, 0x69,
-	0x6e, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52,
-	0x13, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x72,
-	0x61, 0x74, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x70, 0x61, 0x72,
-	0x74, 0x31, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x50, 0x61,
-	0x72, 0x74, 0x31, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x70, 0x61, 0x72, 0x74,
-	0x32, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x50, 0x61, 0x72,
-	0x74, 0x32, 0x22, 0x9f, 0x01, 0x0a, 0x14, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x50, 0x61,
-	0x74, 0x68, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6f,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x6f,
-	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73,
-	0x69, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x74, 0x72, 0x61,
-	0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69,
-	0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61,
-	0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x74, 0x79, 0x70, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x14,
-	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63,
-	0x6f, 0x6d, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x2f, 0x62,
-	0x69, 0x6f, 0x2d, 0x72, 0x64, 0x2f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x07, 0x6e, 0x65, 0x78, 0x74, 0x48, 0x6f, 0x70, 0x22, 0xf8, 0x05, 0x0a, 0x07, 0x42, 0x47, 0x50,
+	0x50, 0x61, 0x74, 0x68, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x61, 0x74, 0x68, 0x5f, 0x69, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x70,
+	0x61, 0x74, 0x68, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x26, 0x0a,
+	0x08, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x68, 0x6f, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49, 0x50, 0x52, 0x07, 0x6e, 0x65,
+	0x78, 0x74, 0x48, 0x6f, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x6c, 0x6f, 0x63, 0x61, 0x6c, 0x5f, 0x70,
+	0x72, 0x65, 0x66, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x6c,
+	0x50, 0x72, 0x65, 0x66, 0x12, 0x31, 0x0a, 0x07, 0x61, 0x73, 0x5f, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74,
+	0x65, 0x2e, 0x41, 0x53, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x52,
+	0x06, 0x61, 0x73, 0x50, 0x61, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69,
+	0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x12,
+	0x10, 0x0a, 0x03, 0x6d, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x6d, 0x65,
+	0x64, 0x12, 0x12, 0x0a, 0x04, 0x65, 0x62, 0x67, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x65, 0x62, 0x67, 0x70, 0x12, 0x25, 0x0a, 0x0e, 0x62, 0x67, 0x70, 0x5f, 0x69, 0x64, 0x65,
+	0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0d, 0x62,
+	0x67, 0x70, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x66, 0x69, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x06,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62,
+	0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49, 0x50, 0x52, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63,
+	0x65, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74, 0x69, 0x65, 0x73,
+	0x18, 0x0a, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x12, 0x46, 0x0a, 0x11, 0x6c, 0x61, 0x72, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x6d,
+	0x6d, 0x75, 0x6e, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x4c, 0x61, 0x72, 0x67, 0x65,
+	0x43, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74, 0x79, 0x52, 0x10, 0x6c, 0x61, 0x72, 0x67, 0x65,
+	0x43, 0x6f, 0x6d, 0x6d, 0x75, 0x6e, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x6f,
+	0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0c, 0x6f, 0x72, 0x69, 0x67, 0x69, 0x6e, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6c, 0x69, 0x73, 0x74,
+	0x18, 0x0d, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x4e, 0x0a, 0x12, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x5f, 0x61,
+	0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1f, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x55, 0x6e, 0x6b, 0x6e,
+	0x6f, 0x77, 0x6e, 0x50, 0x61, 0x74, 0x68, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65,
+	0x52, 0x11, 0x75, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75,
+	0x74, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x62, 0x6d, 0x70, 0x5f, 0x70, 0x6f, 0x73, 0x74, 0x5f,
+	0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x62, 0x6d,
+	0x70, 0x50, 0x6f, 0x73, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x28, 0x0a, 0x10, 0x6f,
+	0x6e, 0x6c, 0x79, 0x5f, 0x74, 0x6f, 0x5f, 0x63, 0x75, 0x73, 0x74, 0x6f, 0x6d, 0x65, 0x72, 0x18,
+	0x10, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0e, 0x6f, 0x6e, 0x6c, 0x79, 0x54, 0x6f, 0x43, 0x75, 0x73,
+	0x74, 0x6f, 0x6d, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x65,
+	0x73, 0x65, 0x6e, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x6d, 0x65, 0x64, 0x50,
+	0x72, 0x65, 0x73, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x18, 0x12, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x33,
+	0x0a, 0x0a, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78, 0x5f, 0x73, 0x69, 0x64, 0x18, 0x13, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x14, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x53, 0x49, 0x44, 0x52, 0x09, 0x70, 0x72, 0x65, 0x66, 0x69, 0x78,
+	0x53, 0x69, 0x64, 0x22, 0xb9, 0x01, 0x0a, 0x09, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x53, 0x49,
+	0x44, 0x12, 0x3f, 0x0a, 0x0b, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x53, 0x49, 0x44, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x0a, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x49, 0x6e, 0x64,
+	0x65, 0x78, 0x12, 0x3e, 0x0a, 0x0f, 0x73, 0x72, 0x76, 0x36, 0x5f, 0x6c, 0x33, 0x5f, 0x73, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x62, 0x69,
+	0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x53, 0x52, 0x76, 0x36, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x52, 0x0d, 0x73, 0x72, 0x76, 0x36, 0x4c, 0x33, 0x53, 0x65, 0x72, 0x76, 0x69,
+	0x63, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x74, 0x6c, 0x76, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x50, 0x72, 0x65,
+	0x66, 0x69, 0x78, 0x53, 0x49, 0x44, 0x54, 0x4c, 0x56, 0x52, 0x04, 0x74, 0x6c, 0x76, 0x73, 0x22,
+	0x41, 0x0a, 0x13, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x53, 0x49, 0x44, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x22, 0x74, 0x0a, 0x0b, 0x53, 0x52, 0x76, 0x36, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63,
+	0x65, 0x12, 0x31, 0x0a, 0x04, 0x73, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1d, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x53, 0x52, 0x76, 0x36,
+	0x53, 0x49, 0x44, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x04,
+	0x73, 0x69, 0x64, 0x73, 0x12, 0x32, 0x0a, 0x08, 0x73, 0x75, 0x62, 0x5f, 0x74, 0x6c, 0x76, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75,
+	0x74, 0x65, 0x2e, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x53, 0x49, 0x44, 0x54, 0x4c, 0x56, 0x52,
+	0x07, 0x73, 0x75, 0x62, 0x54, 0x6c, 0x76, 0x73, 0x22, 0xec, 0x01, 0x0a, 0x12, 0x53, 0x52, 0x76,
+	0x36, 0x53, 0x49, 0x44, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x1d, 0x0a, 0x03, 0x73, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x62,
+	0x69, 0x6f, 0x2e, 0x6e, 0x65, 0x74, 0x2e, 0x49, 0x50, 0x52, 0x03, 0x73, 0x69, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x66, 0x6c, 0x61, 0x67, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x66,
+	0x6c, 0x61, 0x67, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x5f, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x10, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f,
+	0x72, 0x12, 0x39, 0x0a, 0x09, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65,
+	0x2e, 0x53, 0x52, 0x76, 0x36, 0x53, 0x49, 0x44, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72,
+	0x65, 0x52, 0x09, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x12, 0x39, 0x0a, 0x0c,
+	0x73, 0x75, 0x62, 0x5f, 0x73, 0x75, 0x62, 0x5f, 0x74, 0x6c, 0x76, 0x73, 0x18, 0x05, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x62, 0x69, 0x6f, 0x2e, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2e, 0x50,
+	0x72, 0x65, 0x66, 0x69, 0x78, 0x53, 0x49, 0x44, 0x54, 0x4c, 0x56, 0x52, 0x0a, 0x73, 0x75, 0x62,
+	0x53, 0x75, 0x62, 0x54, 0x6c, 0x76, 0x73, 0x22, 0x8e, 0x02, 0x0a, 0x10, 0x53, 0x52, 0x76, 0x36,
+	0x53, 0x49, 0x44, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x75, 0x72, 0x65, 0x12, 0x2a, 0x0a, 0x11,
+	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6c, 0x65,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0f, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x6f, 0x72,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4c, 0x65, 0x6e, 0x12, 0x28, 0x0a, 0x10, 0x6c, 0x6f, 0x63, 0x61,
+	0x74, 0x6f, 0x72, 0x5f, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6c, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0e, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x6f, 0x72, 0x4e, 0x6f, 0x64, 0x65, 0x4c,
+	0x65, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c,
+	0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4c, 0x65, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x6c, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x61, 0x72, 0x67,
+	0x75, 0x6d, 0x65, 0x6e, 0x74, 0x4c, 0x65, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x65, 0x6e, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x4c, 0x65, 0x6e, 0x12, 0x31, 0x0a, 0x14, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x13, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x69,
+	0x6f, 0x6e, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x38, 0x0a, 0x0c, 0x50, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x53, 0x49, 0x44, 0x54, 0x4c, 0x56, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x22, 0x5c, 0x0a, 0x0d, 0x41, 0x53, 0x50, 0x61, 0x74, 0x68, 0x53, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x73, 0x5f, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e,
+	0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x61, 0x73, 0x53, 0x65, 0x71, 0x75,
+	0x65, 0x6e, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x73, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0d, 0x52, 0x04, 0x61, 0x73, 0x6e, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x6f, 0x6e, 0x66,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x63, 0x6f, 0x6e, 0x66, 0x65, 0x64,
+	0x22, 0x81, 0x01, 0x0a, 0x0e, 0x4c, 0x61, 0x72, 0x67, 0x65, 0x43, 0x6f, 0x6d, 0x6d, 0x75, 0x6e,
+	0x69, 0x74, 0x79, 0x12, 0x31, 0x0a, 0x14, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x5f, 0x61, 0x64,
+	0x6d, 0x69, 0x6e, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x13, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x69, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x70,
+	0x61, 0x72, 0x74, 0x31, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61,
+	0x50, 0x61, 0x72, 0x74, 0x31, 0x12, 0x1d, 0x0a, 0x0a, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x70, 0x61,
+	0x72, 0x74, 0x32, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x64, 0x61, 0x74, 0x61, 0x50,
+	0x61, 0x72, 0x74, 0x32, 0x22, 0x9f, 0x01, 0x0a, 0x14, 0x55, 0x6e, 0x6b, 0x6e, 0x6f, 0x77, 0x6e,
+	0x50, 0x61, 0x74, 0x68, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x08, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x69, 0x74, 0x69, 0x76, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x72,
+	0x74, 0x69, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x70, 0x61, 0x72, 0x74,
+	0x69, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x63, 0x6f, 0x64, 0x65,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x74, 0x79, 0x70, 0x65, 0x43, 0x6f, 0x64, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
+	0x2f, 0x62, 0x69, 0x6f, 0x2d, 0x72, 0x64, 0x2f, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x2f, 0x61, 0x70,
+	0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -801,39 +1457,56 @@ func file_route_api_route_proto_rawDescGZIP() []byte {
 	return file_route_api_route_proto_rawDescData
 }
 
-var file_route_api_route_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_route_api_route_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_route_api_route_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_route_api_route_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_route_api_route_proto_goTypes = []interface{}{
 	(Path_Type)(0),               // 0: bio.route.Path.Type
 	(Path_HiddenReason)(0),       // 1: bio.route.Path.HiddenReason
-	(*Route)(nil),                // 2: bio.route.Route
-	(*Path)(nil),                 // 3: bio.route.Path
-	(*StaticPath)(nil),           // 4: bio.route.StaticPath
-	(*BGPPath)(nil),              // 5: bio.route.BGPPath
-	(*ASPathSegment)(nil),        // 6: bio.route.ASPathSegment
-	(*LargeCommunity)(nil),       // 7: bio.route.LargeCommunity
-	(*UnknownPathAttribute)(nil), // 8: bio.route.UnknownPathAttribute
-	(*api.Prefix)(nil),           // 9: bio.net.Prefix
-	(*api.IP)(nil),               // 10: bio.net.IP
+	(Path_SelectionReason)(0),    // 2: bio.route.Path.SelectionReason
+	(*Route)(nil),                // 3: bio.route.Route
+	(*Path)(nil),                 // 4: bio.route.Path
+	(*StaticPath)(nil),           // 5: bio.route.StaticPath
+	(*BGPPath)(nil),              // 6: bio.route.BGPPath
+	(*PrefixSID)(nil),            // 7: bio.route.PrefixSID
+	(*PrefixSIDLabelIndex)(nil),  // 8: bio.route.PrefixSIDLabelIndex
+	(*SRv6Service)(nil),          // 9: bio.route.SRv6Service
+	(*SRv6SIDInformation)(nil),   // 10: bio.route.SRv6SIDInformation
+	(*SRv6SIDStructure)(nil),     // 11: bio.route.SRv6SIDStructure
+	(*PrefixSIDTLV)(nil),         // 12: bio.route.PrefixSIDTLV
+	(*ASPathSegment)(nil),        // 13: bio.route.ASPathSegment
+	(*LargeCommunity)(nil),       // 14: bio.route.LargeCommunity
+	(*UnknownPathAttribute)(nil), // 15: bio.route.UnknownPathAttribute
+	(*api.Prefix)(nil),           // 16: bio.net.Prefix
+	(*api.IP)(nil),               // 17: bio.net.IP
 }
 var file_route_api_route_proto_depIdxs = []int32{
-	9,  // 0: bio.route.Route.pfx:type_name -> bio.net.Prefix
-	3,  // 1: bio.route.Route.paths:type_name -> bio.route.Path
+	16, // 0: bio.route.Route.pfx:type_name -> bio.net.Prefix
+	4,  // 1: bio.route.Route.paths:type_name -> bio.route.Path
 	0,  // 2: bio.route.Path.type:type_name -> bio.route.Path.Type
-	4,  // 3: bio.route.Path.static_path:type_name -> bio.route.StaticPath
-	5,  // 4: bio.route.Path.bgp_path:type_name -> bio.route.BGPPath
+	5,  // 3: bio.route.Path.static_path:type_name -> bio.route.StaticPath
+	6,  // 4: bio.route.Path.bgp_path:type_name -> bio.route.BGPPath
 	1,  // 5: bio.route.Path.hidden_reason:type_name -> bio.route.Path.HiddenReason
-	10, // 6: bio.route.StaticPath.next_hop:type_name -> bio.net.IP
-	10, // 7: bio.route.BGPPath.next_hop:type_name -> bio.net.IP
-	6,  // 8: bio.route.BGPPath.as_path:type_name -> bio.route.ASPathSegment
-	10, // 9: bio.route.BGPPath.source:type_name -> bio.net.IP
-	7,  // 10: bio.route.BGPPath.large_communities:type_name -> bio.route.LargeCommunity
-	8,  // 11: bio.route.BGPPath.unknown_attributes:type_name -> bio.route.UnknownPathAttribute
-	12, // [12:12] is the sub-list for method output_type
-	12, // [12:12] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	2,  // 6: bio.route.Path.selection_reason:type_name -> bio.route.Path.SelectionReason
+	17, // 7: bio.route.StaticPath.next_hop:type_name -> bio.net.IP
+	17, // 8: bio.route.BGPPath.next_hop:type_name -> bio.net.IP
+	13, // 9: bio.route.BGPPath.as_path:type_name -> bio.route.ASPathSegment
+	17, // 10: bio.route.BGPPath.source:type_name -> bio.net.IP
+	14, // 11: bio.route.BGPPath.large_communities:type_name -> bio.route.LargeCommunity
+	15, // 12: bio.route.BGPPath.unknown_attributes:type_name -> bio.route.UnknownPathAttribute
+	7,  // 13: bio.route.BGPPath.prefix_sid:type_name -> bio.route.PrefixSID
+	8,  // 14: bio.route.PrefixSID.label_index:type_name -> bio.route.PrefixSIDLabelIndex
+	9,  // 15: bio.route.PrefixSID.srv6_l3_service:type_name -> bio.route.SRv6Service
+	12, // 16: bio.route.PrefixSID.tlvs:type_name -> bio.route.PrefixSIDTLV
+	10, // 17: bio.route.SRv6Service.sids:type_name -> bio.route.SRv6SIDInformation
+	12, // 18: bio.route.SRv6Service.sub_tlvs:type_name -> bio.route.PrefixSIDTLV
+	17, // 19: bio.route.SRv6SIDInformation.sid:type_name -> bio.net.IP
+	11, // 20: bio.route.SRv6SIDInformation.structure:type_name -> bio.route.SRv6SIDStructure
+	12, // 21: bio.route.SRv6SIDInformation.sub_sub_tlvs:type_name -> bio.route.PrefixSIDTLV
+	22, // [22:22] is the sub-list for method output_type
+	22, // [22:22] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_route_api_route_proto_init() }
@@ -891,7 +1564,7 @@ func file_route_api_route_proto_init() {
 			}
 		}
 		file_route_api_route_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ASPathSegment); i {
+			switch v := v.(*PrefixSID); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -903,7 +1576,7 @@ func file_route_api_route_proto_init() {
 			}
 		}
 		file_route_api_route_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LargeCommunity); i {
+			switch v := v.(*PrefixSIDLabelIndex); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -915,6 +1588,78 @@ func file_route_api_route_proto_init() {
 			}
 		}
 		file_route_api_route_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SRv6Service); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_api_route_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SRv6SIDInformation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_api_route_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SRv6SIDStructure); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_api_route_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrefixSIDTLV); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_api_route_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ASPathSegment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_api_route_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LargeCommunity); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_route_api_route_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*UnknownPathAttribute); i {
 			case 0:
 				return &v.state
@@ -932,8 +1677,8 @@ func file_route_api_route_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_route_api_route_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   7,
+			NumEnums:      3,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -3,7 +3,9 @@ package route
 import (
 	"crypto/sha256"
 	"fmt"
+	"math"
 	"strings"
+	"unsafe"
 
 	"github.com/bio-routing/tflow2/convert"
 
@@ -14,15 +16,21 @@ import (
 
 // BGPPath represents a set of BGP path attributes
 type BGPPath struct {
-	BGPPathA          *BGPPathA
-	ASPath            *types.ASPath
-	ClusterList       *types.ClusterList
-	Communities       *types.Communities
-	LargeCommunities  *types.LargeCommunities
-	UnknownAttributes []types.UnknownPathAttribute
-	PathIdentifier    uint32
-	ASPathLen         uint16
-	BMPPostPolicy     bool // BMPPostPolicy fields is a hack used in BMP to differentiate between pre/post policy routes (L flag of the per peer header)
+	BGPPathA            *BGPPathA
+	ASPath              *types.ASPath
+	ClusterList         *types.ClusterList
+	Communities         *types.Communities
+	LargeCommunities    *types.LargeCommunities
+	PMSITunnel          *types.PMSITunnel
+	TunnelEncapsulation *types.TunnelEncapsulation
+	PrefixSID           *types.PrefixSID
+	LabelStack          *types.LabelStack // LabelStack holds the MPLS labels of a labeled unicast (RFC8277) route
+	UnknownAttributes   []types.UnknownPathAttribute
+	PathIdentifier      uint32
+	ASPathLen           uint16
+	Weight              uint32 // Weight is a local only attribute (never advertised), paths with higher weight are preferred
+	IGPMetric           uint32 // IGPMetric is the local only interior cost to reach the next hop, paths with lower cost are preferred
+	BMPPostPolicy       bool   // BMPPostPolicy fields is a hack used in BMP to differentiate between pre/post policy routes (L flag of the per peer header)
 }
 
 // BGPPathA represents cachable BGP path attributes
@@ -38,6 +46,9 @@ type BGPPathA struct {
 	AtomicAggregate bool
 	Origin          uint8
 	OnlyToCustomer  uint32
+
+	// MEDPresent is set if the path carries a MED. Otherwise MED is 0 and only used as its default.
+	MEDPresent bool
 }
 
 // NewBGPPathA creates a new BGPPathA
@@ -49,6 +60,21 @@ func NewBGPPathA() *BGPPathA {
 	}
 }
 
+// Copy creates a copy of a BGPPathA. The copy is not part of the cache until Dedup() is called on it.
+func (b *BGPPathA) Copy() *BGPPathA {
+	if b == nil {
+		return nil
+	}
+
+	cp := *b
+	if b.Aggregator != nil {
+		aggr := *b.Aggregator
+		cp.Aggregator = &aggr
+	}
+
+	return &cp
+}
+
 func (b *BGPPathA) Dedup() *BGPPathA {
 	return bgpC.get(b)
 }
@@ -74,6 +100,7 @@ func (b *BGPPath) ToProto() *api.BGPPath {
 		a.LocalPref = b.BGPPathA.LocalPref
 		a.Origin = uint32(b.BGPPathA.Origin)
 		a.Med = b.BGPPathA.MED
+		a.MedPresent = b.BGPPathA.MEDPresent
 		a.Ebgp = b.BGPPathA.EBGP
 		a.BgpIdentifier = b.BGPPathA.BGPIdentifier
 		a.OriginatorId = b.BGPPathA.OriginatorID
@@ -109,6 +136,13 @@ func (b *BGPPath) ToProto() *api.BGPPath {
 		}
 	}
 
+	if b.LabelStack != nil {
+		a.Labels = make([]uint32, len(*b.LabelStack))
+		copy(a.Labels, *b.LabelStack)
+	}
+
+	a.PrefixSid = b.PrefixSID.ToProto()
+
 	for i := range b.UnknownAttributes {
 		a.UnknownAttributes[i] = b.UnknownAttributes[i].ToProto()
 	}
@@ -125,6 +159,7 @@ func BGPPathFromProtoBGPPath(pb *api.BGPPath, dedup bool) *BGPPath {
 			OriginatorID:   pb.OriginatorId,
 			Origin:         uint8(pb.Origin),
 			MED:            pb.Med,
+			MEDPresent:     pb.MedPresent,
 			EBGP:           pb.Ebgp,
 			BGPIdentifier:  pb.BgpIdentifier,
 			Source:         bnet.IPFromProtoIP(pb.Source).Ptr(),
@@ -169,6 +204,15 @@ func BGPPathFromProtoBGPPath(pb *api.BGPPath, dedup bool) *BGPPath {
 		copy(*p.ClusterList, pb.ClusterList)
 	}
 
+	if len(pb.Labels) > 0 {
+		labels := make(types.LabelStack, len(pb.Labels))
+		p.LabelStack = &labels
+		copy(*p.LabelStack, pb.Labels)
+	}
+
+	p.PrefixSID = types.PrefixSIDFromProtoPrefixSID(pb.PrefixSid)
+
+	p.NormalizeCommunities()
 	return p
 }
 
@@ -205,6 +249,21 @@ func (b *BGPPath) Length() uint16 {
 		onlyToCustomer = 4
 	}
 
+	pmsiTunnelLen := uint16(0)
+	if b.PMSITunnel != nil {
+		pmsiTunnelLen = 3 + 5 + uint16(len(b.PMSITunnel.TunnelID))
+	}
+
+	tunnelEncapsulationLen := uint16(0)
+	if b.TunnelEncapsulation != nil && len(*b.TunnelEncapsulation) != 0 {
+		tunnelEncapsulationLen = b.TunnelEncapsulation.WireLength()
+	}
+
+	prefixSIDLen := uint16(0)
+	if b.PrefixSID != nil {
+		prefixSIDLen = b.PrefixSID.WireLength()
+	}
+
 	unknownAttributesLen := uint16(0)
 	if b.UnknownAttributes != nil {
 		for _, unknownAttr := range b.UnknownAttributes {
@@ -212,15 +271,33 @@ func (b *BGPPath) Length() uint16 {
 		}
 	}
 
-	return 4*7 + 4 + asPathLen + communitiesLen + largeCommunitiesLen + clusterListLen + originatorID + onlyToCustomer + unknownAttributesLen
+	return 4*7 + 4 + asPathLen + communitiesLen + largeCommunitiesLen + clusterListLen + originatorID + onlyToCustomer + pmsiTunnelLen + tunnelEncapsulationLen + prefixSIDLen + unknownAttributesLen
 }
 
 // ECMP determines if routes b and c are euqal in terms of ECMP
 func (b *BGPPath) ECMP(c *BGPPath) bool {
-	return b.BGPPathA.LocalPref == c.BGPPathA.LocalPref &&
-		b.ASPathLen == c.ASPathLen &&
-		b.BGPPathA.MED == c.BGPPathA.MED &&
-		b.BGPPathA.Origin == c.BGPPathA.Origin
+	return b.ECMPWithOptions(c, nil)
+}
+
+// ECMPWithOptions determines if routes b and c are equal in terms of ECMP given the selection options opts
+func (b *BGPPath) ECMPWithOptions(c *BGPPath, opts *SelectionOptions) bool {
+	if b.Weight != c.Weight ||
+		b.IGPMetric != c.IGPMetric ||
+		b.BGPPathA.LocalPref != c.BGPPathA.LocalPref ||
+		b.BGPPathA.med(opts) != c.BGPPathA.med(opts) ||
+		b.BGPPathA.Origin != c.BGPPathA.Origin {
+		return false
+	}
+
+	if !opts.ignoreASPathLength() && b.ASPathLen != c.ASPathLen {
+		return false
+	}
+
+	if opts.asPathMultipathStrict() && !b.ASPath.Compare(c.ASPath) {
+		return false
+	}
+
+	return true
 }
 
 // Compare checks if paths are the same
@@ -229,6 +306,14 @@ func (b *BGPPath) Compare(c *BGPPath) bool {
 		return false
 	}
 
+	if b.Weight != c.Weight {
+		return false
+	}
+
+	if b.IGPMetric != c.IGPMetric {
+		return false
+	}
+
 	if !b.BGPPathA.compare(c.BGPPathA) {
 		return false
 	}
@@ -249,6 +334,22 @@ func (b *BGPPath) Compare(c *BGPPath) bool {
 		return false
 	}
 
+	if !b.PMSITunnel.Compare(c.PMSITunnel) {
+		return false
+	}
+
+	if !b.TunnelEncapsulation.Compare(c.TunnelEncapsulation) {
+		return false
+	}
+
+	if !b.PrefixSID.Compare(c.PrefixSID) {
+		return false
+	}
+
+	if !b.LabelStack.Compare(c.LabelStack) {
+		return false
+	}
+
 	if !b.compareUnknownAttributes(c) {
 		return false
 	}
@@ -348,6 +449,16 @@ func (b *BGPPath) compareUnknownAttributes(c *BGPPath) bool {
 	return true
 }
 
+// med gets the MED used in best path selection. A missing MED is the lowest possible one unless opts asks to treat it as
+// the highest.
+func (b *BGPPathA) med(opts *SelectionOptions) uint32 {
+	if !b.MEDPresent && opts.medMissingAsWorst() {
+		return math.MaxUint32
+	}
+
+	return b.MED
+}
+
 func (b *BGPPathA) compare(c *BGPPathA) bool {
 	if b.NextHop.Compare(c.NextHop) != 0 {
 		return false
@@ -361,7 +472,7 @@ func (b *BGPPathA) compare(c *BGPPathA) bool {
 		return false
 	}
 
-	if b.EBGP != c.EBGP || b.AtomicAggregate != c.AtomicAggregate || b.Origin != c.Origin {
+	if b.EBGP != c.EBGP || b.AtomicAggregate != c.AtomicAggregate || b.Origin != c.Origin || b.MEDPresent != c.MEDPresent {
 		return false
 	}
 
@@ -389,156 +500,145 @@ func (b *BGPPath) Equal(c *BGPPath) bool {
 
 // Select returns negative if b < c, 0 if paths are equal, positive if b > c
 func (b *BGPPath) Select(c *BGPPath) int8 {
-	if c.BGPPathA.LocalPref < b.BGPPathA.LocalPref {
-		return 1
+	return b.SelectWithOptions(c, nil)
+}
+
+// SelectWithOptions is like Select with the best path selection altered by opts
+func (b *BGPPath) SelectWithOptions(c *BGPPath, opts *SelectionOptions) int8 {
+	return int8(-CompareBGPPathsWithOptions(b, c, opts))
+}
+
+// CompareBGPPaths compares a and b in the order of the BGP best path selection.
+// It returns a negative value if a is preferred over b, 0 if both are equally preferred and a positive value if b is preferred over a.
+// Sorting paths in ascending order using CompareBGPPaths puts the best path first.
+func CompareBGPPaths(a, b *BGPPath) int {
+	return CompareBGPPathsWithOptions(a, b, nil)
+}
+
+// CompareBGPPathsWithOptions is like CompareBGPPaths with the best path selection altered by opts
+func CompareBGPPathsWithOptions(a, b *BGPPath, opts *SelectionOptions) int {
+	ret, _ := compareBGPPaths(a, b, opts)
+	return ret
+}
+
+// compareBGPPaths is like CompareBGPPathsWithOptions additionally returning the deciding selection step
+func compareBGPPaths(a, b *BGPPath, opts *SelectionOptions) (int, uint8) {
+	// Weight is evaluated before any of the RFC4271 steps
+	if b.Weight < a.Weight {
+		return -1, SelectionReasonWeight
+	}
+
+	if b.Weight > a.Weight {
+		return 1, SelectionReasonWeight
+	}
+
+	if b.BGPPathA.LocalPref < a.BGPPathA.LocalPref {
+		return -1, SelectionReasonLocalPref
 	}
 
-	if c.BGPPathA.LocalPref > b.BGPPathA.LocalPref {
-		return -1
+	if b.BGPPathA.LocalPref > a.BGPPathA.LocalPref {
+		return 1, SelectionReasonLocalPref
 	}
 
 	// 9.1.2.2.  Breaking Ties (Phase 2)
 
 	// a)
-	if c.ASPathLen > b.ASPathLen {
-		return 1
-	}
+	if !opts.ignoreASPathLength() {
+		if b.ASPathLen > a.ASPathLen {
+			return -1, SelectionReasonASPathLength
+		}
 
-	if c.ASPathLen < b.ASPathLen {
-		return -1
+		if b.ASPathLen < a.ASPathLen {
+			return 1, SelectionReasonASPathLength
+		}
 	}
 
 	// b)
-	if c.BGPPathA.Origin > b.BGPPathA.Origin {
-		return 1
+	if b.BGPPathA.Origin > a.BGPPathA.Origin {
+		return -1, SelectionReasonOrigin
 	}
 
-	if c.BGPPathA.Origin < b.BGPPathA.Origin {
-		return -1
+	if b.BGPPathA.Origin < a.BGPPathA.Origin {
+		return 1, SelectionReasonOrigin
 	}
 
 	// c)
-	if c.BGPPathA.MED > b.BGPPathA.MED {
-		return 1
+	if b.BGPPathA.med(opts) > a.BGPPathA.med(opts) {
+		return -1, SelectionReasonMED
 	}
 
-	if c.BGPPathA.MED < b.BGPPathA.MED {
-		return -1
+	if b.BGPPathA.med(opts) < a.BGPPathA.med(opts) {
+		return 1, SelectionReasonMED
 	}
 
 	// d)
-	if c.BGPPathA.EBGP && !b.BGPPathA.EBGP {
-		return -1
+	if b.BGPPathA.EBGP && !a.BGPPathA.EBGP {
+		return 1, SelectionReasonEBGP
 	}
 
-	if !c.BGPPathA.EBGP && b.BGPPathA.EBGP {
-		return 1
+	if !b.BGPPathA.EBGP && a.BGPPathA.EBGP {
+		return -1, SelectionReasonEBGP
 	}
 
-	// e) TODO: interior cost (hello IS-IS and OSPF)
+	// e)
+	if b.IGPMetric > a.IGPMetric {
+		return -1, SelectionReasonIGPMetric
+	}
+
+	if b.IGPMetric < a.IGPMetric {
+		return 1, SelectionReasonIGPMetric
+	}
 
 	// f) + RFC4456 9. (Route Reflection)
-	bgpIdentifierC := c.BGPPathA.BGPIdentifier
 	bgpIdentifierB := b.BGPPathA.BGPIdentifier
+	bgpIdentifierA := a.BGPPathA.BGPIdentifier
 
 	// IF an OriginatorID (set by an RR) is present, use this instead of Originator
-	if c.BGPPathA.OriginatorID != 0 {
-		bgpIdentifierC = c.BGPPathA.OriginatorID
-	}
-
 	if b.BGPPathA.OriginatorID != 0 {
 		bgpIdentifierB = b.BGPPathA.OriginatorID
 	}
 
-	if bgpIdentifierC < bgpIdentifierB {
-		return 1
+	if a.BGPPathA.OriginatorID != 0 {
+		bgpIdentifierA = a.BGPPathA.OriginatorID
+	}
+
+	if bgpIdentifierB < bgpIdentifierA {
+		return -1, SelectionReasonRouterID
 	}
 
-	if bgpIdentifierC > bgpIdentifierB {
-		return -1
+	if bgpIdentifierB > bgpIdentifierA {
+		return 1, SelectionReasonRouterID
 	}
 
-	if c.ClusterList != nil && b.ClusterList != nil {
+	if b.ClusterList != nil && a.ClusterList != nil {
 		// Additionally check for the shorter ClusterList
-		if len(*c.ClusterList) < len(*b.ClusterList) {
-			return 1
+		if len(*b.ClusterList) < len(*a.ClusterList) {
+			return -1, SelectionReasonClusterListLength
 		}
 
-		if len(*c.ClusterList) > len(*b.ClusterList) {
-			return -1
+		if len(*b.ClusterList) > len(*a.ClusterList) {
+			return 1, SelectionReasonClusterListLength
 		}
 	}
 
 	// g)
-	if c.BGPPathA.Source.Compare(b.BGPPathA.Source) == -1 {
-		return 1
+	if b.BGPPathA.Source.Compare(a.BGPPathA.Source) == -1 {
+		return -1, SelectionReasonPeerAddress
 	}
 
-	if c.BGPPathA.Source.Compare(b.BGPPathA.Source) == 1 {
-		return -1
+	if b.BGPPathA.Source.Compare(a.BGPPathA.Source) == 1 {
+		return 1, SelectionReasonPeerAddress
 	}
 
-	if c.BGPPathA.NextHop.Compare(b.BGPPathA.NextHop) == -1 {
-		return 1
+	if b.BGPPathA.NextHop.Compare(a.BGPPathA.NextHop) == -1 {
+		return -1, SelectionReasonNextHop
 	}
 
-	if c.BGPPathA.NextHop.Compare(b.BGPPathA.NextHop) == 1 {
-		return -1
+	if b.BGPPathA.NextHop.Compare(a.BGPPathA.NextHop) == 1 {
+		return 1, SelectionReasonNextHop
 	}
 
-	return 0
-}
-
-func (b *BGPPath) betterECMP(c *BGPPath) bool {
-	if c.BGPPathA.LocalPref < b.BGPPathA.LocalPref {
-		return false
-	}
-
-	if c.BGPPathA.LocalPref > b.BGPPathA.LocalPref {
-		return true
-	}
-
-	if c.ASPathLen > b.ASPathLen {
-		return false
-	}
-
-	if c.ASPathLen < b.ASPathLen {
-		return true
-	}
-
-	if c.BGPPathA.Origin > b.BGPPathA.Origin {
-		return false
-	}
-
-	if c.BGPPathA.Origin < b.BGPPathA.Origin {
-		return true
-	}
-
-	if c.BGPPathA.MED > b.BGPPathA.MED {
-		return false
-	}
-
-	if c.BGPPathA.MED < b.BGPPathA.MED {
-		return true
-	}
-
-	return false
-}
-
-func (b *BGPPath) better(c *BGPPath) bool {
-	if b.betterECMP(c) {
-		return true
-	}
-
-	if c.BGPPathA.BGPIdentifier < b.BGPPathA.BGPIdentifier {
-		return true
-	}
-
-	if c.BGPPathA.Source.Compare(b.BGPPathA.Source) == -1 {
-		return true
-	}
-
-	return false
+	return 0, SelectionReasonNone
 }
 
 // Print all known information about a route in logfile friendly format
@@ -568,6 +668,12 @@ func (b *BGPPath) String() string {
 	fmt.Fprintf(buf, "MED: %d, ", b.BGPPathA.MED)
 	fmt.Fprintf(buf, "Path ID: %d, ", b.PathIdentifier)
 	fmt.Fprintf(buf, "Source: %s, ", b.BGPPathA.Source)
+	if b.Weight != 0 {
+		fmt.Fprintf(buf, "Weight: %d, ", b.Weight)
+	}
+	if b.IGPMetric != 0 {
+		fmt.Fprintf(buf, "IGP Metric: %d, ", b.IGPMetric)
+	}
 	if b.BGPPathA.OnlyToCustomer != 0 {
 		fmt.Fprintf(buf, "OnlyToCustomer: %d, ", b.BGPPathA.OnlyToCustomer)
 	}
@@ -577,6 +683,18 @@ func (b *BGPPath) String() string {
 	if b.LargeCommunities != nil {
 		fmt.Fprintf(buf, "LargeCommunities: %v", *b.LargeCommunities)
 	}
+	if b.PMSITunnel != nil {
+		fmt.Fprintf(buf, ", PMSITunnel: %s", b.PMSITunnel.String())
+	}
+	if b.TunnelEncapsulation != nil {
+		fmt.Fprintf(buf, ", TunnelEncapsulation: %s", b.TunnelEncapsulation.String())
+	}
+	if b.PrefixSID != nil {
+		fmt.Fprintf(buf, ", PrefixSID: %s", b.PrefixSID.String())
+	}
+	if b.LabelStack != nil {
+		fmt.Fprintf(buf, ", Labels: %s", b.LabelStack.String())
+	}
 
 	if b.BGPPathA.OriginatorID != 0 {
 		oid := convert.Uint32Byte(b.BGPPathA.OriginatorID)
@@ -585,6 +703,15 @@ func (b *BGPPath) String() string {
 	if b.ClusterList != nil {
 		fmt.Fprintf(buf, ", ClusterList %s", b.ClusterListString())
 	}
+	if b.BGPPathA.Aggregator != nil {
+		fmt.Fprintf(buf, ", Aggregator: %d %s", b.BGPPathA.Aggregator.ASN, dottedQuad(b.BGPPathA.Aggregator.Address))
+	}
+	if b.BGPPathA.AtomicAggregate {
+		fmt.Fprintf(buf, ", AtomicAggregate")
+	}
+	for _, a := range b.UnknownAttributes {
+		fmt.Fprintf(buf, ", Unknown Attribute %d (optional=%t, transitive=%t, partial=%t): %x", a.TypeCode, a.Optional, a.Transitive, a.Partial, a.Value)
+	}
 
 	return buf.String()
 }
@@ -616,6 +743,12 @@ func (b *BGPPath) Print() string {
 	fmt.Fprintf(buf, "\t\tMED: %d\n", b.BGPPathA.MED)
 	fmt.Fprintf(buf, "\t\tPath ID: %d\n", b.PathIdentifier)
 	fmt.Fprintf(buf, "\t\tSource: %s\n", b.BGPPathA.Source)
+	if b.Weight != 0 {
+		fmt.Fprintf(buf, "\t\tWeight: %d\n", b.Weight)
+	}
+	if b.IGPMetric != 0 {
+		fmt.Fprintf(buf, "\t\tIGP Metric: %d\n", b.IGPMetric)
+	}
 	if b.BGPPathA.OnlyToCustomer != 0 {
 		fmt.Fprintf(buf, "\t\tOnlyToCustomer: %d\n", b.BGPPathA.OnlyToCustomer)
 	}
@@ -625,6 +758,18 @@ func (b *BGPPath) Print() string {
 	if b.LargeCommunities != nil {
 		fmt.Fprintf(buf, "\t\tLargeCommunities: %v\n", *b.LargeCommunities)
 	}
+	if b.PMSITunnel != nil {
+		fmt.Fprintf(buf, "\t\tPMSITunnel: %s\n", b.PMSITunnel.String())
+	}
+	if b.TunnelEncapsulation != nil {
+		fmt.Fprintf(buf, "\t\tTunnelEncapsulation: %s\n", b.TunnelEncapsulation.String())
+	}
+	if b.PrefixSID != nil {
+		fmt.Fprintf(buf, "\t\tPrefixSID: %s\n", b.PrefixSID.String())
+	}
+	if b.LabelStack != nil {
+		fmt.Fprintf(buf, "\t\tLabels: %s\n", b.LabelStack.String())
+	}
 
 	if b.BGPPathA.OriginatorID != 0 {
 		oid := convert.Uint32Byte(b.BGPPathA.OriginatorID)
@@ -648,7 +793,7 @@ func (b *BGPPath) Prepend(asn uint32, times uint16) {
 	}
 
 	first := (*b.ASPath)[0]
-	if first.Type == types.ASSet {
+	if first.Type != types.ASSequence {
 		b.insertNewASSequence()
 	}
 
@@ -667,6 +812,34 @@ func (b *BGPPath) Prepend(asn uint32, times uint16) {
 	b.ASPathLen = b.ASPath.Length()
 }
 
+// RemovePrivateASNs removes all private ASNs except the left most one (the neighbor) from the AS path
+func (b *BGPPath) RemovePrivateASNs() {
+	if b.ASPath == nil {
+		return
+	}
+
+	pa := b.ASPath.RemovePrivateASNs()
+	b.ASPath = &pa
+	b.ASPathLen = b.ASPath.Length()
+}
+
+// RemoveConfedSegments removes all confederation segments from the AS path
+func (b *BGPPath) RemoveConfedSegments() {
+	if b.ASPath == nil {
+		return
+	}
+
+	pa := b.ASPath.RemoveConfedSegments()
+	b.ASPath = &pa
+	b.ASPathLen = b.ASPath.Length()
+}
+
+// NormalizeCommunities sorts and deduplicates communities and large communities so equal sets compare and hash equally
+func (b *BGPPath) NormalizeCommunities() {
+	b.Communities.Normalize()
+	b.LargeCommunities.Normalize()
+}
+
 func (b *BGPPath) insertNewASSequence() {
 	pa := make(types.ASPath, len(*b.ASPath)+1)
 	copy(pa[1:], (*b.ASPath))
@@ -686,6 +859,10 @@ func (b *BGPPath) Copy() *BGPPath {
 
 	cp := *b
 
+	if cp.BGPPathA != nil {
+		cp.BGPPathA = cp.BGPPathA.Copy()
+	}
+
 	if cp.ASPath != nil {
 		asPath := make(types.ASPath, len(*cp.ASPath))
 		cp.ASPath = &asPath
@@ -710,36 +887,107 @@ func (b *BGPPath) Copy() *BGPPath {
 		copy(*cp.ClusterList, *b.ClusterList)
 	}
 
+	cp.PMSITunnel = b.PMSITunnel.Copy()
+	cp.TunnelEncapsulation = b.TunnelEncapsulation.Copy()
+	cp.PrefixSID = b.PrefixSID.Copy()
+	cp.LabelStack = b.LabelStack.Copy()
+
+	if b.UnknownAttributes != nil {
+		cp.UnknownAttributes = make([]types.UnknownPathAttribute, len(b.UnknownAttributes))
+		for i, a := range b.UnknownAttributes {
+			cp.UnknownAttributes[i] = a
+			cp.UnknownAttributes[i].Value = append([]byte(nil), a.Value...)
+		}
+	}
+
 	return &cp
 }
 
+// EstimatedSize estimates the memory used by the path attributes in bytes
+func (b *BGPPath) EstimatedSize() uint64 {
+	if b == nil {
+		return 0
+	}
+
+	size := uint64(unsafe.Sizeof(*b))
+	if b.BGPPathA != nil {
+		size += uint64(unsafe.Sizeof(*b.BGPPathA))
+	}
+
+	if b.ASPath != nil {
+		for _, seg := range *b.ASPath {
+			size += uint64(unsafe.Sizeof(seg)) + uint64(4*len(seg.ASNs))
+		}
+	}
+
+	if b.Communities != nil {
+		size += uint64(4 * len(*b.Communities))
+	}
+
+	if b.LargeCommunities != nil {
+		size += uint64(len(*b.LargeCommunities)) * uint64(unsafe.Sizeof(types.LargeCommunity{}))
+	}
+
+	if b.ClusterList != nil {
+		size += uint64(4 * len(*b.ClusterList))
+	}
+
+	if b.LabelStack != nil {
+		size += uint64(4 * len(*b.LabelStack))
+	}
+
+	for _, a := range b.UnknownAttributes {
+		size += uint64(unsafe.Sizeof(a)) + uint64(len(a.Value))
+	}
+
+	return size
+}
+
+func (b *BGPPath) unknownAttributesString() string {
+	buf := &strings.Builder{}
+	for _, a := range b.UnknownAttributes {
+		fmt.Fprintf(buf, "%d/%t/%t/%t/%x ", a.TypeCode, a.Optional, a.Transitive, a.Partial, a.Value)
+	}
+
+	return buf.String()
+}
+
 // ComputeHash computes an hash over all attributes of the path
 func (b *BGPPath) ComputeHash() string {
-	s := fmt.Sprintf("%s\t%d\t%s\t%d\t%d\t%v\t%d\t%s\t%s\t%s\t%d\t%s",
+	s := fmt.Sprintf("%s\t%d\t%s\t%d\t%d\t%v\t%v\t%d\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%v\t%s",
 		b.BGPPathA.NextHop.String(),
 		b.BGPPathA.LocalPref,
 		b.ASPath.String(),
 		b.BGPPathA.Origin,
 		b.BGPPathA.MED,
+		b.BGPPathA.MEDPresent,
 		b.BGPPathA.EBGP,
 		b.BGPPathA.BGPIdentifier,
 		b.BGPPathA.Source.String(),
 		b.Communities.String(),
 		b.LargeCommunities.String(),
 		b.BGPPathA.OriginatorID,
-		b.ClusterList.String())
+		b.ClusterList.String(),
+		b.PMSITunnel.String(),
+		b.TunnelEncapsulation.String(),
+		b.PrefixSID.String(),
+		b.LabelStack.String(),
+		b.unknownAttributesString(),
+		b.BGPPathA.AtomicAggregate,
+		b.aggregatorString())
 
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(s)))
 }
 
 // ComputeHash computes an hash over all attributes of the path
 func (b *BGPPath) ComputeHashWithPathID() string {
-	s := fmt.Sprintf("%s\t%d\t%s\t%d\t%d\t%v\t%d\t%s\t%s\t%s\t%d\t%d\t%s",
+	s := fmt.Sprintf("%s\t%d\t%s\t%d\t%d\t%v\t%v\t%d\t%s\t%s\t%s\t%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%v\t%s",
 		b.BGPPathA.NextHop.String(),
 		b.BGPPathA.LocalPref,
 		b.ASPath.String(),
 		b.BGPPathA.Origin,
 		b.BGPPathA.MED,
+		b.BGPPathA.MEDPresent,
 		b.BGPPathA.EBGP,
 		b.BGPPathA.BGPIdentifier,
 		b.BGPPathA.Source.String(),
@@ -747,11 +995,26 @@ func (b *BGPPath) ComputeHashWithPathID() string {
 		b.LargeCommunities.String(),
 		b.PathIdentifier,
 		b.BGPPathA.OriginatorID,
-		b.ClusterList.String())
+		b.ClusterList.String(),
+		b.PMSITunnel.String(),
+		b.TunnelEncapsulation.String(),
+		b.PrefixSID.String(),
+		b.LabelStack.String(),
+		b.unknownAttributesString(),
+		b.BGPPathA.AtomicAggregate,
+		b.aggregatorString())
 
 	return fmt.Sprintf("%x", sha256.Sum256([]byte(s)))
 }
 
+func (b *BGPPath) aggregatorString() string {
+	if b.BGPPathA.Aggregator == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%d %s", b.BGPPathA.Aggregator.ASN, dottedQuad(b.BGPPathA.Aggregator.Address))
+}
+
 // CommunitiesString returns the formated communities
 func (b *BGPPath) CommunitiesString() string {
 	str := &strings.Builder{}
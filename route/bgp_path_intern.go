@@ -0,0 +1,126 @@
+package route
+
+import (
+	"fmt"
+	"sync"
+)
+
+var (
+	bgpI *bgpPathInterner
+)
+
+func init() {
+	bgpI = newBGPPathInterner()
+}
+
+// bgpPathInterner shares identical BGPPaths by pointer. Entries are reference counted and dropped
+// once the last reference was released.
+type bgpPathInterner struct {
+	entries   map[string]*internedBGPPath
+	entriesMu sync.Mutex
+}
+
+type internedBGPPath struct {
+	path *BGPPath
+	refs uint64
+}
+
+func newBGPPathInterner() *bgpPathInterner {
+	return &bgpPathInterner{
+		entries: make(map[string]*internedBGPPath),
+	}
+}
+
+func (bgpi *bgpPathInterner) intern(p *BGPPath) *BGPPath {
+	key := p.internKey()
+
+	bgpi.entriesMu.Lock()
+	defer bgpi.entriesMu.Unlock()
+
+	if e, ok := bgpi.entries[key]; ok {
+		if e.path == p || e.path.internEqual(p) {
+			e.refs++
+			return e.path
+		}
+
+		// Attributes not covered by the hash differ. Keep p private.
+		return p
+	}
+
+	p.BGPPathA = p.BGPPathA.Dedup()
+	bgpi.entries[key] = &internedBGPPath{
+		path: p,
+		refs: 1,
+	}
+
+	return p
+}
+
+func (bgpi *bgpPathInterner) release(p *BGPPath) {
+	key := p.internKey()
+
+	bgpi.entriesMu.Lock()
+	defer bgpi.entriesMu.Unlock()
+
+	e, ok := bgpi.entries[key]
+	if !ok || e.path != p {
+		return
+	}
+
+	e.refs--
+	if e.refs == 0 {
+		delete(bgpi.entries, key)
+	}
+}
+
+func (bgpi *bgpPathInterner) count() int {
+	bgpi.entriesMu.Lock()
+	defer bgpi.entriesMu.Unlock()
+
+	return len(bgpi.entries)
+}
+
+// Intern returns a shared BGPPath equal to b and takes a reference on it. The returned path must
+// not be modified. Use Copy() to derive a modified path. Every call must be paired with Release().
+func (b *BGPPath) Intern() *BGPPath {
+	if !b.internable() {
+		return b
+	}
+
+	return bgpI.intern(b)
+}
+
+// Release drops a reference taken by Intern(). Paths which were not interned are ignored.
+func (b *BGPPath) Release() {
+	if !b.internable() {
+		return
+	}
+
+	bgpI.release(b)
+}
+
+// InternedBGPPathCount returns the number of distinct interned BGPPaths
+func InternedBGPPathCount() int {
+	return bgpI.count()
+}
+
+// internKey extends the hash of p by attributes which commonly differ between otherwise equal paths
+func (b *BGPPath) internKey() string {
+	return fmt.Sprintf("%s\t%d\t%v", b.ComputeHashWithPathID(), b.BGPPathA.OnlyToCustomer, b.BMPPostPolicy)
+}
+
+// internable checks if all attributes needed to compute the hash of b are present
+func (b *BGPPath) internable() bool {
+	return b != nil && b.BGPPathA != nil && b.BGPPathA.NextHop != nil && b.BGPPathA.Source != nil
+}
+
+// internEqual checks if two paths are equal including the attributes ignored by Compare()
+func (b *BGPPath) internEqual(c *BGPPath) bool {
+	if !b.Compare(c) {
+		return false
+	}
+
+	return b.BGPPathA.OnlyToCustomer == c.BGPPathA.OnlyToCustomer &&
+		b.ASPathLen == c.ASPathLen &&
+		b.BMPPostPolicy == c.BMPPostPolicy
+}
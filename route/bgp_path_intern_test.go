@@ -0,0 +1,89 @@
+package route
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func internTestPath(localPref uint32) *BGPPath {
+	return &BGPPath{
+		BGPPathA: &BGPPathA{
+			NextHop:   bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			Source:    bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			LocalPref: localPref,
+		},
+		ASPath: &types.ASPath{
+			{
+				Type: types.ASSequence,
+				ASNs: []uint32{65001, 65002},
+			},
+		},
+		ASPathLen: 2,
+	}
+}
+
+func TestIntern(t *testing.T) {
+	before := InternedBGPPathCount()
+
+	a := internTestPath(100).Intern()
+	b := internTestPath(100).Intern()
+	c := internTestPath(200).Intern()
+
+	assert.True(t, a == b, "equal paths must be shared")
+	assert.False(t, a == c, "different paths must not be shared")
+	assert.Equal(t, before+2, InternedBGPPathCount())
+
+	a.Release()
+	assert.Equal(t, before+2, InternedBGPPathCount(), "b still holds a reference")
+
+	b.Release()
+	c.Release()
+	assert.Equal(t, before, InternedBGPPathCount())
+}
+
+func TestInternAttributesNotHashed(t *testing.T) {
+	a := internTestPath(300)
+	a.Weight = 10
+
+	b := internTestPath(300)
+
+	a = a.Intern()
+	defer a.Release()
+
+	res := b.Intern()
+	defer res.Release()
+
+	assert.True(t, res == b, "paths differing in weight must not be shared")
+	assert.Equal(t, uint32(10), a.Weight)
+	assert.Equal(t, uint32(0), res.Weight)
+}
+
+func TestInternOnlyToCustomer(t *testing.T) {
+	a := internTestPath(400)
+	a.BGPPathA.OnlyToCustomer = 65001
+
+	a = a.Intern()
+	defer a.Release()
+
+	b := internTestPath(400).Intern()
+	defer b.Release()
+
+	assert.False(t, a == b, "paths differing in OTC must not be shared")
+	assert.True(t, b == internTestPath(400).Intern(), "path without OTC must be interned")
+	b.Release()
+}
+
+func TestInternIncomplete(t *testing.T) {
+	p := &BGPPath{
+		BGPPathA: &BGPPathA{},
+	}
+
+	assert.True(t, p == p.Intern())
+	p.Release()
+
+	var n *BGPPath
+	assert.Nil(t, n.Intern())
+}
@@ -1,6 +1,7 @@
 package route
 
 import (
+	"sort"
 	"testing"
 
 	bnet "github.com/bio-routing/bio-rd/net"
@@ -208,6 +209,44 @@ func TestBGPSelect(t *testing.T) {
 			},
 			expected: -1,
 		},
+		{
+			name: "Weight overrides Lpref",
+			p: &BGPPath{
+				Weight: 100,
+				BGPPathA: &BGPPathA{
+					LocalPref: 100,
+					Source:    bnet.IPv4(0).Ptr(),
+					NextHop:   bnet.IPv4(0).Ptr(),
+				},
+			},
+			q: &BGPPath{
+				BGPPathA: &BGPPathA{
+					LocalPref: 200,
+					Source:    bnet.IPv4(0).Ptr(),
+					NextHop:   bnet.IPv4(0).Ptr(),
+				},
+			},
+			expected: 1,
+		},
+		{
+			name: "Weight overrides Lpref #2",
+			p: &BGPPath{
+				BGPPathA: &BGPPathA{
+					LocalPref: 200,
+					Source:    bnet.IPv4(0).Ptr(),
+					NextHop:   bnet.IPv4(0).Ptr(),
+				},
+			},
+			q: &BGPPath{
+				Weight: 1,
+				BGPPathA: &BGPPathA{
+					LocalPref: 100,
+					Source:    bnet.IPv4(0).Ptr(),
+					NextHop:   bnet.IPv4(0).Ptr(),
+				},
+			},
+			expected: -1,
+		},
 		{
 			name: "AS Path Len",
 			p: &BGPPath{
@@ -491,6 +530,321 @@ func TestBGPECMP(t *testing.T) {
 	}
 }
 
+func TestBGPSelectWithOptions(t *testing.T) {
+	p := &BGPPath{
+		BGPPathA: &BGPPathA{
+			LocalPref: 100,
+			MED:       10,
+			Source:    bnet.IPv4(0).Ptr(),
+			NextHop:   bnet.IPv4(0).Ptr(),
+		},
+		ASPathLen: 3,
+	}
+	q := &BGPPath{
+		BGPPathA: &BGPPathA{
+			LocalPref: 100,
+			MED:       20,
+			Source:    bnet.IPv4(0).Ptr(),
+			NextHop:   bnet.IPv4(0).Ptr(),
+		},
+		ASPathLen: 1,
+	}
+
+	tests := []struct {
+		name     string
+		opts     *SelectionOptions
+		expected int8
+	}{
+		{
+			name:     "No options",
+			opts:     nil,
+			expected: -1,
+		},
+		{
+			name:     "Default options",
+			opts:     &SelectionOptions{},
+			expected: -1,
+		},
+		{
+			name: "AS path length ignored, MED decides",
+			opts: &SelectionOptions{
+				IgnoreASPathLength: true,
+			},
+			expected: 1,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, p.SelectWithOptions(q, test.opts), test.name)
+		assert.Equal(t, -test.expected, q.SelectWithOptions(p, test.opts), test.name)
+	}
+}
+
+func TestCompareBGPPaths(t *testing.T) {
+	path := func(f func(p *BGPPath)) *BGPPath {
+		p := &BGPPath{
+			BGPPathA: &BGPPathA{
+				LocalPref:     100,
+				BGPIdentifier: 100,
+				Source:        bnet.IPv4(100).Ptr(),
+				NextHop:       bnet.IPv4(100).Ptr(),
+			},
+			ASPathLen: 2,
+		}
+		if f != nil {
+			f(p)
+		}
+
+		return p
+	}
+
+	tests := []struct {
+		name     string
+		a        *BGPPath
+		b        *BGPPath
+		expected int
+	}{
+		{
+			name:     "Equal",
+			a:        path(nil),
+			b:        path(nil),
+			expected: 0,
+		},
+		{
+			name:     "Higher weight",
+			a:        path(func(p *BGPPath) { p.Weight = 10; p.BGPPathA.LocalPref = 50 }),
+			b:        path(nil),
+			expected: -1,
+		},
+		{
+			name:     "Higher local pref",
+			a:        path(func(p *BGPPath) { p.BGPPathA.LocalPref = 200; p.ASPathLen = 10 }),
+			b:        path(nil),
+			expected: -1,
+		},
+		{
+			name:     "Shorter AS path",
+			a:        path(func(p *BGPPath) { p.ASPathLen = 1; p.BGPPathA.Origin = 2 }),
+			b:        path(nil),
+			expected: -1,
+		},
+		{
+			name:     "Lower origin",
+			a:        path(nil),
+			b:        path(func(p *BGPPath) { p.BGPPathA.Origin = 1; p.BGPPathA.MED = 0 }),
+			expected: -1,
+		},
+		{
+			name:     "Lower MED",
+			a:        path(func(p *BGPPath) { p.BGPPathA.MED = 10 }),
+			b:        path(func(p *BGPPath) { p.BGPPathA.MED = 20; p.BGPPathA.EBGP = true }),
+			expected: -1,
+		},
+		{
+			name:     "eBGP over iBGP",
+			a:        path(func(p *BGPPath) { p.BGPPathA.EBGP = true; p.BGPPathA.BGPIdentifier = 1 }),
+			b:        path(nil),
+			expected: -1,
+		},
+		{
+			name:     "Lower IGP metric",
+			a:        path(func(p *BGPPath) { p.IGPMetric = 10 }),
+			b:        path(func(p *BGPPath) { p.IGPMetric = 20; p.BGPPathA.BGPIdentifier = 200 }),
+			expected: -1,
+		},
+		{
+			name:     "Router ID",
+			a:        path(func(p *BGPPath) { p.BGPPathA.BGPIdentifier = 200 }),
+			b:        path(func(p *BGPPath) { p.BGPPathA.Source = bnet.IPv4(1).Ptr() }),
+			expected: -1,
+		},
+		{
+			name:     "Originator ID replaces router ID",
+			a:        path(func(p *BGPPath) { p.BGPPathA.OriginatorID = 200 }),
+			b:        path(func(p *BGPPath) { p.BGPPathA.BGPIdentifier = 150 }),
+			expected: -1,
+		},
+		{
+			name: "Cluster list length",
+			a: path(func(p *BGPPath) {
+				p.ClusterList = &types.ClusterList{1, 2}
+			}),
+			b: path(func(p *BGPPath) {
+				p.ClusterList = &types.ClusterList{1}
+				p.BGPPathA.Source = bnet.IPv4(200).Ptr()
+			}),
+			expected: -1,
+		},
+		{
+			name:     "Peer address",
+			a:        path(func(p *BGPPath) { p.BGPPathA.Source = bnet.IPv4(200).Ptr() }),
+			b:        path(func(p *BGPPath) { p.BGPPathA.NextHop = bnet.IPv4(200).Ptr() }),
+			expected: -1,
+		},
+		{
+			name:     "Next hop",
+			a:        path(func(p *BGPPath) { p.BGPPathA.NextHop = bnet.IPv4(200).Ptr() }),
+			b:        path(nil),
+			expected: -1,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, CompareBGPPaths(test.a, test.b), test.name)
+		assert.Equal(t, -test.expected, CompareBGPPaths(test.b, test.a), test.name)
+		assert.Equal(t, int8(-test.expected), test.a.Select(test.b), test.name)
+	}
+}
+
+func TestCompareBGPPathsSort(t *testing.T) {
+	worst := &BGPPath{
+		BGPPathA: &BGPPathA{
+			LocalPref: 50,
+			Source:    bnet.IPv4(0).Ptr(),
+			NextHop:   bnet.IPv4(0).Ptr(),
+		},
+	}
+	middle := &BGPPath{
+		BGPPathA: &BGPPathA{
+			LocalPref: 100,
+			Source:    bnet.IPv4(0).Ptr(),
+			NextHop:   bnet.IPv4(0).Ptr(),
+		},
+	}
+	best := &BGPPath{
+		Weight: 1,
+		BGPPathA: &BGPPathA{
+			LocalPref: 10,
+			Source:    bnet.IPv4(0).Ptr(),
+			NextHop:   bnet.IPv4(0).Ptr(),
+		},
+	}
+
+	paths := []*BGPPath{worst, best, middle}
+	sort.Slice(paths, func(i, j int) bool {
+		return CompareBGPPaths(paths[i], paths[j]) < 0
+	})
+
+	assert.Equal(t, []*BGPPath{best, middle, worst}, paths)
+}
+
+func TestBGPECMPWithOptions(t *testing.T) {
+	asPath := func(asns ...uint32) *types.ASPath {
+		return &types.ASPath{
+			{
+				Type: types.ASSequence,
+				ASNs: asns,
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		p        *BGPPath
+		q        *BGPPath
+		opts     *SelectionOptions
+		expected bool
+	}{
+		{
+			name: "Same AS path",
+			p: &BGPPath{
+				BGPPathA:  NewBGPPathA(),
+				ASPath:    asPath(100, 200),
+				ASPathLen: 2,
+			},
+			q: &BGPPath{
+				BGPPathA:  NewBGPPathA(),
+				ASPath:    asPath(100, 200),
+				ASPathLen: 2,
+			},
+			expected: true,
+		},
+		{
+			name: "Different AS path",
+			p: &BGPPath{
+				BGPPathA:  NewBGPPathA(),
+				ASPath:    asPath(100, 200),
+				ASPathLen: 2,
+			},
+			q: &BGPPath{
+				BGPPathA:  NewBGPPathA(),
+				ASPath:    asPath(300, 200),
+				ASPathLen: 2,
+			},
+			expected: true,
+		},
+		{
+			name: "Different AS path with multipath strict",
+			p: &BGPPath{
+				BGPPathA:  NewBGPPathA(),
+				ASPath:    asPath(100, 200),
+				ASPathLen: 2,
+			},
+			q: &BGPPath{
+				BGPPathA:  NewBGPPathA(),
+				ASPath:    asPath(300, 200),
+				ASPathLen: 2,
+			},
+			opts: &SelectionOptions{
+				ASPathMultipathStrict: true,
+			},
+			expected: false,
+		},
+		{
+			name: "Different AS path length",
+			p: &BGPPath{
+				BGPPathA:  NewBGPPathA(),
+				ASPath:    asPath(100, 200),
+				ASPathLen: 2,
+			},
+			q: &BGPPath{
+				BGPPathA:  NewBGPPathA(),
+				ASPath:    asPath(300),
+				ASPathLen: 1,
+			},
+			expected: false,
+		},
+		{
+			name: "Different AS path length with AS path length ignored",
+			p: &BGPPath{
+				BGPPathA:  NewBGPPathA(),
+				ASPath:    asPath(100, 200),
+				ASPathLen: 2,
+			},
+			q: &BGPPath{
+				BGPPathA:  NewBGPPathA(),
+				ASPath:    asPath(300),
+				ASPathLen: 1,
+			},
+			opts: &SelectionOptions{
+				IgnoreASPathLength: true,
+			},
+			expected: true,
+		},
+		{
+			name: "MED differs",
+			p: &BGPPath{
+				BGPPathA: &BGPPathA{
+					MED: 200,
+				},
+				ASPath:    asPath(100),
+				ASPathLen: 1,
+			},
+			q: &BGPPath{
+				BGPPathA:  NewBGPPathA(),
+				ASPath:    asPath(300),
+				ASPathLen: 1,
+			},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		res := test.p.ECMPWithOptions(test.q, test.opts)
+		assert.Equal(t, test.expected, res, test.name)
+	}
+}
+
 func TestLength(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -593,6 +947,30 @@ func TestLength(t *testing.T) {
 			},
 			expected: 44 + 19 + 6 + 4,
 		},
+		{
+			name: "PMSI tunnel and VXLAN tunnel encapsulation",
+			path: &BGPPath{
+				BGPPathA: NewBGPPathA(),
+				ASPath: &types.ASPath{
+					{
+						Type: types.ASSequence,
+						ASNs: []uint32{15169, 199714},
+					},
+				},
+				PMSITunnel: &types.PMSITunnel{
+					TunnelType: types.PMSITunnelTypeIngressReplication,
+					Label:      10000,
+					TunnelID:   []byte{192, 0, 2, 1},
+				},
+				TunnelEncapsulation: &types.TunnelEncapsulation{
+					{
+						Type:     types.TunnelTypeVXLAN,
+						Endpoint: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+					},
+				},
+			},
+			expected: 44 + 12 + 19,
+		},
 	}
 
 	for _, test := range tests {
@@ -643,3 +1021,426 @@ func TestBGPPathString(t *testing.T) {
 		assert.Equal(t, test.expectedPrint, test.input.Print())
 	}
 }
+
+func TestTunnelEncapsulationHashAndCopy(t *testing.T) {
+	p := &BGPPath{
+		BGPPathA: NewBGPPathA(),
+		ASPath:   &types.ASPath{},
+		TunnelEncapsulation: &types.TunnelEncapsulation{
+			{
+				Type:     types.TunnelTypeVXLAN,
+				Endpoint: bnet.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+				VXLAN: &types.VXLANEncapsulation{
+					VNIDValid: true,
+					VNID:      100,
+				},
+			},
+		},
+	}
+
+	cp := p.Copy()
+	assert.True(t, p.Compare(cp))
+	assert.Equal(t, p.ComputeHash(), cp.ComputeHash())
+
+	(*cp.TunnelEncapsulation)[0].VXLAN.VNID = 200
+	assert.Equal(t, uint32(100), (*p.TunnelEncapsulation)[0].VXLAN.VNID)
+	assert.False(t, p.Compare(cp))
+	assert.NotEqual(t, p.ComputeHash(), cp.ComputeHash())
+	assert.NotEqual(t, p.ComputeHashWithPathID(), cp.ComputeHashWithPathID())
+
+	cp.TunnelEncapsulation = nil
+	assert.False(t, p.Compare(cp))
+	assert.NotEqual(t, p.ComputeHash(), cp.ComputeHash())
+}
+
+func TestPrefixSIDHashAndCopy(t *testing.T) {
+	p := &BGPPath{
+		BGPPathA: NewBGPPathA(),
+		ASPath:   &types.ASPath{},
+		PrefixSID: &types.PrefixSID{
+			LabelIndex: &types.PrefixSIDLabelIndex{
+				Index: 100,
+			},
+			SRv6L3Service: &types.SRv6Service{
+				SIDs: []*types.SRv6SIDInformation{
+					{
+						SID:              bnet.IPv6FromBlocks(0x2001, 0xdb8, 1, 0, 0, 0, 0, 0),
+						EndpointBehavior: 0x13,
+						Structure: &types.SRv6SIDStructure{
+							LocatorBlockLen: 40,
+							LocatorNodeLen:  24,
+							FunctionLen:     16,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	cp := p.Copy()
+	assert.True(t, p.Compare(cp))
+	assert.Equal(t, p.ComputeHash(), cp.ComputeHash())
+
+	cp.PrefixSID.LabelIndex.Index = 200
+	assert.Equal(t, uint32(100), p.PrefixSID.LabelIndex.Index)
+	assert.False(t, p.Compare(cp))
+	assert.NotEqual(t, p.ComputeHash(), cp.ComputeHash())
+	assert.NotEqual(t, p.ComputeHashWithPathID(), cp.ComputeHashWithPathID())
+
+	cp = p.Copy()
+	cp.PrefixSID.SRv6L3Service.SIDs[0].Structure.FunctionLen = 8
+	assert.Equal(t, uint8(16), p.PrefixSID.SRv6L3Service.SIDs[0].Structure.FunctionLen)
+	assert.False(t, p.Compare(cp))
+	assert.NotEqual(t, p.ComputeHash(), cp.ComputeHash())
+
+	cp.PrefixSID = nil
+	assert.False(t, p.Compare(cp))
+	assert.NotEqual(t, p.ComputeHash(), cp.ComputeHash())
+}
+
+func TestLabelStackHashAndCopy(t *testing.T) {
+	p := &BGPPath{
+		BGPPathA:   NewBGPPathA(),
+		ASPath:     &types.ASPath{},
+		LabelStack: &types.LabelStack{16001, 299824},
+	}
+
+	cp := p.Copy()
+	assert.True(t, p.Compare(cp))
+	assert.Equal(t, p.ComputeHash(), cp.ComputeHash())
+
+	(*cp.LabelStack)[1] = types.LabelImplicitNull
+	assert.Equal(t, uint32(299824), (*p.LabelStack)[1])
+	assert.False(t, p.Compare(cp))
+	assert.NotEqual(t, p.ComputeHash(), cp.ComputeHash())
+	assert.NotEqual(t, p.ComputeHashWithPathID(), cp.ComputeHashWithPathID())
+
+	cp.LabelStack = nil
+	assert.False(t, p.Compare(cp))
+	assert.NotEqual(t, p.ComputeHash(), cp.ComputeHash())
+
+	assert.Contains(t, p.String(), "Labels: 16001 299824")
+	assert.Contains(t, p.Print(), "\t\tLabels: 16001 299824\n")
+}
+
+func TestLabelStackProto(t *testing.T) {
+	p := &BGPPath{
+		BGPPathA: &BGPPathA{
+			NextHop: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			Source:  bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+		},
+		ASPath:     &types.ASPath{},
+		LabelStack: &types.LabelStack{16001, types.LabelImplicitNull},
+	}
+
+	pb := p.ToProto()
+	assert.Equal(t, []uint32{16001, types.LabelImplicitNull}, pb.Labels)
+	assert.Equal(t, p.LabelStack, BGPPathFromProtoBGPPath(pb, false).LabelStack)
+
+	p.LabelStack = nil
+	assert.Nil(t, p.ToProto().Labels)
+	assert.Nil(t, BGPPathFromProtoBGPPath(p.ToProto(), false).LabelStack)
+}
+
+func TestPrefixSIDProto(t *testing.T) {
+	p := &BGPPath{
+		BGPPathA: &BGPPathA{
+			NextHop: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			Source:  bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+		},
+		ASPath: &types.ASPath{},
+		PrefixSID: &types.PrefixSID{
+			LabelIndex: &types.PrefixSIDLabelIndex{
+				Flags: 0x8000,
+				Index: 100,
+			},
+			SRv6L3Service: &types.SRv6Service{
+				SIDs: []*types.SRv6SIDInformation{
+					{
+						SID:              bnet.IPv6FromBlocks(0x2001, 0xdb8, 1, 0, 0, 0, 0, 0),
+						Flags:            1,
+						EndpointBehavior: 0x13,
+						Structure: &types.SRv6SIDStructure{
+							LocatorBlockLen:     40,
+							LocatorNodeLen:      24,
+							FunctionLen:         16,
+							TranspositionLen:    16,
+							TranspositionOffset: 64,
+						},
+						SubSubTLVs: []types.PrefixSIDTLV{
+							{Type: 9, Value: []byte{1}},
+						},
+					},
+				},
+				SubTLVs: []types.PrefixSIDTLV{
+					{Type: 7, Value: []byte{2, 3}},
+				},
+			},
+			TLVs: []types.PrefixSIDTLV{
+				{Type: 3, Value: []byte{0, 0, 0x3e, 0x80, 0, 0x1f, 0x40}},
+			},
+		},
+	}
+
+	pb := p.ToProto()
+	if !assert.NotNil(t, pb.PrefixSid) {
+		return
+	}
+
+	assert.Equal(t, uint32(100), pb.PrefixSid.LabelIndex.Index)
+	assert.Equal(t, uint32(0x13), pb.PrefixSid.Srv6L3Service.Sids[0].EndpointBehavior)
+	assert.Equal(t, p.PrefixSID, BGPPathFromProtoBGPPath(pb, false).PrefixSID)
+
+	p.PrefixSID = nil
+	assert.Nil(t, p.ToProto().PrefixSid)
+	assert.Nil(t, BGPPathFromProtoBGPPath(p.ToProto(), false).PrefixSID)
+}
+
+func TestUnknownAttributesHashAndCopy(t *testing.T) {
+	p := &BGPPath{
+		BGPPathA: NewBGPPathA(),
+		ASPath:   &types.ASPath{},
+		UnknownAttributes: []types.UnknownPathAttribute{
+			{
+				Optional:   true,
+				Transitive: true,
+				TypeCode:   200,
+				Value:      []byte{1, 2, 3},
+			},
+		},
+	}
+
+	cp := p.Copy()
+	assert.True(t, p.Compare(cp))
+	assert.Equal(t, p.ComputeHash(), cp.ComputeHash())
+
+	cp.UnknownAttributes[0].Value[0] = 10
+	assert.Equal(t, []byte{1, 2, 3}, p.UnknownAttributes[0].Value)
+	assert.False(t, p.Compare(cp))
+	assert.NotEqual(t, p.ComputeHash(), cp.ComputeHash())
+	assert.NotEqual(t, p.ComputeHashWithPathID(), cp.ComputeHashWithPathID())
+
+	cp.UnknownAttributes = nil
+	assert.False(t, p.Compare(cp))
+	assert.NotEqual(t, p.ComputeHash(), cp.ComputeHash())
+}
+
+func TestNormalizedCommunitiesHash(t *testing.T) {
+	a := BGPPathFromProtoBGPPath(&api.BGPPath{
+		NextHop:     bnet.IPv4FromOctets(10, 0, 0, 1).ToProto(),
+		Source:      bnet.IPv4FromOctets(10, 0, 0, 2).ToProto(),
+		Communities: []uint32{100, 200, 300},
+		LargeCommunities: []*api.LargeCommunity{
+			{GlobalAdministrator: 1, DataPart1: 2, DataPart2: 3},
+			{GlobalAdministrator: 4, DataPart1: 5, DataPart2: 6},
+		},
+	}, false)
+
+	b := BGPPathFromProtoBGPPath(&api.BGPPath{
+		NextHop:     bnet.IPv4FromOctets(10, 0, 0, 1).ToProto(),
+		Source:      bnet.IPv4FromOctets(10, 0, 0, 2).ToProto(),
+		Communities: []uint32{300, 100, 200, 100},
+		LargeCommunities: []*api.LargeCommunity{
+			{GlobalAdministrator: 4, DataPart1: 5, DataPart2: 6},
+			{GlobalAdministrator: 1, DataPart1: 2, DataPart2: 3},
+			{GlobalAdministrator: 4, DataPart1: 5, DataPart2: 6},
+		},
+	}, false)
+
+	assert.True(t, a.Compare(b))
+	assert.Equal(t, a.ComputeHash(), b.ComputeHash())
+	assert.Equal(t, a.ComputeHashWithPathID(), b.ComputeHashWithPathID())
+
+	c := &BGPPath{
+		BGPPathA:    a.BGPPathA,
+		ASPath:      a.ASPath,
+		Communities: &types.Communities{200, 300, 300, 100},
+		LargeCommunities: &types.LargeCommunities{
+			{GlobalAdministrator: 4, DataPart1: 5, DataPart2: 6},
+			{GlobalAdministrator: 1, DataPart1: 2, DataPart2: 3},
+		},
+	}
+	assert.NotEqual(t, a.ComputeHash(), c.ComputeHash())
+
+	c.NormalizeCommunities()
+	assert.Equal(t, a.ComputeHash(), c.ComputeHash())
+}
+
+func TestAggregatorHashAndCopy(t *testing.T) {
+	p := &BGPPath{
+		BGPPathA: NewBGPPathA(),
+		ASPath:   &types.ASPath{},
+	}
+	p.BGPPathA.AtomicAggregate = true
+	p.BGPPathA.Aggregator = &types.Aggregator{
+		ASN:     200000,
+		Address: 100,
+	}
+
+	cp := p.Copy()
+	assert.True(t, p.Compare(cp))
+	assert.Equal(t, p.ComputeHash(), cp.ComputeHash())
+
+	cp.BGPPathA.Aggregator.ASN = 65000
+	assert.Equal(t, uint32(200000), p.BGPPathA.Aggregator.ASN)
+	assert.False(t, p.Compare(cp))
+	assert.NotEqual(t, p.ComputeHash(), cp.ComputeHash())
+	assert.NotEqual(t, p.ComputeHashWithPathID(), cp.ComputeHashWithPathID())
+
+	cp = p.Copy()
+	cp.BGPPathA.AtomicAggregate = false
+	assert.False(t, p.Compare(cp))
+	assert.NotEqual(t, p.ComputeHash(), cp.ComputeHash())
+}
+
+func TestMissingMEDHashAndSelect(t *testing.T) {
+	missing := &BGPPath{
+		BGPPathA: NewBGPPathA(),
+		ASPath:   &types.ASPath{},
+	}
+	zero := missing.Copy()
+	zero.BGPPathA.MEDPresent = true
+	high := zero.Copy()
+	high.BGPPathA.MED = 100
+
+	assert.False(t, missing.Compare(zero))
+	assert.NotEqual(t, missing.ComputeHash(), zero.ComputeHash())
+	assert.NotEqual(t, missing.ComputeHashWithPathID(), zero.ComputeHashWithPathID())
+
+	tests := []struct {
+		name     string
+		opts     *SelectionOptions
+		a        *BGPPath
+		b        *BGPPath
+		expected int
+	}{
+		{
+			name:     "Missing MED equals MED 0 by default",
+			a:        missing,
+			b:        zero,
+			expected: 0,
+		},
+		{
+			name:     "Missing MED preferred over MED 100 by default",
+			a:        missing,
+			b:        high,
+			expected: -1,
+		},
+		{
+			name: "Missing MED as worst, MED 0 preferred",
+			opts: &SelectionOptions{
+				MEDMissingAsWorst: true,
+			},
+			a:        missing,
+			b:        zero,
+			expected: 1,
+		},
+		{
+			name: "Missing MED as worst, MED 100 preferred",
+			opts: &SelectionOptions{
+				MEDMissingAsWorst: true,
+			},
+			a:        missing,
+			b:        high,
+			expected: 1,
+		},
+		{
+			name: "Missing MED as worst, both MEDs present",
+			opts: &SelectionOptions{
+				MEDMissingAsWorst: true,
+			},
+			a:        zero,
+			b:        high,
+			expected: -1,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, CompareBGPPathsWithOptions(test.a, test.b, test.opts), test.name)
+		assert.Equal(t, -test.expected, CompareBGPPathsWithOptions(test.b, test.a, test.opts), test.name)
+		assert.Equal(t, test.expected == 0, test.a.ECMPWithOptions(test.b, test.opts), test.name)
+	}
+
+	assert.True(t, BGPPathFromProtoBGPPath(zero.ToProto(), false).BGPPathA.MEDPresent)
+	assert.False(t, BGPPathFromProtoBGPPath(missing.ToProto(), false).BGPPathA.MEDPresent)
+}
+
+func TestASPathLengthSelection(t *testing.T) {
+	newPath := func(asPath types.ASPath) *BGPPath {
+		return &BGPPath{
+			BGPPathA:  NewBGPPathA(),
+			ASPath:    &asPath,
+			ASPathLen: asPath.Length(),
+		}
+	}
+
+	tests := []struct {
+		name        string
+		a           *BGPPath
+		b           *BGPPath
+		expectedLen uint16
+		expected    int
+	}{
+		{
+			name: "AS_SET counts as one",
+			a: newPath(types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{100}},
+				{Type: types.ASSet, ASNs: []uint32{200, 300, 400}},
+			}),
+			b: newPath(types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{100, 200, 300}},
+			}),
+			expectedLen: 2,
+			expected:    -1,
+		},
+		{
+			name: "Confed segments are not counted",
+			a: newPath(types.ASPath{
+				{Type: types.ASConfedSequence, ASNs: []uint32{65001, 65002, 65003}},
+				{Type: types.ASConfedSet, ASNs: []uint32{65004}},
+				{Type: types.ASSequence, ASNs: []uint32{100}},
+			}),
+			b: newPath(types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{100, 200}},
+			}),
+			expectedLen: 1,
+			expected:    -1,
+		},
+		{
+			name: "Confed segments and AS_SET equal to sequence",
+			a: newPath(types.ASPath{
+				{Type: types.ASConfedSequence, ASNs: []uint32{65001}},
+				{Type: types.ASSequence, ASNs: []uint32{100}},
+				{Type: types.ASSet, ASNs: []uint32{200, 300}},
+			}),
+			b: newPath(types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{100, 200}},
+			}),
+			expectedLen: 2,
+			expected:    0,
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expectedLen, test.a.ASPathLen, test.name)
+		assert.Equal(t, test.expected, CompareBGPPathsWithOptions(test.a, test.b, nil), test.name)
+		assert.Equal(t, -test.expected, CompareBGPPathsWithOptions(test.b, test.a, nil), test.name)
+	}
+}
+
+func TestBGPPathRemoveConfedSegments(t *testing.T) {
+	b := &BGPPath{
+		ASPath: &types.ASPath{
+			{Type: types.ASConfedSequence, ASNs: []uint32{65001, 65002}},
+			{Type: types.ASSequence, ASNs: []uint32{100, 200}},
+		},
+	}
+
+	b.RemoveConfedSegments()
+	b.Prepend(300, 1)
+
+	assert.Equal(t, &types.ASPath{
+		{Type: types.ASSequence, ASNs: []uint32{300, 100, 200}},
+	}, b.ASPath)
+	assert.Equal(t, uint16(3), b.ASPathLen)
+}
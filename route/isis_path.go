@@ -0,0 +1,82 @@
+package route
+
+import (
+	"fmt"
+	"strings"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+// ISISPath represents a path of a route computed by ISIS
+type ISISPath struct {
+	NextHop *bnet.IP
+	Metric  uint32
+	Level   uint8
+
+	// Tag is the administrative tag of the prefix (RFC5130). Zero if untagged.
+	Tag uint32
+}
+
+// Select returns negative if s < t, 0 if paths are equal, positive if s > t
+func (s *ISISPath) Select(t *ISISPath) int8 {
+	if s.Level < t.Level {
+		return 1
+	}
+
+	if s.Level > t.Level {
+		return -1
+	}
+
+	if s.Metric < t.Metric {
+		return 1
+	}
+
+	if s.Metric > t.Metric {
+		return -1
+	}
+
+	return s.NextHop.Compare(t.NextHop)
+}
+
+// Equal returns true if s and t are equal
+func (s *ISISPath) Equal(t *ISISPath) bool {
+	if s == nil || t == nil {
+		return false
+	}
+
+	return s.Select(t) == 0
+}
+
+// ECMP determines if path s and t are equal in terms of ECMP
+func (s *ISISPath) ECMP(t *ISISPath) bool {
+	return s.Level == t.Level && s.Metric == t.Metric
+}
+
+// Copy copies an ISIS path
+func (s *ISISPath) Copy() *ISISPath {
+	if s == nil {
+		return nil
+	}
+
+	cp := *s
+	return &cp
+}
+
+// Print all known information about a route in logfile friendly format
+func (s *ISISPath) String() string {
+	return fmt.Sprintf("Next hop: %s, Metric: %d, Level: %d, ", s.NextHop, s.Metric, s.Level)
+}
+
+// Print all known information about a route in human readable form
+func (s *ISISPath) Print() string {
+	buf := &strings.Builder{}
+
+	fmt.Fprintf(buf, "\t\tNext hop: %s\n", s.NextHop)
+	fmt.Fprintf(buf, "\t\tMetric: %d\n", s.Metric)
+	fmt.Fprintf(buf, "\t\tLevel: %d\n", s.Level)
+	if s.Tag != 0 {
+		fmt.Fprintf(buf, "\t\tTag: %d\n", s.Tag)
+	}
+
+	return buf.String()
+}
@@ -0,0 +1,319 @@
+package route
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/bio-routing/tflow2/convert"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+)
+
+// The JSON representations below use structs rather than maps so that the field order and thus the output is stable
+
+type routeJSON struct {
+	Prefix string  `json:"prefix"`
+	Paths  []*Path `json:"paths"`
+}
+
+type pathJSON struct {
+	Protocol     string      `json:"protocol"`
+	HiddenReason string      `json:"hidden_reason,omitempty"`
+	LTime        uint32      `json:"ltime,omitempty"`
+	Discard      bool        `json:"discard,omitempty"`
+	Stale        bool        `json:"stale,omitempty"`
+	Static       *StaticPath `json:"static,omitempty"`
+	BGP          *BGPPath    `json:"bgp,omitempty"`
+	FIB          *FIBPath    `json:"fib,omitempty"`
+	ISIS         *ISISPath   `json:"isis,omitempty"`
+}
+
+type staticPathJSON struct {
+	NextHop string `json:"next_hop"`
+}
+
+type isisPathJSON struct {
+	NextHop string `json:"next_hop"`
+	Metric  uint32 `json:"metric"`
+	Level   uint8  `json:"level"`
+	Tag     uint32 `json:"tag,omitempty"`
+}
+
+type fibPathJSON struct {
+	Src      string `json:"src"`
+	NextHop  string `json:"next_hop"`
+	Priority int    `json:"priority"`
+	Protocol int    `json:"protocol"`
+	Type     int    `json:"type"`
+	Table    int    `json:"table"`
+	Kernel   bool   `json:"kernel"`
+}
+
+type bgpPathJSON struct {
+	NextHop             string                 `json:"next_hop"`
+	Source              string                 `json:"source"`
+	LocalPref           uint32                 `json:"local_pref"`
+	MED                 uint32                 `json:"med"`
+	Origin              string                 `json:"origin"`
+	EBGP                bool                   `json:"ebgp"`
+	BGPIdentifier       string                 `json:"bgp_identifier"`
+	PathIdentifier      uint32                 `json:"path_identifier"`
+	Weight              uint32                 `json:"weight"`
+	IGPMetric           uint32                 `json:"igp_metric"`
+	ASPath              []asPathSegmentJSON    `json:"as_path"`
+	Communities         []string               `json:"communities"`
+	LargeCommunities    []string               `json:"large_communities"`
+	OriginatorID        string                 `json:"originator_id,omitempty"`
+	ClusterList         []string               `json:"cluster_list"`
+	Aggregator          *aggregatorJSON        `json:"aggregator,omitempty"`
+	AtomicAggregate     bool                   `json:"atomic_aggregate"`
+	OnlyToCustomer      uint32                 `json:"only_to_customer,omitempty"`
+	PMSITunnel          string                 `json:"pmsi_tunnel,omitempty"`
+	TunnelEncapsulation string                 `json:"tunnel_encapsulation,omitempty"`
+	PrefixSID           string                 `json:"prefix_sid,omitempty"`
+	Labels              string                 `json:"labels,omitempty"`
+	UnknownAttributes   []unknownAttributeJSON `json:"unknown_attributes"`
+}
+
+type asPathSegmentJSON struct {
+	Type string   `json:"type"`
+	ASNs []uint32 `json:"asns"`
+}
+
+type aggregatorJSON struct {
+	ASN     uint32 `json:"asn"`
+	Address string `json:"address"`
+}
+
+type unknownAttributeJSON struct {
+	TypeCode   uint8  `json:"type_code"`
+	Optional   bool   `json:"optional"`
+	Transitive bool   `json:"transitive"`
+	Partial    bool   `json:"partial"`
+	Value      string `json:"value"`
+}
+
+// MarshalJSON marshals a route including all of its paths into a stable JSON representation
+func (r *Route) MarshalJSON() ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ret := routeJSON{
+		Paths: r.paths,
+	}
+
+	if r.pfx != nil {
+		ret.Prefix = r.pfx.String()
+	}
+
+	if ret.Paths == nil {
+		ret.Paths = make([]*Path, 0)
+	}
+
+	return json.Marshal(ret)
+}
+
+// MarshalJSON marshals a path into a stable JSON representation
+func (p *Path) MarshalJSON() ([]byte, error) {
+	ret := pathJSON{
+		Protocol:     protocolString(p.Type),
+		HiddenReason: p.HiddenReasonString(),
+		LTime:        p.LTime,
+		Discard:      p.Discard,
+		Stale:        p.Stale,
+	}
+
+	switch p.Type {
+	case StaticPathType:
+		ret.Static = p.StaticPath
+	case BGPPathType:
+		ret.BGP = p.BGPPath
+	case FIBPathType:
+		ret.FIB = p.FIBPath
+	case ISISPathType:
+		ret.ISIS = p.ISISPath
+	}
+
+	return json.Marshal(ret)
+}
+
+func protocolString(pathType uint8) string {
+	switch pathType {
+	case StaticPathType:
+		return "static"
+	case BGPPathType:
+		return "BGP"
+	case FIBPathType:
+		return "Netlink"
+	case ISISPathType:
+		return "ISIS"
+	}
+
+	return "unknown"
+}
+
+// MarshalJSON marshals a static path into a stable JSON representation
+func (s *StaticPath) MarshalJSON() ([]byte, error) {
+	return json.Marshal(staticPathJSON{
+		NextHop: ipString(s.NextHop),
+	})
+}
+
+// MarshalJSON marshals an ISIS path into a stable JSON representation
+func (s *ISISPath) MarshalJSON() ([]byte, error) {
+	return json.Marshal(isisPathJSON{
+		NextHop: ipString(s.NextHop),
+		Metric:  s.Metric,
+		Level:   s.Level,
+		Tag:     s.Tag,
+	})
+}
+
+// MarshalJSON marshals a FIB path into a stable JSON representation
+func (s *FIBPath) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fibPathJSON{
+		Src:      ipString(s.Src),
+		NextHop:  ipString(s.NextHop),
+		Priority: s.Priority,
+		Protocol: s.Protocol,
+		Type:     s.Type,
+		Table:    s.Table,
+		Kernel:   s.Kernel,
+	})
+}
+
+// MarshalJSON marshals all attributes of a BGP path into a stable JSON representation.
+// Unknown attributes are represented by their hex encoded value.
+func (b *BGPPath) MarshalJSON() ([]byte, error) {
+	ret := bgpPathJSON{
+		PathIdentifier:    b.PathIdentifier,
+		Weight:            b.Weight,
+		IGPMetric:         b.IGPMetric,
+		ASPath:            make([]asPathSegmentJSON, 0),
+		Communities:       make([]string, 0),
+		LargeCommunities:  make([]string, 0),
+		ClusterList:       make([]string, 0),
+		UnknownAttributes: make([]unknownAttributeJSON, 0),
+	}
+
+	if b.BGPPathA != nil {
+		ret.NextHop = ipString(b.BGPPathA.NextHop)
+		ret.Source = ipString(b.BGPPathA.Source)
+		ret.LocalPref = b.BGPPathA.LocalPref
+		ret.MED = b.BGPPathA.MED
+		ret.Origin = originString(b.BGPPathA.Origin)
+		ret.EBGP = b.BGPPathA.EBGP
+		ret.BGPIdentifier = dottedQuad(b.BGPPathA.BGPIdentifier)
+		ret.AtomicAggregate = b.BGPPathA.AtomicAggregate
+		ret.OnlyToCustomer = b.BGPPathA.OnlyToCustomer
+
+		if b.BGPPathA.OriginatorID != 0 {
+			ret.OriginatorID = dottedQuad(b.BGPPathA.OriginatorID)
+		}
+
+		if b.BGPPathA.Aggregator != nil {
+			ret.Aggregator = &aggregatorJSON{
+				ASN:     b.BGPPathA.Aggregator.ASN,
+				Address: dottedQuad(b.BGPPathA.Aggregator.Address),
+			}
+		}
+	}
+
+	if b.ASPath != nil {
+		for _, s := range *b.ASPath {
+			ret.ASPath = append(ret.ASPath, asPathSegmentJSON{
+				Type: asPathSegmentTypeString(s.Type),
+				ASNs: append(make([]uint32, 0, len(s.ASNs)), s.ASNs...),
+			})
+		}
+	}
+
+	if b.Communities != nil {
+		for _, c := range *b.Communities {
+			ret.Communities = append(ret.Communities, types.CommunityStringForUint32(c))
+		}
+	}
+
+	if b.LargeCommunities != nil {
+		for _, c := range *b.LargeCommunities {
+			ret.LargeCommunities = append(ret.LargeCommunities, c.String())
+		}
+	}
+
+	if b.ClusterList != nil {
+		for _, cid := range *b.ClusterList {
+			ret.ClusterList = append(ret.ClusterList, dottedQuad(cid))
+		}
+	}
+
+	if b.PMSITunnel != nil {
+		ret.PMSITunnel = b.PMSITunnel.String()
+	}
+
+	if b.TunnelEncapsulation != nil {
+		ret.TunnelEncapsulation = b.TunnelEncapsulation.String()
+	}
+
+	if b.PrefixSID != nil {
+		ret.PrefixSID = b.PrefixSID.String()
+	}
+
+	if b.LabelStack != nil {
+		ret.Labels = b.LabelStack.String()
+	}
+
+	for _, a := range b.UnknownAttributes {
+		ret.UnknownAttributes = append(ret.UnknownAttributes, unknownAttributeJSON{
+			TypeCode:   a.TypeCode,
+			Optional:   a.Optional,
+			Transitive: a.Transitive,
+			Partial:    a.Partial,
+			Value:      hex.EncodeToString(a.Value),
+		})
+	}
+
+	return json.Marshal(ret)
+}
+
+func originString(origin uint8) string {
+	switch origin {
+	case 0:
+		return "IGP"
+	case 1:
+		return "EGP"
+	case 2:
+		return "Incomplete"
+	}
+
+	return ""
+}
+
+func asPathSegmentTypeString(t uint8) string {
+	switch t {
+	case types.ASSequence:
+		return "sequence"
+	case types.ASSet:
+		return "set"
+	case types.ASConfedSequence:
+		return "confed_sequence"
+	case types.ASConfedSet:
+		return "confed_set"
+	}
+
+	return fmt.Sprintf("unknown(%d)", t)
+}
+
+func ipString(addr *bnet.IP) string {
+	if addr == nil {
+		return ""
+	}
+
+	return addr.String()
+}
+
+func dottedQuad(v uint32) string {
+	o := convert.Uint32Byte(v)
+	return fmt.Sprintf("%d.%d.%d.%d", o[0], o[1], o[2], o[3])
+}
@@ -0,0 +1,144 @@
+package route
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+)
+
+// complexTestRoute is the complex route used in TestDumpRIBInOut
+func complexTestRoute() *Route {
+	return NewRoute(bnet.NewPfx(bnet.IPv4FromOctets(20, 0, 0, 0), 16).Ptr(), &Path{
+		Type: BGPPathType,
+		BGPPath: &BGPPath{
+			BGPPathA: &BGPPathA{
+				OriginatorID: 1,
+				NextHop:      bnet.IPv4FromOctets(100, 100, 100, 100).Ptr(),
+				Source:       bnet.IPv4FromOctets(100, 100, 100, 100).Ptr(),
+				LocalPref:    1000,
+				MED:          2000,
+			},
+			ASPath: &types.ASPath{
+				types.ASPathSegment{
+					Type: types.ASSequence,
+					ASNs: []uint32{15169, 3320},
+				},
+			},
+			Communities: &types.Communities{100, 200, 300},
+			LargeCommunities: &types.LargeCommunities{
+				{
+					GlobalAdministrator: 1,
+					DataPart1:           2,
+					DataPart2:           3,
+				},
+			},
+			UnknownAttributes: []types.UnknownPathAttribute{
+				{
+					Optional:   true,
+					Transitive: true,
+					Partial:    true,
+					TypeCode:   222,
+					Value:      []byte{0xff, 0xff},
+				},
+			},
+			ClusterList: &types.ClusterList{},
+		},
+	})
+}
+
+func TestRouteMarshalJSON(t *testing.T) {
+	expected := `{"prefix":"20.0.0.0/16","paths":[{"protocol":"BGP","bgp":{` +
+		`"next_hop":"100.100.100.100","source":"100.100.100.100","local_pref":1000,"med":2000,"origin":"IGP","ebgp":false,` +
+		`"bgp_identifier":"0.0.0.0","path_identifier":0,"weight":0,"igp_metric":0,"as_path":[{"type":"sequence","asns":[15169,3320]}],` +
+		`"communities":["(0,100)","(0,200)","(0,300)"],"large_communities":["(1,2,3)"],"originator_id":"0.0.0.1","cluster_list":[],` +
+		`"atomic_aggregate":false,"unknown_attributes":[{"type_code":222,"optional":true,"transitive":true,"partial":true,"value":"ffff"}]}}]}`
+
+	for i := 0; i < 3; i++ {
+		b, err := json.Marshal(complexTestRoute())
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(b))
+	}
+}
+
+func TestPathMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     *Path
+		expected string
+	}{
+		{
+			name: "Static path",
+			path: &Path{
+				Type: StaticPathType,
+				StaticPath: &StaticPath{
+					NextHop: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+				},
+			},
+			expected: `{"protocol":"static","static":{"next_hop":"10.0.0.1"}}`,
+		},
+		{
+			name: "Discard static path",
+			path: &Path{
+				Type:    StaticPathType,
+				Discard: true,
+				StaticPath: &StaticPath{
+					NextHop: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+				},
+			},
+			expected: `{"protocol":"static","discard":true,"static":{"next_hop":"10.0.0.1"}}`,
+		},
+		{
+			name: "Hidden BGP path with aggregator",
+			path: &Path{
+				Type:         BGPPathType,
+				HiddenReason: HiddenReasonASLoop,
+				BGPPath: &BGPPath{
+					BGPPathA: &BGPPathA{
+						NextHop:         bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+						Source:          bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+						Origin:          2,
+						EBGP:            true,
+						BGPIdentifier:   167772162,
+						AtomicAggregate: true,
+						Aggregator: &types.Aggregator{
+							ASN:     65000,
+							Address: 167772163,
+						},
+					},
+					ASPath: &types.ASPath{
+						types.ASPathSegment{
+							Type: types.ASSet,
+							ASNs: []uint32{1, 2},
+						},
+					},
+					ClusterList: &types.ClusterList{167772164},
+				},
+			},
+			expected: `{"protocol":"BGP","hidden_reason":"AS Path loop","bgp":{` +
+				`"next_hop":"10.0.0.1","source":"10.0.0.2","local_pref":0,"med":0,"origin":"Incomplete","ebgp":true,` +
+				`"bgp_identifier":"10.0.0.2","path_identifier":0,"weight":0,"igp_metric":0,"as_path":[{"type":"set","asns":[1,2]}],` +
+				`"communities":[],"large_communities":[],"cluster_list":["10.0.0.4"],"aggregator":{"asn":65000,"address":"10.0.0.3"},` +
+				`"atomic_aggregate":true,"unknown_attributes":[]}}`,
+		},
+	}
+
+	for _, test := range tests {
+		b, err := json.Marshal(test.path)
+		assert.NoError(t, err, test.name)
+		assert.Equal(t, test.expected, string(b), test.name)
+	}
+}
+
+func TestComplexPathString(t *testing.T) {
+	expected := "Local Pref: 1000, Origin: IGP, AS Path: 15169 3320, BGP type: internal, NEXT HOP: 100.100.100.100, MED: 2000, " +
+		"Path ID: 0, Source: 100.100.100.100, Communities: [100 200 300], LargeCommunities: [{1 2 3}], OriginatorID: 0.0.0.1, " +
+		"ClusterList , Unknown Attribute 222 (optional=true, transitive=true, partial=true): ffff"
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, expected, complexTestRoute().Paths()[0].String())
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unsafe"
 
 	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/route/api"
@@ -17,6 +18,7 @@ const (
 	HiddenReasonOurOriginatorID
 	HiddenReasonClusterLoop
 	HiddenReasonOTCMismatch
+	HiddenReasonInvalidNextHop
 )
 
 // Path represents a network path
@@ -24,13 +26,21 @@ type Path struct {
 	Type         uint8
 	HiddenReason uint8  // If set, Path is hidden and ineligible to be installed in LocRIB and used for path selection
 	LTime        uint32 // The time we learned this path, as unix epoch (seconds)
+	Discard      bool   // If set, traffic matching the path is dropped (blackhole) instead of forwarded to the next hop
+	Stale        bool   // If set, the path was restored from a snapshot and not yet confirmed by its source
 	StaticPath   *StaticPath
 	BGPPath      *BGPPath
 	FIBPath      *FIBPath
+	ISISPath     *ISISPath
 }
 
 // Select returns negative if p < q, 0 if paths are equal, positive if p > q
 func (p *Path) Select(q *Path) int8 {
+	return p.SelectWithOptions(q, nil)
+}
+
+// SelectWithOptions is like Select with the best path selection altered by opts
+func (p *Path) SelectWithOptions(q *Path, opts *SelectionOptions) int8 {
 	switch {
 	case p == nil && q == nil:
 		return 0
@@ -51,11 +61,13 @@ func (p *Path) Select(q *Path) int8 {
 
 	switch p.Type {
 	case BGPPathType:
-		return p.BGPPath.Select(q.BGPPath)
+		return p.BGPPath.SelectWithOptions(q.BGPPath, opts)
 	case StaticPathType:
 		return p.StaticPath.Select(q.StaticPath)
 	case FIBPathType:
 		return p.FIBPath.Select(q.FIBPath)
+	case ISISPathType:
+		return p.ISISPath.Select(q.ISISPath)
 	}
 
 	return 0
@@ -63,13 +75,24 @@ func (p *Path) Select(q *Path) int8 {
 
 // ECMP checks if path p and q are equal enough to be considered for ECMP usage
 func (p *Path) ECMP(q *Path) bool {
+	return p.ECMPWithOptions(q, nil)
+}
+
+// ECMPWithOptions is like ECMP with the best path selection altered by opts
+func (p *Path) ECMPWithOptions(q *Path, opts *SelectionOptions) bool {
+	if p.Type != q.Type {
+		return false
+	}
+
 	switch p.Type {
 	case BGPPathType:
-		return p.BGPPath.ECMP(q.BGPPath)
+		return p.BGPPath.ECMPWithOptions(q.BGPPath, opts)
 	case StaticPathType:
 		return p.StaticPath.ECMP(q.StaticPath)
 	case FIBPathType:
 		return p.FIBPath.ECMP(q.FIBPath)
+	case ISISPathType:
+		return p.ISISPath.ECMP(q.ISISPath)
 	}
 
 	panic("Unknown path type")
@@ -81,6 +104,8 @@ func (p *Path) ToProto() *api.Path {
 		StaticPath:  p.StaticPath.ToProto(),
 		BgpPath:     p.BGPPath.ToProto(),
 		TimeLearned: p.LTime,
+		Discard:     p.Discard,
+		Stale:       p.Stale,
 	}
 
 	switch p.Type {
@@ -105,6 +130,8 @@ func (p *Path) ToProto() *api.Path {
 		a.HiddenReason = api.Path_HiddenReasonClusterLoop
 	case HiddenReasonOTCMismatch:
 		a.HiddenReason = api.Path_HiddenReasonOTCMismatch
+	case HiddenReasonInvalidNextHop:
+		a.HiddenReason = api.Path_HiddenReasonInvalidNextHop
 	}
 
 	return a
@@ -116,7 +143,7 @@ func (p *Path) Compare(q *Path) bool {
 		return false
 	}
 
-	if p.Type != q.Type {
+	if p.Type != q.Type || p.Discard != q.Discard || p.Stale != q.Stale {
 		return false
 	}
 
@@ -136,7 +163,7 @@ func (p *Path) Equal(q *Path) bool {
 		return false
 	}
 
-	if p.Type != q.Type {
+	if p.Type != q.Type || p.Discard != q.Discard || p.Stale != q.Stale {
 		return false
 	}
 
@@ -145,6 +172,8 @@ func (p *Path) Equal(q *Path) bool {
 		return p.BGPPath.Equal(q.BGPPath)
 	case StaticPathType:
 		return p.StaticPath.Equal(q.StaticPath)
+	case ISISPathType:
+		return p.ISISPath.Equal(q.ISISPath)
 	}
 
 	return p.Select(q) == 0
@@ -182,6 +211,8 @@ func (p *Path) String() string {
 		return p.BGPPath.String()
 	case FIBPathType:
 		return p.FIBPath.String()
+	case ISISPathType:
+		return p.ISISPath.String()
 	default:
 		return fmt.Sprintf("Unknown path type. Probably not implemented yet (%d)", p.Type)
 	}
@@ -199,6 +230,8 @@ func (p *Path) Print() string {
 		protocol = "BGP"
 	case FIBPathType:
 		protocol = "Netlink"
+	case ISISPathType:
+		protocol = "ISIS"
 	}
 
 	fmt.Fprintf(buf, "\tProtocol: %s\n", protocol)
@@ -209,6 +242,14 @@ func (p *Path) Print() string {
 		fmt.Fprintf(buf, "\tHidden: no\n")
 	}
 
+	if p.Discard {
+		fmt.Fprintf(buf, "\tDiscard: yes\n")
+	}
+
+	if p.Stale {
+		fmt.Fprintf(buf, "\tStale: yes\n")
+	}
+
 	if p.LTime != 0 {
 		fmt.Fprintf(buf, "\tAge: %s\n", time.Since(time.Unix(int64(p.LTime), 0)).Truncate(time.Second).String())
 
@@ -221,11 +262,31 @@ func (p *Path) Print() string {
 		buf.WriteString(p.BGPPath.Print())
 	case FIBPathType:
 		buf.WriteString(p.FIBPath.Print())
+	case ISISPathType:
+		buf.WriteString(p.ISISPath.Print())
 	}
 
 	return buf.String()
 }
 
+// EstimatedSize estimates the memory used by the path in bytes
+func (p *Path) EstimatedSize() uint64 {
+	size := uint64(unsafe.Sizeof(*p))
+	if p.StaticPath != nil {
+		size += uint64(unsafe.Sizeof(*p.StaticPath))
+	}
+
+	if p.FIBPath != nil {
+		size += uint64(unsafe.Sizeof(*p.FIBPath))
+	}
+
+	if p.ISISPath != nil {
+		size += uint64(unsafe.Sizeof(*p.ISISPath))
+	}
+
+	return size + p.BGPPath.EstimatedSize()
+}
+
 // Copy a route
 func (p *Path) Copy() *Path {
 	if p == nil {
@@ -235,6 +296,7 @@ func (p *Path) Copy() *Path {
 	cp := *p
 	cp.BGPPath = cp.BGPPath.Copy()
 	cp.StaticPath = cp.StaticPath.Copy()
+	cp.ISISPath = cp.ISISPath.Copy()
 
 	return &cp
 }
@@ -248,6 +310,8 @@ func (p *Path) NextHop() *bnet.IP {
 		return p.StaticPath.NextHop
 	case FIBPathType:
 		return p.FIBPath.NextHop
+	case ISISPathType:
+		return p.ISISPath.NextHop
 	}
 
 	panic("Unknown path type")
@@ -275,6 +339,8 @@ func (p *Path) HiddenReasonString() string {
 		return "Found our cluster ID in cluster list"
 	case HiddenReasonOTCMismatch:
 		return "OTC mismatch"
+	case HiddenReasonInvalidNextHop:
+		return "Invalid Next-Hop"
 	default:
 		return "unknown"
 	}
@@ -182,6 +182,24 @@ func TestEqual(t *testing.T) {
 			},
 			expected: true,
 		},
+		{
+			name:     "Static path and discard static path",
+			p:        &Path{Type: StaticPathType, StaticPath: &StaticPath{NextHop: &bnet.IP{}}},
+			q:        &Path{Type: StaticPathType, Discard: true, StaticPath: &StaticPath{NextHop: &bnet.IP{}}},
+			expected: false,
+		},
+		{
+			name:     "Both discard static paths",
+			p:        &Path{Type: StaticPathType, Discard: true, StaticPath: &StaticPath{NextHop: &bnet.IP{}}},
+			q:        &Path{Type: StaticPathType, Discard: true, StaticPath: &StaticPath{NextHop: &bnet.IP{}}},
+			expected: true,
+		},
+		{
+			name:     "Static path and stale static path",
+			p:        &Path{Type: StaticPathType, StaticPath: &StaticPath{NextHop: &bnet.IP{}}},
+			q:        &Path{Type: StaticPathType, Stale: true, StaticPath: &StaticPath{NextHop: &bnet.IP{}}},
+			expected: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -1033,6 +1051,11 @@ func TestPrint(t *testing.T) {
 			},
 			result: "\tProtocol: static\n\tHidden: yes (Filtered by Policy)\n\t\tNext hop: ::\n",
 		},
+		{
+			name:   "Static Path (discard)",
+			path:   &Path{Type: StaticPathType, Discard: true, StaticPath: &StaticPath{NextHop: &bnet.IP{}}},
+			result: "\tProtocol: static\n\tHidden: no\n\tDiscard: yes\n\t\tNext hop: ::\n",
+		},
 	}
 
 	for _, test := range tests {
@@ -1088,6 +1111,19 @@ func TestToProto(t *testing.T) {
 				TimeLearned: 2342,
 			},
 		},
+		{
+			name: "Discard BGP path",
+			path: &Path{
+				Type:    BGPPathType,
+				Discard: true,
+				BGPPath: bgpPath.BGPPath,
+			},
+			result: &api.Path{
+				Type:    api.Path_BGP,
+				BgpPath: bgpPath.BGPPath.ToProto(),
+				Discard: true,
+			},
+		},
 
 		{
 			name: "BGP path",
@@ -34,6 +34,11 @@ type Route struct {
 	mu        sync.Mutex
 	paths     []*Path
 	ecmpPaths uint
+
+	// selected is set once the paths have been ordered by path selection.
+	// selectionReason is the selection step preferring the best path over the next best one.
+	selected        bool
+	selectionReason uint8
 }
 
 // NewRoute generates a new route with path p
@@ -67,14 +72,20 @@ func NewRouteAddPath(pfx *net.Prefix, p []*Path) *Route {
 	return r
 }
 
-// Copy returns a copy of route r
+// Copy returns a copy of route r. The paths are shared with r.
 func (r *Route) Copy() *Route {
 	if r == nil {
 		return nil
 	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	n := &Route{
-		pfx:       r.pfx,
-		ecmpPaths: r.ecmpPaths,
+		pfx:             r.pfx,
+		ecmpPaths:       r.ecmpPaths,
+		selected:        r.selected,
+		selectionReason: r.selectionReason,
 	}
 	n.paths = make([]*Path, len(r.paths))
 	copy(n.paths, r.paths)
@@ -144,6 +155,18 @@ func (r *Route) BestPath() *Path {
 	return r.paths[0]
 }
 
+// BackupPath returns the path that becomes the best path once the current best path is removed. nil if non exists
+func (r *Route) BackupPath() *Path {
+	if r == nil {
+		return nil
+	}
+	if len(r.paths) < 2 {
+		return nil
+	}
+
+	return r.paths[1]
+}
+
 // AddPath adds path p to route r
 func (r *Route) AddPath(p *Path) {
 	if p == nil {
@@ -200,14 +223,34 @@ func removePath(paths []*Path, remove *Path) []*Path {
 
 // PathSelection recalculates the best path + active paths
 func (r *Route) PathSelection() {
+	r.PathSelectionWithOptions(nil)
+}
+
+// PathSelectionWithOptions recalculates the best path + active paths with the selection altered by opts
+func (r *Route) PathSelectionWithOptions(opts *SelectionOptions) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	sort.Slice(r.paths, func(i, j int) bool {
-		return r.paths[i].Select(r.paths[j]) == 1
+		return r.paths[i].SelectWithOptions(r.paths[j], opts) == 1
 	})
 
-	r.updateEqualPathCount()
+	if d := opts.administrativeDistances(); d != nil {
+		r.paths = orderByAdministrativeDistance(r.paths, d)
+	}
+
+	r.updateEqualPathCount(opts)
+	r.updateSelectionReason(opts)
+}
+
+// PromoteWithOptions updates the active paths of route r after paths have been removed from its ordered path list.
+// Removing a path keeps the remaining paths in order, so the backup path is promoted to best path without another path selection.
+func (r *Route) PromoteWithOptions(opts *SelectionOptions) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.updateEqualPathCount(opts)
+	r.updateSelectionReason(opts)
 }
 
 // Equal compares if two routes are the same
@@ -280,6 +323,11 @@ func (r *Route) ToProto() *api.Route {
 		a.Paths[i] = r.paths[i].ToProto()
 	}
 
+	if r.selected && len(a.Paths) > 0 {
+		a.Paths[0].Best = true
+		a.Paths[0].SelectionReason = selectionReasonToProto(r.selectionReason)
+	}
+
 	return a
 }
 
@@ -291,7 +339,10 @@ func RouteFromProtoRoute(ar *api.Route, dedup bool) *Route {
 	}
 
 	for i := range ar.Paths {
-		p := &Path{}
+		p := &Path{
+			Discard: ar.Paths[i].Discard,
+			Stale:   ar.Paths[i].Stale,
+		}
 		switch ar.Paths[i].Type {
 		case api.Path_BGP:
 			p.Type = BGPPathType
@@ -307,7 +358,31 @@ func RouteFromProtoRoute(ar *api.Route, dedup bool) *Route {
 	return r
 }
 
-func (r *Route) updateEqualPathCount() {
+// orderByAdministrativeDistance reorders the per protocol groups of the sorted paths by the distance of their best paths.
+// Groups with equal distance keep their order.
+func orderByAdministrativeDistance(paths []*Path, d *AdministrativeDistances) []*Path {
+	groups := make([][]*Path, 0)
+	for i, p := range paths {
+		if i == 0 || p.Type != paths[i-1].Type {
+			groups = append(groups, make([]*Path, 0))
+		}
+
+		groups[len(groups)-1] = append(groups[len(groups)-1], p)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return d.Distance(groups[i][0]) < d.Distance(groups[j][0])
+	})
+
+	ret := make([]*Path, 0, len(paths))
+	for _, g := range groups {
+		ret = append(ret, g...)
+	}
+
+	return ret
+}
+
+func (r *Route) updateEqualPathCount(opts *SelectionOptions) {
 	if len(r.paths) == 0 {
 		r.ecmpPaths = 0
 		return
@@ -315,7 +390,7 @@ func (r *Route) updateEqualPathCount() {
 
 	count := uint(1)
 	for i := 0; i < len(r.paths)-1; i++ {
-		if !r.paths[i].ECMP(r.paths[i+1]) {
+		if !r.paths[i].ECMPWithOptions(r.paths[i+1], opts) {
 			break
 		}
 		count++
@@ -324,6 +399,22 @@ func (r *Route) updateEqualPathCount() {
 	r.ecmpPaths = count
 }
 
+func (r *Route) updateSelectionReason(opts *SelectionOptions) {
+	r.selected = true
+	r.selectionReason = SelectionReasonNone
+	if len(r.paths) > 1 {
+		r.selectionReason = r.paths[0].SelectionReason(r.paths[1], opts)
+	}
+}
+
+// SelectionReason returns the selection step preferring the best path over the next best one
+func (r *Route) SelectionReason() uint8 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.selectionReason
+}
+
 func getBestProtocol(paths []*Path) uint8 {
 	best := uint8(0)
 	for _, p := range paths {
@@ -342,11 +433,16 @@ func getBestProtocol(paths []*Path) uint8 {
 
 // Print returns a printable representation of route `r`
 func (r *Route) Print() string {
-	ret := fmt.Sprintf("%s:\n", r.pfx.String())
-	ret += "All Paths:\n"
+	return string(r.AppendPrint(nil))
+}
+
+// AppendPrint appends the output of Print() to dst and returns the extended buffer
+func (r *Route) AppendPrint(dst []byte) []byte {
+	dst = r.pfx.AppendString(dst)
+	dst = append(dst, ":\nAll Paths:\n"...)
 	for _, p := range r.paths {
-		ret += p.Print()
+		dst = append(dst, p.Print()...)
 	}
 
-	return ret
+	return dst
 }
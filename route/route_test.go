@@ -111,6 +111,167 @@ func TestPathSelection(t *testing.T) {
 	}
 }
 
+func TestPathSelectionWithOptions(t *testing.T) {
+	newPath := func(firstAS uint32, asPathLen uint16) *Path {
+		return &Path{
+			Type: BGPPathType,
+			BGPPath: &BGPPath{
+				BGPPathA: &BGPPathA{
+					LocalPref:     100,
+					BGPIdentifier: firstAS,
+					NextHop:       bnet.IPv4(firstAS).Ptr(),
+					Source:        bnet.IPv4(firstAS).Ptr(),
+				},
+				ASPath: &types.ASPath{
+					{
+						Type: types.ASSequence,
+						ASNs: []uint32{firstAS},
+					},
+				},
+				ASPathLen: asPathLen,
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		opts         *SelectionOptions
+		expectedBest *Path
+		expectedECMP uint
+	}{
+		{
+			name:         "Defaults",
+			expectedBest: newPath(200, 1),
+			expectedECMP: 2,
+		},
+		{
+			name: "Multipath strict",
+			opts: &SelectionOptions{
+				ASPathMultipathStrict: true,
+			},
+			expectedBest: newPath(200, 1),
+			expectedECMP: 1,
+		},
+		{
+			name: "AS path length ignored",
+			opts: &SelectionOptions{
+				IgnoreASPathLength: true,
+			},
+			expectedBest: newPath(300, 2),
+			expectedECMP: 3,
+		},
+		{
+			name: "AS path length ignored and multipath strict",
+			opts: &SelectionOptions{
+				IgnoreASPathLength:    true,
+				ASPathMultipathStrict: true,
+			},
+			expectedBest: newPath(300, 2),
+			expectedECMP: 1,
+		},
+	}
+
+	for _, test := range tests {
+		r := &Route{
+			paths: []*Path{
+				newPath(100, 1),
+				newPath(200, 1),
+				newPath(300, 2),
+			},
+		}
+
+		r.PathSelectionWithOptions(test.opts)
+		assert.Equal(t, test.expectedBest, r.BestPath(), test.name)
+		assert.Equal(t, test.expectedECMP, r.ECMPPathCount(), test.name)
+	}
+}
+
+func TestPathSelectionAdministrativeDistance(t *testing.T) {
+	isisPath := &Path{
+		Type: ISISPathType,
+		ISISPath: &ISISPath{
+			NextHop: bnet.IPv4(1).Ptr(),
+			Metric:  10,
+			Level:   2,
+		},
+	}
+
+	bgpPath := func(localPref uint32, ebgp bool) *Path {
+		return &Path{
+			Type: BGPPathType,
+			BGPPath: &BGPPath{
+				BGPPathA: &BGPPathA{
+					LocalPref: localPref,
+					EBGP:      ebgp,
+					NextHop:   bnet.IPv4(localPref).Ptr(),
+					Source:    bnet.IPv4(localPref).Ptr(),
+				},
+				ASPath: &types.ASPath{},
+			},
+		}
+	}
+
+	distances := func(ibgp uint8) *AdministrativeDistances {
+		d := DefaultAdministrativeDistances()
+		d.IBGP = ibgp
+		return d
+	}
+
+	tests := []struct {
+		name           string
+		paths          []*Path
+		opts           *SelectionOptions
+		expectedBest   *Path
+		expectedBackup *Path
+		expectedECMP   uint
+	}{
+		{
+			name:           "Default distances prefer ISIS over iBGP",
+			paths:          []*Path{bgpPath(100, false), isisPath},
+			opts:           &SelectionOptions{AdministrativeDistances: DefaultAdministrativeDistances()},
+			expectedBest:   isisPath,
+			expectedBackup: bgpPath(100, false),
+			expectedECMP:   1,
+		},
+		{
+			name:           "Lowered iBGP distance prefers iBGP over ISIS",
+			paths:          []*Path{isisPath, bgpPath(100, false)},
+			opts:           &SelectionOptions{AdministrativeDistances: distances(100)},
+			expectedBest:   bgpPath(100, false),
+			expectedBackup: isisPath,
+			expectedECMP:   1,
+		},
+		{
+			name:           "Default distances prefer eBGP over ISIS",
+			paths:          []*Path{isisPath, bgpPath(100, true)},
+			opts:           &SelectionOptions{AdministrativeDistances: DefaultAdministrativeDistances()},
+			expectedBest:   bgpPath(100, true),
+			expectedBackup: isisPath,
+			expectedECMP:   1,
+		},
+		{
+			name:           "Distance of the BGP best path decides",
+			paths:          []*Path{bgpPath(100, true), isisPath, bgpPath(200, false)},
+			opts:           &SelectionOptions{AdministrativeDistances: DefaultAdministrativeDistances()},
+			expectedBest:   isisPath,
+			expectedBackup: bgpPath(200, false),
+			expectedECMP:   1,
+		},
+	}
+
+	for _, test := range tests {
+		r := &Route{
+			paths: test.paths,
+		}
+
+		r.PathSelectionWithOptions(test.opts)
+		assert.Equal(t, test.expectedBest, r.BestPath(), test.name)
+		assert.Equal(t, test.expectedBackup, r.BackupPath(), test.name)
+		assert.Equal(t, test.expectedECMP, r.ECMPPathCount(), test.name)
+		assert.Equal(t, uint8(SelectionReasonProtocol), r.SelectionReason(), test.name)
+	}
+}
+
 func TestNewRoute(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -827,6 +988,45 @@ func TestBestPath(t *testing.T) {
 	}
 }
 
+func TestBackupPathPromotion(t *testing.T) {
+	newPath := func(lpref uint32) *Path {
+		return &Path{
+			Type: BGPPathType,
+			BGPPath: &BGPPath{
+				BGPPathA: &BGPPathA{
+					LocalPref: lpref,
+					NextHop:   bnet.IPv4(lpref).Ptr(),
+					Source:    bnet.IPv4(lpref).Ptr(),
+				},
+			},
+		}
+	}
+
+	r := NewRouteAddPath(bnet.NewPfx(bnet.IPv4(0), 0).Ptr(), []*Path{
+		newPath(100),
+		newPath(300),
+		newPath(200),
+	})
+	r.PathSelection()
+
+	assert.Equal(t, newPath(300), r.BestPath())
+	assert.Equal(t, newPath(200), r.BackupPath())
+
+	r.RemovePath(newPath(300))
+	r.PromoteWithOptions(nil)
+	assert.Equal(t, newPath(200), r.BestPath())
+	assert.Equal(t, newPath(100), r.BackupPath())
+	assert.Equal(t, uint(1), r.ECMPPathCount())
+
+	r.RemovePath(newPath(200))
+	r.PromoteWithOptions(nil)
+	assert.Equal(t, newPath(100), r.BestPath())
+	assert.Nil(t, r.BackupPath())
+
+	var nilRoute *Route
+	assert.Nil(t, nilRoute.BackupPath())
+}
+
 func TestECMPPaths(t *testing.T) {
 	tests := []struct {
 		route    *Route
@@ -1130,6 +1330,69 @@ func TestRouteToProto(t *testing.T) {
 	}
 }
 
+func TestRouteToProtoBestPath(t *testing.T) {
+	newPath := func(lpref uint32, asPathLen uint16) *Path {
+		return &Path{
+			Type: BGPPathType,
+			BGPPath: &BGPPath{
+				BGPPathA: &BGPPathA{
+					LocalPref: lpref,
+					NextHop:   bnet.IPv4(lpref).Ptr(),
+					Source:    bnet.IPv4(lpref).Ptr(),
+				},
+				ASPathLen: asPathLen,
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		paths          []*Path
+		expectedReason api.Path_SelectionReason
+	}{
+		{
+			name:           "Local Preference",
+			paths:          []*Path{newPath(100, 1), newPath(200, 3), newPath(150, 1)},
+			expectedReason: api.Path_SelectionReasonLocalPref,
+		},
+		{
+			name:           "AS Path length",
+			paths:          []*Path{newPath(100, 3), newPath(100, 2)},
+			expectedReason: api.Path_SelectionReasonASPathLength,
+		},
+		{
+			name:           "Single path",
+			paths:          []*Path{newPath(100, 3)},
+			expectedReason: api.Path_SelectionReasonNone,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := NewRouteAddPath(bnet.NewPfx(bnet.IPv4(0), 0).Ptr(), test.paths)
+			for _, p := range r.ToProto().Paths {
+				assert.False(t, p.Best, "best path marked before path selection")
+			}
+
+			r.PathSelection()
+
+			best := 0
+			for i, p := range r.ToProto().Paths {
+				if !p.Best {
+					assert.Equal(t, api.Path_SelectionReasonNone, p.SelectionReason)
+					continue
+				}
+
+				best++
+				assert.Equal(t, 0, i, "best path is not the first path")
+				assert.Equal(t, test.expectedReason, p.SelectionReason)
+			}
+
+			assert.Equal(t, 1, best)
+		})
+	}
+}
+
 func TestRouteFromProtoRoute(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -1174,6 +1437,33 @@ func TestRouteFromProtoRoute(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "Discard static route",
+			protoRoute: &api.Route{
+				Pfx: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 1), 32).Ptr().ToProto(),
+				Paths: []*api.Path{
+					{
+						Type:    api.Path_Static,
+						Discard: true,
+						StaticPath: &api.StaticPath{
+							NextHop: bnet.IPv4FromOctets(192, 168, 0, 1).Ptr().ToProto(),
+						},
+					},
+				},
+			},
+			result: &Route{
+				pfx: bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 1), 32).Ptr(),
+				paths: []*Path{
+					{
+						Type:    StaticPathType,
+						Discard: true,
+						StaticPath: &StaticPath{
+							NextHop: bnet.IPv4FromOctets(192, 168, 0, 1).Ptr(),
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "Bare BGP route",
 			protoRoute: &api.Route{
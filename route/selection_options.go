@@ -0,0 +1,80 @@
+package route
+
+// SelectionOptions alters the best path selection. All options are off by default.
+type SelectionOptions struct {
+	// IgnoreASPathLength skips the AS path length comparison in BGP best path selection and multipath
+	IgnoreASPathLength bool
+
+	// ASPathMultipathStrict requires BGP paths to have the same AS path to be used for multipath.
+	// Unless set paths with different AS paths of the same length are used for multipath.
+	ASPathMultipathStrict bool
+
+	// MEDMissingAsWorst treats BGP paths without MED as having the highest possible MED.
+	// Unless set a missing MED is treated as 0 and preferred over any MED carried by a path.
+	MEDMissingAsWorst bool
+
+	// AdministrativeDistances arbitrates between paths of different protocols for the same prefix.
+	// Unless set paths of different protocols are ordered by their path type.
+	AdministrativeDistances *AdministrativeDistances
+}
+
+// AdministrativeDistances represents the preference of paths learned by different protocols. Lower distances are preferred.
+// The distance only decides between protocols, paths of the same protocol are compared by the protocols own selection rules.
+type AdministrativeDistances struct {
+	Static uint8
+	EBGP   uint8
+	IBGP   uint8
+	OSPF   uint8
+	ISIS   uint8
+}
+
+// DefaultAdministrativeDistances returns the commonly used administrative distances
+func DefaultAdministrativeDistances() *AdministrativeDistances {
+	return &AdministrativeDistances{
+		Static: 1,
+		EBGP:   20,
+		OSPF:   110,
+		ISIS:   115,
+		IBGP:   200,
+	}
+}
+
+// Distance gets the administrative distance of path p. Paths of other types, e.g. FIB paths, have the distance 255.
+func (d *AdministrativeDistances) Distance(p *Path) uint8 {
+	switch p.Type {
+	case StaticPathType:
+		return d.Static
+	case BGPPathType:
+		if p.BGPPath.BGPPathA.EBGP {
+			return d.EBGP
+		}
+
+		return d.IBGP
+	case OSPFPathType:
+		return d.OSPF
+	case ISISPathType:
+		return d.ISIS
+	}
+
+	return 255
+}
+
+func (o *SelectionOptions) ignoreASPathLength() bool {
+	return o != nil && o.IgnoreASPathLength
+}
+
+func (o *SelectionOptions) asPathMultipathStrict() bool {
+	return o != nil && o.ASPathMultipathStrict
+}
+
+func (o *SelectionOptions) medMissingAsWorst() bool {
+	return o != nil && o.MEDMissingAsWorst
+}
+
+func (o *SelectionOptions) administrativeDistances() *AdministrativeDistances {
+	if o == nil {
+		return nil
+	}
+
+	return o.AdministrativeDistances
+}
@@ -0,0 +1,101 @@
+package route
+
+import "github.com/bio-routing/bio-rd/route/api"
+
+// Path selection steps preferring the best path of a route over the next best one
+const (
+	SelectionReasonNone = iota
+	SelectionReasonProtocol
+	SelectionReasonWeight
+	SelectionReasonLocalPref
+	SelectionReasonASPathLength
+	SelectionReasonOrigin
+	SelectionReasonMED
+	SelectionReasonEBGP
+	SelectionReasonRouterID
+	SelectionReasonClusterListLength
+	SelectionReasonPeerAddress
+	SelectionReasonNextHop
+	SelectionReasonIGPMetric
+)
+
+// SelectionReason returns the selection step preferring path p over q. SelectionReasonNone if no step prefers either.
+func (p *Path) SelectionReason(q *Path, opts *SelectionOptions) uint8 {
+	if p == nil || q == nil {
+		return SelectionReasonNone
+	}
+
+	if p.Type != q.Type {
+		return SelectionReasonProtocol
+	}
+
+	if p.Type == BGPPathType {
+		_, reason := compareBGPPaths(p.BGPPath, q.BGPPath, opts)
+		return reason
+	}
+
+	return SelectionReasonNone
+}
+
+// SelectionReasonString returns a human readable representation of a selection reason
+func SelectionReasonString(reason uint8) string {
+	switch reason {
+	case SelectionReasonProtocol:
+		return "Protocol"
+	case SelectionReasonWeight:
+		return "Weight"
+	case SelectionReasonLocalPref:
+		return "Local Preference"
+	case SelectionReasonASPathLength:
+		return "AS Path length"
+	case SelectionReasonOrigin:
+		return "Origin"
+	case SelectionReasonMED:
+		return "MED"
+	case SelectionReasonEBGP:
+		return "EBGP over IBGP"
+	case SelectionReasonRouterID:
+		return "Router ID"
+	case SelectionReasonClusterListLength:
+		return "Cluster List length"
+	case SelectionReasonPeerAddress:
+		return "Peer address"
+	case SelectionReasonNextHop:
+		return "Next-Hop"
+	case SelectionReasonIGPMetric:
+		return "IGP metric"
+	default:
+		return ""
+	}
+}
+
+func selectionReasonToProto(reason uint8) api.Path_SelectionReason {
+	switch reason {
+	case SelectionReasonProtocol:
+		return api.Path_SelectionReasonProtocol
+	case SelectionReasonWeight:
+		return api.Path_SelectionReasonWeight
+	case SelectionReasonLocalPref:
+		return api.Path_SelectionReasonLocalPref
+	case SelectionReasonASPathLength:
+		return api.Path_SelectionReasonASPathLength
+	case SelectionReasonOrigin:
+		return api.Path_SelectionReasonOrigin
+	case SelectionReasonMED:
+		return api.Path_SelectionReasonMED
+	case SelectionReasonEBGP:
+		return api.Path_SelectionReasonEBGP
+	case SelectionReasonRouterID:
+		return api.Path_SelectionReasonRouterID
+	case SelectionReasonClusterListLength:
+		return api.Path_SelectionReasonClusterListLength
+	case SelectionReasonPeerAddress:
+		return api.Path_SelectionReasonPeerAddress
+	case SelectionReasonNextHop:
+		return api.Path_SelectionReasonNextHop
+	case SelectionReasonIGPMetric:
+		return api.Path_SelectionReasonIGPMetric
+	default:
+		return api.Path_SelectionReasonNone
+	}
+}
@@ -2,6 +2,7 @@ package adjRIBIn
 
 import (
 	"sync"
+	"sync/atomic"
 
 	"github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/protocols/bgp/packet"
@@ -19,6 +20,30 @@ type AdjRIBIn struct {
 	exportFilterChain filter.Chain
 	contributingASNs  *routingtable.ContributingASNs
 	sessionAttrs      routingtable.SessionAttrs
+	stale             map[stalePath]struct{}
+
+	// advertised holds the paths as advertised to the clients, i.e. after applying the filter chain. Filters may depend
+	// on external state, so the result of an earlier run can not be reproduced.
+	advertised map[stalePath]*route.Path
+
+	// prePolicyPaths and prePolicyBytes account for all stored paths, including the ones rejected by the filter chain
+	prePolicyPaths atomic.Int64
+	prePolicyBytes atomic.Int64
+
+	// asLoops holds the number of stored paths per prefix hidden as one of our ASNs is part of their AS path.
+	// asLoopSamples counts the prefixes becoming part of asLoops for sampling the log.
+	asLoops       map[net.Prefix]int
+	asLoopSamples uint64
+}
+
+// asLoopLogSampleRate is the number of prefixes with an AS path loop per logged sample
+const asLoopLogSampleRate = 1000
+
+// stalePath identifies a path received from the peer, e.g. one marked stale. Without ADD-PATH a prefix has only one
+// path received from the peer.
+type stalePath struct {
+	pfx    net.Prefix
+	pathID uint32
 }
 
 // New creates a new Adjacency RIB In
@@ -28,6 +53,8 @@ func New(exportFilterChain filter.Chain, contributingASNs *routingtable.Contribu
 		exportFilterChain: exportFilterChain,
 		contributingASNs:  contributingASNs,
 		sessionAttrs:      sessionAttrs,
+		advertised:        make(map[stalePath]*route.Path),
+		asLoops:           make(map[net.Prefix]int),
 	}
 	a.clientManager = routingtable.NewClientManager(a)
 	return a
@@ -46,6 +73,14 @@ func (a *AdjRIBIn) Dump() []*route.Route {
 	return a.rt.Dump()
 }
 
+// Snapshot gets a consistent copy of all routes which is not changed by later updates of the RIB
+func (a *AdjRIBIn) Snapshot() []*route.Route {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.rt.Snapshot()
+}
+
 // Flush drops all routes from the AdjRIBIn
 func (a *AdjRIBIn) Flush() {
 	a.mu.Lock()
@@ -57,50 +92,148 @@ func (a *AdjRIBIn) Flush() {
 			a.removePath(route.Prefix(), path)
 		}
 	}
+
+	a.stale = nil
+}
+
+// MarkStale marks all paths as stale. Paths received again are unmarked, all others get removed by RemoveStale.
+func (a *AdjRIBIn) MarkStale() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.stale = make(map[stalePath]struct{})
+	for _, r := range a.rt.Dump() {
+		for _, p := range r.Paths() {
+			a.stale[a.stalePath(r.Prefix(), p)] = struct{}{}
+		}
+	}
+}
+
+// RemoveStale removes all paths still marked stale and returns the number of removed paths
+func (a *AdjRIBIn) RemoveStale() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stale := a.stale
+	a.stale = nil
+
+	for sp := range stale {
+		pfx := sp.pfx
+		a.removePath(&pfx, &route.Path{
+			BGPPath: &route.BGPPath{
+				PathIdentifier: sp.pathID,
+			},
+		})
+	}
+
+	return len(stale)
 }
 
-// ReplaceFilterChain replaces the filter chain
+func (a *AdjRIBIn) stalePath(pfx *net.Prefix, p *route.Path) stalePath {
+	sp := stalePath{
+		pfx: *pfx,
+	}
+
+	if a.sessionAttrs.AddPathRX && p != nil {
+		sp.pathID = p.BGPPath.PathIdentifier
+	}
+
+	return sp
+}
+
+// ReplaceFilterChain replaces the filter chain. All stored paths are run through the old and the new chain and only
+// the paths the decision or the resulting attributes changed for are propagated to the clients.
 func (a *AdjRIBIn) ReplaceFilterChain(c filter.Chain) {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
 	routes := a.rt.Dump()
-	for _, route := range routes {
-		paths := route.Paths()
+	for _, r := range routes {
+		paths := r.Paths()
 		for _, path := range paths {
-			currentPath, currentReject := a.exportFilterChain.Process(route.Prefix(), path)
-			newPath, newReject := c.Process(route.Prefix(), path)
-
-			if currentReject && newReject {
+			// Paths ineligible in the first place have never been advertised
+			if path.HiddenReason != route.HiddenReasonNone {
 				continue
 			}
 
-			if currentReject && !newReject {
-				for _, client := range a.clientManager.Clients() {
-					client.AddPath(route.Prefix(), newPath)
-				}
+			newPath, newReject := c.Process(r.Prefix(), path)
+			a.updateAdvertised(r.Prefix(), path, newPath, newReject)
+		}
+	}
 
-				continue
-			}
+	a.exportFilterChain = c
+}
 
-			if !currentReject && newReject {
-				for _, client := range a.clientManager.Clients() {
-					client.RemovePath(route.Prefix(), newPath)
-				}
+// updateAdvertised propagates the changes between the path advertised for the stored path p and its new filter result
+// newPath to the clients
+func (a *AdjRIBIn) updateAdvertised(pfx *net.Prefix, p *route.Path, newPath *route.Path, newReject bool) {
+	k := a.stalePath(pfx, p)
+	currentPath, advertised := a.advertised[k]
+
+	if !advertised && newReject {
+		return
+	}
+
+	if !advertised && !newReject {
+		a.advertised[k] = newPath
+		for _, client := range a.clientManager.Clients() {
+			client.AddPath(pfx, newPath)
+		}
+
+		return
+	}
+
+	if advertised && newReject {
+		delete(a.advertised, k)
+		for _, client := range a.clientManager.Clients() {
+			client.RemovePath(pfx, currentPath)
+		}
+
+		return
+	}
+
+	if currentPath.Equal(newPath) {
+		return
+	}
+
+	a.advertised[k] = newPath
+	for _, client := range a.clientManager.Clients() {
+		client.ReplacePath(pfx, currentPath, newPath)
+	}
+}
+
+// ReapplyFilterChain runs all stored paths through the filter chain again. Filters may depend on external state, e.g.
+// prefix lists, so the results are compared to the advertised paths and only changes are propagated to the clients.
+func (a *AdjRIBIn) ReapplyFilterChain() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, r := range a.rt.Dump() {
+		for _, path := range r.Paths() {
+			// Paths ineligible in the first place have never been advertised
+			if path.HiddenReason != route.HiddenReasonNone {
 				continue
 			}
 
-			if !currentReject && !newReject {
-				for _, client := range a.clientManager.Clients() {
-					if !currentPath.Equal(newPath) {
-						client.ReplacePath(route.Prefix(), currentPath, newPath)
-					}
-				}
-			}
+			newPath, reject := a.exportFilterChain.Process(r.Prefix(), path)
+			a.updateAdvertised(r.Prefix(), path, newPath, reject)
 		}
 	}
+}
 
-	a.exportFilterChain = c
+// EvaluatePath runs a stored path through the filter chain without advertising it. The result is rejected if the path
+// would not be advertised to the clients.
+func (a *AdjRIBIn) EvaluatePath(pfx *net.Prefix, p *route.Path) filter.ChainResult {
+	if p.HiddenReason != route.HiddenReasonNone {
+		return filter.ChainResult{
+			Reject: true,
+		}
+	}
+
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.exportFilterChain.Evaluate(pfx, p)
 }
 
 func (a *AdjRIBIn) ReplacePath(pfx *net.Prefix, old *route.Path, new *route.Path) {
@@ -116,8 +249,8 @@ func (a *AdjRIBIn) UpdateNewClient(client routingtable.RouteTableClient) error {
 	for _, route := range routes {
 		paths := route.Paths()
 		for _, path := range paths {
-			path, reject := a.exportFilterChain.Process(route.Prefix(), path)
-			if reject {
+			path, advertised := a.advertised[a.stalePath(route.Prefix(), path)]
+			if !advertised {
 				continue
 			}
 
@@ -150,6 +283,20 @@ func (a *AdjRIBIn) AddPath(pfx *net.Prefix, p *route.Path) error {
 
 // addPath replaces the path for prefix `pfx`. If the prefix doesn't exist it is added.
 func (a *AdjRIBIn) addPath(pfx *net.Prefix, p *route.Path) error {
+	// Validation may alter the path (OTC), so it has to happen before the path is shared.
+	// Paths hidden by the session already, e.g. due to an invalid next hop, keep their reason.
+	if p.HiddenReason == route.HiddenReasonNone {
+		p.HiddenReason = a.validatePath(p)
+	}
+	p.BGPPath = p.BGPPath.Intern()
+	delete(a.stale, a.stalePath(pfx, p))
+
+	// A re-advertisement without changes must not cause churn downstream
+	if old := a.storedPath(pfx, p); old != nil && unchangedPath(old, p) {
+		p.BGPPath.Release()
+		return nil
+	}
+
 	var oldPaths []*route.Path
 	if a.sessionAttrs.AddPathRX {
 		oldPaths = make([]*route.Path, 0)
@@ -167,26 +314,67 @@ func (a *AdjRIBIn) addPath(pfx *net.Prefix, p *route.Path) error {
 	} else {
 		oldPaths = a.rt.ReplacePath(pfx, p)
 	}
+	a.accountPath(pfx, p, 1)
+	a.accountPaths(pfx, oldPaths, -1)
 	a.removePathsFromClients(pfx, oldPaths)
+	releasePaths(oldPaths)
 
 	// Bail out if this path is considered ineligible
-	p.HiddenReason = a.validatePath(p)
 	if p.HiddenReason != route.HiddenReasonNone {
 		return nil
 	}
 
+	k := a.stalePath(pfx, p)
 	p, reject := a.exportFilterChain.Process(pfx, p)
 	if reject {
 		p.HiddenReason = route.HiddenReasonFilteredByPolicy
 		return nil
 	}
 
+	a.advertised[k] = p
 	for _, client := range a.clientManager.Clients() {
 		client.AddPath(pfx, p)
 	}
 	return nil
 }
 
+// storedPath gets the path p replaces if there is exactly one
+func (a *AdjRIBIn) storedPath(pfx *net.Prefix, p *route.Path) *route.Path {
+	r := a.rt.Get(pfx)
+	if r == nil {
+		return nil
+	}
+
+	var ret *route.Path
+	for _, path := range r.Paths() {
+		if a.sessionAttrs.AddPathRX && path.BGPPath.PathIdentifier != p.BGPPath.PathIdentifier {
+			continue
+		}
+
+		if ret != nil {
+			return nil
+		}
+
+		ret = path
+	}
+
+	return ret
+}
+
+// unchangedPath checks if p carries the same attributes as the stored path old and is equally eligible
+func unchangedPath(old *route.Path, p *route.Path) bool {
+	if old.HiddenReason != p.HiddenReason || old.BGPPath.Weight != p.BGPPath.Weight {
+		return false
+	}
+
+	// OTC is not part of the hash
+	if old.BGPPath.BGPPathA.OnlyToCustomer != p.BGPPath.BGPPathA.OnlyToCustomer {
+		return false
+	}
+
+	return old.BGPPath.ComputeHash() == p.BGPPath.ComputeHash()
+}
+
 // RemovePath removes the path for prefix `pfx`
 func (a *AdjRIBIn) RemovePath(pfx *net.Prefix, p *route.Path) bool {
 	a.mu.Lock()
@@ -197,6 +385,8 @@ func (a *AdjRIBIn) RemovePath(pfx *net.Prefix, p *route.Path) bool {
 
 // removePath removes the path for prefix `pfx`
 func (a *AdjRIBIn) removePath(pfx *net.Prefix, p *route.Path) bool {
+	delete(a.stale, a.stalePath(pfx, p))
+
 	r := a.rt.Get(pfx)
 	if r == nil {
 		return false
@@ -215,10 +405,81 @@ func (a *AdjRIBIn) removePath(pfx *net.Prefix, p *route.Path) bool {
 		removed = append(removed, path)
 	}
 
+	a.accountPaths(pfx, removed, -1)
 	a.removePathsFromClients(pfx, removed)
+	releasePaths(removed)
 	return true
 }
 
+// ASLoopCount returns the number of prefixes with a stored path hidden as one of our ASNs is part of its AS path
+func (a *AdjRIBIn) ASLoopCount() uint64 {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return uint64(len(a.asLoops))
+}
+
+// accountASLoop accounts for a stored or removed path hidden due to an AS path loop. Prefixes are counted once,
+// regardless of how often the path is re-advertised or how many paths it has. If enabled, the first prefix and every
+// asLoopLogSampleRate-th one after it are logged. A peer reflecting our routes back to us causes many loops.
+func (a *AdjRIBIn) accountASLoop(pfx *net.Prefix, p *route.Path, sign int) {
+	n := a.asLoops[*pfx] + sign
+	if n <= 0 {
+		delete(a.asLoops, *pfx)
+		return
+	}
+
+	a.asLoops[*pfx] = n
+	if n > 1 {
+		return
+	}
+
+	a.asLoopSamples++
+	if !a.sessionAttrs.LogASLoops || (a.asLoopSamples-1)%asLoopLogSampleRate != 0 {
+		return
+	}
+
+	log.WithFields(log.Fields{
+		"peer":     a.sessionAttrs.PeerIP.String(),
+		"prefix":   pfx.String(),
+		"as_path":  p.BGPPath.ASPath.String(),
+		"prefixes": len(a.asLoops),
+	}).Info("Received path containing one of our ASNs in its AS path")
+}
+
+// PrePolicyPathCount returns the number of stored paths, including the ones rejected by the filter chain
+func (a *AdjRIBIn) PrePolicyPathCount() uint64 {
+	return uint64(a.prePolicyPaths.Load())
+}
+
+// PrePolicyBytes returns an estimate of the memory used by all stored paths, including the ones rejected by the
+// filter chain. Interned attributes shared with other RIBs are accounted for each path, so this is an upper bound.
+func (a *AdjRIBIn) PrePolicyBytes() uint64 {
+	return uint64(a.prePolicyBytes.Load())
+}
+
+func (a *AdjRIBIn) accountPaths(pfx *net.Prefix, paths []*route.Path, sign int64) {
+	for _, p := range paths {
+		a.accountPath(pfx, p, sign)
+	}
+}
+
+func (a *AdjRIBIn) accountPath(pfx *net.Prefix, p *route.Path, sign int64) {
+	a.prePolicyPaths.Add(sign)
+	a.prePolicyBytes.Add(sign * int64(p.EstimatedSize()))
+
+	if p.HiddenReason == route.HiddenReasonASLoop {
+		a.accountASLoop(pfx, p, int(sign))
+	}
+}
+
+// releasePaths drops the references on the interned attributes of paths removed from the RIB
+func releasePaths(paths []*route.Path) {
+	for _, p := range paths {
+		p.BGPPath.Release()
+	}
+}
+
 func (a *AdjRIBIn) removePathsFromClients(pfx *net.Prefix, paths []*route.Path) {
 	for _, path := range paths {
 		// If this path wasn't eligible in the first place, we didn't announce it
@@ -226,10 +487,13 @@ func (a *AdjRIBIn) removePathsFromClients(pfx *net.Prefix, paths []*route.Path)
 			continue
 		}
 
-		path, reject := a.exportFilterChain.Process(pfx, path)
-		if reject {
+		k := a.stalePath(pfx, path)
+		path, advertised := a.advertised[k]
+		if !advertised {
 			continue
 		}
+
+		delete(a.advertised, k)
 		for _, client := range a.clientManager.Clients() {
 			client.RemovePath(pfx, path)
 		}
@@ -353,6 +617,7 @@ func (a *AdjRIBIn) validatePathOnlyToCustomer(path *route.Path) bool {
 	// 3. Route rcvd from Provider, Peer or RS without OTC set
 	if path.BGPPath.BGPPathA.OnlyToCustomer == 0 &&
 		(pr == packet.PeerRoleRoleProvider || pr == packet.PeerRoleRolePeer || pr == packet.PeerRoleRoleRS) {
+		path.BGPPath = path.BGPPath.Copy()
 		path.BGPPath.BGPPathA.OnlyToCustomer = a.sessionAttrs.PeerASN
 		return true
 	}
@@ -1,6 +1,7 @@
 package adjRIBIn
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/bio-routing/bio-rd/net"
@@ -9,6 +10,8 @@ import (
 	"github.com/bio-routing/bio-rd/route"
 	"github.com/bio-routing/bio-rd/routingtable"
 	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/filter/actions"
+	"github.com/bio-routing/bio-rd/routingtable/locRIB"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -66,7 +69,7 @@ func TestAddPath(t *testing.T) {
 					Type: route.BGPPathType,
 					BGPPath: &route.BGPPath{
 						BGPPathA: &route.BGPPathA{
-							LocalPref: 100,
+							LocalPref: 200,
 							NextHop:   net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
 							Source:    net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
 						},
@@ -89,7 +92,7 @@ func TestAddPath(t *testing.T) {
 					Type: route.BGPPathType,
 					BGPPath: &route.BGPPath{
 						BGPPathA: &route.BGPPathA{
-							LocalPref: 100,
+							LocalPref: 200,
 							NextHop:   net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
 							Source:    net.IPv4FromOctets(20, 0, 0, 0).Ptr(),
 						},
@@ -850,3 +853,573 @@ func TestPeerRoleOTC(t *testing.T) {
 		assert.Equal(t, test.expected, adjRIBIn.rt.Dump(), test.name)
 	}
 }
+
+func internTestPath(source *net.IP) *route.Path {
+	return &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			BGPPathA: &route.BGPPathA{
+				NextHop:   source,
+				Source:    source,
+				LocalPref: 100,
+				EBGP:      true,
+			},
+			ASPath: &types.ASPath{
+				{
+					Type: types.ASSequence,
+					ASNs: []uint32{65001, 65002},
+				},
+			},
+			ASPathLen: 2,
+		},
+	}
+}
+
+func TestAddPathInternsAttributes(t *testing.T) {
+	before := route.InternedBGPPathCount()
+
+	exportFilterChain := filter.Chain{
+		filter.NewFilter("MODIFY", []*filter.Term{
+			filter.NewTerm("MODIFY", []*filter.TermCondition{}, []actions.Action{
+				actions.NewSetMEDAction(1000),
+				actions.NewASPathPrependAction(65000, 3),
+				actions.NewAcceptAction(),
+			}),
+		}),
+	}
+
+	ribs := make([]*AdjRIBIn, 0)
+	for peer := 0; peer < 3; peer++ {
+		source := net.IPv4FromOctets(192, 168, 0, uint8(peer)).Dedup()
+		a := New(exportFilterChain, routingtable.NewContributingASNs(), routingtable.SessionAttrs{})
+		a.Register(routingtable.NewRTMockClient())
+
+		for i := 0; i < 100; i++ {
+			a.AddPath(net.NewPfx(net.IPv4FromOctets(10, 0, uint8(i), 0), 24).Ptr(), internTestPath(source))
+		}
+
+		ribs = append(ribs, a)
+	}
+
+	distinct := make(map[*route.BGPPath]struct{})
+	for _, a := range ribs {
+		for _, r := range a.Dump() {
+			for _, p := range r.Paths() {
+				distinct[p.BGPPath] = struct{}{}
+
+				assert.Equal(t, uint32(0), p.BGPPath.BGPPathA.MED, "shared MED modified by filter")
+				assert.Equal(t, "65001 65002", p.BGPPath.ASPath.String(), "shared AS path modified by filter")
+				assert.Equal(t, uint16(2), p.BGPPath.ASPathLen, "shared AS path length modified by filter")
+			}
+		}
+	}
+
+	assert.Equal(t, 3, len(distinct), "one attribute set per peer expected")
+	assert.Equal(t, before+3, route.InternedBGPPathCount())
+
+	for _, a := range ribs {
+		a.Flush()
+	}
+
+	assert.Equal(t, before, route.InternedBGPPathCount(), "attributes still interned after flush")
+}
+
+func TestAddPathOTCDoesNotModifyInterned(t *testing.T) {
+	source := net.IPv4FromOctets(192, 168, 1, 1).Dedup()
+	routerID := net.IPv4FromOctets(1, 1, 1, 1).Ptr().ToUint32()
+
+	customer := New(filter.NewAcceptAllFilterChain(), routingtable.NewContributingASNs(), routingtable.SessionAttrs{
+		RouterID: routerID,
+	})
+	provider := New(filter.NewAcceptAllFilterChain(), routingtable.NewContributingASNs(), routingtable.SessionAttrs{
+		RouterID:          routerID,
+		PeerASN:           23,
+		PeerRoleEnabled:   true,
+		PeerRoleAdvByPeer: true,
+		PeerRoleRemote:    packet.PeerRoleRoleProvider,
+	})
+
+	pfx := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	customer.AddPath(pfx, internTestPath(source))
+	provider.AddPath(pfx, internTestPath(source))
+
+	assert.Equal(t, uint32(0), customer.Get(pfx).Paths()[0].BGPPath.BGPPathA.OnlyToCustomer)
+	assert.Equal(t, uint32(23), provider.Get(pfx).Paths()[0].BGPPath.BGPPathA.OnlyToCustomer)
+
+	customer.Flush()
+	provider.Flush()
+}
+
+func TestRemoveStale(t *testing.T) {
+	source := net.IPv4FromOctets(192, 168, 0, 1).Dedup()
+	pfx1 := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	pfx2 := net.NewPfx(net.IPv4FromOctets(10, 1, 0, 0), 16).Ptr()
+	pfx3 := net.NewPfx(net.IPv4FromOctets(10, 2, 0, 0), 16).Ptr()
+
+	withPathID := func(id uint32) *route.Path {
+		p := internTestPath(source)
+		p.BGPPath.PathIdentifier = id
+		return p
+	}
+
+	tests := []struct {
+		name            string
+		addPath         bool
+		before          func(a *AdjRIBIn)
+		refresh         func(a *AdjRIBIn)
+		expectedRemoved int
+		expected        map[*net.Prefix]int
+	}{
+		{
+			name: "Paths not re-advertised are removed",
+			before: func(a *AdjRIBIn) {
+				a.AddPath(pfx1, internTestPath(source))
+				a.AddPath(pfx2, internTestPath(source))
+				a.AddPath(pfx3, internTestPath(source))
+			},
+			refresh: func(a *AdjRIBIn) {
+				a.AddPath(pfx1, internTestPath(source))
+				a.RemovePath(pfx3, nil)
+			},
+			expectedRemoved: 1,
+			expected: map[*net.Prefix]int{
+				pfx1: 1,
+				pfx2: 0,
+				pfx3: 0,
+			},
+		},
+		{
+			name: "Paths received during refresh are kept",
+			before: func(a *AdjRIBIn) {
+				a.AddPath(pfx1, internTestPath(source))
+			},
+			refresh: func(a *AdjRIBIn) {
+				a.AddPath(pfx2, internTestPath(source))
+			},
+			expectedRemoved: 1,
+			expected: map[*net.Prefix]int{
+				pfx1: 0,
+				pfx2: 1,
+			},
+		},
+		{
+			name:    "ADD-PATH paths are tracked individually",
+			addPath: true,
+			before: func(a *AdjRIBIn) {
+				a.AddPath(pfx1, withPathID(1))
+				a.AddPath(pfx1, withPathID(2))
+				a.AddPath(pfx2, withPathID(1))
+			},
+			refresh: func(a *AdjRIBIn) {
+				a.AddPath(pfx1, withPathID(2))
+			},
+			expectedRemoved: 2,
+			expected: map[*net.Prefix]int{
+				pfx1: 1,
+				pfx2: 0,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		a := New(filter.NewAcceptAllFilterChain(), routingtable.NewContributingASNs(), routingtable.SessionAttrs{
+			AddPathRX: test.addPath,
+		})
+		mc := routingtable.NewRTMockClient()
+		a.Register(mc)
+
+		test.before(a)
+		a.MarkStale()
+		test.refresh(a)
+
+		assert.Equal(t, test.expectedRemoved, a.RemoveStale(), test.name)
+		for pfx, n := range test.expected {
+			r := a.Get(pfx)
+			if n == 0 {
+				assert.Nil(t, r, "%s: %s", test.name, pfx)
+				continue
+			}
+
+			assert.Equal(t, n, len(r.Paths()), "%s: %s", test.name, pfx)
+		}
+
+		assert.Equal(t, 0, a.RemoveStale(), "%s: nothing stale after cleanup", test.name)
+		a.Flush()
+	}
+}
+
+func BenchmarkAddPath(b *testing.B) {
+	source := net.IPv4FromOctets(192, 168, 0, 1).Dedup()
+
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		a := New(filter.NewAcceptAllFilterChain(), routingtable.NewContributingASNs(), routingtable.SessionAttrs{})
+		for i := 0; i < 10000; i++ {
+			a.AddPath(net.NewPfx(net.IPv4FromOctets(10, uint8(i>>8), uint8(i), 0), 24).Ptr(), internTestPath(source))
+		}
+
+		a.Flush()
+	}
+}
+
+// switchAction rejects paths while reject is set, e.g. like a filter depending on an external prefix list
+type switchAction struct {
+	reject *bool
+}
+
+func (s switchAction) Do(p *net.Prefix, pa *route.Path) actions.Result {
+	return actions.Result{
+		Path:      pa,
+		Reject:    *s.reject,
+		Terminate: true,
+	}
+}
+
+func (s switchAction) Equal(x actions.Action) bool {
+	return false
+}
+
+func TestReapplyFilterChain(t *testing.T) {
+	source := net.IPv4FromOctets(192, 168, 0, 1).Dedup()
+	pfx := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+
+	reject := true
+	c := filter.Chain{
+		filter.NewFilter("SWITCH", []*filter.Term{
+			filter.NewTerm("SWITCH", nil, []actions.Action{switchAction{reject: &reject}}),
+		}),
+	}
+
+	a := New(c, routingtable.NewContributingASNs(), routingtable.SessionAttrs{RouterID: 1})
+	rib := locRIB.New("inet.0")
+	a.Register(rib)
+
+	a.AddPath(pfx, internTestPath(source))
+	assert.Equal(t, int64(0), rib.RouteCount())
+
+	reject = false
+	a.ReapplyFilterChain()
+	assert.Equal(t, int64(1), rib.RouteCount())
+	assert.Len(t, rib.Get(pfx).Paths(), 1)
+
+	// Re-applying an unchanged filter chain must not duplicate paths
+	a.ReapplyFilterChain()
+	assert.Len(t, rib.Get(pfx).Paths(), 1)
+
+	reject = true
+	a.ReapplyFilterChain()
+	assert.Equal(t, int64(0), rib.RouteCount())
+	assert.Equal(t, int64(1), a.RouteCount(), "stored path must be kept")
+}
+
+type localPrefAction struct {
+	localPref *uint32
+}
+
+func (l localPrefAction) Do(p *net.Prefix, pa *route.Path) actions.Result {
+	modified := pa.Copy()
+	modified.BGPPath.BGPPathA.LocalPref = *l.localPref
+
+	return actions.Result{
+		Path: modified,
+	}
+}
+
+func (l localPrefAction) Equal(x actions.Action) bool {
+	return false
+}
+
+func TestReapplyFilterChainModified(t *testing.T) {
+	source := net.IPv4FromOctets(192, 168, 0, 1).Dedup()
+	pfx := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+
+	localPref := uint32(150)
+	c := filter.Chain{
+		filter.NewFilter("LOCAL_PREF", []*filter.Term{
+			filter.NewTerm("LOCAL_PREF", nil, []actions.Action{localPrefAction{localPref: &localPref}}),
+		}),
+	}
+
+	a := New(c, routingtable.NewContributingASNs(), routingtable.SessionAttrs{RouterID: 1})
+	rib := locRIB.New("inet.0")
+	a.Register(rib)
+
+	a.AddPath(pfx, internTestPath(source))
+	assert.Equal(t, uint32(150), rib.Get(pfx).Paths()[0].BGPPath.BGPPathA.LocalPref)
+
+	// The path advertised before has to be replaced, not the one resulting from the changed state
+	localPref = 200
+	a.ReapplyFilterChain()
+	if assert.Len(t, rib.Get(pfx).Paths(), 1) {
+		assert.Equal(t, uint32(200), rib.Get(pfx).Paths()[0].BGPPath.BGPPathA.LocalPref)
+	}
+
+	a.RemovePath(pfx, internTestPath(source))
+	assert.Equal(t, int64(0), rib.RouteCount())
+}
+
+func TestReplaceFilterChain(t *testing.T) {
+	source := net.IPv4FromOctets(192, 168, 0, 1).Dedup()
+	unchanged := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	nowRejected := net.NewPfx(net.IPv4FromOctets(11, 0, 0, 0), 8).Ptr()
+	nowAccepted := net.NewPfx(net.IPv4FromOctets(12, 0, 0, 0), 8).Ptr()
+	modified := net.NewPfx(net.IPv4FromOctets(13, 0, 0, 0), 8).Ptr()
+	alwaysRejected := net.NewPfx(net.IPv4FromOctets(14, 0, 0, 0), 8).Ptr()
+
+	match := func(pfxs ...*net.Prefix) []*filter.TermCondition {
+		return []*filter.TermCondition{
+			filter.NewTermConditionWithPrefixLists(filter.NewPrefixList(pfxs...)),
+		}
+	}
+
+	oldChain := filter.Chain{
+		filter.NewFilter("OLD", []*filter.Term{
+			filter.NewTerm("REJECT", match(nowAccepted, alwaysRejected), []actions.Action{actions.NewRejectAction()}),
+			filter.NewTerm("LOCAL_PREF", match(nowRejected), []actions.Action{actions.NewSetLocalPrefAction(150), actions.NewAcceptAction()}),
+			filter.NewTerm("ACCEPT", nil, []actions.Action{actions.NewAcceptAction()}),
+		}),
+	}
+
+	newChain := filter.Chain{
+		filter.NewFilter("NEW", []*filter.Term{
+			filter.NewTerm("REJECT", match(nowRejected, alwaysRejected), []actions.Action{actions.NewRejectAction()}),
+			filter.NewTerm("LOCAL_PREF", match(modified), []actions.Action{actions.NewSetLocalPrefAction(200), actions.NewAcceptAction()}),
+			filter.NewTerm("ACCEPT", nil, []actions.Action{actions.NewAcceptAction()}),
+		}),
+	}
+
+	a := New(oldChain, routingtable.NewContributingASNs(), routingtable.SessionAttrs{RouterID: 1})
+	rib := locRIB.New("inet.0")
+	a.Register(rib)
+
+	for _, pfx := range []*net.Prefix{unchanged, nowRejected, nowAccepted, modified, alwaysRejected} {
+		a.AddPath(pfx, internTestPath(source))
+	}
+	assert.Equal(t, int64(3), rib.RouteCount())
+
+	sub := rib.Subscribe(10)
+	a.ReplaceFilterChain(newChain)
+	rib.Unsubscribe(sub)
+
+	events := make(map[net.Prefix]locRIB.EventType)
+	for e := range sub.Events() {
+		events[*e.Route.Prefix()] = e.Type
+	}
+
+	assert.Equal(t, map[net.Prefix]locRIB.EventType{
+		*nowRejected: locRIB.EventRemove,
+		*nowAccepted: locRIB.EventAdd,
+		*modified:    locRIB.EventModify,
+	}, events, "only routes the decision changed for must be updated")
+
+	assert.Equal(t, int64(3), rib.RouteCount())
+	assert.Nil(t, rib.Get(nowRejected))
+	assert.Len(t, rib.Get(modified).Paths(), 1)
+	assert.Equal(t, uint32(200), rib.Get(modified).BestPath().BGPPath.BGPPathA.LocalPref)
+	assert.Equal(t, uint32(100), rib.Get(unchanged).BestPath().BGPPath.BGPPathA.LocalPref)
+}
+
+func TestImplicitWithdraw(t *testing.T) {
+	source := net.IPv4FromOctets(192, 168, 0, 1).Dedup()
+	pfx := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+
+	changed := func() *route.Path {
+		p := internTestPath(source)
+		p.BGPPath.BGPPathA.LocalPref = 200
+		return p
+	}
+
+	tests := []struct {
+		name     string
+		addPath  bool
+		readd    *route.Path
+		expected []locRIB.EventType
+	}{
+		{
+			name:     "Identical re-advertisement",
+			readd:    internTestPath(source),
+			expected: []locRIB.EventType{},
+		},
+		{
+			name:     "Identical re-advertisement with ADD-PATH",
+			addPath:  true,
+			readd:    internTestPath(source),
+			expected: []locRIB.EventType{},
+		},
+		{
+			name:     "Re-advertisement with changed attributes",
+			readd:    changed(),
+			expected: []locRIB.EventType{locRIB.EventRemove, locRIB.EventAdd},
+		},
+		{
+			name:     "Re-advertisement with changed attributes with ADD-PATH",
+			addPath:  true,
+			readd:    changed(),
+			expected: []locRIB.EventType{locRIB.EventRemove, locRIB.EventAdd},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			before := route.InternedBGPPathCount()
+
+			a := New(filter.NewAcceptAllFilterChain(), routingtable.NewContributingASNs(), routingtable.SessionAttrs{RouterID: 1, AddPathRX: test.addPath})
+			rib := locRIB.New("inet.0")
+			a.Register(rib)
+			a.AddPath(pfx, internTestPath(source))
+			prePolicyBytes := a.PrePolicyBytes()
+
+			sub := rib.Subscribe(10)
+			a.AddPath(pfx, test.readd)
+			rib.Unsubscribe(sub)
+
+			events := make([]locRIB.EventType, 0)
+			for e := range sub.Events() {
+				events = append(events, e.Type)
+			}
+
+			assert.Equal(t, test.expected, events)
+			assert.Equal(t, uint64(1), a.PrePolicyPathCount())
+			assert.Equal(t, prePolicyBytes, a.PrePolicyBytes())
+			assert.Len(t, rib.Get(pfx).Paths(), 1)
+			assert.Equal(t, test.readd.BGPPath.BGPPathA.LocalPref, rib.Get(pfx).BestPath().BGPPath.BGPPathA.LocalPref)
+
+			a.Flush()
+			assert.Equal(t, before, route.InternedBGPPathCount(), "attributes still interned after flush")
+		})
+	}
+}
+
+func TestPrePolicyAccounting(t *testing.T) {
+	source := net.IPv4FromOctets(192, 168, 0, 1).Dedup()
+	pfxA := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	pfxB := net.NewPfx(net.IPv4FromOctets(11, 0, 0, 0), 8).Ptr()
+
+	a := New(filter.NewDrainFilterChain(), routingtable.NewContributingASNs(), routingtable.SessionAttrs{RouterID: 1})
+	rib := locRIB.New("inet.0")
+	a.Register(rib)
+
+	assert.Equal(t, uint64(0), a.PrePolicyPathCount())
+	assert.Equal(t, uint64(0), a.PrePolicyBytes())
+
+	a.AddPath(pfxA, internTestPath(source))
+	a.AddPath(pfxB, internTestPath(source))
+	assert.Equal(t, int64(0), rib.RouteCount(), "all paths must be rejected")
+	assert.Equal(t, uint64(2), a.PrePolicyPathCount(), "rejected paths must be accounted for")
+
+	pathSize := internTestPath(source).EstimatedSize()
+	assert.NotZero(t, pathSize)
+	assert.Equal(t, 2*pathSize, a.PrePolicyBytes())
+
+	// Replacing a path must not change the accounting
+	a.AddPath(pfxA, internTestPath(source))
+	assert.Equal(t, uint64(2), a.PrePolicyPathCount())
+	assert.Equal(t, 2*pathSize, a.PrePolicyBytes())
+
+	a.RemovePath(pfxA, internTestPath(source))
+	assert.Equal(t, uint64(1), a.PrePolicyPathCount())
+	assert.Equal(t, pathSize, a.PrePolicyBytes())
+
+	a.Flush()
+	assert.Equal(t, uint64(0), a.PrePolicyPathCount())
+	assert.Equal(t, uint64(0), a.PrePolicyBytes())
+}
+
+func TestSnapshotWhileMutating(t *testing.T) {
+	source := net.IPv4FromOctets(192, 168, 0, 1).Dedup()
+	pfxs := []*net.Prefix{
+		net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(),
+		net.NewPfx(net.IPv4FromOctets(11, 0, 0, 0), 8).Ptr(),
+		net.NewPfx(net.IPv4FromOctets(12, 0, 0, 0), 8).Ptr(),
+	}
+
+	newPath := func(localPref uint32) *route.Path {
+		p := internTestPath(source)
+		p.BGPPath.BGPPathA.LocalPref = localPref
+		return p
+	}
+
+	a := New(filter.NewAcceptAllFilterChain(), routingtable.NewContributingASNs(), routingtable.SessionAttrs{RouterID: 1})
+	for _, pfx := range pfxs {
+		a.AddPath(pfx, newPath(0))
+	}
+
+	first := a.Snapshot()
+
+	done := make(chan struct{})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for i := uint32(1); ; i++ {
+			select {
+			case <-done:
+				return
+			default:
+			}
+
+			// Every replacement removes the old path before adding the new one
+			for _, pfx := range pfxs {
+				a.AddPath(pfx, newPath(i))
+			}
+		}
+	}()
+
+	for i := 0; i < 1000; i++ {
+		snapshot := a.Snapshot()
+		if !assert.Len(t, snapshot, len(pfxs)) {
+			break
+		}
+
+		for _, r := range snapshot {
+			assert.Len(t, r.Paths(), 1, "replacement of path of %s must not be visible", r.Prefix().String())
+		}
+	}
+
+	close(done)
+	wg.Wait()
+
+	for _, r := range first {
+		assert.Equal(t, uint32(0), r.BestPath().BGPPath.BGPPathA.LocalPref, "snapshot must not be changed by later updates")
+	}
+}
+
+func TestASLoopCount(t *testing.T) {
+	source := net.IPv4FromOctets(192, 168, 0, 1).Dedup()
+	pfxA := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	pfxB := net.NewPfx(net.IPv4FromOctets(11, 0, 0, 0), 8).Ptr()
+
+	contributingASNs := routingtable.NewContributingASNs()
+	contributingASNs.Add(65002)
+
+	a := New(filter.NewAcceptAllFilterChain(), contributingASNs, routingtable.SessionAttrs{
+		RouterID:   1,
+		PeerIP:     source,
+		LogASLoops: true,
+	})
+	rib := locRIB.New("inet.0")
+	a.Register(rib)
+
+	a.AddPath(pfxA, internTestPath(source))
+	a.AddPath(pfxB, internTestPath(source))
+	a.AddPath(pfxA, internTestPath(source))
+	assert.Equal(t, uint64(2), a.ASLoopCount(), "re-advertisements of a prefix are counted once")
+	assert.Equal(t, int64(0), rib.RouteCount(), "paths with an AS path loop must not be installed")
+	assert.Equal(t, uint8(route.HiddenReasonASLoop), a.Get(pfxA).Paths()[0].HiddenReason)
+
+	// Paths without loop are not counted
+	p := internTestPath(source)
+	p.BGPPath.ASPath = &types.ASPath{
+		{
+			Type: types.ASSequence,
+			ASNs: []uint32{65001},
+		},
+	}
+	a.AddPath(pfxA, p)
+	assert.Equal(t, uint64(1), a.ASLoopCount(), "prefix no longer looped")
+	assert.Equal(t, int64(1), rib.RouteCount())
+
+	a.RemovePath(pfxB, internTestPath(source))
+	assert.Equal(t, uint64(0), a.ASLoopCount(), "withdrawn prefixes are not counted")
+}
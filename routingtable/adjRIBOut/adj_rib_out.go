@@ -23,6 +23,9 @@ type AdjRIBOut struct {
 	pathIDManager            *pathIDManager
 	exportFilterChain        filter.Chain
 	exportFilterChainPending filter.Chain
+	defaultOriginated        *bnet.Prefix
+	defaultOriginateChain    filter.Chain
+	defaultConditionPaths    map[bnet.Prefix][]*route.Path
 	mu                       sync.RWMutex
 }
 
@@ -52,6 +55,14 @@ func (a *AdjRIBOut) Dump() []*route.Route {
 	return a.rt.Dump()
 }
 
+// Snapshot gets a consistent copy of all routes which is not changed by later updates of the RIB
+func (a *AdjRIBOut) Snapshot() []*route.Route {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.rt.Snapshot()
+}
+
 func (a *AdjRIBOut) EndOfRIB() {
 	for _, client := range a.clientManager.Clients() {
 		client.EndOfRIB()
@@ -76,6 +87,11 @@ func (a *AdjRIBOut) checkPropagateUpdate(pfx *bnet.Prefix, p *route.Path) (retPa
 		return nil, false
 	}
 
+	return a.preparePath(pfx, p)
+}
+
+// preparePath copies a path and modifies its attributes for being advertised to the peer
+func (a *AdjRIBOut) preparePath(pfx *bnet.Prefix, p *route.Path) (retPath *route.Path, propagate bool) {
 	p = p.Copy()
 
 	if a.sessionAttrs.IBGP {
@@ -85,6 +101,29 @@ func (a *AdjRIBOut) checkPropagateUpdate(pfx *bnet.Prefix, p *route.Path) (retPa
 	return a.checkPropagateUpdateEBGP(pfx, p)
 }
 
+// EvaluatePath runs a path through the attribute modifications and the export filter chain applied to paths advertised
+// to the peer without advertising it. The result is rejected if the path would not be advertised.
+func (a *AdjRIBOut) EvaluatePath(pfx *bnet.Prefix, p *route.Path) filter.ChainResult {
+	if a.isDefaultOriginated(pfx) || !routingtable.ShouldPropagateUpdate(pfx, p, &a.sessionAttrs) {
+		return filter.ChainResult{
+			Reject: true,
+		}
+	}
+
+	p, propagate := a.preparePath(pfx, p)
+	if !propagate {
+		return filter.ChainResult{
+			Reject: true,
+		}
+	}
+
+	a.mu.RLock()
+	c := a.exportFilterChain
+	a.mu.RUnlock()
+
+	return c.Evaluate(pfx, p)
+}
+
 func (a *AdjRIBOut) checkPropagateUpdateIBGP(pfx *bnet.Prefix, p *route.Path) (retPath *route.Path, propagate bool) {
 	// Don't export routes learned via iBGP to an iBGP neighbor which is NOT a route reflection client
 	if !p.BGPPath.BGPPathA.EBGP && a.sessionAttrs.IBGP && !a.sessionAttrs.RouteReflectorClient {
@@ -118,13 +157,21 @@ func (a *AdjRIBOut) checkPropagateUpdateIBGP(pfx *bnet.Prefix, p *route.Path) (r
 		p.BGPPath.ClusterList = &cList
 	}
 
+	if a.sessionAttrs.NextHopSelf && !a.sessionAttrs.RouteServerClient {
+		p.BGPPath.BGPPathA.NextHop = a.sessionAttrs.LocalIP
+	}
+
 	return p, true
 }
 
 func (a *AdjRIBOut) checkPropagateUpdateEBGP(pfx *bnet.Prefix, p *route.Path) (retPath *route.Path, propagate bool) {
-	// If the neighbor is an eBGP peer and not a Route Server client modify ASPath and Next Hop
+	// We are not part of a confederation, so all eBGP peers are outside of any confederation segment of the path
+	p.BGPPath.RemoveConfedSegments()
+
+	// If the neighbor is an eBGP peer and not a Route Server client modify ASPath and Next Hop.
+	// A Route Server is transparent: Neither our ASN is prepended nor is the Next Hop changed.
 	if !a.sessionAttrs.RouteServerClient {
-		p.BGPPath.Prepend(a.sessionAttrs.LocalASN, 1)
+		a.prependLocalASN(p)
 		p.BGPPath.BGPPathA.NextHop = a.sessionAttrs.LocalIP
 	}
 
@@ -149,12 +196,52 @@ func (a *AdjRIBOut) checkPropagateUpdateEBGP(pfx *bnet.Prefix, p *route.Path) (r
 	return p, true
 }
 
+// stripCommunities returns p without the community attributes not to be sent to the peer. As export policies may add
+// communities this happens after the export filter chain was applied. p itself is not modified.
+func (a *AdjRIBOut) stripCommunities(p *route.Path) *route.Path {
+	communities := a.sessionAttrs.StripCommunities && p.BGPPath.Communities != nil
+	largeCommunities := a.sessionAttrs.StripLargeCommunities && p.BGPPath.LargeCommunities != nil
+	if !communities && !largeCommunities {
+		return p
+	}
+
+	bgpPath := *p.BGPPath
+	if communities {
+		bgpPath.Communities = nil
+	}
+
+	if largeCommunities {
+		bgpPath.LargeCommunities = nil
+	}
+
+	ret := *p
+	ret.BGPPath = bgpPath.Dedup()
+	return &ret
+}
+
+// prependLocalASN prepends our ASN(s) to the AS path. If an alternate ASN is presented to the neighbor it is
+// prepended in front of the real local ASN, or replaces it entirely (replace-as).
+func (a *AdjRIBOut) prependLocalASN(p *route.Path) {
+	if a.sessionAttrs.LocalASOverride == 0 || !a.sessionAttrs.LocalASReplace {
+		p.BGPPath.Prepend(a.sessionAttrs.LocalASN, 1)
+	}
+
+	if a.sessionAttrs.LocalASOverride != 0 {
+		p.BGPPath.Prepend(a.sessionAttrs.LocalASOverride, 1)
+	}
+}
+
 func (a *AdjRIBOut) AddPathInitialDump(pfx *bnet.Prefix, p *route.Path) error {
 	return a.AddPath(pfx, p)
 }
 
 // AddPath adds path p to prefix `pfx`
 func (a *AdjRIBOut) AddPath(pfx *bnet.Prefix, p *route.Path) error {
+	a.updateDefaultCondition(pfx, p, true)
+	if a.isDefaultOriginated(pfx) {
+		return nil
+	}
+
 	p, propagate := a.checkPropagateUpdate(pfx, p)
 	if !propagate {
 		return nil
@@ -174,6 +261,8 @@ func (a *AdjRIBOut) AddPath(pfx *bnet.Prefix, p *route.Path) error {
 }
 
 func (a *AdjRIBOut) addPath(pfx *bnet.Prefix, p *route.Path) error {
+	p = a.stripCommunities(p)
+
 	if a.sessionAttrs.AddPathTX {
 		pathID, err := a.pathIDManager.addPath(p)
 		if err != nil {
@@ -201,6 +290,8 @@ func (a *AdjRIBOut) addPath(pfx *bnet.Prefix, p *route.Path) error {
 
 // RemovePath removes the path for prefix `pfx`
 func (a *AdjRIBOut) RemovePath(pfx *bnet.Prefix, p *route.Path) bool {
+	a.updateDefaultCondition(pfx, p, false)
+
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
@@ -208,6 +299,10 @@ func (a *AdjRIBOut) RemovePath(pfx *bnet.Prefix, p *route.Path) bool {
 }
 
 func (a *AdjRIBOut) removePath(pfx *bnet.Prefix, p *route.Path) bool {
+	if a._isDefaultOriginated(pfx) {
+		return false
+	}
+
 	if !routingtable.ShouldPropagateUpdate(pfx, p, &a.sessionAttrs) {
 		return false
 	}
@@ -329,6 +424,10 @@ func (a *AdjRIBOut) ReplacePath(pfx *bnet.Prefix, old *route.Path, new *route.Pa
 
 // RefreshRoute refreshes a route
 func (a *AdjRIBOut) RefreshRoute(pfx *bnet.Prefix, ribPaths []*route.Path) {
+	if a._isDefaultOriginated(pfx) {
+		return
+	}
+
 	for _, p := range ribPaths {
 		p, propagate := a.checkPropagateUpdate(pfx, p)
 		if !propagate {
@@ -1828,3 +1828,347 @@ func TestOTC(t *testing.T) {
 		assert.Equal(t, test.expected, adjRIBOut.rt.Dump())
 	}
 }
+
+func TestWellKnownCommunities(t *testing.T) {
+	localIP := net.IPv4FromOctets(127, 0, 0, 1).Ptr()
+	peerIP := net.IPv4FromOctets(127, 0, 0, 2).Ptr()
+	pfx := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+
+	iBGP := routingtable.SessionAttrs{
+		Type:     route.BGPPathType,
+		LocalIP:  localIP,
+		PeerIP:   peerIP,
+		IBGP:     true,
+		LocalASN: 41981,
+		PeerASN:  41981,
+	}
+
+	eBGP := routingtable.SessionAttrs{
+		Type:     route.BGPPathType,
+		LocalIP:  localIP,
+		PeerIP:   peerIP,
+		IBGP:     false,
+		LocalASN: 41981,
+		PeerASN:  65000,
+	}
+
+	tests := []struct {
+		name         string
+		sessionAttrs routingtable.SessionAttrs
+		community    uint32
+		expectedAdv  bool
+	}{
+		{
+			name:         "NO_EXPORT to iBGP peer",
+			sessionAttrs: iBGP,
+			community:    types.WellKnownCommunityNoExport,
+			expectedAdv:  true,
+		},
+		{
+			name:         "NO_EXPORT to eBGP peer",
+			sessionAttrs: eBGP,
+			community:    types.WellKnownCommunityNoExport,
+			expectedAdv:  false,
+		},
+		{
+			name:         "NO_ADVERTISE to iBGP peer",
+			sessionAttrs: iBGP,
+			community:    types.WellKnownCommunityNoAdvertise,
+			expectedAdv:  false,
+		},
+		{
+			name:         "NO_ADVERTISE to eBGP peer",
+			sessionAttrs: eBGP,
+			community:    types.WellKnownCommunityNoAdvertise,
+			expectedAdv:  false,
+		},
+		{
+			name:         "NO_EXPORT_SUBCONFED to iBGP peer",
+			sessionAttrs: iBGP,
+			community:    types.WellKnownCommunityNoExportSubConfed,
+			expectedAdv:  true,
+		},
+		{
+			name:         "NO_EXPORT_SUBCONFED to eBGP peer",
+			sessionAttrs: eBGP,
+			community:    types.WellKnownCommunityNoExportSubConfed,
+			expectedAdv:  false,
+		},
+		{
+			name:         "other community to eBGP peer",
+			sessionAttrs: eBGP,
+			community:    100,
+			expectedAdv:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adjRIBOut := New(nil, test.sessionAttrs, filter.NewAcceptAllFilterChain())
+
+			adjRIBOut.AddPath(pfx, &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{
+						Source:  net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+						NextHop: net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+						EBGP:    true,
+					},
+					ASPath: &types.ASPath{},
+					Communities: &types.Communities{
+						test.community,
+					},
+				},
+			})
+
+			assert.Equal(t, test.expectedAdv, adjRIBOut.Get(pfx) != nil)
+		})
+	}
+}
+
+func TestStripCommunities(t *testing.T) {
+	pfx := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+
+	tests := []struct {
+		name                     string
+		stripCommunities         bool
+		stripLargeCommunities    bool
+		expectedCommunities      bool
+		expectedLargeCommunities bool
+	}{
+		{
+			name:                     "send all",
+			expectedCommunities:      true,
+			expectedLargeCommunities: true,
+		},
+		{
+			name:                     "communities disabled",
+			stripCommunities:         true,
+			expectedLargeCommunities: true,
+		},
+		{
+			name:                  "large communities disabled",
+			stripLargeCommunities: true,
+			expectedCommunities:   true,
+		},
+		{
+			name:                  "all disabled",
+			stripCommunities:      true,
+			stripLargeCommunities: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adjRIBOut := New(nil, routingtable.SessionAttrs{
+				Type:                  route.BGPPathType,
+				LocalIP:               net.IPv4FromOctets(127, 0, 0, 1).Ptr(),
+				PeerIP:                net.IPv4FromOctets(127, 0, 0, 2).Ptr(),
+				IBGP:                  true,
+				LocalASN:              41981,
+				PeerASN:               41981,
+				StripCommunities:      test.stripCommunities,
+				StripLargeCommunities: test.stripLargeCommunities,
+			}, filter.NewAcceptAllFilterChain())
+
+			p := &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{
+						Source:  net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+						NextHop: net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+						EBGP:    true,
+					},
+					ASPath:           &types.ASPath{},
+					Communities:      &types.Communities{100},
+					LargeCommunities: &types.LargeCommunities{{GlobalAdministrator: 1, DataPart1: 2, DataPart2: 3}},
+				},
+			}
+			adjRIBOut.AddPath(pfx, p)
+
+			r := adjRIBOut.Get(pfx)
+			if !assert.NotNil(t, r) || !assert.Equal(t, 1, len(r.Paths())) {
+				return
+			}
+
+			advertised := r.Paths()[0].BGPPath
+			assert.Equal(t, test.expectedCommunities, advertised.Communities != nil)
+			assert.Equal(t, test.expectedLargeCommunities, advertised.LargeCommunities != nil)
+			assert.Equal(t, &types.Communities{100}, p.BGPPath.Communities, "original path must not be modified")
+		})
+	}
+}
+
+func TestLocalASOverride(t *testing.T) {
+	pfx := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+
+	tests := []struct {
+		name            string
+		localASOverride uint32
+		localASReplace  bool
+		expected        []uint32
+	}{
+		{
+			name:     "no override",
+			expected: []uint32{65000, 65100},
+		},
+		{
+			name:            "override",
+			localASOverride: 65001,
+			expected:        []uint32{65001, 65000, 65100},
+		},
+		{
+			name:            "override with replace-as",
+			localASOverride: 65001,
+			localASReplace:  true,
+			expected:        []uint32{65001, 65100},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adjRIBOut := New(nil, routingtable.SessionAttrs{
+				Type:            route.BGPPathType,
+				LocalIP:         net.IPv4FromOctets(127, 0, 0, 1).Ptr(),
+				PeerIP:          net.IPv4FromOctets(127, 0, 0, 2).Ptr(),
+				LocalASN:        65000,
+				PeerASN:         65200,
+				LocalASOverride: test.localASOverride,
+				LocalASReplace:  test.localASReplace,
+			}, filter.NewAcceptAllFilterChain())
+
+			adjRIBOut.AddPath(pfx, &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{
+						Source:  net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+						NextHop: net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+						EBGP:    true,
+					},
+					ASPath: &types.ASPath{
+						types.ASPathSegment{
+							Type: types.ASSequence,
+							ASNs: []uint32{65100},
+						},
+					},
+					ASPathLen: 1,
+				},
+			})
+
+			r := adjRIBOut.Get(pfx)
+			if r == nil {
+				t.Fatalf("route not advertised")
+			}
+
+			assert.Equal(t, test.expected, (*r.Paths()[0].BGPPath.ASPath)[0].ASNs)
+		})
+	}
+}
+
+func TestRouteServerClient(t *testing.T) {
+	pfx := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+
+	tests := []struct {
+		name         string
+		sessionAttrs routingtable.SessionAttrs
+	}{
+		{
+			name: "route server client",
+			sessionAttrs: routingtable.SessionAttrs{
+				Type:              route.BGPPathType,
+				LocalIP:           net.IPv4FromOctets(127, 0, 0, 1).Ptr(),
+				PeerIP:            net.IPv4FromOctets(127, 0, 0, 2).Ptr(),
+				LocalASN:          65000,
+				PeerASN:           65200,
+				RouteServerClient: true,
+			},
+		},
+		{
+			name: "route server client with local-as override",
+			sessionAttrs: routingtable.SessionAttrs{
+				Type:              route.BGPPathType,
+				LocalIP:           net.IPv4FromOctets(127, 0, 0, 1).Ptr(),
+				PeerIP:            net.IPv4FromOctets(127, 0, 0, 2).Ptr(),
+				LocalASN:          65000,
+				PeerASN:           65200,
+				LocalASOverride:   65001,
+				RouteServerClient: true,
+			},
+		},
+		{
+			name: "route server client with next-hop-self",
+			sessionAttrs: routingtable.SessionAttrs{
+				Type:              route.BGPPathType,
+				LocalIP:           net.IPv4FromOctets(127, 0, 0, 1).Ptr(),
+				PeerIP:            net.IPv4FromOctets(127, 0, 0, 2).Ptr(),
+				LocalASN:          65000,
+				PeerASN:           65200,
+				RouteServerClient: true,
+				NextHopSelf:       true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adjRIBOut := New(nil, test.sessionAttrs, filter.NewAcceptAllFilterChain())
+
+			p := &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{
+						Source:  net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+						NextHop: net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+						EBGP:    true,
+					},
+					ASPath: &types.ASPath{
+						types.ASPathSegment{
+							Type: types.ASSequence,
+							ASNs: []uint32{65100},
+						},
+					},
+					ASPathLen: 1,
+				},
+			}
+			adjRIBOut.AddPath(pfx, p)
+
+			assert.Equal(t, []*route.Route{
+				route.NewRoute(pfx, p),
+			}, adjRIBOut.rt.Dump())
+		})
+	}
+}
+
+func TestNextHopSelfIBGP(t *testing.T) {
+	localIP := net.IPv4FromOctets(127, 0, 0, 1).Ptr()
+	pfx := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+
+	adjRIBOut := New(nil, routingtable.SessionAttrs{
+		Type:        route.BGPPathType,
+		LocalIP:     localIP,
+		PeerIP:      net.IPv4FromOctets(127, 0, 0, 2).Ptr(),
+		IBGP:        true,
+		LocalASN:    65000,
+		PeerASN:     65000,
+		NextHopSelf: true,
+	}, filter.NewAcceptAllFilterChain())
+
+	adjRIBOut.AddPath(pfx, &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			BGPPathA: &route.BGPPathA{
+				Source:  net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+				NextHop: net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+				EBGP:    true,
+			},
+			ASPath: &types.ASPath{},
+		},
+	})
+
+	r := adjRIBOut.Get(pfx)
+	if r == nil {
+		t.Fatalf("route not advertised")
+	}
+
+	assert.Equal(t, localIP, r.Paths()[0].BGPPath.BGPPathA.NextHop)
+}
@@ -0,0 +1,137 @@
+package adjRIBOut
+
+import (
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+)
+
+const defaultOriginateLocalPref = 100
+
+// OriginateDefault injects a default route (pfx) into the Adj-RIB-Out regardless of the contents of the Loc-RIB.
+// Paths for pfx learned from the Loc-RIB are ignored from now on. If c is not empty it is a condition: The default
+// route is only advertised while c accepts at least one path of the Loc-RIB and is withdrawn once no path is accepted
+// anymore. An accepting chain may also modify the attributes of the default route. Returns true if the default route
+// is advertised.
+func (a *AdjRIBOut) OriginateDefault(pfx *bnet.Prefix, c filter.Chain) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.removeFromRT(pfx)
+	a.defaultOriginated = pfx
+	a.defaultOriginateChain = c
+	a.defaultConditionPaths = make(map[bnet.Prefix][]*route.Path)
+
+	return a.updateDefaultOrigination()
+}
+
+// updateDefaultCondition tracks if the Loc-RIB path p of pfx is accepted by the default origination condition and
+// advertises or withdraws the default route accordingly
+func (a *AdjRIBOut) updateDefaultCondition(pfx *bnet.Prefix, p *route.Path, add bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.defaultOriginated == nil || len(a.defaultOriginateChain) == 0 {
+		return
+	}
+
+	paths := a.defaultConditionPaths[*pfx]
+	for i := range paths {
+		if paths[i].Equal(p) {
+			paths = append(paths[:i], paths[i+1:]...)
+			break
+		}
+	}
+
+	if add {
+		if _, reject := a.defaultOriginateChain.Process(pfx, p); !reject {
+			paths = append(paths, p)
+		}
+	}
+
+	if len(paths) == 0 {
+		delete(a.defaultConditionPaths, *pfx)
+	} else {
+		a.defaultConditionPaths[*pfx] = paths
+	}
+
+	a.updateDefaultOrigination()
+}
+
+// updateDefaultOrigination advertises the default route if its condition is met and withdraws it otherwise
+func (a *AdjRIBOut) updateDefaultOrigination() bool {
+	pfx := a.defaultOriginated
+	c := a.defaultOriginateChain
+	if len(c) > 0 && len(a.defaultConditionPaths) == 0 {
+		a.removeFromRT(pfx)
+		return false
+	}
+
+	if a.rt.Get(pfx) != nil {
+		return true
+	}
+
+	p := a.defaultPath(pfx)
+	if len(c) > 0 {
+		// Terms matching specific Loc-RIB routes only may reject the default route itself, it keeps its attributes then
+		if modified, reject := c.Process(pfx, p); !reject {
+			p = modified
+		}
+	}
+
+	p.BGPPath = p.BGPPath.Dedup()
+	a.addPath(pfx, p)
+	return true
+}
+
+func (a *AdjRIBOut) defaultPath(pfx *bnet.Prefix) *route.Path {
+	p := &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			BGPPathA: route.NewBGPPathA(),
+			ASPath:   &types.ASPath{},
+		},
+	}
+
+	if a.sessionAttrs.LocalIP != nil {
+		p.BGPPath.BGPPathA.NextHop = a.sessionAttrs.LocalIP
+	}
+
+	if a.sessionAttrs.IBGP {
+		p.BGPPath.BGPPathA.LocalPref = defaultOriginateLocalPref
+		return p
+	}
+
+	p, _ = a.checkPropagateUpdateEBGP(pfx, p)
+	return p
+}
+
+// isDefaultOriginated checks if pfx is the prefix of an originated default route
+func (a *AdjRIBOut) isDefaultOriginated(pfx *bnet.Prefix) bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a._isDefaultOriginated(pfx)
+}
+
+func (a *AdjRIBOut) _isDefaultOriginated(pfx *bnet.Prefix) bool {
+	return a.defaultOriginated != nil && a.defaultOriginated.Equal(pfx)
+}
+
+// removeFromRT withdraws all paths for pfx that might have been learned from the Loc-RIB before
+func (a *AdjRIBOut) removeFromRT(pfx *bnet.Prefix) {
+	r := a.rt.Get(pfx)
+	if r == nil {
+		return
+	}
+
+	for _, p := range r.Paths() {
+		a.rt.RemovePath(pfx, p)
+		if a.sessionAttrs.AddPathTX {
+			a.pathIDManager.releasePath(p)
+		}
+
+		a.removePathFromClients(pfx, p)
+	}
+}
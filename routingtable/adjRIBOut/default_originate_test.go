@@ -0,0 +1,204 @@
+package adjRIBOut
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/bio-routing/bio-rd/routingtable/filter"
+	"github.com/bio-routing/bio-rd/routingtable/filter/actions"
+)
+
+func TestOriginateDefault(t *testing.T) {
+	localIP := net.IPv4FromOctets(127, 0, 0, 1).Ptr()
+	peerIP := net.IPv4FromOctets(127, 0, 0, 2).Ptr()
+	defaultPfx := net.NewPfx(net.IPv4(0), 0).Ptr()
+
+	tests := []struct {
+		name         string
+		sessionAttrs routingtable.SessionAttrs
+		filterChain  filter.Chain
+		routesAdd    []*route.Route
+		expected     []*route.Route
+		expectedAdv  bool
+	}{
+		{
+			name: "eBGP without route-map",
+			sessionAttrs: routingtable.SessionAttrs{
+				Type:     route.BGPPathType,
+				LocalIP:  localIP,
+				PeerIP:   peerIP,
+				LocalASN: 41981,
+				PeerASN:  65000,
+			},
+			expected: []*route.Route{
+				route.NewRoute(defaultPfx, &route.Path{
+					Type: route.BGPPathType,
+					BGPPath: &route.BGPPath{
+						BGPPathA: &route.BGPPathA{
+							NextHop: localIP,
+							Source:  net.IPv4(0).Ptr(),
+						},
+						ASPath: &types.ASPath{
+							types.ASPathSegment{
+								Type: types.ASSequence,
+								ASNs: []uint32{41981},
+							},
+						},
+						ASPathLen: 1,
+					},
+				}),
+			},
+			expectedAdv: true,
+		},
+		{
+			name: "iBGP with route-map setting MED, condition met by default in Loc-RIB",
+			sessionAttrs: routingtable.SessionAttrs{
+				Type:     route.BGPPathType,
+				LocalIP:  localIP,
+				PeerIP:   peerIP,
+				IBGP:     true,
+				LocalASN: 41981,
+				PeerASN:  41981,
+			},
+			filterChain: filter.Chain{
+				filter.NewFilter("DEFAULT", []*filter.Term{
+					filter.NewTerm("MED", []*filter.TermCondition{
+						filter.NewTermConditionWithRouteFilters(filter.NewRouteFilter(defaultPfx, filter.NewExactMatcher())),
+					}, []actions.Action{
+						actions.NewSetMEDAction(42),
+						actions.NewAcceptAction(),
+					}),
+				}),
+			},
+			routesAdd: []*route.Route{
+				route.NewRoute(defaultPfx, &route.Path{
+					Type: route.BGPPathType,
+					BGPPath: &route.BGPPath{
+						BGPPathA: &route.BGPPathA{
+							NextHop: net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+							Source:  net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+							EBGP:    true,
+						},
+						ASPath: &types.ASPath{},
+					},
+				}),
+			},
+			expected: []*route.Route{
+				route.NewRoute(defaultPfx, &route.Path{
+					Type: route.BGPPathType,
+					BGPPath: &route.BGPPath{
+						BGPPathA: &route.BGPPathA{
+							NextHop:    localIP,
+							Source:     net.IPv4(0).Ptr(),
+							LocalPref:  100,
+							MED:        42,
+							MEDPresent: true,
+						},
+						ASPath: &types.ASPath{},
+					},
+				}),
+			},
+			expectedAdv: false,
+		},
+		{
+			name: "route-map condition not matching",
+			sessionAttrs: routingtable.SessionAttrs{
+				Type:     route.BGPPathType,
+				LocalIP:  localIP,
+				PeerIP:   peerIP,
+				LocalASN: 41981,
+				PeerASN:  65000,
+			},
+			filterChain: filter.Chain{
+				filter.NewFilter("DEFAULT", []*filter.Term{
+					filter.NewTerm("COND", []*filter.TermCondition{
+						filter.NewTermConditionWithRouteFilters(filter.NewRouteFilter(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), filter.NewExactMatcher())),
+					}, []actions.Action{
+						actions.NewAcceptAction(),
+					}),
+					filter.NewTerm("ELSE", []*filter.TermCondition{}, []actions.Action{
+						actions.NewRejectAction(),
+					}),
+				}),
+			},
+			expected:    []*route.Route{},
+			expectedAdv: false,
+		},
+		{
+			name: "rejecting route-map",
+			sessionAttrs: routingtable.SessionAttrs{
+				Type:     route.BGPPathType,
+				LocalIP:  localIP,
+				PeerIP:   peerIP,
+				LocalASN: 41981,
+				PeerASN:  65000,
+			},
+			filterChain: filter.NewDrainFilterChain(),
+			expected:    []*route.Route{},
+			expectedAdv: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			adjRIBOut := New(nil, test.sessionAttrs, filter.NewAcceptAllFilterChain())
+			adv := adjRIBOut.OriginateDefault(defaultPfx, test.filterChain)
+			assert.Equal(t, test.expectedAdv, adv)
+
+			for _, r := range test.routesAdd {
+				adjRIBOut.AddPath(r.Prefix(), r.Paths()[0])
+			}
+
+			assert.Equal(t, test.expected, adjRIBOut.rt.Dump())
+		})
+	}
+}
+
+func TestOriginateDefaultCondition(t *testing.T) {
+	defaultPfx := net.NewPfx(net.IPv4(0), 0).Ptr()
+	condPfx := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	condPath := &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			BGPPathA: &route.BGPPathA{
+				NextHop: net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+				Source:  net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+			},
+			ASPath: &types.ASPath{},
+		},
+	}
+
+	adjRIBOut := New(nil, routingtable.SessionAttrs{
+		Type:     route.BGPPathType,
+		LocalIP:  net.IPv4FromOctets(127, 0, 0, 1).Ptr(),
+		PeerIP:   net.IPv4FromOctets(127, 0, 0, 2).Ptr(),
+		LocalASN: 41981,
+		PeerASN:  65000,
+	}, filter.NewAcceptAllFilterChain())
+
+	adv := adjRIBOut.OriginateDefault(defaultPfx, filter.Chain{
+		filter.NewFilter("DEFAULT", []*filter.Term{
+			filter.NewTerm("COND", []*filter.TermCondition{
+				filter.NewTermConditionWithRouteFilters(filter.NewRouteFilter(condPfx, filter.NewExactMatcher())),
+			}, []actions.Action{
+				actions.NewAcceptAction(),
+			}),
+			filter.NewTerm("ELSE", []*filter.TermCondition{}, []actions.Action{
+				actions.NewRejectAction(),
+			}),
+		}),
+	})
+	assert.False(t, adv)
+	assert.Nil(t, adjRIBOut.rt.Get(defaultPfx))
+
+	adjRIBOut.AddPath(condPfx, condPath)
+	assert.NotNil(t, adjRIBOut.rt.Get(defaultPfx), "default route must be advertised once the condition is met")
+
+	adjRIBOut.RemovePath(condPfx, condPath)
+	assert.Nil(t, adjRIBOut.rt.Get(defaultPfx), "default route must be withdrawn once the condition is not met anymore")
+}
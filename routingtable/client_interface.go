@@ -33,6 +33,18 @@ type AdjRIB interface {
 type AdjRIBIn interface {
 	AdjRIB
 	Flush()
+	// MarkStale marks all paths as stale, e.g. at the beginning of an enhanced route refresh (RFC7313)
+	MarkStale()
+	// RemoveStale removes all paths not received again since MarkStale and returns their number
+	RemoveStale() int
+	// ReapplyFilterChain runs all stored paths through the filter chain again (soft reconfiguration inbound)
+	ReapplyFilterChain()
+	// PrePolicyPathCount returns the number of paths stored before applying the filter chain
+	PrePolicyPathCount() uint64
+	// PrePolicyBytes returns an estimate of the memory used by the paths stored before applying the filter chain
+	PrePolicyBytes() uint64
+	// ASLoopCount returns the number of prefixes with a received path hidden as one of our ASNs is part of its AS path
+	ASLoopCount() uint64
 }
 
 // AdjRIBOut is the interface any AdjRIBOut must implement
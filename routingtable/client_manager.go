@@ -13,6 +13,12 @@ type ClientOptions struct {
 	BestOnly bool
 	EcmpOnly bool
 	MaxPaths uint
+
+	// FIB marks a client installing paths into a forwarding table. Errors returned by its AddPath are recorded as FIB failures.
+	FIB bool
+
+	// SuppressFIBFailures withholds paths of prefixes that failed to be installed into the FIB from the client
+	SuppressFIBFailures bool
 }
 
 // GetMaxPaths calculates the maximum amount of wanted paths given that ecmpPaths paths exist
@@ -29,6 +29,7 @@ func (a *AddCommunityAction) Do(p net.Prefix, pa *route.Path) Result {
 
 		*modified.BGPPath.Communities = append(*modified.BGPPath.Communities, com)
 	}
+	modified.BGPPath.Communities.Normalize()
 
 	return Result{Path: modified}
 }
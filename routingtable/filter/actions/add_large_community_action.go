@@ -27,5 +27,6 @@ func (a *AddLargeCommunityAction) Do(p net.Prefix, pa *route.Path) Result {
 	}
 
 	*modified.BGPPath.LargeCommunities = append(*modified.BGPPath.LargeCommunities, *a.communities...)
+	modified.BGPPath.LargeCommunities.Normalize()
 	return Result{Path: modified}
 }
@@ -43,7 +43,7 @@ func TestAddingLargeCommunities(t *testing.T) {
 					DataPart2:           3,
 				},
 			},
-			expected: "(5,6,7) (1,2,3)",
+			expected: "(1,2,3) (5,6,7)",
 		},
 		{
 			name: "add two to existing",
@@ -66,7 +66,7 @@ func TestAddingLargeCommunities(t *testing.T) {
 					DataPart2:           9,
 				},
 			},
-			expected: "(5,6,7) (1,2,3) (7,8,9)",
+			expected: "(1,2,3) (5,6,7) (7,8,9)",
 		},
 	}
 
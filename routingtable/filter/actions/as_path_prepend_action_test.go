@@ -64,6 +64,8 @@ func TestAppendPath(t *testing.T) {
 				return
 			}
 
+			assert.Equal(t, uint16(2), test.bgpPath.ASPathLen, "original path must not be modified")
+
 			assert.Equal(t, test.expectedPath, res.Path.BGPPath.ASPath.String(), "ASPath")
 			assert.Equal(t, test.expectedLength, res.Path.BGPPath.ASPathLen, "ASPathLen")
 		})
@@ -0,0 +1,36 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// DiscardAction turns a path into a discard (blackhole) path, e.g. for remote triggered blackholing (RFC7999)
+type DiscardAction struct{}
+
+// NewDiscardAction creates a new DiscardAction
+func NewDiscardAction() *DiscardAction {
+	return &DiscardAction{}
+}
+
+// Do applies the action
+func (a *DiscardAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.Discard {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	modified.Discard = true
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *DiscardAction) Equal(b Action) bool {
+	switch b.(type) {
+	case *DiscardAction:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,47 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+func TestDiscardAction(t *testing.T) {
+	tests := []struct {
+		name string
+		path *route.Path
+	}{
+		{
+			name: "BGP path",
+			path: &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{
+						NextHop: bnet.IPv4FromOctets(192, 168, 1, 1).Ptr(),
+					},
+				},
+			},
+		},
+		{
+			name: "Already discarded",
+			path: &route.Path{
+				Type:    route.StaticPathType,
+				Discard: true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			discard := test.path.Discard
+			res := NewDiscardAction().Do(bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 1), 32).Ptr(), test.path)
+
+			assert.True(t, res.Path.Discard)
+			assert.False(t, res.Terminate)
+			assert.Equal(t, discard, test.path.Discard, "input path modified")
+		})
+	}
+}
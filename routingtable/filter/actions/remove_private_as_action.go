@@ -0,0 +1,36 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// RemovePrivateASAction strips private ASNs (RFC6996) from the AS path except the left most one (the directly connected peer)
+type RemovePrivateASAction struct{}
+
+// NewRemovePrivateASAction creates a new RemovePrivateASAction
+func NewRemovePrivateASAction() *RemovePrivateASAction {
+	return &RemovePrivateASAction{}
+}
+
+// Do applies the action
+func (a *RemovePrivateASAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.BGPPath == nil || pa.BGPPath.ASPath == nil {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	modified.BGPPath.RemovePrivateASNs()
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *RemovePrivateASAction) Equal(b Action) bool {
+	switch b.(type) {
+	case *RemovePrivateASAction:
+		return true
+	default:
+		return false
+	}
+}
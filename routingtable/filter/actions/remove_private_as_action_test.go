@@ -0,0 +1,70 @@
+package actions
+
+import (
+	"testing"
+
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+)
+
+func TestRemovePrivateASAction(t *testing.T) {
+	tests := []struct {
+		name           string
+		asPath         *types.ASPath
+		expected       *types.ASPath
+		expectedLength uint16
+	}{
+		{
+			name: "Public path",
+			asPath: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{3320, 15169}},
+			},
+			expected: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{3320, 15169}},
+			},
+			expectedLength: 2,
+		},
+		{
+			name: "Mixed path with private neighbor",
+			asPath: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{65001, 3320, 65002, 4200000000, 15169}},
+			},
+			expected: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{65001, 3320, 15169}},
+			},
+			expectedLength: 3,
+		},
+		{
+			name: "Private only AS set",
+			asPath: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{3320}},
+				{Type: types.ASSet, ASNs: []uint32{65001, 65002}},
+			},
+			expected: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{3320}},
+			},
+			expectedLength: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: route.NewBGPPathA(),
+					ASPath:   test.asPath,
+				},
+			}
+
+			res := NewRemovePrivateASAction().Do(bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), p)
+
+			assert.Equal(t, test.expected, res.Path.BGPPath.ASPath)
+			assert.Equal(t, test.expectedLength, res.Path.BGPPath.ASPathLen)
+			assert.False(t, res.Terminate)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// SetISISMetricAction sets the metric of an ISIS prefix
+type SetISISMetricAction struct {
+	metric uint32
+}
+
+// NewSetISISMetricAction creates new SetISISMetricAction
+func NewSetISISMetricAction(metric uint32) *SetISISMetricAction {
+	return &SetISISMetricAction{
+		metric: metric,
+	}
+}
+
+// Do applies the action
+func (a *SetISISMetricAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.ISISPath == nil {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	modified.ISISPath.Metric = a.metric
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *SetISISMetricAction) Equal(b Action) bool {
+	switch b.(type) {
+	case *SetISISMetricAction:
+	default:
+		return false
+	}
+
+	return a.metric == b.(*SetISISMetricAction).metric
+}
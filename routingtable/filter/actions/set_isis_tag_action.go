@@ -0,0 +1,41 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// SetISISTagAction sets the administrative tag of an ISIS prefix
+type SetISISTagAction struct {
+	tag uint32
+}
+
+// NewSetISISTagAction creates new SetISISTagAction
+func NewSetISISTagAction(tag uint32) *SetISISTagAction {
+	return &SetISISTagAction{
+		tag: tag,
+	}
+}
+
+// Do applies the action
+func (a *SetISISTagAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.ISISPath == nil {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	modified.ISISPath.Tag = a.tag
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *SetISISTagAction) Equal(b Action) bool {
+	switch b.(type) {
+	case *SetISISTagAction:
+	default:
+		return false
+	}
+
+	return a.tag == b.(*SetISISTagAction).tag
+}
@@ -0,0 +1,46 @@
+package actions
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetISISTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		isisPath *route.ISISPath
+		expected uint32
+	}{
+		{
+			name: "ISISPath is nil",
+		},
+		{
+			name: "modify path",
+			isisPath: &route.ISISPath{
+				Tag: 10,
+			},
+			expected: 20,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewSetISISTagAction(20)
+			orig := &route.Path{
+				ISISPath: test.isisPath,
+			}
+			res := a.Do(bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), orig)
+
+			if test.isisPath == nil {
+				assert.Nil(t, res.Path.ISISPath)
+				return
+			}
+
+			assert.Equal(t, test.expected, res.Path.ISISPath.Tag)
+			assert.Equal(t, uint32(10), orig.ISISPath.Tag, "original path must not be modified")
+		})
+	}
+}
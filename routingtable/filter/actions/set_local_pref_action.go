@@ -5,16 +5,19 @@ import (
 	"github.com/bio-routing/bio-rd/route"
 )
 
+// SetLocalPrefAction sets the BGP LOCAL_PREF
 type SetLocalPrefAction struct {
 	pref uint32
 }
 
+// NewSetLocalPrefAction creates a new SetLocalPrefAction
 func NewSetLocalPrefAction(pref uint32) *SetLocalPrefAction {
 	return &SetLocalPrefAction{
 		pref: pref,
 	}
 }
 
+// Do applies the action
 func (a *SetLocalPrefAction) Do(p *net.Prefix, pa *route.Path) Result {
 	if pa.BGPPath == nil {
 		return Result{Path: pa}
@@ -33,5 +36,5 @@ func (a *SetLocalPrefAction) Equal(b Action) bool {
 		return false
 	}
 
-	return true
+	return a.pref == b.(*SetLocalPrefAction).pref
 }
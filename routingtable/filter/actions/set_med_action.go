@@ -25,6 +25,7 @@ func (a *SetMEDAction) Do(p *net.Prefix, pa *route.Path) Result {
 
 	modified := pa.Copy()
 	modified.BGPPath.BGPPathA.MED = a.med
+	modified.BGPPath.BGPPathA.MEDPresent = true
 
 	return Result{Path: modified}
 }
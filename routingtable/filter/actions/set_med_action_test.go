@@ -0,0 +1,49 @@
+package actions
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMED(t *testing.T) {
+	tests := []struct {
+		name        string
+		bgpPath     *route.BGPPath
+		expectedMED uint32
+	}{
+		{
+			name: "BGPPath is nil",
+		},
+		{
+			name: "modify path",
+			bgpPath: &route.BGPPath{
+				BGPPathA: &route.BGPPathA{
+					MED: 10,
+				},
+			},
+			expectedMED: 20,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewSetMEDAction(20)
+			orig := &route.Path{
+				BGPPath: test.bgpPath,
+			}
+			res := a.Do(bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), orig)
+
+			if test.bgpPath == nil {
+				assert.Nil(t, res.Path.BGPPath)
+				return
+			}
+
+			assert.Equal(t, test.expectedMED, res.Path.BGPPath.BGPPathA.MED)
+			assert.True(t, res.Path.BGPPath.BGPPathA.MEDPresent)
+			assert.Equal(t, uint32(10), orig.BGPPath.BGPPathA.MED, "original path must not be modified")
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// SetOriginAction sets the BGP ORIGIN (0 = IGP, 1 = EGP, 2 = incomplete)
+type SetOriginAction struct {
+	origin uint8
+}
+
+// NewSetOriginAction creates a new SetOriginAction
+func NewSetOriginAction(origin uint8) *SetOriginAction {
+	return &SetOriginAction{
+		origin: origin,
+	}
+}
+
+// Do applies the action
+func (a *SetOriginAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.BGPPath == nil {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	modified.BGPPath.BGPPathA.Origin = a.origin
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *SetOriginAction) Equal(b Action) bool {
+	switch b.(type) {
+	case *SetOriginAction:
+	default:
+		return false
+	}
+
+	return a.origin == b.(*SetOriginAction).origin
+}
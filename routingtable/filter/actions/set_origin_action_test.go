@@ -0,0 +1,48 @@
+package actions
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetOrigin(t *testing.T) {
+	tests := []struct {
+		name           string
+		bgpPath        *route.BGPPath
+		expectedOrigin uint8
+	}{
+		{
+			name: "BGPPath is nil",
+		},
+		{
+			name: "modify path",
+			bgpPath: &route.BGPPath{
+				BGPPathA: &route.BGPPathA{
+					Origin: 0,
+				},
+			},
+			expectedOrigin: 2,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewSetOriginAction(2)
+			orig := &route.Path{
+				BGPPath: test.bgpPath,
+			}
+			res := a.Do(bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), orig)
+
+			if test.bgpPath == nil {
+				assert.Nil(t, res.Path.BGPPath)
+				return
+			}
+
+			assert.Equal(t, test.expectedOrigin, res.Path.BGPPath.BGPPathA.Origin)
+			assert.Equal(t, uint8(0), orig.BGPPath.BGPPathA.Origin, "original path must not be modified")
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package actions
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// SetWeightAction sets the local only weight of a BGP path
+type SetWeightAction struct {
+	weight uint32
+}
+
+// NewSetWeightAction creates a new SetWeightAction
+func NewSetWeightAction(weight uint32) *SetWeightAction {
+	return &SetWeightAction{
+		weight: weight,
+	}
+}
+
+// Do applies the action
+func (a *SetWeightAction) Do(p *net.Prefix, pa *route.Path) Result {
+	if pa.BGPPath == nil {
+		return Result{Path: pa}
+	}
+
+	modified := pa.Copy()
+	modified.BGPPath.Weight = a.weight
+
+	return Result{Path: modified}
+}
+
+// Equal compares actions
+func (a *SetWeightAction) Equal(b Action) bool {
+	switch b.(type) {
+	case *SetWeightAction:
+	default:
+		return false
+	}
+
+	return a.weight == b.(*SetWeightAction).weight
+}
@@ -0,0 +1,47 @@
+package actions
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetWeight(t *testing.T) {
+	tests := []struct {
+		name           string
+		bgpPath        *route.BGPPath
+		expectedWeight uint32
+	}{
+		{
+			name: "BGPPath is nil",
+		},
+		{
+			name: "modify path",
+			bgpPath: &route.BGPPath{
+				BGPPathA: &route.BGPPathA{},
+				Weight:   0,
+			},
+			expectedWeight: 500,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewSetWeightAction(500)
+			orig := &route.Path{
+				BGPPath: test.bgpPath,
+			}
+			res := a.Do(bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), orig)
+
+			if test.bgpPath == nil {
+				assert.Nil(t, res.Path.BGPPath)
+				return
+			}
+
+			assert.Equal(t, test.expectedWeight, res.Path.BGPPath.Weight)
+			assert.Equal(t, uint32(0), orig.BGPPath.Weight, "original path must not be modified")
+		})
+	}
+}
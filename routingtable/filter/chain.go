@@ -1,25 +1,64 @@
 package filter
 
 import (
+	"fmt"
+
 	"github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/route"
 )
 
 type Chain []*Filter
 
+// ChainResult is the outcome of processing a path by a filter chain
+type ChainResult struct {
+	Path   *route.Path
+	Reject bool
+
+	// Filter and Term name the term rejecting the path
+	Filter string
+	Term   string
+}
+
+// RejectReason returns a human readable description of the term rejecting the path (if any)
+func (r ChainResult) RejectReason() string {
+	if !r.Reject || r.Term == "" {
+		return ""
+	}
+
+	return fmt.Sprintf("rejected by term %q of filter %q", r.Term, r.Filter)
+}
+
 // Process processes a filter chain
 func (c Chain) Process(p *net.Prefix, pa *route.Path) (modPath *route.Path, reject bool) {
+	res := c.Evaluate(p, pa)
+	return res.Path, res.Reject
+}
+
+// Evaluate processes a filter chain and records the term rejecting the path
+func (c Chain) Evaluate(p *net.Prefix, pa *route.Path) ChainResult {
 	mp := pa.Copy()
 	for _, f := range c {
 		res := f.Process(p, mp)
 		if res.Terminate {
-			return res.Path, res.Reject
+			ret := ChainResult{
+				Path:   res.Path,
+				Reject: res.Reject,
+			}
+
+			if res.Reject {
+				ret.Filter = f.Name()
+				ret.Term = res.Term
+			}
+
+			return ret
 		}
 
 		mp = res.Path
 	}
 
-	return mp, false
+	return ChainResult{
+		Path: mp,
+	}
 }
 
 // Equal compares twp filter chains
@@ -6,7 +6,18 @@ type CommunityFilter struct {
 	community uint32
 }
 
+// NewCommunityFilter creates a filter matching paths carrying community
+func NewCommunityFilter(community uint32) *CommunityFilter {
+	return &CommunityFilter{
+		community: community,
+	}
+}
+
 func (f *CommunityFilter) Matches(coms *types.Communities) bool {
+	if coms == nil {
+		return false
+	}
+
 	for _, com := range *coms {
 		if com == f.community {
 			return true
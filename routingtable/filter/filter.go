@@ -1,6 +1,8 @@
 package filter
 
 import (
+	"sort"
+
 	"github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/route"
 )
@@ -9,6 +11,9 @@ type FilterResult struct {
 	Path      *route.Path
 	Terminate bool
 	Reject    bool
+
+	// Term is the name of the term terminating processing of the filter by a matching action
+	Term string
 }
 
 type Filter struct {
@@ -16,10 +21,17 @@ type Filter struct {
 	terms []*Term
 }
 
+// NewFilter creates a new filter. Terms are ordered by their sequence numbers, terms with equal sequence numbers keep their order.
 func NewFilter(name string, terms []*Term) *Filter {
+	sorted := make([]*Term, len(terms))
+	copy(sorted, terms)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].sequence < sorted[j].sequence
+	})
+
 	f := &Filter{
 		name:  name,
-		terms: terms,
+		terms: sorted,
 	}
 
 	return f
@@ -32,22 +44,60 @@ func (f *Filter) Name() string {
 
 // Process processes a filter
 func (f *Filter) Process(p *net.Prefix, pa *route.Path) FilterResult {
-	for _, t := range f.terms {
+	permitted := false
+	for i := 0; i < len(f.terms); i++ {
+		t := f.terms[i]
 		res := t.Process(p, pa)
-		if res.Terminate {
+		pa = res.Path
+
+		if !res.Matched || t.flow.Mode == FlowDefault {
+			if res.Terminate {
+				return FilterResult{
+					Path:      pa,
+					Terminate: res.Terminate,
+					Reject:    res.Reject,
+					Term:      t.name,
+				}
+			}
+
+			continue
+		}
+
+		if res.Reject {
 			return FilterResult{
 				Path:      pa,
-				Terminate: res.Terminate,
-				Reject:    res.Reject,
+				Terminate: true,
+				Reject:    true,
+				Term:      t.name,
 			}
 		}
+
+		if res.Terminate {
+			permitted = true
+		}
+
+		if t.flow.Mode == FlowGoto {
+			i = f.gotoIndex(i, t.flow.Goto) - 1
+		}
 	}
 
 	return FilterResult{
-		Path: pa,
+		Path:      pa,
+		Terminate: permitted,
 	}
 }
 
+// gotoIndex finds the index of the first term after term[i] with a sequence number of at least seq
+func (f *Filter) gotoIndex(i int, seq uint32) int {
+	for j := i + 1; j < len(f.terms); j++ {
+		if f.terms[j].sequence >= seq {
+			return j
+		}
+	}
+
+	return len(f.terms)
+}
+
 func (f *Filter) equal(x *Filter) bool {
 	if len(f.terms) != len(x.terms) {
 		return false
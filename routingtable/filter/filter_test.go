@@ -85,3 +85,252 @@ func TestProcessTerms(t *testing.T) {
 		})
 	}
 }
+
+func TestProcessFlowControl(t *testing.T) {
+	pfx := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	matchPfx := []*TermCondition{
+		NewTermConditionWithPrefixLists(NewPrefixList(pfx)),
+	}
+	noMatchPfx := []*TermCondition{
+		NewTermConditionWithPrefixLists(NewPrefixList(net.NewPfx(net.IPv4FromOctets(192, 168, 0, 0), 16).Ptr())),
+	}
+
+	tests := []struct {
+		name              string
+		terms             []*Term
+		expectTerminate   bool
+		expectReject      bool
+		expectedMED       uint32
+		expectedLocalPref uint32
+	}{
+		{
+			name: "first match terminates",
+			terms: []*Term{
+				NewSequencedTerm("10", 10, matchPfx, []actions.Action{
+					actions.NewSetMEDAction(10),
+					actions.NewAcceptAction(),
+				}, FlowControl{}),
+				NewSequencedTerm("20", 20, matchPfx, []actions.Action{
+					actions.NewSetMEDAction(20),
+					actions.NewAcceptAction(),
+				}, FlowControl{}),
+			},
+			expectTerminate: true,
+			expectedMED:     10,
+		},
+		{
+			name: "terms are processed in sequence order",
+			terms: []*Term{
+				NewSequencedTerm("30", 30, matchPfx, []actions.Action{
+					actions.NewRejectAction(),
+				}, FlowControl{}),
+				NewSequencedTerm("10", 10, matchPfx, []actions.Action{
+					actions.NewSetMEDAction(10),
+					actions.NewAcceptAction(),
+				}, FlowControl{}),
+			},
+			expectTerminate: true,
+			expectedMED:     10,
+		},
+		{
+			name: "permit with continue applies following terms",
+			terms: []*Term{
+				NewSequencedTerm("10", 10, matchPfx, []actions.Action{
+					actions.NewSetMEDAction(10),
+					actions.NewAcceptAction(),
+				}, FlowControl{Mode: FlowContinue}),
+				NewSequencedTerm("20", 20, matchPfx, []actions.Action{
+					actions.NewSetLocalPrefAction(200),
+					actions.NewAcceptAction(),
+				}, FlowControl{}),
+			},
+			expectTerminate:   true,
+			expectedMED:       10,
+			expectedLocalPref: 200,
+		},
+		{
+			name: "permit with continue and no further match stays permitted",
+			terms: []*Term{
+				NewSequencedTerm("10", 10, matchPfx, []actions.Action{
+					actions.NewSetMEDAction(10),
+					actions.NewAcceptAction(),
+				}, FlowControl{Mode: FlowContinue}),
+				NewSequencedTerm("20", 20, noMatchPfx, []actions.Action{
+					actions.NewRejectAction(),
+				}, FlowControl{}),
+			},
+			expectTerminate: true,
+			expectedMED:     10,
+		},
+		{
+			name: "goto skips terms",
+			terms: []*Term{
+				NewSequencedTerm("10", 10, matchPfx, []actions.Action{
+					actions.NewSetMEDAction(10),
+					actions.NewAcceptAction(),
+				}, FlowControl{Mode: FlowGoto, Goto: 30}),
+				NewSequencedTerm("20", 20, matchPfx, []actions.Action{
+					actions.NewRejectAction(),
+				}, FlowControl{}),
+				NewSequencedTerm("30", 30, matchPfx, []actions.Action{
+					actions.NewSetLocalPrefAction(300),
+				}, FlowControl{}),
+			},
+			expectTerminate:   true,
+			expectedMED:       10,
+			expectedLocalPref: 300,
+		},
+		{
+			name: "goto with a sequence number beyond the last term ends the filter",
+			terms: []*Term{
+				NewSequencedTerm("10", 10, matchPfx, []actions.Action{
+					actions.NewAcceptAction(),
+				}, FlowControl{Mode: FlowGoto, Goto: 100}),
+				NewSequencedTerm("20", 20, matchPfx, []actions.Action{
+					actions.NewRejectAction(),
+				}, FlowControl{}),
+			},
+			expectTerminate: true,
+		},
+		{
+			name: "deny with continue terminates",
+			terms: []*Term{
+				NewSequencedTerm("10", 10, matchPfx, []actions.Action{
+					actions.NewRejectAction(),
+				}, FlowControl{Mode: FlowContinue}),
+				NewSequencedTerm("20", 20, matchPfx, []actions.Action{
+					actions.NewAcceptAction(),
+				}, FlowControl{}),
+			},
+			expectTerminate: true,
+			expectReject:    true,
+		},
+		{
+			name: "continue without permit falls through to next filter",
+			terms: []*Term{
+				NewSequencedTerm("10", 10, matchPfx, []actions.Action{
+					actions.NewSetMEDAction(10),
+				}, FlowControl{Mode: FlowContinue}),
+			},
+			expectTerminate: false,
+			expectedMED:     10,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := NewFilter("ROUTE_MAP", test.terms)
+			res := f.Process(pfx, &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{},
+				},
+			})
+
+			assert.Equal(t, test.expectTerminate, res.Terminate)
+			assert.Equal(t, test.expectReject, res.Reject)
+			assert.Equal(t, test.expectedMED, res.Path.BGPPath.BGPPathA.MED)
+			assert.Equal(t, test.expectedLocalPref, res.Path.BGPPath.BGPPathA.LocalPref)
+		})
+	}
+}
+
+func TestChainEvaluate(t *testing.T) {
+	pfx := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	matchPfx := []*TermCondition{
+		NewTermConditionWithPrefixLists(NewPrefixList(pfx)),
+	}
+	noMatchPfx := []*TermCondition{
+		NewTermConditionWithPrefixLists(NewPrefixList(net.NewPfx(net.IPv4FromOctets(192, 168, 0, 0), 16).Ptr())),
+	}
+
+	tests := []struct {
+		name           string
+		chain          Chain
+		expectReject   bool
+		expectedFilter string
+		expectedTerm   string
+		expectedReason string
+	}{
+		{
+			name: "accepted",
+			chain: Chain{
+				NewFilter("import", []*Term{
+					NewTerm("accept", matchPfx, []actions.Action{
+						actions.NewAcceptAction(),
+					}),
+				}),
+			},
+		},
+		{
+			name: "rejected by the matching term",
+			chain: Chain{
+				NewFilter("import", []*Term{
+					NewTerm("martians", noMatchPfx, []actions.Action{
+						actions.NewRejectAction(),
+					}),
+					NewTerm("too-specific", matchPfx, []actions.Action{
+						actions.NewRejectAction(),
+					}),
+					NewTerm("accept", nil, []actions.Action{
+						actions.NewAcceptAction(),
+					}),
+				}),
+			},
+			expectReject:   true,
+			expectedFilter: "import",
+			expectedTerm:   "too-specific",
+			expectedReason: `rejected by term "too-specific" of filter "import"`,
+		},
+		{
+			name: "rejected by a later filter",
+			chain: Chain{
+				NewFilter("communities", []*Term{
+					NewTerm("tag", nil, []actions.Action{
+						actions.NewSetLocalPrefAction(200),
+					}),
+				}),
+				NewFilter("import", []*Term{
+					NewTerm("reject-all", nil, []actions.Action{
+						actions.NewRejectAction(),
+					}),
+				}),
+			},
+			expectReject:   true,
+			expectedFilter: "import",
+			expectedTerm:   "reject-all",
+			expectedReason: `rejected by term "reject-all" of filter "import"`,
+		},
+		{
+			name: "rejected after continue",
+			chain: Chain{
+				NewFilter("import", []*Term{
+					NewSequencedTerm("10", 10, matchPfx, []actions.Action{
+						actions.NewAcceptAction(),
+					}, FlowControl{Mode: FlowContinue}),
+					NewSequencedTerm("20", 20, matchPfx, []actions.Action{
+						actions.NewRejectAction(),
+					}, FlowControl{}),
+				}),
+			},
+			expectReject:   true,
+			expectedFilter: "import",
+			expectedTerm:   "20",
+			expectedReason: `rejected by term "20" of filter "import"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := test.chain.Evaluate(pfx, &route.Path{
+				Type:    route.BGPPathType,
+				BGPPath: &route.BGPPath{BGPPathA: &route.BGPPathA{}},
+			})
+
+			assert.Equal(t, test.expectReject, res.Reject)
+			assert.Equal(t, test.expectedFilter, res.Filter)
+			assert.Equal(t, test.expectedTerm, res.Term)
+			assert.Equal(t, test.expectedReason, res.RejectReason())
+		})
+	}
+}
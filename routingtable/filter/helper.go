@@ -25,6 +25,58 @@ func NewAcceptAllFilterChain() Chain {
 	}
 }
 
+// NewBlackholeTerm returns a term turning paths carrying any of the given communities into discard paths,
+// e.g. for remote triggered blackholing using the BLACKHOLE community (RFC7999). Processing continues with the next term.
+func NewBlackholeTerm(communities ...uint32) *Term {
+	filters := make([]*CommunityFilter, 0, len(communities))
+	for _, c := range communities {
+		filters = append(filters, NewCommunityFilter(c))
+	}
+
+	return NewTerm(
+		"BLACKHOLE",
+		[]*TermCondition{
+			NewTermConditionWithCommunityFilters(filters...),
+		},
+		[]actions.Action{
+			actions.NewDiscardAction(),
+		})
+}
+
+// NewRejectPrivateASFilter returns a filter rejecting paths with private ASNs in their AS path except the left most one
+// (the directly connected peer). Other paths are passed on to the next filter of the chain.
+func NewRejectPrivateASFilter() *Filter {
+	return NewFilter(
+		"REJECT_PRIVATE_AS",
+		[]*Term{
+			NewTerm(
+				"REJECT_PRIVATE_AS",
+				[]*TermCondition{
+					NewTermConditionWithPrivateASFilters(NewPrivateASFilter()),
+				},
+				[]actions.Action{
+					&actions.RejectAction{},
+				}),
+		})
+}
+
+// NewRemovePrivateASFilter returns a filter stripping private ASNs except the left most one (the directly connected peer)
+// from AS paths. All paths are passed on to the next filter of the chain.
+func NewRemovePrivateASFilter() *Filter {
+	return NewFilter(
+		"REMOVE_PRIVATE_AS",
+		[]*Term{
+			NewTerm(
+				"REMOVE_PRIVATE_AS",
+				[]*TermCondition{
+					NewTermConditionWithPrivateASFilters(NewPrivateASFilter()),
+				},
+				[]actions.Action{
+					actions.NewRemovePrivateASAction(),
+				}),
+		})
+}
+
 // NewDrainFilter returns a filter rejecting any paths/prefixes
 func NewDrainFilter() *Filter {
 	return NewFilter(
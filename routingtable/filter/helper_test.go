@@ -4,7 +4,9 @@ import (
 	"testing"
 
 	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/protocols/bgp/types"
 	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable/filter/actions"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,3 +23,146 @@ func TestNewDrainFilter(t *testing.T) {
 	res := f.Process(net.NewPfx(net.IPv4(0), 0).Ptr(), &route.Path{})
 	assert.Equal(t, true, res.Reject)
 }
+
+func TestNewBlackholeTerm(t *testing.T) {
+	tests := []struct {
+		name            string
+		communities     *types.Communities
+		expectedDiscard bool
+	}{
+		{
+			name:            "BLACKHOLE community",
+			communities:     &types.Communities{100, types.WellKnownCommunityBlackhole},
+			expectedDiscard: true,
+		},
+		{
+			name:        "Other communities",
+			communities: &types.Communities{100, types.WellKnownCommunityNoExport},
+		},
+		{
+			name: "No communities",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := NewFilter("RTBH", []*Term{
+				NewBlackholeTerm(types.WellKnownCommunityBlackhole),
+				NewTerm("ACCEPT", []*TermCondition{}, []actions.Action{
+					actions.NewAcceptAction(),
+				}),
+			})
+
+			pa := &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA: &route.BGPPathA{
+						NextHop: net.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+					},
+					Communities: test.communities,
+				},
+			}
+
+			res := f.Process(net.NewPfx(net.IPv4FromOctets(192, 0, 2, 1), 32).Ptr(), pa)
+			assert.False(t, res.Reject)
+			assert.Equal(t, test.expectedDiscard, res.Path.Discard)
+			assert.Equal(t, net.IPv4FromOctets(10, 0, 0, 1).Ptr(), res.Path.NextHop())
+
+			if !test.expectedDiscard {
+				assert.Equal(t, pa, res.Path, "path without BLACKHOLE community modified")
+			}
+		})
+	}
+}
+
+func TestPrivateASFilters(t *testing.T) {
+	tests := []struct {
+		name           string
+		asPath         *types.ASPath
+		expectedReject bool
+		expectedStrip  *types.ASPath
+	}{
+		{
+			name: "Public path",
+			asPath: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{3320, 15169}},
+			},
+			expectedStrip: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{3320, 15169}},
+			},
+		},
+		{
+			name: "Private neighbor",
+			asPath: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{65001, 3320, 15169}},
+			},
+			expectedStrip: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{65001, 3320, 15169}},
+			},
+		},
+		{
+			name: "Prepended private neighbor",
+			asPath: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{65001, 65001, 65001, 3320, 15169}},
+			},
+			expectedStrip: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{65001, 65001, 65001, 3320, 15169}},
+			},
+		},
+		{
+			name: "Prepended private neighbor and private ASN in path",
+			asPath: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{65001, 65001, 3320, 64512, 15169}},
+			},
+			expectedReject: true,
+			expectedStrip: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{65001, 65001, 3320, 15169}},
+			},
+		},
+		{
+			name: "Private ASN in path",
+			asPath: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{3320, 64512, 15169}},
+			},
+			expectedReject: true,
+			expectedStrip: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{3320, 15169}},
+			},
+		},
+		{
+			name: "Private 4-byte origin behind private neighbor",
+			asPath: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{65001, 3320, 4200000001}},
+			},
+			expectedReject: true,
+			expectedStrip: &types.ASPath{
+				{Type: types.ASSequence, ASNs: []uint32{65001, 3320}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pa := &route.Path{
+				Type: route.BGPPathType,
+				BGPPath: &route.BGPPath{
+					BGPPathA:  route.NewBGPPathA(),
+					ASPath:    test.asPath,
+					ASPathLen: test.asPath.Length(),
+				},
+			}
+			pfx := net.NewPfx(net.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()
+
+			res, reject := Chain{NewRejectPrivateASFilter(), NewAcceptAllFilter()}.Process(pfx, pa)
+			assert.Equal(t, test.expectedReject, reject)
+			if !reject {
+				assert.Equal(t, test.asPath, res.BGPPath.ASPath)
+			}
+
+			res, reject = Chain{NewRemovePrivateASFilter(), NewAcceptAllFilter()}.Process(pfx, pa)
+			assert.False(t, reject)
+			assert.Equal(t, test.expectedStrip, res.BGPPath.ASPath)
+			assert.Equal(t, test.expectedStrip.Length(), res.BGPPath.ASPathLen)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package filter
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+)
+
+// NextHopFilter matches the next hop of a path against a prefix list
+type NextHopFilter struct {
+	prefixList *PrefixList
+}
+
+// NewNextHopFilter creates a new NextHopFilter. The next hop is matched as host prefix (/32 or /128) against l.
+func NewNextHopFilter(l *PrefixList) *NextHopFilter {
+	return &NextHopFilter{
+		prefixList: l,
+	}
+}
+
+// Matches checks if next hop nh is matched by the prefix list
+func (f *NextHopFilter) Matches(nh *net.IP) bool {
+	if nh == nil {
+		return false
+	}
+
+	pfxLen := uint8(128)
+	if nh.IsIPv4() {
+		pfxLen = 32
+	}
+
+	return f.prefixList.Matches(net.NewPfx(*nh, pfxLen).Ptr())
+}
+
+func (f *NextHopFilter) equal(x *NextHopFilter) bool {
+	return f.prefixList.equal(x.prefixList)
+}
@@ -0,0 +1,22 @@
+package filter
+
+import (
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// OriginFilter matches the ORIGIN attribute of a path (0 = IGP, 1 = EGP, 2 = incomplete)
+type OriginFilter struct {
+	origin uint8
+}
+
+// NewOriginFilter creates a new OriginFilter
+func NewOriginFilter(origin uint8) *OriginFilter {
+	return &OriginFilter{
+		origin: origin,
+	}
+}
+
+// Matches checks if the origin of the path is f.origin
+func (f *OriginFilter) Matches(p *route.BGPPath) bool {
+	return p.BGPPathA.Origin == f.origin
+}
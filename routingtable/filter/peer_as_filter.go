@@ -0,0 +1,32 @@
+package filter
+
+import (
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// PeerASFilter matches the ASN of the directly connected peer a path was learned from.
+// This is the left most ASN of the AS path.
+type PeerASFilter struct {
+	asn uint32
+}
+
+// NewPeerASFilter creates a new PeerASFilter
+func NewPeerASFilter(asn uint32) *PeerASFilter {
+	return &PeerASFilter{
+		asn: asn,
+	}
+}
+
+// Matches checks if the path was learned from a peer with ASN f.asn
+func (f *PeerASFilter) Matches(p *route.BGPPath) bool {
+	if p.ASPath == nil || len(*p.ASPath) == 0 {
+		return false
+	}
+
+	first := (*p.ASPath)[0].GetFirstASN()
+	if first == nil {
+		return false
+	}
+
+	return *first == f.asn
+}
@@ -25,10 +25,24 @@ func NewPrefixListWithMatcher(matcher PrefixMatcher, pfxs ...*net.Prefix) *Prefi
 
 func (l *PrefixList) Matches(p *net.Prefix) bool {
 	for _, a := range l.allowed {
-		if a.Equal(p) {
+		if l.matcher.Match(a, p) {
 			return true
 		}
 	}
 
 	return false
 }
+
+func (l *PrefixList) equal(x *PrefixList) bool {
+	if len(l.allowed) != len(x.allowed) || !l.matcher.equal(x.matcher) {
+		return false
+	}
+
+	for i := range l.allowed {
+		if !l.allowed[i].Equal(x.allowed[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,23 @@
+package filter
+
+import (
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// PrivateASFilter matches paths with private ASNs (RFC6996) in their AS path.
+// The left most ASN of the AS path (the directly connected peer) is not considered.
+type PrivateASFilter struct{}
+
+// NewPrivateASFilter creates a new PrivateASFilter
+func NewPrivateASFilter() *PrivateASFilter {
+	return &PrivateASFilter{}
+}
+
+// Matches checks if the AS path of p contains a private ASN
+func (f *PrivateASFilter) Matches(p *route.BGPPath) bool {
+	if p.ASPath == nil {
+		return false
+	}
+
+	return p.ASPath.ContainsPrivateASN()
+}
@@ -70,6 +70,46 @@ func TestInRange(t *testing.T) {
 			pattern:  net.NewPfx(net.IPv4FromOctets(1, 2, 0, 0), 22).Ptr(),
 			expected: false,
 		},
+		{
+			name:     "IPv6 host route in ::/0 range (0-128)",
+			prefix:   net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1), 128).Ptr(),
+			pattern:  net.NewPfx(net.IPv6(0, 0), 0).Ptr(),
+			begin:    0,
+			end:      128,
+			expected: true,
+		},
+		{
+			name:     "IPv6 /127 in range (48-127)",
+			prefix:   net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 1, 0, 0, 0, 0, 2), 127).Ptr(),
+			pattern:  net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 1, 0, 0, 0, 0, 0), 48).Ptr(),
+			begin:    48,
+			end:      127,
+			expected: true,
+		},
+		{
+			name:     "IPv6 host route longer than range (48-127)",
+			prefix:   net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 1, 0, 0, 0, 0, 2), 128).Ptr(),
+			pattern:  net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 1, 0, 0, 0, 0, 0), 48).Ptr(),
+			begin:    48,
+			end:      127,
+			expected: false,
+		},
+		{
+			name:     "IPv6 differing outside of the lower 32 bits of the network (48-64)",
+			prefix:   net.NewPfx(net.IPv6FromBlocks(0x3001, 0xdb8, 1, 0, 0, 0, 0, 0), 64).Ptr(),
+			pattern:  net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 1, 0, 0, 0, 0, 0), 48).Ptr(),
+			begin:    48,
+			end:      64,
+			expected: false,
+		},
+		{
+			name:     "IPv6 prefix in IPv4 range (0-128)",
+			prefix:   net.NewPfx(net.IPv6FromBlocks(0, 0, 0, 0, 0, 0, 0x0a00, 0), 120).Ptr(),
+			pattern:  net.NewPfx(net.IPv4(0), 0).Ptr(),
+			begin:    0,
+			end:      128,
+			expected: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -152,6 +192,18 @@ func TestOrLonger(t *testing.T) {
 			pattern:  net.NewPfx(net.IPv4FromOctets(1, 2, 3, 0), 24).Ptr(),
 			expected: false,
 		},
+		{
+			name:     "IPv6 host route exact",
+			prefix:   net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1), 128).Ptr(),
+			pattern:  net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1), 128).Ptr(),
+			expected: true,
+		},
+		{
+			name:     "IPv6 host route of other /127",
+			prefix:   net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0x8000, 1), 128).Ptr(),
+			pattern:  net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 127).Ptr(),
+			expected: false,
+		},
 	}
 
 	for _, test := range tests {
@@ -187,6 +239,24 @@ func TestLonger(t *testing.T) {
 			pattern:  net.NewPfx(net.IPv4FromOctets(1, 2, 3, 0), 24).Ptr(),
 			expected: false,
 		},
+		{
+			name:     "IPv6 host route longer than /127",
+			prefix:   net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1), 128).Ptr(),
+			pattern:  net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 127).Ptr(),
+			expected: true,
+		},
+		{
+			name:     "IPv6 host route exact",
+			prefix:   net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1), 128).Ptr(),
+			pattern:  net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 1), 128).Ptr(),
+			expected: false,
+		},
+		{
+			name:     "IPv6 longer than ::/0",
+			prefix:   net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32).Ptr(),
+			pattern:  net.NewPfx(net.IPv6(0, 0), 0).Ptr(),
+			expected: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -0,0 +1,26 @@
+package filter
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+)
+
+// SourceFilter matches the address of the peer a path was received from
+type SourceFilter struct {
+	source *net.IP
+}
+
+// NewSourceFilter creates a new SourceFilter
+func NewSourceFilter(source *net.IP) *SourceFilter {
+	return &SourceFilter{
+		source: source,
+	}
+}
+
+// Matches checks if the path was received from f.source
+func (f *SourceFilter) Matches(source *net.IP) bool {
+	if source == nil {
+		return false
+	}
+
+	return f.source.Compare(source) == 0
+}
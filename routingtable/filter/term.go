@@ -6,17 +6,37 @@ import (
 	"github.com/bio-routing/bio-rd/routingtable/filter/actions"
 )
 
+const (
+	// FlowDefault stops processing of the filter as soon as an action terminates (first match)
+	FlowDefault = iota
+
+	// FlowContinue continues processing with the next term of the filter after a match
+	FlowContinue
+
+	// FlowGoto continues processing with the term with the next higher or equal sequence number after a match
+	FlowGoto
+)
+
+// FlowControl defines how processing of a filter proceeds after a term matched (route-map continue semantics)
+type FlowControl struct {
+	Mode uint8
+	Goto uint32
+}
+
 // Term matches a path against a list of conditions and performs actions if it matches
 type Term struct {
-	name string
-	from []*TermCondition
-	then []actions.Action
+	name     string
+	sequence uint32
+	from     []*TermCondition
+	then     []actions.Action
+	flow     FlowControl
 }
 
 type TermResult struct {
 	Path      *route.Path
 	Terminate bool
 	Reject    bool
+	Matched   bool
 }
 
 // NewTerm creates a new term
@@ -30,6 +50,23 @@ func NewTerm(name string, from []*TermCondition, then []actions.Action) *Term {
 	return t
 }
 
+// NewSequencedTerm creates a new term with a sequence number and flow control. Terms of a filter are processed in order of
+// their sequence numbers. If a matching term has a flow control other than FlowDefault, an accepting action does not
+// terminate processing of the filter but marks the path as permitted and continues at the term selected by the flow control.
+// Rejecting actions always terminate processing.
+func NewSequencedTerm(name string, sequence uint32, from []*TermCondition, then []actions.Action, flow FlowControl) *Term {
+	t := NewTerm(name, from, then)
+	t.sequence = sequence
+	t.flow = flow
+
+	return t
+}
+
+// Sequence returns the sequence number of the term
+func (t *Term) Sequence() uint32 {
+	return t.sequence
+}
+
 // Process processes a path returning if the path should be rejected and returns a possible modified version of the path
 func (t *Term) Process(p *net.Prefix, pa *route.Path) TermResult {
 	if len(t.from) == 0 {
@@ -53,15 +90,20 @@ func (t *Term) processActions(p *net.Prefix, pa *route.Path) TermResult {
 				Path:      pa,
 				Terminate: true,
 				Reject:    res.Reject,
+				Matched:   true,
 			}
 		}
 		pa = res.Path
 	}
 
-	return TermResult{Path: pa}
+	return TermResult{Path: pa, Matched: true}
 }
 
 func (t *Term) equal(x *Term) bool {
+	if t.sequence != x.sequence || t.flow != x.flow {
+		return false
+	}
+
 	if len(t.from) != len(x.from) {
 		return false
 	}
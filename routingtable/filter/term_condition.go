@@ -10,6 +10,11 @@ type TermCondition struct {
 	routeFilters          []*RouteFilter
 	communityFilters      []*CommunityFilter
 	largeCommunityFilters []*LargeCommunityFilter
+	nextHopFilters        []*NextHopFilter
+	peerASFilters         []*PeerASFilter
+	originFilters         []*OriginFilter
+	sourceFilters         []*SourceFilter
+	privateASFilters      []*PrivateASFilter
 }
 
 func NewTermCondition(prefixLists []*PrefixList, routeFilters []*RouteFilter) *TermCondition {
@@ -31,11 +36,82 @@ func NewTermConditionWithPrefixLists(filters ...*PrefixList) *TermCondition {
 	}
 }
 
+func NewTermConditionWithCommunityFilters(filters ...*CommunityFilter) *TermCondition {
+	return &TermCondition{
+		communityFilters: filters,
+	}
+}
+
+func NewTermConditionWithNextHopFilters(filters ...*NextHopFilter) *TermCondition {
+	return &TermCondition{
+		nextHopFilters: filters,
+	}
+}
+
+func NewTermConditionWithPeerASFilters(filters ...*PeerASFilter) *TermCondition {
+	return &TermCondition{
+		peerASFilters: filters,
+	}
+}
+
+func NewTermConditionWithOriginFilters(filters ...*OriginFilter) *TermCondition {
+	return &TermCondition{
+		originFilters: filters,
+	}
+}
+
+func NewTermConditionWithSourceFilters(filters ...*SourceFilter) *TermCondition {
+	return &TermCondition{
+		sourceFilters: filters,
+	}
+}
+
+func NewTermConditionWithPrivateASFilters(filters ...*PrivateASFilter) *TermCondition {
+	return &TermCondition{
+		privateASFilters: filters,
+	}
+}
+
+// AddCommunityFilters adds community filters to the condition
+func (f *TermCondition) AddCommunityFilters(filters ...*CommunityFilter) *TermCondition {
+	f.communityFilters = append(f.communityFilters, filters...)
+	return f
+}
+
+// AddNextHopFilters adds next hop filters to the condition
+func (f *TermCondition) AddNextHopFilters(filters ...*NextHopFilter) *TermCondition {
+	f.nextHopFilters = append(f.nextHopFilters, filters...)
+	return f
+}
+
+// AddPeerASFilters adds peer AS filters to the condition
+func (f *TermCondition) AddPeerASFilters(filters ...*PeerASFilter) *TermCondition {
+	f.peerASFilters = append(f.peerASFilters, filters...)
+	return f
+}
+
+// AddOriginFilters adds origin filters to the condition
+func (f *TermCondition) AddOriginFilters(filters ...*OriginFilter) *TermCondition {
+	f.originFilters = append(f.originFilters, filters...)
+	return f
+}
+
+// AddSourceFilters adds source filters to the condition
+func (f *TermCondition) AddSourceFilters(filters ...*SourceFilter) *TermCondition {
+	f.sourceFilters = append(f.sourceFilters, filters...)
+	return f
+}
+
 func (f *TermCondition) Matches(p *net.Prefix, pa *route.Path) bool {
 	return f.matchesPrefixListFilters(p) &&
 		f.matchesRouteFilters(p) &&
 		f.matchesCommunityFilters(pa) &&
-		f.matchesLargeCommunityFilters(pa)
+		f.matchesLargeCommunityFilters(pa) &&
+		f.matchesNextHopFilters(pa) &&
+		f.matchesPeerASFilters(pa) &&
+		f.matchesOriginFilters(pa) &&
+		f.matchesSourceFilters(pa) &&
+		f.matchesPrivateASFilters(pa)
 }
 
 func (t *TermCondition) matchesPrefixListFilters(p *net.Prefix) bool {
@@ -102,6 +178,100 @@ func (t *TermCondition) matchesLargeCommunityFilters(pa *route.Path) bool {
 	return false
 }
 
+func (t *TermCondition) matchesNextHopFilters(pa *route.Path) bool {
+	if len(t.nextHopFilters) == 0 {
+		return true
+	}
+
+	switch {
+	case pa.Type == route.BGPPathType && pa.BGPPath != nil:
+	case pa.Type == route.StaticPathType && pa.StaticPath != nil:
+	case pa.Type == route.FIBPathType && pa.FIBPath != nil:
+	default:
+		return false
+	}
+
+	for _, l := range t.nextHopFilters {
+		if l.Matches(pa.NextHop()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *TermCondition) matchesPeerASFilters(pa *route.Path) bool {
+	if len(t.peerASFilters) == 0 {
+		return true
+	}
+
+	if pa.BGPPath == nil {
+		return false
+	}
+
+	for _, l := range t.peerASFilters {
+		if l.Matches(pa.BGPPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *TermCondition) matchesOriginFilters(pa *route.Path) bool {
+	if len(t.originFilters) == 0 {
+		return true
+	}
+
+	if pa.BGPPath == nil {
+		return false
+	}
+
+	for _, l := range t.originFilters {
+		if l.Matches(pa.BGPPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *TermCondition) matchesSourceFilters(pa *route.Path) bool {
+	if len(t.sourceFilters) == 0 {
+		return true
+	}
+
+	if pa.BGPPath == nil {
+		return false
+	}
+
+	for _, l := range t.sourceFilters {
+		if l.Matches(pa.BGPPath.BGPPathA.Source) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (t *TermCondition) matchesPrivateASFilters(pa *route.Path) bool {
+	if len(t.privateASFilters) == 0 {
+		return true
+	}
+
+	if pa.BGPPath == nil {
+		return false
+	}
+
+	for _, l := range t.privateASFilters {
+		if l.Matches(pa.BGPPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (t *TermCondition) equal(x *TermCondition) bool {
 	if len(t.routeFilters) != len(x.routeFilters) {
 		return false
@@ -115,15 +285,55 @@ func (t *TermCondition) equal(x *TermCondition) bool {
 		return false
 	}
 
+	if len(t.nextHopFilters) != len(x.nextHopFilters) ||
+		len(t.peerASFilters) != len(x.peerASFilters) ||
+		len(t.originFilters) != len(x.originFilters) ||
+		len(t.sourceFilters) != len(x.sourceFilters) ||
+		len(t.privateASFilters) != len(x.privateASFilters) {
+		return false
+	}
+
 	for i := range t.routeFilters {
 		if !t.routeFilters[i].equal(x.routeFilters[i]) {
 			return false
 		}
 	}
 
-	// TODO: Compare community filters
+	for i := range t.peerASFilters {
+		if *t.peerASFilters[i] != *x.peerASFilters[i] {
+			return false
+		}
+	}
+
+	for i := range t.originFilters {
+		if *t.originFilters[i] != *x.originFilters[i] {
+			return false
+		}
+	}
+
+	for i := range t.sourceFilters {
+		if t.sourceFilters[i].source.Compare(x.sourceFilters[i].source) != 0 {
+			return false
+		}
+	}
+
+	for i := range t.communityFilters {
+		if *t.communityFilters[i] != *x.communityFilters[i] {
+			return false
+		}
+	}
+
+	for i := range t.largeCommunityFilters {
+		if *t.largeCommunityFilters[i] != *x.largeCommunityFilters[i] {
+			return false
+		}
+	}
 
-	// TODO: Compare large community filters
+	for i := range t.nextHopFilters {
+		if !t.nextHopFilters[i].equal(x.nextHopFilters[i]) {
+			return false
+		}
+	}
 
 	return true
 }
@@ -206,3 +206,178 @@ func TestMatches(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchesBGPAttributes(t *testing.T) {
+	pfx := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 24).Ptr()
+	path := &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			BGPPathA: &route.BGPPathA{
+				NextHop: net.IPv4FromOctets(192, 0, 2, 1).Ptr(),
+				Source:  net.IPv4FromOctets(192, 0, 2, 2).Ptr(),
+				Origin:  2,
+			},
+			ASPath: &types.ASPath{
+				types.ASPathSegment{
+					Type: types.ASSequence,
+					ASNs: []uint32{65100, 65200},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		condition *TermCondition
+		path      *route.Path
+		expected  bool
+	}{
+		{
+			name: "next hop in prefix list",
+			condition: NewTermConditionWithNextHopFilters(NewNextHopFilter(
+				NewPrefixListWithMatcher(NewOrLongerMatcher(), net.NewPfx(net.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()))),
+			path:     path,
+			expected: true,
+		},
+		{
+			name: "next hop not in prefix list",
+			condition: NewTermConditionWithNextHopFilters(NewNextHopFilter(
+				NewPrefixListWithMatcher(NewOrLongerMatcher(), net.NewPfx(net.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()))),
+			path:     path,
+			expected: false,
+		},
+		{
+			name: "next hop exact host prefix",
+			condition: NewTermConditionWithNextHopFilters(NewNextHopFilter(
+				NewPrefixList(net.NewPfx(net.IPv4FromOctets(192, 0, 2, 1), 32).Ptr()))),
+			path:     path,
+			expected: true,
+		},
+		{
+			name:      "peer AS matches",
+			condition: NewTermConditionWithPeerASFilters(NewPeerASFilter(65100)),
+			path:      path,
+			expected:  true,
+		},
+		{
+			name:      "peer AS does not match origin AS",
+			condition: NewTermConditionWithPeerASFilters(NewPeerASFilter(65200)),
+			path:      path,
+			expected:  false,
+		},
+		{
+			name:      "peer AS with one of two filters matching",
+			condition: NewTermConditionWithPeerASFilters(NewPeerASFilter(1), NewPeerASFilter(65100)),
+			path:      path,
+			expected:  true,
+		},
+		{
+			name:      "origin incomplete matches",
+			condition: NewTermConditionWithOriginFilters(NewOriginFilter(2)),
+			path:      path,
+			expected:  true,
+		},
+		{
+			name:      "origin IGP does not match",
+			condition: NewTermConditionWithOriginFilters(NewOriginFilter(0)),
+			path:      path,
+			expected:  false,
+		},
+		{
+			name:      "source matches",
+			condition: NewTermConditionWithSourceFilters(NewSourceFilter(net.IPv4FromOctets(192, 0, 2, 2).Ptr())),
+			path:      path,
+			expected:  true,
+		},
+		{
+			name:      "source does not match",
+			condition: NewTermConditionWithSourceFilters(NewSourceFilter(net.IPv4FromOctets(192, 0, 2, 1).Ptr())),
+			path:      path,
+			expected:  false,
+		},
+		{
+			name: "all terms combined match",
+			condition: NewTermConditionWithRouteFilters(NewRouteFilter(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), NewOrLongerMatcher())).
+				AddPeerASFilters(NewPeerASFilter(65100)).
+				AddOriginFilters(NewOriginFilter(2)).
+				AddSourceFilters(NewSourceFilter(net.IPv4FromOctets(192, 0, 2, 2).Ptr())).
+				AddNextHopFilters(NewNextHopFilter(NewPrefixListWithMatcher(NewOrLongerMatcher(), net.NewPfx(net.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()))),
+			path:     path,
+			expected: true,
+		},
+		{
+			name: "combined terms with one not matching",
+			condition: NewTermConditionWithPeerASFilters(NewPeerASFilter(65100)).
+				AddOriginFilters(NewOriginFilter(0)),
+			path:     path,
+			expected: false,
+		},
+		{
+			name:      "peer AS filter, bgp path is nil",
+			condition: NewTermConditionWithPeerASFilters(NewPeerASFilter(65100)),
+			path:      &route.Path{},
+			expected:  false,
+		},
+		{
+			name: "next hop filter, static path",
+			condition: NewTermConditionWithNextHopFilters(NewNextHopFilter(
+				NewPrefixListWithMatcher(NewOrLongerMatcher(), net.NewPfx(net.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()))),
+			path: &route.Path{
+				Type: route.StaticPathType,
+				StaticPath: &route.StaticPath{
+					NextHop: net.IPv4FromOctets(192, 0, 2, 3).Ptr(),
+				},
+			},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.condition.Matches(pfx, test.path))
+		})
+	}
+}
+
+func TestTermConditionEqual(t *testing.T) {
+	pfxA := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr()
+	pfxB := net.NewPfx(net.IPv4FromOctets(192, 168, 0, 0), 16).Ptr()
+
+	tests := []struct {
+		name     string
+		a        *TermCondition
+		b        *TermCondition
+		expected bool
+	}{
+		{
+			name:     "Equal next hop filters",
+			a:        NewTermConditionWithNextHopFilters(NewNextHopFilter(NewPrefixListWithMatcher(NewOrLongerMatcher(), pfxA))),
+			b:        NewTermConditionWithNextHopFilters(NewNextHopFilter(NewPrefixListWithMatcher(NewOrLongerMatcher(), pfxA))),
+			expected: true,
+		},
+		{
+			name:     "Next hop filters with different prefixes",
+			a:        NewTermConditionWithNextHopFilters(NewNextHopFilter(NewPrefixListWithMatcher(NewOrLongerMatcher(), pfxA))),
+			b:        NewTermConditionWithNextHopFilters(NewNextHopFilter(NewPrefixListWithMatcher(NewOrLongerMatcher(), pfxB))),
+			expected: false,
+		},
+		{
+			name:     "Next hop filters with different matchers",
+			a:        NewTermConditionWithNextHopFilters(NewNextHopFilter(NewPrefixListWithMatcher(NewOrLongerMatcher(), pfxA))),
+			b:        NewTermConditionWithNextHopFilters(NewNextHopFilter(NewPrefixList(pfxA))),
+			expected: false,
+		},
+		{
+			name:     "Different large community filters",
+			a:        &TermCondition{largeCommunityFilters: []*LargeCommunityFilter{{types.LargeCommunity{GlobalAdministrator: 1}}}},
+			b:        &TermCondition{largeCommunityFilters: []*LargeCommunityFilter{{types.LargeCommunity{GlobalAdministrator: 2}}}},
+			expected: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.a.equal(test.b))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package locRIB
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// Batch collects path additions and removals to be applied to a LocRIB at once
+type Batch struct {
+	ops []batchOp
+}
+
+type batchOp struct {
+	pfx    *net.Prefix
+	path   *route.Path
+	remove bool
+}
+
+// NewBatch creates a new empty batch. size is the expected number of operations.
+func NewBatch(size int) *Batch {
+	return &Batch{
+		ops: make([]batchOp, 0, size),
+	}
+}
+
+// AddPath adds the addition of path p of prefix pfx to the batch
+func (b *Batch) AddPath(pfx *net.Prefix, p *route.Path) {
+	b.ops = append(b.ops, batchOp{
+		pfx:  pfx,
+		path: p,
+	})
+}
+
+// RemovePath adds the removal of path p of prefix pfx to the batch
+func (b *Batch) RemovePath(pfx *net.Prefix, p *route.Path) {
+	b.ops = append(b.ops, batchOp{
+		pfx:    pfx,
+		path:   p,
+		remove: true,
+	})
+}
+
+// Len gets the number of operations in the batch
+func (b *Batch) Len() int {
+	return len(b.ops)
+}
+
+// Apply applies all operations of b in order under a single lock. The path selection runs once per prefix and clients
+// as well as subscribers are notified once per changed prefix about the difference between the route before and
+// after applying the batch.
+func (a *LocRIB) Apply(b *Batch) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	countBefore := a.RouteCount()
+	oldRoutes := make(map[net.Prefix]*route.Route)
+	pfxs := make([]*net.Prefix, 0)
+	for _, op := range b.ops {
+		r := a.rt.Get(op.pfx)
+		if _, seen := oldRoutes[*op.pfx]; !seen {
+			oldRoute := &route.Route{}
+			if r != nil {
+				oldRoute = r.Copy()
+			}
+
+			oldRoutes[*op.pfx] = oldRoute
+			pfxs = append(pfxs, op.pfx)
+		}
+
+		if !op.remove {
+			a.rt.AddPath(op.pfx, op.path)
+			continue
+		}
+
+		if r != nil {
+			a.rt.RemovePath(op.pfx, op.path)
+		}
+	}
+
+	for _, pfx := range pfxs {
+		oldRoute := oldRoutes[*pfx]
+		r := a.rt.Get(pfx)
+		if r == nil && len(oldRoute.Paths()) == 0 {
+			continue
+		}
+
+		if r != nil {
+			r.PathSelectionWithOptions(a.selectionOptions)
+		}
+
+		a.propagateChanges(oldRoute, r.Copy())
+	}
+
+	if a.countTarget != nil {
+		target := int64(a.countTarget.target)
+		if countBefore < target && a.RouteCount() >= target {
+			a.countTarget.ch <- struct{}{}
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package locRIB
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable"
+	"github.com/stretchr/testify/assert"
+)
+
+func batchTestPath(lpref uint32) *route.Path {
+	return &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			BGPPathA: &route.BGPPathA{
+				LocalPref: lpref,
+				NextHop:   bnet.IPv4(lpref).Ptr(),
+				Source:    bnet.IPv4(lpref).Ptr(),
+			},
+		},
+	}
+}
+
+func TestApply(t *testing.T) {
+	pfxA := bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()
+	pfxB := bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr()
+	pfxC := bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24).Ptr()
+
+	rib := New("inet.0")
+	rib.AddPath(pfxB, batchTestPath(100))
+
+	downstream := New("downstream")
+	rib.RegisterWithOptions(downstream, routingtable.ClientOptions{MaxPaths: 10})
+	sub := rib.Subscribe(16)
+
+	b := NewBatch(6)
+	b.AddPath(pfxA, batchTestPath(100))
+	b.AddPath(pfxA, batchTestPath(300))
+	b.AddPath(pfxA, batchTestPath(200))
+	b.RemovePath(pfxA, batchTestPath(100))
+	b.RemovePath(pfxB, batchTestPath(100))
+	b.AddPath(pfxC, batchTestPath(100))
+	b.RemovePath(pfxC, batchTestPath(100))
+	assert.Equal(t, 7, b.Len())
+	rib.Apply(b)
+
+	rib.Unsubscribe(sub)
+	events := make([]*Event, 0)
+	for e := range sub.Events() {
+		events = append(events, e)
+	}
+
+	// pfxC was added and removed again within the batch, so there must not be an event for it
+	if assert.Len(t, events, 2) {
+		assert.Equal(t, EventAdd, events[0].Type)
+		assert.Equal(t, pfxA, events[0].Route.Prefix())
+		assert.Equal(t, []*route.Path{batchTestPath(300), batchTestPath(200)}, events[0].Route.Paths())
+		assert.Equal(t, batchTestPath(300), events[0].Path)
+
+		assert.Equal(t, EventRemove, events[1].Type)
+		assert.Equal(t, pfxB, events[1].Route.Prefix())
+	}
+
+	assert.Equal(t, uint64(1), rib.Count())
+	assert.Equal(t, []*route.Path{batchTestPath(300), batchTestPath(200)}, downstream.Get(pfxA).Paths())
+	assert.Nil(t, downstream.Get(pfxB))
+	assert.Nil(t, downstream.Get(pfxC))
+}
+
+func TestApplyCountTarget(t *testing.T) {
+	rib := New("inet.0")
+	ch := make(chan struct{}, 2)
+	rib.SetCountTarget(2, ch)
+
+	b := NewBatch(3)
+	for i := uint32(1); i <= 3; i++ {
+		b.AddPath(bnet.NewPfx(bnet.IPv4(i<<8), 24).Ptr(), batchTestPath(100))
+	}
+
+	rib.Apply(b)
+	rib.Apply(NewBatch(0))
+	assert.Len(t, ch, 1)
+}
+
+const (
+	benchmarkPrefixes = 1000
+	benchmarkPaths    = 16
+)
+
+func benchmarkPfxPaths() []*pfxPath {
+	ret := make([]*pfxPath, 0, benchmarkPrefixes*benchmarkPaths)
+	for i := uint32(0); i < benchmarkPrefixes; i++ {
+		for j := uint32(1); j <= benchmarkPaths; j++ {
+			ret = append(ret, &pfxPath{
+				pfx:  bnet.NewPfx(bnet.IPv4(i<<8), 24),
+				path: batchTestPath(j),
+			})
+		}
+	}
+
+	return ret
+}
+
+func BenchmarkAddPathSingle(b *testing.B) {
+	pps := benchmarkPfxPaths()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rib := New("inet.0")
+		rib.Subscribe(len(pps))
+		for _, pp := range pps {
+			rib.AddPath(pp.pfx.Ptr(), pp.path)
+		}
+	}
+}
+
+func BenchmarkAddPathBatch(b *testing.B) {
+	pps := benchmarkPfxPaths()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rib := New("inet.0")
+		rib.Subscribe(len(pps))
+		batch := NewBatch(len(pps))
+		for _, pp := range pps {
+			batch.AddPath(pp.pfx.Ptr(), pp.path)
+		}
+
+		rib.Apply(batch)
+	}
+}
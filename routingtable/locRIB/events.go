@@ -0,0 +1,215 @@
+package locRIB
+
+import (
+	"sync"
+
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// EventType is the kind of change of a route of the LocRIB
+type EventType uint8
+
+const (
+	// EventAdd is emitted when a route for a prefix not present before is added
+	EventAdd EventType = iota
+
+	// EventRemove is emitted when the last path of a route is removed
+	EventRemove
+
+	// EventModify is emitted when the paths of an existing route or their order change
+	EventModify
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventAdd:
+		return "add"
+	case EventRemove:
+		return "remove"
+	case EventModify:
+		return "modify"
+	}
+
+	return "unknown"
+}
+
+// Event describes a change of a route of the LocRIB
+type Event struct {
+	Type EventType
+
+	// Route is a copy of the route after the change. For EventRemove it is a copy of the route before its removal.
+	Route *route.Route
+
+	// Path is the best path of Route
+	Path *route.Path
+}
+
+// Subscription receives the events of a LocRIB
+type Subscription struct {
+	ch         chan *Event
+	mu         sync.Mutex
+	closed     bool
+	overflowed bool
+}
+
+// Events gets the channel events are delivered on. It is closed on Unsubscribe or once the subscription overflowed.
+func (s *Subscription) Events() <-chan *Event {
+	return s.ch
+}
+
+// Overflowed tells if the subscription was ended as its buffer was full. Events were lost in this case, so subscribers
+// have to subscribe again and resync with a snapshot of the LocRIB.
+func (s *Subscription) Overflowed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.overflowed
+}
+
+// deliver sends e to the subscriber. false is returned if the buffer was full and the subscription therefore closed.
+func (s *Subscription) deliver(e *Event) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return false
+	}
+
+	select {
+	case s.ch <- e:
+		return true
+	default:
+	}
+
+	s.overflowed = true
+	s.closed = true
+	close(s.ch)
+	return false
+}
+
+func (s *Subscription) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	s.closed = true
+	close(s.ch)
+}
+
+type subscriptions struct {
+	subs map[*Subscription]struct{}
+	mu   sync.RWMutex
+}
+
+// Subscribe subscribes to the route changes of the LocRIB. Up to bufferSize events are buffered. A slow subscriber
+// never blocks updates of the LocRIB: Once its buffer is full the subscription is closed and marked overflowed.
+func (a *LocRIB) Subscribe(bufferSize int) *Subscription {
+	s := &Subscription{
+		ch: make(chan *Event, bufferSize),
+	}
+
+	a.subscriptions.mu.Lock()
+	defer a.subscriptions.mu.Unlock()
+
+	if a.subscriptions.subs == nil {
+		a.subscriptions.subs = make(map[*Subscription]struct{})
+	}
+
+	a.subscriptions.subs[s] = struct{}{}
+	return s
+}
+
+// Unsubscribe ends a subscription and closes its events channel
+func (a *LocRIB) Unsubscribe(s *Subscription) {
+	a.subscriptions.mu.Lock()
+	defer a.subscriptions.mu.Unlock()
+
+	if _, ok := a.subscriptions.subs[s]; !ok {
+		return
+	}
+
+	delete(a.subscriptions.subs, s)
+	s.close()
+}
+
+// emitEvent notifies all subscribers about the change from oldRoute to newRoute. Either route may be nil or have no
+// paths if the prefix was added or removed.
+func (a *LocRIB) emitEvent(oldRoute *route.Route, newRoute *route.Route) {
+	a.subscriptions.mu.RLock()
+	if len(a.subscriptions.subs) == 0 {
+		a.subscriptions.mu.RUnlock()
+		return
+	}
+
+	e := newEvent(oldRoute, newRoute)
+	if e == nil {
+		a.subscriptions.mu.RUnlock()
+		return
+	}
+
+	var overflowed []*Subscription
+	for s := range a.subscriptions.subs {
+		if !s.deliver(e) {
+			overflowed = append(overflowed, s)
+		}
+	}
+	a.subscriptions.mu.RUnlock()
+
+	if len(overflowed) == 0 {
+		return
+	}
+
+	a.subscriptions.mu.Lock()
+	defer a.subscriptions.mu.Unlock()
+
+	for _, s := range overflowed {
+		delete(a.subscriptions.subs, s)
+	}
+}
+
+func newEvent(oldRoute *route.Route, newRoute *route.Route) *Event {
+	oldPaths := oldRoute.Paths()
+	newPaths := newRoute.Paths()
+
+	switch {
+	case len(oldPaths) == 0 && len(newPaths) == 0:
+		return nil
+	case len(oldPaths) == 0:
+		return &Event{
+			Type:  EventAdd,
+			Route: newRoute.Copy(),
+			Path:  newRoute.BestPath(),
+		}
+	case len(newPaths) == 0:
+		return &Event{
+			Type:  EventRemove,
+			Route: oldRoute.Copy(),
+			Path:  oldRoute.BestPath(),
+		}
+	case pathsEqual(oldPaths, newPaths):
+		return nil
+	}
+
+	return &Event{
+		Type:  EventModify,
+		Route: newRoute.Copy(),
+		Path:  newRoute.BestPath(),
+	}
+}
+
+func pathsEqual(a []*route.Path, b []*route.Path) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,92 @@
+package locRIB
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribe(t *testing.T) {
+	newPath := func(lpref uint32) *route.Path {
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				BGPPathA: &route.BGPPathA{
+					LocalPref: lpref,
+					NextHop:   bnet.IPv4(lpref).Ptr(),
+					Source:    bnet.IPv4(lpref).Ptr(),
+				},
+			},
+		}
+	}
+
+	type event struct {
+		eventType EventType
+		path      *route.Path
+		paths     int
+	}
+
+	pfx := bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()
+	rib := New("inet.0")
+	sub := rib.Subscribe(16)
+
+	rib.AddPath(pfx, newPath(100))
+	rib.AddPath(pfx, newPath(200))
+	rib.ReplacePath(pfx, newPath(100), newPath(300))
+	rib.RemovePath(pfx, newPath(300))
+	rib.RemovePath(pfx, newPath(400))
+	rib.RemovePath(pfx, newPath(200))
+
+	expected := []event{
+		{eventType: EventAdd, path: newPath(100), paths: 1},
+		{eventType: EventModify, path: newPath(200), paths: 2},
+		{eventType: EventModify, path: newPath(300), paths: 2},
+		{eventType: EventModify, path: newPath(200), paths: 1},
+		{eventType: EventRemove, path: newPath(200), paths: 1},
+	}
+
+	rib.Unsubscribe(sub)
+	got := make([]event, 0)
+	for e := range sub.Events() {
+		assert.Equal(t, pfx, e.Route.Prefix())
+		got = append(got, event{
+			eventType: e.Type,
+			path:      e.Path,
+			paths:     len(e.Route.Paths()),
+		})
+	}
+
+	assert.Equal(t, expected, got)
+	assert.False(t, sub.Overflowed())
+
+	rib.AddPath(pfx, newPath(100))
+	assert.False(t, sub.Overflowed(), "no events after unsubscribing")
+}
+
+func TestSubscribeClosesOverflowedSubscription(t *testing.T) {
+	rib := New("inet.0")
+	sub := rib.Subscribe(1)
+
+	for i := uint32(1); i <= 3; i++ {
+		rib.AddPath(bnet.NewPfx(bnet.IPv4(i<<8), 24).Ptr(), &route.Path{
+			Type: route.StaticPathType,
+			StaticPath: &route.StaticPath{
+				NextHop: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			},
+		})
+	}
+
+	assert.True(t, sub.Overflowed())
+	assert.Empty(t, rib.subscriptions.subs)
+
+	e := <-sub.Events()
+	assert.Equal(t, EventAdd, e.Type)
+	assert.Equal(t, bnet.NewPfx(bnet.IPv4(1<<8), 24).Ptr(), e.Route.Prefix())
+
+	_, ok := <-sub.Events()
+	assert.False(t, ok, "events channel must be closed")
+
+	rib.Unsubscribe(sub)
+}
@@ -0,0 +1,62 @@
+package locRIB
+
+import (
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// FIBFailure gets the reason why the route for pfx could not be installed into the FIB by a client registered with the FIB option
+func (a *LocRIB) FIBFailure(pfx *net.Prefix) (string, bool) {
+	a.fibFailuresMu.RLock()
+	defer a.fibFailuresMu.RUnlock()
+
+	reason, ok := a.fibFailures[*pfx]
+	return reason, ok
+}
+
+// FIBFailures gets the reasons of all routes that could not be installed into the FIB keyed by prefix
+func (a *LocRIB) FIBFailures() map[net.Prefix]string {
+	a.fibFailuresMu.RLock()
+	defer a.fibFailuresMu.RUnlock()
+
+	ret := make(map[net.Prefix]string, len(a.fibFailures))
+	for pfx, reason := range a.fibFailures {
+		ret[pfx] = reason
+	}
+
+	return ret
+}
+
+func (a *LocRIB) hasFIBFailure(pfx *net.Prefix) bool {
+	if pfx == nil {
+		return false
+	}
+
+	_, ok := a.FIBFailure(pfx)
+	return ok
+}
+
+// updateFIBFailure records the outcome of installing the paths of r into the FIB.
+// The state is kept if no paths were installed as the route did not change from the FIBs point of view.
+func (a *LocRIB) updateFIBFailure(pfx *net.Prefix, r *route.Route, installed bool, err error) {
+	if pfx == nil {
+		return
+	}
+
+	a.fibFailuresMu.Lock()
+	defer a.fibFailuresMu.Unlock()
+
+	if len(r.Paths()) == 0 {
+		delete(a.fibFailures, *pfx)
+		return
+	}
+
+	if err != nil {
+		a.fibFailures[*pfx] = err.Error()
+		return
+	}
+
+	if installed {
+		delete(a.fibFailures, *pfx)
+	}
+}
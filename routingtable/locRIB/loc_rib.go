@@ -3,6 +3,7 @@ package locRIB
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/route"
@@ -20,6 +21,11 @@ type LocRIB struct {
 	mu               sync.RWMutex
 	contributingASNs *routingtable.ContributingASNs
 	countTarget      *countTarget
+	selectionOptions *route.SelectionOptions
+	lastUpdate       time.Time
+	fibFailures      map[net.Prefix]string
+	fibFailuresMu    sync.RWMutex
+	subscriptions    subscriptions
 }
 
 type countTarget struct {
@@ -33,12 +39,29 @@ func New(name string) *LocRIB {
 		name:             name,
 		rt:               routingtable.NewRoutingTable(),
 		contributingASNs: routingtable.NewContributingASNs(),
+		fibFailures:      make(map[net.Prefix]string),
 	}
 	a.clientManager = routingtable.NewClientManager(a)
 
 	return a
 }
 
+// SetSelectionOptions sets the options altering the best path selection of the LocRIB
+func (a *LocRIB) SetSelectionOptions(opts *route.SelectionOptions) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.selectionOptions = opts
+}
+
+// SelectionOptions gets the options altering the best path selection of the LocRIB
+func (a *LocRIB) SelectionOptions() *route.SelectionOptions {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.selectionOptions
+}
+
 // Name gets the name of the LocRIB
 func (a *LocRIB) Name() string {
 	return a.name
@@ -66,6 +89,11 @@ func (a *LocRIB) LPM(pfx *net.Prefix) (res []*route.Route) {
 	return a.rt.LPM(pfx)
 }
 
+// LPMAddr gets the most specific route covering addr. Returns nil if there is none.
+func (a *LocRIB) LPMAddr(addr *net.IP) *route.Route {
+	return a.rt.LPMAddr(addr)
+}
+
 // Get gets a route
 func (a *LocRIB) Get(pfx *net.Prefix) *route.Route {
 	return a.rt.Get(pfx)
@@ -84,6 +112,14 @@ func (a *LocRIB) Dump() []*route.Route {
 	return a.rt.Dump()
 }
 
+// Snapshot gets a consistent copy of all routes which is not changed by later updates of the RIB
+func (a *LocRIB) Snapshot() []*route.Route {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.rt.Snapshot()
+}
+
 // SetCountTarget sets a target and a channel to send a message to when a certain route count is reached
 func (a *LocRIB) SetCountTarget(count uint64, ch chan struct{}) {
 	a.countTarget = &countTarget{
@@ -111,8 +147,23 @@ func (a *LocRIB) UpdateNewClient(client routingtable.RouteTableClient) error {
 			n = uint(math.Min(int(n), len(r.Paths())))
 		}
 
+		if opts.SuppressFIBFailures && a.hasFIBFailure(r.Prefix()) {
+			continue
+		}
+
+		installed := false
+		var fibErr error
 		for _, p := range r.Paths()[:n] {
-			client.AddPathInitialDump(r.Prefix(), p)
+			err := client.AddPathInitialDump(r.Prefix(), p)
+			if err != nil && fibErr == nil {
+				fibErr = err
+			}
+
+			installed = true
+		}
+
+		if opts.FIB {
+			a.updateFIBFailure(r.Prefix(), r, installed, fibErr)
 		}
 	}
 
@@ -139,10 +190,22 @@ func (a *LocRIB) RefreshClient(client routingtable.RouteTableClient) {
 			n = uint(math.Min(int(n), len(r.Paths())))
 		}
 
+		if opts.SuppressFIBFailures && a.hasFIBFailure(r.Prefix()) {
+			continue
+		}
+
 		client.RefreshRoute(r.Prefix(), r.Paths()[:n])
 	}
 }
 
+// LastUpdate returns the time of the last change of the LocRIB. It is zero if the LocRIB was never changed.
+func (a *LocRIB) LastUpdate() time.Time {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return a.lastUpdate
+}
+
 // RouteCount returns the number of stored routes
 func (a *LocRIB) RouteCount() int64 {
 	return a.rt.GetRouteCount()
@@ -174,7 +237,7 @@ func (a *LocRIB) AddPath(pfx *net.Prefix, p *route.Path) error {
 		r = a.rt.Get(pfx)
 	}
 
-	r.PathSelection()
+	r.PathSelectionWithOptions(a.selectionOptions)
 	newRoute := r.Copy()
 
 	a.propagateChanges(oldRoute, newRoute)
@@ -203,8 +266,9 @@ func (a *LocRIB) RemovePath(pfx *net.Prefix, p *route.Path) bool {
 		return true
 	}
 
+	// The paths of r are kept ordered, so the backup path moves up without recomputation
 	a.rt.RemovePath(pfx, p)
-	r.PathSelection()
+	r.PromoteWithOptions(a.selectionOptions)
 
 	r = a.rt.Get(pfx)
 	newRoute := r.Copy()
@@ -230,47 +294,103 @@ func (a *LocRIB) ReplacePath(pfx *net.Prefix, oldPath *route.Path, newPath *rout
 		return
 	}
 
-	r.PathSelection()
+	r.PathSelectionWithOptions(a.selectionOptions)
 	a.propagateChanges(oldRoute, r)
 }
 
 func (a *LocRIB) propagateChanges(oldRoute *route.Route, newRoute *route.Route) {
-	a.removePathsFromClients(oldRoute, newRoute)
-	a.addPathsToClients(oldRoute, newRoute)
-}
+	a.lastUpdate = time.Now()
+
+	pfx := changedPrefix(oldRoute, newRoute)
+	oldFailed := a.hasFIBFailure(pfx)
 
-func (a *LocRIB) addPathsToClients(oldRoute *route.Route, newRoute *route.Route) {
-	for _, client := range a.clientManager.Clients() {
+	// FIB clients are updated first so that clients suppressing FIB failures get to see the outcome of the installation
+	clients := a.clientManager.Clients()
+	installed := false
+	var fibErr error
+	for _, client := range clients {
 		opts := a.clientManager.GetOptions(client)
-		oldMaxPaths := opts.GetMaxPaths(oldRoute.ECMPPathCount())
-		newMaxPaths := opts.GetMaxPaths(newRoute.ECMPPathCount())
+		if !opts.FIB {
+			continue
+		}
 
-		oldPathsLimit := int(math.Min(int(oldMaxPaths), len(oldRoute.Paths())))
-		newPathsLimit := int(math.Min(int(newMaxPaths), len(newRoute.Paths())))
+		a.removePathsFromClient(client, opts, oldRoute, newRoute)
+		n, err := a.addPathsToClient(client, opts, oldRoute, newRoute)
+		if err != nil && fibErr == nil {
+			fibErr = err
+		}
 
-		advertise := route.PathsDiff(newRoute.Paths()[0:newPathsLimit], oldRoute.Paths()[0:oldPathsLimit])
+		installed = installed || n > 0
+	}
 
-		for _, p := range advertise {
-			client.AddPath(newRoute.Prefix(), p)
+	a.updateFIBFailure(pfx, newRoute, installed, fibErr)
+	newFailed := a.hasFIBFailure(pfx)
+
+	for _, client := range clients {
+		opts := a.clientManager.GetOptions(client)
+		if opts.FIB {
+			continue
+		}
+
+		o, n := oldRoute, newRoute
+		if opts.SuppressFIBFailures {
+			if oldFailed {
+				o = &route.Route{}
+			}
+
+			if newFailed {
+				n = &route.Route{}
+			}
 		}
+
+		a.removePathsFromClient(client, opts, o, n)
+		a.addPathsToClient(client, opts, o, n)
 	}
+
+	a.emitEvent(oldRoute, newRoute)
 }
 
-func (a *LocRIB) removePathsFromClients(oldRoute *route.Route, newRoute *route.Route) {
-	for _, client := range a.clientManager.Clients() {
-		opts := a.clientManager.GetOptions(client)
-		oldMaxPaths := opts.GetMaxPaths(oldRoute.ECMPPathCount())
-		newMaxPaths := opts.GetMaxPaths(newRoute.ECMPPathCount())
+func changedPrefix(oldRoute *route.Route, newRoute *route.Route) *net.Prefix {
+	if newRoute != nil && newRoute.Prefix() != nil {
+		return newRoute.Prefix()
+	}
 
-		oldPathsLimit := int(math.Min(int(oldMaxPaths), len(oldRoute.Paths())))
-		newPathsLimit := int(math.Min(int(newMaxPaths), len(newRoute.Paths())))
+	return oldRoute.Prefix()
+}
 
-		withdraw := route.PathsDiff(oldRoute.Paths()[0:oldPathsLimit], newRoute.Paths()[0:newPathsLimit])
+// addPathsToClient propagates paths that are in newRoute but not in oldRoute. It returns the number of paths sent and the first error returned by the client.
+func (a *LocRIB) addPathsToClient(client routingtable.RouteTableClient, opts routingtable.ClientOptions, oldRoute *route.Route, newRoute *route.Route) (int, error) {
+	oldMaxPaths := opts.GetMaxPaths(oldRoute.ECMPPathCount())
+	newMaxPaths := opts.GetMaxPaths(newRoute.ECMPPathCount())
 
-		for _, p := range withdraw {
-			client.RemovePath(oldRoute.Prefix(), p)
+	oldPathsLimit := int(math.Min(int(oldMaxPaths), len(oldRoute.Paths())))
+	newPathsLimit := int(math.Min(int(newMaxPaths), len(newRoute.Paths())))
+
+	advertise := route.PathsDiff(newRoute.Paths()[0:newPathsLimit], oldRoute.Paths()[0:oldPathsLimit])
+
+	var ret error
+	for _, p := range advertise {
+		err := client.AddPath(newRoute.Prefix(), p)
+		if err != nil && ret == nil {
+			ret = err
 		}
 	}
+
+	return len(advertise), ret
+}
+
+func (a *LocRIB) removePathsFromClient(client routingtable.RouteTableClient, opts routingtable.ClientOptions, oldRoute *route.Route, newRoute *route.Route) {
+	oldMaxPaths := opts.GetMaxPaths(oldRoute.ECMPPathCount())
+	newMaxPaths := opts.GetMaxPaths(newRoute.ECMPPathCount())
+
+	oldPathsLimit := int(math.Min(int(oldMaxPaths), len(oldRoute.Paths())))
+	newPathsLimit := int(math.Min(int(newMaxPaths), len(newRoute.Paths())))
+
+	withdraw := route.PathsDiff(oldRoute.Paths()[0:oldPathsLimit], newRoute.Paths()[0:newPathsLimit])
+
+	for _, p := range withdraw {
+		client.RemovePath(oldRoute.Prefix(), p)
+	}
 }
 
 // ContainsPfxPath returns true if this prefix and path combination is
@@ -1,10 +1,13 @@
 package locRIB
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	bnet "github.com/bio-routing/bio-rd/net"
 	"github.com/bio-routing/bio-rd/route"
+	"github.com/bio-routing/bio-rd/routingtable"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -97,3 +100,219 @@ func TestLocRIB_RemovePathUnknown(t *testing.T) {
 				},
 			}))
 }
+
+func TestLastUpdate(t *testing.T) {
+	rib := New("inet.0")
+	assert.True(t, rib.LastUpdate().IsZero())
+
+	pfx := bnet.NewPfx(bnet.IPv4(1), 32).Ptr()
+	p := &route.Path{
+		Type: route.StaticPathType,
+		StaticPath: &route.StaticPath{
+			NextHop: bnet.IPv4(2).Ptr(),
+		},
+	}
+
+	rib.RemovePath(pfx, p)
+	assert.True(t, rib.LastUpdate().IsZero(), "removing an unknown prefix is no change")
+
+	before := time.Now()
+	rib.AddPath(pfx, p)
+	added := rib.LastUpdate()
+	assert.False(t, added.Before(before))
+
+	rib.RemovePath(pfx, p)
+	assert.False(t, rib.LastUpdate().Before(added))
+}
+
+type fibMockClient struct {
+	*routingtable.RTMockClient
+	added []*route.Path
+	err   error
+}
+
+func (f *fibMockClient) AddPath(pfx *bnet.Prefix, p *route.Path) error {
+	f.added = append(f.added, p)
+	return f.err
+}
+
+func TestRemoveBestPathPromotesBackup(t *testing.T) {
+	newPath := func(lpref uint32) *route.Path {
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				BGPPathA: &route.BGPPathA{
+					LocalPref: lpref,
+					NextHop:   bnet.IPv4(lpref).Ptr(),
+					Source:    bnet.IPv4(lpref).Ptr(),
+				},
+			},
+		}
+	}
+
+	rib := New("inet.0")
+	fib := &fibMockClient{
+		RTMockClient: routingtable.NewRTMockClient(),
+	}
+	rib.Register(fib)
+
+	pfx := bnet.NewPfx(bnet.IPv4(0), 0).Ptr()
+	rib.AddPath(pfx, newPath(100))
+	rib.AddPath(pfx, newPath(300))
+	rib.AddPath(pfx, newPath(200))
+
+	r := rib.Get(pfx)
+	assert.Equal(t, newPath(300), r.BestPath())
+	assert.Equal(t, newPath(200), r.BackupPath())
+
+	fib.added = nil
+	rib.RemovePath(pfx, newPath(300))
+
+	r = rib.Get(pfx)
+	assert.Equal(t, newPath(200), r.BestPath())
+	assert.Equal(t, newPath(100), r.BackupPath())
+	assert.Equal(t, []*route.Path{newPath(200)}, fib.added)
+	assert.Equal(t, newPath(300), fib.Removed()[len(fib.Removed())-1].Path)
+}
+
+func TestFIBFailure(t *testing.T) {
+	newPath := func(lpref uint32) *route.Path {
+		return &route.Path{
+			Type: route.BGPPathType,
+			BGPPath: &route.BGPPath{
+				BGPPathA: &route.BGPPathA{
+					LocalPref: lpref,
+					NextHop:   bnet.IPv4(lpref).Ptr(),
+					Source:    bnet.IPv4(lpref).Ptr(),
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		suppress        bool
+		expectedAdded   []*route.Path
+		expectedRemoved []*route.Path
+	}{
+		{
+			name:            "Failed routes are advertised",
+			suppress:        false,
+			expectedAdded:   []*route.Path{newPath(100), newPath(200), newPath(300)},
+			expectedRemoved: []*route.Path{newPath(100), newPath(200)},
+		},
+		{
+			name:            "Failed routes are suppressed",
+			suppress:        true,
+			expectedAdded:   []*route.Path{newPath(200)},
+			expectedRemoved: []*route.Path{newPath(200)},
+		},
+	}
+
+	for _, test := range tests {
+		rib := New("inet.0")
+		peer := &fibMockClient{
+			RTMockClient: routingtable.NewRTMockClient(),
+		}
+		rib.RegisterWithOptions(peer, routingtable.ClientOptions{BestOnly: true, SuppressFIBFailures: test.suppress})
+
+		fib := &fibMockClient{
+			RTMockClient: routingtable.NewRTMockClient(),
+			err:          fmt.Errorf("conflicting route with lower administrative distance"),
+		}
+		rib.RegisterWithOptions(fib, routingtable.ClientOptions{BestOnly: true, FIB: true})
+
+		pfx := bnet.NewPfx(bnet.IPv4(0), 0).Ptr()
+		rib.AddPath(pfx, newPath(100))
+
+		reason, failed := rib.FIBFailure(pfx)
+		assert.True(t, failed, test.name)
+		assert.Equal(t, "conflicting route with lower administrative distance", reason, test.name)
+
+		fib.err = nil
+		rib.AddPath(pfx, newPath(200))
+
+		_, failed = rib.FIBFailure(pfx)
+		assert.False(t, failed, test.name)
+
+		fib.err = fmt.Errorf("no route to next hop")
+		rib.AddPath(pfx, newPath(300))
+
+		reason, failed = rib.FIBFailure(pfx)
+		assert.True(t, failed, test.name)
+		assert.Equal(t, "no route to next hop", reason, test.name)
+		assert.Equal(t, map[bnet.Prefix]string{*pfx: "no route to next hop"}, rib.FIBFailures(), test.name)
+
+		assert.Equal(t, test.expectedAdded, peer.added, test.name)
+		removed := make([]*route.Path, 0)
+		for _, r := range peer.Removed() {
+			removed = append(removed, r.Path)
+		}
+		assert.Equal(t, test.expectedRemoved, removed, test.name)
+
+		rib.RemovePath(pfx, newPath(100))
+		rib.RemovePath(pfx, newPath(200))
+		rib.RemovePath(pfx, newPath(300))
+
+		_, failed = rib.FIBFailure(pfx)
+		assert.False(t, failed, test.name)
+	}
+}
+
+func TestAdministrativeDistanceFIBInstallation(t *testing.T) {
+	isisPath := &route.Path{
+		Type: route.ISISPathType,
+		ISISPath: &route.ISISPath{
+			NextHop: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			Metric:  20,
+			Level:   2,
+		},
+	}
+
+	ibgpPath := &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			BGPPathA: &route.BGPPathA{
+				LocalPref: 100,
+				NextHop:   bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+				Source:    bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		ibgp      uint8
+		installed *route.Path
+	}{
+		{
+			name:      "ISIS wins with default distances",
+			ibgp:      200,
+			installed: isisPath,
+		},
+		{
+			name:      "iBGP wins with lower distance",
+			ibgp:      100,
+			installed: ibgpPath,
+		},
+	}
+
+	for _, test := range tests {
+		d := route.DefaultAdministrativeDistances()
+		d.IBGP = test.ibgp
+
+		rib := New("inet.0")
+		rib.SetSelectionOptions(&route.SelectionOptions{AdministrativeDistances: d})
+		fib := &fibMockClient{
+			RTMockClient: routingtable.NewRTMockClient(),
+		}
+		rib.RegisterWithOptions(fib, routingtable.ClientOptions{BestOnly: true, FIB: true})
+
+		pfx := bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 0), 24).Ptr()
+		rib.AddPath(pfx, ibgpPath)
+		rib.AddPath(pfx, isisPath)
+
+		assert.Equal(t, test.installed, rib.Get(pfx).BestPath(), test.name)
+		assert.Equal(t, test.installed, fib.added[len(fib.added)-1], test.name)
+	}
+}
@@ -0,0 +1,69 @@
+package locRIB
+
+import (
+	"fmt"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+)
+
+// maxNextHopRecursion is the maximum number of BGP routes a next hop is resolved through
+const maxNextHopRecursion = 8
+
+// ResolveNextHop resolves a next hop which might not be directly connected (e.g. of a multihop BGP route) recursively
+// through the RIB. The resolution ends at the first non BGP route covering the next hop. The returned address is the
+// next hop of this route or the last resolved address if this route is directly connected.
+func (a *LocRIB) ResolveNextHop(nh *net.IP) (*net.IP, error) {
+	addr, _, err := a.resolveNextHop(nh)
+	return addr, err
+}
+
+// NextHopIGPMetric gets the interior cost to reach a next hop, i.e. the metric of the IS-IS route the next hop
+// resolves through. Next hops resolving through other (e.g. static or connected) routes have a metric of 0.
+func (a *LocRIB) NextHopIGPMetric(nh *net.IP) (uint32, error) {
+	_, p, err := a.resolveNextHop(nh)
+	if err != nil {
+		return 0, err
+	}
+
+	if p.Type == route.ISISPathType && p.ISISPath != nil {
+		return p.ISISPath.Metric, nil
+	}
+
+	return 0, nil
+}
+
+// resolveNextHop is like ResolveNextHop additionally returning the path of the non BGP route the resolution ended at
+func (a *LocRIB) resolveNextHop(nh *net.IP) (*net.IP, *route.Path, error) {
+	addr := nh
+	for i := 0; i < maxNextHopRecursion; i++ {
+		p := a.bestPathFor(addr)
+		if p == nil {
+			return nil, nil, fmt.Errorf("next hop %s is unreachable", addr.String())
+		}
+
+		if p.Type == route.BGPPathType {
+			addr = p.NextHop()
+			continue
+		}
+
+		resolved := p.NextHop()
+		if resolved == nil || (resolved.Higher() == 0 && resolved.Lower() == 0) {
+			return addr, p, nil
+		}
+
+		return resolved, p, nil
+	}
+
+	return nil, nil, fmt.Errorf("next hop %s exceeds the maximum recursion depth of %d", nh.String(), maxNextHopRecursion)
+}
+
+// bestPathFor gets the best path of the most specific route covering addr
+func (a *LocRIB) bestPathFor(addr *net.IP) *route.Path {
+	r := a.rt.LPMAddr(addr)
+	if r == nil {
+		return nil
+	}
+
+	return r.BestPath()
+}
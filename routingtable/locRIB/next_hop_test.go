@@ -0,0 +1,98 @@
+package locRIB
+
+import (
+	"testing"
+
+	bnet "github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+func bgpPathVia(nh bnet.IP) *route.Path {
+	return &route.Path{
+		Type: route.BGPPathType,
+		BGPPath: &route.BGPPath{
+			BGPPathA: &route.BGPPathA{
+				NextHop: nh.Ptr(),
+			},
+		},
+	}
+}
+
+func TestResolveNextHop(t *testing.T) {
+	rib := New("inet.0")
+
+	// Directly connected transfer network
+	rib.AddPath(bnet.NewPfx(bnet.IPv4FromOctets(10, 0, 0, 0), 24).Ptr(), &route.Path{
+		Type: route.StaticPathType,
+		StaticPath: &route.StaticPath{
+			NextHop: bnet.IPv4(0).Ptr(),
+		},
+	})
+
+	// Loopback of the multihop peer learned via the IGP
+	rib.AddPath(bnet.NewPfx(bnet.IPv4FromOctets(192, 0, 2, 1), 32).Ptr(), &route.Path{
+		Type: route.ISISPathType,
+		ISISPath: &route.ISISPath{
+			NextHop: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			Metric:  20,
+		},
+	})
+
+	rib.AddPath(bnet.NewPfx(bnet.IPv4FromOctets(198, 51, 100, 0), 24).Ptr(), bgpPathVia(bnet.IPv4FromOctets(192, 0, 2, 1)))
+	rib.AddPath(bnet.NewPfx(bnet.IPv4FromOctets(203, 0, 113, 0), 24).Ptr(), bgpPathVia(bnet.IPv4FromOctets(198, 51, 100, 1)))
+	rib.AddPath(bnet.NewPfx(bnet.IPv4FromOctets(172, 16, 0, 0), 24).Ptr(), bgpPathVia(bnet.IPv4FromOctets(172, 16, 0, 1)))
+
+	tests := []struct {
+		name     string
+		nh       bnet.IP
+		expected *bnet.IP
+		metric   uint32
+		wantFail bool
+	}{
+		{
+			name:     "Directly connected",
+			nh:       bnet.IPv4FromOctets(10, 0, 0, 2),
+			expected: bnet.IPv4FromOctets(10, 0, 0, 2).Ptr(),
+		},
+		{
+			name:     "Multihop peer via IGP",
+			nh:       bnet.IPv4FromOctets(192, 0, 2, 1),
+			expected: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			metric:   20,
+		},
+		{
+			name:     "Recursive via BGP route",
+			nh:       bnet.IPv4FromOctets(203, 0, 113, 1),
+			expected: bnet.IPv4FromOctets(10, 0, 0, 1).Ptr(),
+			metric:   20,
+		},
+		{
+			name:     "Unreachable",
+			nh:       bnet.IPv4FromOctets(100, 64, 0, 1),
+			wantFail: true,
+		},
+		{
+			name:     "Recursion loop",
+			nh:       bnet.IPv4FromOctets(172, 16, 0, 1),
+			wantFail: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := rib.ResolveNextHop(test.nh.Ptr())
+			metric, metricErr := rib.NextHopIGPMetric(test.nh.Ptr())
+			if test.wantFail {
+				assert.Error(t, err)
+				assert.Error(t, metricErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.NoError(t, metricErr)
+			assert.Equal(t, test.expected, res)
+			assert.Equal(t, test.metric, metric)
+		})
+	}
+}
@@ -81,3 +81,23 @@ func (m *RTMockClient) ReplacePath(*net.Prefix, *route.Path, *route.Path) {}
 func (m *RTMockClient) Dispose() {}
 
 func (m *RTMockClient) Flush() {}
+
+func (m *RTMockClient) MarkStale() {}
+
+func (m *RTMockClient) ReapplyFilterChain() {}
+
+func (m *RTMockClient) PrePolicyPathCount() uint64 {
+	return 0
+}
+
+func (m *RTMockClient) PrePolicyBytes() uint64 {
+	return 0
+}
+
+func (m *RTMockClient) ASLoopCount() uint64 {
+	return 0
+}
+
+func (m *RTMockClient) RemoveStale() int {
+	return 0
+}
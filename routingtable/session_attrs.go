@@ -25,9 +25,18 @@ type SessionAttrs struct {
 	// Peer ASN for this neighbor
 	PeerASN uint32
 
+	// LocalASOverride is the alternate ASN presented to the neighbor instead of LocalASN (local-as), 0 if unused
+	LocalASOverride uint32
+
+	// LocalASReplace indicates that only LocalASOverride is prepended to the AS path instead of LocalASOverride and LocalASN
+	LocalASReplace bool
+
 	// RouteServerClient indicates if the peer is a route server client
 	RouteServerClient bool
 
+	// NextHopSelf indicates if the next hop of routes sent to an iBGP peer is set to LocalIP
+	NextHopSelf bool
+
 	// RouteReflectorClient indicates if the peer is a route reflector client
 	RouteReflectorClient bool
 
@@ -43,6 +52,15 @@ type SessionAttrs struct {
 	// RouterIP indicates the IP address of the remote BMP peer (only for BMP)
 	RouterIP bnet.IP
 
+	// LogASLoops enables logging samples of received paths containing one of our ASNs in their AS path
+	LogASLoops bool
+
+	// StripCommunities removes the COMMUNITIES attribute from all paths advertised to the peer
+	StripCommunities bool
+
+	// StripLargeCommunities removes the LARGE_COMMUNITIES attribute from all paths advertised to the peer
+	StripLargeCommunities bool
+
 	/*
 	 * RFC9234
 	 */
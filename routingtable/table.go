@@ -114,6 +114,28 @@ func (rt *RoutingTable) LPM(pfx *net.Prefix) (res []*route.Route) {
 	return res
 }
 
+// LPMAddr gets the most specific route covering addr. Returns nil if there is none.
+func (rt *RoutingTable) LPMAddr(addr *net.IP) *route.Route {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+
+	if rt.root == nil {
+		return nil
+	}
+
+	pfxLen := uint8(128)
+	if addr.IsIPv4() {
+		pfxLen = 32
+	}
+
+	n := rt.root.longestMatch(net.NewPfx(*addr, pfxLen).Ptr())
+	if n == nil {
+		return nil
+	}
+
+	return n.route
+}
+
 // Get gets the route for pfx from the LPM
 func (rt *RoutingTable) Get(pfx *net.Prefix) *route.Route {
 	rt.mu.RLock()
@@ -143,7 +165,7 @@ func (rt *RoutingTable) GetLonger(pfx *net.Prefix) (res []*route.Route) {
 		return []*route.Route{}
 	}
 
-	return rt.root.get(pfx).dumpPfxs(res)
+	return rt.root.subtree(pfx).dumpPfxs(res)
 }
 
 // Dump dumps all routes in table rt into a slice
@@ -154,3 +176,17 @@ func (rt *RoutingTable) Dump() []*route.Route {
 	res := make([]*route.Route, 0)
 	return rt.root.dump(res)
 }
+
+// Snapshot dumps copies of all routes in table rt into a slice. Unlike the routes returned by Dump
+// the copies are not changed by later updates of the table, so they can be iterated without holding any lock.
+func (rt *RoutingTable) Snapshot() []*route.Route {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+
+	res := rt.root.dump(make([]*route.Route, 0, rt.GetRouteCount()))
+	for i := range res {
+		res[i] = res[i].Copy()
+	}
+
+	return res
+}
@@ -0,0 +1,122 @@
+package routingtable
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bio-routing/bio-rd/net"
+	"github.com/bio-routing/bio-rd/route"
+	"github.com/stretchr/testify/assert"
+)
+
+// mapTable is a naive map based routing table used as baseline for the trie benchmarks
+type mapTable map[net.Prefix]*route.Route
+
+func (m mapTable) lpm(needle *net.Prefix) (res []*route.Route) {
+	for l := 0; l <= int(needle.Len()); l++ {
+		pfx := net.NewPfx(needle.Addr(), uint8(l))
+		if r, ok := m[net.NewPfx(pfx.BaseAddr(), uint8(l))]; ok {
+			res = append(res, r)
+		}
+	}
+
+	return res
+}
+
+func (m mapTable) getLonger(needle *net.Prefix) (res []*route.Route) {
+	for pfx, r := range m {
+		p := pfx
+		if needle.Equal(&p) || needle.Contains(&p) {
+			res = append(res, r)
+		}
+	}
+
+	return res
+}
+
+// benchmarkPrefixes returns n /24s spread over 10.0.0.0/8 and their covering /16s
+func benchmarkPrefixes(n int) []*net.Prefix {
+	res := make([]*net.Prefix, 0, n+n/256+1)
+	for i := 0; i < n; i++ {
+		if i%256 == 0 {
+			res = append(res, net.NewPfx(net.IPv4FromOctets(10, uint8(i>>8), 0, 0), 16).Ptr())
+		}
+
+		res = append(res, net.NewPfx(net.IPv4FromOctets(10, uint8(i>>8), uint8(i), 0), 24).Ptr())
+	}
+
+	return res
+}
+
+func benchmarkTables(n int) (*RoutingTable, mapTable) {
+	rt := NewRoutingTable()
+	m := make(mapTable)
+	for _, pfx := range benchmarkPrefixes(n) {
+		rt.AddPath(pfx, nil)
+		m[*pfx] = rt.Get(pfx)
+	}
+
+	return rt, m
+}
+
+func BenchmarkLPM(b *testing.B) {
+	for _, size := range []int{1000, 10000, 60000} {
+		rt, m := benchmarkTables(size)
+		needle := net.NewPfx(net.IPv4FromOctets(10, uint8(size>>9), 17, 1), 32).Ptr()
+
+		b.Run(fmt.Sprintf("Trie-%d", size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				rt.LPM(needle)
+			}
+		})
+
+		b.Run(fmt.Sprintf("Map-%d", size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				m.lpm(needle)
+			}
+		})
+	}
+}
+
+func BenchmarkGetLonger(b *testing.B) {
+	for _, size := range []int{1000, 10000, 60000} {
+		rt, m := benchmarkTables(size)
+		needle := net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 15).Ptr()
+
+		b.Run(fmt.Sprintf("Trie-%d", size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				rt.GetLonger(needle)
+			}
+		})
+
+		b.Run(fmt.Sprintf("Map-%d", size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				m.getLonger(needle)
+			}
+		})
+	}
+}
+
+func BenchmarkDump(b *testing.B) {
+	for _, size := range []int{1000, 10000, 60000} {
+		rt, _ := benchmarkTables(size)
+
+		b.Run(fmt.Sprintf("Trie-%d", size), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				rt.Dump()
+			}
+		})
+	}
+}
+
+func TestMapTableBaseline(t *testing.T) {
+	rt, m := benchmarkTables(1000)
+
+	needle := net.NewPfx(net.IPv4FromOctets(10, 1, 17, 1), 32).Ptr()
+	assert.Equal(t, 2, len(rt.LPM(needle)), "trie LPM")
+	assert.Equal(t, 2, len(m.lpm(needle)), "baseline LPM")
+
+	needle = net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 15).Ptr()
+	assert.Equal(t, 514, len(rt.GetLonger(needle)), "trie GetLonger")
+	assert.Equal(t, 514, len(m.getLonger(needle)), "baseline GetLonger")
+}
@@ -386,6 +386,30 @@ func TestGetLonger(t *testing.T) {
 			needle:   net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(),
 			expected: []*route.Route{},
 		},
+		{
+			name: "Test 3: More specifics of a prefix not in the table",
+			routes: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 1, 0, 0), 16).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(11, 0, 0, 0), 8).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 2, 0, 0), 16).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 2, 3, 0), 24).Ptr(), nil),
+			},
+			needle: net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(),
+			expected: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 1, 0, 0), 16).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 2, 0, 0), 16).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 2, 3, 0), 24).Ptr(), nil),
+			},
+		},
+		{
+			name: "Test 4: Needle below the existing routes",
+			routes: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 16).Ptr(), nil),
+			},
+			needle:   net.NewPfx(net.IPv4FromOctets(10, 0, 1, 0), 24).Ptr(),
+			expected: nil,
+		},
 	}
 
 	for _, test := range tests {
@@ -448,6 +472,20 @@ func TestLPM(t *testing.T) {
 				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 10).Ptr(), nil),
 			},
 		},
+		{
+			name: "Sibling more specifics",
+			routes: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 12).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 16, 0, 0), 12).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 17, 0, 0), 16).Ptr(), nil),
+			},
+			needle: net.NewPfx(net.IPv4FromOctets(10, 16, 1, 1), 32).Ptr(),
+			expected: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 16, 0, 0), 12).Ptr(), nil),
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -459,6 +497,86 @@ func TestLPM(t *testing.T) {
 	}
 }
 
+func TestLPMAddr(t *testing.T) {
+	tests := []struct {
+		name     string
+		routes   []*route.Route
+		needle   net.IP
+		expected *route.Route
+	}{
+		{
+			name:   "Empty table",
+			needle: net.IPv4FromOctets(10, 0, 0, 1),
+		},
+		{
+			name: "Overlapping prefixes",
+			routes: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4(0), 0).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 1, 0, 0), 16).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 1, 2, 0), 24).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 1, 3, 0), 24).Ptr(), nil),
+			},
+			needle:   net.IPv4FromOctets(10, 1, 2, 3),
+			expected: route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 1, 2, 0), 24).Ptr(), nil),
+		},
+		{
+			name: "Less specific behind a sibling more specific",
+			routes: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 1, 2, 0), 24).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 1, 3, 0), 24).Ptr(), nil),
+			},
+			needle:   net.IPv4FromOctets(10, 1, 4, 1),
+			expected: route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), nil),
+		},
+		{
+			name: "Host route",
+			routes: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 1), 32).Ptr(), nil),
+			},
+			needle:   net.IPv4FromOctets(10, 0, 0, 1),
+			expected: route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 1), 32).Ptr(), nil),
+		},
+		{
+			name: "Miss",
+			routes: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 0, 0, 0), 8).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv4FromOctets(10, 1, 0, 0), 16).Ptr(), nil),
+			},
+			needle: net.IPv4FromOctets(192, 0, 2, 1),
+		},
+		{
+			name: "IPv6 overlapping prefixes",
+			routes: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0, 0, 0, 0, 0, 0), 32).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0x100, 0, 0, 0, 0, 0), 48).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0x100, 0x1, 0, 0, 0, 0), 64).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0x200, 0, 0, 0, 0, 0), 48).Ptr(), nil),
+			},
+			needle:   net.IPv6FromBlocks(0x2001, 0xdb8, 0x100, 0x2, 0, 0, 0, 1),
+			expected: route.NewRoute(net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0x100, 0, 0, 0, 0, 0), 48).Ptr(), nil),
+		},
+		{
+			name: "IPv6 miss",
+			routes: []*route.Route{
+				route.NewRoute(net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0x100, 0, 0, 0, 0, 0), 48).Ptr(), nil),
+				route.NewRoute(net.NewPfx(net.IPv6FromBlocks(0x2001, 0xdb8, 0x200, 0, 0, 0, 0, 0), 48).Ptr(), nil),
+			},
+			needle: net.IPv6FromBlocks(0x2001, 0xdb8, 0x300, 0, 0, 0, 0, 1),
+		},
+	}
+
+	for _, test := range tests {
+		rt := NewRoutingTable()
+		for _, route := range test.routes {
+			rt.AddPath(route.Prefix(), nil)
+		}
+		assert.Equal(t, test.expected, rt.LPMAddr(&test.needle), test.name)
+	}
+}
+
 func TestRemovePath(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -71,6 +71,34 @@ func (n *node) lpm(needle *net.Prefix, res *[]*route.Route) {
 	n.h.lpm(needle, res)
 }
 
+// longestMatch returns the most specific node equal to or covering needle
+func (n *node) longestMatch(needle *net.Prefix) *node {
+	var res *node
+	for n != nil {
+		currentPfx := n.route.Prefix()
+		exact := currentPfx.Equal(needle)
+		if !exact && !currentPfx.Contains(needle) {
+			break
+		}
+
+		if !n.dummy {
+			res = n
+		}
+
+		if exact {
+			break
+		}
+
+		if !needle.Addr().BitAtPosition(n.route.Pfxlen() + 1) {
+			n = n.l
+		} else {
+			n = n.h
+		}
+	}
+
+	return res
+}
+
 func (n *node) dumpPfxs(res []*route.Route) []*route.Route {
 	if n == nil {
 		return nil
@@ -114,6 +142,28 @@ func (n *node) get(pfx *net.Prefix) *node {
 	return n.h.get(pfx)
 }
 
+// subtree returns the topmost node covered by pfx, no matter if pfx itself is part of the trie
+func (n *node) subtree(pfx *net.Prefix) *node {
+	if n == nil {
+		return nil
+	}
+
+	currentPfx := n.route.Prefix()
+	if currentPfx.Equal(pfx) || pfx.Contains(currentPfx) {
+		return n
+	}
+
+	if !currentPfx.Contains(pfx) {
+		return nil
+	}
+
+	b := pfx.Addr().BitAtPosition(n.route.Pfxlen() + 1)
+	if !b {
+		return n.l.subtree(pfx)
+	}
+	return n.h.subtree(pfx)
+}
+
 func (n *node) addPath(pfx *net.Prefix, p *route.Path) (*node, bool) {
 	currentPfx := n.route.Prefix()
 	if currentPfx.Equal(pfx) {
@@ -34,8 +34,18 @@ func isDisallowedByCommunity(p *route.Path, sa *SessionAttrs) bool {
 	}
 
 	for _, com := range *p.BGPPath.Communities {
-		if (com == types.WellKnownCommunityNoExport && !sa.IBGP) || com == types.WellKnownCommunityNoAdvertise {
+		switch com {
+		case types.WellKnownCommunityNoAdvertise:
 			return true
+		case types.WellKnownCommunityNoExport:
+			if !sa.IBGP {
+				return true
+			}
+		case types.WellKnownCommunityNoExportSubConfed:
+			// We do not support confederations, so every eBGP peer is outside of our (sub-)AS
+			if !sa.IBGP {
+				return true
+			}
 		}
 	}
 
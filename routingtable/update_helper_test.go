@@ -48,6 +48,19 @@ func TestShouldPropagateUpdate(t *testing.T) {
 			communities: "(1,2) (65535,65282)",
 			expected:    false,
 		},
+		{
+			name:        "path with no-export-subconfed community",
+			communities: "(1,2) (65535,65283)",
+			expected:    false,
+		},
+		{
+			name:        "path with no-export-subconfed community (iBGP)",
+			communities: "(1,2) (65535,65283)",
+			sessionAttrs: SessionAttrs{
+				IBGP: true,
+			},
+			expected: true,
+		},
 		{
 			name:        "path with no-advertise community (iBGP)",
 			communities: "(1,2) (65535,65282)",
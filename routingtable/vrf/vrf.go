@@ -6,6 +6,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/bio-routing/bio-rd/route"
 	"github.com/bio-routing/bio-rd/routingtable/locRIB"
 )
 
@@ -27,6 +28,7 @@ type VRF struct {
 	ribs               map[addressFamily]*locRIB.LocRIB
 	mu                 sync.Mutex
 	ribNames           map[string]*locRIB.LocRIB
+	selectionOptions   *route.SelectionOptions
 }
 
 // New creates a new VRF. The VRF is registered automatically to the global VRF registry.
@@ -63,6 +65,7 @@ func (v *VRF) createLocRIB(name string, family addressFamily) (*locRIB.LocRIB, e
 	}
 
 	rib := locRIB.New(name)
+	rib.SetSelectionOptions(v.selectionOptions)
 	v.ribs[family] = rib
 	v.ribNames[name] = rib
 
@@ -79,6 +82,17 @@ func (v *VRF) CreateIPv6UnicastLocRIB(name string) (*locRIB.LocRIB, error) {
 	return v.createLocRIB(name, addressFamily{afi: afiIPv6, safi: safiUnicast})
 }
 
+// SetSelectionOptions sets the options altering the best path selection of all RIBs of the VRF
+func (v *VRF) SetSelectionOptions(opts *route.SelectionOptions) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.selectionOptions = opts
+	for _, rib := range v.ribs {
+		rib.SetSelectionOptions(opts)
+	}
+}
+
 // IPv4UnicastRIB returns the local RIB for the IPv4 unicast address family
 func (v *VRF) IPv4UnicastRIB() *locRIB.LocRIB {
 	return v.ribForAddressFamily(addressFamily{afi: afiIPv4, safi: safiUnicast})
@@ -3,6 +3,7 @@ package vrf
 import (
 	"testing"
 
+	"github.com/bio-routing/bio-rd/route"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,6 +40,20 @@ func TestCreateLocRIBTwice(t *testing.T) {
 	assert.NotNil(t, err, "error must not be nil on second invokation")
 }
 
+func TestSetSelectionOptions(t *testing.T) {
+	opts := &route.SelectionOptions{
+		ASPathMultipathStrict: true,
+	}
+
+	v := newUntrackedVRF("master", 0)
+	v.CreateIPv4UnicastLocRIB("inet.0")
+	v.SetSelectionOptions(opts)
+	v.CreateIPv6UnicastLocRIB("inet6.0")
+
+	assert.Equal(t, opts, v.IPv4UnicastRIB().SelectionOptions(), "existing RIB")
+	assert.Equal(t, opts, v.IPv6UnicastRIB().SelectionOptions(), "RIB created afterwards")
+}
+
 func TestRIBByName(t *testing.T) {
 	v := newUntrackedVRF("master", 0)
 	rib, _ := v.CreateIPv6UnicastLocRIB("inet6.0")
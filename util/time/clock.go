@@ -0,0 +1,183 @@
+package time
+
+import (
+	"sync"
+	gotime "time"
+)
+
+// Clock is a clock interface that allows mocking time
+type Clock interface {
+	Now() gotime.Time
+	After(d gotime.Duration) <-chan gotime.Time
+	NewTimer(d gotime.Duration) Timer
+}
+
+// Timer is a timer interface that allows mocking timers
+type Timer interface {
+	C() <-chan gotime.Time
+	Stop() bool
+	Reset(d gotime.Duration) bool
+}
+
+// BIOClock is a wrapper for the time package
+type BIOClock struct{}
+
+// NewBIOClock creates a new BIO clock
+func NewBIOClock() *BIOClock {
+	return &BIOClock{}
+}
+
+// Now returns the current time
+func (c *BIOClock) Now() gotime.Time {
+	return gotime.Now()
+}
+
+// After waits for the duration to elapse and then sends the current time on the returned channel
+func (c *BIOClock) After(d gotime.Duration) <-chan gotime.Time {
+	return gotime.After(d)
+}
+
+// NewTimer creates a new timer firing after d
+func (c *BIOClock) NewTimer(d gotime.Duration) Timer {
+	t := &BIOTimer{
+		t: gotime.NewTimer(d),
+	}
+
+	t.ch = t.t.C
+	return t
+}
+
+// BIOTimer is a wrapper for time.Timer
+type BIOTimer struct {
+	t  *gotime.Timer
+	ch <-chan gotime.Time
+}
+
+// C returns the channel
+func (bt *BIOTimer) C() <-chan gotime.Time {
+	return bt.ch
+}
+
+// Stop stops the timer
+func (bt *BIOTimer) Stop() bool {
+	return bt.t.Stop()
+}
+
+// Reset changes the timer to expire after d
+func (bt *BIOTimer) Reset(d gotime.Duration) bool {
+	return bt.t.Reset(d)
+}
+
+// MockClock is a mocked clock. Time only passes when calling Advance.
+type MockClock struct {
+	mu     sync.Mutex
+	now    gotime.Time
+	timers map[*MockTimer]struct{}
+}
+
+// NewMockClock creates a new mock clock starting at now
+func NewMockClock(now gotime.Time) *MockClock {
+	return &MockClock{
+		now:    now,
+		timers: make(map[*MockTimer]struct{}),
+	}
+}
+
+// Now returns the current time of the mock clock
+func (m *MockClock) Now() gotime.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.now
+}
+
+// After returns a channel receiving the time once the clock advanced by d
+func (m *MockClock) After(d gotime.Duration) <-chan gotime.Time {
+	return m.NewTimer(d).C()
+}
+
+// NewTimer creates a new timer firing once the clock advanced by d
+func (m *MockClock) NewTimer(d gotime.Duration) Timer {
+	t := &MockTimer{
+		clock: m,
+		ch:    make(chan gotime.Time, 1),
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.schedule(t, d)
+	return t
+}
+
+// Advance moves the clock forward by d and fires all timers expiring until then
+func (m *MockClock) Advance(d gotime.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.now = m.now.Add(d)
+	for t := range m.timers {
+		if !t.deadline.After(m.now) {
+			m.fire(t)
+		}
+	}
+}
+
+// PendingTimers returns the number of timers not fired or stopped yet
+func (m *MockClock) PendingTimers() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.timers)
+}
+
+func (m *MockClock) schedule(t *MockTimer, d gotime.Duration) {
+	t.deadline = m.now.Add(d)
+	if d <= 0 {
+		m.fire(t)
+		return
+	}
+
+	m.timers[t] = struct{}{}
+}
+
+func (m *MockClock) fire(t *MockTimer) {
+	delete(m.timers, t)
+
+	select {
+	case t.ch <- m.now:
+	default:
+	}
+}
+
+// MockTimer is a timer of a MockClock
+type MockTimer struct {
+	clock    *MockClock
+	deadline gotime.Time
+	ch       chan gotime.Time
+}
+
+// C returns the channel
+func (t *MockTimer) C() <-chan gotime.Time {
+	return t.ch
+}
+
+// Stop stops the timer
+func (t *MockTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	_, active := t.clock.timers[t]
+	delete(t.clock.timers, t)
+	return active
+}
+
+// Reset changes the timer to expire after d
+func (t *MockTimer) Reset(d gotime.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	_, active := t.clock.timers[t]
+	t.clock.schedule(t, d)
+	return active
+}
@@ -0,0 +1,45 @@
+package time
+
+import (
+	"testing"
+	gotime "time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMockClock(t *testing.T) {
+	start := gotime.Unix(1000, 0)
+	c := NewMockClock(start)
+
+	after := c.After(2 * gotime.Second)
+	timer := c.NewTimer(5 * gotime.Second)
+	assert.Equal(t, 2, c.PendingTimers())
+
+	c.Advance(gotime.Second)
+	assert.Equal(t, start.Add(gotime.Second), c.Now())
+	assertNotFired(t, after)
+
+	c.Advance(gotime.Second)
+	assert.Equal(t, start.Add(2*gotime.Second), <-after)
+	assertNotFired(t, timer.C())
+	assert.Equal(t, 1, c.PendingTimers())
+
+	assert.True(t, timer.Reset(gotime.Second))
+	c.Advance(gotime.Second)
+	assert.Equal(t, start.Add(3*gotime.Second), <-timer.C())
+	assert.False(t, timer.Stop())
+
+	assert.False(t, timer.Reset(gotime.Second))
+	assert.True(t, timer.Stop())
+	c.Advance(gotime.Hour)
+	assertNotFired(t, timer.C())
+	assert.Equal(t, 0, c.PendingTimers())
+}
+
+func assertNotFired(t *testing.T, ch <-chan gotime.Time) {
+	select {
+	case <-ch:
+		t.Errorf("timer fired unexpectedly")
+	default:
+	}
+}